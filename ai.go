@@ -4,12 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/smhanov/llmhub"
 )
 
@@ -24,6 +25,9 @@ type aiChatRequest struct {
 	ContentMarkdown string `json:"content_markdown"`
 	Query           string `json:"query"`
 	WebSearch       bool   `json:"web_search"`
+	// PostID names the post being edited. With Config.PersistAIChat the
+	// post's earlier turns are sent along and this one is stored.
+	PostID string `json:"post_id,omitempty"`
 }
 
 type aiChatResponse struct {
@@ -31,6 +35,9 @@ type aiChatResponse struct {
 	Notes           string `json:"notes,omitempty"`
 }
 
+// handleAdminGetAISettings returns the stored settings, so that saving the
+// form never copies Config.DefaultAISettings into the database. The enabled
+// flags reflect the effective settings, defaults included.
 func (s *service) handleAdminGetAISettings(w http.ResponseWriter, r *http.Request) {
 	settings, err := s.store.GetAISettings(r.Context())
 	if err != nil {
@@ -40,10 +47,11 @@ func (s *service) handleAdminGetAISettings(w http.ResponseWriter, r *http.Reques
 	if settings == nil {
 		settings = &AISettings{}
 	}
+	effective := mergeAISettings(s.cfg.DefaultAISettings, settings)
 	writeJSON(w, aiSettingsResponse{
 		Settings:     *settings,
-		SmartEnabled: aiProviderConfigured(settings.Smart),
-		DumbEnabled:  aiProviderConfigured(settings.Dumb),
+		SmartEnabled: aiProviderConfigured(effective.Smart),
+		DumbEnabled:  aiProviderConfigured(effective.Dumb),
 	})
 }
 
@@ -66,24 +74,97 @@ func (s *service) handleAdminUpdateAISettings(w http.ResponseWriter, r *http.Req
 }
 
 func (s *service) handleAdminAIChat(w http.ResponseWriter, r *http.Request) {
+	req, client, ok := s.prepareAIChat(w, r)
+	if !ok {
+		return
+	}
+
+	prompt := buildAIPrompt(req.ContentMarkdown, req.Query, s.aiChatHistory(r.Context(), req))
+	start := time.Now()
+	resp, err := s.aiGenerate(r.Context(), client, prompt)
+	if err != nil {
+		s.logf("ai chat failed duration=%s err=%v", time.Since(start), err)
+		http.Error(w, fmt.Sprintf("ai request failed: %v", err), http.StatusBadRequest)
+		return
+	}
+	s.logf("ai chat done duration=%s", time.Since(start))
+
+	result := aiChatResult(req, resp.Text())
+	s.recordAIChatTurn(r.Context(), req, result)
+	writeJSON(w, result)
+}
+
+// handleAdminGenerateDescription queues a new meta description for a post,
+// replacing the one it has.
+func (s *service) handleAdminGenerateDescription(w http.ResponseWriter, r *http.Request) {
+	s.queueForcedGeneration(w, r, TaskTypeGenerateDescription)
+}
+
+// handleAdminGenerateTags queues new tags for a post, replacing the ones it
+// has.
+func (s *service) handleAdminGenerateTags(w http.ResponseWriter, r *http.Request) {
+	s.queueForcedGeneration(w, r, TaskTypeGenerateTags)
+}
+
+// queueForcedGeneration queues a taskType task with Force set for the post
+// in the URL and answers 202 with the task, so the editor can poll it.
+func (s *service) queueForcedGeneration(w http.ResponseWriter, r *http.Request, taskType string) {
+	post, err := s.store.GetPostByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "failed to load post", http.StatusInternalServerError)
+		return
+	}
+	if post == nil {
+		http.NotFound(w, r)
+		return
+	}
+	settings, err := s.aiSettings(r.Context())
+	if err != nil {
+		http.Error(w, "failed to load ai settings", http.StatusInternalServerError)
+		return
+	}
+	if dumbAISettings(settings) == nil {
+		http.Error(w, "ai not configured", http.StatusConflict)
+		return
+	}
+
+	payload, _ := json.Marshal(generatePostPayload{PostID: post.ID, Force: true})
+	task := Task{
+		ID:       generateID(),
+		TaskType: taskType,
+		Status:   TaskStatusPending,
+		Payload:  string(payload),
+		Result:   "{}",
+	}
+	if err := s.store.CreateTask(r.Context(), &task); err != nil {
+		http.Error(w, "failed to queue task", http.StatusInternalServerError)
+		return
+	}
+	s.tasks.nudge()
+	writeJSONStatus(w, http.StatusAccepted, task)
+}
+
+// prepareAIChat decodes an AI chat request and builds the client for its
+// tier. On failure it writes the error response and returns false.
+func (s *service) prepareAIChat(w http.ResponseWriter, r *http.Request) (aiChatRequest, *llmhub.Client, bool) {
 	var req aiChatRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "invalid json", http.StatusBadRequest)
-		return
+		return req, nil, false
 	}
 	mode := strings.ToLower(strings.TrimSpace(req.Mode))
 	if mode == "" {
 		mode = "smart"
 	}
 
-	settings, err := s.store.GetAISettings(r.Context())
+	settings, err := s.aiSettings(r.Context())
 	if err != nil {
 		http.Error(w, "failed to load ai settings", http.StatusInternalServerError)
-		return
+		return req, nil, false
 	}
 	if settings == nil {
 		http.Error(w, "ai not configured", http.StatusConflict)
-		return
+		return req, nil, false
 	}
 
 	var providerSettings AIProviderSettings
@@ -95,10 +176,10 @@ func (s *service) handleAdminAIChat(w http.ResponseWriter, r *http.Request) {
 
 	if !aiProviderConfigured(providerSettings) {
 		http.Error(w, "ai not configured", http.StatusConflict)
-		return
+		return req, nil, false
 	}
 
-	log.Printf(
+	s.logf(
 		"ai chat start mode=%s provider=%s model=%s web_search=%t",
 		mode,
 		strings.ToLower(strings.TrimSpace(providerSettings.Provider)),
@@ -109,28 +190,22 @@ func (s *service) handleAdminAIChat(w http.ResponseWriter, r *http.Request) {
 	client, err := newLLMClient(providerSettings, req.WebSearch)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
+		return req, nil, false
 	}
+	return req, client, true
+}
 
-	prompt := buildAIPrompt(req.ContentMarkdown, req.Query)
-	start := time.Now()
-	resp, err := client.Generate(r.Context(), prompt)
-	if err != nil {
-		log.Printf("ai chat failed duration=%s err=%v", time.Since(start), err)
-		http.Error(w, fmt.Sprintf("ai request failed: %v", err), http.StatusBadRequest)
-		return
-	}
-	log.Printf("ai chat done duration=%s", time.Since(start))
-
-	content, notes := parseAIResponse(resp.Text())
+// aiChatResult parses the model's reply, keeping the original markdown when
+// the reply has none.
+func aiChatResult(req aiChatRequest, text string) aiChatResponse {
+	content, notes := parseAIResponse(text)
 	if strings.TrimSpace(content) == "" {
 		content = req.ContentMarkdown
 	}
-
-	writeJSON(w, aiChatResponse{
+	return aiChatResponse{
 		ContentMarkdown: content,
 		Notes:           notes,
-	})
+	}
 }
 
 func aiProviderConfigured(settings AIProviderSettings) bool {
@@ -140,9 +215,66 @@ func aiProviderConfigured(settings AIProviderSettings) bool {
 	if needsAPIKey(settings.Provider) && strings.TrimSpace(settings.APIKey) == "" {
 		return false
 	}
+	if needsBaseURL(settings.Provider) && strings.TrimSpace(settings.BaseURL) == "" {
+		return false
+	}
 	return true
 }
 
+// aiSettings returns the stored AI settings layered over
+// Config.DefaultAISettings. It returns nil only when neither is set.
+func (s *service) aiSettings(ctx context.Context) (*AISettings, error) {
+	stored, err := s.store.GetAISettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.cfg.DefaultAISettings == nil {
+		return stored, nil
+	}
+	return mergeAISettings(s.cfg.DefaultAISettings, stored), nil
+}
+
+// mergeAISettings overlays stored on defaults, field by field for each of the
+// smart and dumb providers.
+func mergeAISettings(defaults, stored *AISettings) *AISettings {
+	merged := &AISettings{}
+	if defaults != nil {
+		*merged = *defaults
+	}
+	if stored != nil {
+		merged.Smart = mergeAIProviderSettings(merged.Smart, stored.Smart)
+		merged.Dumb = mergeAIProviderSettings(merged.Dumb, stored.Dumb)
+	}
+	return merged
+}
+
+func mergeAIProviderSettings(base, override AIProviderSettings) AIProviderSettings {
+	provider := strings.TrimSpace(override.Provider)
+	if provider != "" && !strings.EqualFold(provider, strings.TrimSpace(base.Provider)) {
+		// A different provider was chosen; the default's model and key don't apply.
+		return override
+	}
+	if provider != "" {
+		base.Provider = override.Provider
+	}
+	if strings.TrimSpace(override.Model) != "" {
+		base.Model = override.Model
+	}
+	if strings.TrimSpace(override.APIKey) != "" {
+		base.APIKey = override.APIKey
+	}
+	if strings.TrimSpace(override.BaseURL) != "" {
+		base.BaseURL = override.BaseURL
+	}
+	if override.Temperature != nil {
+		base.Temperature = override.Temperature
+	}
+	if override.MaxTokens != nil {
+		base.MaxTokens = override.MaxTokens
+	}
+	return base
+}
+
 func needsAPIKey(provider string) bool {
 	switch strings.ToLower(strings.TrimSpace(provider)) {
 	case "openai", "anthropic", "gemini":
@@ -152,6 +284,18 @@ func needsAPIKey(provider string) bool {
 	}
 }
 
+// needsBaseURL reports whether the provider runs on a server the user hosts,
+// such as a local Ollama instance, and so has no sensible default address.
+// These providers don't need an API key.
+func needsBaseURL(provider string) bool {
+	switch strings.ToLower(strings.TrimSpace(provider)) {
+	case "ollama":
+		return true
+	default:
+		return false
+	}
+}
+
 func supportsWebSearch(provider string) bool {
 	switch strings.ToLower(strings.TrimSpace(provider)) {
 	case "gemini":
@@ -171,6 +315,9 @@ func newLLMClient(settings AIProviderSettings, webSearch bool) (*llmhub.Client,
 	if needsAPIKey(settings.Provider) && strings.TrimSpace(settings.APIKey) == "" {
 		return nil, fmt.Errorf("api key is required for %s", settings.Provider)
 	}
+	if needsBaseURL(settings.Provider) && strings.TrimSpace(settings.BaseURL) == "" {
+		return nil, fmt.Errorf("base url is required for %s", settings.Provider)
+	}
 
 	opts := []llmhub.Option{
 		llmhub.WithModel(settings.Model),
@@ -187,22 +334,33 @@ func newLLMClient(settings AIProviderSettings, webSearch bool) (*llmhub.Client,
 	if webSearch && supportsWebSearch(settings.Provider) {
 		opts = append(opts, llmhub.WithWebSearch(true))
 	}
-	if supportsWebSearch(settings.Provider) {
-		opts = append(opts, llmhub.WithWebSearch(true))
-	}
 
 	return llmhub.New(settings.Provider, settings.APIKey, opts...)
 }
 
-func buildAIPrompt(content, query string) []*llmhub.Message {
+// buildAIPrompt asks for a rewrite of content. Earlier turns of the
+// conversation go between the system and user messages, with only their
+// requests and notes: the current markdown already reflects the rewrites.
+func buildAIPrompt(content, query string, history []AIChatTurn) []*llmhub.Message {
 	system := llmhub.NewSystemMessage(llmhub.Text(
 		"You are a meticulous blog editor. Rewrite the provided markdown according to the user request. " +
 			"Return only JSON with keys content_markdown and notes. Do not wrap in code fences.",
 	))
+	messages := []*llmhub.Message{system}
+	for _, turn := range history {
+		notes := turn.Notes
+		if strings.TrimSpace(notes) == "" {
+			notes = "Done."
+		}
+		messages = append(messages,
+			llmhub.NewUserMessage(llmhub.Text("User request:\n"+turn.Query)),
+			llmhub.NewAssistantMessage(llmhub.Text(notes)),
+		)
+	}
 	user := llmhub.NewUserMessage(llmhub.Text(
 		"Current markdown:\n" + content + "\n\nUser request:\n" + query,
 	))
-	return []*llmhub.Message{system, user}
+	return append(messages, user)
 }
 
 func parseAIResponse(text string) (string, string) {
@@ -256,7 +414,7 @@ func extractJSONObject(text string) (string, bool) {
 }
 
 func (s *service) aiPreviewConfigured(ctx context.Context) (bool, bool, error) {
-	settings, err := s.store.GetAISettings(ctx)
+	settings, err := s.aiSettings(ctx)
 	if err != nil {
 		return false, false, err
 	}
@@ -305,7 +463,7 @@ func stripThinkTags(text string) string {
 }
 
 func (s *service) checkCommentSpam(ctx context.Context, comment Comment, post Post) (bool, string, error) {
-	settings, err := s.store.GetAISettings(ctx)
+	settings, err := s.aiSettings(ctx)
 	if err != nil {
 		return false, "", err
 	}
@@ -319,42 +477,42 @@ func (s *service) checkCommentSpam(ctx context.Context, comment Comment, post Po
 		return false, "", err
 	}
 
-	prompt := buildCommentSpamPrompt(comment, post)
+	prompt := s.prompts.buildCommentSpamPrompt(comment, post)
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	start := time.Now()
-	log.Printf(
+	s.logf(
 		"ai spam-check start comment_id=%s provider=%s model=%s",
 		comment.ID,
 		strings.ToLower(strings.TrimSpace(provider.Provider)),
 		strings.TrimSpace(provider.Model),
 	)
-	resp, err := client.Generate(ctx, prompt)
+	resp, err := s.aiGenerate(ctx, client, prompt)
 	if err != nil {
-		log.Printf("ai spam-check failed comment_id=%s duration=%s err=%v", comment.ID, time.Since(start), err)
+		s.logf("ai spam-check failed comment_id=%s duration=%s err=%v", comment.ID, time.Since(start), err)
 		return false, "", err
 	}
-	log.Printf("ai spam-check done comment_id=%s duration=%s", comment.ID, time.Since(start))
+	s.logf("ai spam-check done comment_id=%s duration=%s", comment.ID, time.Since(start))
 
 	spam, reason := parseCommentSpamResponse(resp.Text())
 	return spam, reason, nil
 }
 
-func buildCommentSpamPrompt(comment Comment, post Post) []*llmhub.Message {
+const defaultCommentSpamPrompt = "You are an AI assistant who specializes in identifying spam comments on blog posts. " +
+	"Analyze the blog post and comment to determine if the comment is spam. " +
+	"Classify as either \"spam\" or \"not-spam\" using these characteristics: " +
+	"1) relevance to the post content, " +
+	"2) promotional links or content related to cryptocurrency or financial products, " +
+	"3) generic or templated phrases that could apply to any post, " +
+	"4) nonsensical or machine-generated text, " +
+	"5) excessive flattery that does not engage with the content, " +
+	"6) comments addressing the author by name might not be spam, but consider context. " +
+	"If unsure, reply \"not-spam\". Reply with only \"spam\" or \"not-spam\"."
+
+func (p *aiPrompts) buildCommentSpamPrompt(comment Comment, post Post) []*llmhub.Message {
 	excerpt := postExcerptForSpam(post)
-	system := llmhub.NewSystemMessage(llmhub.Text(
-		"You are an AI assistant who specializes in identifying spam comments on blog posts. " +
-			"Analyze the blog post and comment to determine if the comment is spam. " +
-			"Classify as either \"spam\" or \"not-spam\" using these characteristics: " +
-			"1) relevance to the post content, " +
-			"2) promotional links or content related to cryptocurrency or financial products, " +
-			"3) generic or templated phrases that could apply to any post, " +
-			"4) nonsensical or machine-generated text, " +
-			"5) excessive flattery that does not engage with the content, " +
-			"6) comments addressing the author by name might not be spam, but consider context. " +
-			"If unsure, reply \"not-spam\". Reply with only \"spam\" or \"not-spam\".",
-	))
+	system := llmhub.NewSystemMessage(llmhub.Text(p.system(p.commentSpam)))
 	user := llmhub.NewUserMessage(llmhub.Text(
 		"BLOG POST TITLE: " + post.Title + "\n" +
 			"BLOG POST EXCERPT: " + excerpt + "\n" +
@@ -437,7 +595,7 @@ func (s *service) generatePostTags(postID string) {
 			return
 		}
 
-		settings, err := s.store.GetAISettings(ctx)
+		settings, err := s.aiSettings(ctx)
 		if err != nil {
 			return
 		}
@@ -451,22 +609,22 @@ func (s *service) generatePostTags(postID string) {
 			return
 		}
 
-		prompt := buildTaggingPrompt(post.Title, post.ContentMarkdown)
+		prompt := s.prompts.buildTaggingPrompt(post.Title, post.ContentMarkdown)
 		start := time.Now()
-		log.Printf(
+		s.logf(
 			"ai tagger start post_id=%s provider=%s model=%s",
 			post.ID,
 			strings.ToLower(strings.TrimSpace(provider.Provider)),
 			strings.TrimSpace(provider.Model),
 		)
-		resp, err := client.Generate(ctx, prompt)
+		resp, err := s.aiGenerate(ctx, client, prompt)
 		if err != nil {
-			log.Printf("ai tagger failed post_id=%s duration=%s err=%v", post.ID, time.Since(start), err)
+			s.logf("ai tagger failed post_id=%s duration=%s err=%v", post.ID, time.Since(start), err)
 			return
 		}
-		log.Printf("ai tagger done post_id=%s duration=%s", post.ID, time.Since(start))
+		s.logf("ai tagger done post_id=%s duration=%s", post.ID, time.Since(start))
 
-		tags := parseTaggingResponse(resp.Text())
+		tags := parseTaggingResponse(resp.Text(), s.prompts.MaxTags)
 		if len(tags) == 0 {
 			return
 		}
@@ -475,12 +633,7 @@ func (s *service) generatePostTags(postID string) {
 	}()
 }
 
-func buildTaggingPrompt(title, content string) []*llmhub.Message {
-	plainText := markdownToPlainText(content)
-	excerpt := trimToLength(plainText, 3000)
-
-	system := llmhub.NewSystemMessage(llmhub.Text(
-		`You are an expert content taxonomy system. Your goal is to analyze blog posts and generate a list of relevant, specific tags that will be used to calculate content similarity and recommend related reading.
+const defaultTaggingPrompt = `You are an expert content taxonomy system. Your goal is to analyze blog posts and generate a list of relevant, specific tags that will be used to calculate content similarity and recommend related reading.
 
 Tagging Guidelines:
 
@@ -488,7 +641,7 @@ Specificity: Avoid generic tags (e.g., avoid "Update", "General", "News", "Thoug
 
 Granularity: Aim for a mix of broad categories (1-2 tags) and specific niches (3-4 tags).
 
-Quantity: Generate exactly 5 to 8 tags per post.
+Quantity: Generate exactly {{.MinTags}} to {{.MaxTags}} tags per post.
 
 Format: Output strictly a JSON array of strings. Lowercase all tags. Remove punctuation/hashtags.
 
@@ -496,15 +649,20 @@ Relevance: A tag must be central to the post, not just a keyword mentioned once
 
 Example 1: Input Title: "Understanding Goroutines and Channels" Input Content: [Discussion about concurrency patterns in Go...] Output: ["go", "golang", "concurrency", "goroutines", "channels", "backend development"]
 
-Example 2: Input Title: "My travels to Japan and the best Ramen I ate" Input Content: [Travel log about Tokyo and food...] Output: ["travel", "japan", "tokyo", "food", "ramen", "culinary tourism"]`,
-	))
+Example 2: Input Title: "My travels to Japan and the best Ramen I ate" Input Content: [Travel log about Tokyo and food...] Output: ["travel", "japan", "tokyo", "food", "ramen", "culinary tourism"]`
+
+func (p *aiPrompts) buildTaggingPrompt(title, content string) []*llmhub.Message {
+	plainText := markdownToPlainText(content)
+	excerpt := trimToLength(plainText, 3000)
+
+	system := llmhub.NewSystemMessage(llmhub.Text(p.system(p.tagging)))
 	user := llmhub.NewUserMessage(llmhub.Text(
 		"Analyze the following post and return the JSON array of tags.\n\nTitle: " + title + "\nContent: " + excerpt,
 	))
 	return []*llmhub.Message{system, user}
 }
 
-func parseTaggingResponse(text string) []string {
+func parseTaggingResponse(text string, maxTags int) []string {
 	trimmed := stripThinkTags(text)
 	if trimmed == "" {
 		return nil
@@ -513,13 +671,13 @@ func parseTaggingResponse(text string) []string {
 	// Try to parse as JSON array directly
 	var tags []string
 	if json.Unmarshal([]byte(trimmed), &tags) == nil {
-		return cleanTags(tags)
+		return cleanTags(tags, maxTags)
 	}
 
 	// Try to extract JSON array from the response
 	if arr, ok := extractJSONArray(trimmed); ok {
 		if json.Unmarshal([]byte(arr), &tags) == nil {
-			return cleanTags(tags)
+			return cleanTags(tags, maxTags)
 		}
 	}
 
@@ -535,7 +693,7 @@ func extractJSONArray(text string) (string, bool) {
 	return text[start : end+1], true
 }
 
-func cleanTags(tags []string) []string {
+func cleanTags(tags []string, maxTags int) []string {
 	var result []string
 	seen := map[string]bool{}
 	for _, t := range tags {
@@ -547,32 +705,64 @@ func cleanTags(tags []string) []string {
 		seen[t] = true
 		result = append(result, t)
 	}
-	if len(result) > 8 {
-		result = result[:8]
+	if len(result) > maxTags {
+		result = result[:maxTags]
 	}
 	return result
 }
 
-// contentSignificantlyChanged checks if the markdown content has changed enough to re-tag.
-func contentSignificantlyChanged(oldContent, newContent string) bool {
-	old := strings.TrimSpace(oldContent)
-	new_ := strings.TrimSpace(newContent)
-	if old == new_ {
-		return false
-	}
-	if old == "" && new_ != "" {
-		return true
+// maybeQueueRetag regenerates the tags of a post whose Markdown changed
+// significantly in an update, so they keep up with major rewrites. Minor
+// edits, pages and blogs without AI leave tags alone, and so does an update
+// that changed the tags itself: the admin's choice wins over the AI's.
+func (s *service) maybeQueueRetag(ctx context.Context, previous, p *Post) {
+	if previous == nil || p.PostType == PostTypePage || !sameTagSlugs(previous.Tags, p.Tags) ||
+		!contentSignificantlyChanged(previous.ContentMarkdown, p.ContentMarkdown) {
+		return
 	}
-	// Simple heuristic: if at least 10% of the content changed by length
-	oldLen := len([]rune(old))
-	newLen := len([]rune(new_))
-	diff := newLen - oldLen
-	if diff < 0 {
-		diff = -diff
+	settings, err := s.aiSettings(ctx)
+	if err != nil || dumbAISettings(settings) == nil {
+		return
 	}
-	threshold := oldLen / 10
-	if threshold < 50 {
-		threshold = 50
+	s.queueTagGeneration(p.ID, true)
+}
+
+// sameTagSlugs reports whether a and b hold the same tags, in order.
+func sameTagSlugs(a, b []Tag) bool {
+	return slices.EqualFunc(a, b, func(x, y Tag) bool {
+		return strings.EqualFold(x.Slug, y.Slug)
+	})
+}
+
+// minChangedTokens keeps small edits to short posts from counting as
+// significant.
+const minChangedTokens = 10
+
+// contentSignificantlyChanged reports whether at least a tenth of the
+// words of the markdown changed, counting words removed from the old
+// content or added in the new, whatever their order. A rewrite of the same
+// length counts as much as one that doubles the post.
+func contentSignificantlyChanged(oldContent, newContent string) bool {
+	oldTokens := strings.Fields(strings.ToLower(oldContent))
+	newTokens := strings.Fields(strings.ToLower(newContent))
+	if len(oldTokens) == 0 {
+		return len(newTokens) > 0
+	}
+	counts := make(map[string]int, len(oldTokens))
+	for _, t := range oldTokens {
+		counts[t]++
+	}
+	added := 0
+	for _, t := range newTokens {
+		if counts[t] > 0 {
+			counts[t]--
+		} else {
+			added++
+		}
 	}
-	return diff >= threshold
+	// Old tokens left unmatched were removed.
+	removed := len(oldTokens) - (len(newTokens) - added)
+	changed := max(added, removed)
+	total := max(len(oldTokens), len(newTokens))
+	return changed >= minChangedTokens && changed*10 >= total
 }
@@ -0,0 +1,102 @@
+package blog
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Defaults for Config.AIChatHistoryTurns and Config.AIChatHistoryTokens.
+const (
+	defaultAIChatHistoryTurns  = 10
+	defaultAIChatHistoryTokens = 2000
+)
+
+// handleAdminGetAIChatHistory returns the AI chat turns stored for a post,
+// oldest first.
+func (s *service) handleAdminGetAIChatHistory(w http.ResponseWriter, r *http.Request) {
+	if !s.cfg.PersistAIChat {
+		http.Error(w, "ai chat history disabled", http.StatusNotFound)
+		return
+	}
+	turns, err := s.store.GetAIChatHistory(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "failed to load ai chat history", http.StatusInternalServerError)
+		return
+	}
+	if turns == nil {
+		turns = []AIChatTurn{}
+	}
+	writeJSON(w, turns)
+}
+
+func (s *service) handleAdminDeleteAIChatHistory(w http.ResponseWriter, r *http.Request) {
+	if !s.cfg.PersistAIChat {
+		http.Error(w, "ai chat history disabled", http.StatusNotFound)
+		return
+	}
+	if err := s.store.DeleteAIChatHistory(r.Context(), chi.URLParam(r, "id")); err != nil {
+		http.Error(w, "failed to delete ai chat history", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// aiChatHistory returns the earlier turns to send with req: the newest
+// stored turns that fit in Config.AIChatHistoryTokens. It returns nil when
+// history is disabled or the request names no post. A history that fails
+// to load is logged and skipped, so the chat still works.
+func (s *service) aiChatHistory(ctx context.Context, req aiChatRequest) []AIChatTurn {
+	if !s.cfg.PersistAIChat || req.PostID == "" {
+		return nil
+	}
+	turns, err := s.store.GetAIChatHistory(ctx, req.PostID)
+	if err != nil {
+		s.logf("ai chat history load failed post_id=%s err=%v", req.PostID, err)
+		return nil
+	}
+	budget := positiveOr(s.cfg.AIChatHistoryTokens, defaultAIChatHistoryTokens)
+	start := len(turns)
+	for start > 0 {
+		cost := estimateTokens(turns[start-1].Query) + estimateTokens(turns[start-1].Notes)
+		if cost > budget {
+			break
+		}
+		budget -= cost
+		start--
+	}
+	return turns[start:]
+}
+
+// recordAIChatTurn appends a finished exchange to the post's history,
+// keeping the newest Config.AIChatHistoryTurns turns.
+func (s *service) recordAIChatTurn(ctx context.Context, req aiChatRequest, result aiChatResponse) {
+	if !s.cfg.PersistAIChat || req.PostID == "" {
+		return
+	}
+	turns, err := s.store.GetAIChatHistory(ctx, req.PostID)
+	if err != nil {
+		s.logf("ai chat history load failed post_id=%s err=%v", req.PostID, err)
+		return
+	}
+	turns = append(turns, AIChatTurn{
+		Query:     req.Query,
+		Notes:     result.Notes,
+		CreatedAt: time.Now().UTC(),
+	})
+	if limit := positiveOr(s.cfg.AIChatHistoryTurns, defaultAIChatHistoryTurns); len(turns) > limit {
+		turns = turns[len(turns)-limit:]
+	}
+	if err := s.store.SaveAIChatHistory(ctx, req.PostID, turns); err != nil {
+		s.logf("ai chat history save failed post_id=%s err=%v", req.PostID, err)
+	}
+}
+
+// estimateTokens approximates a text's token count at four characters per
+// token, which is close enough to budget the history.
+func estimateTokens(text string) int {
+	return (len(strings.TrimSpace(text)) + 3) / 4
+}
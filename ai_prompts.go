@@ -0,0 +1,114 @@
+package blog
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// AIPrompts overrides the system prompts and limits of the built-in AI
+// features. Empty fields keep the defaults.
+//
+// Prompts are Go text/template strings and may refer to the limits as
+// {{.DescriptionMinLength}}, {{.DescriptionMaxLength}}, {{.MinTags}} and
+// {{.MaxTags}}. Post titles, content and comments are always sent in a
+// separate user message, so they never pass through the template.
+type AIPrompts struct {
+	// DescriptionSystem is the system prompt for meta descriptions.
+	DescriptionSystem string
+	// TaggingSystem is the system prompt for tags. The model must still
+	// answer with a JSON array of strings.
+	TaggingSystem string
+	// CommentSpamSystem is the system prompt for comment spam checks. The
+	// model must still answer "spam" or "not-spam".
+	CommentSpamSystem string
+
+	// DescriptionMinLength and DescriptionMaxLength bound the length of
+	// meta descriptions in characters (default 140 and 160). Longer
+	// answers are cut to DescriptionMaxLength.
+	DescriptionMinLength int
+	DescriptionMaxLength int
+	// MinTags and MaxTags bound the number of tags per post (default 5
+	// and 8). Extra tags are dropped.
+	MinTags int
+	MaxTags int
+}
+
+// aiPromptLimits is the data the prompt templates are executed with.
+type aiPromptLimits struct {
+	DescriptionMinLength int
+	DescriptionMaxLength int
+	MinTags              int
+	MaxTags              int
+}
+
+// aiPrompts holds the parsed prompt templates and resolved limits.
+type aiPrompts struct {
+	aiPromptLimits
+	description *template.Template
+	tagging     *template.Template
+	commentSpam *template.Template
+}
+
+// newAIPrompts resolves cfg against the defaults. It fails when a prompt
+// doesn't parse or refers to something other than the limits.
+func newAIPrompts(cfg *AIPrompts) (*aiPrompts, error) {
+	if cfg == nil {
+		cfg = &AIPrompts{}
+	}
+	p := &aiPrompts{aiPromptLimits: aiPromptLimits{
+		DescriptionMinLength: positiveOr(cfg.DescriptionMinLength, 140),
+		DescriptionMaxLength: positiveOr(cfg.DescriptionMaxLength, 160),
+		MinTags:              positiveOr(cfg.MinTags, 5),
+		MaxTags:              positiveOr(cfg.MaxTags, 8),
+	}}
+	if p.DescriptionMinLength > p.DescriptionMaxLength {
+		return nil, fmt.Errorf("ai prompts: description min length %d exceeds max length %d", p.DescriptionMinLength, p.DescriptionMaxLength)
+	}
+	if p.MinTags > p.MaxTags {
+		return nil, fmt.Errorf("ai prompts: min tags %d exceeds max tags %d", p.MinTags, p.MaxTags)
+	}
+
+	var err error
+	if p.description, err = p.parse("description", cfg.DescriptionSystem, defaultDescriptionPrompt); err != nil {
+		return nil, err
+	}
+	if p.tagging, err = p.parse("tagging", cfg.TaggingSystem, defaultTaggingPrompt); err != nil {
+		return nil, err
+	}
+	if p.commentSpam, err = p.parse("comment spam", cfg.CommentSpamSystem, defaultCommentSpamPrompt); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *aiPrompts) parse(name, text, fallback string) (*template.Template, error) {
+	if strings.TrimSpace(text) == "" {
+		text = fallback
+	}
+	tpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("ai prompts: parse %s prompt: %w", name, err)
+	}
+	// Execute once so mistakes such as unknown fields fail at startup
+	// rather than on every request.
+	if err := tpl.Execute(&strings.Builder{}, p.aiPromptLimits); err != nil {
+		return nil, fmt.Errorf("ai prompts: %s prompt: %w", name, err)
+	}
+	return tpl, nil
+}
+
+// system renders a prompt template. newAIPrompts has already executed it
+// with the same data, so it doesn't fail.
+func (p *aiPrompts) system(tpl *template.Template) string {
+	var b strings.Builder
+	_ = tpl.Execute(&b, p.aiPromptLimits)
+	return b.String()
+}
+
+func positiveOr(v, fallback int) int {
+	if v > 0 {
+		return v
+	}
+	return fallback
+}
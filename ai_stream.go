@@ -0,0 +1,149 @@
+package blog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/smhanov/llmhub"
+)
+
+// aiStreamDelta is the payload of a "delta" event: the next piece of the
+// model's raw reply.
+type aiStreamDelta struct {
+	Text string `json:"text"`
+}
+
+// aiStreamError is the payload of an "error" event.
+type aiStreamError struct {
+	Error string `json:"error"`
+}
+
+// defaultMaxConcurrentAIStreams is the stream limit when
+// Config.MaxConcurrentAIStreams is not set.
+const defaultMaxConcurrentAIStreams = 8
+
+// newAIStreamSlots returns the semaphore bounding concurrent AI streams, or
+// nil when MaxConcurrentAIStreams is negative.
+func newAIStreamSlots(cfg Config) chan struct{} {
+	limit := cfg.MaxConcurrentAIStreams
+	if limit < 0 {
+		return nil
+	}
+	if limit == 0 {
+		limit = defaultMaxConcurrentAIStreams
+	}
+	return make(chan struct{}, limit)
+}
+
+// handleAdminAIChatStream is the Server-Sent Events flavour of
+// handleAdminAIChat. It sends a "delta" event for each chunk of the reply,
+// then a "done" event carrying the parsed aiChatResponse, or an "error"
+// event if the model fails part way. Providers that can't stream are asked
+// for the whole reply, which arrives as a single delta. The model request
+// is cancelled as soon as the client goes away. At most
+// Config.MaxConcurrentAIStreams streams are open at once; further requests
+// get 429.
+func (s *service) handleAdminAIChatStream(w http.ResponseWriter, r *http.Request) {
+	if s.aiStreams != nil {
+		select {
+		case s.aiStreams <- struct{}{}:
+			defer func() { <-s.aiStreams }()
+		default:
+			s.logf("ai chat stream rejected: %d streams already open", cap(s.aiStreams))
+			http.Error(w, "too many ai streams", http.StatusTooManyRequests)
+			return
+		}
+	}
+
+	req, client, ok := s.prepareAIChat(w, r)
+	if !ok {
+		return
+	}
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	prompt := buildAIPrompt(req.ContentMarkdown, req.Query, s.aiChatHistory(ctx, req))
+	start := time.Now()
+	chunks, err := client.Stream(ctx, prompt)
+	if errors.Is(err, llmhub.ErrNotImplemented) {
+		chunks, err = generateAsStream(ctx, client, prompt)
+	}
+	s.metrics.aiRequest(err)
+	if err != nil {
+		s.logf("ai chat stream failed duration=%s err=%v", time.Since(start), err)
+		http.Error(w, fmt.Sprintf("ai request failed: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	rc := http.NewResponseController(w)
+	send := func(event string, v any) bool {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return false
+		}
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data); err != nil {
+			return false
+		}
+		return rc.Flush() == nil
+	}
+
+	var text strings.Builder
+	for {
+		var chunk llmhub.StreamChunk
+		var open bool
+		select {
+		case <-ctx.Done():
+			// The client is gone; return so its stream slot is freed even
+			// if the provider is slow to notice.
+			return
+		case chunk, open = <-chunks:
+		}
+		if !open {
+			break
+		}
+		if chunk.Err != nil {
+			s.logf("ai chat stream failed duration=%s err=%v", time.Since(start), chunk.Err)
+			send("error", aiStreamError{Error: fmt.Sprintf("ai request failed: %v", chunk.Err)})
+			return
+		}
+		if chunk.Delta != "" {
+			text.WriteString(chunk.Delta)
+			if !send("delta", aiStreamDelta{Text: chunk.Delta}) {
+				// The client is gone; the deferred cancel stops the model.
+				return
+			}
+		}
+		if chunk.Done {
+			break
+		}
+	}
+	if ctx.Err() != nil {
+		return
+	}
+	s.logf("ai chat stream done duration=%s", time.Since(start))
+	result := aiChatResult(req, text.String())
+	s.recordAIChatTurn(ctx, req, result)
+	send("done", result)
+}
+
+// generateAsStream runs a buffered Generate call and delivers its reply as a
+// one-chunk stream, for providers without streaming support.
+func generateAsStream(ctx context.Context, client *llmhub.Client, prompt []*llmhub.Message) (<-chan llmhub.StreamChunk, error) {
+	resp, err := client.Generate(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+	ch := make(chan llmhub.StreamChunk, 1)
+	ch <- llmhub.StreamChunk{Delta: resp.Text(), Done: true}
+	close(ch)
+	return ch, nil
+}
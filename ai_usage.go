@@ -0,0 +1,122 @@
+package blog
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/smhanov/llmhub"
+)
+
+// AIPrice is the price of a model in US dollars per 1,000 tokens.
+type AIPrice struct {
+	PromptPer1K     float64
+	CompletionPer1K float64
+}
+
+// aiUsage is the token usage recorded in an AI task's result. Providers
+// that don't report usage leave every field empty, and the usage is then
+// left out of the result.
+type aiUsage struct {
+	Model            string  `json:"model,omitempty"`
+	Calls            int     `json:"calls,omitempty"`
+	PromptTokens     int     `json:"prompt_tokens,omitempty"`
+	CompletionTokens int     `json:"completion_tokens,omitempty"`
+	TotalTokens      int     `json:"total_tokens,omitempty"`
+	CostUSD          float64 `json:"cost_usd,omitempty"`
+}
+
+// recordAIUsage adds the usage reported in resp to u, which may be nil,
+// and returns it. It returns nil while no usage has been reported.
+func (s *service) recordAIUsage(u *aiUsage, provider AIProviderSettings, resp *llmhub.Response) *aiUsage {
+	if resp == nil {
+		return u
+	}
+	reported := resp.Usage
+	if reported.TotalTokens == 0 {
+		reported.TotalTokens = reported.PromptTokens + reported.CompletionTokens
+	}
+	if reported.TotalTokens == 0 {
+		return u
+	}
+	model := strings.TrimSpace(provider.Model)
+	if u == nil {
+		u = &aiUsage{Model: model}
+	} else if u.Model != model {
+		u.Model = ""
+	}
+	u.Calls++
+	u.PromptTokens += reported.PromptTokens
+	u.CompletionTokens += reported.CompletionTokens
+	u.TotalTokens += reported.TotalTokens
+	if price, ok := s.cfg.AIPricing[model]; ok {
+		u.CostUSD += float64(reported.PromptTokens)/1000*price.PromptPer1K +
+			float64(reported.CompletionTokens)/1000*price.CompletionPer1K
+	}
+	return u
+}
+
+func (u *aiUsage) add(other aiUsage) {
+	u.Calls += other.Calls
+	u.PromptTokens += other.PromptTokens
+	u.CompletionTokens += other.CompletionTokens
+	u.TotalTokens += other.TotalTokens
+	u.CostUSD += other.CostUSD
+}
+
+// aiTaskResult is the result of an AI task that only records usage.
+type aiTaskResult struct {
+	Usage *aiUsage `json:"usage,omitempty"`
+}
+
+type aiUsageReport struct {
+	Tasks      int                `json:"tasks"`
+	Total      aiUsage            `json:"total"`
+	ByTaskType map[string]aiUsage `json:"by_task_type"`
+	ByModel    map[string]aiUsage `json:"by_model"`
+}
+
+// handleAdminAIUsage sums the token usage recorded in recent task results.
+// ?limit sets how many recent tasks are read (default 500).
+func (s *service) handleAdminAIUsage(w http.ResponseWriter, r *http.Request) {
+	limit := 500
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			limit = n
+		}
+	}
+	tasks, err := s.store.ListRecentTasks(r.Context(), limit)
+	if err != nil {
+		http.Error(w, "failed to list tasks", http.StatusInternalServerError)
+		return
+	}
+
+	report := aiUsageReport{
+		ByTaskType: map[string]aiUsage{},
+		ByModel:    map[string]aiUsage{},
+	}
+	for _, task := range tasks {
+		var result aiTaskResult
+		if json.Unmarshal([]byte(task.Result), &result) != nil || result.Usage == nil {
+			continue
+		}
+		usage := *result.Usage
+		report.Tasks++
+		report.Total.add(usage)
+
+		byType := report.ByTaskType[task.TaskType]
+		byType.add(usage)
+		report.ByTaskType[task.TaskType] = byType
+
+		model := usage.Model
+		if model == "" {
+			model = "unknown"
+		}
+		byModel := report.ByModel[model]
+		byModel.Model = model
+		byModel.add(usage)
+		report.ByModel[model] = byModel
+	}
+	writeJSON(w, report)
+}
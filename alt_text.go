@@ -0,0 +1,335 @@
+package blog
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/smhanov/llmhub"
+)
+
+// altTextImagesPerRun caps how many images one alt text task describes.
+// When more are waiting, the task queues a follow-up for the rest.
+const altTextImagesPerRun = 20
+
+// altTextMaxImageBytes is the largest image sent to the model.
+const altTextMaxImageBytes = 10 << 20
+
+type altTextPayload struct {
+	// PostIDs limits the task to these posts; empty means every post.
+	PostIDs []string `json:"post_ids"`
+}
+
+type altTextResult struct {
+	ImagesFound  int      `json:"images_found"`
+	Generated    int      `json:"generated"`
+	PostsUpdated int      `json:"posts_updated"`
+	Remaining    int      `json:"remaining"`
+	Errors       []string `json:"errors,omitempty"`
+}
+
+func (s *service) queueAltTextGeneration(postIDs []string) (*Task, error) {
+	payload, _ := json.Marshal(altTextPayload{PostIDs: postIDs})
+	task := Task{
+		ID:       generateID(),
+		TaskType: TaskTypeGenerateAltText,
+		Status:   TaskStatusPending,
+		Payload:  string(payload),
+		Result:   "{}",
+	}
+	if err := s.store.CreateTask(context.Background(), &task); err != nil {
+		s.logf("tasks: queue alt text: %v", err)
+		return nil, err
+	}
+	s.tasks.nudge()
+	return &task, nil
+}
+
+// handleAdminGenerateAltText queues alt text generation for the posts in
+// the request body, or for every post when none are listed.
+func (s *service) handleAdminGenerateAltText(w http.ResponseWriter, r *http.Request) {
+	var payload altTextPayload
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil && err != io.EOF {
+			http.Error(w, "invalid json", http.StatusBadRequest)
+			return
+		}
+	}
+	task, err := s.queueAltTextGeneration(payload.PostIDs)
+	if err != nil {
+		http.Error(w, "failed to queue alt text generation", http.StatusInternalServerError)
+		return
+	}
+	writeJSONStatus(w, http.StatusAccepted, task)
+}
+
+// processGenerateAltText asks the smart model to describe images that have
+// no alt text and writes the descriptions into the post's HTML and
+// Markdown. Images that already have alt text are left alone.
+func (s *service) processGenerateAltText(ctx context.Context, task *Task) error {
+	var payload altTextPayload
+	if err := json.Unmarshal([]byte(task.Payload), &payload); err != nil {
+		return failTask(fmt.Errorf("invalid payload: %w", err))
+	}
+
+	settings, err := s.aiSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("load ai settings: %w", err)
+	}
+	if settings == nil || !aiProviderConfigured(settings.Smart) {
+		return nil // AI not configured, skip silently
+	}
+	client, err := newLLMClient(settings.Smart, false)
+	if err != nil {
+		return fmt.Errorf("create ai client: %w", err)
+	}
+
+	postIDs := payload.PostIDs
+	if len(postIDs) == 0 {
+		posts, err := s.store.ListAllPosts(ctx, 0, 0)
+		if err != nil {
+			return fmt.Errorf("load posts: %w", err)
+		}
+		for _, p := range posts {
+			postIDs = append(postIDs, p.ID)
+		}
+	}
+
+	var result altTextResult
+	for _, postID := range postIDs {
+		post, err := s.store.GetPostByID(ctx, postID)
+		if err != nil || post == nil {
+			continue
+		}
+		sources := imagesMissingAlt(post.ContentHTML, post.ContentMarkdown)
+		result.ImagesFound += len(sources)
+
+		alts := map[string]string{}
+		for _, src := range sources {
+			// Only descriptions count toward the cap, so a post full of
+			// broken images can't keep the rest from being described.
+			if result.Generated >= altTextImagesPerRun {
+				result.Remaining++
+				continue
+			}
+			alt, err := s.generateAltText(ctx, client, post.Title, src)
+			if err != nil {
+				s.logf("ai alt text failed post_id=%s src=%s err=%v", post.ID, src, err)
+				result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", src, err))
+				continue
+			}
+			alts[src] = alt
+			result.Generated++
+		}
+		if len(alts) > 0 {
+			if err := s.applyAltText(ctx, post.ID, alts); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("update post %s: %v", post.ID, err))
+			} else {
+				result.PostsUpdated++
+			}
+		}
+		s.saveTaskResult(ctx, task, result)
+	}
+
+	s.saveTaskResult(ctx, task, result)
+	s.logf("tasks: alt text complete found=%d generated=%d remaining=%d errors=%d",
+		result.ImagesFound, result.Generated, result.Remaining, len(result.Errors))
+	if result.Remaining > 0 && result.Generated > 0 {
+		s.queueAltTextGeneration(payload.PostIDs)
+	}
+	return nil
+}
+
+// applyAltText writes alts into the latest copy of the post, so edits made
+// while the model was running are kept.
+func (s *service) applyAltText(ctx context.Context, postID string, alts map[string]string) error {
+	latest, err := s.store.GetPostByID(ctx, postID)
+	if err != nil || latest == nil {
+		return err
+	}
+	changed := false
+	for src, alt := range alts {
+		if updated := setMissingHTMLAlt(latest.ContentHTML, src, alt); updated != latest.ContentHTML {
+			latest.ContentHTML = updated
+			changed = true
+		}
+		md := setMissingHTMLAlt(latest.ContentMarkdown, src, alt)
+		md = setMissingMarkdownAlt(md, src, alt)
+		if md != latest.ContentMarkdown {
+			latest.ContentMarkdown = md
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return s.store.UpdatePost(ctx, latest)
+}
+
+func (s *service) generateAltText(ctx context.Context, client *llmhub.Client, title, src string) (string, error) {
+	dataURL, err := s.loadImageDataURL(ctx, src)
+	if err != nil {
+		return "", err
+	}
+	system := llmhub.NewSystemMessage(llmhub.Text(
+		`You write alt text for images in blog posts, for readers using screen readers.
+- Describe what the image shows and why it matters in the post, in one sentence
+- At most 125 characters
+- Don't start with "Image of" or "Picture of"
+- Return ONLY the alt text, nothing else — no quotes, no JSON, no labels`,
+	))
+	user := llmhub.NewUserMessage(llmhub.Text("Post title: "+title), llmhub.Image(dataURL))
+
+	aiCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+	resp, err := s.aiGenerate(aiCtx, client, []*llmhub.Message{system, user})
+	if err != nil {
+		return "", fmt.Errorf("ai generation: %w", err)
+	}
+	alt := parseAltTextResponse(resp.Text())
+	if alt == "" {
+		return "", fmt.Errorf("ai returned empty alt text")
+	}
+	return alt, nil
+}
+
+// loadImageDataURL reads an image from the ImageStore when it is served by
+// the blog, or downloads it otherwise, and returns it as a data URL.
+// Downloads use remoteImportClient, since post content may point anywhere.
+func (s *service) loadImageDataURL(ctx context.Context, src string) (string, error) {
+	var contentType string
+	var body io.ReadCloser
+	if name, ok := strings.CutPrefix(src, s.routePrefix+"/images/"); ok && s.cfg.ImageStore != nil {
+		ct, reader, err := s.cfg.ImageStore.GetImage(ctx, name)
+		if err != nil {
+			return "", fmt.Errorf("load image: %w", err)
+		}
+		contentType, body = ct, reader
+	} else {
+		parsed, err := url.Parse(src)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+			return "", fmt.Errorf("unsupported image url")
+		}
+		fetchCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+		req, err := http.NewRequestWithContext(fetchCtx, http.MethodGet, src, nil)
+		if err != nil {
+			return "", err
+		}
+		resp, err := s.remoteImportClient().Do(req)
+		if err != nil {
+			return "", fmt.Errorf("download: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return "", fmt.Errorf("http status %d", resp.StatusCode)
+		}
+		contentType, body = resp.Header.Get("Content-Type"), resp.Body
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(body, altTextMaxImageBytes+1))
+	if err != nil {
+		return "", fmt.Errorf("read image: %w", err)
+	}
+	if len(data) > altTextMaxImageBytes {
+		return "", fmt.Errorf("image too large")
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if !strings.HasPrefix(mediaType, "image/") {
+		mediaType = contentTypeFromExtension(path.Ext(src))
+	}
+	if contentTypeFromExtension(extensionFromContentType(mediaType)) != mediaType {
+		return "", fmt.Errorf("unsupported image type %q", mediaType)
+	}
+	return "data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(data), nil
+}
+
+func parseAltTextResponse(text string) string {
+	alt := strings.Join(strings.Fields(stripThinkTags(text)), " ")
+	alt = strings.Trim(alt, `"'`)
+	return trimToLength(alt, 250)
+}
+
+var (
+	htmlImgTagRe       = regexp.MustCompile(`(?i)<img\b[^>]*>`)
+	imgSrcAttrRe       = regexp.MustCompile(`(?i)\ssrc\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+	imgAltAttrRe       = regexp.MustCompile(`(?i)\salt\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+	markdownImageSrcRe = regexp.MustCompile(`!\[([^\]]*)\]\(\s*<?([^\s)>]+)>?((?:\s+"[^"]*")?\s*)\)`)
+	markdownAltEscer   = strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`)
+)
+
+// imagesMissingAlt returns the sources of images with empty or missing alt
+// text in a post's HTML and Markdown, in order of appearance.
+func imagesMissingAlt(htmlContent, markdown string) []string {
+	seen := map[string]bool{}
+	var out []string
+	add := func(src string) {
+		src = html.UnescapeString(strings.TrimSpace(src))
+		if src == "" || strings.HasPrefix(src, "data:") || seen[src] {
+			return
+		}
+		seen[src] = true
+		out = append(out, src)
+	}
+	for _, content := range []string{htmlContent, markdown} {
+		for _, tag := range htmlImgTagRe.FindAllString(content, -1) {
+			if src, ok := imgTagSrc(tag); ok && !imgTagHasAlt(tag) {
+				add(src)
+			}
+		}
+	}
+	for _, m := range markdownImageSrcRe.FindAllStringSubmatch(markdown, -1) {
+		if strings.TrimSpace(m[1]) == "" {
+			add(m[2])
+		}
+	}
+	return out
+}
+
+func imgTagSrc(tag string) (string, bool) {
+	m := imgSrcAttrRe.FindStringSubmatch(tag)
+	if m == nil {
+		return "", false
+	}
+	return m[1] + m[2], true
+}
+
+func imgTagHasAlt(tag string) bool {
+	m := imgAltAttrRe.FindStringSubmatch(tag)
+	return m != nil && strings.TrimSpace(m[1]+m[2]+m[3]) != ""
+}
+
+// setMissingHTMLAlt adds alt to every <img> tag showing src that has no
+// alt text yet.
+func setMissingHTMLAlt(content, src, alt string) string {
+	return htmlImgTagRe.ReplaceAllStringFunc(content, func(tag string) string {
+		tagSrc, ok := imgTagSrc(tag)
+		if !ok || html.UnescapeString(strings.TrimSpace(tagSrc)) != src || imgTagHasAlt(tag) {
+			return tag
+		}
+		tag = imgAltAttrRe.ReplaceAllString(tag, "")
+		return tag[:4] + ` alt="` + html.EscapeString(alt) + `"` + tag[4:]
+	})
+}
+
+// setMissingMarkdownAlt fills in the alt text of ![](src) images.
+func setMissingMarkdownAlt(markdown, src, alt string) string {
+	return markdownImageSrcRe.ReplaceAllStringFunc(markdown, func(image string) string {
+		m := markdownImageSrcRe.FindStringSubmatch(image)
+		if strings.TrimSpace(m[1]) != "" || m[2] != src {
+			return image
+		}
+		return "![" + markdownAltEscer.Replace(alt) + "](" + m[2] + m[3] + ")"
+	})
+}
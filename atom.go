@@ -0,0 +1,168 @@
+package blog
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// atomFeed is the top-level Atom 1.0 document.
+type atomFeed struct {
+	XMLName xml.Name     `xml:"http://www.w3.org/2005/Atom feed"`
+	Lang    string       `xml:"http://www.w3.org/XML/1998/namespace lang,attr,omitempty"`
+	ID      string       `xml:"id"`
+	Title   string       `xml:"title"`
+	Updated string       `xml:"updated"`
+	Links   []atomLinkEl `xml:"link"`
+	Author  atomPerson   `xml:"author"`
+	Entries []atomEntry  `xml:"entry"`
+}
+
+type atomLinkEl struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+	// Image carries the author's avatar using the GData extension that
+	// feed readers already understand for Atom authors.
+	Image *atomPersonImage `xml:"http://schemas.google.com/g/2005 image,omitempty"`
+}
+
+type atomPersonImage struct {
+	Rel string `xml:"rel,attr"`
+	Src string `xml:"src,attr"`
+}
+
+type atomEntry struct {
+	Lang       string         `xml:"http://www.w3.org/XML/1998/namespace lang,attr,omitempty"`
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Updated    string         `xml:"updated"`
+	Published  string         `xml:"published,omitempty"`
+	Author     *atomPerson    `xml:"author,omitempty"`
+	Link       atomLinkEl     `xml:"link"`
+	Summary    string         `xml:"summary,omitempty"`
+	Content    atomText       `xml:"content"`
+	Categories []atomCategory `xml:"category,omitempty"`
+}
+
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+func (s *service) handleAtomFeed(w http.ResponseWriter, r *http.Request) {
+	posts, err := s.listPublishedPosts(r.Context(), 20, 0)
+	if err != nil {
+		http.Error(w, "failed to list posts", http.StatusInternalServerError)
+		return
+	}
+	if len(posts) > 0 {
+		_ = s.store.LoadPostsTags(r.Context(), posts)
+	}
+
+	settings := resolveBlogSettings(nil)
+	if rawSettings, err := s.store.GetBlogSettings(r.Context()); err == nil {
+		settings = resolveBlogSettings(rawSettings)
+	}
+	title := s.effectiveTitle(settings)
+	if title == "" {
+		title = "Blog"
+	}
+	author := s.cfg.DefaultAuthorDisplayName
+	if author == "" {
+		author = title
+	}
+
+	authors := s.authorProfiles(r.Context())
+
+	siteURL := s.baseURL(r)
+	homeURL := siteURL + s.routePrefix + "/"
+	feedURL := siteURL + s.routePrefix + "/feed.atom"
+
+	lang := s.feedLanguage(posts)
+	var updated time.Time
+	entries := make([]atomEntry, 0, len(posts))
+	for _, p := range posts {
+		link := siteURL + s.routePrefix + s.postPath(p.Slug)
+		entry := atomEntry{
+			ID:      atomTagURI(siteURL, s.routePrefix, p),
+			Title:   p.Title,
+			Link:    atomLinkEl{Href: link, Rel: "alternate", Type: "text/html"},
+			Summary: firstNonEmpty(p.Excerpt, p.MetaDescription),
+			Content: atomText{Type: "html", Value: s.sanitizeContent(p.ContentHTML)},
+		}
+		if postLang := s.postLanguage(&p); !strings.EqualFold(postLang, lang) {
+			entry.Lang = postLang
+		}
+
+		if profile := authors[p.AuthorID]; profile != nil && profile.Name != "" {
+			entry.Author = &atomPerson{Name: profile.Name, URI: profile.homeLink()}
+			if profile.AvatarURL != "" {
+				entry.Author.Image = &atomPersonImage{Rel: "http://schemas.google.com/g/2005#thumbnail", Src: profile.AvatarURL}
+			}
+		}
+
+		entryUpdated := publishedAtOrZero(p)
+		if p.PublishedAt != nil {
+			entry.Published = p.PublishedAt.UTC().Format(time.RFC3339)
+		}
+		if p.UpdatedAt != nil && p.UpdatedAt.After(entryUpdated) {
+			entryUpdated = p.UpdatedAt.UTC()
+		}
+		entry.Updated = entryUpdated.Format(time.RFC3339)
+		if entryUpdated.After(updated) {
+			updated = entryUpdated
+		}
+
+		for _, tag := range p.Tags {
+			entry.Categories = append(entry.Categories, atomCategory{Term: tag.Name})
+		}
+		entries = append(entries, entry)
+	}
+	if updated.IsZero() {
+		updated = time.Now().UTC()
+	}
+
+	feed := atomFeed{
+		Lang:    lang,
+		ID:      homeURL,
+		Title:   title,
+		Updated: updated.Format(time.RFC3339),
+		Links: []atomLinkEl{
+			{Href: feedURL, Rel: "self", Type: "application/atom+xml"},
+			{Href: homeURL, Rel: "alternate", Type: "text/html"},
+		},
+		Author:  atomPerson{Name: author},
+		Entries: entries,
+	}
+
+	if err := writeCachedXML(w, r, "application/atom+xml", feed, newestModified(posts)); err != nil {
+		http.Error(w, "failed to encode Atom", http.StatusInternalServerError)
+	}
+}
+
+// atomTagURI returns a stable RFC 4151 tag URI for a post, such as
+// "tag:example.com,2024-05-01:/blog/my-post". It depends only on the site
+// host, the publication date and the slug, so edits don't change it.
+func atomTagURI(siteURL, routePrefix string, p Post) string {
+	host := siteURL
+	if parsed, err := url.Parse(siteURL); err == nil && parsed.Hostname() != "" {
+		host = parsed.Hostname()
+	}
+	date := "2000-01-01"
+	if p.PublishedAt != nil {
+		date = p.PublishedAt.UTC().Format("2006-01-02")
+	}
+	return "tag:" + host + "," + date + ":" + routePrefix + "/" + p.Slug
+}
@@ -0,0 +1,209 @@
+package blog
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func (s *service) handleAdminListAuthors(w http.ResponseWriter, r *http.Request) {
+	authors, err := s.store.ListAuthors(r.Context())
+	if err != nil {
+		http.Error(w, "failed to list authors", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, authors)
+}
+
+func (s *service) handleAdminGetAuthor(w http.ResponseWriter, r *http.Request) {
+	id, ok := authorIDParam(w, r)
+	if !ok {
+		return
+	}
+	author, err := s.store.GetAuthor(r.Context(), id)
+	if err != nil {
+		http.Error(w, "failed to load author", http.StatusInternalServerError)
+		return
+	}
+	if author == nil {
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, author)
+}
+
+// handleAdminCreateAuthor adds an author with a newly allocated ID. IDs of
+// deleted authors are not reused, so posts still pointing at one don't pick
+// up a stranger.
+func (s *service) handleAdminCreateAuthor(w http.ResponseWriter, r *http.Request) {
+	author, ok := decodeAuthor(w, r)
+	if !ok {
+		return
+	}
+	id, err := s.store.NextAuthorID(r.Context())
+	if err != nil {
+		http.Error(w, "failed to allocate author id", http.StatusInternalServerError)
+		return
+	}
+	author.ID = id
+	if err := s.store.UpsertAuthor(r.Context(), &author); err != nil {
+		http.Error(w, "failed to save author", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, author)
+}
+
+// handleAdminUpdateAuthor creates or replaces the author with the ID in the
+// path. Posts pick the author up through their AuthorID.
+func (s *service) handleAdminUpdateAuthor(w http.ResponseWriter, r *http.Request) {
+	id, ok := authorIDParam(w, r)
+	if !ok {
+		return
+	}
+	author, ok := decodeAuthor(w, r)
+	if !ok {
+		return
+	}
+	author.ID = id
+	if err := s.store.UpsertAuthor(r.Context(), &author); err != nil {
+		http.Error(w, "failed to save author", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, author)
+}
+
+// decodeAuthor reads and cleans up an author from the request body.
+func decodeAuthor(w http.ResponseWriter, r *http.Request) (Author, bool) {
+	var author Author
+	if err := json.NewDecoder(r.Body).Decode(&author); err != nil {
+		http.Error(w, "invalid json", http.StatusBadRequest)
+		return Author{}, false
+	}
+	author.Name = strings.TrimSpace(author.Name)
+	author.Email = strings.TrimSpace(author.Email)
+	author.AvatarURL = strings.TrimSpace(author.AvatarURL)
+	author.Bio = strings.TrimSpace(author.Bio)
+	for label, link := range author.Links {
+		link = strings.TrimSpace(link)
+		if link == "" {
+			delete(author.Links, label)
+			continue
+		}
+		if !isHTTPURL(link) {
+			http.Error(w, "invalid link for "+label, http.StatusBadRequest)
+			return Author{}, false
+		}
+		author.Links[label] = link
+	}
+	return author, true
+}
+
+func (s *service) handleAdminDeleteAuthor(w http.ResponseWriter, r *http.Request) {
+	id, ok := authorIDParam(w, r)
+	if !ok {
+		return
+	}
+	if err := s.store.DeleteAuthor(r.Context(), id); err != nil {
+		http.Error(w, "failed to delete author", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func authorIDParam(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id < 0 {
+		http.Error(w, "invalid author id", http.StatusBadRequest)
+		return 0, false
+	}
+	return id, true
+}
+
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// AuthorLink is one of an author's social links, as shown on public pages.
+type AuthorLink struct {
+	Label string
+	URL   string
+}
+
+// AuthorProfile is the public view of an author for templates. It leaves
+// out the email address.
+type AuthorProfile struct {
+	Name      string
+	AvatarURL string
+	Bio       string
+	Links     []AuthorLink
+}
+
+// authorProfile returns the public view of the author, or nil when the
+// author has nothing to show.
+func (s *service) authorProfile(author *Author) *AuthorProfile {
+	if author == nil {
+		return nil
+	}
+	profile := &AuthorProfile{
+		Name:      author.Name,
+		AvatarURL: s.authorAvatarURL(author),
+		Bio:       author.Bio,
+	}
+	for label, link := range author.Links {
+		profile.Links = append(profile.Links, AuthorLink{Label: label, URL: link})
+	}
+	sort.Slice(profile.Links, func(i, j int) bool { return profile.Links[i].Label < profile.Links[j].Label })
+	if profile.Name == "" && profile.Bio == "" && profile.AvatarURL == "" && len(profile.Links) == 0 {
+		return nil
+	}
+	return profile
+}
+
+// authorProfiles returns the public profile of every author by ID, for
+// pages and feeds that show several posts. Errors leave it empty.
+func (s *service) authorProfiles(ctx context.Context) map[int]*AuthorProfile {
+	profiles := map[int]*AuthorProfile{}
+	if list, err := s.store.ListAuthors(ctx); err == nil {
+		for i := range list {
+			profiles[list[i].ID] = s.authorProfile(&list[i])
+		}
+	}
+	return profiles
+}
+
+// authorAvatarURL returns the author's uploaded avatar as an absolute URL,
+// or their Gravatar when only an email is set.
+func (s *service) authorAvatarURL(author *Author) string {
+	if author.AvatarURL != "" {
+		return s.resolveImageURL(author.AvatarURL)
+	}
+	email := strings.ToLower(strings.TrimSpace(author.Email))
+	if email == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(email))
+	return "https://gravatar.com/avatar/" + hex.EncodeToString(sum[:]) + "?s=160&d=mp"
+}
+
+// homeLink returns the link used as the author's home page: "website" if
+// set, otherwise the first link by label.
+func (p *AuthorProfile) homeLink() string {
+	if p == nil || len(p.Links) == 0 {
+		return ""
+	}
+	for _, link := range p.Links {
+		if strings.EqualFold(link.Label, "website") {
+			return link.URL
+		}
+	}
+	return p.Links[0].URL
+}
@@ -8,9 +8,11 @@ import (
 	"html/template"
 	"io/fs"
 	"net/http"
+	"net/netip"
 	"os"
 	"path"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -34,11 +36,233 @@ type Config struct {
 	CustomCSSURLs       []string
 	// StaticFilePath is the optional directory from which to serve files not found as posts.
 	StaticFilePath string
-	// TemplatesDir is an optional directory containing custom templates (list.html, post.html).
+	// TemplatesDir is an optional directory containing custom templates (list.html, post.html, notfound.html).
 	// If set, templates found here override the embedded defaults.
 	TemplatesDir string
+	// TemplateFuncs adds functions to every page template, including the
+	// custom layout and templates from TemplatesDir. Built-in functions win
+	// when a name is taken, so the embedded templates keep working.
+	TemplateFuncs template.FuncMap
+	// ListPages includes pages (posts with PostType "page", such as those
+	// imported from WordPress) in the post list and feeds. By default they
+	// are only reachable at their slug and through the sitemap.
+	ListPages bool
+	// RequirePostVersion rejects post updates that don't carry the
+	// updated_at the post was loaded with (428 Precondition Required).
+	// Updates that carry it are always checked, and get 409 Conflict if
+	// the post was saved since.
+	RequirePostVersion bool
+	// TrailingSlash sets the canonical form of post URLs. "strip" (the
+	// default) serves posts at <prefix>/slug and "append" at <prefix>/slug/;
+	// either way, requests with the other form or a differently cased slug
+	// get a 301 to the canonical URL. "off" serves only the exact slug.
+	TrailingSlash string
 	// ListAll disables pagination and displays every published post on a single page.
 	ListAll bool
+	// MaxPageSize caps the ?limit query parameter on the index and tag pages.
+	// Defaults to 100.
+	MaxPageSize int
+	// ThinArchiveThreshold marks tag pages with fewer published posts than
+	// this as thin content: they render with a noindex,follow robots tag and
+	// are left out of the sitemap, but stay reachable. Defaults to 2. Set it
+	// to a negative number to index every tag page.
+	ThinArchiveThreshold int
+	// PagedFeeds splits the RSS feeds into pages of 20 posts, served with
+	// ?page=N. Each page links to the others with RFC 5005 atom:link
+	// first, last, prev and next elements.
+	PagedFeeds bool
+	// ServeSitemap mounts GET <prefix>/sitemap.xml, a sitemap index, and the
+	// GET <prefix>/sitemap-{n}.xml chunks it references.
+	ServeSitemap bool
+	// ServeTagsAPI mounts GET <prefix>/api/tags, a paginated JSON list of
+	// the visible tags with their post counts.
+	ServeTagsAPI bool
+	// ServeMetrics mounts GET <prefix>/admin/api/metrics, counters in the
+	// Prometheus text format, behind AdminAuthMiddleware.
+	ServeMetrics bool
+	// SecretKey signs tokens such as comment form timestamps and draft
+	// preview links. When empty a
+	// random key is generated at startup, so tokens don't survive restarts
+	// or work across multiple instances.
+	SecretKey string
+	// CommentMinSubmitTime rejects comments posted sooner than this after
+	// the comment form was loaded, which catches bots that submit
+	// instantly. Zero (the default) disables the check.
+	CommentMinSubmitTime time.Duration
+	// CommentFormTokenMaxAge rejects comment forms older than this
+	// (default 24h). Only used when CommentMinSubmitTime is set.
+	CommentFormTokenMaxAge time.Duration
+	// PreviewLinkMaxAge is how long a draft preview link works after it is
+	// issued (default 7 days).
+	PreviewLinkMaxAge time.Duration
+	// CommentEditWindow is how long after posting a commenter may edit
+	// their comment (default 15 minutes). Negative allows edits at any
+	// time. Deleting is always allowed.
+	CommentEditWindow time.Duration
+	// RelatedPostsHalfLife is the age at which a post's related-posts score
+	// is halved, so older posts rank below newer ones sharing the same tags
+	// (default 2 years). Negative turns the decay off.
+	RelatedPostsHalfLife time.Duration
+	// RelatedPostsCount is how many posts the Read Next section shows
+	// (default 5). Negative hides the section.
+	RelatedPostsCount int
+	// RelatedPostsNoRandomFill stops Read Next from filling the places
+	// related posts leave empty with random recent posts, so posts without
+	// tags in common show fewer cards or none.
+	RelatedPostsNoRandomFill bool
+	// TrackViews counts how often each published post is viewed. Repeat
+	// views by one visitor within 30 minutes count once. Counts are shown
+	// in the admin post list and passed to post.html as .ViewCount.
+	TrackViews bool
+	// MaxCommentDepth is how deeply comments may nest, counting top-level
+	// comments as 1. The default of 2 allows one level of replies.
+	MaxCommentDepth int
+	// CommentClaimLinks lets commenters move their identity to another
+	// device: GET <prefix>/comments/claim-link returns a link that, opened
+	// elsewhere, sets the same commenter cookie so they can edit or delete
+	// their comments there. Links expire after CommentClaimTTL (default 1h).
+	CommentClaimLinks bool
+	CommentClaimTTL   time.Duration
+	// CommentRateLimit is the number of comments a single client IP or
+	// commenter cookie may post per CommentRateWindow (default 5 per 10
+	// minutes). Set it to a negative number to disable rate limiting.
+	CommentRateLimit  int
+	CommentRateWindow time.Duration
+	// DefaultAISettings configures AI providers without a stored settings
+	// row. Settings saved in the admin override it field by field.
+	DefaultAISettings *AISettings
+	// AIPricing maps a model name to its price per 1,000 tokens. AI tasks
+	// record an estimated cost for models listed here.
+	AIPricing map[string]AIPrice
+	// MaxConcurrentAIStreams caps the AI chat streams open at once; more
+	// get 429 Too Many Requests. Defaults to 8. Set it to a negative
+	// number for no limit.
+	MaxConcurrentAIStreams int
+	// PersistAIChat stores each post's AI chat turns, when chat requests
+	// carry a post_id, so editors can resume the conversation. Earlier
+	// turns are sent with each new request.
+	PersistAIChat bool
+	// AIChatHistoryTurns caps the turns kept per post (default 10).
+	AIChatHistoryTurns int
+	// AIChatHistoryTokens caps the estimated tokens of earlier turns sent
+	// with a request (default 2000); the oldest turns are left out first.
+	AIChatHistoryTokens int
+	// AIPrompts overrides the prompts and limits used to generate meta
+	// descriptions and tags and to check comments for spam.
+	AIPrompts *AIPrompts
+	// HeadingAnchors gives every heading in saved posts a stable id and a
+	// "¶" permalink so readers can copy links to sections.
+	HeadingAnchors bool
+	// ImageCaptions wraps images that stand alone in a paragraph and have a
+	// title, as in ![alt](url "caption"), in a <figure> with the title as
+	// its <figcaption> when posts are saved.
+	ImageCaptions bool
+	// MarkdownExtensions turns on GFM, footnotes, definition lists and
+	// syntax highlighting for saved posts. Tables are always on.
+	MarkdownExtensions MarkdownExtensions
+	// SanitizePolicy filters post HTML when it is served on post pages and
+	// in feeds: "strict" keeps text and basic formatting, "standard" adds
+	// links, images, code and tables, and "relaxed" adds iframes from
+	// SanitizeAllowedIframeHosts (such as "www.youtube.com"). Empty serves
+	// the HTML as stored, which suits blogs whose authors are all trusted.
+	SanitizePolicy             string
+	SanitizeAllowedIframeHosts []string
+	// SanitizeHTML filters post HTML when it is saved, for blogs whose
+	// admin access is shared with less trusted editors. It uses
+	// SanitizePolicy, or the "standard" preset when that is empty, so
+	// scripts, event handlers and javascript: URLs never reach the store.
+	// Off by default: raw HTML in Markdown is stored as written.
+	SanitizeHTML bool
+	// TableOfContents shows a list of a post's h2 and h3 headings above its
+	// content. FeatureFlags can turn it on or off per request.
+	TableOfContents bool
+	// ShowMoreByAuthor adds a "More by" section to posts listing other
+	// posts by the same author, skipping those already under Read Next.
+	// MoreByAuthorCount sets how many are shown (default 3).
+	ShowMoreByAuthor  bool
+	MoreByAuthorCount int
+	// FeatureFlags, if set, is called for every blog request. The flags it
+	// returns override the defaults from Config for that request, and are
+	// read back with FeatureEnabled(r.Context(), name).
+	FeatureFlags func(r *http.Request) map[string]bool
+	// Compression gzips HTML, JSON, XML and other text responses for clients
+	// that send Accept-Encoding: gzip. Images are never recompressed.
+	Compression bool
+	// ImageVariants, when set, makes image uploads also store downscaled
+	// copies (e.g. 320/640/1280px wide) for srcset.
+	ImageVariants *ImageVariantConfig
+	// MaxImageUploadBytes caps the size of an uploaded image. Zero means
+	// 20MB; a negative value removes the cap.
+	MaxImageUploadBytes int64
+	// PingSearchEngines lists sitemap ping endpoints, such as
+	// "https://example-search.com/ping". When a post goes live or a live
+	// post's slug changes, a background task requests each one with
+	// ?sitemap=<sitemap URL>.
+	PingSearchEngines []string
+	// PingSitemapURL is the sitemap URL sent in pings. It defaults to the
+	// built-in <prefix>/sitemap.xml when ServeSitemap is set; with neither,
+	// no pings are sent.
+	PingSitemapURL string
+	// TrustedProxies lists the addresses or CIDR ranges of reverse proxies
+	// whose X-Forwarded-For header gives the client IP, and whose
+	// X-Forwarded-Host and X-Forwarded-Port headers are trusted when
+	// building absolute URLs without SiteURL.
+	TrustedProxies []string
+	// AllowedOrigins lists the origins, such as "https://app.example.com",
+	// whose pages may call the JSON comment and tags API, with the
+	// commenter cookie. HTML pages never answer cross-origin requests.
+	AllowedOrigins []string
+	// Webmentions accepts Webmentions at POST <prefix>/webmention. Each is
+	// verified in the background by fetching its source, and verified
+	// mentions are listed under the post.
+	Webmentions bool
+	// TrustedImportHosts lists hostnames that remote WXR imports,
+	// webmention verification and alt text generation may fetch from even
+	// when they resolve to loopback or private addresses.
+	TrustedImportHosts []string
+	// RemoteImportMaxBytes caps the size of a remote WXR download
+	// (default 512 MB).
+	RemoteImportMaxBytes int64
+	// WebhookURL receives a JSON POST for comment.created,
+	// comment.status_changed and post.published events. When WebhookSecret
+	// is set, the body's HMAC-SHA256 is sent as
+	// X-Spore-Signature: sha256=<hex>.
+	WebhookURL    string
+	WebhookSecret string
+	// EmailNotifier, when set, is told about every new comment.
+	EmailNotifier EmailNotifier
+	// SMTP configures the built-in email notifier used when EmailNotifier
+	// is nil.
+	SMTP *SMTPConfig
+	// PushRetryAttempts is how many times a push notification is tried
+	// before it is dropped (default 5). Failed sends are retried by a
+	// background task after PushRetryBackoff (default 30s), doubling the
+	// delay each time. 404 and 410 responses remove the subscription
+	// instead.
+	PushRetryAttempts int
+	PushRetryBackoff  time.Duration
+	// TaskWorkers is how many background tasks run at once (default 1).
+	TaskWorkers int
+	// TaskMaxAttempts is how many times a failing background task is run
+	// before it is marked failed (default 3). Retries wait TaskRetryBackoff
+	// (default 30s), doubling after each attempt up to an hour. Set it to 1
+	// to fail tasks on their first error.
+	TaskMaxAttempts  int
+	TaskRetryBackoff time.Duration
+	// NotificationDigestInterval batches new-comment push notifications into
+	// a single digest sent at most once per interval. Zero (the default)
+	// notifies admins immediately on every comment.
+	NotificationDigestInterval time.Duration
+	// TimeZone is the IANA zone, such as "Europe/Paris", that absolute
+	// dates on public pages are shown in. Empty keeps the stored zone,
+	// normally UTC.
+	TimeZone string
+	// DateFormat is the Go time layout for absolute dates on public pages
+	// (default "Jan 2, 2006").
+	DateFormat string
+	// DateLabels translates the wording around dates on public pages, such
+	// as "Published %s" and "%d days" for approximate dates.
+	DateLabels DateLabels
 	// Optional metadata used for WXR export/import.
 	SiteTitle                string
 	SiteDescription          string
@@ -47,6 +271,19 @@ type Config struct {
 	DefaultAuthorLogin       string
 	DefaultAuthorDisplayName string
 	ImportAuthorID           int
+	// AuthorLoginToID maps WordPress author logins (the dc:creator of each
+	// item) to local author IDs for WXR imports. Posts by authors not in
+	// the map get ImportAuthorID, and the import result lists those
+	// authors under unmapped_authors.
+	AuthorLoginToID map[string]int
+	// CommentImportBatchSize is how many imported comments are written per
+	// batch when the store implements BatchSaver (default 500).
+	CommentImportBatchSize int
+	// Logger receives everything the blog logs. Defaults to the standard
+	// log package.
+	Logger Logger
+	// LogRequests logs one line per request served through Logger.
+	LogRequests bool
 }
 
 type service struct {
@@ -59,6 +296,26 @@ type service struct {
 	pushPublicKey  string
 	pushPrivateKey string
 	pushSubscriber string
+	secret         []byte
+	trustedProxies []netip.Prefix
+	email          EmailNotifier
+	sanitizer      *sanitizePolicy
+	prompts        *aiPrompts
+	aiStreams      chan struct{}
+	views          *viewCounter
+	allowedOrigins map[string]bool
+	metrics        *metrics
+	closing        chan struct{} // closed by Handler.Close
+	closeOnce      sync.Once
+	commentLimiter *rateLimiter
+	// postProcessingMu makes queuePostProcessing's check for a pending
+	// task and its insert atomic within the process.
+	postProcessingMu sync.Mutex
+	// sitemapChunkSize overrides sitemapMaxURLs (used by tests).
+	sitemapChunkSize int
+	// highlightCSS is the stylesheet for syntax highlighting, nil when
+	// MarkdownExtensions.SyntaxHighlighting is off.
+	highlightCSS []byte
 }
 
 // Handler serves the blog's HTTP routes and provides methods for integrating
@@ -84,23 +341,72 @@ func NewHandler(cfg Config) (*Handler, error) {
 	if !strings.HasPrefix(routePrefix, "/") {
 		routePrefix = "/" + routePrefix
 	}
+	trailingSlash, err := normalizeTrailingSlash(cfg.TrailingSlash)
+	if err != nil {
+		return nil, err
+	}
+	cfg.TrailingSlash = trailingSlash
 	tpls, err := parseTemplates(cfg)
 	if err != nil {
 		return nil, err
 	}
+	trustedProxies, err := parseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+	sanitizer := newSanitizePolicy(cfg.SanitizePolicy, cfg.SanitizeAllowedIframeHosts)
+	if sanitizer == nil && strings.TrimSpace(cfg.SanitizePolicy) != "" {
+		return nil, fmt.Errorf("unknown sanitize policy %q", cfg.SanitizePolicy)
+	}
+	prompts, err := newAIPrompts(cfg.AIPrompts)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.MarkdownExtensions.validate(); err != nil {
+		return nil, err
+	}
+	allowedOrigins, err := parseAllowedOrigins(cfg.AllowedOrigins)
+	if err != nil {
+		return nil, err
+	}
 
 	s := &service{
-		cfg:         cfg,
-		templates:   tpls,
-		routePrefix: strings.TrimSuffix(routePrefix, "/"),
-		adminFS:     adminAssetsFS,
-		store:       newStoreAdapter(cfg.Store),
+		cfg:            cfg,
+		templates:      tpls,
+		routePrefix:    strings.TrimSuffix(routePrefix, "/"),
+		adminFS:        adminAssetsFS,
+		store:          newStoreAdapter(cfg.Store),
+		secret:         signingKey(cfg),
+		commentLimiter: newCommentRateLimiter(cfg),
+		trustedProxies: trustedProxies,
+		sanitizer:      sanitizer,
+		prompts:        prompts,
+		aiStreams:      newAIStreamSlots(cfg),
+		allowedOrigins: allowedOrigins,
+		metrics:        newMetrics(),
+		closing:        make(chan struct{}),
+	}
+	s.store.relatedHalfLife = cfg.RelatedPostsHalfLife
+	if cfg.MarkdownExtensions.SyntaxHighlighting {
+		if s.highlightCSS, err = highlightCSS(cfg.MarkdownExtensions); err != nil {
+			return nil, fmt.Errorf("build highlight stylesheet: %w", err)
+		}
+	}
+	if cfg.TrackViews {
+		s.views = newViewCounter()
 	}
 	s.configurePushFromEnv()
+	switch {
+	case cfg.EmailNotifier != nil:
+		s.email = cfg.EmailNotifier
+	case cfg.SMTP != nil:
+		s.email = newSMTPNotifier(*cfg.SMTP, cfg.SiteURL, s.routePrefix)
+	}
 
 	r := chi.NewRouter()
 
 	r.Route(s.routePrefix, func(r chi.Router) {
+		r.Use(s.featureFlags)
 		s.mountPublicRoutes(r)
 
 		// Admin assets and API
@@ -115,14 +421,52 @@ func NewHandler(cfg Config) (*Handler, error) {
 	// Start background task runner (resumes pending tasks from DB)
 	s.tasks = newTaskRunner(s)
 	s.tasks.start()
+	if cfg.NotificationDigestInterval > 0 {
+		go s.runNotificationDigests(cfg.NotificationDigestInterval)
+	}
 
-	return &Handler{Handler: r, svc: s}, nil
+	var handler http.Handler = r
+	if cfg.Compression {
+		handler = compressResponses(handler)
+	}
+	if cfg.LogRequests {
+		handler = s.logRequests(handler)
+	}
+
+	return &Handler{Handler: handler, svc: s}, nil
+}
+
+// Close stops the handler's background work: the task runner stops taking
+// tasks and Close waits for the running ones to finish, or for ctx to be
+// done, whichever comes first. Tasks still running then are cancelled and
+// put back in the queue. Views counted with Config.TrackViews are then
+// saved. Call it after http.Server.Shutdown; pending tasks stay queued
+// and run when the next handler starts. Calling Close more than once is
+// safe.
+func (h *Handler) Close(ctx context.Context) error {
+	s := h.svc
+	s.closeOnce.Do(func() { close(s.closing) })
+	if err := s.tasks.stop(ctx); err != nil {
+		return err
+	}
+	if s.views != nil {
+		counts := s.views.take(time.Now())
+		if err := s.store.AddPostViews(ctx, counts); err != nil {
+			s.views.restore(counts) // only the views not stored
+			return fmt.Errorf("save view counts: %w", err)
+		}
+	}
+	return nil
 }
 
 func parseTemplates(cfg Config) (map[string]*template.Template, error) {
+	dates, err := newDateFormatter(cfg)
+	if err != nil {
+		return nil, err
+	}
 	funcMap := template.FuncMap{
 		"safeHTML":            func(s string) template.HTML { return template.HTML(s) },
-		"formatPublishedDate": formatPublishedDate,
+		"formatPublishedDate": dates.formatPublishedDate,
 		"rfc3339": func(t *time.Time) string {
 			if t == nil {
 				return ""
@@ -137,6 +481,12 @@ func parseTemplates(cfg Config) (map[string]*template.Template, error) {
 		"truncate":  tplTruncate,
 		"stripHTML": tplStripHTML,
 		"now":       func() time.Time { return time.Now() },
+		"postPath":  func(slug string) string { return postPath(cfg.TrailingSlash, slug) },
+	}
+	for name, fn := range cfg.TemplateFuncs {
+		if _, builtin := funcMap[name]; !builtin {
+			funcMap[name] = fn
+		}
 	}
 
 	build := func(extra ...string) (*template.Template, error) {
@@ -237,8 +587,14 @@ func parseTemplates(cfg Config) (map[string]*template.Template, error) {
 		return nil, err
 	}
 
+	notFoundTpl, err := buildTpl("notfound.html")
+	if err != nil {
+		return nil, err
+	}
+
 	return map[string]*template.Template{
-		"list.html": listTpl,
-		"post.html": postTpl,
+		"list.html":     listTpl,
+		"post.html":     postTpl,
+		"notfound.html": notFoundTpl,
 	}, nil
 }
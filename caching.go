@@ -0,0 +1,100 @@
+package blog
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// writeCached writes a public page or feed with cache validators: a weak
+// ETag hashed from the body and, when modified is set, a Last-Modified
+// header. A client that already has the same body gets 304 Not Modified
+// instead. modified only follows the posts shown, while pages also change
+// with comments, view counts and settings, so the ETag wins whenever the
+// client sends one. Cache-Control defaults to no-cache, so browsers and
+// CDNs keep copies but check them on each use rather than guessing a
+// lifetime from Last-Modified.
+func writeCached(w http.ResponseWriter, r *http.Request, contentType string, modified time.Time, body []byte) {
+	sum := sha256.Sum256(body)
+	etag := `W/"` + hex.EncodeToString(sum[:16]) + `"`
+	h := w.Header()
+	h.Set("ETag", etag)
+	if !modified.IsZero() {
+		h.Set("Last-Modified", modified.UTC().Format(http.TimeFormat))
+	}
+	if h.Get("Cache-Control") == "" {
+		h.Set("Cache-Control", "no-cache")
+	}
+	if notModified(r, etag, modified) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	h.Set("Content-Type", contentType)
+	_, _ = w.Write(body)
+}
+
+// notModified reports whether the request's conditional headers match the
+// response. If-Modified-Since is only consulted without If-None-Match, as
+// RFC 9110 requires.
+func notModified(r *http.Request, etag string, modified time.Time) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" && !modified.IsZero() {
+		t, err := http.ParseTime(ims)
+		return err == nil && !modified.Truncate(time.Second).After(t)
+	}
+	return false
+}
+
+// postModified returns when p last changed: its UpdatedAt, or its
+// PublishedAt when that is later or there is no UpdatedAt.
+func postModified(p Post) time.Time {
+	var t time.Time
+	if p.UpdatedAt != nil {
+		t = *p.UpdatedAt
+	}
+	if p.PublishedAt != nil && p.PublishedAt.After(t) {
+		t = *p.PublishedAt
+	}
+	return t
+}
+
+// newestModified returns the latest postModified of posts.
+func newestModified(posts []Post) time.Time {
+	var newest time.Time
+	for _, p := range posts {
+		if t := postModified(p); t.After(newest) {
+			newest = t
+		}
+	}
+	return newest
+}
+
+// executeCachedTemplate is executeTemplate for public pages, sent through
+// writeCached.
+func (s *service) executeCachedTemplate(w http.ResponseWriter, r *http.Request, name string, data any, modified time.Time) {
+	tpl, ok := s.templates[name]
+	if !ok {
+		http.Error(w, "template not found", http.StatusInternalServerError)
+		return
+	}
+	var buf bytes.Buffer
+	if err := tpl.ExecuteTemplate(&buf, "base.html", data); err != nil {
+		http.Error(w, "template render error", http.StatusInternalServerError)
+		return
+	}
+	writeCached(w, r, "text/html; charset=utf-8", modified, buf.Bytes())
+}
@@ -0,0 +1,126 @@
+package blog
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultCommentClaimTTL is how long a claim link stays valid when
+// Config.CommentClaimTTL is unset.
+const defaultCommentClaimTTL = time.Hour
+
+var errClaimTokenInvalid = errors.New("invalid or expired claim link")
+
+// claimAEAD derives an AES-GCM cipher for claim tokens from the service key.
+// Claim tokens carry the raw owner token, so they are encrypted rather than
+// merely signed to keep it out of logs and browser history.
+func (s *service) claimAEAD() (cipher.AEAD, error) {
+	key := sha256.Sum256(append([]byte("comment-claim:"), s.secret...))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (s *service) commentClaimTTL() time.Duration {
+	if s.cfg.CommentClaimTTL > 0 {
+		return s.cfg.CommentClaimTTL
+	}
+	return defaultCommentClaimTTL
+}
+
+// commentClaimToken seals the owner token and its expiry into a URL-safe
+// string.
+func (s *service) commentClaimToken(ownerToken string, expires time.Time) (string, error) {
+	aead, err := s.claimAEAD()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	plain := strconv.FormatInt(expires.Unix(), 10) + ":" + ownerToken
+	sealed := aead.Seal(nonce, nonce, []byte(plain), nil)
+	return base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// openCommentClaimToken returns the owner token sealed in token, provided it
+// hasn't expired.
+func (s *service) openCommentClaimToken(token string, now time.Time) (string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", errClaimTokenInvalid
+	}
+	aead, err := s.claimAEAD()
+	if err != nil {
+		return "", err
+	}
+	if len(raw) < aead.NonceSize() {
+		return "", errClaimTokenInvalid
+	}
+	plain, err := aead.Open(nil, raw[:aead.NonceSize()], raw[aead.NonceSize():], nil)
+	if err != nil {
+		return "", errClaimTokenInvalid
+	}
+	ts, ownerToken, ok := strings.Cut(string(plain), ":")
+	expires, err := strconv.ParseInt(ts, 10, 64)
+	if !ok || err != nil || now.Unix() > expires || ownerToken == "" {
+		return "", errClaimTokenInvalid
+	}
+	return ownerToken, nil
+}
+
+// handleCommentClaimLink returns a link that gives another device the
+// caller's commenter identity. The caller must own at least one comment.
+func (s *service) handleCommentClaimLink(w http.ResponseWriter, r *http.Request) {
+	token := ownerToken(r)
+	if token == "" {
+		http.Error(w, "not allowed", http.StatusForbidden)
+		return
+	}
+	owns, err := s.store.HasCommentsByOwner(r.Context(), hashToken(token))
+	if err != nil {
+		http.Error(w, "failed to load comments", http.StatusInternalServerError)
+		return
+	}
+	if !owns {
+		http.Error(w, "not allowed", http.StatusForbidden)
+		return
+	}
+
+	expires := time.Now().Add(s.commentClaimTTL()).UTC()
+	claim, err := s.commentClaimToken(token, expires)
+	if err != nil {
+		http.Error(w, "failed to create claim link", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, map[string]any{
+		"url":        s.canonicalURL(r, "/comments/claim?token="+url.QueryEscape(claim)),
+		"expires_at": expires,
+	})
+}
+
+// handleCommentClaim sets the owner cookie from a claim link and sends the
+// reader to the blog index.
+func (s *service) handleCommentClaim(w http.ResponseWriter, r *http.Request) {
+	token, err := s.openCommentClaimToken(r.URL.Query().Get("token"), time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.setOwnerCookie(w, r, token)
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, s.routePrefix+"/", http.StatusSeeOther)
+}
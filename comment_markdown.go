@@ -0,0 +1,39 @@
+package blog
+
+import (
+	"bytes"
+	"html"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	gmhtml "github.com/yuin/goldmark/renderer/html"
+)
+
+// commentMarkdown renders comment text. Raw HTML is not passed through, and
+// single newlines stay line breaks as they were when comments were plain
+// text.
+var commentMarkdown = goldmark.New(
+	goldmark.WithExtensions(extension.Strikethrough, extension.Linkify),
+	goldmark.WithRendererOptions(gmhtml.WithHardWraps()),
+)
+
+// commentPolicy is the allowlist for rendered comments: emphasis, links,
+// code, quotes and lists. Links are marked as user-generated.
+var commentPolicy = &sanitizePolicy{
+	tags: map[string][]string{
+		"p": nil, "br": nil, "strong": nil, "em": nil, "del": nil,
+		"code": nil, "pre": nil, "blockquote": nil, "ul": nil, "ol": {"start"}, "li": nil,
+		"a": {"href"},
+	},
+	linkRel: "nofollow ugc",
+}
+
+// renderCommentHTML converts a comment's Markdown to HTML that is safe to
+// insert into the page. The Markdown stays what is stored and edited.
+func renderCommentHTML(content string) string {
+	var buf bytes.Buffer
+	if err := commentMarkdown.Convert([]byte(content), &buf); err != nil {
+		return "<p>" + html.EscapeString(content) + "</p>"
+	}
+	return commentPolicy.sanitize(buf.String())
+}
@@ -0,0 +1,49 @@
+package blog
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Moderation modes for BlogSettings.ModerationMode.
+const (
+	moderationAuto   = "auto"   // comments are published straight away
+	moderationManual = "manual" // comments wait for an admin to approve them
+	moderationAI     = "ai"     // comments wait for the dumb model's spam check
+)
+
+// normalizeModerationMode lowercases a moderation mode and reports whether
+// it is one Spore knows. The empty string, the default, is valid.
+func normalizeModerationMode(value string) (string, bool) {
+	value = strings.TrimSpace(strings.ToLower(value))
+	switch value {
+	case "", moderationAuto, moderationManual, moderationAI:
+		return value, true
+	default:
+		return "", false
+	}
+}
+
+// moderationMode returns the mode new comments are moderated by. Without
+// one set, comments are spam checked when a dumb provider is configured
+// and published straight away otherwise.
+func (s *service) moderationMode(ctx context.Context, settings BlogSettings) string {
+	if settings.ModerationMode != "" {
+		return settings.ModerationMode
+	}
+	ai, err := s.aiSettings(ctx)
+	if err == nil && ai != nil && aiProviderConfigured(ai.Dumb) {
+		return moderationAI
+	}
+	return moderationAuto
+}
+
+// commentsClosed reports whether settings.CloseCommentsAfterDays has
+// closed post to new comments at now.
+func commentsClosed(settings BlogSettings, post *Post, now time.Time) bool {
+	if settings.CloseCommentsAfterDays <= 0 || post == nil || post.PublishedAt == nil {
+		return false
+	}
+	return now.Sub(*post.PublishedAt) > time.Duration(settings.CloseCommentsAfterDays)*24*time.Hour
+}
@@ -0,0 +1,52 @@
+package blog
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// commentReactions summarises the upvotes on one comment for the reader
+// viewing it.
+type commentReactions struct {
+	Count   int
+	Reacted bool
+}
+
+type commentReactionResponse struct {
+	ReactionCount int  `json:"reaction_count"`
+	Reacted       bool `json:"reacted"`
+}
+
+// handleReactToComment toggles the reader's upvote on an approved comment.
+// Readers are told apart by the owner cookie, which is issued here if they
+// don't have one yet, so each browser counts once.
+func (s *service) handleReactToComment(w http.ResponseWriter, r *http.Request) {
+	enabled, err := s.commentsEnabled(r)
+	if err != nil {
+		http.Error(w, "failed to load settings", http.StatusInternalServerError)
+		return
+	}
+	if !enabled {
+		http.Error(w, "comments are disabled", http.StatusForbidden)
+		return
+	}
+
+	comment, err := s.store.GetCommentByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "failed to load comment", http.StatusInternalServerError)
+		return
+	}
+	if comment == nil || comment.Status != "approved" {
+		http.NotFound(w, r)
+		return
+	}
+
+	ownerHash := hashToken(s.ensureOwnerToken(w, r))
+	reacted, count, err := s.store.ToggleCommentReaction(r.Context(), comment, ownerHash)
+	if err != nil {
+		http.Error(w, "failed to save reaction", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, commentReactionResponse{ReactionCount: count, Reacted: reacted})
+}
@@ -19,6 +19,12 @@ type blogSettingsPayload struct {
 	Title                string `json:"title"`
 	Description          string `json:"description"`
 	GoogleAnalyticsCode  string `json:"google_analytics_code"`
+	// ModerationMode, SyncSpamCheck and CloseCommentsAfterDays are left
+	// unchanged when the field is missing, so clients that predate them
+	// don't reset them.
+	ModerationMode         *string `json:"moderation_mode"`
+	SyncSpamCheck          *bool   `json:"sync_spam_check"`
+	CloseCommentsAfterDays *int    `json:"close_comments_after_days"`
 }
 
 func (s *service) handleAdminGetBlogSettings(w http.ResponseWriter, r *http.Request) {
@@ -45,15 +51,18 @@ func (s *service) handleAdminGetBlogSettings(w http.ResponseWriter, r *http.Requ
 		return
 	}
 	writeJSON(w, map[string]interface{}{
-		"comments_enabled":      settings.CommentsEnabled,
-		"notifications_enabled": notificationsEnabled,
-		"vapid_public_key":      publicKey,
-		"vapid_private_key":     privateKey,
-		"vapid_subscriber":      subscriber,
-		"date_display":          settings.DateDisplay,
-		"title":                 settings.Title,
-		"description":           settings.Description,
-		"google_analytics_code": settings.GoogleAnalyticsCode,
+		"comments_enabled":          settings.CommentsEnabled,
+		"notifications_enabled":     notificationsEnabled,
+		"vapid_public_key":          publicKey,
+		"vapid_private_key":         privateKey,
+		"vapid_subscriber":          subscriber,
+		"date_display":              settings.DateDisplay,
+		"title":                     settings.Title,
+		"description":               settings.Description,
+		"google_analytics_code":     settings.GoogleAnalyticsCode,
+		"moderation_mode":           settings.ModerationMode,
+		"sync_spam_check":           settings.SyncSpamCheck,
+		"close_comments_after_days": settings.CloseCommentsAfterDays,
 	})
 }
 
@@ -63,12 +72,39 @@ func (s *service) handleAdminUpdateBlogSettings(w http.ResponseWriter, r *http.R
 		http.Error(w, "invalid json", http.StatusBadRequest)
 		return
 	}
+	current, err := s.store.GetBlogSettings(r.Context())
+	if err != nil {
+		http.Error(w, "failed to load settings", http.StatusInternalServerError)
+		return
+	}
+	stored := resolveBlogSettings(current)
 	settings := &BlogSettings{
-		CommentsEnabled:     payload.CommentsEnabled,
-		DateDisplay:         normalizeDateDisplay(payload.DateDisplay),
-		Title:               payload.Title,
-		Description:         payload.Description,
-		GoogleAnalyticsCode: payload.GoogleAnalyticsCode,
+		CommentsEnabled:        payload.CommentsEnabled,
+		DateDisplay:            normalizeDateDisplay(payload.DateDisplay),
+		Title:                  payload.Title,
+		Description:            payload.Description,
+		GoogleAnalyticsCode:    payload.GoogleAnalyticsCode,
+		ModerationMode:         stored.ModerationMode,
+		SyncSpamCheck:          stored.SyncSpamCheck,
+		CloseCommentsAfterDays: stored.CloseCommentsAfterDays,
+	}
+	if payload.ModerationMode != nil {
+		mode, ok := normalizeModerationMode(*payload.ModerationMode)
+		if !ok {
+			http.Error(w, "moderation_mode must be auto, manual or ai", http.StatusBadRequest)
+			return
+		}
+		settings.ModerationMode = mode
+	}
+	if payload.SyncSpamCheck != nil {
+		settings.SyncSpamCheck = *payload.SyncSpamCheck
+	}
+	if payload.CloseCommentsAfterDays != nil {
+		if *payload.CloseCommentsAfterDays < 0 {
+			http.Error(w, "close_comments_after_days must not be negative", http.StatusBadRequest)
+			return
+		}
+		settings.CloseCommentsAfterDays = *payload.CloseCommentsAfterDays
 	}
 	if err := s.store.UpdateBlogSettings(r.Context(), settings); err != nil {
 		http.Error(w, "failed to update settings", http.StatusInternalServerError)
@@ -83,15 +119,18 @@ func (s *service) handleAdminUpdateBlogSettings(w http.ResponseWriter, r *http.R
 		return
 	}
 	writeJSON(w, map[string]interface{}{
-		"comments_enabled":      settings.CommentsEnabled,
-		"notifications_enabled": payload.NotificationsEnabled,
-		"vapid_public_key":      strings.TrimSpace(payload.VAPIDPublicKey),
-		"vapid_private_key":     strings.TrimSpace(payload.VAPIDPrivateKey),
-		"vapid_subscriber":      strings.TrimSpace(payload.VAPIDSubscriber),
-		"date_display":          settings.DateDisplay,
-		"title":                 settings.Title,
-		"description":           settings.Description,
-		"google_analytics_code": settings.GoogleAnalyticsCode,
+		"comments_enabled":          settings.CommentsEnabled,
+		"notifications_enabled":     payload.NotificationsEnabled,
+		"vapid_public_key":          strings.TrimSpace(payload.VAPIDPublicKey),
+		"vapid_private_key":         strings.TrimSpace(payload.VAPIDPrivateKey),
+		"vapid_subscriber":          strings.TrimSpace(payload.VAPIDSubscriber),
+		"date_display":              settings.DateDisplay,
+		"title":                     settings.Title,
+		"description":               settings.Description,
+		"google_analytics_code":     settings.GoogleAnalyticsCode,
+		"moderation_mode":           settings.ModerationMode,
+		"sync_spam_check":           settings.SyncSpamCheck,
+		"close_comments_after_days": settings.CloseCommentsAfterDays,
 	})
 }
 
@@ -135,10 +174,18 @@ func (s *service) handleAdminUpdateCommentStatus(w http.ResponseWriter, r *http.
 		return
 	}
 
+	before, err := s.store.GetCommentByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "failed to load comment", http.StatusInternalServerError)
+		return
+	}
 	if err := s.store.UpdateCommentStatus(r.Context(), id, status, nil); err != nil {
 		http.Error(w, "failed to update status", http.StatusInternalServerError)
 		return
 	}
+	if before != nil && before.Status != status {
+		s.queueCommentStatusWebhook(r, *before, status)
+	}
 	w.WriteHeader(http.StatusNoContent)
 }
 
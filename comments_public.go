@@ -3,38 +3,75 @@ package blog
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 )
 
 const commentOwnerCookie = "blog_commenter_token"
 
+// Page sizes for GET /{slug}/comments?limit=N, counted in top-level comments.
+const (
+	defaultCommentPageSize = 20
+	maxCommentPageSize     = 100
+)
+
+// defaultMaxCommentDepth allows top-level comments and one level of replies.
+const defaultMaxCommentDepth = 2
+
 type createCommentRequest struct {
 	AuthorName string  `json:"author_name"`
 	Content    string  `json:"content"`
 	ParentID   *string `json:"parent_id"`
+	FormToken  string  `json:"form_token"`
 }
 
 type commentResponse struct {
-	ID         string            `json:"id"`
-	ParentID   *string           `json:"parent_id,omitempty"`
-	AuthorName string            `json:"author_name"`
-	Content    string            `json:"content"`
-	Status     string            `json:"status"`
-	CreatedAt  time.Time         `json:"created_at"`
-	UpdatedAt  *time.Time        `json:"updated_at,omitempty"`
-	Owned      bool              `json:"owned"`
-	Replies    []commentResponse `json:"replies,omitempty"`
+	ID            string            `json:"id"`
+	ParentID      *string           `json:"parent_id,omitempty"`
+	AuthorName    string            `json:"author_name"`
+	Content       string            `json:"content"`
+	ContentHTML   string            `json:"content_html"`
+	Status        string            `json:"status"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     *time.Time        `json:"updated_at,omitempty"`
+	Owned         bool              `json:"owned"`
+	ReactionCount int               `json:"reaction_count"`
+	Reacted       bool              `json:"reacted"`
+	Replies       []commentResponse `json:"replies,omitempty"`
+}
+
+// commentPageResponse is returned by GET /{slug}/comments when ?limit= or
+// ?offset= is given. NextOffset is null on the last page.
+type commentPageResponse struct {
+	Comments   []commentResponse `json:"comments"`
+	Total      int               `json:"total"`
+	NextOffset *int              `json:"next_offset"`
 }
 
 func (s *service) mountCommentRoutes(r chi.Router) {
 	r.Get("/{slug}/comments", s.handleListComments)
+	r.Get("/{slug}/comments/form-token", s.handleCommentFormToken)
 	r.Post("/{slug}/comments", s.handleCreateComment)
+	if s.cfg.CommentClaimLinks {
+		r.Get("/comments/claim-link", s.handleCommentClaimLink)
+		r.Get("/comments/claim", s.handleCommentClaim)
+	}
 	r.Put("/comments/{id}", s.handleUpdateComment)
 	r.Delete("/comments/{id}", s.handleDeleteComment)
+	r.Post("/comments/{id}/react", s.handleReactToComment)
+	if len(s.allowedOrigins) > 0 {
+		for _, pattern := range []string{"/{slug}/comments", "/comments/{id}", "/comments/{id}/react"} {
+			r.Options(pattern, handlePreflight)
+		}
+	}
 }
 
 func (s *service) handleListComments(w http.ResponseWriter, r *http.Request) {
@@ -60,23 +97,77 @@ func (s *service) handleListComments(w http.ResponseWriter, r *http.Request) {
 	}
 
 	ownerHash := s.ownerTokenHash(r)
+	q := r.URL.Query()
+	if q.Has("limit") || q.Has("offset") {
+		s.writeCommentPage(w, r, post.ID, ownerHash)
+		return
+	}
+
 	comments, err := s.store.ListCommentsByPost(r.Context(), post.ID)
 	if err != nil {
 		http.Error(w, "failed to list comments", http.StatusInternalServerError)
 		return
 	}
 
-	response := buildCommentThread(comments, ownerHash)
+	reactions, err := s.store.ListCommentReactions(r.Context(), post.ID, ownerHash)
+	if err != nil {
+		http.Error(w, "failed to list reactions", http.StatusInternalServerError)
+		return
+	}
+	response := buildCommentThread(comments, ownerHash, reactions)
 	writeJSON(w, response)
 }
 
+// writeCommentPage serves ?limit=&offset= requests: a page of top-level
+// comments with their replies attached.
+func (s *service) writeCommentPage(w http.ResponseWriter, r *http.Request, postID, ownerHash string) {
+	limit := defaultCommentPageSize
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			limit = min(n, maxCommentPageSize)
+		}
+	}
+	offset := 0
+	if v := r.URL.Query().Get("offset"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			offset = n
+		}
+	}
+
+	comments, total, err := s.store.ListCommentsByPostPaged(r.Context(), postID, ownerHash, limit, offset)
+	if err != nil {
+		http.Error(w, "failed to list comments", http.StatusInternalServerError)
+		return
+	}
+	// buildCommentThread expects chronological order.
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+	})
+	reactions, err := s.store.ListCommentReactions(r.Context(), postID, ownerHash)
+	if err != nil {
+		http.Error(w, "failed to list reactions", http.StatusInternalServerError)
+		return
+	}
+
+	resp := commentPageResponse{
+		Comments: buildCommentThread(comments, ownerHash, reactions),
+		Total:    total,
+	}
+	if offset+limit < total {
+		next := offset + limit
+		resp.NextOffset = &next
+	}
+	writeJSON(w, resp)
+}
+
 func (s *service) handleCreateComment(w http.ResponseWriter, r *http.Request) {
-	enabled, err := s.commentsEnabled(r)
+	blogSettings, err := s.store.GetBlogSettings(r.Context())
 	if err != nil {
 		http.Error(w, "failed to load settings", http.StatusInternalServerError)
 		return
 	}
-	if !enabled {
+	settings := resolveBlogSettings(blogSettings)
+	if !settings.CommentsEnabled {
 		http.Error(w, "comments are disabled", http.StatusForbidden)
 		return
 	}
@@ -91,6 +182,10 @@ func (s *service) handleCreateComment(w http.ResponseWriter, r *http.Request) {
 		http.NotFound(w, r)
 		return
 	}
+	if commentsClosed(settings, post, time.Now()) {
+		http.Error(w, "comments closed", http.StatusForbidden)
+		return
+	}
 
 	var payload createCommentRequest
 	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
@@ -98,6 +193,13 @@ func (s *service) handleCreateComment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if s.cfg.CommentMinSubmitTime > 0 {
+		if err := s.checkCommentFormToken(payload.FormToken, post.ID, time.Now()); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
 	payload.AuthorName = strings.TrimSpace(payload.AuthorName)
 	payload.Content = strings.TrimSpace(payload.Content)
 	if len(payload.AuthorName) < 2 || len(payload.AuthorName) > 60 {
@@ -115,16 +217,39 @@ func (s *service) handleCreateComment(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "failed to load parent", http.StatusInternalServerError)
 			return
 		}
-		if parent == nil || parent.PostID != post.ID || parent.ParentID != nil || parent.Status != "approved" {
+		if parent == nil || parent.PostID != post.ID || parent.Status != "approved" {
 			http.Error(w, "invalid parent comment", http.StatusBadRequest)
 			return
 		}
+		depth, err := s.commentDepth(r.Context(), parent)
+		if err != nil {
+			http.Error(w, "failed to load parent", http.StatusInternalServerError)
+			return
+		}
+		if depth >= s.maxCommentDepth() {
+			http.Error(w, "replies are nested too deeply", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if s.commentLimiter != nil {
+		keys := []string{"ip:" + s.clientIP(r)}
+		if hash := s.ownerTokenHash(r); hash != "" {
+			keys = append(keys, "owner:"+hash)
+		}
+		ok, retryAfter := s.commentLimiter.allow(time.Now(), keys...)
+		if !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			http.Error(w, "too many comments, please try again later", http.StatusTooManyRequests)
+			return
+		}
 	}
 
 	ownerToken := s.ensureOwnerToken(w, r)
 	ownerHash := hashToken(ownerToken)
 
 	comment := Comment{
+		ID:             generateID(),
 		PostID:         post.ID,
 		ParentID:       payload.ParentID,
 		AuthorName:     payload.AuthorName,
@@ -133,33 +258,53 @@ func (s *service) handleCreateComment(w http.ResponseWriter, r *http.Request) {
 		CreatedAt:      time.Now().UTC(),
 	}
 
-	settings, err := s.store.GetAISettings(r.Context())
-	if err == nil && settings != nil && aiProviderConfigured(settings.Dumb) {
+	mode := s.moderationMode(r.Context(), settings)
+	if mode == moderationAuto {
+		comment.Status = "approved"
+	} else {
 		comment.Status = "pending"
 	}
-	if comment.Status == "" {
-		comment.Status = "approved"
+
+	// With SyncSpamCheck the comment is saved with the check's verdict.
+	// Otherwise it is saved pending and checked in the background.
+	checkLater := mode == moderationAI && !settings.SyncSpamCheck
+	if mode == moderationAI && settings.SyncSpamCheck {
+		s.applyCommentSpamCheck(context.WithoutCancel(r.Context()), &comment, *post)
 	}
 
 	if err := s.store.CreateComment(r.Context(), &comment); err != nil {
 		http.Error(w, "failed to save comment", http.StatusInternalServerError)
 		return
 	}
-	go s.notifyAdminsOfNewComment(comment, *post)
+	s.metrics.inc(metricCommentsCreated)
 
-	if comment.Status == "pending" {
-		go s.runCommentSpamCheck(comment, *post)
+	// Admins and webhooks hear about the comment once its status is final.
+	baseCtx := withBaseURL(context.Background(), s.baseURL(r))
+	hookPost := s.webhookPost(r, post)
+	announce := func(comment Comment) {
+		go s.notifyAdminsOfNewComment(comment, *post)
+		go s.emailAdminsOfNewComment(baseCtx, comment, *post)
+		s.queueWebhook(webhookEvent{Event: WebhookEventCommentCreated, Comment: &comment, Post: hookPost})
+	}
+	if checkLater {
+		go func(comment Comment) {
+			s.runCommentSpamCheck(&comment, *post)
+			announce(comment)
+		}(comment)
+	} else {
+		announce(comment)
 	}
 
 	resp := commentResponse{
-		ID:         comment.ID,
-		ParentID:   comment.ParentID,
-		AuthorName: comment.AuthorName,
-		Content:    comment.Content,
-		Status:     comment.Status,
-		CreatedAt:  comment.CreatedAt,
-		UpdatedAt:  comment.UpdatedAt,
-		Owned:      true,
+		ID:          comment.ID,
+		ParentID:    comment.ParentID,
+		AuthorName:  comment.AuthorName,
+		Content:     comment.Content,
+		ContentHTML: renderCommentHTML(comment.Content),
+		Status:      comment.Status,
+		CreatedAt:   comment.CreatedAt,
+		UpdatedAt:   comment.UpdatedAt,
+		Owned:       true,
 	}
 	writeJSON(w, resp)
 }
@@ -185,7 +330,11 @@ func (s *service) handleUpdateComment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	updated, err := s.store.UpdateCommentContentByOwner(r.Context(), id, ownerHash, payload.Content)
+	updated, err := s.store.UpdateCommentContentByOwner(r.Context(), id, ownerHash, payload.Content, s.commentEditWindow())
+	if errors.Is(err, errCommentEditWindowClosed) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
 	if err != nil {
 		http.Error(w, "failed to update comment", http.StatusInternalServerError)
 		return
@@ -197,6 +346,22 @@ func (s *service) handleUpdateComment(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// defaultCommentEditWindow is how long owners may edit a comment when
+// Config.CommentEditWindow is unset.
+const defaultCommentEditWindow = 15 * time.Minute
+
+// commentEditWindow returns how long after posting a comment its owner may
+// edit it, or 0 for no limit.
+func (s *service) commentEditWindow() time.Duration {
+	switch {
+	case s.cfg.CommentEditWindow < 0:
+		return 0
+	case s.cfg.CommentEditWindow == 0:
+		return defaultCommentEditWindow
+	}
+	return s.cfg.CommentEditWindow
+}
+
 func (s *service) handleDeleteComment(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	ownerHash := s.ownerTokenHash(r)
@@ -217,7 +382,39 @@ func (s *service) handleDeleteComment(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
-func buildCommentThread(comments []Comment, ownerHash string) []commentResponse {
+// maxCommentDepth returns Config.MaxCommentDepth, or the default of 2.
+func (s *service) maxCommentDepth() int {
+	if s.cfg.MaxCommentDepth > 0 {
+		return s.cfg.MaxCommentDepth
+	}
+	return defaultMaxCommentDepth
+}
+
+// commentDepth returns how deep c sits in its thread, counting top-level
+// comments as depth 1. The walk stops once it passes the configured maximum,
+// so a corrupted parent chain can't loop forever.
+func (s *service) commentDepth(ctx context.Context, c *Comment) (int, error) {
+	depth := 1
+	limit := s.maxCommentDepth()
+	for c.ParentID != nil && depth <= limit {
+		parent, err := s.store.GetCommentByID(ctx, *c.ParentID)
+		if err != nil {
+			return 0, err
+		}
+		if parent == nil {
+			break
+		}
+		c = parent
+		depth++
+	}
+	return depth, nil
+}
+
+// buildCommentThread nests visible comments under their parents. Top-level
+// threads are returned newest first; replies stay in chronological order.
+// Replies whose parent isn't visible are left out. reactions holds the
+// reactions by comment ID and may be nil.
+func buildCommentThread(comments []Comment, ownerHash string, reactions map[string]commentReactions) []commentResponse {
 	replies := map[string][]commentResponse{}
 	roots := []commentResponse{}
 
@@ -234,14 +431,18 @@ func buildCommentThread(comments []Comment, ownerHash string) []commentResponse
 		}
 
 		resp := commentResponse{
-			ID:         c.ID,
-			ParentID:   c.ParentID,
-			AuthorName: c.AuthorName,
-			Content:    c.Content,
-			Status:     status,
-			CreatedAt:  c.CreatedAt,
-			UpdatedAt:  c.UpdatedAt,
-			Owned:      owned,
+			ID:          c.ID,
+			ParentID:    c.ParentID,
+			AuthorName:  c.AuthorName,
+			Content:     c.Content,
+			ContentHTML: renderCommentHTML(c.Content),
+			Status:      status,
+			CreatedAt:   c.CreatedAt,
+			UpdatedAt:   c.UpdatedAt,
+			Owned:       owned,
+		}
+		if r, ok := reactions[c.ID]; ok {
+			resp.ReactionCount, resp.Reacted = r.Count, r.Reacted
 		}
 
 		if c.ParentID == nil {
@@ -252,10 +453,15 @@ func buildCommentThread(comments []Comment, ownerHash string) []commentResponse
 		replies[*c.ParentID] = append(replies[*c.ParentID], resp)
 	}
 
-	for i := range roots {
-		root := &roots[i]
-		root.Replies = replies[root.ID]
+	var attach func(list []commentResponse)
+	attach = func(list []commentResponse) {
+		for i := range list {
+			children := replies[list[i].ID]
+			attach(children)
+			list[i].Replies = children
+		}
 	}
+	attach(roots)
 
 	// Reverse roots to show newest threads first
 	for i, j := 0, len(roots)-1; i < j; i, j = i+1, j-1 {
@@ -274,46 +480,91 @@ func (s *service) commentsEnabled(r *http.Request) (bool, error) {
 	return resolved.CommentsEnabled, nil
 }
 
-func (s *service) ownerTokenHash(r *http.Request) string {
+// maxOwnerTokenLength bounds the owner cookie value we are willing to parse.
+const maxOwnerTokenLength = 64
+
+// ownerToken returns the commenter's owner token, or "" when the cookie is
+// missing or isn't a UUID in the form generateToken produces.
+func ownerToken(r *http.Request) string {
 	cookie, err := r.Cookie(commentOwnerCookie)
-	if err != nil || strings.TrimSpace(cookie.Value) == "" {
+	if err != nil {
+		return ""
+	}
+	value := cookie.Value
+	if value == "" || len(value) > maxOwnerTokenLength {
+		return ""
+	}
+	parsed, err := uuid.Parse(value)
+	if err != nil || parsed.String() != value {
+		return ""
+	}
+	return value
+}
+
+func (s *service) ownerTokenHash(r *http.Request) string {
+	token := ownerToken(r)
+	if token == "" {
 		return ""
 	}
-	return hashToken(cookie.Value)
+	return hashToken(token)
 }
 
+// ensureOwnerToken returns the request's owner token, issuing a fresh one
+// when the cookie is missing or malformed.
 func (s *service) ensureOwnerToken(w http.ResponseWriter, r *http.Request) string {
-	cookie, err := r.Cookie(commentOwnerCookie)
-	if err == nil && strings.TrimSpace(cookie.Value) != "" {
-		return cookie.Value
+	if token := ownerToken(r); token != "" {
+		return token
 	}
 
 	token := generateToken()
+	s.setOwnerCookie(w, r, token)
+	return token
+}
+
+// setOwnerCookie stores the commenter's owner token for a year.
+func (s *service) setOwnerCookie(w http.ResponseWriter, r *http.Request, token string) {
+	// Cookies set for another origin's page are only sent back
+	// cross-site with SameSite=None, which browsers allow only over HTTPS.
+	secure := r.TLS != nil
+	sameSite := http.SameSiteLaxMode
+	if secure && s.corsOrigin(r) != "" {
+		sameSite = http.SameSiteNoneMode
+	}
 	http.SetCookie(w, &http.Cookie{
 		Name:     commentOwnerCookie,
 		Value:    token,
 		Path:     s.routePrefix,
 		HttpOnly: true,
-		SameSite: http.SameSiteLaxMode,
-		Secure:   r.TLS != nil,
+		SameSite: sameSite,
+		Secure:   secure,
 		MaxAge:   60 * 60 * 24 * 365,
 	})
-	return token
 }
 
-func (s *service) runCommentSpamCheck(comment Comment, post Post) {
+// runCommentSpamCheck checks a saved pending comment and stores the
+// verdict, updating comment to match.
+func (s *service) runCommentSpamCheck(comment *Comment, post Post) {
 	ctx := context.Background()
-	spam, reason, err := s.checkCommentSpam(ctx, comment, post)
-	if err != nil {
-		_ = s.store.UpdateCommentStatus(ctx, comment.ID, "approved", nil)
+	s.applyCommentSpamCheck(ctx, comment, post)
+	_ = s.store.UpdateCommentStatus(ctx, comment.ID, comment.Status, comment.SpamReason)
+}
+
+// applyCommentSpamCheck sets comment's status from the spam check:
+// rejected when the model flags it, approved otherwise, including when the
+// check fails.
+func (s *service) applyCommentSpamCheck(ctx context.Context, comment *Comment, post Post) {
+	now := time.Now().UTC()
+	comment.SpamCheckedAt = &now
+	comment.Status = "approved"
+	comment.SpamReason = nil
+	spam, reason, err := s.checkCommentSpam(ctx, *comment, post)
+	if err != nil || !spam {
 		return
 	}
-	if spam {
-		if strings.TrimSpace(reason) == "" {
-			reason = "flagged as spam"
-		}
-		_ = s.store.UpdateCommentStatus(ctx, comment.ID, "rejected", &reason)
-		return
+	if strings.TrimSpace(reason) == "" {
+		reason = "flagged as spam"
 	}
-	_ = s.store.UpdateCommentStatus(ctx, comment.ID, "approved", nil)
+	s.metrics.inc(metricCommentsSpam)
+	comment.Status = "rejected"
+	comment.SpamReason = &reason
 }
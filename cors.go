@@ -0,0 +1,115 @@
+package blog
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// parseAllowedOrigins parses Config.AllowedOrigins into a set of
+// normalized origins such as "https://app.example.com". A wildcard is
+// rejected, since the comment API sends credentials.
+func parseAllowedOrigins(values []string) (map[string]bool, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	origins := map[string]bool{}
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		origin, ok := normalizeOrigin(v)
+		if !ok {
+			return nil, fmt.Errorf("invalid allowed origin %q", v)
+		}
+		origins[origin] = true
+	}
+	return origins, nil
+}
+
+// normalizeOrigin lowercases a scheme://host[:port] origin and reports
+// whether it is one.
+func normalizeOrigin(v string) (string, bool) {
+	u, err := url.Parse(strings.TrimSuffix(v, "/"))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+		u.Path != "" || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return "", false
+	}
+	return strings.ToLower(u.Scheme + "://" + u.Host), true
+}
+
+// corsOrigin returns the request's Origin header when it is in
+// Config.AllowedOrigins, or "".
+func (s *service) corsOrigin(r *http.Request) string {
+	origin := r.Header.Get("Origin")
+	if len(s.allowedOrigins) == 0 || origin == "" {
+		return ""
+	}
+	if normalized, ok := normalizeOrigin(origin); ok && s.allowedOrigins[normalized] {
+		return origin
+	}
+	return ""
+}
+
+// sameOrigin reports whether the request's Origin header names the blog's
+// own origin, as it does for the blog's pages. The origin is taken from
+// baseURL, so it is Config.SiteURL or the host a trusted proxy forwarded,
+// not the Host the proxy rewrote.
+func (s *service) sameOrigin(r *http.Request) bool {
+	origin, ok := normalizeOrigin(r.Header.Get("Origin"))
+	if !ok {
+		return false
+	}
+	base, err := url.Parse(s.baseURL(r))
+	if err != nil {
+		return false
+	}
+	return origin == strings.ToLower(base.Scheme+"://"+base.Host)
+}
+
+// cors lets pages on Config.AllowedOrigins call the JSON API with the
+// commenter cookie. Requests from other origins get no CORS headers, so
+// browsers keep blocking them. Preflight requests are answered here.
+//
+// The cookie is then sent cross-site, so a request that changes state is
+// refused with 403 when its Origin is neither the blog's own nor on the
+// list. Requests without an Origin, which browsers always send on
+// cross-origin POST, PUT and DELETE requests, are let through.
+func (s *service) cors(next http.Handler) http.Handler {
+	if len(s.allowedOrigins) == 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Origin")
+		origin := s.corsOrigin(r)
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+		default:
+			if origin == "" && r.Header.Get("Origin") != "" && !s.sameOrigin(r) {
+				http.Error(w, "origin not allowed", http.StatusForbidden)
+				return
+			}
+		}
+		if origin != "" {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+		if r.Method != http.MethodOptions || r.Header.Get("Access-Control-Request-Method") == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if origin != "" {
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+			w.Header().Set("Access-Control-Max-Age", "600")
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// handlePreflight gives API routes an OPTIONS route for cors to answer.
+func handlePreflight(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+}
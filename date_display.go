@@ -30,10 +30,90 @@ func resolveBlogSettings(settings *BlogSettings) BlogSettings {
 	resolved := *settings
 	resolved.DateDisplay = normalizeDateDisplay(resolved.DateDisplay)
 	resolved.GoogleAnalyticsCode = strings.TrimSpace(resolved.GoogleAnalyticsCode)
+	resolved.ModerationMode, _ = normalizeModerationMode(resolved.ModerationMode)
+	resolved.CloseCommentsAfterDays = max(resolved.CloseCommentsAfterDays, 0)
 	return resolved
 }
 
-func formatPublishedDate(publishedAt *time.Time, dateDisplay string) string {
+// defaultDateFormat is the layout of absolute dates when Config.DateFormat
+// is unset.
+const defaultDateFormat = "Jan 2, 2006"
+
+// DateLabels localizes the dates shown on public pages. Each field is a
+// fmt format; empty fields keep the English default shown in brackets.
+type DateLabels struct {
+	// Published wraps an absolute date ["Published %s"].
+	Published string
+	// PublishedAgo wraps an approximate age ["Published %s ago"].
+	PublishedAgo string
+	// The units of an approximate age, singular then plural, each taking
+	// the count: ["%d second", "%d seconds"] and so on.
+	Seconds [2]string
+	Minutes [2]string
+	Hours   [2]string
+	Days    [2]string
+	Months  [2]string
+	Years   [2]string
+}
+
+var defaultDateLabels = DateLabels{
+	Published:    "Published %s",
+	PublishedAgo: "Published %s ago",
+	Seconds:      [2]string{"%d second", "%d seconds"},
+	Minutes:      [2]string{"%d minute", "%d minutes"},
+	Hours:        [2]string{"%d hour", "%d hours"},
+	Days:         [2]string{"%d day", "%d days"},
+	Months:       [2]string{"%d month", "%d months"},
+	Years:        [2]string{"%d year", "%d years"},
+}
+
+// withDefaults fills the empty fields of l from defaultDateLabels.
+func (l DateLabels) withDefaults() DateLabels {
+	d := defaultDateLabels
+	if l.Published == "" {
+		l.Published = d.Published
+	}
+	if l.PublishedAgo == "" {
+		l.PublishedAgo = d.PublishedAgo
+	}
+	for _, unit := range []struct{ got, def *[2]string }{
+		{&l.Seconds, &d.Seconds}, {&l.Minutes, &d.Minutes}, {&l.Hours, &d.Hours},
+		{&l.Days, &d.Days}, {&l.Months, &d.Months}, {&l.Years, &d.Years},
+	} {
+		for i := range unit.got {
+			if unit.got[i] == "" {
+				unit.got[i] = unit.def[i]
+			}
+		}
+	}
+	return l
+}
+
+// dateFormatter renders publication dates for the formatPublishedDate
+// template function, following Config.TimeZone, Config.DateFormat and
+// Config.DateLabels.
+type dateFormatter struct {
+	loc    *time.Location // nil keeps each date's own zone
+	layout string
+	labels DateLabels
+}
+
+func newDateFormatter(cfg Config) (*dateFormatter, error) {
+	f := &dateFormatter{layout: cfg.DateFormat, labels: cfg.DateLabels.withDefaults()}
+	if f.layout == "" {
+		f.layout = defaultDateFormat
+	}
+	if tz := strings.TrimSpace(cfg.TimeZone); tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return nil, fmt.Errorf("load time zone: %w", err)
+		}
+		f.loc = loc
+	}
+	return f, nil
+}
+
+func (f *dateFormatter) formatPublishedDate(publishedAt *time.Time, dateDisplay string) string {
 	if publishedAt == nil {
 		return ""
 	}
@@ -43,50 +123,43 @@ func formatPublishedDate(publishedAt *time.Time, dateDisplay string) string {
 		if delta < 0 {
 			delta = -delta
 		}
-		return fmt.Sprintf("Published %s ago", humanizeApproxDuration(delta))
+		return fmt.Sprintf(f.labels.PublishedAgo, f.labels.humanizeApproxDuration(delta))
 	}
-	return fmt.Sprintf("Published %s", publishedAt.Format("Jan 2, 2006"))
+	t := *publishedAt
+	if f.loc != nil {
+		t = t.In(f.loc)
+	}
+	return fmt.Sprintf(f.labels.Published, t.Format(f.layout))
 }
 
-func humanizeApproxDuration(delta time.Duration) string {
+// humanizeApproxDuration renders delta as a count of its largest whole
+// unit, such as "3 days".
+func (l DateLabels) humanizeApproxDuration(delta time.Duration) string {
+	plural := func(n int, unit [2]string) string {
+		if n <= 1 {
+			return fmt.Sprintf(unit[0], 1)
+		}
+		return fmt.Sprintf(unit[1], n)
+	}
 	seconds := int(delta.Seconds())
 	if seconds < 60 {
-		if seconds <= 1 {
-			return "1 second"
-		}
-		return fmt.Sprintf("%d seconds", seconds)
+		return plural(seconds, l.Seconds)
 	}
 	minutes := int(delta.Minutes())
 	if minutes < 60 {
-		if minutes == 1 {
-			return "1 minute"
-		}
-		return fmt.Sprintf("%d minutes", minutes)
+		return plural(minutes, l.Minutes)
 	}
 	hours := int(delta.Hours())
 	if hours < 24 {
-		if hours == 1 {
-			return "1 hour"
-		}
-		return fmt.Sprintf("%d hours", hours)
+		return plural(hours, l.Hours)
 	}
 	days := hours / 24
 	if days < 30 {
-		if days == 1 {
-			return "1 day"
-		}
-		return fmt.Sprintf("%d days", days)
+		return plural(days, l.Days)
 	}
 	months := days / 30
 	if months < 12 {
-		if months == 1 {
-			return "1 month"
-		}
-		return fmt.Sprintf("%d months", months)
-	}
-	years := days / 365
-	if years <= 1 {
-		return "1 year"
+		return plural(months, l.Months)
 	}
-	return fmt.Sprintf("%d years", years)
+	return plural(days/365, l.Years)
 }
@@ -0,0 +1,184 @@
+package blog
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EmailNotifier delivers new-comment alerts by email. Set Config.SMTP to use
+// the built-in SMTP implementation, or Config.EmailNotifier to supply your
+// own.
+type EmailNotifier interface {
+	// NotifyNewComment is called in the background after a comment is
+	// saved. Errors are logged and never affect the comment request.
+	NotifyNewComment(ctx context.Context, comment Comment, post Post) error
+}
+
+// SMTPConfig configures the built-in SMTP email notifier.
+type SMTPConfig struct {
+	Host string
+	// Port defaults to 587. The connection is upgraded with STARTTLS when
+	// the server offers it.
+	Port int
+	// Username and Password enable PLAIN authentication when set.
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+// smtpNotifier is the EmailNotifier built from Config.SMTP.
+type smtpNotifier struct {
+	cfg SMTPConfig
+	// siteURL and moderationPath form the moderation link. When siteURL is
+	// empty the base URL of the comment request, carried in ctx, is used.
+	siteURL        string
+	moderationPath string
+	send           func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func newSMTPNotifier(cfg SMTPConfig, siteURL, routePrefix string) *smtpNotifier {
+	return &smtpNotifier{
+		cfg:            cfg,
+		siteURL:        strings.TrimSuffix(strings.TrimSpace(siteURL), "/"),
+		moderationPath: routePrefix + "/admin?view=comments",
+		send:           sendMail,
+	}
+}
+
+// smtpTimeout bounds connecting to the SMTP server and the whole exchange
+// after that, so a server that stops responding can't hang the sender.
+const smtpTimeout = 30 * time.Second
+
+// sendMail is smtp.SendMail with a timeout: it dials with smtpTimeout and
+// sets a deadline on the connection, since smtp.SendMail has neither.
+func sendMail(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+	for _, mailbox := range append([]string{from}, to...) {
+		if strings.ContainsAny(mailbox, "\r\n") {
+			return errors.New("smtp: address contains CR or LF")
+		}
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	conn, err := (&net.Dialer{Timeout: smtpTimeout}).Dial("tcp", addr)
+	if err != nil {
+		return err
+	}
+	if err := conn.SetDeadline(time.Now().Add(smtpTimeout)); err != nil {
+		conn.Close()
+		return err
+	}
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if a != nil {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("smtp: server doesn't support AUTH")
+		}
+		if err := c.Auth(a); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(from); err != nil {
+		return err
+	}
+	for _, mailbox := range to {
+		if err := c.Rcpt(mailbox); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+func (n *smtpNotifier) NotifyNewComment(ctx context.Context, comment Comment, post Post) error {
+	if n.cfg.Host == "" || n.cfg.From == "" || len(n.cfg.To) == 0 {
+		return fmt.Errorf("smtp notifier requires host, from and to")
+	}
+	port := n.cfg.Port
+	if port == 0 {
+		port = 587
+	}
+	var auth smtp.Auth
+	if n.cfg.Username != "" {
+		auth = smtp.PlainAuth("", n.cfg.Username, n.cfg.Password, n.cfg.Host)
+	}
+
+	base := n.siteURL
+	if base == "" {
+		base = baseURLFromContext(ctx)
+	}
+	msg := commentEmailMessage(n.cfg.From, n.cfg.To, comment, post, base+n.moderationPath, time.Now())
+	addr := net.JoinHostPort(n.cfg.Host, strconv.Itoa(port))
+	return n.send(addr, auth, n.cfg.From, n.cfg.To, msg)
+}
+
+// commentEmailMessage builds a plain-text RFC 5322 message for a new comment.
+func commentEmailMessage(from string, to []string, comment Comment, post Post, moderationURL string, now time.Time) []byte {
+	subject := fmt.Sprintf("New comment on %q", post.Title)
+	switch comment.Status {
+	case "pending":
+		subject = fmt.Sprintf("New comment awaiting moderation on %q", post.Title)
+	case "rejected":
+		subject = fmt.Sprintf("New comment rejected as spam on %q", post.Title)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", headerValue(from))
+	fmt.Fprintf(&b, "To: %s\r\n", headerValue(strings.Join(to, ", ")))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", headerValue(subject)))
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	fmt.Fprintf(&b, "%s commented on \"%s\":\r\n\r\n", comment.AuthorName, post.Title)
+	for _, line := range strings.Split(comment.Content, "\n") {
+		fmt.Fprintf(&b, "> %s\r\n", strings.TrimRight(line, "\r"))
+	}
+	fmt.Fprintf(&b, "\r\nModerate comments: %s\r\n", moderationURL)
+	return []byte(b.String())
+}
+
+// headerValue strips line breaks so user-supplied text can't inject headers.
+func headerValue(s string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(s)
+}
+
+// emailAdminsOfNewComment sends the new-comment email, if a notifier is
+// configured, logging any failure.
+func (s *service) emailAdminsOfNewComment(ctx context.Context, comment Comment, post Post) {
+	if s.email == nil {
+		return
+	}
+	if err := s.email.NotifyNewComment(ctx, comment, post); err != nil {
+		s.logf("spore email notification failed for comment %s: %v", comment.ID, err)
+	}
+}
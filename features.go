@@ -0,0 +1,46 @@
+package blog
+
+import (
+	"context"
+	"net/http"
+)
+
+// Feature flag names understood by the built-in handlers.
+const (
+	// FeatureTableOfContents shows a table of contents above posts.
+	// Defaults to Config.TableOfContents.
+	FeatureTableOfContents = "toc"
+	// FeatureRelatedPosts shows the "Read Next" section on posts. Defaults
+	// to on.
+	FeatureRelatedPosts = "related_posts"
+	// FeatureMoreByAuthor shows other posts by the post's author. Defaults
+	// to Config.ShowMoreByAuthor.
+	FeatureMoreByAuthor = "more_by_author"
+)
+
+type featureFlagsKey struct{}
+
+// FeatureEnabled reports whether the named feature is on for the request
+// that ctx belongs to. Flags from Config.FeatureFlags override the
+// defaults from Config. Outside a blog request every feature is off.
+func FeatureEnabled(ctx context.Context, name string) bool {
+	flags, _ := ctx.Value(featureFlagsKey{}).(map[string]bool)
+	return flags[name]
+}
+
+// featureFlags stores the request's resolved feature flags in its context.
+func (s *service) featureFlags(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flags := map[string]bool{
+			FeatureTableOfContents: s.cfg.TableOfContents,
+			FeatureRelatedPosts:    true,
+			FeatureMoreByAuthor:    s.cfg.ShowMoreByAuthor,
+		}
+		if s.cfg.FeatureFlags != nil {
+			for name, on := range s.cfg.FeatureFlags(r) {
+				flags[name] = on
+			}
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), featureFlagsKey{}, flags)))
+	})
+}
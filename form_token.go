@@ -0,0 +1,91 @@
+package blog
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// defaultCommentFormTokenMaxAge bounds how long a comment form stays valid
+// when Config.CommentFormTokenMaxAge is unset.
+const defaultCommentFormTokenMaxAge = 24 * time.Hour
+
+var (
+	errFormTokenInvalid = errors.New("invalid form token")
+	errFormTooFast      = errors.New("comment submitted too quickly")
+	errFormTokenExpired = errors.New("form expired, please reload the page")
+)
+
+// signingKey returns Config.SecretKey, or a random per-process key when it
+// is unset. Tokens signed with a random key stop validating on restart.
+func signingKey(cfg Config) []byte {
+	if cfg.SecretKey != "" {
+		return []byte(cfg.SecretKey)
+	}
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+	return key
+}
+
+// signValue returns a hex HMAC-SHA256 of the given parts under the service key.
+func (s *service) signValue(parts ...string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// commentFormToken returns a token recording when the comment form for
+// postID was served: "<unix seconds>.<signature>".
+func (s *service) commentFormToken(postID string, now time.Time) string {
+	ts := strconv.FormatInt(now.Unix(), 10)
+	return ts + "." + s.signValue("comment-form", postID, ts)
+}
+
+// checkCommentFormToken verifies a token for postID and rejects submissions
+// made sooner than CommentMinSubmitTime after the form was served, or later
+// than CommentFormTokenMaxAge.
+func (s *service) checkCommentFormToken(token, postID string, now time.Time) error {
+	ts, sig, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(sig), []byte(s.signValue("comment-form", postID, ts))) {
+		return errFormTokenInvalid
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return errFormTokenInvalid
+	}
+	age := now.Sub(time.Unix(unix, 0))
+	if age < s.cfg.CommentMinSubmitTime {
+		return errFormTooFast
+	}
+	maxAge := s.cfg.CommentFormTokenMaxAge
+	if maxAge <= 0 {
+		maxAge = defaultCommentFormTokenMaxAge
+	}
+	if age > maxAge {
+		return errFormTokenExpired
+	}
+	return nil
+}
+
+// handleCommentFormToken issues a signed timestamp for the comment form.
+func (s *service) handleCommentFormToken(w http.ResponseWriter, r *http.Request) {
+	post, err := s.store.GetPublishedPostBySlug(r.Context(), chi.URLParam(r, "slug"))
+	if err != nil {
+		http.Error(w, "failed to load post", http.StatusInternalServerError)
+		return
+	}
+	if post == nil {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, map[string]string{"token": s.commentFormToken(post.ID, time.Now())})
+}
@@ -61,7 +61,7 @@ ${e}</tr>
 `}strong(e){return`<strong>${e}</strong>`}em(e){return`<em>${e}</em>`}codespan(e){return`<code>${e}</code>`}br(){return"<br>"}del(e){return`<del>${e}</del>`}link(e,t,i){const n=LB(e);if(n===null)return i;e=n;let o='<a href="'+e+'"';return t&&(o+=' title="'+t+'"'),o+=">"+i+"</a>",o}image(e,t,i){const n=LB(e);if(n===null)return i;e=n;let o=`<img src="${e}" alt="${i}"`;return t&&(o+=` title="${t}"`),o+=">",o}text(e){return e}}class lF{strong(e){return e}em(e){return e}codespan(e){return e}del(e){return e}html(e){return e}text(e){return e}link(e,t,i){return""+i}image(e,t,i){return""+i}br(){return""}}class Td{constructor(e){In(this,"options");In(this,"renderer");In(this,"textRenderer");this.options=e||Rp,this.options.renderer=this.options.renderer||new TL,this.renderer=this.options.renderer,this.renderer.options=this.options,this.textRenderer=new lF}static parse(e,t){return new Td(t).parse(e)}static parseInline(e,t){return new Td(t).parseInline(e)}parse(e,t=!0){let i="";for(let n=0;n<e.length;n++){const o=e[n];if(this.options.extensions&&this.options.extensions.renderers&&this.options.extensions.renderers[o.type]){const r=o,a=this.options.extensions.renderers[r.type].call({parser:this},r);if(a!==!1||!["space","hr","heading","code","table","blockquote","list","html","paragraph","text"].includes(r.type)){i+=a||"";continue}}switch(o.type){case"space":continue;case"hr":{i+=this.renderer.hr();continue}case"heading":{const r=o;i+=this.renderer.heading(this.parseInline(r.tokens),r.depth,IJ(this.parseInline(r.tokens,this.textRenderer)));continue}case"code":{const r=o;i+=this.renderer.code(r.text,r.lang,!!r.escaped);continue}case"table":{const r=o;let a="",l="";for(let c=0;c<r.header.length;c++)l+=this.renderer.tablecell(this.parseInline(r.header[c].tokens),{header:!0,align:r.align[c]});a+=this.renderer.tablerow(l);let d="";for(let c=0;c<r.rows.length;c++){const u=r.rows[c];l="";for(let h=0;h<u.length;h++)l+=this.renderer.tablecell(this.parseInline(u[h].tokens),{header:!1,align:r.align[h]});d+=this.renderer.tablerow(l)}i+=this.renderer.table(a,d);continue}case"blockquote":{const r=o,a=this.parse(r.tokens);i+=this.renderer.blockquote(a);continue}case"list":{const r=o,a=r.ordered,l=r.start,d=r.loose;let c="";for(let u=0;u<r.items.length;u++){const h=r.items[u],f=h.checked,g=h.task;let m="";if(h.task){const _=this.renderer.checkbox(!!f);d?h.tokens.length>0&&h.tokens[0].type==="paragraph"?(h.tokens[0].text=_+" "+h.tokens[0].text,h.tokens[0].tokens&&h.tokens[0].tokens.length>0&&h.tokens[0].tokens[0].type==="text"&&(h.tokens[0].tokens[0].text=_+" "+h.tokens[0].tokens[0].text)):h.tokens.unshift({type:"text",text:_+" "}):m+=_+" "}m+=this.parse(h.tokens,d),c+=this.renderer.listitem(m,g,!!f)}i+=this.renderer.list(c,a,l);continue}case"html":{const r=o;i+=this.renderer.html(r.text,r.block);continue}case"paragraph":{const r=o;i+=this.renderer.paragraph(this.parseInline(r.tokens));continue}case"text":{let r=o,a=r.tokens?this.parseInline(r.tokens):r.text;for(;n+1<e.length&&e[n+1].type==="text";)r=e[++n],a+=`
 `+(r.tokens?this.parseInline(r.tokens):r.text);i+=t?this.renderer.paragraph(a):a;continue}default:{const r='Token with "'+o.type+'" type was not found.';if(this.options.silent)return console.error(r),"";throw new Error(r)}}}return i}parseInline(e,t){t=t||this.renderer;let i="";for(let n=0;n<e.length;n++){const o=e[n];if(this.options.extensions&&this.options.extensions.renderers&&this.options.extensions.renderers[o.type]){const r=this.options.extensions.renderers[o.type].call({parser:this},o);if(r!==!1||!["escape","html","link","image","strong","em","codespan","br","del","text"].includes(o.type)){i+=r||"";continue}}switch(o.type){case"escape":{const r=o;i+=t.text(r.text);break}case"html":{const r=o;i+=t.html(r.text);break}case"link":{const r=o;i+=t.link(r.href,r.title,this.parseInline(r.tokens,t));break}case"image":{const r=o;i+=t.image(r.href,r.title,r.text);break}case"strong":{const r=o;i+=t.strong(this.parseInline(r.tokens,t));break}case"em":{const r=o;i+=t.em(this.parseInline(r.tokens,t));break}case"codespan":{const r=o;i+=t.codespan(r.text);break}case"br":{i+=t.br();break}case"del":{const r=o;i+=t.del(this.parseInline(r.tokens,t));break}case"text":{const r=o;i+=t.text(r.text);break}default:{const r='Token with "'+o.type+'" type was not found.';if(this.options.silent)return console.error(r),"";throw new Error(r)}}}return i}}class S0{constructor(e){In(this,"options");this.options=e||Rp}preprocess(e){return e}postprocess(e){return e}processAllTokens(e){return e}}In(S0,"passThroughHooks",new Set(["preprocess","postprocess","processAllTokens"]));var Tp,sM,Sz;class see{constructor(...e){F3(this,Tp);In(this,"defaults",iF());In(this,"options",this.setOptions);In(this,"parse",Zw(this,Tp,sM).call(this,Nd.lex,Td.parse));In(this,"parseInline",Zw(this,Tp,sM).call(this,Nd.lexInline,Td.parseInline));In(this,"Parser",Td);In(this,"Renderer",TL);In(this,"TextRenderer",lF);In(this,"Lexer",Nd);In(this,"Tokenizer",EL);In(this,"Hooks",S0);this.use(...e)}walkTokens(e,t){var n,o;let i=[];for(const r of e)switch(i=i.concat(t.call(this,r)),r.type){case"table":{const a=r;for(const l of a.header)i=i.concat(this.walkTokens(l.tokens,t));for(const l of a.rows)for(const d of l)i=i.concat(this.walkTokens(d.tokens,t));break}case"list":{const a=r;i=i.concat(this.walkTokens(a.items,t));break}default:{const a=r;(o=(n=this.defaults.extensions)==null?void 0:n.childTokens)!=null&&o[a.type]?this.defaults.extensions.childTokens[a.type].forEach(l=>{const d=a[l].flat(1/0);i=i.concat(this.walkTokens(d,t))}):a.tokens&&(i=i.concat(this.walkTokens(a.tokens,t)))}}return i}use(...e){const t=this.defaults.extensions||{renderers:{},childTokens:{}};return e.forEach(i=>{const n={...i};if(n.async=this.defaults.async||n.async||!1,i.extensions&&(i.extensions.forEach(o=>{if(!o.name)throw new Error("extension name required");if("renderer"in o){const r=t.renderers[o.name];r?t.renderers[o.name]=function(...a){let l=o.renderer.apply(this,a);return l===!1&&(l=r.apply(this,a)),l}:t.renderers[o.name]=o.renderer}if("tokenizer"in o){if(!o.level||o.level!=="block"&&o.level!=="inline")throw new Error("extension level must be 'block' or 'inline'");const r=t[o.level];r?r.unshift(o.tokenizer):t[o.level]=[o.tokenizer],o.start&&(o.level==="block"?t.startBlock?t.startBlock.push(o.start):t.startBlock=[o.start]:o.level==="inline"&&(t.startInline?t.startInline.push(o.start):t.startInline=[o.start]))}"childTokens"in o&&o.childTokens&&(t.childTokens[o.name]=o.childTokens)}),n.extensions=t),i.renderer){const o=this.defaults.renderer||new TL(this.defaults);for(const r in i.renderer){if(!(r in o))throw new Error(`renderer '${r}' does not exist`);if(r==="options")continue;const a=r,l=i.renderer[a],d=o[a];o[a]=(...c)=>{let u=l.apply(o,c);return u===!1&&(u=d.apply(o,c)),u||""}}n.renderer=o}if(i.tokenizer){const o=this.defaults.tokenizer||new EL(this.defaults);for(const r in i.tokenizer){if(!(r in o))throw new Error(`tokenizer '${r}' does not exist`);if(["options","rules","lexer"].includes(r))continue;const a=r,l=i.tokenizer[a],d=o[a];o[a]=(...c)=>{let u=l.apply(o,c);return u===!1&&(u=d.apply(o,c)),u}}n.tokenizer=o}if(i.hooks){const o=this.defaults.hooks||new S0;for(const r in i.hooks){if(!(r in o))throw new Error(`hook '${r}' does not exist`);if(r==="options")continue;const a=r,l=i.hooks[a],d=o[a];S0.passThroughHooks.has(r)?o[a]=c=>{if(this.defaults.async)return Promise.resolve(l.call(o,c)).then(h=>d.call(o,h));const u=l.call(o,c);return d.call(o,u)}:o[a]=(...c)=>{let u=l.apply(o,c);return u===!1&&(u=d.apply(o,c)),u}}n.hooks=o}if(i.walkTokens){const o=this.defaults.walkTokens,r=i.walkTokens;n.walkTokens=function(a){let l=[];return l.push(r.call(this,a)),o&&(l=l.concat(o.call(this,a))),l}}this.defaults={...this.defaults,...n}}),this}setOptions(e){return this.defaults={...this.defaults,...e},this}lexer(e,t){return Nd.lex(e,t??this.defaults)}parser(e,t){return Td.parse(e,t??this.defaults)}}Tp=new WeakSet,sM=function(e,t){return(i,n)=>{const o={...n},r={...this.defaults,...o};this.defaults.async===!0&&o.async===!1&&(r.silent||console.warn("marked(): The async option was set to true by an extension. The async: false option sent to parse will be ignored."),r.async=!0);const a=Zw(this,Tp,Sz).call(this,!!r.silent,!!r.async);if(typeof i>"u"||i===null)return a(new Error("marked(): input parameter is undefined or null"));if(typeof i!="string")return a(new Error("marked(): input parameter is of type "+Object.prototype.toString.call(i)+", string expected"));if(r.hooks&&(r.hooks.options=r),r.async)return Promise.resolve(r.hooks?r.hooks.preprocess(i):i).then(l=>e(l,r)).then(l=>r.hooks?r.hooks.processAllTokens(l):l).then(l=>r.walkTokens?Promise.all(this.walkTokens(l,r.walkTokens)).then(()=>l):l).then(l=>t(l,r)).then(l=>r.hooks?r.hooks.postprocess(l):l).catch(a);try{r.hooks&&(i=r.hooks.preprocess(i));let l=e(i,r);r.hooks&&(l=r.hooks.processAllTokens(l)),r.walkTokens&&this.walkTokens(l,r.walkTokens);let d=t(l,r);return r.hooks&&(d=r.hooks.postprocess(d)),d}catch(l){return a(l)}}},Sz=function(e,t){return i=>{if(i.message+=`
 Please report this to https://github.com/markedjs/marked.`,e){const n="<p>An error occurred:</p><pre>"+Xr(i.message+"",!0)+"</pre>";return t?Promise.resolve(n):n}if(t)return Promise.reject(i);throw i}};const Xg=new see;function Hi(s,e){return Xg.parse(s,e)}Hi.options=Hi.setOptions=function(s){return Xg.setOptions(s),Hi.defaults=Xg.defaults,hz(Hi.defaults),Hi};Hi.getDefaults=iF;Hi.defaults=Rp;Hi.use=function(...s){return Xg.use(...s),Hi.defaults=Xg.defaults,hz(Hi.defaults),Hi};Hi.walkTokens=function(s,e){return Xg.walkTokens(s,e)};Hi.parseInline=Xg.parseInline;Hi.Parser=Td;Hi.parser=Td.parse;Hi.Renderer=TL;Hi.TextRenderer=lF;Hi.Lexer=Nd;Hi.lexer=Nd.lex;Hi.Tokenizer=EL;Hi.Hooks=S0;Hi.parse=Hi;Hi.options;Hi.setOptions;Hi.use;Hi.walkTokens;Hi.parseInline;Td.parse;Nd.lex;/*! @license DOMPurify 3.3.1 | (c) Cure53 and other contributors | Released under the Apache license 2.0 and Mozilla Public License 2.0 | github.com/cure53/DOMPurify/blob/3.3.1/LICENSE */const{entries:yz,setPrototypeOf:IB,isFrozen:oee,getPrototypeOf:ree,getOwnPropertyDescriptor:aee}=Object;let{freeze:ar,seal:Ka,create:oM}=Object,{apply:rM,construct:aM}=typeof Reflect<"u"&&Reflect;ar||(ar=function(e){return e});Ka||(Ka=function(e){return e});rM||(rM=function(e,t){for(var i=arguments.length,n=new Array(i>2?i-2:0),o=2;o<i;o++)n[o-2]=arguments[o];return e.apply(t,n)});aM||(aM=function(e){for(var t=arguments.length,i=new Array(t>1?t-1:0),n=1;n<t;n++)i[n-1]=arguments[n];return new e(...i)});const sS=lr(Array.prototype.forEach),lee=lr(Array.prototype.lastIndexOf),EB=lr(Array.prototype.pop),ob=lr(Array.prototype.push),dee=lr(Array.prototype.splice),Py=lr(String.prototype.toLowerCase),EE=lr(String.prototype.toString),NE=lr(String.prototype.match),rb=lr(String.prototype.replace),cee=lr(String.prototype.indexOf),uee=lr(String.prototype.trim),rl=lr(Object.prototype.hasOwnProperty),qo=lr(RegExp.prototype.test),ab=hee(TypeError);function lr(s){return function(e){e instanceof RegExp&&(e.lastIndex=0);for(var t=arguments.length,i=new Array(t>1?t-1:0),n=1;n<t;n++)i[n-1]=arguments[n];return rM(s,e,i)}}function hee(s){return function(){for(var e=arguments.length,t=new Array(e),i=0;i<e;i++)t[i]=arguments[i];return aM(s,t)}}function ui(s,e){let t=arguments.length>2&&arguments[2]!==void 0?arguments[2]:Py;IB&&IB(s,null);let i=e.length;for(;i--;){let n=e[i];if(typeof n=="string"){const o=t(n);o!==n&&(oee(e)||(e[i]=o),n=o)}s[n]=!0}return s}function fee(s){for(let e=0;e<s.length;e++)rl(s,e)||(s[e]=null);return s}function ad(s){const e=oM(null);for(const[t,i]of yz(s))rl(s,t)&&(Array.isArray(i)?e[t]=fee(i):i&&typeof i=="object"&&i.constructor===Object?e[t]=ad(i):e[t]=i);return e}function lb(s,e){for(;s!==null;){const i=aee(s,e);if(i){if(i.get)return lr(i.get);if(typeof i.value=="function")return lr(i.value)}s=ree(s)}function t(){return null}return t}const NB=ar(["a","abbr","acronym","address","area","article","aside","audio","b","bdi","bdo","big","blink","blockquote","body","br","button","canvas","caption","center","cite","code","col","colgroup","content","data","datalist","dd","decorator","del","details","dfn","dialog","dir","div","dl","dt","element","em","fieldset","figcaption","figure","font","footer","form","h1","h2","h3","h4","h5","h6","head","header","hgroup","hr","html","i","img","input","ins","kbd","label","legend","li","main","map","mark","marquee","menu","menuitem","meter","nav","nobr","ol","optgroup","option","output","p","picture","pre","progress","q","rp","rt","ruby","s","samp","search","section","select","shadow","slot","small","source","spacer","span","strike","strong","style","sub","summary","sup","table","tbody","td","template","textarea","tfoot","th","thead","time","tr","track","tt","u","ul","var","video","wbr"]),TE=ar(["svg","a","altglyph","altglyphdef","altglyphitem","animatecolor","animatemotion","animatetransform","circle","clippath","defs","desc","ellipse","enterkeyhint","exportparts","filter","font","g","glyph","glyphref","hkern","image","inputmode","line","lineargradient","marker","mask","metadata","mpath","part","path","pattern","polygon","polyline","radialgradient","rect","stop","style","switch","symbol","text","textpath","title","tref","tspan","view","vkern"]),ME=ar(["feBlend","feColorMatrix","feComponentTransfer","feComposite","feConvolveMatrix","feDiffuseLighting","feDisplacementMap","feDistantLight","feDropShadow","feFlood","feFuncA","feFuncB","feFuncG","feFuncR","feGaussianBlur","feImage","feMerge","feMergeNode","feMorphology","feOffset","fePointLight","feSpecularLighting","feSpotLight","feTile","feTurbulence"]),gee=ar(["animate","color-profile","cursor","discard","font-face","font-face-format","font-face-name","font-face-src","font-face-uri","foreignobject","hatch","hatchpath","mesh","meshgradient","meshpatch","meshrow","missing-glyph","script","set","solidcolor","unknown","use"]),RE=ar(["math","menclose","merror","mfenced","mfrac","mglyph","mi","mlabeledtr","mmultiscripts","mn","mo","mover","mpadded","mphantom","mroot","mrow","ms","mspace","msqrt","mstyle","msub","msup","msubsup","mtable","mtd","mtext","mtr","munder","munderover","mprescripts"]),pee=ar(["maction","maligngroup","malignmark","mlongdiv","mscarries","mscarry","msgroup","mstack","msline","msrow","semantics","annotation","annotation-xml","mprescripts","none"]),TB=ar(["#text"]),MB=ar(["accept","action","align","alt","autocapitalize","autocomplete","autopictureinpicture","autoplay","background","bgcolor","border","capture","cellpadding","cellspacing","checked","cite","class","clear","color","cols","colspan","controls","controlslist","coords","crossorigin","datetime","decoding","default","dir","disabled","disablepictureinpicture","disableremoteplayback","download","draggable","enctype","enterkeyhint","exportparts","face","for","headers","height","hidden","high","href","hreflang","id","inert","inputmode","integrity","ismap","kind","label","lang","list","loading","loop","low","max","maxlength","media","method","min","minlength","multiple","muted","name","nonce","noshade","novalidate","nowrap","open","optimum","part","pattern","placeholder","playsinline","popover","popovertarget","popovertargetaction","poster","preload","pubdate","radiogroup","readonly","rel","required","rev","reversed","role","rows","rowspan","spellcheck","scope","selected","shape","size","sizes","slot","span","srclang","start","src","srcset","step","style","summary","tabindex","title","translate","type","usemap","valign","value","width","wrap","xmlns","slot"]),AE=ar(["accent-height","accumulate","additive","alignment-baseline","amplitude","ascent","attributename","attributetype","azimuth","basefrequency","baseline-shift","begin","bias","by","class","clip","clippathunits","clip-path","clip-rule","color","color-interpolation","color-interpolation-filters","color-profile","color-rendering","cx","cy","d","dx","dy","diffuseconstant","direction","display","divisor","dur","edgemode","elevation","end","exponent","fill","fill-opacity","fill-rule","filter","filterunits","flood-color","flood-opacity","font-family","font-size","font-size-adjust","font-stretch","font-style","font-variant","font-weight","fx","fy","g1","g2","glyph-name","glyphref","gradientunits","gradienttransform","height","href","id","image-rendering","in","in2","intercept","k","k1","k2","k3","k4","kerning","keypoints","keysplines","keytimes","lang","lengthadjust","letter-spacing","kernelmatrix","kernelunitlength","lighting-color","local","marker-end","marker-mid","marker-start","markerheight","markerunits","markerwidth","maskcontentunits","maskunits","max","mask","mask-type","media","method","mode","min","name","numoctaves","offset","operator","opacity","order","orient","orientation","origin","overflow","paint-order","path","pathlength","patterncontentunits","patterntransform","patternunits","points","preservealpha","preserveaspectratio","primitiveunits","r","rx","ry","radius","refx","refy","repeatcount","repeatdur","restart","result","rotate","scale","seed","shape-rendering","slope","specularconstant","specularexponent","spreadmethod","startoffset","stddeviation","stitchtiles","stop-color","stop-opacity","stroke-dasharray","stroke-dashoffset","stroke-linecap","stroke-linejoin","stroke-miterlimit","stroke-opacity","stroke","stroke-width","style","surfacescale","systemlanguage","tabindex","tablevalues","targetx","targety","transform","transform-origin","text-anchor","text-decoration","text-rendering","textlength","type","u1","u2","unicode","values","viewbox","visibility","version","vert-adv-y","vert-origin-x","vert-origin-y","width","word-spacing","wrap","writing-mode","xchannelselector","ychannelselector","x","x1","x2","xmlns","y","y1","y2","z","zoomandpan"]),RB=ar(["accent","accentunder","align","bevelled","close","columnsalign","columnlines","columnspan","denomalign","depth","dir","display","displaystyle","encoding","fence","frame","height","href","id","largeop","length","linethickness","lspace","lquote","mathbackground","mathcolor","mathsize","mathvariant","maxsize","minsize","movablelimits","notation","numalign","open","rowalign","rowlines","rowspacing","rowspan","rspace","rquote","scriptlevel","scriptminsize","scriptsizemultiplier","selection","separator","separators","stretchy","subscriptshift","supscriptshift","symmetric","voffset","width","xmlns"]),oS=ar(["xlink:href","xml:id","xlink:title","xml:space","xmlns:xlink"]),mee=Ka(/\{\{[\w\W]*|[\w\W]*\}\}/gm),_ee=Ka(/<%[\w\W]*|[\w\W]*%>/gm),vee=Ka(/\$\{[\w\W]*/gm),bee=Ka(/^data-[\-\w.\u00B7-\uFFFF]+$/),Cee=Ka(/^aria-[\-\w]+$/),Lz=Ka(/^(?:(?:(?:f|ht)tps?|mailto|tel|callto|sms|cid|xmpp|matrix):|[^a-z]|[a-z+.\-]+(?:[^a-z+.\-:]|$))/i),wee=Ka(/^(?:\w+script|data):/i),See=Ka(/[\u0000-\u0020\u00A0\u1680\u180E\u2000-\u2029\u205F\u3000]/g),xz=Ka(/^html$/i),yee=Ka(/^[a-z][.\w]*(-[.\w]+)+$/i);var AB=Object.freeze({__proto__:null,ARIA_ATTR:Cee,ATTR_WHITESPACE:See,CUSTOM_ELEMENT:yee,DATA_ATTR:bee,DOCTYPE_NAME:xz,ERB_EXPR:_ee,IS_ALLOWED_URI:Lz,IS_SCRIPT_OR_DATA:wee,MUSTACHE_EXPR:mee,TMPLIT_EXPR:vee});const db={element:1,text:3,progressingInstruction:7,comment:8,document:9},Lee=function(){return typeof window>"u"?null:window},xee=function(e,t){if(typeof e!="object"||typeof e.createPolicy!="function")return null;let i=null;const n="data-tt-policy-suffix";t&&t.hasAttribute(n)&&(i=t.getAttribute(n));const o="dompurify"+(i?"#"+i:"");try{return e.createPolicy(o,{createHTML(r){return r},createScriptURL(r){return r}})}catch{return console.warn("TrustedTypes policy "+o+" could not be created."),null}},PB=function(){return{afterSanitizeAttributes:[],afterSanitizeElements:[],afterSanitizeShadowDOM:[],beforeSanitizeAttributes:[],beforeSanitizeElements:[],beforeSanitizeShadowDOM:[],uponSanitizeAttribute:[],uponSanitizeElement:[],uponSanitizeShadowNode:[]}};function Dz(){let s=arguments.length>0&&arguments[0]!==void 0?arguments[0]:Lee();const e=Ye=>Dz(Ye);if(e.version="3.3.1",e.removed=[],!s||!s.document||s.document.nodeType!==db.document||!s.Element)return e.isSupported=!1,e;let{document:t}=s;const i=t,n=i.currentScript,{DocumentFragment:o,HTMLTemplateElement:r,Node:a,Element:l,NodeFilter:d,NamedNodeMap:c=s.NamedNodeMap||s.MozNamedAttrMap,HTMLFormElement:u,DOMParser:h,trustedTypes:f}=s,g=l.prototype,m=lb(g,"cloneNode"),_=lb(g,"remove"),v=lb(g,"nextSibling"),b=lb(g,"childNodes"),C=lb(g,"parentNode");if(typeof r=="function"){const Ye=t.createElement("template");Ye.content&&Ye.content.ownerDocument&&(t=Ye.content.ownerDocument)}let w,S="";const{implementation:L,createNodeIterator:D,createDocumentFragment:I,getElementsByTagName:T}=t,{importNode:V}=i;let P=PB();e.isSupported=typeof yz=="function"&&typeof C=="function"&&L&&L.createHTMLDocument!==void 0;const{MUSTACHE_EXPR:A,ERB_EXPR:B,TMPLIT_EXPR:Q,DATA_ATTR:ae,ARIA_ATTR:we,IS_SCRIPT_OR_DATA:Le,ATTR_WHITESPACE:le,CUSTOM_ELEMENT:oe}=AB;let{IS_ALLOWED_URI:Pe}=AB,de=null;const Ne=ui({},[...NB,...TE,...ME,...RE,...TB]);let be=null;const di=ui({},[...MB,...AE,...RB,...oS]);let jt=Object.seal(oM(null,{tagNameCheck:{writable:!0,configurable:!1,enumerable:!0,value:null},attributeNameCheck:{writable:!0,configurable:!1,enumerable:!0,value:null},allowCustomizedBuiltInElements:{writable:!0,configurable:!1,enumerable:!0,value:!1}})),nt=null,Ct=null;const Qt=Object.seal(oM(null,{tagCheck:{writable:!0,configurable:!1,enumerable:!0,value:null},attributeCheck:{writable:!0,configurable:!1,enumerable:!0,value:null}}));let wn=!0,Ai=!0,rt=!1,K=!0,ee=!1,_e=!0,Ee=!1,xe=!1,Ie=!1,Ke=!1,Ue=!1,G=!1,W=!0,q=!1;const E="user-content-";let R=!0,$=!1,H={},te=null;const pe=ui({},["annotation-xml","audio","colgroup","desc","foreignobject","head","iframe","math","mi","mn","mo","ms","mtext","noembed","noframes","noscript","plaintext","script","style","svg","template","thead","title","video","xmp"]);let Me=null;const Oe=ui({},["audio","video","img","source","image","track"]);let tt=null;const et=ui({},["alt","class","for","id","label","name","pattern","placeholder","role","summary","title","value","style","xmlns"]),Bt="http://www.w3.org/1998/Math/MathML",Vt="http://www.w3.org/2000/svg",Be="http://www.w3.org/1999/xhtml";let Wt=Be,ci=!1,Yn=null;const Ht=ui({},[Bt,Vt,Be],EE);let zi=ui({},["mi","mo","mn","ms","mtext"]),J=ui({},["annotation-xml"]);const O=ui({},["title","style","font","a","script"]);let ce=null;const ri=["application/xhtml+xml","text/html"],kn="text/html";let vi=null,yo=null;const ya=t.createElement("form"),pc=function(Y){return Y instanceof RegExp||Y instanceof Function},Ys=function(){let Y=arguments.length>0&&arguments[0]!==void 0?arguments[0]:{};if(!(yo&&yo===Y)){if((!Y||typeof Y!="object")&&(Y={}),Y=ad(Y),ce=ri.indexOf(Y.PARSER_MEDIA_TYPE)===-1?kn:Y.PARSER_MEDIA_TYPE,vi=ce==="application/xhtml+xml"?EE:Py,de=rl(Y,"ALLOWED_TAGS")?ui({},Y.ALLOWED_TAGS,vi):Ne,be=rl(Y,"ALLOWED_ATTR")?ui({},Y.ALLOWED_ATTR,vi):di,Yn=rl(Y,"ALLOWED_NAMESPACES")?ui({},Y.ALLOWED_NAMESPACES,EE):Ht,tt=rl(Y,"ADD_URI_SAFE_ATTR")?ui(ad(et),Y.ADD_URI_SAFE_ATTR,vi):et,Me=rl(Y,"ADD_DATA_URI_TAGS")?ui(ad(Oe),Y.ADD_DATA_URI_TAGS,vi):Oe,te=rl(Y,"FORBID_CONTENTS")?ui({},Y.FORBID_CONTENTS,vi):pe,nt=rl(Y,"FORBID_TAGS")?ui({},Y.FORBID_TAGS,vi):ad({}),Ct=rl(Y,"FORBID_ATTR")?ui({},Y.FORBID_ATTR,vi):ad({}),H=rl(Y,"USE_PROFILES")?Y.USE_PROFILES:!1,wn=Y.ALLOW_ARIA_ATTR!==!1,Ai=Y.ALLOW_DATA_ATTR!==!1,rt=Y.ALLOW_UNKNOWN_PROTOCOLS||!1,K=Y.ALLOW_SELF_CLOSE_IN_ATTR!==!1,ee=Y.SAFE_FOR_TEMPLATES||!1,_e=Y.SAFE_FOR_XML!==!1,Ee=Y.WHOLE_DOCUMENT||!1,Ke=Y.RETURN_DOM||!1,Ue=Y.RETURN_DOM_FRAGMENT||!1,G=Y.RETURN_TRUSTED_TYPE||!1,Ie=Y.FORCE_BODY||!1,W=Y.SANITIZE_DOM!==!1,q=Y.SANITIZE_NAMED_PROPS||!1,R=Y.KEEP_CONTENT!==!1,$=Y.IN_PLACE||!1,Pe=Y.ALLOWED_URI_REGEXP||Lz,Wt=Y.NAMESPACE||Be,zi=Y.MATHML_TEXT_INTEGRATION_POINTS||zi,J=Y.HTML_INTEGRATION_POINTS||J,jt=Y.CUSTOM_ELEMENT_HANDLING||{},Y.CUSTOM_ELEMENT_HANDLING&&pc(Y.CUSTOM_ELEMENT_HANDLING.tagNameCheck)&&(jt.tagNameCheck=Y.CUSTOM_ELEMENT_HANDLING.tagNameCheck),Y.CUSTOM_ELEMENT_HANDLING&&pc(Y.CUSTOM_ELEMENT_HANDLING.attributeNameCheck)&&(jt.attributeNameCheck=Y.CUSTOM_ELEMENT_HANDLING.attributeNameCheck),Y.CUSTOM_ELEMENT_HANDLING&&typeof Y.CUSTOM_ELEMENT_HANDLING.allowCustomizedBuiltInElements=="boolean"&&(jt.allowCustomizedBuiltInElements=Y.CUSTOM_ELEMENT_HANDLING.allowCustomizedBuiltInElements),ee&&(Ai=!1),Ue&&(Ke=!0),H&&(de=ui({},TB),be=[],H.html===!0&&(ui(de,NB),ui(be,MB)),H.svg===!0&&(ui(de,TE),ui(be,AE),ui(be,oS)),H.svgFilters===!0&&(ui(de,ME),ui(be,AE),ui(be,oS)),H.mathMl===!0&&(ui(de,RE),ui(be,RB),ui(be,oS))),Y.ADD_TAGS&&(typeof Y.ADD_TAGS=="function"?Qt.tagCheck=Y.ADD_TAGS:(de===Ne&&(de=ad(de)),ui(de,Y.ADD_TAGS,vi))),Y.ADD_ATTR&&(typeof Y.ADD_ATTR=="function"?Qt.attributeCheck=Y.ADD_ATTR:(be===di&&(be=ad(be)),ui(be,Y.ADD_ATTR,vi))),Y.ADD_URI_SAFE_ATTR&&ui(tt,Y.ADD_URI_SAFE_ATTR,vi),Y.FORBID_CONTENTS&&(te===pe&&(te=ad(te)),ui(te,Y.FORBID_CONTENTS,vi)),Y.ADD_FORBID_CONTENTS&&(te===pe&&(te=ad(te)),ui(te,Y.ADD_FORBID_CONTENTS,vi)),R&&(de["#text"]=!0),Ee&&ui(de,["html","head","body"]),de.table&&(ui(de,["tbody"]),delete nt.tbody),Y.TRUSTED_TYPES_POLICY){if(typeof Y.TRUSTED_TYPES_POLICY.createHTML!="function")throw ab('TRUSTED_TYPES_POLICY configuration option must provide a "createHTML" hook.');if(typeof Y.TRUSTED_TYPES_POLICY.createScriptURL!="function")throw ab('TRUSTED_TYPES_POLICY configuration option must provide a "createScriptURL" hook.');w=Y.TRUSTED_TYPES_POLICY,S=w.createHTML("")}else w===void 0&&(w=xee(f,n)),w!==null&&typeof S=="string"&&(S=w.createHTML(""));ar&&ar(Y),yo=Y}},Qa=ui({},[...TE,...ME,...gee]),Ja=ui({},[...RE,...pee]),mc=function(Y){let Re=C(Y);(!Re||!Re.tagName)&&(Re={namespaceURI:Wt,tagName:"template"});const Ge=Py(Y.tagName),yi=Py(Re.tagName);return Yn[Y.namespaceURI]?Y.namespaceURI===Vt?Re.namespaceURI===Be?Ge==="svg":Re.namespaceURI===Bt?Ge==="svg"&&(yi==="annotation-xml"||zi[yi]):!!Qa[Ge]:Y.namespaceURI===Bt?Re.namespaceURI===Be?Ge==="math":Re.namespaceURI===Vt?Ge==="math"&&J[yi]:!!Ja[Ge]:Y.namespaceURI===Be?Re.namespaceURI===Vt&&!J[yi]||Re.namespaceURI===Bt&&!zi[yi]?!1:!Ja[Ge]&&(O[Ge]||!Qa[Ge]):!!(ce==="application/xhtml+xml"&&Yn[Y.namespaceURI]):!1},Uo=function(Y){ob(e.removed,{element:Y});try{C(Y).removeChild(Y)}catch{_(Y)}},$o=function(Y,Re){try{ob(e.removed,{attribute:Re.getAttributeNode(Y),from:Re})}catch{ob(e.removed,{attribute:null,from:Re})}if(Re.removeAttribute(Y),Y==="is")if(Ke||Ue)try{Uo(Re)}catch{}else try{Re.setAttribute(Y,"")}catch{}},jo=function(Y){let Re=null,Ge=null;if(Ie)Y="<remove></remove>"+Y;else{const Vn=NE(Y,/^[\r\n\t ]+/);Ge=Vn&&Vn[0]}ce==="application/xhtml+xml"&&Wt===Be&&(Y='<html xmlns="http://www.w3.org/1999/xhtml"><head></head><body>'+Y+"</body></html>");const yi=w?w.createHTML(Y):Y;if(Wt===Be)try{Re=new h().parseFromString(yi,ce)}catch{}if(!Re||!Re.documentElement){Re=L.createDocument(Wt,"template",null);try{Re.documentElement.innerHTML=ci?S:yi}catch{}}const ps=Re.body||Re.documentElement;return Y&&Ge&&ps.insertBefore(t.createTextNode(Ge),ps.childNodes[0]||null),Wt===Be?T.call(Re,Ee?"html":"body")[0]:Ee?Re.documentElement:ps},$u=function(Y){return D.call(Y.ownerDocument||Y,Y,d.SHOW_ELEMENT|d.SHOW_COMMENT|d.SHOW_TEXT|d.SHOW_PROCESSING_INSTRUCTION|d.SHOW_CDATA_SECTION,null)},Yp=function(Y){return Y instanceof u&&(typeof Y.nodeName!="string"||typeof Y.textContent!="string"||typeof Y.removeChild!="function"||!(Y.attributes instanceof c)||typeof Y.removeAttribute!="function"||typeof Y.setAttribute!="function"||typeof Y.namespaceURI!="string"||typeof Y.insertBefore!="function"||typeof Y.hasChildNodes!="function")},Jv=function(Y){return typeof a=="function"&&Y instanceof a};function La(Ye,Y,Re){sS(Ye,Ge=>{Ge.call(e,Y,Re,yo)})}const Gw=function(Y){let Re=null;if(La(P.beforeSanitizeElements,Y,null),Yp(Y))return Uo(Y),!0;const Ge=vi(Y.nodeName);if(La(P.uponSanitizeElement,Y,{tagName:Ge,allowedTags:de}),_e&&Y.hasChildNodes()&&!Jv(Y.firstElementChild)&&qo(/<[/\w!]/g,Y.innerHTML)&&qo(/<[/\w!]/g,Y.textContent)||Y.nodeType===db.progressingInstruction||_e&&Y.nodeType===db.comment&&qo(/<[/\w]/g,Y.data))return Uo(Y),!0;if(!(Qt.tagCheck instanceof Function&&Qt.tagCheck(Ge))&&(!de[Ge]||nt[Ge])){if(!nt[Ge]&&ie(Ge)&&(jt.tagNameCheck instanceof RegExp&&qo(jt.tagNameCheck,Ge)||jt.tagNameCheck instanceof Function&&jt.tagNameCheck(Ge)))return!1;if(R&&!te[Ge]){const yi=C(Y)||Y.parentNode,ps=b(Y)||Y.childNodes;if(ps&&yi){const Vn=ps.length;for(let Ko=Vn-1;Ko>=0;--Ko){const _c=m(ps[Ko],!0);_c.__removalCount=(Y.__removalCount||0)+1,yi.insertBefore(_c,v(Y))}}}return Uo(Y),!0}return Y instanceof l&&!mc(Y)||(Ge==="noscript"||Ge==="noembed"||Ge==="noframes")&&qo(/<\/no(script|embed|frames)/i,Y.innerHTML)?(Uo(Y),!0):(ee&&Y.nodeType===db.text&&(Re=Y.textContent,sS([A,B,Q],yi=>{Re=rb(Re,yi," ")}),Y.textContent!==Re&&(ob(e.removed,{element:Y.cloneNode()}),Y.textContent=Re)),La(P.afterSanitizeElements,Y,null),!1)},bt=function(Y,Re,Ge){if(W&&(Re==="id"||Re==="name")&&(Ge in t||Ge in ya))return!1;if(!(Ai&&!Ct[Re]&&qo(ae,Re))){if(!(wn&&qo(we,Re))){if(!(Qt.attributeCheck instanceof Function&&Qt.attributeCheck(Re,Y))){if(!be[Re]||Ct[Re]){if(!(ie(Y)&&(jt.tagNameCheck instanceof RegExp&&qo(jt.tagNameCheck,Y)||jt.tagNameCheck instanceof Function&&jt.tagNameCheck(Y))&&(jt.attributeNameCheck instanceof RegExp&&qo(jt.attributeNameCheck,Re)||jt.attributeNameCheck instanceof Function&&jt.attributeNameCheck(Re,Y))||Re==="is"&&jt.allowCustomizedBuiltInElements&&(jt.tagNameCheck instanceof RegExp&&qo(jt.tagNameCheck,Ge)||jt.tagNameCheck instanceof Function&&jt.tagNameCheck(Ge))))return!1}else if(!tt[Re]){if(!qo(Pe,rb(Ge,le,""))){if(!((Re==="src"||Re==="xlink:href"||Re==="href")&&Y!=="script"&&cee(Ge,"data:")===0&&Me[Y])){if(!(rt&&!qo(Le,rb(Ge,le,"")))){if(Ge)return!1}}}}}}}return!0},ie=function(Y){return Y!=="annotation-xml"&&NE(Y,oe)},We=function(Y){La(P.beforeSanitizeAttributes,Y,null);const{attributes:Re}=Y;if(!Re||Yp(Y))return;const Ge={attrName:"",attrValue:"",keepAttr:!0,allowedAttributes:be,forceKeepAttr:void 0};let yi=Re.length;for(;yi--;){const ps=Re[yi],{name:Vn,namespaceURI:Ko,value:_c}=ps,Xp=vi(Vn),mE=_c;let Xs=Vn==="value"?mE:uee(mE);if(Ge.attrName=Xp,Ge.attrValue=Xs,Ge.keepAttr=!0,Ge.forceKeepAttr=void 0,La(P.uponSanitizeAttribute,Y,Ge),Xs=Ge.attrValue,q&&(Xp==="id"||Xp==="name")&&($o(Vn,Y),Xs=E+Xs),_e&&qo(/((--!?|])>)|<\/(style|title|textarea)/i,Xs)){$o(Vn,Y);continue}if(Xp==="attributename"&&NE(Xs,"href")){$o(Vn,Y);continue}if(Ge.forceKeepAttr)continue;if(!Ge.keepAttr){$o(Vn,Y);continue}if(!K&&qo(/\/>/i,Xs)){$o(Vn,Y);continue}ee&&sS([A,B,Q],P3=>{Xs=rb(Xs,P3," ")});const A3=vi(Y.nodeName);if(!bt(A3,Xp,Xs)){$o(Vn,Y);continue}if(w&&typeof f=="object"&&typeof f.getAttributeType=="function"&&!Ko)switch(f.getAttributeType(A3,Xp)){case"TrustedHTML":{Xs=w.createHTML(Xs);break}case"TrustedScriptURL":{Xs=w.createScriptURL(Xs);break}}if(Xs!==mE)try{Ko?Y.setAttributeNS(Ko,Vn,Xs):Y.setAttribute(Vn,Xs),Yp(Y)?Uo(Y):EB(e.removed)}catch{$o(Vn,Y)}}La(P.afterSanitizeAttributes,Y,null)},ot=function Ye(Y){let Re=null;const Ge=$u(Y);for(La(P.beforeSanitizeShadowDOM,Y,null);Re=Ge.nextNode();)La(P.uponSanitizeShadowNode,Re,null),Gw(Re),We(Re),Re.content instanceof o&&Ye(Re.content);La(P.afterSanitizeShadowDOM,Y,null)};return e.sanitize=function(Ye){let Y=arguments.length>1&&arguments[1]!==void 0?arguments[1]:{},Re=null,Ge=null,yi=null,ps=null;if(ci=!Ye,ci&&(Ye="<!-->"),typeof Ye!="string"&&!Jv(Ye))if(typeof Ye.toString=="function"){if(Ye=Ye.toString(),typeof Ye!="string")throw ab("dirty is not a string, aborting")}else throw ab("toString is not a function");if(!e.isSupported)return Ye;if(xe||Ys(Y),e.removed=[],typeof Ye=="string"&&($=!1),$){if(Ye.nodeName){const _c=vi(Ye.nodeName);if(!de[_c]||nt[_c])throw ab("root node is forbidden and cannot be sanitized in-place")}}else if(Ye instanceof a)Re=jo("<!---->"),Ge=Re.ownerDocument.importNode(Ye,!0),Ge.nodeType===db.element&&Ge.nodeName==="BODY"||Ge.nodeName==="HTML"?Re=Ge:Re.appendChild(Ge);else{if(!Ke&&!ee&&!Ee&&Ye.indexOf("<")===-1)return w&&G?w.createHTML(Ye):Ye;if(Re=jo(Ye),!Re)return Ke?null:G?S:""}Re&&Ie&&Uo(Re.firstChild);const Vn=$u($?Ye:Re);for(;yi=Vn.nextNode();)Gw(yi),We(yi),yi.content instanceof o&&ot(yi.content);if($)return Ye;if(Ke){if(Ue)for(ps=I.call(Re.ownerDocument);Re.firstChild;)ps.appendChild(Re.firstChild);else ps=Re;return(be.shadowroot||be.shadowrootmode)&&(ps=V.call(i,ps,!0)),ps}let Ko=Ee?Re.outerHTML:Re.innerHTML;return Ee&&de["!doctype"]&&Re.ownerDocument&&Re.ownerDocument.doctype&&Re.ownerDocument.doctype.name&&qo(xz,Re.ownerDocument.doctype.name)&&(Ko="<!DOCTYPE "+Re.ownerDocument.doctype.name+`>
-`+Ko),ee&&sS([A,B,Q],_c=>{Ko=rb(Ko,_c," ")}),w&&G?w.createHTML(Ko):Ko},e.setConfig=function(){let Ye=arguments.length>0&&arguments[0]!==void 0?arguments[0]:{};Ys(Ye),xe=!0},e.clearConfig=function(){yo=null,xe=!1},e.isValidAttribute=function(Ye,Y,Re){yo||Ys({});const Ge=vi(Ye),yi=vi(Y);return bt(Ge,yi,Re)},e.addHook=function(Ye,Y){typeof Y=="function"&&ob(P[Ye],Y)},e.removeHook=function(Ye,Y){if(Y!==void 0){const Re=lee(P[Ye],Y);return Re===-1?void 0:dee(P[Ye],Re,1)[0]}return EB(P[Ye])},e.removeHooks=function(Ye){P[Ye]=[]},e.removeAllHooks=function(){P=PB()},e}var kz=Dz();const Ps="/blog/admin/".replace(/\/$/,"");async function Vo(s,e={}){const t=await fetch(s,{headers:{"Content-Type":"application/json"},...e});if(!t.ok){const n=await t.text();throw new Error(`Request failed ${t.status}: ${n}`)}const i=await t.text();return i?JSON.parse(i):null}async function Dee(){return Vo(`${Ps}/api/posts`)}async function kee(s){return Vo(`${Ps}/api/posts`,{method:"POST",body:JSON.stringify(s)})}async function Iee(s,e){return Vo(`${Ps}/api/posts/${s}`,{method:"PUT",body:JSON.stringify(e)})}async function Eee(s){await Vo(`${Ps}/api/posts/${s}`,{method:"DELETE"})}async function Nee(){return Vo(`${Ps}/api/settings`)}async function Tee(s){return Vo(`${Ps}/api/settings`,{method:"PUT",body:JSON.stringify(s)})}async function Mee(s={}){const e=new URLSearchParams(s).toString(),t=e?`?${e}`:"";return Vo(`${Ps}/api/comments${t}`)}async function Ree(s,e){await Vo(`${Ps}/api/comments/${s}/status`,{method:"PUT",body:JSON.stringify({status:e})})}async function Aee(s){await Vo(`${Ps}/api/comments/${s}`,{method:"DELETE"})}async function OB(){return Vo(`${Ps}/api/notifications/vapid-key`)}async function Pee(s){await Vo(`${Ps}/api/notifications/subscribe`,{method:"POST",body:JSON.stringify(s)})}async function Oee(s){await Vo(`${Ps}/api/notifications/subscribe`,{method:"DELETE",body:JSON.stringify({endpoint:s})})}async function Fee(){return Vo(`${Ps}/api/ai/settings`)}async function Bee(s){return Vo(`${Ps}/api/ai/settings`,{method:"PUT",body:JSON.stringify(s)})}async function Wee(s){return Vo(`${Ps}/api/ai/chat`,{method:"POST",body:JSON.stringify(s)})}async function Hee(){const s=await fetch(`${Ps}/api/wxr/export`);if(!s.ok){const e=await s.text();throw new Error(`Export failed ${s.status}: ${e}`)}return s.blob()}async function Vee(s){const e=new FormData;e.append("file",s);const t=await fetch(`${Ps}/api/wxr/import`,{method:"POST",body:e});if(!t.ok){const i=await t.text();throw new Error(`Import failed ${t.status}: ${i}`)}return t.json()}async function zee(){const s=await Vo(`${Ps}/api/images/enabled`);return(s==null?void 0:s.enabled)??!1}async function Uee(s){const e=new FormData;e.append("image",s);const t=await fetch(`${Ps}/api/images`,{method:"POST",body:e});if(!t.ok){const i=await t.text();throw new Error(`Upload failed ${t.status}: ${i}`)}return t.json()}function Kr(s,e=0){return s[s.length-(1+e)]}function $ee(s){if(s.length===0)throw new Error("Invalid tail call");return[s.slice(0,s.length-1),s[s.length-1]]}function Ki(s,e,t=(i,n)=>i===n){if(s===e)return!0;if(!s||!e||s.length!==e.length)return!1;for(let i=0,n=s.length;i<n;i++)if(!t(s[i],e[i]))return!1;return!0}function jee(s,e){const t=s.length-1;e<t&&(s[e]=s[t]),s.pop()}function uC(s,e,t){return Kee(s.length,i=>t(s[i],e))}function Kee(s,e){let t=0,i=s-1;for(;t<=i;){const n=(t+i)/2|0,o=e(n);if(o<0)t=n+1;else if(o>0)i=n-1;else return n}return-(t+1)}function lM(s,e,t){if(s=s|0,s>=e.length)throw new TypeError("invalid index");const i=e[Math.floor(e.length*Math.random())],n=[],o=[],r=[];for(const a of e){const l=t(a,i);l<0?n.push(a):l>0?o.push(a):r.push(a)}return s<n.length?lM(s,n,t):s<n.length+r.length?r[0]:lM(s-(n.length+r.length),o,t)}function FB(s,e){const t=[];let i;for(const n of s.slice(0).sort(e))!i||e(i[0],n)!==0?(i=[n],t.push(i)):i.push(n);return t}function*dF(s,e){let t,i;for(const n of s)i!==void 0&&e(i,n)?t.push(n):(t&&(yield t),t=[n]),i=n;t&&(yield t)}function Iz(s,e){for(let t=0;t<=s.length;t++)e(t===0?void 0:s[t-1],t===s.length?void 0:s[t])}function qee(s,e){for(let t=0;t<s.length;t++)e(t===0?void 0:s[t-1],s[t],t+1===s.length?void 0:s[t+1])}function Al(s){return s.filter(e=>!!e)}function BB(s){let e=0;for(let t=0;t<s.length;t++)s[t]&&(s[e]=s[t],e+=1);s.length=e}function Ez(s){return!Array.isArray(s)||s.length===0}function Ms(s){return Array.isArray(s)&&s.length>0}function Lu(s,e=t=>t){const t=new Set;return s.filter(i=>{const n=e(i);return t.has(n)?!1:(t.add(n),!0)})}function cF(s,e){return s.length>0?s[0]:e}function Do(s,e){let t=typeof e=="number"?s:0;typeof e=="number"?t=s:(t=0,e=s);const i=[];if(t<=e)for(let n=t;n<e;n++)i.push(n);else for(let n=t;n>e;n--)i.push(n);return i}function ZD(s,e,t){const i=s.slice(0,e),n=s.slice(e);return i.concat(t,n)}function PE(s,e){const t=s.indexOf(e);t>-1&&(s.splice(t,1),s.unshift(e))}function rS(s,e){const t=s.indexOf(e);t>-1&&(s.splice(t,1),s.push(e))}function dM(s,e){for(const t of e)s.push(t)}function uF(s){return Array.isArray(s)?s:[s]}function Gee(s,e,t){const i=Nz(s,e),n=s.length,o=t.length;s.length=n+o;for(let r=n-1;r>=i;r--)s[r+o]=s[r];for(let r=0;r<o;r++)s[r+i]=t[r]}function WB(s,e,t,i){const n=Nz(s,e);let o=s.splice(n,t);return o===void 0&&(o=[]),Gee(s,n,i),o}function Nz(s,e){return e<0?Math.max(e+s.length,0):Math.min(e,s.length)}var hC;(function(s){function e(o){return o<0}s.isLessThan=e;function t(o){return o<=0}s.isLessThanOrEqual=t;function i(o){return o>0}s.isGreaterThan=i;function n(o){return o===0}s.isNeitherLessOrGreaterThan=n,s.greaterThan=1,s.lessThan=-1,s.neitherLessOrGreaterThan=0})(hC||(hC={}));function Po(s,e){return(t,i)=>e(s(t),s(i))}function Zee(...s){return(e,t)=>{for(const i of s){const n=i(e,t);if(!hC.isNeitherLessOrGreaterThan(n))return n}return hC.neitherLessOrGreaterThan}}const Ua=(s,e)=>s-e,Yee=(s,e)=>Ua(s?1:0,e?1:0);function Tz(s){return(e,t)=>-s(e,t)}class xu{constructor(e){this.items=e,this.firstIdx=0,this.lastIdx=this.items.length-1}get length(){return this.lastIdx-this.firstIdx+1}takeWhile(e){let t=this.firstIdx;for(;t<this.items.length&&e(this.items[t]);)t++;const i=t===this.firstIdx?null:this.items.slice(this.firstIdx,t);return this.firstIdx=t,i}takeFromEndWhile(e){let t=this.lastIdx;for(;t>=0&&e(this.items[t]);)t--;const i=t===this.lastIdx?null:this.items.slice(t+1,this.lastIdx+1);return this.lastIdx=t,i}peek(){if(this.length!==0)return this.items[this.firstIdx]}dequeue(){const e=this.items[this.firstIdx];return this.firstIdx++,e}takeCount(e){const t=this.items.slice(this.firstIdx,this.firstIdx+e);return this.firstIdx+=e,t}}class Hd{constructor(e){this.iterate=e}toArray(){const e=[];return this.iterate(t=>(e.push(t),!0)),e}filter(e){return new Hd(t=>this.iterate(i=>e(i)?t(i):!0))}map(e){return new Hd(t=>this.iterate(i=>t(e(i))))}findLast(e){let t;return this.iterate(i=>(e(i)&&(t=i),!0)),t}findLastMaxBy(e){let t,i=!0;return this.iterate(n=>((i||hC.isGreaterThan(e(n,t)))&&(i=!1,t=n),!0)),t}}Hd.empty=new Hd(s=>{});class ML{constructor(e){this._indexMap=e}static createSortPermutation(e,t){const i=Array.from(e.keys()).sort((n,o)=>t(e[n],e[o]));return new ML(i)}apply(e){return e.map((t,i)=>e[this._indexMap[i]])}inverse(){const e=this._indexMap.slice();for(let t=0;t<this._indexMap.length;t++)e[this._indexMap[t]]=t;return new ML(e)}}function fo(s){return typeof s=="string"}function ro(s){return typeof s=="object"&&s!==null&&!Array.isArray(s)&&!(s instanceof RegExp)&&!(s instanceof Date)}function Xee(s){const e=Object.getPrototypeOf(Uint8Array);return typeof s=="object"&&s instanceof e}function ef(s){return typeof s=="number"&&!isNaN(s)}function HB(s){return!!s&&typeof s[Symbol.iterator]=="function"}function Mz(s){return s===!0||s===!1}function Mo(s){return typeof s>"u"}function Vd(s){return!Dr(s)}function Dr(s){return Mo(s)||s===null}function Pt(s,e){if(!s)throw new Error(e?`Unexpected type, expected '${e}'`:"Unexpected type")}function Ch(s){if(Dr(s))throw new Error("Assertion Failed: argument is undefined or null");return s}function fC(s){return typeof s=="function"}function Qee(s,e){const t=Math.min(s.length,e.length);for(let i=0;i<t;i++)Jee(s[i],e[i])}function Jee(s,e){if(fo(e)){if(typeof s!==e)throw new Error(`argument does not match constraint: typeof ${e}`)}else if(fC(e)){try{if(s instanceof e)return}catch{}if(!Dr(s)&&s.constructor===e||e.length===1&&e.call(void 0,s)===!0)return;throw new Error("argument does not match one of these constraints: arg instanceof constraint, arg.constructor === constraint, nor constraint(arg) === true")}}function Ac(s){if(!s||typeof s!="object"||s instanceof RegExp)return s;const e=Array.isArray(s)?[]:{};return Object.entries(s).forEach(([t,i])=>{e[t]=i&&typeof i=="object"?Ac(i):i}),e}function ete(s){if(!s||typeof s!="object")return s;const e=[s];for(;e.length>0;){const t=e.shift();Object.freeze(t);for(const i in t)if(Rz.call(t,i)){const n=t[i];typeof n=="object"&&!Object.isFrozen(n)&&!Xee(n)&&e.push(n)}}return s}const Rz=Object.prototype.hasOwnProperty;function Az(s,e){return cM(s,e,new Set)}function cM(s,e,t){if(Dr(s))return s;const i=e(s);if(typeof i<"u")return i;if(Array.isArray(s)){const n=[];for(const o of s)n.push(cM(o,e,t));return n}if(ro(s)){if(t.has(s))throw new Error("Cannot clone recursive data-structure");t.add(s);const n={};for(const o in s)Rz.call(s,o)&&(n[o]=cM(s[o],e,t));return t.delete(s),n}return s}function YD(s,e,t=!0){return ro(s)?(ro(e)&&Object.keys(e).forEach(i=>{i in s?t&&(ro(s[i])&&ro(e[i])?YD(s[i],e[i],t):s[i]=e[i]):s[i]=e[i]}),s):e}function Mr(s,e){if(s===e)return!0;if(s==null||e===null||e===void 0||typeof s!=typeof e||typeof s!="object"||Array.isArray(s)!==Array.isArray(e))return!1;let t,i;if(Array.isArray(s)){if(s.length!==e.length)return!1;for(t=0;t<s.length;t++)if(!Mr(s[t],e[t]))return!1}else{const n=[];for(i in s)n.push(i);n.sort();const o=[];for(i in e)o.push(i);if(o.sort(),!Mr(n,o))return!1;for(t=0;t<n.length;t++)if(!Mr(s[n[t]],e[n[t]]))return!1}return!0}function tte(s){let e=[];for(;Object.prototype!==s;)e=e.concat(Object.getOwnPropertyNames(s)),s=Object.getPrototypeOf(s);return e}function hF(s){const e=[];for(const t of tte(s))typeof s[t]=="function"&&e.push(t);return e}function ite(s,e){const t=n=>function(){const o=Array.prototype.slice.call(arguments,0);return e(n,o)},i={};for(const n of s)i[n]=t(n);return i}let nte=typeof document<"u"&&document.location&&document.location.hash.indexOf("pseudo=true")>=0;function Pz(s,e){let t;return e.length===0?t=s:t=s.replace(/\{(\d+)\}/g,(i,n)=>{const o=n[0],r=e[o];let a=i;return typeof r=="string"?a=r:(typeof r=="number"||typeof r=="boolean"||r===void 0||r===null)&&(a=String(r)),a}),nte&&(t="［"+t.replace(/[aouei]/g,"$&$&")+"］"),t}function p(s,e,...t){return Pz(e,t)}function st(s,e,...t){const i=Pz(e,t);return{value:i,original:i}}var OE,FE;const Em="en";let RL=!1,AL=!1,Oy=!1,Oz=!1,fF=!1,gF=!1,Fz=!1,aS,Fy=Em,VB=Em,ste,il;const uu=globalThis;let Js;typeof uu.vscode<"u"&&typeof uu.vscode.process<"u"?Js=uu.vscode.process:typeof process<"u"&&typeof((OE=process==null?void 0:process.versions)===null||OE===void 0?void 0:OE.node)=="string"&&(Js=process);const ote=typeof((FE=Js==null?void 0:Js.versions)===null||FE===void 0?void 0:FE.electron)=="string",rte=ote&&(Js==null?void 0:Js.type)==="renderer";if(typeof Js=="object"){RL=Js.platform==="win32",AL=Js.platform==="darwin",Oy=Js.platform==="linux",Oy&&Js.env.SNAP&&Js.env.SNAP_REVISION,Js.env.CI||Js.env.BUILD_ARTIFACTSTAGINGDIRECTORY,aS=Em,Fy=Em;const s=Js.env.VSCODE_NLS_CONFIG;if(s)try{const e=JSON.parse(s),t=e.availableLanguages["*"];aS=e.locale,VB=e.osLocale,Fy=t||Em,ste=e._translationsConfigFile}catch{}Oz=!0}else typeof navigator=="object"&&!rte?(il=navigator.userAgent,RL=il.indexOf("Windows")>=0,AL=il.indexOf("Macintosh")>=0,gF=(il.indexOf("Macintosh")>=0||il.indexOf("iPad")>=0||il.indexOf("iPhone")>=0)&&!!navigator.maxTouchPoints&&navigator.maxTouchPoints>0,Oy=il.indexOf("Linux")>=0,Fz=(il==null?void 0:il.indexOf("Mobi"))>=0,fF=!0,p({},"_"),aS=Em,Fy=aS,VB=navigator.language):console.error("Unable to resolve platform.");const Ls=RL,St=AL,po=Oy,Zd=Oz,Nf=fF,ate=fF&&typeof uu.importScripts=="function",lte=ate?uu.origin:void 0,Pl=gF,Bz=Fz,Yd=il,dte=Fy,cte=typeof uu.postMessage=="function"&&!uu.importScripts,Wz=(()=>{if(cte){const s=[];uu.addEventListener("message",t=>{if(t.data&&t.data.vscodeScheduleAsyncWork)for(let i=0,n=s.length;i<n;i++){const o=s[i];if(o.id===t.data.vscodeScheduleAsyncWork){s.splice(i,1),o.callback();return}}});let e=0;return t=>{const i=++e;s.push({id:i,callback:t}),uu.postMessage({vscodeScheduleAsyncWork:i},"*")}}return s=>setTimeout(s)})(),ir=AL||gF?2:RL?1:3;let zB=!0,UB=!1;function Hz(){if(!UB){UB=!0;const s=new Uint8Array(2);s[0]=1,s[1]=2,zB=new Uint16Array(s.buffer)[0]===513}return zB}const Vz=!!(Yd&&Yd.indexOf("Chrome")>=0),ute=!!(Yd&&Yd.indexOf("Firefox")>=0),hte=!!(!Vz&&Yd&&Yd.indexOf("Safari")>=0),fte=!!(Yd&&Yd.indexOf("Edg/")>=0),gte=!!(Yd&&Yd.indexOf("Android")>=0),Es={tabSize:4,indentSize:4,insertSpaces:!0,detectIndentation:!0,trimAutoWhitespace:!0,largeFileOptimizations:!0,bracketPairColorizationOptions:{enabled:!0,independentColorPoolPerBracketType:!1}};var kt;(function(s){function e(C){return C&&typeof C=="object"&&typeof C[Symbol.iterator]=="function"}s.is=e;const t=Object.freeze([]);function i(){return t}s.empty=i;function*n(C){yield C}s.single=n;function o(C){return e(C)?C:n(C)}s.wrap=o;function r(C){return C||t}s.from=r;function*a(C){for(let w=C.length-1;w>=0;w--)yield C[w]}s.reverse=a;function l(C){return!C||C[Symbol.iterator]().next().done===!0}s.isEmpty=l;function d(C){return C[Symbol.iterator]().next().value}s.first=d;function c(C,w){for(const S of C)if(w(S))return!0;return!1}s.some=c;function u(C,w){for(const S of C)if(w(S))return S}s.find=u;function*h(C,w){for(const S of C)w(S)&&(yield S)}s.filter=h;function*f(C,w){let S=0;for(const L of C)yield w(L,S++)}s.map=f;function*g(...C){for(const w of C)yield*w}s.concat=g;function m(C,w,S){let L=S;for(const D of C)L=w(L,D);return L}s.reduce=m;function*_(C,w,S=C.length){for(w<0&&(w+=C.length),S<0?S+=C.length:S>C.length&&(S=C.length);w<S;w++)yield C[w]}s.slice=_;function v(C,w=Number.POSITIVE_INFINITY){const S=[];if(w===0)return[S,C];const L=C[Symbol.iterator]();for(let D=0;D<w;D++){const I=L.next();if(I.done)return[S,s.empty()];S.push(I.value)}return[S,{[Symbol.iterator](){return L}}]}s.consume=v;async function b(C){const w=[];for await(const S of C)w.push(S);return Promise.resolve(w)}s.asyncToArray=b})(kt||(kt={}));let Tn=class uM{constructor(e){this.element=e,this.next=uM.Undefined,this.prev=uM.Undefined}};Tn.Undefined=new Tn(void 0);class ao{constructor(){this._first=Tn.Undefined,this._last=Tn.Undefined,this._size=0}get size(){return this._size}isEmpty(){return this._first===Tn.Undefined}clear(){let e=this._first;for(;e!==Tn.Undefined;){const t=e.next;e.prev=Tn.Undefined,e.next=Tn.Undefined,e=t}this._first=Tn.Undefined,this._last=Tn.Undefined,this._size=0}unshift(e){return this._insert(e,!1)}push(e){return this._insert(e,!0)}_insert(e,t){const i=new Tn(e);if(this._first===Tn.Undefined)this._first=i,this._last=i;else if(t){const o=this._last;this._last=i,i.prev=o,o.next=i}else{const o=this._first;this._first=i,i.next=o,o.prev=i}this._size+=1;let n=!1;return()=>{n||(n=!0,this._remove(i))}}shift(){if(this._first!==Tn.Undefined){const e=this._first.element;return this._remove(this._first),e}}pop(){if(this._last!==Tn.Undefined){const e=this._last.element;return this._remove(this._last),e}}_remove(e){if(e.prev!==Tn.Undefined&&e.next!==Tn.Undefined){const t=e.prev;t.next=e.next,e.next.prev=t}else e.prev===Tn.Undefined&&e.next===Tn.Undefined?(this._first=Tn.Undefined,this._last=Tn.Undefined):e.next===Tn.Undefined?(this._last=this._last.prev,this._last.next=Tn.Undefined):e.prev===Tn.Undefined&&(this._first=this._first.next,this._first.prev=Tn.Undefined);this._size-=1}*[Symbol.iterator](){let e=this._first;for(;e!==Tn.Undefined;)yield e.element,e=e.next}}const zz="`~!@#$%^&*()-=+[{]}\\|;:'\",.<>/?";function pte(s=""){let e="(-?\\d*\\.\\d\\w*)|([^";for(const t of zz)s.indexOf(t)>=0||(e+="\\"+t);return e+="\\s]+)",new RegExp(e,"g")}const pF=pte();function mF(s){let e=pF;if(s&&s instanceof RegExp)if(s.global)e=s;else{let t="g";s.ignoreCase&&(t+="i"),s.multiline&&(t+="m"),s.unicode&&(t+="u"),e=new RegExp(s.source,t)}return e.lastIndex=0,e}const Uz=new ao;Uz.unshift({maxLen:1e3,windowSize:15,timeBudget:150});function gC(s,e,t,i,n){if(e=mF(e),n||(n=kt.first(Uz)),t.length>n.maxLen){let d=s-n.maxLen/2;return d<0?d=0:i+=d,t=t.substring(d,s+n.maxLen/2),gC(s,e,t,i,n)}const o=Date.now(),r=s-1-i;let a=-1,l=null;for(let d=1;!(Date.now()-o>=n.timeBudget);d++){const c=r-n.windowSize*d;e.lastIndex=Math.max(0,c);const u=mte(e,t,r,a);if(!u&&l||(l=u,c<=0))break;a=c}if(l){const d={word:l[0],startColumn:i+1+l.index,endColumn:i+1+l.index+l[0].length};return e.lastIndex=0,d}return null}function mte(s,e,t,i){let n;for(;n=s.exec(e);){const o=n.index||0;if(o<=t&&s.lastIndex>=t)return n;if(i>0&&o>i)return null}return null}const ld=8;class $z{constructor(e){this._values=e}hasChanged(e){return this._values[e]}}class jz{constructor(){this.stableMinimapLayoutInput=null,this.stableFitMaxMinimapScale=0,this.stableFitRemainingWidth=0}}class Oi{constructor(e,t,i,n){this.id=e,this.name=t,this.defaultValue=i,this.schema=n}applyUpdate(e,t){return XD(e,t)}compute(e,t,i){return i}}class y0{constructor(e,t){this.newValue=e,this.didChange=t}}function XD(s,e){if(typeof s!="object"||typeof e!="object"||!s||!e)return new y0(e,s!==e);if(Array.isArray(s)||Array.isArray(e)){const i=Array.isArray(s)&&Array.isArray(e)&&Ki(s,e);return new y0(e,!i)}let t=!1;for(const i in e)if(e.hasOwnProperty(i)){const n=XD(s[i],e[i]);n.didChange&&(s[i]=n.newValue,t=!0)}return new y0(s,t)}class ew{constructor(e){this.schema=void 0,this.id=e,this.name="_never_",this.defaultValue=void 0}applyUpdate(e,t){return XD(e,t)}validate(e){return this.defaultValue}}class Iv{constructor(e,t,i,n){this.id=e,this.name=t,this.defaultValue=i,this.schema=n}applyUpdate(e,t){return XD(e,t)}validate(e){return typeof e>"u"?this.defaultValue:e}compute(e,t,i){return i}}function Fe(s,e){return typeof s>"u"?e:s==="false"?!1:!!s}class Rt extends Iv{constructor(e,t,i,n=void 0){typeof n<"u"&&(n.type="boolean",n.default=i),super(e,t,i,n)}validate(e){return Fe(e,this.defaultValue)}}function og(s,e,t,i){if(typeof s>"u")return e;let n=parseInt(s,10);return isNaN(n)?e:(n=Math.max(t,n),n=Math.min(i,n),n|0)}class hi extends Iv{static clampedInt(e,t,i,n){return og(e,t,i,n)}constructor(e,t,i,n,o,r=void 0){typeof r<"u"&&(r.type="integer",r.default=i,r.minimum=n,r.maximum=o),super(e,t,i,r),this.minimum=n,this.maximum=o}validate(e){return hi.clampedInt(e,this.defaultValue,this.minimum,this.maximum)}}function _te(s,e,t,i){if(typeof s>"u")return e;const n=sa.float(s,e);return sa.clamp(n,t,i)}class sa extends Iv{static clamp(e,t,i){return e<t?t:e>i?i:e}static float(e,t){if(typeof e=="number")return e;if(typeof e>"u")return t;const i=parseFloat(e);return isNaN(i)?t:i}constructor(e,t,i,n,o){typeof o<"u"&&(o.type="number",o.default=i),super(e,t,i,o),this.validationFn=n}validate(e){return this.validationFn(sa.float(e,this.defaultValue))}}class to extends Iv{static string(e,t){return typeof e!="string"?t:e}constructor(e,t,i,n=void 0){typeof n<"u"&&(n.type="string",n.default=i),super(e,t,i,n)}validate(e){return to.string(e,this.defaultValue)}}function sn(s,e,t,i){return typeof s!="string"?e:i&&s in i?i[s]:t.indexOf(s)===-1?e:s}class Gi extends Iv{constructor(e,t,i,n,o=void 0){typeof o<"u"&&(o.type="string",o.enum=n,o.default=i),super(e,t,i,o),this._allowedValues=n}validate(e){return sn(e,this.defaultValue,this._allowedValues)}}class lS extends Oi{constructor(e,t,i,n,o,r,a=void 0){typeof a<"u"&&(a.type="string",a.enum=o,a.default=n),super(e,t,i,a),this._allowedValues=o,this._convert=r}validate(e){return typeof e!="string"?this.defaultValue:this._allowedValues.indexOf(e)===-1?this.defaultValue:this._convert(e)}}function vte(s){switch(s){case"none":return 0;case"keep":return 1;case"brackets":return 2;case"advanced":return 3;case"full":return 4}}class bte extends Oi{constructor(){super(2,"accessibilitySupport",0,{type:"string",enum:["auto","on","off"],enumDescriptions:[p("accessibilitySupport.auto","Use platform APIs to detect when a Screen Reader is attached."),p("accessibilitySupport.on","Optimize for usage with a Screen Reader."),p("accessibilitySupport.off","Assume a screen reader is not attached.")],default:"auto",tags:["accessibility"],description:p("accessibilitySupport","Controls if the UI should run in a mode where it is optimized for screen readers.")})}validate(e){switch(e){case"auto":return 0;case"off":return 1;case"on":return 2}return this.defaultValue}compute(e,t,i){return i===0?e.accessibilitySupport:i}}class Cte extends Oi{constructor(){const e={insertSpace:!0,ignoreEmptyLines:!0};super(23,"comments",e,{"editor.comments.insertSpace":{type:"boolean",default:e.insertSpace,description:p("comments.insertSpace","Controls whether a space character is inserted when commenting.")},"editor.comments.ignoreEmptyLines":{type:"boolean",default:e.ignoreEmptyLines,description:p("comments.ignoreEmptyLines","Controls if empty lines should be ignored with toggle, add or remove actions for line comments.")}})}validate(e){if(!e||typeof e!="object")return this.defaultValue;const t=e;return{insertSpace:Fe(t.insertSpace,this.defaultValue.insertSpace),ignoreEmptyLines:Fe(t.ignoreEmptyLines,this.defaultValue.ignoreEmptyLines)}}}function wte(s){switch(s){case"blink":return 1;case"smooth":return 2;case"phase":return 3;case"expand":return 4;case"solid":return 5}}var ls;(function(s){s[s.Line=1]="Line",s[s.Block=2]="Block",s[s.Underline=3]="Underline",s[s.LineThin=4]="LineThin",s[s.BlockOutline=5]="BlockOutline",s[s.UnderlineThin=6]="UnderlineThin"})(ls||(ls={}));function Ste(s){switch(s){case"line":return ls.Line;case"block":return ls.Block;case"underline":return ls.Underline;case"line-thin":return ls.LineThin;case"block-outline":return ls.BlockOutline;case"underline-thin":return ls.UnderlineThin}}class yte extends ew{constructor(){super(142)}compute(e,t,i){const n=["monaco-editor"];return t.get(39)&&n.push(t.get(39)),e.extraEditorClassName&&n.push(e.extraEditorClassName),t.get(74)==="default"?n.push("mouse-default"):t.get(74)==="copy"&&n.push("mouse-copy"),t.get(111)&&n.push("showUnused"),t.get(140)&&n.push("showDeprecated"),n.join(" ")}}class Lte extends Rt{constructor(){super(37,"emptySelectionClipboard",!0,{description:p("emptySelectionClipboard","Controls whether copying without a selection copies the current line.")})}compute(e,t,i){return i&&e.emptySelectionClipboard}}class xte extends Oi{constructor(){const e={cursorMoveOnType:!0,seedSearchStringFromSelection:"always",autoFindInSelection:"never",globalFindClipboard:!1,addExtraSpaceOnTop:!0,loop:!0};super(41,"find",e,{"editor.find.cursorMoveOnType":{type:"boolean",default:e.cursorMoveOnType,description:p("find.cursorMoveOnType","Controls whether the cursor should jump to find matches while typing.")},"editor.find.seedSearchStringFromSelection":{type:"string",enum:["never","always","selection"],default:e.seedSearchStringFromSelection,enumDescriptions:[p("editor.find.seedSearchStringFromSelection.never","Never seed search string from the editor selection."),p("editor.find.seedSearchStringFromSelection.always","Always seed search string from the editor selection, including word at cursor position."),p("editor.find.seedSearchStringFromSelection.selection","Only seed search string from the editor selection.")],description:p("find.seedSearchStringFromSelection","Controls whether the search string in the Find Widget is seeded from the editor selection.")},"editor.find.autoFindInSelection":{type:"string",enum:["never","always","multiline"],default:e.autoFindInSelection,enumDescriptions:[p("editor.find.autoFindInSelection.never","Never turn on Find in Selection automatically (default)."),p("editor.find.autoFindInSelection.always","Always turn on Find in Selection automatically."),p("editor.find.autoFindInSelection.multiline","Turn on Find in Selection automatically when multiple lines of content are selected.")],description:p("find.autoFindInSelection","Controls the condition for turning on Find in Selection automatically.")},"editor.find.globalFindClipboard":{type:"boolean",default:e.globalFindClipboard,description:p("find.globalFindClipboard","Controls whether the Find Widget should read or modify the shared find clipboard on macOS."),included:St},"editor.find.addExtraSpaceOnTop":{type:"boolean",default:e.addExtraSpaceOnTop,description:p("find.addExtraSpaceOnTop","Controls whether the Find Widget should add extra lines on top of the editor. When true, you can scroll beyond the first line when the Find Widget is visible.")},"editor.find.loop":{type:"boolean",default:e.loop,description:p("find.loop","Controls whether the search automatically restarts from the beginning (or the end) when no further matches can be found.")}})}validate(e){if(!e||typeof e!="object")return this.defaultValue;const t=e;return{cursorMoveOnType:Fe(t.cursorMoveOnType,this.defaultValue.cursorMoveOnType),seedSearchStringFromSelection:typeof e.seedSearchStringFromSelection=="boolean"?e.seedSearchStringFromSelection?"always":"never":sn(t.seedSearchStringFromSelection,this.defaultValue.seedSearchStringFromSelection,["never","always","selection"]),autoFindInSelection:typeof e.autoFindInSelection=="boolean"?e.autoFindInSelection?"always":"never":sn(t.autoFindInSelection,this.defaultValue.autoFindInSelection,["never","always","multiline"]),globalFindClipboard:Fe(t.globalFindClipboard,this.defaultValue.globalFindClipboard),addExtraSpaceOnTop:Fe(t.addExtraSpaceOnTop,this.defaultValue.addExtraSpaceOnTop),loop:Fe(t.loop,this.defaultValue.loop)}}}class kr extends Oi{constructor(){super(51,"fontLigatures",kr.OFF,{anyOf:[{type:"boolean",description:p("fontLigatures","Enables/Disables font ligatures ('calt' and 'liga' font features). Change this to a string for fine-grained control of the 'font-feature-settings' CSS property.")},{type:"string",description:p("fontFeatureSettings","Explicit 'font-feature-settings' CSS property. A boolean can be passed instead if one only needs to turn on/off ligatures.")}],description:p("fontLigaturesGeneral","Configures font ligatures or font features. Can be either a boolean to enable/disable ligatures or a string for the value of the CSS 'font-feature-settings' property."),default:!1})}validate(e){return typeof e>"u"?this.defaultValue:typeof e=="string"?e==="false"||e.length===0?kr.OFF:e==="true"?kr.ON:e:e?kr.ON:kr.OFF}}kr.OFF='"liga" off, "calt" off';kr.ON='"liga" on, "calt" on';class _l extends Oi{constructor(){super(54,"fontVariations",_l.OFF,{anyOf:[{type:"boolean",description:p("fontVariations","Enables/Disables the translation from font-weight to font-variation-settings. Change this to a string for fine-grained control of the 'font-variation-settings' CSS property.")},{type:"string",description:p("fontVariationSettings","Explicit 'font-variation-settings' CSS property. A boolean can be passed instead if one only needs to translate font-weight to font-variation-settings.")}],description:p("fontVariationsGeneral","Configures font variations. Can be either a boolean to enable/disable the translation from font-weight to font-variation-settings or a string for the value of the CSS 'font-variation-settings' property."),default:!1})}validate(e){return typeof e>"u"?this.defaultValue:typeof e=="string"?e==="false"?_l.OFF:e==="true"?_l.TRANSLATE:e:e?_l.TRANSLATE:_l.OFF}compute(e,t,i){return e.fontInfo.fontVariationSettings}}_l.OFF="normal";_l.TRANSLATE="translate";class Dte extends ew{constructor(){super(50)}compute(e,t,i){return e.fontInfo}}class kte extends Iv{constructor(){super(52,"fontSize",Oo.fontSize,{type:"number",minimum:6,maximum:100,default:Oo.fontSize,description:p("fontSize","Controls the font size in pixels.")})}validate(e){const t=sa.float(e,this.defaultValue);return t===0?Oo.fontSize:sa.clamp(t,6,100)}compute(e,t,i){return e.fontInfo.fontSize}}class Sd extends Oi{constructor(){super(53,"fontWeight",Oo.fontWeight,{anyOf:[{type:"number",minimum:Sd.MINIMUM_VALUE,maximum:Sd.MAXIMUM_VALUE,errorMessage:p("fontWeightErrorMessage",'Only "normal" and "bold" keywords or numbers between 1 and 1000 are allowed.')},{type:"string",pattern:"^(normal|bold|1000|[1-9][0-9]{0,2})$"},{enum:Sd.SUGGESTION_VALUES}],default:Oo.fontWeight,description:p("fontWeight",'Controls the font weight. Accepts "normal" and "bold" keywords or numbers between 1 and 1000.')})}validate(e){return e==="normal"||e==="bold"?e:String(hi.clampedInt(e,Oo.fontWeight,Sd.MINIMUM_VALUE,Sd.MAXIMUM_VALUE))}}Sd.SUGGESTION_VALUES=["normal","bold","100","200","300","400","500","600","700","800","900"];Sd.MINIMUM_VALUE=1;Sd.MAXIMUM_VALUE=1e3;class Ite extends Oi{constructor(){const e={multiple:"peek",multipleDefinitions:"peek",multipleTypeDefinitions:"peek",multipleDeclarations:"peek",multipleImplementations:"peek",multipleReferences:"peek",alternativeDefinitionCommand:"editor.action.goToReferences",alternativeTypeDefinitionCommand:"editor.action.goToReferences",alternativeDeclarationCommand:"editor.action.goToReferences",alternativeImplementationCommand:"",alternativeReferenceCommand:""},t={type:"string",enum:["peek","gotoAndPeek","goto"],default:e.multiple,enumDescriptions:[p("editor.gotoLocation.multiple.peek","Show Peek view of the results (default)"),p("editor.gotoLocation.multiple.gotoAndPeek","Go to the primary result and show a Peek view"),p("editor.gotoLocation.multiple.goto","Go to the primary result and enable Peek-less navigation to others")]},i=["","editor.action.referenceSearch.trigger","editor.action.goToReferences","editor.action.peekImplementation","editor.action.goToImplementation","editor.action.peekTypeDefinition","editor.action.goToTypeDefinition","editor.action.peekDeclaration","editor.action.revealDeclaration","editor.action.peekDefinition","editor.action.revealDefinitionAside","editor.action.revealDefinition"];super(58,"gotoLocation",e,{"editor.gotoLocation.multiple":{deprecationMessage:p("editor.gotoLocation.multiple.deprecated","This setting is deprecated, please use separate settings like 'editor.editor.gotoLocation.multipleDefinitions' or 'editor.editor.gotoLocation.multipleImplementations' instead.")},"editor.gotoLocation.multipleDefinitions":{description:p("editor.editor.gotoLocation.multipleDefinitions","Controls the behavior the 'Go to Definition'-command when multiple target locations exist."),...t},"editor.gotoLocation.multipleTypeDefinitions":{description:p("editor.editor.gotoLocation.multipleTypeDefinitions","Controls the behavior the 'Go to Type Definition'-command when multiple target locations exist."),...t},"editor.gotoLocation.multipleDeclarations":{description:p("editor.editor.gotoLocation.multipleDeclarations","Controls the behavior the 'Go to Declaration'-command when multiple target locations exist."),...t},"editor.gotoLocation.multipleImplementations":{description:p("editor.editor.gotoLocation.multipleImplemenattions","Controls the behavior the 'Go to Implementations'-command when multiple target locations exist."),...t},"editor.gotoLocation.multipleReferences":{description:p("editor.editor.gotoLocation.multipleReferences","Controls the behavior the 'Go to References'-command when multiple target locations exist."),...t},"editor.gotoLocation.alternativeDefinitionCommand":{type:"string",default:e.alternativeDefinitionCommand,enum:i,description:p("alternativeDefinitionCommand","Alternative command id that is being executed when the result of 'Go to Definition' is the current location.")},"editor.gotoLocation.alternativeTypeDefinitionCommand":{type:"string",default:e.alternativeTypeDefinitionCommand,enum:i,description:p("alternativeTypeDefinitionCommand","Alternative command id that is being executed when the result of 'Go to Type Definition' is the current location.")},"editor.gotoLocation.alternativeDeclarationCommand":{type:"string",default:e.alternativeDeclarationCommand,enum:i,description:p("alternativeDeclarationCommand","Alternative command id that is being executed when the result of 'Go to Declaration' is the current location.")},"editor.gotoLocation.alternativeImplementationCommand":{type:"string",default:e.alternativeImplementationCommand,enum:i,description:p("alternativeImplementationCommand","Alternative command id that is being executed when the result of 'Go to Implementation' is the current location.")},"editor.gotoLocation.alternativeReferenceCommand":{type:"string",default:e.alternativeReferenceCommand,enum:i,description:p("alternativeReferenceCommand","Alternative command id that is being executed when the result of 'Go to Reference' is the current location.")}})}validate(e){var t,i,n,o,r;if(!e||typeof e!="object")return this.defaultValue;const a=e;return{multiple:sn(a.multiple,this.defaultValue.multiple,["peek","gotoAndPeek","goto"]),multipleDefinitions:(t=a.multipleDefinitions)!==null&&t!==void 0?t:sn(a.multipleDefinitions,"peek",["peek","gotoAndPeek","goto"]),multipleTypeDefinitions:(i=a.multipleTypeDefinitions)!==null&&i!==void 0?i:sn(a.multipleTypeDefinitions,"peek",["peek","gotoAndPeek","goto"]),multipleDeclarations:(n=a.multipleDeclarations)!==null&&n!==void 0?n:sn(a.multipleDeclarations,"peek",["peek","gotoAndPeek","goto"]),multipleImplementations:(o=a.multipleImplementations)!==null&&o!==void 0?o:sn(a.multipleImplementations,"peek",["peek","gotoAndPeek","goto"]),multipleReferences:(r=a.multipleReferences)!==null&&r!==void 0?r:sn(a.multipleReferences,"peek",["peek","gotoAndPeek","goto"]),alternativeDefinitionCommand:to.string(a.alternativeDefinitionCommand,this.defaultValue.alternativeDefinitionCommand),alternativeTypeDefinitionCommand:to.string(a.alternativeTypeDefinitionCommand,this.defaultValue.alternativeTypeDefinitionCommand),alternativeDeclarationCommand:to.string(a.alternativeDeclarationCommand,this.defaultValue.alternativeDeclarationCommand),alternativeImplementationCommand:to.string(a.alternativeImplementationCommand,this.defaultValue.alternativeImplementationCommand),alternativeReferenceCommand:to.string(a.alternativeReferenceCommand,this.defaultValue.alternativeReferenceCommand)}}}class Ete extends Oi{constructor(){const e={enabled:!0,delay:300,hidingDelay:300,sticky:!0,above:!0};super(60,"hover",e,{"editor.hover.enabled":{type:"boolean",default:e.enabled,description:p("hover.enabled","Controls whether the hover is shown.")},"editor.hover.delay":{type:"number",default:e.delay,minimum:0,maximum:1e4,description:p("hover.delay","Controls the delay in milliseconds after which the hover is shown.")},"editor.hover.sticky":{type:"boolean",default:e.sticky,description:p("hover.sticky","Controls whether the hover should remain visible when mouse is moved over it.")},"editor.hover.hidingDelay":{type:"integer",minimum:0,default:e.hidingDelay,description:p("hover.hidingDelay","Controls the delay in milliseconds after which the hover is hidden. Requires `editor.hover.sticky` to be enabled.")},"editor.hover.above":{type:"boolean",default:e.above,description:p("hover.above","Prefer showing hovers above the line, if there's space.")}})}validate(e){if(!e||typeof e!="object")return this.defaultValue;const t=e;return{enabled:Fe(t.enabled,this.defaultValue.enabled),delay:hi.clampedInt(t.delay,this.defaultValue.delay,0,1e4),sticky:Fe(t.sticky,this.defaultValue.sticky),hidingDelay:hi.clampedInt(t.hidingDelay,this.defaultValue.hidingDelay,0,6e5),above:Fe(t.above,this.defaultValue.above)}}}class o_ extends ew{constructor(){super(145)}compute(e,t,i){return o_.computeLayout(t,{memory:e.memory,outerWidth:e.outerWidth,outerHeight:e.outerHeight,isDominatedByLongLines:e.isDominatedByLongLines,lineHeight:e.fontInfo.lineHeight,viewLineCount:e.viewLineCount,lineNumbersDigitCount:e.lineNumbersDigitCount,typicalHalfwidthCharacterWidth:e.fontInfo.typicalHalfwidthCharacterWidth,maxDigitWidth:e.fontInfo.maxDigitWidth,pixelRatio:e.pixelRatio,glyphMarginDecorationLaneCount:e.glyphMarginDecorationLaneCount})}static computeContainedMinimapLineCount(e){const t=e.height/e.lineHeight,i=Math.floor(e.paddingTop/e.lineHeight);let n=Math.floor(e.paddingBottom/e.lineHeight);e.scrollBeyondLastLine&&(n=Math.max(n,t-1));const o=(i+e.viewLineCount+n)/(e.pixelRatio*e.height),r=Math.floor(e.viewLineCount/o);return{typicalViewportLineCount:t,extraLinesBeforeFirstLine:i,extraLinesBeyondLastLine:n,desiredRatio:o,minimapLineCount:r}}static _computeMinimapLayout(e,t){const i=e.outerWidth,n=e.outerHeight,o=e.pixelRatio;if(!e.minimap.enabled)return{renderMinimap:0,minimapLeft:0,minimapWidth:0,minimapHeightIsEditorHeight:!1,minimapIsSampling:!1,minimapScale:1,minimapLineHeight:1,minimapCanvasInnerWidth:0,minimapCanvasInnerHeight:Math.floor(o*n),minimapCanvasOuterWidth:0,minimapCanvasOuterHeight:n};const r=t.stableMinimapLayoutInput,a=r&&e.outerHeight===r.outerHeight&&e.lineHeight===r.lineHeight&&e.typicalHalfwidthCharacterWidth===r.typicalHalfwidthCharacterWidth&&e.pixelRatio===r.pixelRatio&&e.scrollBeyondLastLine===r.scrollBeyondLastLine&&e.paddingTop===r.paddingTop&&e.paddingBottom===r.paddingBottom&&e.minimap.enabled===r.minimap.enabled&&e.minimap.side===r.minimap.side&&e.minimap.size===r.minimap.size&&e.minimap.showSlider===r.minimap.showSlider&&e.minimap.renderCharacters===r.minimap.renderCharacters&&e.minimap.maxColumn===r.minimap.maxColumn&&e.minimap.scale===r.minimap.scale&&e.verticalScrollbarWidth===r.verticalScrollbarWidth&&e.isViewportWrapping===r.isViewportWrapping,l=e.lineHeight,d=e.typicalHalfwidthCharacterWidth,c=e.scrollBeyondLastLine,u=e.minimap.renderCharacters;let h=o>=2?Math.round(e.minimap.scale*2):e.minimap.scale;const f=e.minimap.maxColumn,g=e.minimap.size,m=e.minimap.side,_=e.verticalScrollbarWidth,v=e.viewLineCount,b=e.remainingWidth,C=e.isViewportWrapping,w=u?2:3;let S=Math.floor(o*n);const L=S/o;let D=!1,I=!1,T=w*h,V=h/o,P=1;if(g==="fill"||g==="fit"){const{typicalViewportLineCount:le,extraLinesBeforeFirstLine:oe,extraLinesBeyondLastLine:Pe,desiredRatio:de,minimapLineCount:Ne}=o_.computeContainedMinimapLineCount({viewLineCount:v,scrollBeyondLastLine:c,paddingTop:e.paddingTop,paddingBottom:e.paddingBottom,height:n,lineHeight:l,pixelRatio:o});if(v/Ne>1)D=!0,I=!0,h=1,T=1,V=h/o;else{let di=!1,jt=h+1;if(g==="fit"){const nt=Math.ceil((oe+v+Pe)*T);C&&a&&b<=t.stableFitRemainingWidth?(di=!0,jt=t.stableFitMaxMinimapScale):di=nt>S}if(g==="fill"||di){D=!0;const nt=h;T=Math.min(l*o,Math.max(1,Math.floor(1/de))),C&&a&&b<=t.stableFitRemainingWidth&&(jt=t.stableFitMaxMinimapScale),h=Math.min(jt,Math.max(1,Math.floor(T/w))),h>nt&&(P=Math.min(2,h/nt)),V=h/o/P,S=Math.ceil(Math.max(le,oe+v+Pe)*T),C?(t.stableMinimapLayoutInput=e,t.stableFitRemainingWidth=b,t.stableFitMaxMinimapScale=h):(t.stableMinimapLayoutInput=null,t.stableFitRemainingWidth=0)}}}const A=Math.floor(f*V),B=Math.min(A,Math.max(0,Math.floor((b-_-2)*V/(d+V)))+ld);let Q=Math.floor(o*B);const ae=Q/o;Q=Math.floor(Q*P);const we=u?1:2,Le=m==="left"?0:i-B-_;return{renderMinimap:we,minimapLeft:Le,minimapWidth:B,minimapHeightIsEditorHeight:D,minimapIsSampling:I,minimapScale:h,minimapLineHeight:T,minimapCanvasInnerWidth:Q,minimapCanvasInnerHeight:S,minimapCanvasOuterWidth:ae,minimapCanvasOuterHeight:L}}static computeLayout(e,t){const i=t.outerWidth|0,n=t.outerHeight|0,o=t.lineHeight|0,r=t.lineNumbersDigitCount|0,a=t.typicalHalfwidthCharacterWidth,l=t.maxDigitWidth,d=t.pixelRatio,c=t.viewLineCount,u=e.get(137),h=u==="inherit"?e.get(136):u,f=h==="inherit"?e.get(132):h,g=e.get(135),m=t.isDominatedByLongLines,_=e.get(57),v=e.get(68).renderType!==0,b=e.get(69),C=e.get(105),w=e.get(84),S=e.get(73),L=e.get(103),D=L.verticalScrollbarSize,I=L.verticalHasArrows,T=L.arrowSize,V=L.horizontalScrollbarSize,P=e.get(43),A=e.get(110)!=="never";let B=e.get(66);P&&A&&(B+=16);let Q=0;if(v){const Qt=Math.max(r,b);Q=Math.round(Qt*l)}let ae=0;_&&(ae=o*t.glyphMarginDecorationLaneCount);let we=0,Le=we+ae,le=Le+Q,oe=le+B;const Pe=i-ae-Q-B;let de=!1,Ne=!1,be=-1;h==="inherit"&&m?(de=!0,Ne=!0):f==="on"||f==="bounded"?Ne=!0:f==="wordWrapColumn"&&(be=g);const di=o_._computeMinimapLayout({outerWidth:i,outerHeight:n,lineHeight:o,typicalHalfwidthCharacterWidth:a,pixelRatio:d,scrollBeyondLastLine:C,paddingTop:w.top,paddingBottom:w.bottom,minimap:S,verticalScrollbarWidth:D,viewLineCount:c,remainingWidth:Pe,isViewportWrapping:Ne},t.memory||new jz);di.renderMinimap!==0&&di.minimapLeft===0&&(we+=di.minimapWidth,Le+=di.minimapWidth,le+=di.minimapWidth,oe+=di.minimapWidth);const jt=Pe-di.minimapWidth,nt=Math.max(1,Math.floor((jt-D-2)/a)),Ct=I?T:0;return Ne&&(be=Math.max(1,nt),f==="bounded"&&(be=Math.min(be,g))),{width:i,height:n,glyphMarginLeft:we,glyphMarginWidth:ae,glyphMarginDecorationLaneCount:t.glyphMarginDecorationLaneCount,lineNumbersLeft:Le,lineNumbersWidth:Q,decorationsLeft:le,decorationsWidth:B,contentLeft:oe,contentWidth:jt,minimap:di,viewportColumn:nt,isWordWrapMinified:de,isViewportWrapping:Ne,wrappingColumn:be,verticalScrollbarWidth:D,horizontalScrollbarHeight:V,overviewRuler:{top:Ct,width:D,height:n-2*Ct,right:0}}}}class Nte extends Oi{constructor(){super(139,"wrappingStrategy","simple",{"editor.wrappingStrategy":{enumDescriptions:[p("wrappingStrategy.simple","Assumes that all characters are of the same width. This is a fast algorithm that works correctly for monospace fonts and certain scripts (like Latin characters) where glyphs are of equal width."),p("wrappingStrategy.advanced","Delegates wrapping points computation to the browser. This is a slow algorithm, that might cause freezes for large files, but it works correctly in all cases.")],type:"string",enum:["simple","advanced"],default:"simple",description:p("wrappingStrategy","Controls the algorithm that computes wrapping points. Note that when in accessibility mode, advanced will be used for the best experience.")}})}validate(e){return sn(e,"simple",["simple","advanced"])}compute(e,t,i){return t.get(2)===2?"advanced":i}}var Ma;(function(s){s.Off="off",s.OnCode="onCode",s.On="on"})(Ma||(Ma={}));class Tte extends Oi{constructor(){const e={enabled:Ma.On};super(65,"lightbulb",e,{"editor.lightbulb.enabled":{type:"string",tags:["experimental"],enum:[Ma.Off,Ma.OnCode,Ma.On],default:e.enabled,enumDescriptions:[p("editor.lightbulb.enabled.off","Disable the code action menu."),p("editor.lightbulb.enabled.onCode","Show the code action menu when the cursor is on lines with code."),p("editor.lightbulb.enabled.on","Show the code action menu when the cursor is on lines with code or on empty lines.")],description:p("enabled","Enables the Code Action lightbulb in the editor.")}})}validate(e){return!e||typeof e!="object"?this.defaultValue:{enabled:sn(e.enabled,this.defaultValue.enabled,[Ma.Off,Ma.OnCode,Ma.On])}}}class Mte extends Oi{constructor(){const e={enabled:!0,maxLineCount:5,defaultModel:"outlineModel",scrollWithEditor:!0};super(115,"stickyScroll",e,{"editor.stickyScroll.enabled":{type:"boolean",default:e.enabled,description:p("editor.stickyScroll.enabled","Shows the nested current scopes during the scroll at the top of the editor."),tags:["experimental"]},"editor.stickyScroll.maxLineCount":{type:"number",default:e.maxLineCount,minimum:1,maximum:20,description:p("editor.stickyScroll.maxLineCount","Defines the maximum number of sticky lines to show.")},"editor.stickyScroll.defaultModel":{type:"string",enum:["outlineModel","foldingProviderModel","indentationModel"],default:e.defaultModel,description:p("editor.stickyScroll.defaultModel","Defines the model to use for determining which lines to stick. If the outline model does not exist, it will fall back on the folding provider model which falls back on the indentation model. This order is respected in all three cases.")},"editor.stickyScroll.scrollWithEditor":{type:"boolean",default:e.scrollWithEditor,description:p("editor.stickyScroll.scrollWithEditor","Enable scrolling of Sticky Scroll with the editor's horizontal scrollbar.")}})}validate(e){if(!e||typeof e!="object")return this.defaultValue;const t=e;return{enabled:Fe(t.enabled,this.defaultValue.enabled),maxLineCount:hi.clampedInt(t.maxLineCount,this.defaultValue.maxLineCount,1,20),defaultModel:sn(t.defaultModel,this.defaultValue.defaultModel,["outlineModel","foldingProviderModel","indentationModel"]),scrollWithEditor:Fe(t.scrollWithEditor,this.defaultValue.scrollWithEditor)}}}class Rte extends Oi{constructor(){const e={enabled:"on",fontSize:0,fontFamily:"",padding:!1};super(141,"inlayHints",e,{"editor.inlayHints.enabled":{type:"string",default:e.enabled,description:p("inlayHints.enable","Enables the inlay hints in the editor."),enum:["on","onUnlessPressed","offUnlessPressed","off"],markdownEnumDescriptions:[p("editor.inlayHints.on","Inlay hints are enabled"),p("editor.inlayHints.onUnlessPressed","Inlay hints are showing by default and hide when holding {0}",St?"Ctrl+Option":"Ctrl+Alt"),p("editor.inlayHints.offUnlessPressed","Inlay hints are hidden by default and show when holding {0}",St?"Ctrl+Option":"Ctrl+Alt"),p("editor.inlayHints.off","Inlay hints are disabled")]},"editor.inlayHints.fontSize":{type:"number",default:e.fontSize,markdownDescription:p("inlayHints.fontSize","Controls font size of inlay hints in the editor. As default the {0} is used when the configured value is less than {1} or greater than the editor font size.","`#editor.fontSize#`","`5`")},"editor.inlayHints.fontFamily":{type:"string",default:e.fontFamily,markdownDescription:p("inlayHints.fontFamily","Controls font family of inlay hints in the editor. When set to empty, the {0} is used.","`#editor.fontFamily#`")},"editor.inlayHints.padding":{type:"boolean",default:e.padding,description:p("inlayHints.padding","Enables the padding around the inlay hints in the editor.")}})}validate(e){if(!e||typeof e!="object")return this.defaultValue;const t=e;return typeof t.enabled=="boolean"&&(t.enabled=t.enabled?"on":"off"),{enabled:sn(t.enabled,this.defaultValue.enabled,["on","off","offUnlessPressed","onUnlessPressed"]),fontSize:hi.clampedInt(t.fontSize,this.defaultValue.fontSize,0,100),fontFamily:to.string(t.fontFamily,this.defaultValue.fontFamily),padding:Fe(t.padding,this.defaultValue.padding)}}}class Ate extends Oi{constructor(){super(66,"lineDecorationsWidth",10)}validate(e){return typeof e=="string"&&/^\d+(\.\d+)?ch$/.test(e)?-parseFloat(e.substring(0,e.length-2)):hi.clampedInt(e,this.defaultValue,0,1e3)}compute(e,t,i){return i<0?hi.clampedInt(-i*e.fontInfo.typicalHalfwidthCharacterWidth,this.defaultValue,0,1e3):i}}class Pte extends sa{constructor(){super(67,"lineHeight",Oo.lineHeight,e=>sa.clamp(e,0,150),{markdownDescription:p("lineHeight",`Controls the line height. 
+`+Ko),ee&&sS([A,B,Q],_c=>{Ko=rb(Ko,_c," ")}),w&&G?w.createHTML(Ko):Ko},e.setConfig=function(){let Ye=arguments.length>0&&arguments[0]!==void 0?arguments[0]:{};Ys(Ye),xe=!0},e.clearConfig=function(){yo=null,xe=!1},e.isValidAttribute=function(Ye,Y,Re){yo||Ys({});const Ge=vi(Ye),yi=vi(Y);return bt(Ge,yi,Re)},e.addHook=function(Ye,Y){typeof Y=="function"&&ob(P[Ye],Y)},e.removeHook=function(Ye,Y){if(Y!==void 0){const Re=lee(P[Ye],Y);return Re===-1?void 0:dee(P[Ye],Re,1)[0]}return EB(P[Ye])},e.removeHooks=function(Ye){P[Ye]=[]},e.removeAllHooks=function(){P=PB()},e}var kz=Dz();const Ps="/blog/admin/".replace(/\/$/,"");async function Vo(s,e={}){const t=await fetch(s,{headers:{"Content-Type":"application/json"},...e});if(!t.ok){const n=await t.text(),r=new Error(`Request failed ${t.status}: ${n}`);throw r.status=t.status,r}const i=await t.text();return i?JSON.parse(i):null}async function Dee(){return Vo(`${Ps}/api/posts`)}async function kee(s){return Vo(`${Ps}/api/posts`,{method:"POST",body:JSON.stringify(s)})}async function Iee(s,e){return Vo(`${Ps}/api/posts/${s}`,{method:"PUT",body:JSON.stringify(e)})}async function Eee(s){await Vo(`${Ps}/api/posts/${s}`,{method:"DELETE"})}async function Nee(){return Vo(`${Ps}/api/settings`)}async function Tee(s){return Vo(`${Ps}/api/settings`,{method:"PUT",body:JSON.stringify(s)})}async function Mee(s={}){const e=new URLSearchParams(s).toString(),t=e?`?${e}`:"";return Vo(`${Ps}/api/comments${t}`)}async function Ree(s,e){await Vo(`${Ps}/api/comments/${s}/status`,{method:"PUT",body:JSON.stringify({status:e})})}async function Aee(s){await Vo(`${Ps}/api/comments/${s}`,{method:"DELETE"})}async function OB(){return Vo(`${Ps}/api/notifications/vapid-key`)}async function Pee(s){await Vo(`${Ps}/api/notifications/subscribe`,{method:"POST",body:JSON.stringify(s)})}async function Oee(s){await Vo(`${Ps}/api/notifications/subscribe`,{method:"DELETE",body:JSON.stringify({endpoint:s})})}async function Fee(){return Vo(`${Ps}/api/ai/settings`)}async function Bee(s){return Vo(`${Ps}/api/ai/settings`,{method:"PUT",body:JSON.stringify(s)})}async function Wee(s){return Vo(`${Ps}/api/ai/chat`,{method:"POST",body:JSON.stringify(s)})}async function Hee(){const s=await fetch(`${Ps}/api/wxr/export`);if(!s.ok){const e=await s.text();throw new Error(`Export failed ${s.status}: ${e}`)}return s.blob()}async function Vee(s){const e=new FormData;e.append("file",s);const t=await fetch(`${Ps}/api/wxr/import`,{method:"POST",body:e});if(!t.ok){const i=await t.text();throw new Error(`Import failed ${t.status}: ${i}`)}return t.json()}async function zee(){const s=await Vo(`${Ps}/api/images/enabled`);return(s==null?void 0:s.enabled)??!1}async function Uee(s){const e=new FormData;e.append("image",s);const t=await fetch(`${Ps}/api/images`,{method:"POST",body:e});if(!t.ok){const i=await t.text();throw new Error(`Upload failed ${t.status}: ${i}`)}return t.json()}function Kr(s,e=0){return s[s.length-(1+e)]}function $ee(s){if(s.length===0)throw new Error("Invalid tail call");return[s.slice(0,s.length-1),s[s.length-1]]}function Ki(s,e,t=(i,n)=>i===n){if(s===e)return!0;if(!s||!e||s.length!==e.length)return!1;for(let i=0,n=s.length;i<n;i++)if(!t(s[i],e[i]))return!1;return!0}function jee(s,e){const t=s.length-1;e<t&&(s[e]=s[t]),s.pop()}function uC(s,e,t){return Kee(s.length,i=>t(s[i],e))}function Kee(s,e){let t=0,i=s-1;for(;t<=i;){const n=(t+i)/2|0,o=e(n);if(o<0)t=n+1;else if(o>0)i=n-1;else return n}return-(t+1)}function lM(s,e,t){if(s=s|0,s>=e.length)throw new TypeError("invalid index");const i=e[Math.floor(e.length*Math.random())],n=[],o=[],r=[];for(const a of e){const l=t(a,i);l<0?n.push(a):l>0?o.push(a):r.push(a)}return s<n.length?lM(s,n,t):s<n.length+r.length?r[0]:lM(s-(n.length+r.length),o,t)}function FB(s,e){const t=[];let i;for(const n of s.slice(0).sort(e))!i||e(i[0],n)!==0?(i=[n],t.push(i)):i.push(n);return t}function*dF(s,e){let t,i;for(const n of s)i!==void 0&&e(i,n)?t.push(n):(t&&(yield t),t=[n]),i=n;t&&(yield t)}function Iz(s,e){for(let t=0;t<=s.length;t++)e(t===0?void 0:s[t-1],t===s.length?void 0:s[t])}function qee(s,e){for(let t=0;t<s.length;t++)e(t===0?void 0:s[t-1],s[t],t+1===s.length?void 0:s[t+1])}function Al(s){return s.filter(e=>!!e)}function BB(s){let e=0;for(let t=0;t<s.length;t++)s[t]&&(s[e]=s[t],e+=1);s.length=e}function Ez(s){return!Array.isArray(s)||s.length===0}function Ms(s){return Array.isArray(s)&&s.length>0}function Lu(s,e=t=>t){const t=new Set;return s.filter(i=>{const n=e(i);return t.has(n)?!1:(t.add(n),!0)})}function cF(s,e){return s.length>0?s[0]:e}function Do(s,e){let t=typeof e=="number"?s:0;typeof e=="number"?t=s:(t=0,e=s);const i=[];if(t<=e)for(let n=t;n<e;n++)i.push(n);else for(let n=t;n>e;n--)i.push(n);return i}function ZD(s,e,t){const i=s.slice(0,e),n=s.slice(e);return i.concat(t,n)}function PE(s,e){const t=s.indexOf(e);t>-1&&(s.splice(t,1),s.unshift(e))}function rS(s,e){const t=s.indexOf(e);t>-1&&(s.splice(t,1),s.push(e))}function dM(s,e){for(const t of e)s.push(t)}function uF(s){return Array.isArray(s)?s:[s]}function Gee(s,e,t){const i=Nz(s,e),n=s.length,o=t.length;s.length=n+o;for(let r=n-1;r>=i;r--)s[r+o]=s[r];for(let r=0;r<o;r++)s[r+i]=t[r]}function WB(s,e,t,i){const n=Nz(s,e);let o=s.splice(n,t);return o===void 0&&(o=[]),Gee(s,n,i),o}function Nz(s,e){return e<0?Math.max(e+s.length,0):Math.min(e,s.length)}var hC;(function(s){function e(o){return o<0}s.isLessThan=e;function t(o){return o<=0}s.isLessThanOrEqual=t;function i(o){return o>0}s.isGreaterThan=i;function n(o){return o===0}s.isNeitherLessOrGreaterThan=n,s.greaterThan=1,s.lessThan=-1,s.neitherLessOrGreaterThan=0})(hC||(hC={}));function Po(s,e){return(t,i)=>e(s(t),s(i))}function Zee(...s){return(e,t)=>{for(const i of s){const n=i(e,t);if(!hC.isNeitherLessOrGreaterThan(n))return n}return hC.neitherLessOrGreaterThan}}const Ua=(s,e)=>s-e,Yee=(s,e)=>Ua(s?1:0,e?1:0);function Tz(s){return(e,t)=>-s(e,t)}class xu{constructor(e){this.items=e,this.firstIdx=0,this.lastIdx=this.items.length-1}get length(){return this.lastIdx-this.firstIdx+1}takeWhile(e){let t=this.firstIdx;for(;t<this.items.length&&e(this.items[t]);)t++;const i=t===this.firstIdx?null:this.items.slice(this.firstIdx,t);return this.firstIdx=t,i}takeFromEndWhile(e){let t=this.lastIdx;for(;t>=0&&e(this.items[t]);)t--;const i=t===this.lastIdx?null:this.items.slice(t+1,this.lastIdx+1);return this.lastIdx=t,i}peek(){if(this.length!==0)return this.items[this.firstIdx]}dequeue(){const e=this.items[this.firstIdx];return this.firstIdx++,e}takeCount(e){const t=this.items.slice(this.firstIdx,this.firstIdx+e);return this.firstIdx+=e,t}}class Hd{constructor(e){this.iterate=e}toArray(){const e=[];return this.iterate(t=>(e.push(t),!0)),e}filter(e){return new Hd(t=>this.iterate(i=>e(i)?t(i):!0))}map(e){return new Hd(t=>this.iterate(i=>t(e(i))))}findLast(e){let t;return this.iterate(i=>(e(i)&&(t=i),!0)),t}findLastMaxBy(e){let t,i=!0;return this.iterate(n=>((i||hC.isGreaterThan(e(n,t)))&&(i=!1,t=n),!0)),t}}Hd.empty=new Hd(s=>{});class ML{constructor(e){this._indexMap=e}static createSortPermutation(e,t){const i=Array.from(e.keys()).sort((n,o)=>t(e[n],e[o]));return new ML(i)}apply(e){return e.map((t,i)=>e[this._indexMap[i]])}inverse(){const e=this._indexMap.slice();for(let t=0;t<this._indexMap.length;t++)e[this._indexMap[t]]=t;return new ML(e)}}function fo(s){return typeof s=="string"}function ro(s){return typeof s=="object"&&s!==null&&!Array.isArray(s)&&!(s instanceof RegExp)&&!(s instanceof Date)}function Xee(s){const e=Object.getPrototypeOf(Uint8Array);return typeof s=="object"&&s instanceof e}function ef(s){return typeof s=="number"&&!isNaN(s)}function HB(s){return!!s&&typeof s[Symbol.iterator]=="function"}function Mz(s){return s===!0||s===!1}function Mo(s){return typeof s>"u"}function Vd(s){return!Dr(s)}function Dr(s){return Mo(s)||s===null}function Pt(s,e){if(!s)throw new Error(e?`Unexpected type, expected '${e}'`:"Unexpected type")}function Ch(s){if(Dr(s))throw new Error("Assertion Failed: argument is undefined or null");return s}function fC(s){return typeof s=="function"}function Qee(s,e){const t=Math.min(s.length,e.length);for(let i=0;i<t;i++)Jee(s[i],e[i])}function Jee(s,e){if(fo(e)){if(typeof s!==e)throw new Error(`argument does not match constraint: typeof ${e}`)}else if(fC(e)){try{if(s instanceof e)return}catch{}if(!Dr(s)&&s.constructor===e||e.length===1&&e.call(void 0,s)===!0)return;throw new Error("argument does not match one of these constraints: arg instanceof constraint, arg.constructor === constraint, nor constraint(arg) === true")}}function Ac(s){if(!s||typeof s!="object"||s instanceof RegExp)return s;const e=Array.isArray(s)?[]:{};return Object.entries(s).forEach(([t,i])=>{e[t]=i&&typeof i=="object"?Ac(i):i}),e}function ete(s){if(!s||typeof s!="object")return s;const e=[s];for(;e.length>0;){const t=e.shift();Object.freeze(t);for(const i in t)if(Rz.call(t,i)){const n=t[i];typeof n=="object"&&!Object.isFrozen(n)&&!Xee(n)&&e.push(n)}}return s}const Rz=Object.prototype.hasOwnProperty;function Az(s,e){return cM(s,e,new Set)}function cM(s,e,t){if(Dr(s))return s;const i=e(s);if(typeof i<"u")return i;if(Array.isArray(s)){const n=[];for(const o of s)n.push(cM(o,e,t));return n}if(ro(s)){if(t.has(s))throw new Error("Cannot clone recursive data-structure");t.add(s);const n={};for(const o in s)Rz.call(s,o)&&(n[o]=cM(s[o],e,t));return t.delete(s),n}return s}function YD(s,e,t=!0){return ro(s)?(ro(e)&&Object.keys(e).forEach(i=>{i in s?t&&(ro(s[i])&&ro(e[i])?YD(s[i],e[i],t):s[i]=e[i]):s[i]=e[i]}),s):e}function Mr(s,e){if(s===e)return!0;if(s==null||e===null||e===void 0||typeof s!=typeof e||typeof s!="object"||Array.isArray(s)!==Array.isArray(e))return!1;let t,i;if(Array.isArray(s)){if(s.length!==e.length)return!1;for(t=0;t<s.length;t++)if(!Mr(s[t],e[t]))return!1}else{const n=[];for(i in s)n.push(i);n.sort();const o=[];for(i in e)o.push(i);if(o.sort(),!Mr(n,o))return!1;for(t=0;t<n.length;t++)if(!Mr(s[n[t]],e[n[t]]))return!1}return!0}function tte(s){let e=[];for(;Object.prototype!==s;)e=e.concat(Object.getOwnPropertyNames(s)),s=Object.getPrototypeOf(s);return e}function hF(s){const e=[];for(const t of tte(s))typeof s[t]=="function"&&e.push(t);return e}function ite(s,e){const t=n=>function(){const o=Array.prototype.slice.call(arguments,0);return e(n,o)},i={};for(const n of s)i[n]=t(n);return i}let nte=typeof document<"u"&&document.location&&document.location.hash.indexOf("pseudo=true")>=0;function Pz(s,e){let t;return e.length===0?t=s:t=s.replace(/\{(\d+)\}/g,(i,n)=>{const o=n[0],r=e[o];let a=i;return typeof r=="string"?a=r:(typeof r=="number"||typeof r=="boolean"||r===void 0||r===null)&&(a=String(r)),a}),nte&&(t="［"+t.replace(/[aouei]/g,"$&$&")+"］"),t}function p(s,e,...t){return Pz(e,t)}function st(s,e,...t){const i=Pz(e,t);return{value:i,original:i}}var OE,FE;const Em="en";let RL=!1,AL=!1,Oy=!1,Oz=!1,fF=!1,gF=!1,Fz=!1,aS,Fy=Em,VB=Em,ste,il;const uu=globalThis;let Js;typeof uu.vscode<"u"&&typeof uu.vscode.process<"u"?Js=uu.vscode.process:typeof process<"u"&&typeof((OE=process==null?void 0:process.versions)===null||OE===void 0?void 0:OE.node)=="string"&&(Js=process);const ote=typeof((FE=Js==null?void 0:Js.versions)===null||FE===void 0?void 0:FE.electron)=="string",rte=ote&&(Js==null?void 0:Js.type)==="renderer";if(typeof Js=="object"){RL=Js.platform==="win32",AL=Js.platform==="darwin",Oy=Js.platform==="linux",Oy&&Js.env.SNAP&&Js.env.SNAP_REVISION,Js.env.CI||Js.env.BUILD_ARTIFACTSTAGINGDIRECTORY,aS=Em,Fy=Em;const s=Js.env.VSCODE_NLS_CONFIG;if(s)try{const e=JSON.parse(s),t=e.availableLanguages["*"];aS=e.locale,VB=e.osLocale,Fy=t||Em,ste=e._translationsConfigFile}catch{}Oz=!0}else typeof navigator=="object"&&!rte?(il=navigator.userAgent,RL=il.indexOf("Windows")>=0,AL=il.indexOf("Macintosh")>=0,gF=(il.indexOf("Macintosh")>=0||il.indexOf("iPad")>=0||il.indexOf("iPhone")>=0)&&!!navigator.maxTouchPoints&&navigator.maxTouchPoints>0,Oy=il.indexOf("Linux")>=0,Fz=(il==null?void 0:il.indexOf("Mobi"))>=0,fF=!0,p({},"_"),aS=Em,Fy=aS,VB=navigator.language):console.error("Unable to resolve platform.");const Ls=RL,St=AL,po=Oy,Zd=Oz,Nf=fF,ate=fF&&typeof uu.importScripts=="function",lte=ate?uu.origin:void 0,Pl=gF,Bz=Fz,Yd=il,dte=Fy,cte=typeof uu.postMessage=="function"&&!uu.importScripts,Wz=(()=>{if(cte){const s=[];uu.addEventListener("message",t=>{if(t.data&&t.data.vscodeScheduleAsyncWork)for(let i=0,n=s.length;i<n;i++){const o=s[i];if(o.id===t.data.vscodeScheduleAsyncWork){s.splice(i,1),o.callback();return}}});let e=0;return t=>{const i=++e;s.push({id:i,callback:t}),uu.postMessage({vscodeScheduleAsyncWork:i},"*")}}return s=>setTimeout(s)})(),ir=AL||gF?2:RL?1:3;let zB=!0,UB=!1;function Hz(){if(!UB){UB=!0;const s=new Uint8Array(2);s[0]=1,s[1]=2,zB=new Uint16Array(s.buffer)[0]===513}return zB}const Vz=!!(Yd&&Yd.indexOf("Chrome")>=0),ute=!!(Yd&&Yd.indexOf("Firefox")>=0),hte=!!(!Vz&&Yd&&Yd.indexOf("Safari")>=0),fte=!!(Yd&&Yd.indexOf("Edg/")>=0),gte=!!(Yd&&Yd.indexOf("Android")>=0),Es={tabSize:4,indentSize:4,insertSpaces:!0,detectIndentation:!0,trimAutoWhitespace:!0,largeFileOptimizations:!0,bracketPairColorizationOptions:{enabled:!0,independentColorPoolPerBracketType:!1}};var kt;(function(s){function e(C){return C&&typeof C=="object"&&typeof C[Symbol.iterator]=="function"}s.is=e;const t=Object.freeze([]);function i(){return t}s.empty=i;function*n(C){yield C}s.single=n;function o(C){return e(C)?C:n(C)}s.wrap=o;function r(C){return C||t}s.from=r;function*a(C){for(let w=C.length-1;w>=0;w--)yield C[w]}s.reverse=a;function l(C){return!C||C[Symbol.iterator]().next().done===!0}s.isEmpty=l;function d(C){return C[Symbol.iterator]().next().value}s.first=d;function c(C,w){for(const S of C)if(w(S))return!0;return!1}s.some=c;function u(C,w){for(const S of C)if(w(S))return S}s.find=u;function*h(C,w){for(const S of C)w(S)&&(yield S)}s.filter=h;function*f(C,w){let S=0;for(const L of C)yield w(L,S++)}s.map=f;function*g(...C){for(const w of C)yield*w}s.concat=g;function m(C,w,S){let L=S;for(const D of C)L=w(L,D);return L}s.reduce=m;function*_(C,w,S=C.length){for(w<0&&(w+=C.length),S<0?S+=C.length:S>C.length&&(S=C.length);w<S;w++)yield C[w]}s.slice=_;function v(C,w=Number.POSITIVE_INFINITY){const S=[];if(w===0)return[S,C];const L=C[Symbol.iterator]();for(let D=0;D<w;D++){const I=L.next();if(I.done)return[S,s.empty()];S.push(I.value)}return[S,{[Symbol.iterator](){return L}}]}s.consume=v;async function b(C){const w=[];for await(const S of C)w.push(S);return Promise.resolve(w)}s.asyncToArray=b})(kt||(kt={}));let Tn=class uM{constructor(e){this.element=e,this.next=uM.Undefined,this.prev=uM.Undefined}};Tn.Undefined=new Tn(void 0);class ao{constructor(){this._first=Tn.Undefined,this._last=Tn.Undefined,this._size=0}get size(){return this._size}isEmpty(){return this._first===Tn.Undefined}clear(){let e=this._first;for(;e!==Tn.Undefined;){const t=e.next;e.prev=Tn.Undefined,e.next=Tn.Undefined,e=t}this._first=Tn.Undefined,this._last=Tn.Undefined,this._size=0}unshift(e){return this._insert(e,!1)}push(e){return this._insert(e,!0)}_insert(e,t){const i=new Tn(e);if(this._first===Tn.Undefined)this._first=i,this._last=i;else if(t){const o=this._last;this._last=i,i.prev=o,o.next=i}else{const o=this._first;this._first=i,i.next=o,o.prev=i}this._size+=1;let n=!1;return()=>{n||(n=!0,this._remove(i))}}shift(){if(this._first!==Tn.Undefined){const e=this._first.element;return this._remove(this._first),e}}pop(){if(this._last!==Tn.Undefined){const e=this._last.element;return this._remove(this._last),e}}_remove(e){if(e.prev!==Tn.Undefined&&e.next!==Tn.Undefined){const t=e.prev;t.next=e.next,e.next.prev=t}else e.prev===Tn.Undefined&&e.next===Tn.Undefined?(this._first=Tn.Undefined,this._last=Tn.Undefined):e.next===Tn.Undefined?(this._last=this._last.prev,this._last.next=Tn.Undefined):e.prev===Tn.Undefined&&(this._first=this._first.next,this._first.prev=Tn.Undefined);this._size-=1}*[Symbol.iterator](){let e=this._first;for(;e!==Tn.Undefined;)yield e.element,e=e.next}}const zz="`~!@#$%^&*()-=+[{]}\\|;:'\",.<>/?";function pte(s=""){let e="(-?\\d*\\.\\d\\w*)|([^";for(const t of zz)s.indexOf(t)>=0||(e+="\\"+t);return e+="\\s]+)",new RegExp(e,"g")}const pF=pte();function mF(s){let e=pF;if(s&&s instanceof RegExp)if(s.global)e=s;else{let t="g";s.ignoreCase&&(t+="i"),s.multiline&&(t+="m"),s.unicode&&(t+="u"),e=new RegExp(s.source,t)}return e.lastIndex=0,e}const Uz=new ao;Uz.unshift({maxLen:1e3,windowSize:15,timeBudget:150});function gC(s,e,t,i,n){if(e=mF(e),n||(n=kt.first(Uz)),t.length>n.maxLen){let d=s-n.maxLen/2;return d<0?d=0:i+=d,t=t.substring(d,s+n.maxLen/2),gC(s,e,t,i,n)}const o=Date.now(),r=s-1-i;let a=-1,l=null;for(let d=1;!(Date.now()-o>=n.timeBudget);d++){const c=r-n.windowSize*d;e.lastIndex=Math.max(0,c);const u=mte(e,t,r,a);if(!u&&l||(l=u,c<=0))break;a=c}if(l){const d={word:l[0],startColumn:i+1+l.index,endColumn:i+1+l.index+l[0].length};return e.lastIndex=0,d}return null}function mte(s,e,t,i){let n;for(;n=s.exec(e);){const o=n.index||0;if(o<=t&&s.lastIndex>=t)return n;if(i>0&&o>i)return null}return null}const ld=8;class $z{constructor(e){this._values=e}hasChanged(e){return this._values[e]}}class jz{constructor(){this.stableMinimapLayoutInput=null,this.stableFitMaxMinimapScale=0,this.stableFitRemainingWidth=0}}class Oi{constructor(e,t,i,n){this.id=e,this.name=t,this.defaultValue=i,this.schema=n}applyUpdate(e,t){return XD(e,t)}compute(e,t,i){return i}}class y0{constructor(e,t){this.newValue=e,this.didChange=t}}function XD(s,e){if(typeof s!="object"||typeof e!="object"||!s||!e)return new y0(e,s!==e);if(Array.isArray(s)||Array.isArray(e)){const i=Array.isArray(s)&&Array.isArray(e)&&Ki(s,e);return new y0(e,!i)}let t=!1;for(const i in e)if(e.hasOwnProperty(i)){const n=XD(s[i],e[i]);n.didChange&&(s[i]=n.newValue,t=!0)}return new y0(s,t)}class ew{constructor(e){this.schema=void 0,this.id=e,this.name="_never_",this.defaultValue=void 0}applyUpdate(e,t){return XD(e,t)}validate(e){return this.defaultValue}}class Iv{constructor(e,t,i,n){this.id=e,this.name=t,this.defaultValue=i,this.schema=n}applyUpdate(e,t){return XD(e,t)}validate(e){return typeof e>"u"?this.defaultValue:e}compute(e,t,i){return i}}function Fe(s,e){return typeof s>"u"?e:s==="false"?!1:!!s}class Rt extends Iv{constructor(e,t,i,n=void 0){typeof n<"u"&&(n.type="boolean",n.default=i),super(e,t,i,n)}validate(e){return Fe(e,this.defaultValue)}}function og(s,e,t,i){if(typeof s>"u")return e;let n=parseInt(s,10);return isNaN(n)?e:(n=Math.max(t,n),n=Math.min(i,n),n|0)}class hi extends Iv{static clampedInt(e,t,i,n){return og(e,t,i,n)}constructor(e,t,i,n,o,r=void 0){typeof r<"u"&&(r.type="integer",r.default=i,r.minimum=n,r.maximum=o),super(e,t,i,r),this.minimum=n,this.maximum=o}validate(e){return hi.clampedInt(e,this.defaultValue,this.minimum,this.maximum)}}function _te(s,e,t,i){if(typeof s>"u")return e;const n=sa.float(s,e);return sa.clamp(n,t,i)}class sa extends Iv{static clamp(e,t,i){return e<t?t:e>i?i:e}static float(e,t){if(typeof e=="number")return e;if(typeof e>"u")return t;const i=parseFloat(e);return isNaN(i)?t:i}constructor(e,t,i,n,o){typeof o<"u"&&(o.type="number",o.default=i),super(e,t,i,o),this.validationFn=n}validate(e){return this.validationFn(sa.float(e,this.defaultValue))}}class to extends Iv{static string(e,t){return typeof e!="string"?t:e}constructor(e,t,i,n=void 0){typeof n<"u"&&(n.type="string",n.default=i),super(e,t,i,n)}validate(e){return to.string(e,this.defaultValue)}}function sn(s,e,t,i){return typeof s!="string"?e:i&&s in i?i[s]:t.indexOf(s)===-1?e:s}class Gi extends Iv{constructor(e,t,i,n,o=void 0){typeof o<"u"&&(o.type="string",o.enum=n,o.default=i),super(e,t,i,o),this._allowedValues=n}validate(e){return sn(e,this.defaultValue,this._allowedValues)}}class lS extends Oi{constructor(e,t,i,n,o,r,a=void 0){typeof a<"u"&&(a.type="string",a.enum=o,a.default=n),super(e,t,i,a),this._allowedValues=o,this._convert=r}validate(e){return typeof e!="string"?this.defaultValue:this._allowedValues.indexOf(e)===-1?this.defaultValue:this._convert(e)}}function vte(s){switch(s){case"none":return 0;case"keep":return 1;case"brackets":return 2;case"advanced":return 3;case"full":return 4}}class bte extends Oi{constructor(){super(2,"accessibilitySupport",0,{type:"string",enum:["auto","on","off"],enumDescriptions:[p("accessibilitySupport.auto","Use platform APIs to detect when a Screen Reader is attached."),p("accessibilitySupport.on","Optimize for usage with a Screen Reader."),p("accessibilitySupport.off","Assume a screen reader is not attached.")],default:"auto",tags:["accessibility"],description:p("accessibilitySupport","Controls if the UI should run in a mode where it is optimized for screen readers.")})}validate(e){switch(e){case"auto":return 0;case"off":return 1;case"on":return 2}return this.defaultValue}compute(e,t,i){return i===0?e.accessibilitySupport:i}}class Cte extends Oi{constructor(){const e={insertSpace:!0,ignoreEmptyLines:!0};super(23,"comments",e,{"editor.comments.insertSpace":{type:"boolean",default:e.insertSpace,description:p("comments.insertSpace","Controls whether a space character is inserted when commenting.")},"editor.comments.ignoreEmptyLines":{type:"boolean",default:e.ignoreEmptyLines,description:p("comments.ignoreEmptyLines","Controls if empty lines should be ignored with toggle, add or remove actions for line comments.")}})}validate(e){if(!e||typeof e!="object")return this.defaultValue;const t=e;return{insertSpace:Fe(t.insertSpace,this.defaultValue.insertSpace),ignoreEmptyLines:Fe(t.ignoreEmptyLines,this.defaultValue.ignoreEmptyLines)}}}function wte(s){switch(s){case"blink":return 1;case"smooth":return 2;case"phase":return 3;case"expand":return 4;case"solid":return 5}}var ls;(function(s){s[s.Line=1]="Line",s[s.Block=2]="Block",s[s.Underline=3]="Underline",s[s.LineThin=4]="LineThin",s[s.BlockOutline=5]="BlockOutline",s[s.UnderlineThin=6]="UnderlineThin"})(ls||(ls={}));function Ste(s){switch(s){case"line":return ls.Line;case"block":return ls.Block;case"underline":return ls.Underline;case"line-thin":return ls.LineThin;case"block-outline":return ls.BlockOutline;case"underline-thin":return ls.UnderlineThin}}class yte extends ew{constructor(){super(142)}compute(e,t,i){const n=["monaco-editor"];return t.get(39)&&n.push(t.get(39)),e.extraEditorClassName&&n.push(e.extraEditorClassName),t.get(74)==="default"?n.push("mouse-default"):t.get(74)==="copy"&&n.push("mouse-copy"),t.get(111)&&n.push("showUnused"),t.get(140)&&n.push("showDeprecated"),n.join(" ")}}class Lte extends Rt{constructor(){super(37,"emptySelectionClipboard",!0,{description:p("emptySelectionClipboard","Controls whether copying without a selection copies the current line.")})}compute(e,t,i){return i&&e.emptySelectionClipboard}}class xte extends Oi{constructor(){const e={cursorMoveOnType:!0,seedSearchStringFromSelection:"always",autoFindInSelection:"never",globalFindClipboard:!1,addExtraSpaceOnTop:!0,loop:!0};super(41,"find",e,{"editor.find.cursorMoveOnType":{type:"boolean",default:e.cursorMoveOnType,description:p("find.cursorMoveOnType","Controls whether the cursor should jump to find matches while typing.")},"editor.find.seedSearchStringFromSelection":{type:"string",enum:["never","always","selection"],default:e.seedSearchStringFromSelection,enumDescriptions:[p("editor.find.seedSearchStringFromSelection.never","Never seed search string from the editor selection."),p("editor.find.seedSearchStringFromSelection.always","Always seed search string from the editor selection, including word at cursor position."),p("editor.find.seedSearchStringFromSelection.selection","Only seed search string from the editor selection.")],description:p("find.seedSearchStringFromSelection","Controls whether the search string in the Find Widget is seeded from the editor selection.")},"editor.find.autoFindInSelection":{type:"string",enum:["never","always","multiline"],default:e.autoFindInSelection,enumDescriptions:[p("editor.find.autoFindInSelection.never","Never turn on Find in Selection automatically (default)."),p("editor.find.autoFindInSelection.always","Always turn on Find in Selection automatically."),p("editor.find.autoFindInSelection.multiline","Turn on Find in Selection automatically when multiple lines of content are selected.")],description:p("find.autoFindInSelection","Controls the condition for turning on Find in Selection automatically.")},"editor.find.globalFindClipboard":{type:"boolean",default:e.globalFindClipboard,description:p("find.globalFindClipboard","Controls whether the Find Widget should read or modify the shared find clipboard on macOS."),included:St},"editor.find.addExtraSpaceOnTop":{type:"boolean",default:e.addExtraSpaceOnTop,description:p("find.addExtraSpaceOnTop","Controls whether the Find Widget should add extra lines on top of the editor. When true, you can scroll beyond the first line when the Find Widget is visible.")},"editor.find.loop":{type:"boolean",default:e.loop,description:p("find.loop","Controls whether the search automatically restarts from the beginning (or the end) when no further matches can be found.")}})}validate(e){if(!e||typeof e!="object")return this.defaultValue;const t=e;return{cursorMoveOnType:Fe(t.cursorMoveOnType,this.defaultValue.cursorMoveOnType),seedSearchStringFromSelection:typeof e.seedSearchStringFromSelection=="boolean"?e.seedSearchStringFromSelection?"always":"never":sn(t.seedSearchStringFromSelection,this.defaultValue.seedSearchStringFromSelection,["never","always","selection"]),autoFindInSelection:typeof e.autoFindInSelection=="boolean"?e.autoFindInSelection?"always":"never":sn(t.autoFindInSelection,this.defaultValue.autoFindInSelection,["never","always","multiline"]),globalFindClipboard:Fe(t.globalFindClipboard,this.defaultValue.globalFindClipboard),addExtraSpaceOnTop:Fe(t.addExtraSpaceOnTop,this.defaultValue.addExtraSpaceOnTop),loop:Fe(t.loop,this.defaultValue.loop)}}}class kr extends Oi{constructor(){super(51,"fontLigatures",kr.OFF,{anyOf:[{type:"boolean",description:p("fontLigatures","Enables/Disables font ligatures ('calt' and 'liga' font features). Change this to a string for fine-grained control of the 'font-feature-settings' CSS property.")},{type:"string",description:p("fontFeatureSettings","Explicit 'font-feature-settings' CSS property. A boolean can be passed instead if one only needs to turn on/off ligatures.")}],description:p("fontLigaturesGeneral","Configures font ligatures or font features. Can be either a boolean to enable/disable ligatures or a string for the value of the CSS 'font-feature-settings' property."),default:!1})}validate(e){return typeof e>"u"?this.defaultValue:typeof e=="string"?e==="false"||e.length===0?kr.OFF:e==="true"?kr.ON:e:e?kr.ON:kr.OFF}}kr.OFF='"liga" off, "calt" off';kr.ON='"liga" on, "calt" on';class _l extends Oi{constructor(){super(54,"fontVariations",_l.OFF,{anyOf:[{type:"boolean",description:p("fontVariations","Enables/Disables the translation from font-weight to font-variation-settings. Change this to a string for fine-grained control of the 'font-variation-settings' CSS property.")},{type:"string",description:p("fontVariationSettings","Explicit 'font-variation-settings' CSS property. A boolean can be passed instead if one only needs to translate font-weight to font-variation-settings.")}],description:p("fontVariationsGeneral","Configures font variations. Can be either a boolean to enable/disable the translation from font-weight to font-variation-settings or a string for the value of the CSS 'font-variation-settings' property."),default:!1})}validate(e){return typeof e>"u"?this.defaultValue:typeof e=="string"?e==="false"?_l.OFF:e==="true"?_l.TRANSLATE:e:e?_l.TRANSLATE:_l.OFF}compute(e,t,i){return e.fontInfo.fontVariationSettings}}_l.OFF="normal";_l.TRANSLATE="translate";class Dte extends ew{constructor(){super(50)}compute(e,t,i){return e.fontInfo}}class kte extends Iv{constructor(){super(52,"fontSize",Oo.fontSize,{type:"number",minimum:6,maximum:100,default:Oo.fontSize,description:p("fontSize","Controls the font size in pixels.")})}validate(e){const t=sa.float(e,this.defaultValue);return t===0?Oo.fontSize:sa.clamp(t,6,100)}compute(e,t,i){return e.fontInfo.fontSize}}class Sd extends Oi{constructor(){super(53,"fontWeight",Oo.fontWeight,{anyOf:[{type:"number",minimum:Sd.MINIMUM_VALUE,maximum:Sd.MAXIMUM_VALUE,errorMessage:p("fontWeightErrorMessage",'Only "normal" and "bold" keywords or numbers between 1 and 1000 are allowed.')},{type:"string",pattern:"^(normal|bold|1000|[1-9][0-9]{0,2})$"},{enum:Sd.SUGGESTION_VALUES}],default:Oo.fontWeight,description:p("fontWeight",'Controls the font weight. Accepts "normal" and "bold" keywords or numbers between 1 and 1000.')})}validate(e){return e==="normal"||e==="bold"?e:String(hi.clampedInt(e,Oo.fontWeight,Sd.MINIMUM_VALUE,Sd.MAXIMUM_VALUE))}}Sd.SUGGESTION_VALUES=["normal","bold","100","200","300","400","500","600","700","800","900"];Sd.MINIMUM_VALUE=1;Sd.MAXIMUM_VALUE=1e3;class Ite extends Oi{constructor(){const e={multiple:"peek",multipleDefinitions:"peek",multipleTypeDefinitions:"peek",multipleDeclarations:"peek",multipleImplementations:"peek",multipleReferences:"peek",alternativeDefinitionCommand:"editor.action.goToReferences",alternativeTypeDefinitionCommand:"editor.action.goToReferences",alternativeDeclarationCommand:"editor.action.goToReferences",alternativeImplementationCommand:"",alternativeReferenceCommand:""},t={type:"string",enum:["peek","gotoAndPeek","goto"],default:e.multiple,enumDescriptions:[p("editor.gotoLocation.multiple.peek","Show Peek view of the results (default)"),p("editor.gotoLocation.multiple.gotoAndPeek","Go to the primary result and show a Peek view"),p("editor.gotoLocation.multiple.goto","Go to the primary result and enable Peek-less navigation to others")]},i=["","editor.action.referenceSearch.trigger","editor.action.goToReferences","editor.action.peekImplementation","editor.action.goToImplementation","editor.action.peekTypeDefinition","editor.action.goToTypeDefinition","editor.action.peekDeclaration","editor.action.revealDeclaration","editor.action.peekDefinition","editor.action.revealDefinitionAside","editor.action.revealDefinition"];super(58,"gotoLocation",e,{"editor.gotoLocation.multiple":{deprecationMessage:p("editor.gotoLocation.multiple.deprecated","This setting is deprecated, please use separate settings like 'editor.editor.gotoLocation.multipleDefinitions' or 'editor.editor.gotoLocation.multipleImplementations' instead.")},"editor.gotoLocation.multipleDefinitions":{description:p("editor.editor.gotoLocation.multipleDefinitions","Controls the behavior the 'Go to Definition'-command when multiple target locations exist."),...t},"editor.gotoLocation.multipleTypeDefinitions":{description:p("editor.editor.gotoLocation.multipleTypeDefinitions","Controls the behavior the 'Go to Type Definition'-command when multiple target locations exist."),...t},"editor.gotoLocation.multipleDeclarations":{description:p("editor.editor.gotoLocation.multipleDeclarations","Controls the behavior the 'Go to Declaration'-command when multiple target locations exist."),...t},"editor.gotoLocation.multipleImplementations":{description:p("editor.editor.gotoLocation.multipleImplemenattions","Controls the behavior the 'Go to Implementations'-command when multiple target locations exist."),...t},"editor.gotoLocation.multipleReferences":{description:p("editor.editor.gotoLocation.multipleReferences","Controls the behavior the 'Go to References'-command when multiple target locations exist."),...t},"editor.gotoLocation.alternativeDefinitionCommand":{type:"string",default:e.alternativeDefinitionCommand,enum:i,description:p("alternativeDefinitionCommand","Alternative command id that is being executed when the result of 'Go to Definition' is the current location.")},"editor.gotoLocation.alternativeTypeDefinitionCommand":{type:"string",default:e.alternativeTypeDefinitionCommand,enum:i,description:p("alternativeTypeDefinitionCommand","Alternative command id that is being executed when the result of 'Go to Type Definition' is the current location.")},"editor.gotoLocation.alternativeDeclarationCommand":{type:"string",default:e.alternativeDeclarationCommand,enum:i,description:p("alternativeDeclarationCommand","Alternative command id that is being executed when the result of 'Go to Declaration' is the current location.")},"editor.gotoLocation.alternativeImplementationCommand":{type:"string",default:e.alternativeImplementationCommand,enum:i,description:p("alternativeImplementationCommand","Alternative command id that is being executed when the result of 'Go to Implementation' is the current location.")},"editor.gotoLocation.alternativeReferenceCommand":{type:"string",default:e.alternativeReferenceCommand,enum:i,description:p("alternativeReferenceCommand","Alternative command id that is being executed when the result of 'Go to Reference' is the current location.")}})}validate(e){var t,i,n,o,r;if(!e||typeof e!="object")return this.defaultValue;const a=e;return{multiple:sn(a.multiple,this.defaultValue.multiple,["peek","gotoAndPeek","goto"]),multipleDefinitions:(t=a.multipleDefinitions)!==null&&t!==void 0?t:sn(a.multipleDefinitions,"peek",["peek","gotoAndPeek","goto"]),multipleTypeDefinitions:(i=a.multipleTypeDefinitions)!==null&&i!==void 0?i:sn(a.multipleTypeDefinitions,"peek",["peek","gotoAndPeek","goto"]),multipleDeclarations:(n=a.multipleDeclarations)!==null&&n!==void 0?n:sn(a.multipleDeclarations,"peek",["peek","gotoAndPeek","goto"]),multipleImplementations:(o=a.multipleImplementations)!==null&&o!==void 0?o:sn(a.multipleImplementations,"peek",["peek","gotoAndPeek","goto"]),multipleReferences:(r=a.multipleReferences)!==null&&r!==void 0?r:sn(a.multipleReferences,"peek",["peek","gotoAndPeek","goto"]),alternativeDefinitionCommand:to.string(a.alternativeDefinitionCommand,this.defaultValue.alternativeDefinitionCommand),alternativeTypeDefinitionCommand:to.string(a.alternativeTypeDefinitionCommand,this.defaultValue.alternativeTypeDefinitionCommand),alternativeDeclarationCommand:to.string(a.alternativeDeclarationCommand,this.defaultValue.alternativeDeclarationCommand),alternativeImplementationCommand:to.string(a.alternativeImplementationCommand,this.defaultValue.alternativeImplementationCommand),alternativeReferenceCommand:to.string(a.alternativeReferenceCommand,this.defaultValue.alternativeReferenceCommand)}}}class Ete extends Oi{constructor(){const e={enabled:!0,delay:300,hidingDelay:300,sticky:!0,above:!0};super(60,"hover",e,{"editor.hover.enabled":{type:"boolean",default:e.enabled,description:p("hover.enabled","Controls whether the hover is shown.")},"editor.hover.delay":{type:"number",default:e.delay,minimum:0,maximum:1e4,description:p("hover.delay","Controls the delay in milliseconds after which the hover is shown.")},"editor.hover.sticky":{type:"boolean",default:e.sticky,description:p("hover.sticky","Controls whether the hover should remain visible when mouse is moved over it.")},"editor.hover.hidingDelay":{type:"integer",minimum:0,default:e.hidingDelay,description:p("hover.hidingDelay","Controls the delay in milliseconds after which the hover is hidden. Requires `editor.hover.sticky` to be enabled.")},"editor.hover.above":{type:"boolean",default:e.above,description:p("hover.above","Prefer showing hovers above the line, if there's space.")}})}validate(e){if(!e||typeof e!="object")return this.defaultValue;const t=e;return{enabled:Fe(t.enabled,this.defaultValue.enabled),delay:hi.clampedInt(t.delay,this.defaultValue.delay,0,1e4),sticky:Fe(t.sticky,this.defaultValue.sticky),hidingDelay:hi.clampedInt(t.hidingDelay,this.defaultValue.hidingDelay,0,6e5),above:Fe(t.above,this.defaultValue.above)}}}class o_ extends ew{constructor(){super(145)}compute(e,t,i){return o_.computeLayout(t,{memory:e.memory,outerWidth:e.outerWidth,outerHeight:e.outerHeight,isDominatedByLongLines:e.isDominatedByLongLines,lineHeight:e.fontInfo.lineHeight,viewLineCount:e.viewLineCount,lineNumbersDigitCount:e.lineNumbersDigitCount,typicalHalfwidthCharacterWidth:e.fontInfo.typicalHalfwidthCharacterWidth,maxDigitWidth:e.fontInfo.maxDigitWidth,pixelRatio:e.pixelRatio,glyphMarginDecorationLaneCount:e.glyphMarginDecorationLaneCount})}static computeContainedMinimapLineCount(e){const t=e.height/e.lineHeight,i=Math.floor(e.paddingTop/e.lineHeight);let n=Math.floor(e.paddingBottom/e.lineHeight);e.scrollBeyondLastLine&&(n=Math.max(n,t-1));const o=(i+e.viewLineCount+n)/(e.pixelRatio*e.height),r=Math.floor(e.viewLineCount/o);return{typicalViewportLineCount:t,extraLinesBeforeFirstLine:i,extraLinesBeyondLastLine:n,desiredRatio:o,minimapLineCount:r}}static _computeMinimapLayout(e,t){const i=e.outerWidth,n=e.outerHeight,o=e.pixelRatio;if(!e.minimap.enabled)return{renderMinimap:0,minimapLeft:0,minimapWidth:0,minimapHeightIsEditorHeight:!1,minimapIsSampling:!1,minimapScale:1,minimapLineHeight:1,minimapCanvasInnerWidth:0,minimapCanvasInnerHeight:Math.floor(o*n),minimapCanvasOuterWidth:0,minimapCanvasOuterHeight:n};const r=t.stableMinimapLayoutInput,a=r&&e.outerHeight===r.outerHeight&&e.lineHeight===r.lineHeight&&e.typicalHalfwidthCharacterWidth===r.typicalHalfwidthCharacterWidth&&e.pixelRatio===r.pixelRatio&&e.scrollBeyondLastLine===r.scrollBeyondLastLine&&e.paddingTop===r.paddingTop&&e.paddingBottom===r.paddingBottom&&e.minimap.enabled===r.minimap.enabled&&e.minimap.side===r.minimap.side&&e.minimap.size===r.minimap.size&&e.minimap.showSlider===r.minimap.showSlider&&e.minimap.renderCharacters===r.minimap.renderCharacters&&e.minimap.maxColumn===r.minimap.maxColumn&&e.minimap.scale===r.minimap.scale&&e.verticalScrollbarWidth===r.verticalScrollbarWidth&&e.isViewportWrapping===r.isViewportWrapping,l=e.lineHeight,d=e.typicalHalfwidthCharacterWidth,c=e.scrollBeyondLastLine,u=e.minimap.renderCharacters;let h=o>=2?Math.round(e.minimap.scale*2):e.minimap.scale;const f=e.minimap.maxColumn,g=e.minimap.size,m=e.minimap.side,_=e.verticalScrollbarWidth,v=e.viewLineCount,b=e.remainingWidth,C=e.isViewportWrapping,w=u?2:3;let S=Math.floor(o*n);const L=S/o;let D=!1,I=!1,T=w*h,V=h/o,P=1;if(g==="fill"||g==="fit"){const{typicalViewportLineCount:le,extraLinesBeforeFirstLine:oe,extraLinesBeyondLastLine:Pe,desiredRatio:de,minimapLineCount:Ne}=o_.computeContainedMinimapLineCount({viewLineCount:v,scrollBeyondLastLine:c,paddingTop:e.paddingTop,paddingBottom:e.paddingBottom,height:n,lineHeight:l,pixelRatio:o});if(v/Ne>1)D=!0,I=!0,h=1,T=1,V=h/o;else{let di=!1,jt=h+1;if(g==="fit"){const nt=Math.ceil((oe+v+Pe)*T);C&&a&&b<=t.stableFitRemainingWidth?(di=!0,jt=t.stableFitMaxMinimapScale):di=nt>S}if(g==="fill"||di){D=!0;const nt=h;T=Math.min(l*o,Math.max(1,Math.floor(1/de))),C&&a&&b<=t.stableFitRemainingWidth&&(jt=t.stableFitMaxMinimapScale),h=Math.min(jt,Math.max(1,Math.floor(T/w))),h>nt&&(P=Math.min(2,h/nt)),V=h/o/P,S=Math.ceil(Math.max(le,oe+v+Pe)*T),C?(t.stableMinimapLayoutInput=e,t.stableFitRemainingWidth=b,t.stableFitMaxMinimapScale=h):(t.stableMinimapLayoutInput=null,t.stableFitRemainingWidth=0)}}}const A=Math.floor(f*V),B=Math.min(A,Math.max(0,Math.floor((b-_-2)*V/(d+V)))+ld);let Q=Math.floor(o*B);const ae=Q/o;Q=Math.floor(Q*P);const we=u?1:2,Le=m==="left"?0:i-B-_;return{renderMinimap:we,minimapLeft:Le,minimapWidth:B,minimapHeightIsEditorHeight:D,minimapIsSampling:I,minimapScale:h,minimapLineHeight:T,minimapCanvasInnerWidth:Q,minimapCanvasInnerHeight:S,minimapCanvasOuterWidth:ae,minimapCanvasOuterHeight:L}}static computeLayout(e,t){const i=t.outerWidth|0,n=t.outerHeight|0,o=t.lineHeight|0,r=t.lineNumbersDigitCount|0,a=t.typicalHalfwidthCharacterWidth,l=t.maxDigitWidth,d=t.pixelRatio,c=t.viewLineCount,u=e.get(137),h=u==="inherit"?e.get(136):u,f=h==="inherit"?e.get(132):h,g=e.get(135),m=t.isDominatedByLongLines,_=e.get(57),v=e.get(68).renderType!==0,b=e.get(69),C=e.get(105),w=e.get(84),S=e.get(73),L=e.get(103),D=L.verticalScrollbarSize,I=L.verticalHasArrows,T=L.arrowSize,V=L.horizontalScrollbarSize,P=e.get(43),A=e.get(110)!=="never";let B=e.get(66);P&&A&&(B+=16);let Q=0;if(v){const Qt=Math.max(r,b);Q=Math.round(Qt*l)}let ae=0;_&&(ae=o*t.glyphMarginDecorationLaneCount);let we=0,Le=we+ae,le=Le+Q,oe=le+B;const Pe=i-ae-Q-B;let de=!1,Ne=!1,be=-1;h==="inherit"&&m?(de=!0,Ne=!0):f==="on"||f==="bounded"?Ne=!0:f==="wordWrapColumn"&&(be=g);const di=o_._computeMinimapLayout({outerWidth:i,outerHeight:n,lineHeight:o,typicalHalfwidthCharacterWidth:a,pixelRatio:d,scrollBeyondLastLine:C,paddingTop:w.top,paddingBottom:w.bottom,minimap:S,verticalScrollbarWidth:D,viewLineCount:c,remainingWidth:Pe,isViewportWrapping:Ne},t.memory||new jz);di.renderMinimap!==0&&di.minimapLeft===0&&(we+=di.minimapWidth,Le+=di.minimapWidth,le+=di.minimapWidth,oe+=di.minimapWidth);const jt=Pe-di.minimapWidth,nt=Math.max(1,Math.floor((jt-D-2)/a)),Ct=I?T:0;return Ne&&(be=Math.max(1,nt),f==="bounded"&&(be=Math.min(be,g))),{width:i,height:n,glyphMarginLeft:we,glyphMarginWidth:ae,glyphMarginDecorationLaneCount:t.glyphMarginDecorationLaneCount,lineNumbersLeft:Le,lineNumbersWidth:Q,decorationsLeft:le,decorationsWidth:B,contentLeft:oe,contentWidth:jt,minimap:di,viewportColumn:nt,isWordWrapMinified:de,isViewportWrapping:Ne,wrappingColumn:be,verticalScrollbarWidth:D,horizontalScrollbarHeight:V,overviewRuler:{top:Ct,width:D,height:n-2*Ct,right:0}}}}class Nte extends Oi{constructor(){super(139,"wrappingStrategy","simple",{"editor.wrappingStrategy":{enumDescriptions:[p("wrappingStrategy.simple","Assumes that all characters are of the same width. This is a fast algorithm that works correctly for monospace fonts and certain scripts (like Latin characters) where glyphs are of equal width."),p("wrappingStrategy.advanced","Delegates wrapping points computation to the browser. This is a slow algorithm, that might cause freezes for large files, but it works correctly in all cases.")],type:"string",enum:["simple","advanced"],default:"simple",description:p("wrappingStrategy","Controls the algorithm that computes wrapping points. Note that when in accessibility mode, advanced will be used for the best experience.")}})}validate(e){return sn(e,"simple",["simple","advanced"])}compute(e,t,i){return t.get(2)===2?"advanced":i}}var Ma;(function(s){s.Off="off",s.OnCode="onCode",s.On="on"})(Ma||(Ma={}));class Tte extends Oi{constructor(){const e={enabled:Ma.On};super(65,"lightbulb",e,{"editor.lightbulb.enabled":{type:"string",tags:["experimental"],enum:[Ma.Off,Ma.OnCode,Ma.On],default:e.enabled,enumDescriptions:[p("editor.lightbulb.enabled.off","Disable the code action menu."),p("editor.lightbulb.enabled.onCode","Show the code action menu when the cursor is on lines with code."),p("editor.lightbulb.enabled.on","Show the code action menu when the cursor is on lines with code or on empty lines.")],description:p("enabled","Enables the Code Action lightbulb in the editor.")}})}validate(e){return!e||typeof e!="object"?this.defaultValue:{enabled:sn(e.enabled,this.defaultValue.enabled,[Ma.Off,Ma.OnCode,Ma.On])}}}class Mte extends Oi{constructor(){const e={enabled:!0,maxLineCount:5,defaultModel:"outlineModel",scrollWithEditor:!0};super(115,"stickyScroll",e,{"editor.stickyScroll.enabled":{type:"boolean",default:e.enabled,description:p("editor.stickyScroll.enabled","Shows the nested current scopes during the scroll at the top of the editor."),tags:["experimental"]},"editor.stickyScroll.maxLineCount":{type:"number",default:e.maxLineCount,minimum:1,maximum:20,description:p("editor.stickyScroll.maxLineCount","Defines the maximum number of sticky lines to show.")},"editor.stickyScroll.defaultModel":{type:"string",enum:["outlineModel","foldingProviderModel","indentationModel"],default:e.defaultModel,description:p("editor.stickyScroll.defaultModel","Defines the model to use for determining which lines to stick. If the outline model does not exist, it will fall back on the folding provider model which falls back on the indentation model. This order is respected in all three cases.")},"editor.stickyScroll.scrollWithEditor":{type:"boolean",default:e.scrollWithEditor,description:p("editor.stickyScroll.scrollWithEditor","Enable scrolling of Sticky Scroll with the editor's horizontal scrollbar.")}})}validate(e){if(!e||typeof e!="object")return this.defaultValue;const t=e;return{enabled:Fe(t.enabled,this.defaultValue.enabled),maxLineCount:hi.clampedInt(t.maxLineCount,this.defaultValue.maxLineCount,1,20),defaultModel:sn(t.defaultModel,this.defaultValue.defaultModel,["outlineModel","foldingProviderModel","indentationModel"]),scrollWithEditor:Fe(t.scrollWithEditor,this.defaultValue.scrollWithEditor)}}}class Rte extends Oi{constructor(){const e={enabled:"on",fontSize:0,fontFamily:"",padding:!1};super(141,"inlayHints",e,{"editor.inlayHints.enabled":{type:"string",default:e.enabled,description:p("inlayHints.enable","Enables the inlay hints in the editor."),enum:["on","onUnlessPressed","offUnlessPressed","off"],markdownEnumDescriptions:[p("editor.inlayHints.on","Inlay hints are enabled"),p("editor.inlayHints.onUnlessPressed","Inlay hints are showing by default and hide when holding {0}",St?"Ctrl+Option":"Ctrl+Alt"),p("editor.inlayHints.offUnlessPressed","Inlay hints are hidden by default and show when holding {0}",St?"Ctrl+Option":"Ctrl+Alt"),p("editor.inlayHints.off","Inlay hints are disabled")]},"editor.inlayHints.fontSize":{type:"number",default:e.fontSize,markdownDescription:p("inlayHints.fontSize","Controls font size of inlay hints in the editor. As default the {0} is used when the configured value is less than {1} or greater than the editor font size.","`#editor.fontSize#`","`5`")},"editor.inlayHints.fontFamily":{type:"string",default:e.fontFamily,markdownDescription:p("inlayHints.fontFamily","Controls font family of inlay hints in the editor. When set to empty, the {0} is used.","`#editor.fontFamily#`")},"editor.inlayHints.padding":{type:"boolean",default:e.padding,description:p("inlayHints.padding","Enables the padding around the inlay hints in the editor.")}})}validate(e){if(!e||typeof e!="object")return this.defaultValue;const t=e;return typeof t.enabled=="boolean"&&(t.enabled=t.enabled?"on":"off"),{enabled:sn(t.enabled,this.defaultValue.enabled,["on","off","offUnlessPressed","onUnlessPressed"]),fontSize:hi.clampedInt(t.fontSize,this.defaultValue.fontSize,0,100),fontFamily:to.string(t.fontFamily,this.defaultValue.fontFamily),padding:Fe(t.padding,this.defaultValue.padding)}}}class Ate extends Oi{constructor(){super(66,"lineDecorationsWidth",10)}validate(e){return typeof e=="string"&&/^\d+(\.\d+)?ch$/.test(e)?-parseFloat(e.substring(0,e.length-2)):hi.clampedInt(e,this.defaultValue,0,1e3)}compute(e,t,i){return i<0?hi.clampedInt(-i*e.fontInfo.typicalHalfwidthCharacterWidth,this.defaultValue,0,1e3):i}}class Pte extends sa{constructor(){super(67,"lineHeight",Oo.lineHeight,e=>sa.clamp(e,0,150),{markdownDescription:p("lineHeight",`Controls the line height. 
  - Use 0 to automatically compute the line height from the font size.
  - Values between 0 and 8 will be used as a multiplier with the font size.
  - Values greater than or equal to 8 will be used as effective values.`)})}compute(e,t,i){return e.fontInfo.lineHeight}}class Ote extends Oi{constructor(){const e={enabled:!0,size:"proportional",side:"right",showSlider:"mouseover",autohide:!1,renderCharacters:!0,maxColumn:120,scale:1,showRegionSectionHeaders:!0,showMarkSectionHeaders:!0,sectionHeaderFontSize:9};super(73,"minimap",e,{"editor.minimap.enabled":{type:"boolean",default:e.enabled,description:p("minimap.enabled","Controls whether the minimap is shown.")},"editor.minimap.autohide":{type:"boolean",default:e.autohide,description:p("minimap.autohide","Controls whether the minimap is hidden automatically.")},"editor.minimap.size":{type:"string",enum:["proportional","fill","fit"],enumDescriptions:[p("minimap.size.proportional","The minimap has the same size as the editor contents (and might scroll)."),p("minimap.size.fill","The minimap will stretch or shrink as necessary to fill the height of the editor (no scrolling)."),p("minimap.size.fit","The minimap will shrink as necessary to never be larger than the editor (no scrolling).")],default:e.size,description:p("minimap.size","Controls the size of the minimap.")},"editor.minimap.side":{type:"string",enum:["left","right"],default:e.side,description:p("minimap.side","Controls the side where to render the minimap.")},"editor.minimap.showSlider":{type:"string",enum:["always","mouseover"],default:e.showSlider,description:p("minimap.showSlider","Controls when the minimap slider is shown.")},"editor.minimap.scale":{type:"number",default:e.scale,minimum:1,maximum:3,enum:[1,2,3],description:p("minimap.scale","Scale of content drawn in the minimap: 1, 2 or 3.")},"editor.minimap.renderCharacters":{type:"boolean",default:e.renderCharacters,description:p("minimap.renderCharacters","Render the actual characters on a line as opposed to color blocks.")},"editor.minimap.maxColumn":{type:"number",default:e.maxColumn,description:p("minimap.maxColumn","Limit the width of the minimap to render at most a certain number of columns.")},"editor.minimap.showRegionSectionHeaders":{type:"boolean",default:e.showRegionSectionHeaders,description:p("minimap.showRegionSectionHeaders","Controls whether named regions are shown as section headers in the minimap.")},"editor.minimap.showMarkSectionHeaders":{type:"boolean",default:e.showMarkSectionHeaders,description:p("minimap.showMarkSectionHeaders","Controls whether MARK: comments are shown as section headers in the minimap.")},"editor.minimap.sectionHeaderFontSize":{type:"number",default:e.sectionHeaderFontSize,description:p("minimap.sectionHeaderFontSize","Controls the font size of section headers in the minimap.")}})}validate(e){var t;if(!e||typeof e!="object")return this.defaultValue;const i=e;return{enabled:Fe(i.enabled,this.defaultValue.enabled),autohide:Fe(i.autohide,this.defaultValue.autohide),size:sn(i.size,this.defaultValue.size,["proportional","fill","fit"]),side:sn(i.side,this.defaultValue.side,["right","left"]),showSlider:sn(i.showSlider,this.defaultValue.showSlider,["always","mouseover"]),renderCharacters:Fe(i.renderCharacters,this.defaultValue.renderCharacters),scale:hi.clampedInt(i.scale,1,1,3),maxColumn:hi.clampedInt(i.maxColumn,this.defaultValue.maxColumn,1,1e4),showRegionSectionHeaders:Fe(i.showRegionSectionHeaders,this.defaultValue.showRegionSectionHeaders),showMarkSectionHeaders:Fe(i.showMarkSectionHeaders,this.defaultValue.showMarkSectionHeaders),sectionHeaderFontSize:sa.clamp((t=i.sectionHeaderFontSize)!==null&&t!==void 0?t:this.defaultValue.sectionHeaderFontSize,4,32)}}}function Fte(s){return s==="ctrlCmd"?St?"metaKey":"ctrlKey":"altKey"}class Bte extends Oi{constructor(){super(84,"padding",{top:0,bottom:0},{"editor.padding.top":{type:"number",default:0,minimum:0,maximum:1e3,description:p("padding.top","Controls the amount of space between the top edge of the editor and the first line.")},"editor.padding.bottom":{type:"number",default:0,minimum:0,maximum:1e3,description:p("padding.bottom","Controls the amount of space between the bottom edge of the editor and the last line.")}})}validate(e){if(!e||typeof e!="object")return this.defaultValue;const t=e;return{top:hi.clampedInt(t.top,0,0,1e3),bottom:hi.clampedInt(t.bottom,0,0,1e3)}}}class Wte extends Oi{constructor(){const e={enabled:!0,cycle:!0};super(86,"parameterHints",e,{"editor.parameterHints.enabled":{type:"boolean",default:e.enabled,description:p("parameterHints.enabled","Enables a pop-up that shows parameter documentation and type information as you type.")},"editor.parameterHints.cycle":{type:"boolean",default:e.cycle,description:p("parameterHints.cycle","Controls whether the parameter hints menu cycles or closes when reaching the end of the list.")}})}validate(e){if(!e||typeof e!="object")return this.defaultValue;const t=e;return{enabled:Fe(t.enabled,this.defaultValue.enabled),cycle:Fe(t.cycle,this.defaultValue.cycle)}}}class Hte extends ew{constructor(){super(143)}compute(e,t,i){return e.pixelRatio}}class Vte extends Oi{constructor(){const e={other:"on",comments:"off",strings:"off"},t=[{type:"boolean"},{type:"string",enum:["on","inline","off"],enumDescriptions:[p("on","Quick suggestions show inside the suggest widget"),p("inline","Quick suggestions show as ghost text"),p("off","Quick suggestions are disabled")]}];super(89,"quickSuggestions",e,{type:"object",additionalProperties:!1,properties:{strings:{anyOf:t,default:e.strings,description:p("quickSuggestions.strings","Enable quick suggestions inside strings.")},comments:{anyOf:t,default:e.comments,description:p("quickSuggestions.comments","Enable quick suggestions inside comments.")},other:{anyOf:t,default:e.other,description:p("quickSuggestions.other","Enable quick suggestions outside of strings and comments.")}},default:e,markdownDescription:p("quickSuggestions","Controls whether suggestions should automatically show up while typing. This can be controlled for typing in comments, strings, and other code. Quick suggestion can be configured to show as ghost text or with the suggest widget. Also be aware of the '{0}'-setting which controls if suggestions are triggered by special characters.","#editor.suggestOnTriggerCharacters#")}),this.defaultValue=e}validate(e){if(typeof e=="boolean"){const d=e?"on":"off";return{comments:d,strings:d,other:d}}if(!e||typeof e!="object")return this.defaultValue;const{other:t,comments:i,strings:n}=e,o=["on","inline","off"];let r,a,l;return typeof t=="boolean"?r=t?"on":"off":r=sn(t,this.defaultValue.other,o),typeof i=="boolean"?a=i?"on":"off":a=sn(i,this.defaultValue.comments,o),typeof n=="boolean"?l=n?"on":"off":l=sn(n,this.defaultValue.strings,o),{other:r,comments:a,strings:l}}}class zte extends Oi{constructor(){super(68,"lineNumbers",{renderType:1,renderFn:null},{type:"string",enum:["off","on","relative","interval"],enumDescriptions:[p("lineNumbers.off","Line numbers are not rendered."),p("lineNumbers.on","Line numbers are rendered as absolute number."),p("lineNumbers.relative","Line numbers are rendered as distance in lines to cursor position."),p("lineNumbers.interval","Line numbers are rendered every 10 lines.")],default:"on",description:p("lineNumbers","Controls the display of line numbers.")})}validate(e){let t=this.defaultValue.renderType,i=this.defaultValue.renderFn;return typeof e<"u"&&(typeof e=="function"?(t=4,i=e):e==="interval"?t=3:e==="relative"?t=2:e==="on"?t=1:t=0),{renderType:t,renderFn:i}}}function PL(s){const e=s.get(98);return e==="editable"?s.get(91):e!=="on"}class Ute extends Oi{constructor(){const e=[],t={type:"number",description:p("rulers.size","Number of monospace characters at which this editor ruler will render.")};super(102,"rulers",e,{type:"array",items:{anyOf:[t,{type:["object"],properties:{column:t,color:{type:"string",description:p("rulers.color","Color of this editor ruler."),format:"color-hex"}}}]},default:e,description:p("rulers","Render vertical rulers after a certain number of monospace characters. Use multiple values for multiple rulers. No rulers are drawn if array is empty.")})}validate(e){if(Array.isArray(e)){const t=[];for(const i of e)if(typeof i=="number")t.push({column:hi.clampedInt(i,0,0,1e4),color:null});else if(i&&typeof i=="object"){const n=i;t.push({column:hi.clampedInt(n.column,0,0,1e4),color:n.color})}return t.sort((i,n)=>i.column-n.column),t}return this.defaultValue}}class $te extends Oi{constructor(){super(92,"readOnlyMessage",void 0)}validate(e){return!e||typeof e!="object"?this.defaultValue:e}}function $B(s,e){if(typeof s!="string")return e;switch(s){case"hidden":return 2;case"visible":return 3;default:return 1}}let jte=class extends Oi{constructor(){const e={vertical:1,horizontal:1,arrowSize:11,useShadows:!0,verticalHasArrows:!1,horizontalHasArrows:!1,horizontalScrollbarSize:12,horizontalSliderSize:12,verticalScrollbarSize:14,verticalSliderSize:14,handleMouseWheel:!0,alwaysConsumeMouseWheel:!0,scrollByPage:!1,ignoreHorizontalScrollbarInContentHeight:!1};super(103,"scrollbar",e,{"editor.scrollbar.vertical":{type:"string",enum:["auto","visible","hidden"],enumDescriptions:[p("scrollbar.vertical.auto","The vertical scrollbar will be visible only when necessary."),p("scrollbar.vertical.visible","The vertical scrollbar will always be visible."),p("scrollbar.vertical.fit","The vertical scrollbar will always be hidden.")],default:"auto",description:p("scrollbar.vertical","Controls the visibility of the vertical scrollbar.")},"editor.scrollbar.horizontal":{type:"string",enum:["auto","visible","hidden"],enumDescriptions:[p("scrollbar.horizontal.auto","The horizontal scrollbar will be visible only when necessary."),p("scrollbar.horizontal.visible","The horizontal scrollbar will always be visible."),p("scrollbar.horizontal.fit","The horizontal scrollbar will always be hidden.")],default:"auto",description:p("scrollbar.horizontal","Controls the visibility of the horizontal scrollbar.")},"editor.scrollbar.verticalScrollbarSize":{type:"number",default:e.verticalScrollbarSize,description:p("scrollbar.verticalScrollbarSize","The width of the vertical scrollbar.")},"editor.scrollbar.horizontalScrollbarSize":{type:"number",default:e.horizontalScrollbarSize,description:p("scrollbar.horizontalScrollbarSize","The height of the horizontal scrollbar.")},"editor.scrollbar.scrollByPage":{type:"boolean",default:e.scrollByPage,description:p("scrollbar.scrollByPage","Controls whether clicks scroll by page or jump to click position.")},"editor.scrollbar.ignoreHorizontalScrollbarInContentHeight":{type:"boolean",default:e.ignoreHorizontalScrollbarInContentHeight,description:p("scrollbar.ignoreHorizontalScrollbarInContentHeight","When set, the horizontal scrollbar will not increase the size of the editor's content.")}})}validate(e){if(!e||typeof e!="object")return this.defaultValue;const t=e,i=hi.clampedInt(t.horizontalScrollbarSize,this.defaultValue.horizontalScrollbarSize,0,1e3),n=hi.clampedInt(t.verticalScrollbarSize,this.defaultValue.verticalScrollbarSize,0,1e3);return{arrowSize:hi.clampedInt(t.arrowSize,this.defaultValue.arrowSize,0,1e3),vertical:$B(t.vertical,this.defaultValue.vertical),horizontal:$B(t.horizontal,this.defaultValue.horizontal),useShadows:Fe(t.useShadows,this.defaultValue.useShadows),verticalHasArrows:Fe(t.verticalHasArrows,this.defaultValue.verticalHasArrows),horizontalHasArrows:Fe(t.horizontalHasArrows,this.defaultValue.horizontalHasArrows),handleMouseWheel:Fe(t.handleMouseWheel,this.defaultValue.handleMouseWheel),alwaysConsumeMouseWheel:Fe(t.alwaysConsumeMouseWheel,this.defaultValue.alwaysConsumeMouseWheel),horizontalScrollbarSize:i,horizontalSliderSize:hi.clampedInt(t.horizontalSliderSize,i,0,1e3),verticalScrollbarSize:n,verticalSliderSize:hi.clampedInt(t.verticalSliderSize,n,0,1e3),scrollByPage:Fe(t.scrollByPage,this.defaultValue.scrollByPage),ignoreHorizontalScrollbarInContentHeight:Fe(t.ignoreHorizontalScrollbarInContentHeight,this.defaultValue.ignoreHorizontalScrollbarInContentHeight)}}};const br="inUntrustedWorkspace",Io={allowedCharacters:"editor.unicodeHighlight.allowedCharacters",invisibleCharacters:"editor.unicodeHighlight.invisibleCharacters",nonBasicASCII:"editor.unicodeHighlight.nonBasicASCII",ambiguousCharacters:"editor.unicodeHighlight.ambiguousCharacters",includeComments:"editor.unicodeHighlight.includeComments",includeStrings:"editor.unicodeHighlight.includeStrings",allowedLocales:"editor.unicodeHighlight.allowedLocales"};class Kte extends Oi{constructor(){const e={nonBasicASCII:br,invisibleCharacters:!0,ambiguousCharacters:!0,includeComments:br,includeStrings:!0,allowedCharacters:{},allowedLocales:{_os:!0,_vscode:!0}};super(125,"unicodeHighlight",e,{[Io.nonBasicASCII]:{restricted:!0,type:["boolean","string"],enum:[!0,!1,br],default:e.nonBasicASCII,description:p("unicodeHighlight.nonBasicASCII","Controls whether all non-basic ASCII characters are highlighted. Only characters between U+0020 and U+007E, tab, line-feed and carriage-return are considered basic ASCII.")},[Io.invisibleCharacters]:{restricted:!0,type:"boolean",default:e.invisibleCharacters,description:p("unicodeHighlight.invisibleCharacters","Controls whether characters that just reserve space or have no width at all are highlighted.")},[Io.ambiguousCharacters]:{restricted:!0,type:"boolean",default:e.ambiguousCharacters,description:p("unicodeHighlight.ambiguousCharacters","Controls whether characters are highlighted that can be confused with basic ASCII characters, except those that are common in the current user locale.")},[Io.includeComments]:{restricted:!0,type:["boolean","string"],enum:[!0,!1,br],default:e.includeComments,description:p("unicodeHighlight.includeComments","Controls whether characters in comments should also be subject to Unicode highlighting.")},[Io.includeStrings]:{restricted:!0,type:["boolean","string"],enum:[!0,!1,br],default:e.includeStrings,description:p("unicodeHighlight.includeStrings","Controls whether characters in strings should also be subject to Unicode highlighting.")},[Io.allowedCharacters]:{restricted:!0,type:"object",default:e.allowedCharacters,description:p("unicodeHighlight.allowedCharacters","Defines allowed characters that are not being highlighted."),additionalProperties:{type:"boolean"}},[Io.allowedLocales]:{restricted:!0,type:"object",additionalProperties:{type:"boolean"},default:e.allowedLocales,description:p("unicodeHighlight.allowedLocales","Unicode characters that are common in allowed locales are not being highlighted.")}})}applyUpdate(e,t){let i=!1;t.allowedCharacters&&e&&(Mr(e.allowedCharacters,t.allowedCharacters)||(e={...e,allowedCharacters:t.allowedCharacters},i=!0)),t.allowedLocales&&e&&(Mr(e.allowedLocales,t.allowedLocales)||(e={...e,allowedLocales:t.allowedLocales},i=!0));const n=super.applyUpdate(e,t);return i?new y0(n.newValue,!0):n}validate(e){if(!e||typeof e!="object")return this.defaultValue;const t=e;return{nonBasicASCII:r_(t.nonBasicASCII,br,[!0,!1,br]),invisibleCharacters:Fe(t.invisibleCharacters,this.defaultValue.invisibleCharacters),ambiguousCharacters:Fe(t.ambiguousCharacters,this.defaultValue.ambiguousCharacters),includeComments:r_(t.includeComments,br,[!0,!1,br]),includeStrings:r_(t.includeStrings,br,[!0,!1,br]),allowedCharacters:this.validateBooleanMap(e.allowedCharacters,this.defaultValue.allowedCharacters),allowedLocales:this.validateBooleanMap(e.allowedLocales,this.defaultValue.allowedLocales)}}validateBooleanMap(e,t){if(typeof e!="object"||!e)return t;const i={};for(const[n,o]of Object.entries(e))o===!0&&(i[n]=!0);return i}}class qte extends Oi{constructor(){const e={enabled:!0,mode:"subwordSmart",showToolbar:"onHover",suppressSuggestions:!1,keepOnBlur:!1,fontFamily:"default"};super(62,"inlineSuggest",e,{"editor.inlineSuggest.enabled":{type:"boolean",default:e.enabled,description:p("inlineSuggest.enabled","Controls whether to automatically show inline suggestions in the editor.")},"editor.inlineSuggest.showToolbar":{type:"string",default:e.showToolbar,enum:["always","onHover","never"],enumDescriptions:[p("inlineSuggest.showToolbar.always","Show the inline suggestion toolbar whenever an inline suggestion is shown."),p("inlineSuggest.showToolbar.onHover","Show the inline suggestion toolbar when hovering over an inline suggestion."),p("inlineSuggest.showToolbar.never","Never show the inline suggestion toolbar.")],description:p("inlineSuggest.showToolbar","Controls when to show the inline suggestion toolbar.")},"editor.inlineSuggest.suppressSuggestions":{type:"boolean",default:e.suppressSuggestions,description:p("inlineSuggest.suppressSuggestions","Controls how inline suggestions interact with the suggest widget. If enabled, the suggest widget is not shown automatically when inline suggestions are available.")},"editor.inlineSuggest.fontFamily":{type:"string",default:e.fontFamily,description:p("inlineSuggest.fontFamily","Controls the font family of the inline suggestions.")}})}validate(e){if(!e||typeof e!="object")return this.defaultValue;const t=e;return{enabled:Fe(t.enabled,this.defaultValue.enabled),mode:sn(t.mode,this.defaultValue.mode,["prefix","subword","subwordSmart"]),showToolbar:sn(t.showToolbar,this.defaultValue.showToolbar,["always","onHover","never"]),suppressSuggestions:Fe(t.suppressSuggestions,this.defaultValue.suppressSuggestions),keepOnBlur:Fe(t.keepOnBlur,this.defaultValue.keepOnBlur),fontFamily:to.string(t.fontFamily,this.defaultValue.fontFamily)}}}class Gte extends Oi{constructor(){const e={enabled:!1,showToolbar:"onHover",fontFamily:"default",keepOnBlur:!1,backgroundColoring:!1};super(63,"experimentalInlineEdit",e,{"editor.experimentalInlineEdit.enabled":{type:"boolean",default:e.enabled,description:p("inlineEdit.enabled","Controls whether to show inline edits in the editor.")},"editor.experimentalInlineEdit.showToolbar":{type:"string",default:e.showToolbar,enum:["always","onHover","never"],enumDescriptions:[p("inlineEdit.showToolbar.always","Show the inline edit toolbar whenever an inline suggestion is shown."),p("inlineEdit.showToolbar.onHover","Show the inline edit toolbar when hovering over an inline suggestion."),p("inlineEdit.showToolbar.never","Never show the inline edit toolbar.")],description:p("inlineEdit.showToolbar","Controls when to show the inline edit toolbar.")},"editor.experimentalInlineEdit.fontFamily":{type:"string",default:e.fontFamily,description:p("inlineEdit.fontFamily","Controls the font family of the inline edit.")},"editor.experimentalInlineEdit.backgroundColoring":{type:"boolean",default:e.backgroundColoring,description:p("inlineEdit.backgroundColoring","Controls whether to color the background of inline edits.")}})}validate(e){if(!e||typeof e!="object")return this.defaultValue;const t=e;return{enabled:Fe(t.enabled,this.defaultValue.enabled),showToolbar:sn(t.showToolbar,this.defaultValue.showToolbar,["always","onHover","never"]),fontFamily:to.string(t.fontFamily,this.defaultValue.fontFamily),keepOnBlur:Fe(t.keepOnBlur,this.defaultValue.keepOnBlur),backgroundColoring:Fe(t.backgroundColoring,this.defaultValue.backgroundColoring)}}}class Zte extends Oi{constructor(){const e={enabled:Es.bracketPairColorizationOptions.enabled,independentColorPoolPerBracketType:Es.bracketPairColorizationOptions.independentColorPoolPerBracketType};super(15,"bracketPairColorization",e,{"editor.bracketPairColorization.enabled":{type:"boolean",default:e.enabled,markdownDescription:p("bracketPairColorization.enabled","Controls whether bracket pair colorization is enabled or not. Use {0} to override the bracket highlight colors.","`#workbench.colorCustomizations#`")},"editor.bracketPairColorization.independentColorPoolPerBracketType":{type:"boolean",default:e.independentColorPoolPerBracketType,description:p("bracketPairColorization.independentColorPoolPerBracketType","Controls whether each bracket type has its own independent color pool.")}})}validate(e){if(!e||typeof e!="object")return this.defaultValue;const t=e;return{enabled:Fe(t.enabled,this.defaultValue.enabled),independentColorPoolPerBracketType:Fe(t.independentColorPoolPerBracketType,this.defaultValue.independentColorPoolPerBracketType)}}}class Yte extends Oi{constructor(){const e={bracketPairs:!1,bracketPairsHorizontal:"active",highlightActiveBracketPair:!0,indentation:!0,highlightActiveIndentation:!0};super(16,"guides",e,{"editor.guides.bracketPairs":{type:["boolean","string"],enum:[!0,"active",!1],enumDescriptions:[p("editor.guides.bracketPairs.true","Enables bracket pair guides."),p("editor.guides.bracketPairs.active","Enables bracket pair guides only for the active bracket pair."),p("editor.guides.bracketPairs.false","Disables bracket pair guides.")],default:e.bracketPairs,description:p("editor.guides.bracketPairs","Controls whether bracket pair guides are enabled or not.")},"editor.guides.bracketPairsHorizontal":{type:["boolean","string"],enum:[!0,"active",!1],enumDescriptions:[p("editor.guides.bracketPairsHorizontal.true","Enables horizontal guides as addition to vertical bracket pair guides."),p("editor.guides.bracketPairsHorizontal.active","Enables horizontal guides only for the active bracket pair."),p("editor.guides.bracketPairsHorizontal.false","Disables horizontal bracket pair guides.")],default:e.bracketPairsHorizontal,description:p("editor.guides.bracketPairsHorizontal","Controls whether horizontal bracket pair guides are enabled or not.")},"editor.guides.highlightActiveBracketPair":{type:"boolean",default:e.highlightActiveBracketPair,description:p("editor.guides.highlightActiveBracketPair","Controls whether the editor should highlight the active bracket pair.")},"editor.guides.indentation":{type:"boolean",default:e.indentation,description:p("editor.guides.indentation","Controls whether the editor should render indent guides.")},"editor.guides.highlightActiveIndentation":{type:["boolean","string"],enum:[!0,"always",!1],enumDescriptions:[p("editor.guides.highlightActiveIndentation.true","Highlights the active indent guide."),p("editor.guides.highlightActiveIndentation.always","Highlights the active indent guide even if bracket guides are highlighted."),p("editor.guides.highlightActiveIndentation.false","Do not highlight the active indent guide.")],default:e.highlightActiveIndentation,description:p("editor.guides.highlightActiveIndentation","Controls whether the editor should highlight the active indent guide.")}})}validate(e){if(!e||typeof e!="object")return this.defaultValue;const t=e;return{bracketPairs:r_(t.bracketPairs,this.defaultValue.bracketPairs,[!0,!1,"active"]),bracketPairsHorizontal:r_(t.bracketPairsHorizontal,this.defaultValue.bracketPairsHorizontal,[!0,!1,"active"]),highlightActiveBracketPair:Fe(t.highlightActiveBracketPair,this.defaultValue.highlightActiveBracketPair),indentation:Fe(t.indentation,this.defaultValue.indentation),highlightActiveIndentation:r_(t.highlightActiveIndentation,this.defaultValue.highlightActiveIndentation,[!0,!1,"always"])}}}function r_(s,e,t){const i=t.indexOf(s);return i===-1?e:t[i]}class Xte extends Oi{constructor(){const e={insertMode:"insert",filterGraceful:!0,snippetsPreventQuickSuggestions:!1,localityBonus:!1,shareSuggestSelections:!1,selectionMode:"always",showIcons:!0,showStatusBar:!1,preview:!1,previewMode:"subwordSmart",showInlineDetails:!0,showMethods:!0,showFunctions:!0,showConstructors:!0,showDeprecated:!0,matchOnWordStartOnly:!0,showFields:!0,showVariables:!0,showClasses:!0,showStructs:!0,showInterfaces:!0,showModules:!0,showProperties:!0,showEvents:!0,showOperators:!0,showUnits:!0,showValues:!0,showConstants:!0,showEnums:!0,showEnumMembers:!0,showKeywords:!0,showWords:!0,showColors:!0,showFiles:!0,showReferences:!0,showFolders:!0,showTypeParameters:!0,showSnippets:!0,showUsers:!0,showIssues:!0};super(118,"suggest",e,{"editor.suggest.insertMode":{type:"string",enum:["insert","replace"],enumDescriptions:[p("suggest.insertMode.insert","Insert suggestion without overwriting text right of the cursor."),p("suggest.insertMode.replace","Insert suggestion and overwrite text right of the cursor.")],default:e.insertMode,description:p("suggest.insertMode","Controls whether words are overwritten when accepting completions. Note that this depends on extensions opting into this feature.")},"editor.suggest.filterGraceful":{type:"boolean",default:e.filterGraceful,description:p("suggest.filterGraceful","Controls whether filtering and sorting suggestions accounts for small typos.")},"editor.suggest.localityBonus":{type:"boolean",default:e.localityBonus,description:p("suggest.localityBonus","Controls whether sorting favors words that appear close to the cursor.")},"editor.suggest.shareSuggestSelections":{type:"boolean",default:e.shareSuggestSelections,markdownDescription:p("suggest.shareSuggestSelections","Controls whether remembered suggestion selections are shared between multiple workspaces and windows (needs `#editor.suggestSelection#`).")},"editor.suggest.selectionMode":{type:"string",enum:["always","never","whenTriggerCharacter","whenQuickSuggestion"],enumDescriptions:[p("suggest.insertMode.always","Always select a suggestion when automatically triggering IntelliSense."),p("suggest.insertMode.never","Never select a suggestion when automatically triggering IntelliSense."),p("suggest.insertMode.whenTriggerCharacter","Select a suggestion only when triggering IntelliSense from a trigger character."),p("suggest.insertMode.whenQuickSuggestion","Select a suggestion only when triggering IntelliSense as you type.")],default:e.selectionMode,markdownDescription:p("suggest.selectionMode","Controls whether a suggestion is selected when the widget shows. Note that this only applies to automatically triggered suggestions (`#editor.quickSuggestions#` and `#editor.suggestOnTriggerCharacters#`) and that a suggestion is always selected when explicitly invoked, e.g via `Ctrl+Space`.")},"editor.suggest.snippetsPreventQuickSuggestions":{type:"boolean",default:e.snippetsPreventQuickSuggestions,description:p("suggest.snippetsPreventQuickSuggestions","Controls whether an active snippet prevents quick suggestions.")},"editor.suggest.showIcons":{type:"boolean",default:e.showIcons,description:p("suggest.showIcons","Controls whether to show or hide icons in suggestions.")},"editor.suggest.showStatusBar":{type:"boolean",default:e.showStatusBar,description:p("suggest.showStatusBar","Controls the visibility of the status bar at the bottom of the suggest widget.")},"editor.suggest.preview":{type:"boolean",default:e.preview,description:p("suggest.preview","Controls whether to preview the suggestion outcome in the editor.")},"editor.suggest.showInlineDetails":{type:"boolean",default:e.showInlineDetails,description:p("suggest.showInlineDetails","Controls whether suggest details show inline with the label or only in the details widget.")},"editor.suggest.maxVisibleSuggestions":{type:"number",deprecationMessage:p("suggest.maxVisibleSuggestions.dep","This setting is deprecated. The suggest widget can now be resized.")},"editor.suggest.filteredTypes":{type:"object",deprecationMessage:p("deprecated","This setting is deprecated, please use separate settings like 'editor.suggest.showKeywords' or 'editor.suggest.showSnippets' instead.")},"editor.suggest.showMethods":{type:"boolean",default:!0,markdownDescription:p("editor.suggest.showMethods","When enabled IntelliSense shows `method`-suggestions.")},"editor.suggest.showFunctions":{type:"boolean",default:!0,markdownDescription:p("editor.suggest.showFunctions","When enabled IntelliSense shows `function`-suggestions.")},"editor.suggest.showConstructors":{type:"boolean",default:!0,markdownDescription:p("editor.suggest.showConstructors","When enabled IntelliSense shows `constructor`-suggestions.")},"editor.suggest.showDeprecated":{type:"boolean",default:!0,markdownDescription:p("editor.suggest.showDeprecated","When enabled IntelliSense shows `deprecated`-suggestions.")},"editor.suggest.matchOnWordStartOnly":{type:"boolean",default:!0,markdownDescription:p("editor.suggest.matchOnWordStartOnly","When enabled IntelliSense filtering requires that the first character matches on a word start. For example, `c` on `Console` or `WebContext` but _not_ on `description`. When disabled IntelliSense will show more results but still sorts them by match quality.")},"editor.suggest.showFields":{type:"boolean",default:!0,markdownDescription:p("editor.suggest.showFields","When enabled IntelliSense shows `field`-suggestions.")},"editor.suggest.showVariables":{type:"boolean",default:!0,markdownDescription:p("editor.suggest.showVariables","When enabled IntelliSense shows `variable`-suggestions.")},"editor.suggest.showClasses":{type:"boolean",default:!0,markdownDescription:p("editor.suggest.showClasss","When enabled IntelliSense shows `class`-suggestions.")},"editor.suggest.showStructs":{type:"boolean",default:!0,markdownDescription:p("editor.suggest.showStructs","When enabled IntelliSense shows `struct`-suggestions.")},"editor.suggest.showInterfaces":{type:"boolean",default:!0,markdownDescription:p("editor.suggest.showInterfaces","When enabled IntelliSense shows `interface`-suggestions.")},"editor.suggest.showModules":{type:"boolean",default:!0,markdownDescription:p("editor.suggest.showModules","When enabled IntelliSense shows `module`-suggestions.")},"editor.suggest.showProperties":{type:"boolean",default:!0,markdownDescription:p("editor.suggest.showPropertys","When enabled IntelliSense shows `property`-suggestions.")},"editor.suggest.showEvents":{type:"boolean",default:!0,markdownDescription:p("editor.suggest.showEvents","When enabled IntelliSense shows `event`-suggestions.")},"editor.suggest.showOperators":{type:"boolean",default:!0,markdownDescription:p("editor.suggest.showOperators","When enabled IntelliSense shows `operator`-suggestions.")},"editor.suggest.showUnits":{type:"boolean",default:!0,markdownDescription:p("editor.suggest.showUnits","When enabled IntelliSense shows `unit`-suggestions.")},"editor.suggest.showValues":{type:"boolean",default:!0,markdownDescription:p("editor.suggest.showValues","When enabled IntelliSense shows `value`-suggestions.")},"editor.suggest.showConstants":{type:"boolean",default:!0,markdownDescription:p("editor.suggest.showConstants","When enabled IntelliSense shows `constant`-suggestions.")},"editor.suggest.showEnums":{type:"boolean",default:!0,markdownDescription:p("editor.suggest.showEnums","When enabled IntelliSense shows `enum`-suggestions.")},"editor.suggest.showEnumMembers":{type:"boolean",default:!0,markdownDescription:p("editor.suggest.showEnumMembers","When enabled IntelliSense shows `enumMember`-suggestions.")},"editor.suggest.showKeywords":{type:"boolean",default:!0,markdownDescription:p("editor.suggest.showKeywords","When enabled IntelliSense shows `keyword`-suggestions.")},"editor.suggest.showWords":{type:"boolean",default:!0,markdownDescription:p("editor.suggest.showTexts","When enabled IntelliSense shows `text`-suggestions.")},"editor.suggest.showColors":{type:"boolean",default:!0,markdownDescription:p("editor.suggest.showColors","When enabled IntelliSense shows `color`-suggestions.")},"editor.suggest.showFiles":{type:"boolean",default:!0,markdownDescription:p("editor.suggest.showFiles","When enabled IntelliSense shows `file`-suggestions.")},"editor.suggest.showReferences":{type:"boolean",default:!0,markdownDescription:p("editor.suggest.showReferences","When enabled IntelliSense shows `reference`-suggestions.")},"editor.suggest.showCustomcolors":{type:"boolean",default:!0,markdownDescription:p("editor.suggest.showCustomcolors","When enabled IntelliSense shows `customcolor`-suggestions.")},"editor.suggest.showFolders":{type:"boolean",default:!0,markdownDescription:p("editor.suggest.showFolders","When enabled IntelliSense shows `folder`-suggestions.")},"editor.suggest.showTypeParameters":{type:"boolean",default:!0,markdownDescription:p("editor.suggest.showTypeParameters","When enabled IntelliSense shows `typeParameter`-suggestions.")},"editor.suggest.showSnippets":{type:"boolean",default:!0,markdownDescription:p("editor.suggest.showSnippets","When enabled IntelliSense shows `snippet`-suggestions.")},"editor.suggest.showUsers":{type:"boolean",default:!0,markdownDescription:p("editor.suggest.showUsers","When enabled IntelliSense shows `user`-suggestions.")},"editor.suggest.showIssues":{type:"boolean",default:!0,markdownDescription:p("editor.suggest.showIssues","When enabled IntelliSense shows `issues`-suggestions.")}})}validate(e){if(!e||typeof e!="object")return this.defaultValue;const t=e;return{insertMode:sn(t.insertMode,this.defaultValue.insertMode,["insert","replace"]),filterGraceful:Fe(t.filterGraceful,this.defaultValue.filterGraceful),snippetsPreventQuickSuggestions:Fe(t.snippetsPreventQuickSuggestions,this.defaultValue.filterGraceful),localityBonus:Fe(t.localityBonus,this.defaultValue.localityBonus),shareSuggestSelections:Fe(t.shareSuggestSelections,this.defaultValue.shareSuggestSelections),selectionMode:sn(t.selectionMode,this.defaultValue.selectionMode,["always","never","whenQuickSuggestion","whenTriggerCharacter"]),showIcons:Fe(t.showIcons,this.defaultValue.showIcons),showStatusBar:Fe(t.showStatusBar,this.defaultValue.showStatusBar),preview:Fe(t.preview,this.defaultValue.preview),previewMode:sn(t.previewMode,this.defaultValue.previewMode,["prefix","subword","subwordSmart"]),showInlineDetails:Fe(t.showInlineDetails,this.defaultValue.showInlineDetails),showMethods:Fe(t.showMethods,this.defaultValue.showMethods),showFunctions:Fe(t.showFunctions,this.defaultValue.showFunctions),showConstructors:Fe(t.showConstructors,this.defaultValue.showConstructors),showDeprecated:Fe(t.showDeprecated,this.defaultValue.showDeprecated),matchOnWordStartOnly:Fe(t.matchOnWordStartOnly,this.defaultValue.matchOnWordStartOnly),showFields:Fe(t.showFields,this.defaultValue.showFields),showVariables:Fe(t.showVariables,this.defaultValue.showVariables),showClasses:Fe(t.showClasses,this.defaultValue.showClasses),showStructs:Fe(t.showStructs,this.defaultValue.showStructs),showInterfaces:Fe(t.showInterfaces,this.defaultValue.showInterfaces),showModules:Fe(t.showModules,this.defaultValue.showModules),showProperties:Fe(t.showProperties,this.defaultValue.showProperties),showEvents:Fe(t.showEvents,this.defaultValue.showEvents),showOperators:Fe(t.showOperators,this.defaultValue.showOperators),showUnits:Fe(t.showUnits,this.defaultValue.showUnits),showValues:Fe(t.showValues,this.defaultValue.showValues),showConstants:Fe(t.showConstants,this.defaultValue.showConstants),showEnums:Fe(t.showEnums,this.defaultValue.showEnums),showEnumMembers:Fe(t.showEnumMembers,this.defaultValue.showEnumMembers),showKeywords:Fe(t.showKeywords,this.defaultValue.showKeywords),showWords:Fe(t.showWords,this.defaultValue.showWords),showColors:Fe(t.showColors,this.defaultValue.showColors),showFiles:Fe(t.showFiles,this.defaultValue.showFiles),showReferences:Fe(t.showReferences,this.defaultValue.showReferences),showFolders:Fe(t.showFolders,this.defaultValue.showFolders),showTypeParameters:Fe(t.showTypeParameters,this.defaultValue.showTypeParameters),showSnippets:Fe(t.showSnippets,this.defaultValue.showSnippets),showUsers:Fe(t.showUsers,this.defaultValue.showUsers),showIssues:Fe(t.showIssues,this.defaultValue.showIssues)}}}class Qte extends Oi{constructor(){super(113,"smartSelect",{selectLeadingAndTrailingWhitespace:!0,selectSubwords:!0},{"editor.smartSelect.selectLeadingAndTrailingWhitespace":{description:p("selectLeadingAndTrailingWhitespace","Whether leading and trailing whitespace should always be selected."),default:!0,type:"boolean"},"editor.smartSelect.selectSubwords":{description:p("selectSubwords","Whether subwords (like 'foo' in 'fooBar' or 'foo_bar') should be selected."),default:!0,type:"boolean"}})}validate(e){return!e||typeof e!="object"?this.defaultValue:{selectLeadingAndTrailingWhitespace:Fe(e.selectLeadingAndTrailingWhitespace,this.defaultValue.selectLeadingAndTrailingWhitespace),selectSubwords:Fe(e.selectSubwords,this.defaultValue.selectSubwords)}}}class Jte extends Oi{constructor(){const e=[];super(130,"wordSegmenterLocales",e,{anyOf:[{description:p("wordSegmenterLocales","Locales to be used for word segmentation when doing word related navigations or operations. Specify the BCP 47 language tag of the word you wish to recognize (e.g., ja, zh-CN, zh-Hant-TW, etc.)."),type:"string"},{description:p("wordSegmenterLocales","Locales to be used for word segmentation when doing word related navigations or operations. Specify the BCP 47 language tag of the word you wish to recognize (e.g., ja, zh-CN, zh-Hant-TW, etc.)."),type:"array",items:{type:"string"}}]})}validate(e){if(typeof e=="string"&&(e=[e]),Array.isArray(e)){const t=[];for(const i of e)if(typeof i=="string")try{Intl.Segmenter.supportedLocalesOf(i).length>0&&t.push(i)}catch{}return t}return this.defaultValue}}class eie extends Oi{constructor(){super(138,"wrappingIndent",1,{"editor.wrappingIndent":{type:"string",enum:["none","same","indent","deepIndent"],enumDescriptions:[p("wrappingIndent.none","No indentation. Wrapped lines begin at column 1."),p("wrappingIndent.same","Wrapped lines get the same indentation as the parent."),p("wrappingIndent.indent","Wrapped lines get +1 indentation toward the parent."),p("wrappingIndent.deepIndent","Wrapped lines get +2 indentation toward the parent.")],description:p("wrappingIndent","Controls the indentation of wrapped lines."),default:"same"}})}validate(e){switch(e){case"none":return 0;case"same":return 1;case"indent":return 2;case"deepIndent":return 3}return 1}compute(e,t,i){return t.get(2)===2?0:i}}class tie extends ew{constructor(){super(146)}compute(e,t,i){const n=t.get(145);return{isDominatedByLongLines:e.isDominatedByLongLines,isWordWrapMinified:n.isWordWrapMinified,isViewportWrapping:n.isViewportWrapping,wrappingColumn:n.wrappingColumn}}}class iie extends Oi{constructor(){const e={enabled:!0,showDropSelector:"afterDrop"};super(36,"dropIntoEditor",e,{"editor.dropIntoEditor.enabled":{type:"boolean",default:e.enabled,markdownDescription:p("dropIntoEditor.enabled","Controls whether you can drag and drop a file into a text editor by holding down the `Shift` key (instead of opening the file in an editor).")},"editor.dropIntoEditor.showDropSelector":{type:"string",markdownDescription:p("dropIntoEditor.showDropSelector","Controls if a widget is shown when dropping files into the editor. This widget lets you control how the file is dropped."),enum:["afterDrop","never"],enumDescriptions:[p("dropIntoEditor.showDropSelector.afterDrop","Show the drop selector widget after a file is dropped into the editor."),p("dropIntoEditor.showDropSelector.never","Never show the drop selector widget. Instead the default drop provider is always used.")],default:"afterDrop"}})}validate(e){if(!e||typeof e!="object")return this.defaultValue;const t=e;return{enabled:Fe(t.enabled,this.defaultValue.enabled),showDropSelector:sn(t.showDropSelector,this.defaultValue.showDropSelector,["afterDrop","never"])}}}class nie extends Oi{constructor(){const e={enabled:!0,showPasteSelector:"afterPaste"};super(85,"pasteAs",e,{"editor.pasteAs.enabled":{type:"boolean",default:e.enabled,markdownDescription:p("pasteAs.enabled","Controls whether you can paste content in different ways.")},"editor.pasteAs.showPasteSelector":{type:"string",markdownDescription:p("pasteAs.showPasteSelector","Controls if a widget is shown when pasting content in to the editor. This widget lets you control how the file is pasted."),enum:["afterPaste","never"],enumDescriptions:[p("pasteAs.showPasteSelector.afterPaste","Show the paste selector widget after content is pasted into the editor."),p("pasteAs.showPasteSelector.never","Never show the paste selector widget. Instead the default pasting behavior is always used.")],default:"afterPaste"}})}validate(e){if(!e||typeof e!="object")return this.defaultValue;const t=e;return{enabled:Fe(t.enabled,this.defaultValue.enabled),showPasteSelector:sn(t.showPasteSelector,this.defaultValue.showPasteSelector,["afterPaste","never"])}}}const sie="Consolas, 'Courier New', monospace",oie="Menlo, Monaco, 'Courier New', monospace",rie="'Droid Sans Mono', 'monospace', monospace",Oo={fontFamily:St?oie:po?rie:sie,fontWeight:"normal",fontSize:St?12:14,lineHeight:0,letterSpacing:0},Nm=[];function ge(s){return Nm[s.id]=s,s}const Ul={acceptSuggestionOnCommitCharacter:ge(new Rt(0,"acceptSuggestionOnCommitCharacter",!0,{markdownDescription:p("acceptSuggestionOnCommitCharacter","Controls whether suggestions should be accepted on commit characters. For example, in JavaScript, the semi-colon (`;`) can be a commit character that accepts a suggestion and types that character.")})),acceptSuggestionOnEnter:ge(new Gi(1,"acceptSuggestionOnEnter","on",["on","smart","off"],{markdownEnumDescriptions:["",p("acceptSuggestionOnEnterSmart","Only accept a suggestion with `Enter` when it makes a textual change."),""],markdownDescription:p("acceptSuggestionOnEnter","Controls whether suggestions should be accepted on `Enter`, in addition to `Tab`. Helps to avoid ambiguity between inserting new lines or accepting suggestions.")})),accessibilitySupport:ge(new bte),accessibilityPageSize:ge(new hi(3,"accessibilityPageSize",10,1,1073741824,{description:p("accessibilityPageSize","Controls the number of lines in the editor that can be read out by a screen reader at once. When we detect a screen reader we automatically set the default to be 500. Warning: this has a performance implication for numbers larger than the default."),tags:["accessibility"]})),ariaLabel:ge(new to(4,"ariaLabel",p("editorViewAccessibleLabel","Editor content"))),ariaRequired:ge(new Rt(5,"ariaRequired",!1,void 0)),screenReaderAnnounceInlineSuggestion:ge(new Rt(8,"screenReaderAnnounceInlineSuggestion",!0,{description:p("screenReaderAnnounceInlineSuggestion","Control whether inline suggestions are announced by a screen reader."),tags:["accessibility"]})),autoClosingBrackets:ge(new Gi(6,"autoClosingBrackets","languageDefined",["always","languageDefined","beforeWhitespace","never"],{enumDescriptions:["",p("editor.autoClosingBrackets.languageDefined","Use language configurations to determine when to autoclose brackets."),p("editor.autoClosingBrackets.beforeWhitespace","Autoclose brackets only when the cursor is to the left of whitespace."),""],description:p("autoClosingBrackets","Controls whether the editor should automatically close brackets after the user adds an opening bracket.")})),autoClosingComments:ge(new Gi(7,"autoClosingComments","languageDefined",["always","languageDefined","beforeWhitespace","never"],{enumDescriptions:["",p("editor.autoClosingComments.languageDefined","Use language configurations to determine when to autoclose comments."),p("editor.autoClosingComments.beforeWhitespace","Autoclose comments only when the cursor is to the left of whitespace."),""],description:p("autoClosingComments","Controls whether the editor should automatically close comments after the user adds an opening comment.")})),autoClosingDelete:ge(new Gi(9,"autoClosingDelete","auto",["always","auto","never"],{enumDescriptions:["",p("editor.autoClosingDelete.auto","Remove adjacent closing quotes or brackets only if they were automatically inserted."),""],description:p("autoClosingDelete","Controls whether the editor should remove adjacent closing quotes or brackets when deleting.")})),autoClosingOvertype:ge(new Gi(10,"autoClosingOvertype","auto",["always","auto","never"],{enumDescriptions:["",p("editor.autoClosingOvertype.auto","Type over closing quotes or brackets only if they were automatically inserted."),""],description:p("autoClosingOvertype","Controls whether the editor should type over closing quotes or brackets.")})),autoClosingQuotes:ge(new Gi(11,"autoClosingQuotes","languageDefined",["always","languageDefined","beforeWhitespace","never"],{enumDescriptions:["",p("editor.autoClosingQuotes.languageDefined","Use language configurations to determine when to autoclose quotes."),p("editor.autoClosingQuotes.beforeWhitespace","Autoclose quotes only when the cursor is to the left of whitespace."),""],description:p("autoClosingQuotes","Controls whether the editor should automatically close quotes after the user adds an opening quote.")})),autoIndent:ge(new lS(12,"autoIndent",4,"full",["none","keep","brackets","advanced","full"],vte,{enumDescriptions:[p("editor.autoIndent.none","The editor will not insert indentation automatically."),p("editor.autoIndent.keep","The editor will keep the current line's indentation."),p("editor.autoIndent.brackets","The editor will keep the current line's indentation and honor language defined brackets."),p("editor.autoIndent.advanced","The editor will keep the current line's indentation, honor language defined brackets and invoke special onEnterRules defined by languages."),p("editor.autoIndent.full","The editor will keep the current line's indentation, honor language defined brackets, invoke special onEnterRules defined by languages, and honor indentationRules defined by languages.")],description:p("autoIndent","Controls whether the editor should automatically adjust the indentation when users type, paste, move or indent lines.")})),automaticLayout:ge(new Rt(13,"automaticLayout",!1)),autoSurround:ge(new Gi(14,"autoSurround","languageDefined",["languageDefined","quotes","brackets","never"],{enumDescriptions:[p("editor.autoSurround.languageDefined","Use language configurations to determine when to automatically surround selections."),p("editor.autoSurround.quotes","Surround with quotes but not brackets."),p("editor.autoSurround.brackets","Surround with brackets but not quotes."),""],description:p("autoSurround","Controls whether the editor should automatically surround selections when typing quotes or brackets.")})),bracketPairColorization:ge(new Zte),bracketPairGuides:ge(new Yte),stickyTabStops:ge(new Rt(116,"stickyTabStops",!1,{description:p("stickyTabStops","Emulate selection behavior of tab characters when using spaces for indentation. Selection will stick to tab stops.")})),codeLens:ge(new Rt(17,"codeLens",!0,{description:p("codeLens","Controls whether the editor shows CodeLens.")})),codeLensFontFamily:ge(new to(18,"codeLensFontFamily","",{description:p("codeLensFontFamily","Controls the font family for CodeLens.")})),codeLensFontSize:ge(new hi(19,"codeLensFontSize",0,0,100,{type:"number",default:0,minimum:0,maximum:100,markdownDescription:p("codeLensFontSize","Controls the font size in pixels for CodeLens. When set to 0, 90% of `#editor.fontSize#` is used.")})),colorDecorators:ge(new Rt(20,"colorDecorators",!0,{description:p("colorDecorators","Controls whether the editor should render the inline color decorators and color picker.")})),colorDecoratorActivatedOn:ge(new Gi(148,"colorDecoratorsActivatedOn","clickAndHover",["clickAndHover","hover","click"],{enumDescriptions:[p("editor.colorDecoratorActivatedOn.clickAndHover","Make the color picker appear both on click and hover of the color decorator"),p("editor.colorDecoratorActivatedOn.hover","Make the color picker appear on hover of the color decorator"),p("editor.colorDecoratorActivatedOn.click","Make the color picker appear on click of the color decorator")],description:p("colorDecoratorActivatedOn","Controls the condition to make a color picker appear from a color decorator")})),colorDecoratorsLimit:ge(new hi(21,"colorDecoratorsLimit",500,1,1e6,{markdownDescription:p("colorDecoratorsLimit","Controls the max number of color decorators that can be rendered in an editor at once.")})),columnSelection:ge(new Rt(22,"columnSelection",!1,{description:p("columnSelection","Enable that the selection with the mouse and keys is doing column selection.")})),comments:ge(new Cte),contextmenu:ge(new Rt(24,"contextmenu",!0)),copyWithSyntaxHighlighting:ge(new Rt(25,"copyWithSyntaxHighlighting",!0,{description:p("copyWithSyntaxHighlighting","Controls whether syntax highlighting should be copied into the clipboard.")})),cursorBlinking:ge(new lS(26,"cursorBlinking",1,"blink",["blink","smooth","phase","expand","solid"],wte,{description:p("cursorBlinking","Control the cursor animation style.")})),cursorSmoothCaretAnimation:ge(new Gi(27,"cursorSmoothCaretAnimation","off",["off","explicit","on"],{enumDescriptions:[p("cursorSmoothCaretAnimation.off","Smooth caret animation is disabled."),p("cursorSmoothCaretAnimation.explicit","Smooth caret animation is enabled only when the user moves the cursor with an explicit gesture."),p("cursorSmoothCaretAnimation.on","Smooth caret animation is always enabled.")],description:p("cursorSmoothCaretAnimation","Controls whether the smooth caret animation should be enabled.")})),cursorStyle:ge(new lS(28,"cursorStyle",ls.Line,"line",["line","block","underline","line-thin","block-outline","underline-thin"],Ste,{description:p("cursorStyle","Controls the cursor style.")})),cursorSurroundingLines:ge(new hi(29,"cursorSurroundingLines",0,0,1073741824,{description:p("cursorSurroundingLines","Controls the minimal number of visible leading lines (minimum 0) and trailing lines (minimum 1) surrounding the cursor. Known as 'scrollOff' or 'scrollOffset' in some other editors.")})),cursorSurroundingLinesStyle:ge(new Gi(30,"cursorSurroundingLinesStyle","default",["default","all"],{enumDescriptions:[p("cursorSurroundingLinesStyle.default","`cursorSurroundingLines` is enforced only when triggered via the keyboard or API."),p("cursorSurroundingLinesStyle.all","`cursorSurroundingLines` is enforced always.")],markdownDescription:p("cursorSurroundingLinesStyle","Controls when `#editor.cursorSurroundingLines#` should be enforced.")})),cursorWidth:ge(new hi(31,"cursorWidth",0,0,1073741824,{markdownDescription:p("cursorWidth","Controls the width of the cursor when `#editor.cursorStyle#` is set to `line`.")})),disableLayerHinting:ge(new Rt(32,"disableLayerHinting",!1)),disableMonospaceOptimizations:ge(new Rt(33,"disableMonospaceOptimizations",!1)),domReadOnly:ge(new Rt(34,"domReadOnly",!1)),dragAndDrop:ge(new Rt(35,"dragAndDrop",!0,{description:p("dragAndDrop","Controls whether the editor should allow moving selections via drag and drop.")})),emptySelectionClipboard:ge(new Lte),dropIntoEditor:ge(new iie),stickyScroll:ge(new Mte),experimentalWhitespaceRendering:ge(new Gi(38,"experimentalWhitespaceRendering","svg",["svg","font","off"],{enumDescriptions:[p("experimentalWhitespaceRendering.svg","Use a new rendering method with svgs."),p("experimentalWhitespaceRendering.font","Use a new rendering method with font characters."),p("experimentalWhitespaceRendering.off","Use the stable rendering method.")],description:p("experimentalWhitespaceRendering","Controls whether whitespace is rendered with a new, experimental method.")})),extraEditorClassName:ge(new to(39,"extraEditorClassName","")),fastScrollSensitivity:ge(new sa(40,"fastScrollSensitivity",5,s=>s<=0?5:s,{markdownDescription:p("fastScrollSensitivity","Scrolling speed multiplier when pressing `Alt`.")})),find:ge(new xte),fixedOverflowWidgets:ge(new Rt(42,"fixedOverflowWidgets",!1)),folding:ge(new Rt(43,"folding",!0,{description:p("folding","Controls whether the editor has code folding enabled.")})),foldingStrategy:ge(new Gi(44,"foldingStrategy","auto",["auto","indentation"],{enumDescriptions:[p("foldingStrategy.auto","Use a language-specific folding strategy if available, else the indentation-based one."),p("foldingStrategy.indentation","Use the indentation-based folding strategy.")],description:p("foldingStrategy","Controls the strategy for computing folding ranges.")})),foldingHighlight:ge(new Rt(45,"foldingHighlight",!0,{description:p("foldingHighlight","Controls whether the editor should highlight folded ranges.")})),foldingImportsByDefault:ge(new Rt(46,"foldingImportsByDefault",!1,{description:p("foldingImportsByDefault","Controls whether the editor automatically collapses import ranges.")})),foldingMaximumRegions:ge(new hi(47,"foldingMaximumRegions",5e3,10,65e3,{description:p("foldingMaximumRegions","The maximum number of foldable regions. Increasing this value may result in the editor becoming less responsive when the current source has a large number of foldable regions.")})),unfoldOnClickAfterEndOfLine:ge(new Rt(48,"unfoldOnClickAfterEndOfLine",!1,{description:p("unfoldOnClickAfterEndOfLine","Controls whether clicking on the empty content after a folded line will unfold the line.")})),fontFamily:ge(new to(49,"fontFamily",Oo.fontFamily,{description:p("fontFamily","Controls the font family.")})),fontInfo:ge(new Dte),fontLigatures2:ge(new kr),fontSize:ge(new kte),fontWeight:ge(new Sd),fontVariations:ge(new _l),formatOnPaste:ge(new Rt(55,"formatOnPaste",!1,{description:p("formatOnPaste","Controls whether the editor should automatically format the pasted content. A formatter must be available and the formatter should be able to format a range in a document.")})),formatOnType:ge(new Rt(56,"formatOnType",!1,{description:p("formatOnType","Controls whether the editor should automatically format the line after typing.")})),glyphMargin:ge(new Rt(57,"glyphMargin",!0,{description:p("glyphMargin","Controls whether the editor should render the vertical glyph margin. Glyph margin is mostly used for debugging.")})),gotoLocation:ge(new Ite),hideCursorInOverviewRuler:ge(new Rt(59,"hideCursorInOverviewRuler",!1,{description:p("hideCursorInOverviewRuler","Controls whether the cursor should be hidden in the overview ruler.")})),hover:ge(new Ete),inDiffEditor:ge(new Rt(61,"inDiffEditor",!1)),letterSpacing:ge(new sa(64,"letterSpacing",Oo.letterSpacing,s=>sa.clamp(s,-5,20),{description:p("letterSpacing","Controls the letter spacing in pixels.")})),lightbulb:ge(new Tte),lineDecorationsWidth:ge(new Ate),lineHeight:ge(new Pte),lineNumbers:ge(new zte),lineNumbersMinChars:ge(new hi(69,"lineNumbersMinChars",5,1,300)),linkedEditing:ge(new Rt(70,"linkedEditing",!1,{description:p("linkedEditing","Controls whether the editor has linked editing enabled. Depending on the language, related symbols such as HTML tags, are updated while editing.")})),links:ge(new Rt(71,"links",!0,{description:p("links","Controls whether the editor should detect links and make them clickable.")})),matchBrackets:ge(new Gi(72,"matchBrackets","always",["always","near","never"],{description:p("matchBrackets","Highlight matching brackets.")})),minimap:ge(new Ote),mouseStyle:ge(new Gi(74,"mouseStyle","text",["text","default","copy"])),mouseWheelScrollSensitivity:ge(new sa(75,"mouseWheelScrollSensitivity",1,s=>s===0?1:s,{markdownDescription:p("mouseWheelScrollSensitivity","A multiplier to be used on the `deltaX` and `deltaY` of mouse wheel scroll events.")})),mouseWheelZoom:ge(new Rt(76,"mouseWheelZoom",!1,{markdownDescription:St?p("mouseWheelZoom.mac","Zoom the font of the editor when using mouse wheel and holding `Cmd`."):p("mouseWheelZoom","Zoom the font of the editor when using mouse wheel and holding `Ctrl`.")})),multiCursorMergeOverlapping:ge(new Rt(77,"multiCursorMergeOverlapping",!0,{description:p("multiCursorMergeOverlapping","Merge multiple cursors when they are overlapping.")})),multiCursorModifier:ge(new lS(78,"multiCursorModifier","altKey","alt",["ctrlCmd","alt"],Fte,{markdownEnumDescriptions:[p("multiCursorModifier.ctrlCmd","Maps to `Control` on Windows and Linux and to `Command` on macOS."),p("multiCursorModifier.alt","Maps to `Alt` on Windows and Linux and to `Option` on macOS.")],markdownDescription:p({},"The modifier to be used to add multiple cursors with the mouse. The Go to Definition and Open Link mouse gestures will adapt such that they do not conflict with the [multicursor modifier](https://code.visualstudio.com/docs/editor/codebasics#_multicursor-modifier).")})),multiCursorPaste:ge(new Gi(79,"multiCursorPaste","spread",["spread","full"],{markdownEnumDescriptions:[p("multiCursorPaste.spread","Each cursor pastes a single line of the text."),p("multiCursorPaste.full","Each cursor pastes the full text.")],markdownDescription:p("multiCursorPaste","Controls pasting when the line count of the pasted text matches the cursor count.")})),multiCursorLimit:ge(new hi(80,"multiCursorLimit",1e4,1,1e5,{markdownDescription:p("multiCursorLimit","Controls the max number of cursors that can be in an active editor at once.")})),occurrencesHighlight:ge(new Gi(81,"occurrencesHighlight","singleFile",["off","singleFile","multiFile"],{markdownEnumDescriptions:[p("occurrencesHighlight.off","Does not highlight occurrences."),p("occurrencesHighlight.singleFile","Highlights occurrences only in the current file."),p("occurrencesHighlight.multiFile","Experimental: Highlights occurrences across all valid open files.")],markdownDescription:p("occurrencesHighlight","Controls whether occurrences should be highlighted across open files.")})),overviewRulerBorder:ge(new Rt(82,"overviewRulerBorder",!0,{description:p("overviewRulerBorder","Controls whether a border should be drawn around the overview ruler.")})),overviewRulerLanes:ge(new hi(83,"overviewRulerLanes",3,0,3)),padding:ge(new Bte),pasteAs:ge(new nie),parameterHints:ge(new Wte),peekWidgetDefaultFocus:ge(new Gi(87,"peekWidgetDefaultFocus","tree",["tree","editor"],{enumDescriptions:[p("peekWidgetDefaultFocus.tree","Focus the tree when opening peek"),p("peekWidgetDefaultFocus.editor","Focus the editor when opening peek")],description:p("peekWidgetDefaultFocus","Controls whether to focus the inline editor or the tree in the peek widget.")})),definitionLinkOpensInPeek:ge(new Rt(88,"definitionLinkOpensInPeek",!1,{description:p("definitionLinkOpensInPeek","Controls whether the Go to Definition mouse gesture always opens the peek widget.")})),quickSuggestions:ge(new Vte),quickSuggestionsDelay:ge(new hi(90,"quickSuggestionsDelay",10,0,1073741824,{description:p("quickSuggestionsDelay","Controls the delay in milliseconds after which quick suggestions will show up.")})),readOnly:ge(new Rt(91,"readOnly",!1)),readOnlyMessage:ge(new $te),renameOnType:ge(new Rt(93,"renameOnType",!1,{description:p("renameOnType","Controls whether the editor auto renames on type."),markdownDeprecationMessage:p("renameOnTypeDeprecate","Deprecated, use `editor.linkedEditing` instead.")})),renderControlCharacters:ge(new Rt(94,"renderControlCharacters",!0,{description:p("renderControlCharacters","Controls whether the editor should render control characters."),restricted:!0})),renderFinalNewline:ge(new Gi(95,"renderFinalNewline",po?"dimmed":"on",["off","on","dimmed"],{description:p("renderFinalNewline","Render last line number when the file ends with a newline.")})),renderLineHighlight:ge(new Gi(96,"renderLineHighlight","line",["none","gutter","line","all"],{enumDescriptions:["","","",p("renderLineHighlight.all","Highlights both the gutter and the current line.")],description:p("renderLineHighlight","Controls how the editor should render the current line highlight.")})),renderLineHighlightOnlyWhenFocus:ge(new Rt(97,"renderLineHighlightOnlyWhenFocus",!1,{description:p("renderLineHighlightOnlyWhenFocus","Controls if the editor should render the current line highlight only when the editor is focused.")})),renderValidationDecorations:ge(new Gi(98,"renderValidationDecorations","editable",["editable","on","off"])),renderWhitespace:ge(new Gi(99,"renderWhitespace","selection",["none","boundary","selection","trailing","all"],{enumDescriptions:["",p("renderWhitespace.boundary","Render whitespace characters except for single spaces between words."),p("renderWhitespace.selection","Render whitespace characters only on selected text."),p("renderWhitespace.trailing","Render only trailing whitespace characters."),""],description:p("renderWhitespace","Controls how the editor should render whitespace characters.")})),revealHorizontalRightPadding:ge(new hi(100,"revealHorizontalRightPadding",15,0,1e3)),roundedSelection:ge(new Rt(101,"roundedSelection",!0,{description:p("roundedSelection","Controls whether selections should have rounded corners.")})),rulers:ge(new Ute),scrollbar:ge(new jte),scrollBeyondLastColumn:ge(new hi(104,"scrollBeyondLastColumn",4,0,1073741824,{description:p("scrollBeyondLastColumn","Controls the number of extra characters beyond which the editor will scroll horizontally.")})),scrollBeyondLastLine:ge(new Rt(105,"scrollBeyondLastLine",!0,{description:p("scrollBeyondLastLine","Controls whether the editor will scroll beyond the last line.")})),scrollPredominantAxis:ge(new Rt(106,"scrollPredominantAxis",!0,{description:p("scrollPredominantAxis","Scroll only along the predominant axis when scrolling both vertically and horizontally at the same time. Prevents horizontal drift when scrolling vertically on a trackpad.")})),selectionClipboard:ge(new Rt(107,"selectionClipboard",!0,{description:p("selectionClipboard","Controls whether the Linux primary clipboard should be supported."),included:po})),selectionHighlight:ge(new Rt(108,"selectionHighlight",!0,{description:p("selectionHighlight","Controls whether the editor should highlight matches similar to the selection.")})),selectOnLineNumbers:ge(new Rt(109,"selectOnLineNumbers",!0)),showFoldingControls:ge(new Gi(110,"showFoldingControls","mouseover",["always","never","mouseover"],{enumDescriptions:[p("showFoldingControls.always","Always show the folding controls."),p("showFoldingControls.never","Never show the folding controls and reduce the gutter size."),p("showFoldingControls.mouseover","Only show the folding controls when the mouse is over the gutter.")],description:p("showFoldingControls","Controls when the folding controls on the gutter are shown.")})),showUnused:ge(new Rt(111,"showUnused",!0,{description:p("showUnused","Controls fading out of unused code.")})),showDeprecated:ge(new Rt(140,"showDeprecated",!0,{description:p("showDeprecated","Controls strikethrough deprecated variables.")})),inlayHints:ge(new Rte),snippetSuggestions:ge(new Gi(112,"snippetSuggestions","inline",["top","bottom","inline","none"],{enumDescriptions:[p("snippetSuggestions.top","Show snippet suggestions on top of other suggestions."),p("snippetSuggestions.bottom","Show snippet suggestions below other suggestions."),p("snippetSuggestions.inline","Show snippets suggestions with other suggestions."),p("snippetSuggestions.none","Do not show snippet suggestions.")],description:p("snippetSuggestions","Controls whether snippets are shown with other suggestions and how they are sorted.")})),smartSelect:ge(new Qte),smoothScrolling:ge(new Rt(114,"smoothScrolling",!1,{description:p("smoothScrolling","Controls whether the editor will scroll using an animation.")})),stopRenderingLineAfter:ge(new hi(117,"stopRenderingLineAfter",1e4,-1,1073741824)),suggest:ge(new Xte),inlineSuggest:ge(new qte),inlineEdit:ge(new Gte),inlineCompletionsAccessibilityVerbose:ge(new Rt(149,"inlineCompletionsAccessibilityVerbose",!1,{description:p("inlineCompletionsAccessibilityVerbose","Controls whether the accessibility hint should be provided to screen reader users when an inline completion is shown.")})),suggestFontSize:ge(new hi(119,"suggestFontSize",0,0,1e3,{markdownDescription:p("suggestFontSize","Font size for the suggest widget. When set to {0}, the value of {1} is used.","`0`","`#editor.fontSize#`")})),suggestLineHeight:ge(new hi(120,"suggestLineHeight",0,0,1e3,{markdownDescription:p("suggestLineHeight","Line height for the suggest widget. When set to {0}, the value of {1} is used. The minimum value is 8.","`0`","`#editor.lineHeight#`")})),suggestOnTriggerCharacters:ge(new Rt(121,"suggestOnTriggerCharacters",!0,{description:p("suggestOnTriggerCharacters","Controls whether suggestions should automatically show up when typing trigger characters.")})),suggestSelection:ge(new Gi(122,"suggestSelection","first",["first","recentlyUsed","recentlyUsedByPrefix"],{markdownEnumDescriptions:[p("suggestSelection.first","Always select the first suggestion."),p("suggestSelection.recentlyUsed","Select recent suggestions unless further typing selects one, e.g. `console.| -> console.log` because `log` has been completed recently."),p("suggestSelection.recentlyUsedByPrefix","Select suggestions based on previous prefixes that have completed those suggestions, e.g. `co -> console` and `con -> const`.")],description:p("suggestSelection","Controls how suggestions are pre-selected when showing the suggest list.")})),tabCompletion:ge(new Gi(123,"tabCompletion","off",["on","off","onlySnippets"],{enumDescriptions:[p("tabCompletion.on","Tab complete will insert the best matching suggestion when pressing tab."),p("tabCompletion.off","Disable tab completions."),p("tabCompletion.onlySnippets","Tab complete snippets when their prefix match. Works best when 'quickSuggestions' aren't enabled.")],description:p("tabCompletion","Enables tab completions.")})),tabIndex:ge(new hi(124,"tabIndex",0,-1,1073741824)),unicodeHighlight:ge(new Kte),unusualLineTerminators:ge(new Gi(126,"unusualLineTerminators","prompt",["auto","off","prompt"],{enumDescriptions:[p("unusualLineTerminators.auto","Unusual line terminators are automatically removed."),p("unusualLineTerminators.off","Unusual line terminators are ignored."),p("unusualLineTerminators.prompt","Unusual line terminators prompt to be removed.")],description:p("unusualLineTerminators","Remove unusual line terminators that might cause problems.")})),useShadowDOM:ge(new Rt(127,"useShadowDOM",!0)),useTabStops:ge(new Rt(128,"useTabStops",!0,{description:p("useTabStops","Spaces and tabs are inserted and deleted in alignment with tab stops.")})),wordBreak:ge(new Gi(129,"wordBreak","normal",["normal","keepAll"],{markdownEnumDescriptions:[p("wordBreak.normal","Use the default line break rule."),p("wordBreak.keepAll","Word breaks should not be used for Chinese/Japanese/Korean (CJK) text. Non-CJK text behavior is the same as for normal.")],description:p("wordBreak","Controls the word break rules used for Chinese/Japanese/Korean (CJK) text.")})),wordSegmenterLocales:ge(new Jte),wordSeparators:ge(new to(131,"wordSeparators",zz,{description:p("wordSeparators","Characters that will be used as word separators when doing word related navigations or operations.")})),wordWrap:ge(new Gi(132,"wordWrap","off",["off","on","wordWrapColumn","bounded"],{markdownEnumDescriptions:[p("wordWrap.off","Lines will never wrap."),p("wordWrap.on","Lines will wrap at the viewport width."),p({},"Lines will wrap at `#editor.wordWrapColumn#`."),p({},"Lines will wrap at the minimum of viewport and `#editor.wordWrapColumn#`.")],description:p({},"Controls how lines should wrap.")})),wordWrapBreakAfterCharacters:ge(new to(133,"wordWrapBreakAfterCharacters"," 	})]?|/&.,;¢°′″‰℃、。｡､￠，．：；？！％・･ゝゞヽヾーァィゥェォッャュョヮヵヶぁぃぅぇぉっゃゅょゎゕゖㇰㇱㇲㇳㇴㇵㇶㇷㇸㇹㇺㇻㇼㇽㇾㇿ々〻ｧｨｩｪｫｬｭｮｯｰ”〉》」』】〕）］｝｣")),wordWrapBreakBeforeCharacters:ge(new to(134,"wordWrapBreakBeforeCharacters","([{‘“〈《「『【〔（［｛｢£¥＄￡￥+＋")),wordWrapColumn:ge(new hi(135,"wordWrapColumn",80,1,1073741824,{markdownDescription:p({},"Controls the wrapping column of the editor when `#editor.wordWrap#` is `wordWrapColumn` or `bounded`.")})),wordWrapOverride1:ge(new Gi(136,"wordWrapOverride1","inherit",["off","on","inherit"])),wordWrapOverride2:ge(new Gi(137,"wordWrapOverride2","inherit",["off","on","inherit"])),editorClassName:ge(new yte),defaultColorDecorators:ge(new Rt(147,"defaultColorDecorators",!1,{markdownDescription:p("defaultColorDecorators","Controls whether inline color decorations should be shown using the default document color provider")})),pixelRatio:ge(new Hte),tabFocusMode:ge(new Rt(144,"tabFocusMode",!1,{markdownDescription:p("tabFocusMode","Controls whether the editor receives tabs or defers them to the workbench for navigation.")})),layoutInfo:ge(new o_),wrappingInfo:ge(new tie),wrappingIndent:ge(new eie),wrappingStrategy:ge(new Nte)};class aie{constructor(){this.listeners=[],this.unexpectedErrorHandler=function(e){setTimeout(()=>{throw e.stack?P_.isErrorNoTelemetry(e)?new P_(e.message+`
@@ -783,4 +783,4 @@ ${e.toString()}`}}class iD{constructor(e=new Cw,t=!1,i,n=J0e){var o;this._servic
 `):void 0}}async provideRenameEdits(e,t){return this._provideRenameEdits(e,this._providerRenameIdx,[],t)}async _provideRenameEdits(e,t,i,n){const o=this._providers[t];if(!o)return{edits:[],rejectReason:i.join(`
 `)};const r=await o.provideRenameEdits(this.model,this.position,e,n);if(r){if(r.rejectReason)return this._provideRenameEdits(e,t+1,i.concat(r.rejectReason),n)}else return this._provideRenameEdits(e,t+1,i.concat(p("no result","No result.")),n);return r}}async function fTe(s,e,t,i){const n=new L3(e,t,s),o=await n.resolveRenameLocation(yt.None);return o!=null&&o.rejectReason?{edits:[],rejectReason:o.rejectReason}:n.provideRenameEdits(i,yt.None)}let Ru=cO=class{static get(e){return e.getContribution(cO.ID)}constructor(e,t,i,n,o,r,a,l,d){this.editor=e,this._instaService=t,this._notificationService=i,this._bulkEditService=n,this._progressService=o,this._logService=r,this._configService=a,this._languageFeaturesService=l,this._telemetryService=d,this._disposableStore=new se,this._cts=new en,this._renameWidget=this._disposableStore.add(this._instaService.createInstance(dO,this.editor,["acceptRenameInput","acceptRenameInputWithPreview"]))}dispose(){this._disposableStore.dispose(),this._cts.dispose(!0)}async run(){var e,t;const i=this._logService.trace.bind(this._logService,"[rename]");if(this._cts.dispose(!0),this._cts=new en,!this.editor.hasModel()){i("editor has no model");return}const n=this.editor.getPosition(),o=new L3(this.editor.getModel(),n,this._languageFeaturesService.renameProvider);if(!o.hasProvider()){i("skeleton has no provider");return}const r=new _f(this.editor,5,void 0,this._cts.token);let a;try{i("resolving rename location");const _=o.resolveRenameLocation(r.token);this._progressService.showWhile(_,250),a=await _,i("resolved rename location")}catch(_){_ instanceof Ol?i("resolve rename location cancelled",JSON.stringify(_,null,"	")):(i("resolve rename location failed",_ instanceof Error?_:JSON.stringify(_,null,"	")),(typeof _=="string"||Tl(_))&&((e=go.get(this.editor))===null||e===void 0||e.showMessage(_||p("resolveRenameLocationFailed","An unknown error occurred while resolving rename location"),n)));return}finally{r.dispose()}if(!a){i("returning early - no loc");return}if(a.rejectReason){i(`returning early - rejected with reason: ${a.rejectReason}`,a.rejectReason),(t=go.get(this.editor))===null||t===void 0||t.showMessage(a.rejectReason,n);return}if(r.token.isCancellationRequested){i("returning early - cts1 cancelled");return}const l=new _f(this.editor,5,a.range,this._cts.token),d=this.editor.getModel(),c=this._languageFeaturesService.newSymbolNamesProvider.all(d),u=await Promise.all(c.map(async _=>{var v;return[_,(v=await _.supportsAutomaticNewSymbolNamesTriggerKind)!==null&&v!==void 0?v:!1]})),h=(_,v)=>{let b=u.slice();return _===bC.Automatic&&(b=b.filter(([C,w])=>w)),b.map(([C])=>C.provideNewSymbolNames(d,a.range,_,v))};i("creating rename input field and awaiting its result");const f=this._bulkEditService.hasPreviewHandler()&&this._configService.getValue(this.editor.getModel().uri,"editor.rename.enablePreview"),g=await this._renameWidget.getInput(a.range,a.text,f,c.length>0?h:void 0,l);if(i("received response from rename input field"),c.length>0&&this._reportTelemetry(c.length,d.getLanguageId(),g),typeof g=="boolean"){i(`returning early - rename input field response - ${g}`),g&&this.editor.focus(),l.dispose();return}this.editor.focus(),i("requesting rename edits");const m=rw(o.provideRenameEdits(g.newName,l.token),l.token).then(async _=>{if(!_){i("returning early - no rename edits result");return}if(!this.editor.hasModel()){i("returning early - no model after rename edits are provided");return}if(_.rejectReason){i(`returning early - rejected with reason: ${_.rejectReason}`),this._notificationService.info(_.rejectReason);return}this.editor.setSelection(x.fromPositions(this.editor.getSelection().getPosition())),i("applying edits"),this._bulkEditService.apply(_,{editor:this.editor,showPreview:g.wantsPreview,label:p("label","Renaming '{0}' to '{1}'",a==null?void 0:a.text,g.newName),code:"undoredo.rename",quotableLabel:p("quotableLabel","Renaming {0} to {1}",a==null?void 0:a.text,g.newName),respectAutoSaveConfig:!0}).then(v=>{i("edits applied"),v.ariaSummary&&Wo(p("aria","Successfully renamed '{0}' to '{1}'. Summary: {2}",a.text,g.newName,v.ariaSummary))}).catch(v=>{i(`error when applying edits ${JSON.stringify(v,null,"	")}`),this._notificationService.error(p("rename.failedApply","Rename failed to apply edits")),this._logService.error(v)})},_=>{i("error when providing rename edits",JSON.stringify(_,null,"	")),this._notificationService.error(p("rename.failed","Rename failed to compute edits")),this._logService.error(_)}).finally(()=>{l.dispose()});return i("returning rename operation"),this._progressService.showWhile(m,250),m}acceptRenameInput(e){this._renameWidget.acceptInput(e)}cancelRenameInput(){this._renameWidget.cancelInput(!0,"cancelRenameInput command")}focusNextRenameSuggestion(){this._renameWidget.focusNextRenameSuggestion()}focusPreviousRenameSuggestion(){this._renameWidget.focusPreviousRenameSuggestion()}_reportTelemetry(e,t,i){const n=typeof i=="boolean"?{kind:"cancelled",languageId:t,nRenameSuggestionProviders:e}:{kind:"accepted",languageId:t,nRenameSuggestionProviders:e,source:i.stats.source.k,nRenameSuggestions:i.stats.nRenameSuggestions,timeBeforeFirstInputFieldEdit:i.stats.timeBeforeFirstInputFieldEdit,wantsPreview:i.wantsPreview,nRenameSuggestionsInvocations:i.stats.nRenameSuggestionsInvocations,hadAutomaticRenameSuggestionsInvocation:i.stats.hadAutomaticRenameSuggestionsInvocation};this._telemetryService.publicLog2("renameInvokedEvent",n)}};Ru.ID="editor.contrib.renameController";Ru=cO=hTe([Xu(1,$e),Xu(2,Cn),Xu(3,yw),Xu(4,Pf),Xu(5,Fs),Xu(6,QF),Xu(7,Te),Xu(8,Os)],Ru);class gTe extends De{constructor(){super({id:"editor.action.rename",label:p("rename.label","Rename Symbol"),alias:"Rename Symbol",precondition:ne.and(N.writable,N.hasRenameProvider),kbOpts:{kbExpr:N.editorTextFocus,primary:60,weight:100},contextMenuOpts:{group:"1_modification",order:1.1}})}runCommand(e,t){const i=e.get(Ot),[n,o]=Array.isArray(t)&&t||[void 0,void 0];return Ze.isUri(n)&&U.isIPosition(o)?i.openCodeEditor({resource:n},i.getActiveCodeEditor()).then(r=>{r&&(r.setPosition(o),r.invokeWithinContext(a=>(this.reportTelemetry(a,r),this.run(a,r))))},ct):super.runCommand(e,t)}run(e,t){const i=e.get(Fs),n=Ru.get(t);return n?(i.trace("[RenameAction] got controller, running..."),n.run()):(i.trace("[RenameAction] returning early - controller missing"),Promise.resolve())}}Ft(Ru.ID,Ru,4);ue(gTe);const x3=Wn.bindToContribution(Ru.get);Se(new x3({id:"acceptRenameInput",precondition:Yv,handler:s=>s.acceptRenameInput(!1),kbOpts:{weight:199,kbExpr:ne.and(N.focus,ne.not("isComposing")),primary:3}}));Se(new x3({id:"acceptRenameInputWithPreview",precondition:ne.and(Yv,ne.has("config.editor.rename.enablePreview")),handler:s=>s.acceptRenameInput(!0),kbOpts:{weight:199,kbExpr:ne.and(N.focus,ne.not("isComposing")),primary:2051}}));Se(new x3({id:"cancelRenameInput",precondition:Yv,handler:s=>s.cancelRenameInput(),kbOpts:{weight:199,kbExpr:N.focus,primary:9,secondary:[1033]}}));_i(class extends vo{constructor(){super({id:"focusNextRenameSuggestion",title:{...st("focusNextRenameSuggestion","Focus Next Rename Suggestion")},precondition:Yv,keybinding:[{primary:18,weight:199}]})}run(e){const t=e.get(Ot).getFocusedCodeEditor();if(!t)return;const i=Ru.get(t);i&&i.focusNextRenameSuggestion()}});_i(class extends vo{constructor(){super({id:"focusPreviousRenameSuggestion",title:{...st("focusPreviousRenameSuggestion","Focus Previous Rename Suggestion")},precondition:Yv,keybinding:[{primary:16,weight:199}]})}run(e){const t=e.get(Ot).getFocusedCodeEditor();if(!t)return;const i=Ru.get(t);i&&i.focusPreviousRenameSuggestion()}});uc("_executeDocumentRenameProvider",function(s,e,t,...i){const[n]=i;Pt(typeof n=="string");const{renameProvider:o}=s.get(Te);return fTe(o,e,t,n)});uc("_executePrepareRename",async function(s,e,t){const{renameProvider:i}=s.get(Te),o=await new L3(e,t,i).resolveRenameLocation(yt.None);if(o!=null&&o.rejectReason)throw new Error(o.rejectReason);return o});qi.as(ql.Configuration).registerConfiguration({id:"editor",properties:{"editor.rename.enablePreview":{scope:5,description:p("enablePreview","Enable/disable the ability to preview changes before renaming"),default:!0,type:"boolean"}}});var pTe=function(s,e,t,i){var n=arguments.length,o=n<3?e:i===null?i=Object.getOwnPropertyDescriptor(e,t):i,r;if(typeof Reflect=="object"&&typeof Reflect.decorate=="function")o=Reflect.decorate(s,e,t,i);else for(var a=s.length-1;a>=0;a--)(r=s[a])&&(o=(n<3?r(o):n>3?r(e,t,o):r(e,t))||o);return n>3&&o&&Object.defineProperty(e,t,o),o},OH=function(s,e){return function(t,i){e(t,i,s)}};let H1=class extends j{constructor(e,t,i){super(),this.editor=e,this.languageConfigurationService=t,this.editorWorkerService=i,this.decorations=this.editor.createDecorationsCollection(),this.options=this.createOptions(e.getOption(73)),this.computePromise=null,this.currentOccurrences={},this._register(e.onDidChangeModel(n=>{this.currentOccurrences={},this.options=this.createOptions(e.getOption(73)),this.stop(),this.computeSectionHeaders.schedule(0)})),this._register(e.onDidChangeModelLanguage(n=>{this.currentOccurrences={},this.options=this.createOptions(e.getOption(73)),this.stop(),this.computeSectionHeaders.schedule(0)})),this._register(t.onDidChange(n=>{var o;const r=(o=this.editor.getModel())===null||o===void 0?void 0:o.getLanguageId();r&&n.affects(r)&&(this.currentOccurrences={},this.options=this.createOptions(e.getOption(73)),this.stop(),this.computeSectionHeaders.schedule(0))})),this._register(e.onDidChangeConfiguration(n=>{this.options&&!n.hasChanged(73)||(this.options=this.createOptions(e.getOption(73)),this.updateDecorations([]),this.stop(),this.computeSectionHeaders.schedule(0))})),this._register(this.editor.onDidChangeModelContent(n=>{this.computeSectionHeaders.schedule()})),this._register(e.onDidChangeModelTokens(n=>{this.computeSectionHeaders.isScheduled()||this.computeSectionHeaders.schedule(1e3)})),this.computeSectionHeaders=this._register(new oi(()=>{this.findSectionHeaders()},250)),this.computeSectionHeaders.schedule(0)}createOptions(e){if(!e||!this.editor.hasModel())return;const t=this.editor.getModel().getLanguageId();if(!t)return;const i=this.languageConfigurationService.getLanguageConfiguration(t).comments,n=this.languageConfigurationService.getLanguageConfiguration(t).foldingRules;if(!(!i&&!(n!=null&&n.markers)))return{foldingRules:n,findMarkSectionHeaders:e.showMarkSectionHeaders,findRegionSectionHeaders:e.showRegionSectionHeaders}}findSectionHeaders(){var e,t;if(!this.editor.hasModel()||!(!((e=this.options)===null||e===void 0)&&e.findMarkSectionHeaders)&&!(!((t=this.options)===null||t===void 0)&&t.findRegionSectionHeaders))return;const i=this.editor.getModel();if(i.isDisposed()||i.isTooLargeForSyncing())return;const n=i.getVersionId();this.editorWorkerService.findSectionHeaders(i.uri,this.options).then(o=>{i.isDisposed()||i.getVersionId()!==n||this.updateDecorations(o)})}updateDecorations(e){const t=this.editor.getModel();t&&(e=e.filter(o=>{if(!o.shouldBeInComments)return!0;const r=t.validateRange(o.range),a=t.tokenization.getLineTokens(r.startLineNumber),l=a.findTokenIndexAtOffset(r.startColumn-1),d=a.getStandardTokenType(l);return a.getLanguageId(l)===t.getLanguageId()&&d===1}));const i=Object.values(this.currentOccurrences).map(o=>o.decorationId),n=e.map(o=>mTe(o));this.editor.changeDecorations(o=>{const r=o.deltaDecorations(i,n);this.currentOccurrences={};for(let a=0,l=r.length;a<l;a++){const d={sectionHeader:e[a],decorationId:r[a]};this.currentOccurrences[d.decorationId]=d}})}stop(){this.computeSectionHeaders.cancel(),this.computePromise&&(this.computePromise.cancel(),this.computePromise=null)}dispose(){super.dispose(),this.stop(),this.decorations.clear()}};H1.ID="editor.sectionHeaderDetector";H1=pTe([OH(1,Si),OH(2,wa)],H1);function mTe(s){return{range:s.range,options:ut.createDynamic({description:"section-header",stickiness:3,collapseOnReplaceEdit:!0,minimap:{color:void 0,position:1,sectionHeaderStyle:s.hasSeparatorLine?2:1,sectionHeaderText:s.text}})}}Ft(H1.ID,H1,1);function _Te(s){for(let e=0,t=s.length;e<t;e+=4){const i=s[e+0],n=s[e+1],o=s[e+2],r=s[e+3];s[e+0]=r,s[e+1]=o,s[e+2]=n,s[e+3]=i}}function vTe(s){const e=new Uint8Array(s.buffer,s.byteOffset,s.length*4);return Hz()||_Te(e),Ck.wrap(e)}function fY(s){const e=new Uint32Array(bTe(s));let t=0;if(e[t++]=s.id,s.type==="full")e[t++]=1,e[t++]=s.data.length,e.set(s.data,t),t+=s.data.length;else{e[t++]=2,e[t++]=s.deltas.length;for(const i of s.deltas)e[t++]=i.start,e[t++]=i.deleteCount,i.data?(e[t++]=i.data.length,e.set(i.data,t),t+=i.data.length):e[t++]=0}return vTe(e)}function bTe(s){let e=0;if(e+=2,s.type==="full")e+=1+s.data.length;else{e+=1,e+=3*s.deltas.length;for(const t of s.deltas)t.data&&(e+=t.data.length)}return e}function rE(s){return s&&!!s.data}function gY(s){return s&&Array.isArray(s.edits)}class CTe{constructor(e,t,i){this.provider=e,this.tokens=t,this.error=i}}function pY(s,e){return s.has(e)}function wTe(s,e){const t=s.orderedGroups(e);return t.length>0?t[0]:[]}async function mY(s,e,t,i,n){const o=wTe(s,e),r=await Promise.all(o.map(async a=>{let l,d=null;try{l=await a.provideDocumentSemanticTokens(e,a===t?i:null,n)}catch(c){d=c,l=null}return(!l||!rE(l)&&!gY(l))&&(l=null),new CTe(a,l,d)}));for(const a of r){if(a.error)throw a.error;if(a.tokens)return a}return r.length>0?r[0]:null}function STe(s,e){const t=s.orderedGroups(e);return t.length>0?t[0]:null}class yTe{constructor(e,t){this.provider=e,this.tokens=t}}function LTe(s,e){return s.has(e)}function _Y(s,e){const t=s.orderedGroups(e);return t.length>0?t[0]:[]}async function D3(s,e,t,i){const n=_Y(s,e),o=await Promise.all(n.map(async r=>{let a;try{a=await r.provideDocumentRangeSemanticTokens(e,t,i)}catch(l){an(l),a=null}return(!a||!rE(a))&&(a=null),new yTe(r,a)}));for(const r of o)if(r.tokens)return r;return o.length>0?o[0]:null}It.registerCommand("_provideDocumentSemanticTokensLegend",async(s,...e)=>{const[t]=e;Pt(t instanceof Ze);const i=s.get(Vi).getModel(t);if(!i)return;const{documentSemanticTokensProvider:n}=s.get(Te),o=STe(n,i);return o?o[0].getLegend():s.get(Ii).executeCommand("_provideDocumentRangeSemanticTokensLegend",t)});It.registerCommand("_provideDocumentSemanticTokens",async(s,...e)=>{const[t]=e;Pt(t instanceof Ze);const i=s.get(Vi).getModel(t);if(!i)return;const{documentSemanticTokensProvider:n}=s.get(Te);if(!pY(n,i))return s.get(Ii).executeCommand("_provideDocumentRangeSemanticTokens",t,i.getFullModelRange());const o=await mY(n,i,null,null,yt.None);if(!o)return;const{provider:r,tokens:a}=o;if(!a||!rE(a))return;const l=fY({id:0,type:"full",data:a.data});return a.resultId&&r.releaseDocumentSemanticTokens(a.resultId),l});It.registerCommand("_provideDocumentRangeSemanticTokensLegend",async(s,...e)=>{const[t,i]=e;Pt(t instanceof Ze);const n=s.get(Vi).getModel(t);if(!n)return;const{documentRangeSemanticTokensProvider:o}=s.get(Te),r=_Y(o,n);if(r.length===0)return;if(r.length===1)return r[0].getLegend();if(!i||!x.isIRange(i))return console.warn("provideDocumentRangeSemanticTokensLegend might be out-of-sync with provideDocumentRangeSemanticTokens unless a range argument is passed in"),r[0].getLegend();const a=await D3(o,n,x.lift(i),yt.None);if(a)return a.provider.getLegend()});It.registerCommand("_provideDocumentRangeSemanticTokens",async(s,...e)=>{const[t,i]=e;Pt(t instanceof Ze),Pt(x.isIRange(i));const n=s.get(Vi).getModel(t);if(!n)return;const{documentRangeSemanticTokensProvider:o}=s.get(Te),r=await D3(o,n,x.lift(i),yt.None);if(!(!r||!r.tokens))return fY({id:0,type:"full",data:r.tokens.data})});const k3="editor.semanticHighlighting";function bL(s,e,t){var i;const n=(i=t.getValue(k3,{overrideIdentifier:s.getLanguageId(),resource:s.uri}))===null||i===void 0?void 0:i.enabled;return typeof n=="boolean"?n:e.getColorTheme().semanticHighlighting}var vY=function(s,e,t,i){var n=arguments.length,o=n<3?e:i===null?i=Object.getOwnPropertyDescriptor(e,t):i,r;if(typeof Reflect=="object"&&typeof Reflect.decorate=="function")o=Reflect.decorate(s,e,t,i);else for(var a=s.length-1;a>=0;a--)(r=s[a])&&(o=(n<3?r(o):n>3?r(e,t,o):r(e,t))||o);return n>3&&o&&Object.defineProperty(e,t,o),o},wd=function(s,e){return function(t,i){e(t,i,s)}},oh;let uO=class extends j{constructor(e,t,i,n,o,r){super(),this._watchers=Object.create(null);const a=c=>{this._watchers[c.uri.toString()]=new V1(c,e,i,o,r)},l=(c,u)=>{u.dispose(),delete this._watchers[c.uri.toString()]},d=()=>{for(const c of t.getModels()){const u=this._watchers[c.uri.toString()];bL(c,i,n)?u||a(c):u&&l(c,u)}};t.getModels().forEach(c=>{bL(c,i,n)&&a(c)}),this._register(t.onModelAdded(c=>{bL(c,i,n)&&a(c)})),this._register(t.onModelRemoved(c=>{const u=this._watchers[c.uri.toString()];u&&l(c,u)})),this._register(n.onDidChangeConfiguration(c=>{c.affectsConfiguration(k3)&&d()})),this._register(i.onDidColorThemeChange(d))}dispose(){for(const e of Object.values(this._watchers))e.dispose();super.dispose()}};uO=vY([wd(0,Hk),wd(1,Vi),wd(2,Hn),wd(3,vt),wd(4,ba),wd(5,Te)],uO);let V1=oh=class extends j{constructor(e,t,i,n,o){super(),this._semanticTokensStylingService=t,this._isDisposed=!1,this._model=e,this._provider=o.documentSemanticTokensProvider,this._debounceInformation=n.for(this._provider,"DocumentSemanticTokens",{min:oh.REQUEST_MIN_DELAY,max:oh.REQUEST_MAX_DELAY}),this._fetchDocumentSemanticTokens=this._register(new oi(()=>this._fetchDocumentSemanticTokensNow(),oh.REQUEST_MIN_DELAY)),this._currentDocumentResponse=null,this._currentDocumentRequestCancellationTokenSource=null,this._documentProvidersChangeListeners=[],this._providersChangedDuringRequest=!1,this._register(this._model.onDidChangeContent(()=>{this._fetchDocumentSemanticTokens.isScheduled()||this._fetchDocumentSemanticTokens.schedule(this._debounceInformation.get(this._model))})),this._register(this._model.onDidChangeAttached(()=>{this._fetchDocumentSemanticTokens.isScheduled()||this._fetchDocumentSemanticTokens.schedule(this._debounceInformation.get(this._model))})),this._register(this._model.onDidChangeLanguage(()=>{this._currentDocumentResponse&&(this._currentDocumentResponse.dispose(),this._currentDocumentResponse=null),this._currentDocumentRequestCancellationTokenSource&&(this._currentDocumentRequestCancellationTokenSource.cancel(),this._currentDocumentRequestCancellationTokenSource=null),this._setDocumentSemanticTokens(null,null,null,[]),this._fetchDocumentSemanticTokens.schedule(0)}));const r=()=>{pi(this._documentProvidersChangeListeners),this._documentProvidersChangeListeners=[];for(const a of this._provider.all(e))typeof a.onDidChange=="function"&&this._documentProvidersChangeListeners.push(a.onDidChange(()=>{if(this._currentDocumentRequestCancellationTokenSource){this._providersChangedDuringRequest=!0;return}this._fetchDocumentSemanticTokens.schedule(0)}))};r(),this._register(this._provider.onDidChange(()=>{r(),this._fetchDocumentSemanticTokens.schedule(this._debounceInformation.get(this._model))})),this._register(i.onDidColorThemeChange(a=>{this._setDocumentSemanticTokens(null,null,null,[]),this._fetchDocumentSemanticTokens.schedule(this._debounceInformation.get(this._model))})),this._fetchDocumentSemanticTokens.schedule(0)}dispose(){this._currentDocumentResponse&&(this._currentDocumentResponse.dispose(),this._currentDocumentResponse=null),this._currentDocumentRequestCancellationTokenSource&&(this._currentDocumentRequestCancellationTokenSource.cancel(),this._currentDocumentRequestCancellationTokenSource=null),pi(this._documentProvidersChangeListeners),this._documentProvidersChangeListeners=[],this._setDocumentSemanticTokens(null,null,null,[]),this._isDisposed=!0,super.dispose()}_fetchDocumentSemanticTokensNow(){if(this._currentDocumentRequestCancellationTokenSource)return;if(!pY(this._provider,this._model)){this._currentDocumentResponse&&this._model.tokenization.setSemanticTokens(null,!1);return}if(!this._model.isAttachedToEditor())return;const e=new en,t=this._currentDocumentResponse?this._currentDocumentResponse.provider:null,i=this._currentDocumentResponse&&this._currentDocumentResponse.resultId||null,n=mY(this._provider,this._model,t,i,e.token);this._currentDocumentRequestCancellationTokenSource=e,this._providersChangedDuringRequest=!1;const o=[],r=this._model.onDidChangeContent(l=>{o.push(l)}),a=new xs(!1);n.then(l=>{if(this._debounceInformation.update(this._model,a.elapsed()),this._currentDocumentRequestCancellationTokenSource=null,r.dispose(),!l)this._setDocumentSemanticTokens(null,null,null,o);else{const{provider:d,tokens:c}=l,u=this._semanticTokensStylingService.getStyling(d);this._setDocumentSemanticTokens(d,c||null,u,o)}},l=>{l&&($l(l)||typeof l.message=="string"&&l.message.indexOf("busy")!==-1)||ct(l),this._currentDocumentRequestCancellationTokenSource=null,r.dispose(),(o.length>0||this._providersChangedDuringRequest)&&(this._fetchDocumentSemanticTokens.isScheduled()||this._fetchDocumentSemanticTokens.schedule(this._debounceInformation.get(this._model)))})}static _copy(e,t,i,n,o){o=Math.min(o,i.length-n,e.length-t);for(let r=0;r<o;r++)i[n+r]=e[t+r]}_setDocumentSemanticTokens(e,t,i,n){const o=this._currentDocumentResponse,r=()=>{(n.length>0||this._providersChangedDuringRequest)&&!this._fetchDocumentSemanticTokens.isScheduled()&&this._fetchDocumentSemanticTokens.schedule(this._debounceInformation.get(this._model))};if(this._currentDocumentResponse&&(this._currentDocumentResponse.dispose(),this._currentDocumentResponse=null),this._isDisposed){e&&t&&e.releaseDocumentSemanticTokens(t.resultId);return}if(!e||!i){this._model.tokenization.setSemanticTokens(null,!1);return}if(!t){this._model.tokenization.setSemanticTokens(null,!0),r();return}if(gY(t)){if(!o){this._model.tokenization.setSemanticTokens(null,!0);return}if(t.edits.length===0)t={resultId:t.resultId,data:o.data};else{let a=0;for(const h of t.edits)a+=(h.data?h.data.length:0)-h.deleteCount;const l=o.data,d=new Uint32Array(l.length+a);let c=l.length,u=d.length;for(let h=t.edits.length-1;h>=0;h--){const f=t.edits[h];if(f.start>l.length){i.warnInvalidEditStart(o.resultId,t.resultId,h,f.start,l.length),this._model.tokenization.setSemanticTokens(null,!0);return}const g=c-(f.start+f.deleteCount);g>0&&(oh._copy(l,c-g,d,u-g,g),u-=g),f.data&&(oh._copy(f.data,0,d,u-f.data.length,f.data.length),u-=f.data.length),c=f.start}c>0&&oh._copy(l,0,d,0,c),t={resultId:t.resultId,data:d}}}if(rE(t)){this._currentDocumentResponse=new xTe(e,t.resultId,t.data);const a=qj(t,i,this._model.getLanguageId());if(n.length>0)for(const l of n)for(const d of a)for(const c of l.changes)d.applyEdit(c.range,c.text);this._model.tokenization.setSemanticTokens(a,!0)}else this._model.tokenization.setSemanticTokens(null,!0);r()}};V1.REQUEST_MIN_DELAY=300;V1.REQUEST_MAX_DELAY=2e3;V1=oh=vY([wd(1,Hk),wd(2,Hn),wd(3,ba),wd(4,Te)],V1);class xTe{constructor(e,t,i){this.provider=e,this.resultId=t,this.data=i}dispose(){this.provider.releaseDocumentSemanticTokens(this.resultId)}}Rw(uO);var DTe=function(s,e,t,i){var n=arguments.length,o=n<3?e:i===null?i=Object.getOwnPropertyDescriptor(e,t):i,r;if(typeof Reflect=="object"&&typeof Reflect.decorate=="function")o=Reflect.decorate(s,e,t,i);else for(var a=s.length-1;a>=0;a--)(r=s[a])&&(o=(n<3?r(o):n>3?r(e,t,o):r(e,t))||o);return n>3&&o&&Object.defineProperty(e,t,o),o},Wb=function(s,e){return function(t,i){e(t,i,s)}};let z1=class extends j{constructor(e,t,i,n,o,r){super(),this._semanticTokensStylingService=t,this._themeService=i,this._configurationService=n,this._editor=e,this._provider=r.documentRangeSemanticTokensProvider,this._debounceInformation=o.for(this._provider,"DocumentRangeSemanticTokens",{min:100,max:500}),this._tokenizeViewport=this._register(new oi(()=>this._tokenizeViewportNow(),100)),this._outstandingRequests=[];const a=()=>{this._editor.hasModel()&&this._tokenizeViewport.schedule(this._debounceInformation.get(this._editor.getModel()))};this._register(this._editor.onDidScrollChange(()=>{a()})),this._register(this._editor.onDidChangeModel(()=>{this._cancelAll(),a()})),this._register(this._editor.onDidChangeModelContent(l=>{this._cancelAll(),a()})),this._register(this._provider.onDidChange(()=>{this._cancelAll(),a()})),this._register(this._configurationService.onDidChangeConfiguration(l=>{l.affectsConfiguration(k3)&&(this._cancelAll(),a())})),this._register(this._themeService.onDidColorThemeChange(()=>{this._cancelAll(),a()})),a()}_cancelAll(){for(const e of this._outstandingRequests)e.cancel();this._outstandingRequests=[]}_removeOutstandingRequest(e){for(let t=0,i=this._outstandingRequests.length;t<i;t++)if(this._outstandingRequests[t]===e){this._outstandingRequests.splice(t,1);return}}_tokenizeViewportNow(){if(!this._editor.hasModel())return;const e=this._editor.getModel();if(e.tokenization.hasCompleteSemanticTokens())return;if(!bL(e,this._themeService,this._configurationService)){e.tokenization.hasSomeSemanticTokens()&&e.tokenization.setSemanticTokens(null,!1);return}if(!LTe(this._provider,e)){e.tokenization.hasSomeSemanticTokens()&&e.tokenization.setSemanticTokens(null,!1);return}const t=this._editor.getVisibleRangesPlusViewportAboveBelow();this._outstandingRequests=this._outstandingRequests.concat(t.map(i=>this._requestRange(e,i)))}_requestRange(e,t){const i=e.getVersionId(),n=Gn(r=>Promise.resolve(D3(this._provider,e,t,r))),o=new xs(!1);return n.then(r=>{if(this._debounceInformation.update(e,o.elapsed()),!r||!r.tokens||e.isDisposed()||e.getVersionId()!==i)return;const{provider:a,tokens:l}=r,d=this._semanticTokensStylingService.getStyling(a);e.tokenization.setPartialSemanticTokens(t,qj(l,d,e.getLanguageId()))}).then(()=>this._removeOutstandingRequest(n),()=>this._removeOutstandingRequest(n)),n}};z1.ID="editor.contrib.viewportSemanticTokens";z1=DTe([Wb(1,Hk),Wb(2,Hn),Wb(3,vt),Wb(4,ba),Wb(5,Te)],z1);Ft(z1.ID,z1,1);class kTe{constructor(e=!0){this.selectSubwords=e}provideSelectionRanges(e,t){const i=[];for(const n of t){const o=[];i.push(o),this.selectSubwords&&this._addInWordRanges(o,e,n),this._addWordRanges(o,e,n),this._addWhitespaceLine(o,e,n),o.push({range:e.getFullModelRange()})}return i}_addInWordRanges(e,t,i){const n=t.getWordAtPosition(i);if(!n)return;const{word:o,startColumn:r}=n,a=i.column-r;let l=a,d=a,c=0;for(;l>=0;l--){const u=o.charCodeAt(l);if(l!==a&&(u===95||u===45))break;if(wh(u)&&_d(c))break;c=u}for(l+=1;d<o.length;d++){const u=o.charCodeAt(d);if(_d(u)&&wh(c))break;if(u===95||u===45)break;c=u}l<d&&e.push({range:new x(i.lineNumber,r+l,i.lineNumber,r+d)})}_addWordRanges(e,t,i){const n=t.getWordAtPosition(i);n&&e.push({range:new x(i.lineNumber,n.startColumn,i.lineNumber,n.endColumn)})}_addWhitespaceLine(e,t,i){t.getLineLength(i.lineNumber)>0&&t.getLineFirstNonWhitespaceColumn(i.lineNumber)===0&&t.getLineLastNonWhitespaceColumn(i.lineNumber)===0&&e.push({range:new x(i.lineNumber,1,i.lineNumber,t.getLineMaxColumn(i.lineNumber))})}}var ITe=function(s,e,t,i){var n=arguments.length,o=n<3?e:i===null?i=Object.getOwnPropertyDescriptor(e,t):i,r;if(typeof Reflect=="object"&&typeof Reflect.decorate=="function")o=Reflect.decorate(s,e,t,i);else for(var a=s.length-1;a>=0;a--)(r=s[a])&&(o=(n<3?r(o):n>3?r(e,t,o):r(e,t))||o);return n>3&&o&&Object.defineProperty(e,t,o),o},ETe=function(s,e){return function(t,i){e(t,i,s)}},hO;class I3{constructor(e,t){this.index=e,this.ranges=t}mov(e){const t=this.index+(e?1:-1);if(t<0||t>=this.ranges.length)return this;const i=new I3(t,this.ranges);return i.ranges[t].equalsRange(this.ranges[this.index])?i.mov(e):i}}let Lv=hO=class{static get(e){return e.getContribution(hO.ID)}constructor(e,t){this._editor=e,this._languageFeaturesService=t,this._ignoreSelection=!1}dispose(){var e;(e=this._selectionListener)===null||e===void 0||e.dispose()}async run(e){if(!this._editor.hasModel())return;const t=this._editor.getSelections(),i=this._editor.getModel();if(this._state||await CY(this._languageFeaturesService.selectionRangeProvider,i,t.map(o=>o.getPosition()),this._editor.getOption(113),yt.None).then(o=>{var r;if(!(!Ms(o)||o.length!==t.length)&&!(!this._editor.hasModel()||!Ki(this._editor.getSelections(),t,(a,l)=>a.equalsSelection(l)))){for(let a=0;a<o.length;a++)o[a]=o[a].filter(l=>l.containsPosition(t[a].getStartPosition())&&l.containsPosition(t[a].getEndPosition())),o[a].unshift(t[a]);this._state=o.map(a=>new I3(0,a)),(r=this._selectionListener)===null||r===void 0||r.dispose(),this._selectionListener=this._editor.onDidChangeCursorPosition(()=>{var a;this._ignoreSelection||((a=this._selectionListener)===null||a===void 0||a.dispose(),this._state=void 0)})}}),!this._state)return;this._state=this._state.map(o=>o.mov(e));const n=this._state.map(o=>Ae.fromPositions(o.ranges[o.index].getStartPosition(),o.ranges[o.index].getEndPosition()));this._ignoreSelection=!0;try{this._editor.setSelections(n)}finally{this._ignoreSelection=!1}}};Lv.ID="editor.contrib.smartSelectController";Lv=hO=ITe([ETe(1,Te)],Lv);class bY extends De{constructor(e,t){super(t),this._forward=e}async run(e,t){const i=Lv.get(t);i&&await i.run(this._forward)}}class NTe extends bY{constructor(){super(!0,{id:"editor.action.smartSelect.expand",label:p("smartSelect.expand","Expand Selection"),alias:"Expand Selection",precondition:void 0,kbOpts:{kbExpr:N.editorTextFocus,primary:1553,mac:{primary:3345,secondary:[1297]},weight:100},menuOpts:{menuId:k.MenubarSelectionMenu,group:"1_basic",title:p({},"&&Expand Selection"),order:2}})}}It.registerCommandAlias("editor.action.smartSelect.grow","editor.action.smartSelect.expand");class TTe extends bY{constructor(){super(!1,{id:"editor.action.smartSelect.shrink",label:p("smartSelect.shrink","Shrink Selection"),alias:"Shrink Selection",precondition:void 0,kbOpts:{kbExpr:N.editorTextFocus,primary:1551,mac:{primary:3343,secondary:[1295]},weight:100},menuOpts:{menuId:k.MenubarSelectionMenu,group:"1_basic",title:p({},"&&Shrink Selection"),order:3}})}}Ft(Lv.ID,Lv,4);ue(NTe);ue(TTe);async function CY(s,e,t,i,n){const o=s.all(e).concat(new kTe(i.selectSubwords));o.length===1&&o.unshift(new yr);const r=[],a=[];for(const l of o)r.push(Promise.resolve(l.provideSelectionRanges(e,t,n)).then(d=>{if(Ms(d)&&d.length===t.length)for(let c=0;c<t.length;c++){a[c]||(a[c]=[]);for(const u of d[c])x.isIRange(u.range)&&x.containsPosition(u.range,t[c])&&a[c].push(x.lift(u.range))}},an));return await Promise.all(r),a.map(l=>{if(l.length===0)return[];l.sort((h,f)=>U.isBefore(h.getStartPosition(),f.getStartPosition())?1:U.isBefore(f.getStartPosition(),h.getStartPosition())||U.isBefore(h.getEndPosition(),f.getEndPosition())?-1:U.isBefore(f.getEndPosition(),h.getEndPosition())?1:0);const d=[];let c;for(const h of l)(!c||x.containsRange(h,c)&&!x.equalsRange(h,c))&&(d.push(h),c=h);if(!i.selectLeadingAndTrailingWhitespace)return d;const u=[d[0]];for(let h=1;h<d.length;h++){const f=d[h-1],g=d[h];if(g.startLineNumber!==f.startLineNumber||g.endLineNumber!==f.endLineNumber){const m=new x(f.startLineNumber,e.getLineFirstNonWhitespaceColumn(f.startLineNumber),f.endLineNumber,e.getLineLastNonWhitespaceColumn(f.endLineNumber));m.containsRange(f)&&!m.equalsRange(f)&&g.containsRange(m)&&!g.equalsRange(m)&&u.push(m);const _=new x(f.startLineNumber,1,f.endLineNumber,e.getLineMaxColumn(f.endLineNumber));_.containsRange(f)&&!_.equalsRange(m)&&g.containsRange(_)&&!g.equalsRange(_)&&u.push(_)}u.push(g)}return u})}It.registerCommand("_executeSelectionRangeProvider",async function(s,...e){const[t,i]=e;Pt(Ze.isUri(t));const n=s.get(Te).selectionRangeProvider,o=await s.get(zo).createModelReference(t);try{return CY(n,o.object.textEditorModel,i,{selectLeadingAndTrailingWhitespace:!0,selectSubwords:!0},yt.None)}finally{o.dispose()}});const MTe=Object.freeze({View:st("view","View"),Help:st("help","Help"),Test:st("test","Test"),File:st("file","File"),Preferences:st("preferences","Preferences"),Developer:st({},"Developer")});class FH{constructor(e,t,i,n=null){this.startLineNumbers=e,this.endLineNumbers=t,this.lastLineRelativePosition=i,this.showEndForLine=n}equals(e){return!!e&&this.lastLineRelativePosition===e.lastLineRelativePosition&&this.showEndForLine===e.showEndForLine&&Ki(this.startLineNumbers,e.startLineNumbers)&&Ki(this.endLineNumbers,e.endLineNumbers)}}const BH=Ou("stickyScrollViewLayer",{createHTML:s=>s}),OT="data-sticky-line-index",WH="data-sticky-is-line",RTe="data-sticky-is-line-number",HH="data-sticky-is-folding-icon";class ATe extends j{constructor(e){super(),this._editor=e,this._foldingIconStore=new se,this._rootDomNode=document.createElement("div"),this._lineNumbersDomNode=document.createElement("div"),this._linesDomNodeScrollable=document.createElement("div"),this._linesDomNode=document.createElement("div"),this._lineHeight=this._editor.getOption(67),this._renderedStickyLines=[],this._lineNumbers=[],this._lastLineRelativePosition=0,this._minContentWidthInPx=0,this._isOnGlyphMargin=!1,this._lineNumbersDomNode.className="sticky-widget-line-numbers",this._lineNumbersDomNode.setAttribute("role","none"),this._linesDomNode.className="sticky-widget-lines",this._linesDomNode.setAttribute("role","list"),this._linesDomNodeScrollable.className="sticky-widget-lines-scrollable",this._linesDomNodeScrollable.appendChild(this._linesDomNode),this._rootDomNode.className="sticky-widget",this._rootDomNode.classList.toggle("peek",e instanceof wf),this._rootDomNode.appendChild(this._lineNumbersDomNode),this._rootDomNode.appendChild(this._linesDomNodeScrollable);const t=()=>{this._linesDomNode.style.left=this._editor.getOption(115).scrollWithEditor?`-${this._editor.getScrollLeft()}px`:"0px"};this._register(this._editor.onDidChangeConfiguration(i=>{i.hasChanged(115)&&t(),i.hasChanged(67)&&(this._lineHeight=this._editor.getOption(67))})),this._register(this._editor.onDidScrollChange(i=>{i.scrollLeftChanged&&t(),i.scrollWidthChanged&&this._updateWidgetWidth()})),this._register(this._editor.onDidChangeModel(()=>{t(),this._updateWidgetWidth()})),this._register(this._foldingIconStore),t(),this._register(this._editor.onDidLayoutChange(i=>{this._updateWidgetWidth()})),this._updateWidgetWidth()}get lineNumbers(){return this._lineNumbers}get lineNumberCount(){return this._lineNumbers.length}getRenderedStickyLine(e){return this._renderedStickyLines.find(t=>t.lineNumber===e)}getCurrentLines(){return this._lineNumbers}setState(e,t,i){if(i===void 0&&(!this._previousState&&!e||this._previousState&&this._previousState.equals(e)))return;const n=this._isWidgetHeightZero(e),o=n?void 0:e,r=n?0:this._findLineToRebuildWidgetFrom(e,i);this._renderRootNode(o,t,r),this._previousState=e}_isWidgetHeightZero(e){if(!e)return!0;const t=e.startLineNumbers.length*this._lineHeight+e.lastLineRelativePosition;if(t>0){this._lastLineRelativePosition=e.lastLineRelativePosition;const i=[...e.startLineNumbers];e.showEndForLine!==null&&(i[e.showEndForLine]=e.endLineNumbers[e.showEndForLine]),this._lineNumbers=i}else this._lastLineRelativePosition=0,this._lineNumbers=[];return t===0}_findLineToRebuildWidgetFrom(e,t){if(!e||!this._previousState)return 0;if(t!==void 0)return t;const i=this._previousState,n=e.startLineNumbers.findIndex(o=>!i.startLineNumbers.includes(o));return n===-1?0:n}_updateWidgetWidth(){const e=this._editor.getLayoutInfo(),t=e.contentLeft;this._lineNumbersDomNode.style.width=`${t}px`,this._linesDomNodeScrollable.style.setProperty("--vscode-editorStickyScroll-scrollableWidth",`${this._editor.getScrollWidth()-e.verticalScrollbarWidth}px`),this._rootDomNode.style.width=`${e.width-e.verticalScrollbarWidth}px`}_clearStickyLinesFromLine(e){this._foldingIconStore.clear();for(let t=e;t<this._renderedStickyLines.length;t++){const i=this._renderedStickyLines[t];i.lineNumberDomNode.remove(),i.lineDomNode.remove()}this._renderedStickyLines=this._renderedStickyLines.slice(0,e),this._rootDomNode.style.display="none"}_useFoldingOpacityTransition(e){this._lineNumbersDomNode.style.setProperty("--vscode-editorStickyScroll-foldingOpacityTransition",`opacity ${e?.5:0}s`)}_setFoldingIconsVisibility(e){for(const t of this._renderedStickyLines){const i=t.foldingIcon;i&&i.setVisible(e?!0:i.isCollapsed)}}async _renderRootNode(e,t,i){if(this._clearStickyLinesFromLine(i),!e)return;for(const a of this._renderedStickyLines)this._updateTopAndZIndexOfStickyLine(a);const n=this._editor.getLayoutInfo(),o=this._lineNumbers.slice(i);for(const[a,l]of o.entries()){const d=this._renderChildNode(a+i,l,t,n);d&&(this._linesDomNode.appendChild(d.lineDomNode),this._lineNumbersDomNode.appendChild(d.lineNumberDomNode),this._renderedStickyLines.push(d))}t&&(this._setFoldingHoverListeners(),this._useFoldingOpacityTransition(!this._isOnGlyphMargin));const r=this._lineNumbers.length*this._lineHeight+this._lastLineRelativePosition;this._rootDomNode.style.display="block",this._lineNumbersDomNode.style.height=`${r}px`,this._linesDomNodeScrollable.style.height=`${r}px`,this._rootDomNode.style.height=`${r}px`,this._rootDomNode.style.marginLeft="0px",this._minContentWidthInPx=Math.max(...this._renderedStickyLines.map(a=>a.scrollWidth))+n.verticalScrollbarWidth,this._editor.layoutOverlayWidget(this)}_setFoldingHoverListeners(){this._editor.getOption(110)==="mouseover"&&(this._foldingIconStore.add(X(this._lineNumbersDomNode,he.MOUSE_ENTER,()=>{this._isOnGlyphMargin=!0,this._setFoldingIconsVisibility(!0)})),this._foldingIconStore.add(X(this._lineNumbersDomNode,he.MOUSE_LEAVE,()=>{this._isOnGlyphMargin=!1,this._useFoldingOpacityTransition(!0),this._setFoldingIconsVisibility(!1)})))}_renderChildNode(e,t,i,n){const o=this._editor._getViewModel();if(!o)return;const r=o.coordinatesConverter.convertModelPositionToViewPosition(new U(t,1)).lineNumber,a=o.getViewLineRenderingData(r),l=this._editor.getOption(68);let d;try{d=uo.filter(a.inlineDecorations,r,a.minColumn,a.maxColumn)}catch{d=[]}const c=new Mf(!0,!0,a.content,a.continuesWithWrappedLine,a.isBasicASCII,a.containsRTL,0,a.tokens,d,a.tabSize,a.startVisibleColumn,1,1,1,500,"none",!0,!0,null),u=new Rv(2e3),h=uw(c,u);let f;BH?f=BH.createHTML(u.build()):f=u.build();const g=document.createElement("span");g.setAttribute(OT,String(e)),g.setAttribute(WH,""),g.setAttribute("role","listitem"),g.tabIndex=0,g.className="sticky-line-content",g.classList.add(`stickyLine${t}`),g.style.lineHeight=`${this._lineHeight}px`,g.innerHTML=f;const m=document.createElement("span");m.setAttribute(OT,String(e)),m.setAttribute(RTe,""),m.className="sticky-line-number",m.style.lineHeight=`${this._lineHeight}px`;const _=n.contentLeft;m.style.width=`${_}px`;const v=document.createElement("span");l.renderType===1||l.renderType===3&&t%10===0?v.innerText=t.toString():l.renderType===2&&(v.innerText=Math.abs(t-this._editor.getPosition().lineNumber).toString()),v.className="sticky-line-number-inner",v.style.lineHeight=`${this._lineHeight}px`,v.style.width=`${n.lineNumbersWidth}px`,v.style.paddingLeft=`${n.lineNumbersLeft}px`,m.appendChild(v);const b=this._renderFoldingIconForLine(i,t);b&&m.appendChild(b.domNode),this._editor.applyFontInfo(g),this._editor.applyFontInfo(v),m.style.lineHeight=`${this._lineHeight}px`,g.style.lineHeight=`${this._lineHeight}px`,m.style.height=`${this._lineHeight}px`,g.style.height=`${this._lineHeight}px`;const C=new PTe(e,t,g,m,b,h.characterMapping,g.scrollWidth);return this._updateTopAndZIndexOfStickyLine(C)}_updateTopAndZIndexOfStickyLine(e){var t;const i=e.index,n=e.lineDomNode,o=e.lineNumberDomNode,r=i===this._lineNumbers.length-1,a="0",l="1";n.style.zIndex=r?a:l,o.style.zIndex=r?a:l;const d=`${i*this._lineHeight+this._lastLineRelativePosition+(!((t=e.foldingIcon)===null||t===void 0)&&t.isCollapsed?1:0)}px`,c=`${i*this._lineHeight}px`;return n.style.top=r?d:c,o.style.top=r?d:c,e}_renderFoldingIconForLine(e,t){const i=this._editor.getOption(110);if(!e||i==="never")return;const n=e.regions,o=n.findRange(t),r=n.getStartLineNumber(o);if(!(t===r))return;const l=n.isCollapsed(o),d=new OTe(l,r,n.getEndLineNumber(o),this._lineHeight);return d.setVisible(this._isOnGlyphMargin?!0:l||i==="always"),d.domNode.setAttribute(HH,""),d}getId(){return"editor.contrib.stickyScrollWidget"}getDomNode(){return this._rootDomNode}getPosition(){return{preference:null}}getMinContentWidthInPx(){return this._minContentWidthInPx}focusLineWithIndex(e){0<=e&&e<this._renderedStickyLines.length&&this._renderedStickyLines[e].lineDomNode.focus()}getEditorPositionFromNode(e){if(!e||e.children.length>0)return null;const t=this._getRenderedStickyLineFromChildDomNode(e);if(!t)return null;const i=h4(t.characterMapping,e,0);return new U(t.lineNumber,i)}getLineNumberFromChildDomNode(e){var t,i;return(i=(t=this._getRenderedStickyLineFromChildDomNode(e))===null||t===void 0?void 0:t.lineNumber)!==null&&i!==void 0?i:null}_getRenderedStickyLineFromChildDomNode(e){const t=this.getLineIndexFromChildDomNode(e);return t===null||t<0||t>=this._renderedStickyLines.length?null:this._renderedStickyLines[t]}getLineIndexFromChildDomNode(e){const t=this._getAttributeValue(e,OT);return t?parseInt(t,10):null}isInStickyLine(e){return this._getAttributeValue(e,WH)!==void 0}isInFoldingIconDomNode(e){return this._getAttributeValue(e,HH)!==void 0}_getAttributeValue(e,t){for(;e&&e!==this._rootDomNode;){const i=e.getAttribute(t);if(i!==null)return i;e=e.parentElement}}}class PTe{constructor(e,t,i,n,o,r,a){this.index=e,this.lineNumber=t,this.lineDomNode=i,this.lineNumberDomNode=n,this.foldingIcon=o,this.characterMapping=r,this.scrollWidth=a}}class OTe{constructor(e,t,i,n){this.isCollapsed=e,this.foldingStartLine=t,this.foldingEndLine=i,this.dimension=n,this.domNode=document.createElement("div"),this.domNode.style.width=`${n}px`,this.domNode.style.height=`${n}px`,this.domNode.className=Xe.asClassName(e?VI:HI)}setVisible(e){this.domNode.style.cursor=e?"pointer":"default",this.domNode.style.opacity=e?"1":"0"}}class tC{constructor(e,t){this.startLineNumber=e,this.endLineNumber=t}}class AD{constructor(e,t,i){this.range=e,this.children=t,this.parent=i}}class wY{constructor(e,t,i,n){this.uri=e,this.version=t,this.element=i,this.outlineProviderId=n}}var aE=function(s,e,t,i){var n=arguments.length,o=n<3?e:i===null?i=Object.getOwnPropertyDescriptor(e,t):i,r;if(typeof Reflect=="object"&&typeof Reflect.decorate=="function")o=Reflect.decorate(s,e,t,i);else for(var a=s.length-1;a>=0;a--)(r=s[a])&&(o=(n<3?r(o):n>3?r(e,t,o):r(e,t))||o);return n>3&&o&&Object.defineProperty(e,t,o),o},U1=function(s,e){return function(t,i){e(t,i,s)}},iC;(function(s){s.OUTLINE_MODEL="outlineModel",s.FOLDING_PROVIDER_MODEL="foldingProviderModel",s.INDENTATION_MODEL="indentationModel"})(iC||(iC={}));var Fh;(function(s){s[s.VALID=0]="VALID",s[s.INVALID=1]="INVALID",s[s.CANCELED=2]="CANCELED"})(Fh||(Fh={}));let fO=class extends j{constructor(e,t,i,n){switch(super(),this._editor=e,this._modelProviders=[],this._modelPromise=null,this._updateScheduler=this._register(new qa(300)),this._updateOperation=this._register(new se),this._editor.getOption(115).defaultModel){case iC.OUTLINE_MODEL:this._modelProviders.push(new gO(this._editor,n));case iC.FOLDING_PROVIDER_MODEL:this._modelProviders.push(new mO(this._editor,t,n));case iC.INDENTATION_MODEL:this._modelProviders.push(new pO(this._editor,i));break}}dispose(){this._modelProviders.forEach(e=>e.dispose()),this._updateOperation.clear(),this._cancelModelPromise(),super.dispose()}_cancelModelPromise(){this._modelPromise&&(this._modelPromise.cancel(),this._modelPromise=null)}async update(e){return this._updateOperation.clear(),this._updateOperation.add({dispose:()=>{this._cancelModelPromise(),this._updateScheduler.cancel()}}),this._cancelModelPromise(),await this._updateScheduler.trigger(async()=>{for(const t of this._modelProviders){const{statusPromise:i,modelPromise:n}=t.computeStickyModel(e);this._modelPromise=n;const o=await i;if(this._modelPromise!==n)return null;switch(o){case Fh.CANCELED:return this._updateOperation.clear(),null;case Fh.VALID:return t.stickyModel}}return null}).catch(t=>(ct(t),null))}};fO=aE([U1(2,$e),U1(3,Te)],fO);class SY extends j{constructor(e){super(),this._editor=e,this._stickyModel=null}get stickyModel(){return this._stickyModel}_invalid(){return this._stickyModel=null,Fh.INVALID}computeStickyModel(e){if(e.isCancellationRequested||!this.isProviderValid())return{statusPromise:this._invalid(),modelPromise:null};const t=Gn(i=>this.createModelFromProvider(i));return{statusPromise:t.then(i=>this.isModelValid(i)?e.isCancellationRequested?Fh.CANCELED:(this._stickyModel=this.createStickyModel(e,i),Fh.VALID):this._invalid()).then(void 0,i=>(ct(i),Fh.CANCELED)),modelPromise:t}}isModelValid(e){return!0}isProviderValid(){return!0}}let gO=class extends SY{constructor(e,t){super(e),this._languageFeaturesService=t}createModelFromProvider(e){return Bc.create(this._languageFeaturesService.documentSymbolProvider,this._editor.getModel(),e)}createStickyModel(e,t){var i;const{stickyOutlineElement:n,providerID:o}=this._stickyModelFromOutlineModel(t,(i=this._stickyModel)===null||i===void 0?void 0:i.outlineProviderId),r=this._editor.getModel();return new wY(r.uri,r.getVersionId(),n,o)}isModelValid(e){return e&&e.children.size>0}_stickyModelFromOutlineModel(e,t){let i;if(kt.first(e.children.values())instanceof zZ){const a=kt.find(e.children.values(),l=>l.id===t);if(a)i=a.children;else{let l="",d=-1,c;for(const[u,h]of e.children.entries()){const f=this._findSumOfRangesOfGroup(h);f>d&&(c=h,d=f,l=h.id)}t=l,i=c.children}}else i=e.children;const n=[],o=Array.from(i.values()).sort((a,l)=>{const d=new tC(a.symbol.range.startLineNumber,a.symbol.range.endLineNumber),c=new tC(l.symbol.range.startLineNumber,l.symbol.range.endLineNumber);return this._comparator(d,c)});for(const a of o)n.push(this._stickyModelFromOutlineElement(a,a.symbol.selectionRange.startLineNumber));return{stickyOutlineElement:new AD(void 0,n,void 0),providerID:t}}_stickyModelFromOutlineElement(e,t){const i=[];for(const o of e.children.values())if(o.symbol.selectionRange.startLineNumber!==o.symbol.range.endLineNumber)if(o.symbol.selectionRange.startLineNumber!==t)i.push(this._stickyModelFromOutlineElement(o,o.symbol.selectionRange.startLineNumber));else for(const r of o.children.values())i.push(this._stickyModelFromOutlineElement(r,o.symbol.selectionRange.startLineNumber));i.sort((o,r)=>this._comparator(o.range,r.range));const n=new tC(e.symbol.selectionRange.startLineNumber,e.symbol.range.endLineNumber);return new AD(n,i,void 0)}_comparator(e,t){return e.startLineNumber!==t.startLineNumber?e.startLineNumber-t.startLineNumber:t.endLineNumber-e.endLineNumber}_findSumOfRangesOfGroup(e){let t=0;for(const i of e.children.values())t+=this._findSumOfRangesOfGroup(i);return e instanceof MP?t+e.symbol.range.endLineNumber-e.symbol.selectionRange.startLineNumber:t}};gO=aE([U1(1,Te)],gO);class yY extends SY{constructor(e){super(e),this._foldingLimitReporter=new WZ(e)}createStickyModel(e,t){const i=this._fromFoldingRegions(t),n=this._editor.getModel();return new wY(n.uri,n.getVersionId(),i,void 0)}isModelValid(e){return e!==null}_fromFoldingRegions(e){const t=e.length,i=[],n=new AD(void 0,[],void 0);for(let o=0;o<t;o++){const r=e.getParentIndex(o);let a;r!==-1?a=i[r]:a=n;const l=new AD(new tC(e.getStartLineNumber(o),e.getEndLineNumber(o)+1),[],a);a.children.push(l),i.push(l)}return n}}let pO=class extends yY{constructor(e,t){super(e),this._languageConfigurationService=t,this.provider=this._register(new h3(e.getModel(),this._languageConfigurationService,this._foldingLimitReporter))}async createModelFromProvider(e){return this.provider.compute(e)}};pO=aE([U1(1,Si)],pO);let mO=class extends yY{constructor(e,t,i){super(e),this._languageFeaturesService=i;const n=Mu.getFoldingRangeProviders(this._languageFeaturesService,e.getModel());n.length>0&&(this.provider=this._register(new g3(e.getModel(),n,t,this._foldingLimitReporter,void 0)))}isProviderValid(){return this.provider!==void 0}async createModelFromProvider(e){var t,i;return(i=(t=this.provider)===null||t===void 0?void 0:t.compute(e))!==null&&i!==void 0?i:null}};mO=aE([U1(2,Te)],mO);var FTe=function(s,e,t,i){var n=arguments.length,o=n<3?e:i===null?i=Object.getOwnPropertyDescriptor(e,t):i,r;if(typeof Reflect=="object"&&typeof Reflect.decorate=="function")o=Reflect.decorate(s,e,t,i);else for(var a=s.length-1;a>=0;a--)(r=s[a])&&(o=(n<3?r(o):n>3?r(e,t,o):r(e,t))||o);return n>3&&o&&Object.defineProperty(e,t,o),o},VH=function(s,e){return function(t,i){e(t,i,s)}};class BTe{constructor(e,t,i){this.startLineNumber=e,this.endLineNumber=t,this.nestingDepth=i}}let _O=class extends j{constructor(e,t,i){super(),this._languageFeaturesService=t,this._languageConfigurationService=i,this._onDidChangeStickyScroll=this._register(new z),this.onDidChangeStickyScroll=this._onDidChangeStickyScroll.event,this._model=null,this._cts=null,this._stickyModelProvider=null,this._editor=e,this._sessionStore=this._register(new se),this._updateSoon=this._register(new oi(()=>this.update(),50)),this._register(this._editor.onDidChangeConfiguration(n=>{n.hasChanged(115)&&this.readConfiguration()})),this.readConfiguration()}readConfiguration(){this._sessionStore.clear(),this._editor.getOption(115).enabled&&(this._sessionStore.add(this._editor.onDidChangeModel(()=>{this._model=null,this.updateStickyModelProvider(),this._onDidChangeStickyScroll.fire(),this.update()})),this._sessionStore.add(this._editor.onDidChangeHiddenAreas(()=>this.update())),this._sessionStore.add(this._editor.onDidChangeModelContent(()=>this._updateSoon.schedule())),this._sessionStore.add(this._languageFeaturesService.documentSymbolProvider.onDidChange(()=>this.update())),this._sessionStore.add(ze(()=>{var t;(t=this._stickyModelProvider)===null||t===void 0||t.dispose(),this._stickyModelProvider=null})),this.updateStickyModelProvider(),this.update())}getVersionId(){var e;return(e=this._model)===null||e===void 0?void 0:e.version}updateStickyModelProvider(){var e;(e=this._stickyModelProvider)===null||e===void 0||e.dispose(),this._stickyModelProvider=null;const t=this._editor;t.hasModel()&&(this._stickyModelProvider=new fO(t,()=>this._updateSoon.schedule(),this._languageConfigurationService,this._languageFeaturesService))}async update(){var e;(e=this._cts)===null||e===void 0||e.dispose(!0),this._cts=new en,await this.updateStickyModel(this._cts.token),this._onDidChangeStickyScroll.fire()}async updateStickyModel(e){if(!this._editor.hasModel()||!this._stickyModelProvider||this._editor.getModel().isTooLargeForTokenization()){this._model=null;return}const t=await this._stickyModelProvider.update(e);e.isCancellationRequested||(this._model=t)}updateIndex(e){return e===-1?e=0:e<0&&(e=-e-2),e}getCandidateStickyLinesIntersectingFromStickyModel(e,t,i,n,o){if(t.children.length===0)return;let r=o;const a=[];for(let c=0;c<t.children.length;c++){const u=t.children[c];u.range&&a.push(u.range.startLineNumber)}const l=this.updateIndex(uC(a,e.startLineNumber,(c,u)=>c-u)),d=this.updateIndex(uC(a,e.startLineNumber+n,(c,u)=>c-u));for(let c=l;c<=d;c++){const u=t.children[c];if(!u)return;if(u.range){const h=u.range.startLineNumber,f=u.range.endLineNumber;e.startLineNumber<=f+1&&h-1<=e.endLineNumber&&h!==r&&(r=h,i.push(new BTe(h,f-1,n+1)),this.getCandidateStickyLinesIntersectingFromStickyModel(e,u,i,n+1,h))}else this.getCandidateStickyLinesIntersectingFromStickyModel(e,u,i,n,o)}}getCandidateStickyLinesIntersecting(e){var t,i;if(!(!((t=this._model)===null||t===void 0)&&t.element))return[];let n=[];this.getCandidateStickyLinesIntersectingFromStickyModel(e,this._model.element,n,0,-1);const o=(i=this._editor._getViewModel())===null||i===void 0?void 0:i.getHiddenAreas();if(o)for(const r of o)n=n.filter(a=>!(a.startLineNumber>=r.startLineNumber&&a.endLineNumber<=r.endLineNumber+1));return n}};_O=FTe([VH(1,Te),VH(2,Si)],_O);var WTe=function(s,e,t,i){var n=arguments.length,o=n<3?e:i===null?i=Object.getOwnPropertyDescriptor(e,t):i,r;if(typeof Reflect=="object"&&typeof Reflect.decorate=="function")o=Reflect.decorate(s,e,t,i);else for(var a=s.length-1;a>=0;a--)(r=s[a])&&(o=(n<3?r(o):n>3?r(e,t,o):r(e,t))||o);return n>3&&o&&Object.defineProperty(e,t,o),o},_m=function(s,e){return function(t,i){e(t,i,s)}},vO;let lc=vO=class extends j{constructor(e,t,i,n,o,r,a){super(),this._editor=e,this._contextMenuService=t,this._languageFeaturesService=i,this._instaService=n,this._contextKeyService=a,this._sessionStore=new se,this._foldingModel=null,this._maxStickyLines=Number.MAX_SAFE_INTEGER,this._candidateDefinitionsLength=-1,this._focusedStickyElementIndex=-1,this._enabled=!1,this._focused=!1,this._positionRevealed=!1,this._onMouseDown=!1,this._endLineNumbers=[],this._showEndForLine=null,this._stickyScrollWidget=new ATe(this._editor),this._stickyLineCandidateProvider=new _O(this._editor,i,o),this._register(this._stickyScrollWidget),this._register(this._stickyLineCandidateProvider),this._widgetState=new FH([],[],0),this._onDidResize(),this._readConfiguration();const l=this._stickyScrollWidget.getDomNode();this._register(this._editor.onDidChangeConfiguration(c=>{this._readConfigurationChange(c)})),this._register(X(l,he.CONTEXT_MENU,async c=>{this._onContextMenu(qe(l),c)})),this._stickyScrollFocusedContextKey=N.stickyScrollFocused.bindTo(this._contextKeyService),this._stickyScrollVisibleContextKey=N.stickyScrollVisible.bindTo(this._contextKeyService);const d=this._register(Za(l));this._register(d.onDidBlur(c=>{this._positionRevealed===!1&&l.clientHeight===0?(this._focusedStickyElementIndex=-1,this.focus()):this._disposeFocusStickyScrollStore()})),this._register(d.onDidFocus(c=>{this.focus()})),this._registerMouseListeners(),this._register(X(l,he.MOUSE_DOWN,c=>{this._onMouseDown=!0}))}static get(e){return e.getContribution(vO.ID)}_disposeFocusStickyScrollStore(){var e;this._stickyScrollFocusedContextKey.set(!1),(e=this._focusDisposableStore)===null||e===void 0||e.dispose(),this._focused=!1,this._positionRevealed=!1,this._onMouseDown=!1}focus(){if(this._onMouseDown){this._onMouseDown=!1,this._editor.focus();return}this._stickyScrollFocusedContextKey.get()!==!0&&(this._focused=!0,this._focusDisposableStore=new se,this._stickyScrollFocusedContextKey.set(!0),this._focusedStickyElementIndex=this._stickyScrollWidget.lineNumbers.length-1,this._stickyScrollWidget.focusLineWithIndex(this._focusedStickyElementIndex))}focusNext(){this._focusedStickyElementIndex<this._stickyScrollWidget.lineNumberCount-1&&this._focusNav(!0)}focusPrevious(){this._focusedStickyElementIndex>0&&this._focusNav(!1)}selectEditor(){this._editor.focus()}_focusNav(e){this._focusedStickyElementIndex=e?this._focusedStickyElementIndex+1:this._focusedStickyElementIndex-1,this._stickyScrollWidget.focusLineWithIndex(this._focusedStickyElementIndex)}goToFocused(){const e=this._stickyScrollWidget.lineNumbers;this._disposeFocusStickyScrollStore(),this._revealPosition({lineNumber:e[this._focusedStickyElementIndex],column:1})}_revealPosition(e){this._reveaInEditor(e,()=>this._editor.revealPosition(e))}_revealLineInCenterIfOutsideViewport(e){this._reveaInEditor(e,()=>this._editor.revealLineInCenterIfOutsideViewport(e.lineNumber,0))}_reveaInEditor(e,t){this._focused&&this._disposeFocusStickyScrollStore(),this._positionRevealed=!0,t(),this._editor.setSelection(x.fromPositions(e)),this._editor.focus()}_registerMouseListeners(){const e=this._register(new se),t=this._register(new EI(this._editor,{extractLineNumberFromMouseEvent:o=>{const r=this._stickyScrollWidget.getEditorPositionFromNode(o.target.element);return r?r.lineNumber:0}})),i=o=>{if(!this._editor.hasModel()||o.target.type!==12||o.target.detail!==this._stickyScrollWidget.getId())return null;const r=o.target.element;if(!r||r.innerText!==r.innerHTML)return null;const a=this._stickyScrollWidget.getEditorPositionFromNode(r);return a?{range:new x(a.lineNumber,a.column,a.lineNumber,a.column+r.innerText.length),textElement:r}:null},n=this._stickyScrollWidget.getDomNode();this._register(rn(n,he.CLICK,o=>{if(o.ctrlKey||o.altKey||o.metaKey||!o.leftButton)return;if(o.shiftKey){const d=this._stickyScrollWidget.getLineIndexFromChildDomNode(o.target);if(d===null)return;const c=new U(this._endLineNumbers[d],1);this._revealLineInCenterIfOutsideViewport(c);return}if(this._stickyScrollWidget.isInFoldingIconDomNode(o.target)){const d=this._stickyScrollWidget.getLineNumberFromChildDomNode(o.target);this._toggleFoldingRegionForLine(d);return}if(!this._stickyScrollWidget.isInStickyLine(o.target))return;let l=this._stickyScrollWidget.getEditorPositionFromNode(o.target);if(!l){const d=this._stickyScrollWidget.getLineNumberFromChildDomNode(o.target);if(d===null)return;l=new U(d,1)}this._revealPosition(l)})),this._register(rn(n,he.MOUSE_MOVE,o=>{if(o.shiftKey){const r=this._stickyScrollWidget.getLineIndexFromChildDomNode(o.target);if(r===null||this._showEndForLine!==null&&this._showEndForLine===r)return;this._showEndForLine=r,this._renderStickyScroll();return}this._showEndForLine!==null&&(this._showEndForLine=null,this._renderStickyScroll())})),this._register(X(n,he.MOUSE_LEAVE,o=>{this._showEndForLine!==null&&(this._showEndForLine=null,this._renderStickyScroll())})),this._register(t.onMouseMoveOrRelevantKeyDown(([o,r])=>{const a=i(o);if(!a||!o.hasTriggerModifier||!this._editor.hasModel()){e.clear();return}const{range:l,textElement:d}=a;if(!l.equalsRange(this._stickyRangeProjectedOnEditor))this._stickyRangeProjectedOnEditor=l,e.clear();else if(d.style.textDecoration==="underline")return;const c=new en;e.add(ze(()=>c.dispose(!0)));let u;PI(this._languageFeaturesService.definitionProvider,this._editor.getModel(),new U(l.startLineNumber,l.startColumn+1),c.token).then(h=>{if(!c.token.isCancellationRequested)if(h.length!==0){this._candidateDefinitionsLength=h.length;const f=d;u!==f?(e.clear(),u=f,u.style.textDecoration="underline",e.add(ze(()=>{u.style.textDecoration="none"}))):u||(u=f,u.style.textDecoration="underline",e.add(ze(()=>{u.style.textDecoration="none"})))}else e.clear()})})),this._register(t.onCancel(()=>{e.clear()})),this._register(t.onExecute(async o=>{if(o.target.type!==12||o.target.detail!==this._stickyScrollWidget.getId())return;const r=this._stickyScrollWidget.getEditorPositionFromNode(o.target.element);r&&(!this._editor.hasModel()||!this._stickyRangeProjectedOnEditor||(this._candidateDefinitionsLength>1&&(this._focused&&this._disposeFocusStickyScrollStore(),this._revealPosition({lineNumber:r.lineNumber,column:1})),this._instaService.invokeFunction(vZ,o,this._editor,{uri:this._editor.getModel().uri,range:this._stickyRangeProjectedOnEditor})))}))}_onContextMenu(e,t){const i=new Fa(e,t);this._contextMenuService.showContextMenu({menuId:k.StickyScrollContext,getAnchor:()=>i})}_toggleFoldingRegionForLine(e){if(!this._foldingModel||e===null)return;const t=this._stickyScrollWidget.getRenderedStickyLine(e),i=t==null?void 0:t.foldingIcon;if(!i)return;AZ(this._foldingModel,Number.MAX_VALUE,[e]),i.isCollapsed=!i.isCollapsed;const n=(i.isCollapsed?this._editor.getTopForLineNumber(i.foldingEndLine):this._editor.getTopForLineNumber(i.foldingStartLine))-this._editor.getOption(67)*t.index+1;this._editor.setScrollTop(n),this._renderStickyScroll(e)}_readConfiguration(){const e=this._editor.getOption(115);if(e.enabled===!1){this._editor.removeOverlayWidget(this._stickyScrollWidget),this._sessionStore.clear(),this._enabled=!1;return}else e.enabled&&!this._enabled&&(this._editor.addOverlayWidget(this._stickyScrollWidget),this._sessionStore.add(this._editor.onDidScrollChange(i=>{i.scrollTopChanged&&(this._showEndForLine=null,this._renderStickyScroll())})),this._sessionStore.add(this._editor.onDidLayoutChange(()=>this._onDidResize())),this._sessionStore.add(this._editor.onDidChangeModelTokens(i=>this._onTokensChange(i))),this._sessionStore.add(this._stickyLineCandidateProvider.onDidChangeStickyScroll(()=>{this._showEndForLine=null,this._renderStickyScroll()})),this._enabled=!0);this._editor.getOption(68).renderType===2&&this._sessionStore.add(this._editor.onDidChangeCursorPosition(()=>{this._showEndForLine=null,this._renderStickyScroll(0)}))}_readConfigurationChange(e){(e.hasChanged(115)||e.hasChanged(73)||e.hasChanged(67)||e.hasChanged(110)||e.hasChanged(68))&&this._readConfiguration(),e.hasChanged(68)&&this._renderStickyScroll(0)}_needsUpdate(e){const t=this._stickyScrollWidget.getCurrentLines();for(const i of t)for(const n of e.ranges)if(i>=n.fromLineNumber&&i<=n.toLineNumber)return!0;return!1}_onTokensChange(e){this._needsUpdate(e)&&this._renderStickyScroll(0)}_onDidResize(){const t=this._editor.getLayoutInfo().height/this._editor.getOption(67);this._maxStickyLines=Math.round(t*.25)}async _renderStickyScroll(e){var t,i;const n=this._editor.getModel();if(!n||n.isTooLargeForTokenization()){this._foldingModel=null,this._stickyScrollWidget.setState(void 0,null);return}const o=this._stickyLineCandidateProvider.getVersionId();if(o===void 0||o===n.getVersionId())if(this._foldingModel=(i=await((t=Mu.get(this._editor))===null||t===void 0?void 0:t.getFoldingModel()))!==null&&i!==void 0?i:null,this._widgetState=this.findScrollWidgetState(),this._stickyScrollVisibleContextKey.set(this._widgetState.startLineNumbers.length!==0),!this._focused)this._stickyScrollWidget.setState(this._widgetState,this._foldingModel,e);else if(this._focusedStickyElementIndex===-1)this._stickyScrollWidget.setState(this._widgetState,this._foldingModel,e),this._focusedStickyElementIndex=this._stickyScrollWidget.lineNumberCount-1,this._focusedStickyElementIndex!==-1&&this._stickyScrollWidget.focusLineWithIndex(this._focusedStickyElementIndex);else{const r=this._stickyScrollWidget.lineNumbers[this._focusedStickyElementIndex];this._stickyScrollWidget.setState(this._widgetState,this._foldingModel,e),this._stickyScrollWidget.lineNumberCount===0?this._focusedStickyElementIndex=-1:(this._stickyScrollWidget.lineNumbers.includes(r)||(this._focusedStickyElementIndex=this._stickyScrollWidget.lineNumberCount-1),this._stickyScrollWidget.focusLineWithIndex(this._focusedStickyElementIndex))}}findScrollWidgetState(){const e=this._editor.getOption(67),t=Math.min(this._maxStickyLines,this._editor.getOption(115).maxLineCount),i=this._editor.getScrollTop();let n=0;const o=[],r=[],a=this._editor.getVisibleRanges();if(a.length!==0){const l=new tC(a[0].startLineNumber,a[a.length-1].endLineNumber),d=this._stickyLineCandidateProvider.getCandidateStickyLinesIntersecting(l);for(const c of d){const u=c.startLineNumber,h=c.endLineNumber,f=c.nestingDepth;if(h-u>0){const g=(f-1)*e,m=f*e,_=this._editor.getBottomForLineNumber(u)-i,v=this._editor.getTopForLineNumber(h)-i,b=this._editor.getBottomForLineNumber(h)-i;if(g>v&&g<=b){o.push(u),r.push(h+1),n=b-m;break}else m>_&&m<=b&&(o.push(u),r.push(h+1));if(o.length===t)break}}}return this._endLineNumbers=r,new FH(o,r,n,this._showEndForLine)}dispose(){super.dispose(),this._sessionStore.dispose()}};lc.ID="store.contrib.stickyScrollController";lc=vO=WTe([_m(1,pr),_m(2,Te),_m(3,$e),_m(4,Si),_m(5,ba),_m(6,Je)],lc);class HTe extends vo{constructor(){super({id:"editor.action.toggleStickyScroll",title:{...st("toggleEditorStickyScroll","Toggle Editor Sticky Scroll"),mnemonicTitle:p({},"&&Toggle Editor Sticky Scroll")},metadata:{description:st("toggleEditorStickyScroll.description","Toggle/enable the editor sticky scroll which shows the nested scopes at the top of the viewport")},category:MTe.View,toggled:{condition:ne.equals("config.editor.stickyScroll.enabled",!0),title:p("stickyScroll","Sticky Scroll"),mnemonicTitle:p({},"&&Sticky Scroll")},menu:[{id:k.CommandPalette},{id:k.MenubarAppearanceMenu,group:"4_editor",order:3},{id:k.StickyScrollContext}]})}async run(e){const t=e.get(vt),i=!t.getValue("editor.stickyScroll.enabled");return t.updateValue("editor.stickyScroll.enabled",i)}}const lE=100;class VTe extends Kl{constructor(){super({id:"editor.action.focusStickyScroll",title:{...st("focusStickyScroll","Focus on the editor sticky scroll"),mnemonicTitle:p({},"&&Focus Sticky Scroll")},precondition:ne.and(ne.has("config.editor.stickyScroll.enabled"),N.stickyScrollVisible),menu:[{id:k.CommandPalette}]})}runEditorCommand(e,t){var i;(i=lc.get(t))===null||i===void 0||i.focus()}}class zTe extends Kl{constructor(){super({id:"editor.action.selectNextStickyScrollLine",title:st("selectNextStickyScrollLine.title","Select the next editor sticky scroll line"),precondition:N.stickyScrollFocused.isEqualTo(!0),keybinding:{weight:lE,primary:18}})}runEditorCommand(e,t){var i;(i=lc.get(t))===null||i===void 0||i.focusNext()}}class UTe extends Kl{constructor(){super({id:"editor.action.selectPreviousStickyScrollLine",title:st("selectPreviousStickyScrollLine.title","Select the previous sticky scroll line"),precondition:N.stickyScrollFocused.isEqualTo(!0),keybinding:{weight:lE,primary:16}})}runEditorCommand(e,t){var i;(i=lc.get(t))===null||i===void 0||i.focusPrevious()}}class $Te extends Kl{constructor(){super({id:"editor.action.goToFocusedStickyScrollLine",title:st("goToFocusedStickyScrollLine.title","Go to the focused sticky scroll line"),precondition:N.stickyScrollFocused.isEqualTo(!0),keybinding:{weight:lE,primary:3}})}runEditorCommand(e,t){var i;(i=lc.get(t))===null||i===void 0||i.goToFocused()}}class jTe extends Kl{constructor(){super({id:"editor.action.selectEditor",title:st("selectEditor.title","Select Editor"),precondition:N.stickyScrollFocused.isEqualTo(!0),keybinding:{weight:lE,primary:9}})}runEditorCommand(e,t){var i;(i=lc.get(t))===null||i===void 0||i.selectEditor()}}Ft(lc.ID,lc,1);_i(HTe);_i(VTe);_i(UTe);_i(zTe);_i($Te);_i(jTe);var LY=function(s,e,t,i){var n=arguments.length,o=n<3?e:i===null?i=Object.getOwnPropertyDescriptor(e,t):i,r;if(typeof Reflect=="object"&&typeof Reflect.decorate=="function")o=Reflect.decorate(s,e,t,i);else for(var a=s.length-1;a>=0;a--)(r=s[a])&&(o=(n<3?r(o):n>3?r(e,t,o):r(e,t))||o);return n>3&&o&&Object.defineProperty(e,t,o),o},h0=function(s,e){return function(t,i){e(t,i,s)}};class KTe{constructor(e,t,i,n,o,r){this.range=e,this.insertText=t,this.filterText=i,this.additionalTextEdits=n,this.command=o,this.completion=r}}let bO=class extends cie{constructor(e,t,i,n,o,r){super(o.disposable),this.model=e,this.line=t,this.word=i,this.completionModel=n,this._suggestMemoryService=r}canBeReused(e,t,i){return this.model===e&&this.line===t&&this.word.word.length>0&&this.word.startColumn===i.startColumn&&this.word.endColumn<i.endColumn&&this.completionModel.getIncompleteProvider().size===0}get items(){var e;const t=[],{items:i}=this.completionModel,n=this._suggestMemoryService.select(this.model,{lineNumber:this.line,column:this.word.endColumn+this.completionModel.lineContext.characterCountDelta},i),o=kt.slice(i,n),r=kt.slice(i,0,n);let a=5;for(const l of kt.concat(o,r)){if(l.score===Nl.Default)continue;const d=new x(l.editStart.lineNumber,l.editStart.column,l.editInsertEnd.lineNumber,l.editInsertEnd.column+this.completionModel.lineContext.characterCountDelta),c=l.completion.insertTextRules&&l.completion.insertTextRules&4?{snippet:l.completion.insertText}:l.completion.insertText;t.push(new KTe(d,c,(e=l.filterTextLow)!==null&&e!==void 0?e:l.labelLow,l.completion.additionalTextEdits,l.completion.command,l)),a-->=0&&l.resolve(yt.None)}return t}};bO=LY([h0(5,jI)],bO);let CO=class extends j{constructor(e,t,i,n){super(),this._languageFeatureService=e,this._clipboardService=t,this._suggestMemoryService=i,this._editorService=n,this._store.add(e.inlineCompletionsProvider.register("*",this))}async provideInlineCompletions(e,t,i,n){var o;if(i.selectedSuggestionInfo)return;let r;for(const g of this._editorService.listCodeEditors())if(g.getModel()===e){r=g;break}if(!r)return;const a=r.getOption(89);if(Jm.isAllOff(a))return;e.tokenization.tokenizeIfCheap(t.lineNumber);const l=e.tokenization.getLineTokens(t.lineNumber),d=l.getStandardTokenType(l.findTokenIndexAtOffset(Math.max(t.column-1-1,0)));if(Jm.valueFor(a,d)!=="inline")return;let c=e.getWordAtPosition(t),u;if(c!=null&&c.word||(u=this._getTriggerCharacterInfo(e,t)),!(c!=null&&c.word)&&!u||(c||(c=e.getWordUntilPosition(t)),c.endColumn!==t.column))return;let h;const f=e.getValueInRange(new x(t.lineNumber,1,t.lineNumber,t.column));if(!u&&(!((o=this._lastResult)===null||o===void 0)&&o.canBeReused(e,t.lineNumber,c))){const g=new DH(f,t.column-this._lastResult.word.endColumn);this._lastResult.completionModel.lineContext=g,this._lastResult.acquire(),h=this._lastResult}else{const g=await p3(this._languageFeatureService.completionProvider,e,t,new T1(void 0,ND.createSuggestFilter(r).itemKind,u==null?void 0:u.providers),u&&{triggerKind:1,triggerCharacter:u.ch},n);let m;g.needsClipboard&&(m=await this._clipboardService.readText());const _=new vh(g.items,t.column,new DH(f,0),ml.None,r.getOption(118),r.getOption(112),{boostFullMatch:!1,firstMatchCanBeWeak:!1},m);h=new bO(e,t.lineNumber,c,_,g,this._suggestMemoryService)}return this._lastResult=h,h}handleItemDidShow(e,t){t.completion.resolve(yt.None)}freeInlineCompletions(e){e.release()}_getTriggerCharacterInfo(e,t){var i;const n=e.getValueInRange(x.fromPositions({lineNumber:t.lineNumber,column:t.column-1},t)),o=new Set;for(const r of this._languageFeatureService.completionProvider.all(e))!((i=r.triggerCharacters)===null||i===void 0)&&i.includes(n)&&o.add(r);if(o.size!==0)return{providers:o,ch:n}}};CO=LY([h0(0,Te),h0(1,Hu),h0(2,jI),h0(3,Ot)],CO);Rw(CO);class qTe extends De{constructor(){super({id:"editor.action.forceRetokenize",label:p("forceRetokenize","Developer: Force Retokenize"),alias:"Developer: Force Retokenize",precondition:void 0})}run(e,t){if(!t.hasModel())return;const i=t.getModel();i.tokenization.resetTokenization();const n=new xs;i.tokenization.forceTokenization(i.getLineCount()),n.stop(),console.log(`tokenization took ${n.elapsed()}`)}}ue(qTe);class dE extends vo{constructor(){super({id:dE.ID,title:st({},"Toggle Tab Key Moves Focus"),precondition:void 0,keybinding:{primary:2091,mac:{primary:1323},weight:100},metadata:{description:st("tabMovesFocusDescriptions","Determines whether the tab key moves focus around the workbench or inserts the tab character in the current editor. This is also called tab trapping, tab navigation, or tab focus mode.")},f1:!0})}run(){const t=!j_.getTabFocusMode();j_.setTabFocusMode(t),Wo(t?p("toggle.tabMovesFocus.on","Pressing Tab will now move focus to the next focusable element"):p("toggle.tabMovesFocus.off","Pressing Tab will now insert the tab character"))}}dE.ID="editor.action.toggleTabFocusMode";_i(dE);var GTe=function(s,e,t,i){var n=arguments.length,o=n<3?e:i===null?i=Object.getOwnPropertyDescriptor(e,t):i,r;if(typeof Reflect=="object"&&typeof Reflect.decorate=="function")o=Reflect.decorate(s,e,t,i);else for(var a=s.length-1;a>=0;a--)(r=s[a])&&(o=(n<3?r(o):n>3?r(e,t,o):r(e,t))||o);return n>3&&o&&Object.defineProperty(e,t,o),o},zH=function(s,e){return function(t,i){e(t,i,s)}};let wO=class extends j{get enabled(){return this._enabled}set enabled(e){e?(this.el.setAttribute("aria-disabled","false"),this.el.tabIndex=0,this.el.style.pointerEvents="auto",this.el.style.opacity="1",this.el.style.cursor="pointer",this._enabled=!1):(this.el.setAttribute("aria-disabled","true"),this.el.tabIndex=-1,this.el.style.pointerEvents="none",this.el.style.opacity="0.4",this.el.style.cursor="default",this._enabled=!0),this._enabled=e}constructor(e,t,i={},n,o){var r,a;super(),this._link=t,this._hoverService=n,this._enabled=!0,this.el=re(e,fe("a.monaco-link",{tabIndex:(r=t.tabIndex)!==null&&r!==void 0?r:0,href:t.href},t.label)),this.hoverDelegate=(a=i.hoverDelegate)!==null&&a!==void 0?a:Co("mouse"),this.setTooltip(t.title),this.el.setAttribute("role","button");const l=this._register(new xt(this.el,"click")),d=this._register(new xt(this.el,"keypress")),c=ve.chain(d.event,f=>f.map(g=>new mi(g)).filter(g=>g.keyCode===3)),u=this._register(new xt(this.el,wi.Tap)).event;this._register(bi.addTarget(this.el));const h=ve.any(l.event,c,u);this._register(h(f=>{this.enabled&&(mt.stop(f,!0),i!=null&&i.opener?i.opener(this._link.href):o.open(this._link.href,{allowCommands:!0}))})),this.enabled=!0}setTooltip(e){this.hoverDelegate.showNativeHover?this.el.title=e??"":!this.hover&&e?this.hover=this._register(this._hoverService.setupUpdatableHover(this.hoverDelegate,this.el,e)):this.hover&&this.hover.update(e)}};wO=GTe([zH(3,fc),zH(4,mr)],wO);var xY=function(s,e,t,i){var n=arguments.length,o=n<3?e:i===null?i=Object.getOwnPropertyDescriptor(e,t):i,r;if(typeof Reflect=="object"&&typeof Reflect.decorate=="function")o=Reflect.decorate(s,e,t,i);else for(var a=s.length-1;a>=0;a--)(r=s[a])&&(o=(n<3?r(o):n>3?r(e,t,o):r(e,t))||o);return n>3&&o&&Object.defineProperty(e,t,o),o},DY=function(s,e){return function(t,i){e(t,i,s)}};const ZTe=26;let SO=class extends j{constructor(e,t){super(),this._editor=e,this.instantiationService=t,this.banner=this._register(this.instantiationService.createInstance(yO))}hide(){this._editor.setBanner(null,0),this.banner.clear()}show(e){this.banner.show({...e,onClose:()=>{var t;this.hide(),(t=e.onClose)===null||t===void 0||t.call(e)}}),this._editor.setBanner(this.banner.element,ZTe)}};SO=xY([DY(1,$e)],SO);let yO=class extends j{constructor(e){super(),this.instantiationService=e,this.markdownRenderer=this.instantiationService.createInstance(tc,{}),this.element=fe("div.editor-banner"),this.element.tabIndex=0}getAriaLabel(e){if(e.ariaLabel)return e.ariaLabel;if(typeof e.message=="string")return e.message}getBannerMessage(e){if(typeof e=="string"){const t=fe("span");return t.innerText=e,t}return this.markdownRenderer.render(e).element}clear(){cs(this.element)}show(e){cs(this.element);const t=this.getAriaLabel(e);t&&this.element.setAttribute("aria-label",t);const i=re(this.element,fe("div.icon-container"));i.setAttribute("aria-hidden","true"),e.icon&&i.appendChild(fe(`div${Xe.asCSSSelector(e.icon)}`));const n=re(this.element,fe("div.message-container"));if(n.setAttribute("aria-hidden","true"),n.appendChild(this.getBannerMessage(e.message)),this.messageActionsContainer=re(this.element,fe("div.message-actions-container")),e.actions)for(const r of e.actions)this._register(this.instantiationService.createInstance(wO,this.messageActionsContainer,{...r,tabIndex:-1},{}));const o=re(this.element,fe("div.action-container"));this.actionBar=this._register(new _a(o)),this.actionBar.push(this._register(new sr("banner.close","Close Banner",Xe.asClassName(pq),!0,()=>{typeof e.onClose=="function"&&e.onClose()})),{icon:!0,label:!1}),this.actionBar.setFocusable(!1)}};yO=xY([DY(0,$e)],yO);var E3=function(s,e,t,i){var n=arguments.length,o=n<3?e:i===null?i=Object.getOwnPropertyDescriptor(e,t):i,r;if(typeof Reflect=="object"&&typeof Reflect.decorate=="function")o=Reflect.decorate(s,e,t,i);else for(var a=s.length-1;a>=0;a--)(r=s[a])&&(o=(n<3?r(o):n>3?r(e,t,o):r(e,t))||o);return n>3&&o&&Object.defineProperty(e,t,o),o},N_=function(s,e){return function(t,i){e(t,i,s)}};const YTe=tn("extensions-warning-message",me.warning,p("warningIcon","Icon shown with a warning message in the extensions editor."));let xv=class extends j{constructor(e,t,i,n){super(),this._editor=e,this._editorWorkerService=t,this._workspaceTrustService=i,this._highlighter=null,this._bannerClosed=!1,this._updateState=o=>{if(o&&o.hasMore){if(this._bannerClosed)return;const r=Math.max(o.ambiguousCharacterCount,o.nonBasicAsciiCharacterCount,o.invisibleCharacterCount);let a;if(o.nonBasicAsciiCharacterCount>=r)a={message:p("unicodeHighlighting.thisDocumentHasManyNonBasicAsciiUnicodeCharacters","This document contains many non-basic ASCII unicode characters"),command:new Qv};else if(o.ambiguousCharacterCount>=r)a={message:p("unicodeHighlighting.thisDocumentHasManyAmbiguousUnicodeCharacters","This document contains many ambiguous unicode characters"),command:new Hf};else if(o.invisibleCharacterCount>=r)a={message:p("unicodeHighlighting.thisDocumentHasManyInvisibleUnicodeCharacters","This document contains many invisible unicode characters"),command:new Xv};else throw new Error("Unreachable");this._bannerController.show({id:"unicodeHighlightBanner",message:a.message,icon:YTe,actions:[{label:a.command.shortLabel,href:`command:${a.command.id}`}],onClose:()=>{this._bannerClosed=!0}})}else this._bannerController.hide()},this._bannerController=this._register(n.createInstance(SO,e)),this._register(this._editor.onDidChangeModel(()=>{this._bannerClosed=!1,this._updateHighlighter()})),this._options=e.getOption(125),this._register(i.onDidChangeTrust(o=>{this._updateHighlighter()})),this._register(e.onDidChangeConfiguration(o=>{o.hasChanged(125)&&(this._options=e.getOption(125),this._updateHighlighter())})),this._updateHighlighter()}dispose(){this._highlighter&&(this._highlighter.dispose(),this._highlighter=null),super.dispose()}_updateHighlighter(){if(this._updateState(null),this._highlighter&&(this._highlighter.dispose(),this._highlighter=null),!this._editor.hasModel())return;const e=XTe(this._workspaceTrustService.isWorkspaceTrusted(),this._options);if([e.nonBasicASCII,e.ambiguousCharacters,e.invisibleCharacters].every(i=>i===!1))return;const t={nonBasicASCII:e.nonBasicASCII,ambiguousCharacters:e.ambiguousCharacters,invisibleCharacters:e.invisibleCharacters,includeComments:e.includeComments,includeStrings:e.includeStrings,allowedCodePoints:Object.keys(e.allowedCharacters).map(i=>i.codePointAt(0)),allowedLocales:Object.keys(e.allowedLocales).map(i=>i==="_os"?new Intl.NumberFormat().resolvedOptions().locale:i==="_vscode"?dte:i)};this._editorWorkerService.canComputeUnicodeHighlights(this._editor.getModel().uri)?this._highlighter=new LO(this._editor,t,this._updateState,this._editorWorkerService):this._highlighter=new QTe(this._editor,t,this._updateState)}getDecorationInfo(e){return this._highlighter?this._highlighter.getDecorationInfo(e):null}};xv.ID="editor.contrib.unicodeHighlighter";xv=E3([N_(1,wa),N_(2,mK),N_(3,$e)],xv);function XTe(s,e){return{nonBasicASCII:e.nonBasicASCII===br?!s:e.nonBasicASCII,ambiguousCharacters:e.ambiguousCharacters,invisibleCharacters:e.invisibleCharacters,includeComments:e.includeComments===br?!s:e.includeComments,includeStrings:e.includeStrings===br?!s:e.includeStrings,allowedCharacters:e.allowedCharacters,allowedLocales:e.allowedLocales}}let LO=class extends j{constructor(e,t,i,n){super(),this._editor=e,this._options=t,this._updateState=i,this._editorWorkerService=n,this._model=this._editor.getModel(),this._decorations=this._editor.createDecorationsCollection(),this._updateSoon=this._register(new oi(()=>this._update(),250)),this._register(this._editor.onDidChangeModelContent(()=>{this._updateSoon.schedule()})),this._updateSoon.schedule()}dispose(){this._decorations.clear(),super.dispose()}_update(){if(this._model.isDisposed())return;if(!this._model.mightContainNonBasicASCII()){this._decorations.clear();return}const e=this._model.getVersionId();this._editorWorkerService.computedUnicodeHighlights(this._model.uri,this._options).then(t=>{if(this._model.isDisposed()||this._model.getVersionId()!==e)return;this._updateState(t);const i=[];if(!t.hasMore)for(const n of t.ranges)i.push({range:n,options:PD.instance.getDecorationFromOptions(this._options)});this._decorations.set(i)})}getDecorationInfo(e){if(!this._decorations.has(e))return null;const t=this._editor.getModel();if(!R4(t,e))return null;const i=t.getValueInRange(e.range);return{reason:IY(i,this._options),inComment:A4(t,e),inString:P4(t,e)}}};LO=E3([N_(3,wa)],LO);class QTe extends j{constructor(e,t,i){super(),this._editor=e,this._options=t,this._updateState=i,this._model=this._editor.getModel(),this._decorations=this._editor.createDecorationsCollection(),this._updateSoon=this._register(new oi(()=>this._update(),250)),this._register(this._editor.onDidLayoutChange(()=>{this._updateSoon.schedule()})),this._register(this._editor.onDidScrollChange(()=>{this._updateSoon.schedule()})),this._register(this._editor.onDidChangeHiddenAreas(()=>{this._updateSoon.schedule()})),this._register(this._editor.onDidChangeModelContent(()=>{this._updateSoon.schedule()})),this._updateSoon.schedule()}dispose(){this._decorations.clear(),super.dispose()}_update(){if(this._model.isDisposed())return;if(!this._model.mightContainNonBasicASCII()){this._decorations.clear();return}const e=this._editor.getVisibleRanges(),t=[],i={ranges:[],ambiguousCharacterCount:0,invisibleCharacterCount:0,nonBasicAsciiCharacterCount:0,hasMore:!1};for(const n of e){const o=qF.computeUnicodeHighlights(this._model,this._options,n);for(const r of o.ranges)i.ranges.push(r);i.ambiguousCharacterCount+=i.ambiguousCharacterCount,i.invisibleCharacterCount+=i.invisibleCharacterCount,i.nonBasicAsciiCharacterCount+=i.nonBasicAsciiCharacterCount,i.hasMore=i.hasMore||o.hasMore}if(!i.hasMore)for(const n of i.ranges)t.push({range:n,options:PD.instance.getDecorationFromOptions(this._options)});this._updateState(i),this._decorations.set(t)}getDecorationInfo(e){if(!this._decorations.has(e))return null;const t=this._editor.getModel(),i=t.getValueInRange(e.range);return R4(t,e)?{reason:IY(i,this._options),inComment:A4(t,e),inString:P4(t,e)}:null}}const kY=p("unicodeHighlight.configureUnicodeHighlightOptions","Configure Unicode Highlight Options");let xO=class{constructor(e,t,i){this._editor=e,this._languageService=t,this._openerService=i,this.hoverOrdinal=5}computeSync(e,t){if(!this._editor.hasModel()||e.type!==1)return[];const i=this._editor.getModel(),n=this._editor.getContribution(xv.ID);if(!n)return[];const o=[],r=new Set;let a=300;for(const l of t){const d=n.getDecorationInfo(l);if(!d)continue;const u=i.getValueInRange(l.range).codePointAt(0),h=FT(u);let f;switch(d.reason.kind){case 0:{sw(d.reason.confusableWith)?f=p("unicodeHighlight.characterIsAmbiguousASCII","The character {0} could be confused with the ASCII character {1}, which is more common in source code.",h,FT(d.reason.confusableWith.codePointAt(0))):f=p("unicodeHighlight.characterIsAmbiguous","The character {0} could be confused with the character {1}, which is more common in source code.",h,FT(d.reason.confusableWith.codePointAt(0)));break}case 1:f=p("unicodeHighlight.characterIsInvisible","The character {0} is invisible.",h);break;case 2:f=p("unicodeHighlight.characterIsNonBasicAscii","The character {0} is not a basic ASCII character.",h);break}if(r.has(f))continue;r.add(f);const g={codePoint:u,reason:d.reason,inComment:d.inComment,inString:d.inString},m=p("unicodeHighlight.adjustSettings","Adjust settings"),_=`command:${jw.ID}?${encodeURIComponent(JSON.stringify(g))}`,v=new Ns("",!0).appendMarkdown(f).appendText(" ").appendLink(_,m,kY);o.push(new bl(this,l.range,[v],!1,a++))}return o}renderHoverParts(e,t){return sxe(e,t,this._editor,this._languageService,this._openerService)}};xO=E3([N_(1,Fi),N_(2,mr)],xO);function DO(s){return`U+${s.toString(16).padStart(4,"0")}`}function FT(s){let e=`\`${DO(s)}\``;return Ud.isInvisibleCharacter(s)||(e+=` "${`${JTe(s)}`}"`),e}function JTe(s){return s===96?"`` ` ``":"`"+String.fromCodePoint(s)+"`"}function IY(s,e){return qF.computeUnicodeHighlightReason(s,e)}class PD{constructor(){this.map=new Map}getDecorationFromOptions(e){return this.getDecoration(!e.includeComments,!e.includeStrings)}getDecoration(e,t){const i=`${e}${t}`;let n=this.map.get(i);return n||(n=ut.createDynamic({description:"unicode-highlight",stickiness:1,className:"unicode-highlight",showIfCollapsed:!0,overviewRuler:null,minimap:null,hideInCommentTokens:e,hideInStringTokens:t}),this.map.set(i,n)),n}}PD.instance=new PD;class eMe extends De{constructor(){super({id:Hf.ID,label:p("action.unicodeHighlight.disableHighlightingInComments","Disable highlighting of characters in comments"),alias:"Disable highlighting of characters in comments",precondition:void 0}),this.shortLabel=p("unicodeHighlight.disableHighlightingInComments.shortLabel","Disable Highlight In Comments")}async run(e,t,i){const n=e==null?void 0:e.get(vt);n&&this.runAction(n)}async runAction(e){await e.updateValue(Io.includeComments,!1,2)}}class tMe extends De{constructor(){super({id:Hf.ID,label:p("action.unicodeHighlight.disableHighlightingInStrings","Disable highlighting of characters in strings"),alias:"Disable highlighting of characters in strings",precondition:void 0}),this.shortLabel=p("unicodeHighlight.disableHighlightingInStrings.shortLabel","Disable Highlight In Strings")}async run(e,t,i){const n=e==null?void 0:e.get(vt);n&&this.runAction(n)}async runAction(e){await e.updateValue(Io.includeStrings,!1,2)}}class Hf extends De{constructor(){super({id:Hf.ID,label:p("action.unicodeHighlight.disableHighlightingOfAmbiguousCharacters","Disable highlighting of ambiguous characters"),alias:"Disable highlighting of ambiguous characters",precondition:void 0}),this.shortLabel=p("unicodeHighlight.disableHighlightingOfAmbiguousCharacters.shortLabel","Disable Ambiguous Highlight")}async run(e,t,i){const n=e==null?void 0:e.get(vt);n&&this.runAction(n)}async runAction(e){await e.updateValue(Io.ambiguousCharacters,!1,2)}}Hf.ID="editor.action.unicodeHighlight.disableHighlightingOfAmbiguousCharacters";class Xv extends De{constructor(){super({id:Xv.ID,label:p("action.unicodeHighlight.disableHighlightingOfInvisibleCharacters","Disable highlighting of invisible characters"),alias:"Disable highlighting of invisible characters",precondition:void 0}),this.shortLabel=p("unicodeHighlight.disableHighlightingOfInvisibleCharacters.shortLabel","Disable Invisible Highlight")}async run(e,t,i){const n=e==null?void 0:e.get(vt);n&&this.runAction(n)}async runAction(e){await e.updateValue(Io.invisibleCharacters,!1,2)}}Xv.ID="editor.action.unicodeHighlight.disableHighlightingOfInvisibleCharacters";class Qv extends De{constructor(){super({id:Qv.ID,label:p("action.unicodeHighlight.disableHighlightingOfNonBasicAsciiCharacters","Disable highlighting of non basic ASCII characters"),alias:"Disable highlighting of non basic ASCII characters",precondition:void 0}),this.shortLabel=p("unicodeHighlight.disableHighlightingOfNonBasicAsciiCharacters.shortLabel","Disable Non ASCII Highlight")}async run(e,t,i){const n=e==null?void 0:e.get(vt);n&&this.runAction(n)}async runAction(e){await e.updateValue(Io.nonBasicASCII,!1,2)}}Qv.ID="editor.action.unicodeHighlight.disableHighlightingOfNonBasicAsciiCharacters";class jw extends De{constructor(){super({id:jw.ID,label:p("action.unicodeHighlight.showExcludeOptions","Show Exclude Options"),alias:"Show Exclude Options",precondition:void 0})}async run(e,t,i){const{codePoint:n,reason:o,inString:r,inComment:a}=i,l=String.fromCodePoint(n),d=e.get(Yl),c=e.get(vt);function u(g){return Ud.isInvisibleCharacter(g)?p("unicodeHighlight.excludeInvisibleCharFromBeingHighlighted","Exclude {0} (invisible character) from being highlighted",DO(g)):p("unicodeHighlight.excludeCharFromBeingHighlighted","Exclude {0} from being highlighted",`${DO(g)} "${l}"`)}const h=[];if(o.kind===0)for(const g of o.notAmbiguousInLocales)h.push({label:p("unicodeHighlight.allowCommonCharactersInLanguage",'Allow unicode characters that are more common in the language "{0}".',g),run:async()=>{nMe(c,[g])}});if(h.push({label:u(n),run:()=>iMe(c,[n])}),a){const g=new eMe;h.push({label:g.label,run:async()=>g.runAction(c)})}else if(r){const g=new tMe;h.push({label:g.label,run:async()=>g.runAction(c)})}if(o.kind===0){const g=new Hf;h.push({label:g.label,run:async()=>g.runAction(c)})}else if(o.kind===1){const g=new Xv;h.push({label:g.label,run:async()=>g.runAction(c)})}else if(o.kind===2){const g=new Qv;h.push({label:g.label,run:async()=>g.runAction(c)})}else sMe(o);const f=await d.pick(h,{title:kY});f&&await f.run()}}jw.ID="editor.action.unicodeHighlight.showExcludeOptions";async function iMe(s,e){const t=s.getValue(Io.allowedCharacters);let i;typeof t=="object"&&t?i=t:i={};for(const n of e)i[String.fromCodePoint(n)]=!0;await s.updateValue(Io.allowedCharacters,i,2)}async function nMe(s,e){var t;const i=(t=s.inspect(Io.allowedLocales).user)===null||t===void 0?void 0:t.value;let n;typeof i=="object"&&i?n=Object.assign({},i):n={};for(const o of e)n[o]=!0;await s.updateValue(Io.allowedLocales,n,2)}function sMe(s){throw new Error(`Unexpected value: ${s}`)}ue(Hf);ue(Xv);ue(Qv);ue(jw);Ft(xv.ID,xv,1);Bf.register(xO);var oMe=function(s,e,t,i){var n=arguments.length,o=n<3?e:i===null?i=Object.getOwnPropertyDescriptor(e,t):i,r;if(typeof Reflect=="object"&&typeof Reflect.decorate=="function")o=Reflect.decorate(s,e,t,i);else for(var a=s.length-1;a>=0;a--)(r=s[a])&&(o=(n<3?r(o):n>3?r(e,t,o):r(e,t))||o);return n>3&&o&&Object.defineProperty(e,t,o),o},UH=function(s,e){return function(t,i){e(t,i,s)}};const EY="ignoreUnusualLineTerminators";function rMe(s,e,t){s.setModelProperty(e.uri,EY,t)}function aMe(s,e){return s.getModelProperty(e.uri,EY)}let $1=class extends j{constructor(e,t,i){super(),this._editor=e,this._dialogService=t,this._codeEditorService=i,this._isPresentingDialog=!1,this._config=this._editor.getOption(126),this._register(this._editor.onDidChangeConfiguration(n=>{n.hasChanged(126)&&(this._config=this._editor.getOption(126),this._checkForUnusualLineTerminators())})),this._register(this._editor.onDidChangeModel(()=>{this._checkForUnusualLineTerminators()})),this._register(this._editor.onDidChangeModelContent(n=>{n.isUndoing||this._checkForUnusualLineTerminators()})),this._checkForUnusualLineTerminators()}async _checkForUnusualLineTerminators(){if(this._config==="off"||!this._editor.hasModel())return;const e=this._editor.getModel();if(!e.mightContainUnusualLineTerminators()||aMe(this._codeEditorService,e)===!0||this._editor.getOption(91))return;if(this._config==="auto"){e.removeUnusualLineTerminators(this._editor.getSelections());return}if(this._isPresentingDialog)return;let i;try{this._isPresentingDialog=!0,i=await this._dialogService.confirm({title:p("unusualLineTerminators.title","Unusual Line Terminators"),message:p("unusualLineTerminators.message","Detected unusual line terminators"),detail:p("unusualLineTerminators.detail","The file '{0}' contains one or more unusual line terminator characters, like Line Separator (LS) or Paragraph Separator (PS).\n\nIt is recommended to remove them from the file. This can be configured via `editor.unusualLineTerminators`.",pa(e.uri)),primaryButton:p({key:"unusualLineTerminators.fix",comment:["&& denotes a mnemonic"]},"&&Remove Unusual Line Terminators"),cancelButton:p("unusualLineTerminators.ignore","Ignore")})}finally{this._isPresentingDialog=!1}if(!i.confirmed){rMe(this._codeEditorService,e,!0);return}e.removeUnusualLineTerminators(this._editor.getSelections())}};$1.ID="editor.contrib.unusualLineTerminatorsDetector";$1=oMe([UH(1,ww),UH(2,Ot)],$1);Ft($1.ID,$1,1);var NY=function(s,e,t,i){var n=arguments.length,o=n<3?e:i===null?i=Object.getOwnPropertyDescriptor(e,t):i,r;if(typeof Reflect=="object"&&typeof Reflect.decorate=="function")o=Reflect.decorate(s,e,t,i);else for(var a=s.length-1;a>=0;a--)(r=s[a])&&(o=(n<3?r(o):n>3?r(e,t,o):r(e,t))||o);return n>3&&o&&Object.defineProperty(e,t,o),o},CL=function(s,e){return function(t,i){e(t,i,s)}},On,kO;const cE=new ye("hasWordHighlights",!1);function TY(s,e,t,i){const n=s.ordered(e);return MF(n.map(o=>()=>Promise.resolve(o.provideDocumentHighlights(e,t,i)).then(void 0,an)),Ms).then(o=>{if(o){const r=new un;return r.set(e.uri,o),r}return new un})}function lMe(s,e,t,i,n,o){const r=s.ordered(e);return MF(r.map(a=>()=>{const l=o.filter(d=>h$(d)).filter(d=>W4(a.selector,d.uri,d.getLanguageId(),!0,void 0,void 0)>0);return Promise.resolve(a.provideMultiDocumentHighlights(e,t,l,n)).then(void 0,an)}),a=>a instanceof un&&a.size>0)}class N3{constructor(e,t,i){this._model=e,this._selection=t,this._wordSeparators=i,this._wordRange=this._getCurrentWordRange(e,t),this._result=null}get result(){return this._result||(this._result=Gn(e=>this._compute(this._model,this._selection,this._wordSeparators,e))),this._result}_getCurrentWordRange(e,t){const i=e.getWordAtPosition(t.getPosition());return i?new x(t.startLineNumber,i.startColumn,t.startLineNumber,i.endColumn):null}isValid(e,t,i){const n=t.startLineNumber,o=t.startColumn,r=t.endColumn,a=this._getCurrentWordRange(e,t);let l=!!(this._wordRange&&this._wordRange.equalsRange(a));for(let d=0,c=i.length;!l&&d<c;d++){const u=i.getRange(d);u&&u.startLineNumber===n&&u.startColumn<=o&&u.endColumn>=r&&(l=!0)}return l}cancel(){this.result.cancel()}}class dMe extends N3{constructor(e,t,i,n){super(e,t,i),this._providers=n}_compute(e,t,i,n){return TY(this._providers,e,t.getPosition(),n).then(o=>o||new un)}}class cMe extends N3{constructor(e,t,i,n,o){super(e,t,i),this._providers=n,this._otherModels=o}_compute(e,t,i,n){return lMe(this._providers,e,t.getPosition(),i,n,this._otherModels).then(o=>o||new un)}}class MY extends N3{constructor(e,t,i,n,o){super(e,t,n),this._otherModels=o,this._selectionIsEmpty=t.isEmpty(),this._word=i}_compute(e,t,i,n){return ku(250,n).then(()=>{const o=new un;let r;if(this._word?r=this._word:r=e.getWordAtPosition(t.getPosition()),!r)return new un;const a=[e,...this._otherModels];for(const l of a){if(l.isDisposed())continue;const c=l.findMatches(r.word,!0,!1,!0,i,!1).map(u=>({range:u.range,kind:vC.Text}));c&&o.set(l.uri,c)}return o})}isValid(e,t,i){const n=t.isEmpty();return this._selectionIsEmpty!==n?!1:super.isValid(e,t,i)}}function uMe(s,e,t,i,n){return s.has(e)?new dMe(e,t,n,s):new MY(e,t,i,n,[])}function hMe(s,e,t,i,n,o){return s.has(e)?new cMe(e,t,n,s,o):new MY(e,t,i,n,o)}uc("_executeDocumentHighlights",async(s,e,t)=>{const i=s.get(Te),n=await TY(i.documentHighlightProvider,e,t,yt.None);return n==null?void 0:n.get(e.uri)});let j1=On=class{constructor(e,t,i,n,o){this.toUnhook=new se,this.workerRequestTokenId=0,this.workerRequestCompleted=!1,this.workerRequestValue=new un,this.lastCursorPositionChangeTime=0,this.renderDecorationsTimer=-1,this.editor=e,this.providers=t,this.multiDocumentProviders=i,this.codeEditorService=o,this._hasWordHighlights=cE.bindTo(n),this._ignorePositionChangeEvent=!1,this.occurrencesHighlight=this.editor.getOption(81),this.model=this.editor.getModel(),this.toUnhook.add(e.onDidChangeCursorPosition(r=>{this._ignorePositionChangeEvent||this.occurrencesHighlight!=="off"&&this._onPositionChanged(r)})),this.toUnhook.add(e.onDidFocusEditorText(r=>{this.occurrencesHighlight!=="off"&&(this.workerRequest||this._run())})),this.toUnhook.add(e.onDidChangeModelContent(r=>{this._stopAll()})),this.toUnhook.add(e.onDidChangeModel(r=>{!r.newModelUrl&&r.oldModelUrl?this._stopSingular():On.query&&this._run()})),this.toUnhook.add(e.onDidChangeConfiguration(r=>{const a=this.editor.getOption(81);this.occurrencesHighlight!==a&&(this.occurrencesHighlight=a,this._stopAll())})),this.decorations=this.editor.createDecorationsCollection(),this.workerRequestTokenId=0,this.workerRequest=null,this.workerRequestCompleted=!1,this.lastCursorPositionChangeTime=0,this.renderDecorationsTimer=-1,On.query&&this._run()}hasDecorations(){return this.decorations.length>0}restore(){this.occurrencesHighlight!=="off"&&this._run()}_getSortedHighlights(){return this.decorations.getRanges().sort(x.compareRangesUsingStarts)}moveNext(){const e=this._getSortedHighlights(),i=(e.findIndex(o=>o.containsPosition(this.editor.getPosition()))+1)%e.length,n=e[i];try{this._ignorePositionChangeEvent=!0,this.editor.setPosition(n.getStartPosition()),this.editor.revealRangeInCenterIfOutsideViewport(n);const o=this._getWord();if(o){const r=this.editor.getModel().getLineContent(n.startLineNumber);Wo(`${r}, ${i+1} of ${e.length} for '${o.word}'`)}}finally{this._ignorePositionChangeEvent=!1}}moveBack(){const e=this._getSortedHighlights(),i=(e.findIndex(o=>o.containsPosition(this.editor.getPosition()))-1+e.length)%e.length,n=e[i];try{this._ignorePositionChangeEvent=!0,this.editor.setPosition(n.getStartPosition()),this.editor.revealRangeInCenterIfOutsideViewport(n);const o=this._getWord();if(o){const r=this.editor.getModel().getLineContent(n.startLineNumber);Wo(`${r}, ${i+1} of ${e.length} for '${o.word}'`)}}finally{this._ignorePositionChangeEvent=!1}}_removeSingleDecorations(){if(!this.editor.hasModel())return;const e=On.storedDecorations.get(this.editor.getModel().uri);e&&(this.editor.removeDecorations(e),On.storedDecorations.delete(this.editor.getModel().uri),this.decorations.length>0&&(this.decorations.clear(),this._hasWordHighlights.set(!1)))}_removeAllDecorations(){const e=this.codeEditorService.listCodeEditors(),t=[];for(const i of e){if(!i.hasModel())continue;const n=On.storedDecorations.get(i.getModel().uri);if(!n)continue;i.removeDecorations(n),t.push(i.getModel().uri);const o=Au.get(i);o!=null&&o.wordHighlighter&&o.wordHighlighter.decorations.length>0&&(o.wordHighlighter.decorations.clear(),o.wordHighlighter.workerRequest=null,o.wordHighlighter._hasWordHighlights.set(!1))}for(const i of t)On.storedDecorations.delete(i)}_stopSingular(){var e,t,i,n;this._removeSingleDecorations(),this.editor.hasTextFocus()&&(((e=this.editor.getModel())===null||e===void 0?void 0:e.uri.scheme)!==dt.vscodeNotebookCell&&((i=(t=On.query)===null||t===void 0?void 0:t.modelInfo)===null||i===void 0?void 0:i.model.uri.scheme)!==dt.vscodeNotebookCell?(On.query=null,this._run()):!((n=On.query)===null||n===void 0)&&n.modelInfo&&(On.query.modelInfo=null)),this.renderDecorationsTimer!==-1&&(clearTimeout(this.renderDecorationsTimer),this.renderDecorationsTimer=-1),this.workerRequest!==null&&(this.workerRequest.cancel(),this.workerRequest=null),this.workerRequestCompleted||(this.workerRequestTokenId++,this.workerRequestCompleted=!0)}_stopAll(){this._removeAllDecorations(),this.renderDecorationsTimer!==-1&&(clearTimeout(this.renderDecorationsTimer),this.renderDecorationsTimer=-1),this.workerRequest!==null&&(this.workerRequest.cancel(),this.workerRequest=null),this.workerRequestCompleted||(this.workerRequestTokenId++,this.workerRequestCompleted=!0)}_onPositionChanged(e){var t;if(this.occurrencesHighlight==="off"){this._stopAll();return}if(e.reason!==3&&((t=this.editor.getModel())===null||t===void 0?void 0:t.uri.scheme)!==dt.vscodeNotebookCell){this._stopAll();return}this._run()}_getWord(){const e=this.editor.getSelection(),t=e.startLineNumber,i=e.startColumn;return this.model.isDisposed()?null:this.model.getWordAtPosition({lineNumber:t,column:i})}getOtherModelsToHighlight(e){if(!e)return[];if(e.uri.scheme===dt.vscodeNotebookCell){const o=[],r=this.codeEditorService.listCodeEditors();for(const a of r){const l=a.getModel();l&&l!==e&&l.uri.scheme===dt.vscodeNotebookCell&&o.push(l)}return o}const i=[],n=this.codeEditorService.listCodeEditors();for(const o of n){if(!P5(o))continue;const r=o.getModel();r&&e===r.modified&&i.push(r.modified)}if(i.length)return i;if(this.occurrencesHighlight==="singleFile")return[];for(const o of n){const r=o.getModel();r&&r!==e&&i.push(r)}return i}_run(){var e;let t;if(this.editor.hasTextFocus()){const n=this.editor.getSelection();if(!n||n.startLineNumber!==n.endLineNumber){On.query=null,this._stopAll();return}const o=n.startColumn,r=n.endColumn,a=this._getWord();if(!a||a.startColumn>o||a.endColumn<r){On.query=null,this._stopAll();return}t=this.workerRequest&&this.workerRequest.isValid(this.model,n,this.decorations),On.query={modelInfo:{model:this.model,selection:n},word:a}}else if(!On.query)return;if(this.lastCursorPositionChangeTime=new Date().getTime(),t)this.workerRequestCompleted&&this.renderDecorationsTimer!==-1&&(clearTimeout(this.renderDecorationsTimer),this.renderDecorationsTimer=-1,this._beginRenderDecorations());else{this._stopAll();const n=++this.workerRequestTokenId;this.workerRequestCompleted=!1;const o=this.getOtherModelsToHighlight(this.editor.getModel());if(!On.query.modelInfo||On.query.modelInfo.model.isDisposed())return;this.workerRequest=this.computeWithModel(On.query.modelInfo.model,On.query.modelInfo.selection,On.query.word,o),(e=this.workerRequest)===null||e===void 0||e.result.then(r=>{n===this.workerRequestTokenId&&(this.workerRequestCompleted=!0,this.workerRequestValue=r||[],this._beginRenderDecorations())},ct)}}computeWithModel(e,t,i,n){return n.length?hMe(this.multiDocumentProviders,e,t,i,this.editor.getOption(131),n):uMe(this.providers,e,t,i,this.editor.getOption(131))}_beginRenderDecorations(){const e=new Date().getTime(),t=this.lastCursorPositionChangeTime+250;e>=t?(this.renderDecorationsTimer=-1,this.renderDecorations()):this.renderDecorationsTimer=setTimeout(()=>{this.renderDecorations()},t-e)}renderDecorations(){var e,t,i;this.renderDecorationsTimer=-1;const n=this.codeEditorService.listCodeEditors();for(const o of n){const r=Au.get(o);if(!r)continue;const a=[],l=(e=o.getModel())===null||e===void 0?void 0:e.uri;if(l&&this.workerRequestValue.has(l)){const d=On.storedDecorations.get(l),c=this.workerRequestValue.get(l);if(c)for(const h of c)h.range&&a.push({range:h.range,options:yNe(h.kind)});let u=[];o.changeDecorations(h=>{u=h.deltaDecorations(d??[],a)}),On.storedDecorations=On.storedDecorations.set(l,u),a.length>0&&((t=r.wordHighlighter)===null||t===void 0||t.decorations.set(a),(i=r.wordHighlighter)===null||i===void 0||i._hasWordHighlights.set(!0))}}}dispose(){this._stopSingular(),this.toUnhook.dispose()}};j1.storedDecorations=new un;j1.query=null;j1=On=NY([CL(4,Ot)],j1);let Au=kO=class extends j{static get(e){return e.getContribution(kO.ID)}constructor(e,t,i,n){super(),this._wordHighlighter=null;const o=()=>{e.hasModel()&&!e.getModel().isTooLargeForTokenization()&&(this._wordHighlighter=new j1(e,i.documentHighlightProvider,i.multiDocumentHighlightProvider,t,n))};this._register(e.onDidChangeModel(r=>{this._wordHighlighter&&(this._wordHighlighter.dispose(),this._wordHighlighter=null),o()})),o()}get wordHighlighter(){return this._wordHighlighter}saveViewState(){return!!(this._wordHighlighter&&this._wordHighlighter.hasDecorations())}moveNext(){var e;(e=this._wordHighlighter)===null||e===void 0||e.moveNext()}moveBack(){var e;(e=this._wordHighlighter)===null||e===void 0||e.moveBack()}restoreViewState(e){this._wordHighlighter&&e&&this._wordHighlighter.restore()}dispose(){this._wordHighlighter&&(this._wordHighlighter.dispose(),this._wordHighlighter=null),super.dispose()}};Au.ID="editor.contrib.wordHighlighter";Au=kO=NY([CL(1,Je),CL(2,Te),CL(3,Ot)],Au);class RY extends De{constructor(e,t){super(t),this._isNext=e}run(e,t){const i=Au.get(t);i&&(this._isNext?i.moveNext():i.moveBack())}}class fMe extends RY{constructor(){super(!0,{id:"editor.action.wordHighlight.next",label:p("wordHighlight.next.label","Go to Next Symbol Highlight"),alias:"Go to Next Symbol Highlight",precondition:cE,kbOpts:{kbExpr:N.editorTextFocus,primary:65,weight:100}})}}class gMe extends RY{constructor(){super(!1,{id:"editor.action.wordHighlight.prev",label:p("wordHighlight.previous.label","Go to Previous Symbol Highlight"),alias:"Go to Previous Symbol Highlight",precondition:cE,kbOpts:{kbExpr:N.editorTextFocus,primary:1089,weight:100}})}}class pMe extends De{constructor(){super({id:"editor.action.wordHighlight.trigger",label:p("wordHighlight.trigger.label","Trigger Symbol Highlight"),alias:"Trigger Symbol Highlight",precondition:cE.toNegated(),kbOpts:{kbExpr:N.editorTextFocus,primary:0,weight:100}})}run(e,t,i){const n=Au.get(t);n&&n.restoreViewState(!0)}}Ft(Au.ID,Au,0);ue(fMe);ue(gMe);ue(pMe);class uE extends Wn{constructor(e){super(e),this._inSelectionMode=e.inSelectionMode,this._wordNavigationType=e.wordNavigationType}runEditorCommand(e,t,i){if(!t.hasModel())return;const n=ga(t.getOption(131),t.getOption(130)),o=t.getModel(),a=t.getSelections().map(l=>{const d=new U(l.positionLineNumber,l.positionColumn),c=this._move(n,o,d,this._wordNavigationType);return this._moveTo(l,c,this._inSelectionMode)});if(o.pushStackElement(),t._getViewModel().setCursorStates("moveWordCommand",3,a.map(l=>At.fromModelSelection(l))),a.length===1){const l=new U(a[0].positionLineNumber,a[0].positionColumn);t.revealPosition(l,0)}}_moveTo(e,t,i){return i?new Ae(e.selectionStartLineNumber,e.selectionStartColumn,t.lineNumber,t.column):new Ae(t.lineNumber,t.column,t.lineNumber,t.column)}}class Vf extends uE{_move(e,t,i,n){return qt.moveWordLeft(e,t,i,n)}}class zf extends uE{_move(e,t,i,n){return qt.moveWordRight(e,t,i,n)}}class mMe extends Vf{constructor(){super({inSelectionMode:!1,wordNavigationType:0,id:"cursorWordStartLeft",precondition:void 0})}}class _Me extends Vf{constructor(){super({inSelectionMode:!1,wordNavigationType:2,id:"cursorWordEndLeft",precondition:void 0})}}class vMe extends Vf{constructor(){var e;super({inSelectionMode:!1,wordNavigationType:1,id:"cursorWordLeft",precondition:void 0,kbOpts:{kbExpr:ne.and(N.textInputFocus,(e=ne.and(hw,oI))===null||e===void 0?void 0:e.negate()),primary:2063,mac:{primary:527},weight:100}})}}class bMe extends Vf{constructor(){super({inSelectionMode:!0,wordNavigationType:0,id:"cursorWordStartLeftSelect",precondition:void 0})}}class CMe extends Vf{constructor(){super({inSelectionMode:!0,wordNavigationType:2,id:"cursorWordEndLeftSelect",precondition:void 0})}}class wMe extends Vf{constructor(){var e;super({inSelectionMode:!0,wordNavigationType:1,id:"cursorWordLeftSelect",precondition:void 0,kbOpts:{kbExpr:ne.and(N.textInputFocus,(e=ne.and(hw,oI))===null||e===void 0?void 0:e.negate()),primary:3087,mac:{primary:1551},weight:100}})}}class SMe extends Vf{constructor(){super({inSelectionMode:!1,wordNavigationType:3,id:"cursorWordAccessibilityLeft",precondition:void 0})}_move(e,t,i,n){return super._move(ga(Ul.wordSeparators.defaultValue,e.intlSegmenterLocales),t,i,n)}}class yMe extends Vf{constructor(){super({inSelectionMode:!0,wordNavigationType:3,id:"cursorWordAccessibilityLeftSelect",precondition:void 0})}_move(e,t,i,n){return super._move(ga(Ul.wordSeparators.defaultValue,e.intlSegmenterLocales),t,i,n)}}class LMe extends zf{constructor(){super({inSelectionMode:!1,wordNavigationType:0,id:"cursorWordStartRight",precondition:void 0})}}class xMe extends zf{constructor(){var e;super({inSelectionMode:!1,wordNavigationType:2,id:"cursorWordEndRight",precondition:void 0,kbOpts:{kbExpr:ne.and(N.textInputFocus,(e=ne.and(hw,oI))===null||e===void 0?void 0:e.negate()),primary:2065,mac:{primary:529},weight:100}})}}class DMe extends zf{constructor(){super({inSelectionMode:!1,wordNavigationType:2,id:"cursorWordRight",precondition:void 0})}}class kMe extends zf{constructor(){super({inSelectionMode:!0,wordNavigationType:0,id:"cursorWordStartRightSelect",precondition:void 0})}}class IMe extends zf{constructor(){var e;super({inSelectionMode:!0,wordNavigationType:2,id:"cursorWordEndRightSelect",precondition:void 0,kbOpts:{kbExpr:ne.and(N.textInputFocus,(e=ne.and(hw,oI))===null||e===void 0?void 0:e.negate()),primary:3089,mac:{primary:1553},weight:100}})}}class EMe extends zf{constructor(){super({inSelectionMode:!0,wordNavigationType:2,id:"cursorWordRightSelect",precondition:void 0})}}class NMe extends zf{constructor(){super({inSelectionMode:!1,wordNavigationType:3,id:"cursorWordAccessibilityRight",precondition:void 0})}_move(e,t,i,n){return super._move(ga(Ul.wordSeparators.defaultValue,e.intlSegmenterLocales),t,i,n)}}class TMe extends zf{constructor(){super({inSelectionMode:!0,wordNavigationType:3,id:"cursorWordAccessibilityRightSelect",precondition:void 0})}_move(e,t,i,n){return super._move(ga(Ul.wordSeparators.defaultValue,e.intlSegmenterLocales),t,i,n)}}class hE extends Wn{constructor(e){super(e),this._whitespaceHeuristics=e.whitespaceHeuristics,this._wordNavigationType=e.wordNavigationType}runEditorCommand(e,t,i){const n=e.get(Si);if(!t.hasModel())return;const o=ga(t.getOption(131),t.getOption(130)),r=t.getModel(),a=t.getSelections(),l=t.getOption(6),d=t.getOption(11),c=n.getLanguageConfiguration(r.getLanguageId()).getAutoClosingPairs(),u=t._getViewModel(),h=a.map(f=>{const g=this._delete({wordSeparators:o,model:r,selection:f,whitespaceHeuristics:this._whitespaceHeuristics,autoClosingDelete:t.getOption(9),autoClosingBrackets:l,autoClosingQuotes:d,autoClosingPairs:c,autoClosedCharacters:u.getCursorAutoClosedCharacters()},this._wordNavigationType);return new vs(g,"")});t.pushUndoStop(),t.executeCommands(this.id,h),t.pushUndoStop()}}class T3 extends hE{_delete(e,t){const i=qt.deleteWordLeft(e,t);return i||new x(1,1,1,1)}}class M3 extends hE{_delete(e,t){const i=qt.deleteWordRight(e,t);if(i)return i;const n=e.model.getLineCount(),o=e.model.getLineMaxColumn(n);return new x(n,o,n,o)}}class MMe extends T3{constructor(){super({whitespaceHeuristics:!1,wordNavigationType:0,id:"deleteWordStartLeft",precondition:N.writable})}}class RMe extends T3{constructor(){super({whitespaceHeuristics:!1,wordNavigationType:2,id:"deleteWordEndLeft",precondition:N.writable})}}class AMe extends T3{constructor(){super({whitespaceHeuristics:!0,wordNavigationType:0,id:"deleteWordLeft",precondition:N.writable,kbOpts:{kbExpr:N.textInputFocus,primary:2049,mac:{primary:513},weight:100}})}}class PMe extends M3{constructor(){super({whitespaceHeuristics:!1,wordNavigationType:0,id:"deleteWordStartRight",precondition:N.writable})}}class OMe extends M3{constructor(){super({whitespaceHeuristics:!1,wordNavigationType:2,id:"deleteWordEndRight",precondition:N.writable})}}class FMe extends M3{constructor(){super({whitespaceHeuristics:!0,wordNavigationType:2,id:"deleteWordRight",precondition:N.writable,kbOpts:{kbExpr:N.textInputFocus,primary:2068,mac:{primary:532},weight:100}})}}class BMe extends De{constructor(){super({id:"deleteInsideWord",precondition:N.writable,label:p("deleteInsideWord","Delete Word"),alias:"Delete Word"})}run(e,t,i){if(!t.hasModel())return;const n=ga(t.getOption(131),t.getOption(130)),o=t.getModel(),a=t.getSelections().map(l=>{const d=qt.deleteInsideWord(n,o,l);return new vs(d,"")});t.pushUndoStop(),t.executeCommands(this.id,a),t.pushUndoStop()}}Se(new mMe);Se(new _Me);Se(new vMe);Se(new bMe);Se(new CMe);Se(new wMe);Se(new LMe);Se(new xMe);Se(new DMe);Se(new kMe);Se(new IMe);Se(new EMe);Se(new SMe);Se(new yMe);Se(new NMe);Se(new TMe);Se(new MMe);Se(new RMe);Se(new AMe);Se(new PMe);Se(new OMe);Se(new FMe);ue(BMe);class WMe extends hE{constructor(){super({whitespaceHeuristics:!0,wordNavigationType:0,id:"deleteWordPartLeft",precondition:N.writable,kbOpts:{kbExpr:N.textInputFocus,primary:0,mac:{primary:769},weight:100}})}_delete(e,t){const i=Mk.deleteWordPartLeft(e);return i||new x(1,1,1,1)}}class HMe extends hE{constructor(){super({whitespaceHeuristics:!0,wordNavigationType:2,id:"deleteWordPartRight",precondition:N.writable,kbOpts:{kbExpr:N.textInputFocus,primary:0,mac:{primary:788},weight:100}})}_delete(e,t){const i=Mk.deleteWordPartRight(e);if(i)return i;const n=e.model.getLineCount(),o=e.model.getLineMaxColumn(n);return new x(n,o,n,o)}}class AY extends uE{_move(e,t,i,n){return Mk.moveWordPartLeft(e,t,i)}}class VMe extends AY{constructor(){super({inSelectionMode:!1,wordNavigationType:0,id:"cursorWordPartLeft",precondition:void 0,kbOpts:{kbExpr:N.textInputFocus,primary:0,mac:{primary:783},weight:100}})}}It.registerCommandAlias("cursorWordPartStartLeft","cursorWordPartLeft");class zMe extends AY{constructor(){super({inSelectionMode:!0,wordNavigationType:0,id:"cursorWordPartLeftSelect",precondition:void 0,kbOpts:{kbExpr:N.textInputFocus,primary:0,mac:{primary:1807},weight:100}})}}It.registerCommandAlias("cursorWordPartStartLeftSelect","cursorWordPartLeftSelect");class PY extends uE{_move(e,t,i,n){return Mk.moveWordPartRight(e,t,i)}}class UMe extends PY{constructor(){super({inSelectionMode:!1,wordNavigationType:2,id:"cursorWordPartRight",precondition:void 0,kbOpts:{kbExpr:N.textInputFocus,primary:0,mac:{primary:785},weight:100}})}}class $Me extends PY{constructor(){super({inSelectionMode:!0,wordNavigationType:2,id:"cursorWordPartRightSelect",precondition:void 0,kbOpts:{kbExpr:N.textInputFocus,primary:0,mac:{primary:1809},weight:100}})}}Se(new WMe);Se(new HMe);Se(new VMe);Se(new zMe);Se(new UMe);Se(new $Me);class IO extends j{constructor(e){super(),this.editor=e,this._register(this.editor.onDidAttemptReadOnlyEdit(()=>this._onDidAttemptReadOnlyEdit()))}_onDidAttemptReadOnlyEdit(){const e=go.get(this.editor);if(e&&this.editor.hasModel()){let t=this.editor.getOptions().get(92);t||(this.editor.isSimpleWidget?t=new Ns(p("editor.simple.readonly","Cannot edit in read-only input")):t=new Ns(p("editor.readonly","Cannot edit in read-only editor"))),e.showMessage(t,this.editor.getPosition())}}}IO.ID="editor.contrib.readOnlyMessageController";Ft(IO.ID,IO,2);var jMe=function(s,e,t,i){var n=arguments.length,o=n<3?e:i===null?i=Object.getOwnPropertyDescriptor(e,t):i,r;if(typeof Reflect=="object"&&typeof Reflect.decorate=="function")o=Reflect.decorate(s,e,t,i);else for(var a=s.length-1;a>=0;a--)(r=s[a])&&(o=(n<3?r(o):n>3?r(e,t,o):r(e,t))||o);return n>3&&o&&Object.defineProperty(e,t,o),o},$H=function(s,e){return function(t,i){e(t,i,s)}};let EO=class extends j{constructor(e,t,i){super(),this._textModel=e,this._languageFeaturesService=t,this._outlineModelService=i,this._currentModel=Nt(this,void 0);const n=Ts("documentSymbolProvider.onDidChange",this._languageFeaturesService.documentSymbolProvider.onDidChange),o=Ts("_textModel.onDidChangeContent",ve.debounce(r=>this._textModel.onDidChangeContent(r),()=>{},100));this._register(ma(async(r,a)=>{n.read(r),o.read(r);const l=a.add(new yCe),d=await this._outlineModelService.getOrCreate(this._textModel,l.token);a.isDisposed||this._currentModel.set(d,void 0)}))}getBreadcrumbItems(e,t){const i=this._currentModel.read(t);if(!i)return[];const n=i.asListOfDocumentSymbols().filter(o=>e.contains(o.range.startLineNumber)&&!e.contains(o.range.endLineNumber));return n.sort(Tz(Po(o=>o.range.endLineNumber-o.range.startLineNumber,Ua))),n.map(o=>({name:o.name,kind:o.kind,startLineNumber:o.range.startLineNumber}))}};EO=jMe([$H(1,Te),$H(2,Uw)],EO);p1.setBreadcrumbsSourceFactory((s,e)=>e.createInstance(EO,s));class NO extends j{constructor(e){super(),this.editor=e,this.widget=null,Pl&&(this._register(e.onDidChangeConfiguration(()=>this.update())),this.update())}update(){const e=!this.editor.getOption(91);!this.widget&&e?this.widget=new fE(this.editor):this.widget&&!e&&(this.widget.dispose(),this.widget=null)}dispose(){super.dispose(),this.widget&&(this.widget.dispose(),this.widget=null)}}NO.ID="editor.contrib.iPadShowKeyboard";class fE extends j{constructor(e){super(),this.editor=e,this._domNode=document.createElement("textarea"),this._domNode.className="iPadShowKeyboard",this._register(X(this._domNode,"touchstart",t=>{this.editor.focus()})),this._register(X(this._domNode,"focus",t=>{this.editor.focus()})),this.editor.addOverlayWidget(this)}dispose(){this.editor.removeOverlayWidget(this),super.dispose()}getId(){return fE.ID}getDomNode(){return this._domNode}getPosition(){return{preference:1}}}fE.ID="editor.contrib.ShowKeyboardWidget";Ft(NO.ID,NO,3);var KMe=function(s,e,t,i){var n=arguments.length,o=n<3?e:i===null?i=Object.getOwnPropertyDescriptor(e,t):i,r;if(typeof Reflect=="object"&&typeof Reflect.decorate=="function")o=Reflect.decorate(s,e,t,i);else for(var a=s.length-1;a>=0;a--)(r=s[a])&&(o=(n<3?r(o):n>3?r(e,t,o):r(e,t))||o);return n>3&&o&&Object.defineProperty(e,t,o),o},jH=function(s,e){return function(t,i){e(t,i,s)}},TO;let Dv=TO=class extends j{static get(e){return e.getContribution(TO.ID)}constructor(e,t,i){super(),this._editor=e,this._languageService=i,this._widget=null,this._register(this._editor.onDidChangeModel(n=>this.stop())),this._register(this._editor.onDidChangeModelLanguage(n=>this.stop())),this._register(Yi.onDidChange(n=>this.stop())),this._register(this._editor.onKeyUp(n=>n.keyCode===9&&this.stop()))}dispose(){this.stop(),super.dispose()}launch(){this._widget||this._editor.hasModel()&&(this._widget=new gE(this._editor,this._languageService))}stop(){this._widget&&(this._widget.dispose(),this._widget=null)}};Dv.ID="editor.contrib.inspectTokens";Dv=TO=KMe([jH(1,jr),jH(2,Fi)],Dv);class qMe extends De{constructor(){super({id:"editor.action.inspectTokens",label:OA.inspectTokensAction,alias:"Developer: Inspect Tokens",precondition:void 0})}run(e,t){const i=Dv.get(t);i==null||i.launch()}}function GMe(s){let e="";for(let t=0,i=s.length;t<i;t++){const n=s.charCodeAt(t);switch(n){case 9:e+="→";break;case 32:e+="·";break;default:e+=String.fromCharCode(n)}}return e}function ZMe(s,e){const t=Yi.get(e);if(t)return t;const i=s.encodeLanguageId(e);return{getInitialState:()=>$_,tokenize:(n,o,r)=>e4(e,r),tokenizeEncoded:(n,o,r)=>Lk(i,r)}}class gE extends j{constructor(e,t){super(),this.allowEditorOverflow=!0,this._editor=e,this._languageService=t,this._model=this._editor.getModel(),this._domNode=document.createElement("div"),this._domNode.className="tokens-inspect-widget",this._tokenizationSupport=ZMe(this._languageService.languageIdCodec,this._model.getLanguageId()),this._compute(this._editor.getPosition()),this._register(this._editor.onDidChangeCursorPosition(i=>this._compute(this._editor.getPosition()))),this._editor.addContentWidget(this)}dispose(){this._editor.removeContentWidget(this),super.dispose()}getId(){return gE._ID}_compute(e){const t=this._getTokensAtLine(e.lineNumber);let i=0;for(let l=t.tokens1.length-1;l>=0;l--){const d=t.tokens1[l];if(e.column-1>=d.offset){i=l;break}}let n=0;for(let l=t.tokens2.length>>>1;l>=0;l--)if(e.column-1>=t.tokens2[l<<1]){n=l;break}const o=this._model.getLineContent(e.lineNumber);let r="";if(i<t.tokens1.length){const l=t.tokens1[i].offset,d=i+1<t.tokens1.length?t.tokens1[i+1].offset:o.length;r=o.substring(l,d)}us(this._domNode,fe("h2.tm-token",void 0,GMe(r),fe("span.tm-token-length",void 0,`${r.length} ${r.length===1?"char":"chars"}`))),re(this._domNode,fe("hr.tokens-inspect-separator",{style:"clear:both"}));const a=(n<<1)+1<t.tokens2.length?this._decodeMetadata(t.tokens2[(n<<1)+1]):null;re(this._domNode,fe("table.tm-metadata-table",void 0,fe("tbody",void 0,fe("tr",void 0,fe("td.tm-metadata-key",void 0,"language"),fe("td.tm-metadata-value",void 0,`${a?a.languageId:"-?-"}`)),fe("tr",void 0,fe("td.tm-metadata-key",void 0,"token type"),fe("td.tm-metadata-value",void 0,`${a?this._tokenTypeToString(a.tokenType):"-?-"}`)),fe("tr",void 0,fe("td.tm-metadata-key",void 0,"font style"),fe("td.tm-metadata-value",void 0,`${a?this._fontStyleToString(a.fontStyle):"-?-"}`)),fe("tr",void 0,fe("td.tm-metadata-key",void 0,"foreground"),fe("td.tm-metadata-value",void 0,`${a?Z.Format.CSS.formatHex(a.foreground):"-?-"}`)),fe("tr",void 0,fe("td.tm-metadata-key",void 0,"background"),fe("td.tm-metadata-value",void 0,`${a?Z.Format.CSS.formatHex(a.background):"-?-"}`))))),re(this._domNode,fe("hr.tokens-inspect-separator")),i<t.tokens1.length&&re(this._domNode,fe("span.tm-token-type",void 0,t.tokens1[i].type)),this._editor.layoutContentWidget(this)}_decodeMetadata(e){const t=Yi.getColorMap(),i=ws.getLanguageId(e),n=ws.getTokenType(e),o=ws.getFontStyle(e),r=ws.getForeground(e),a=ws.getBackground(e);return{languageId:this._languageService.languageIdCodec.decodeLanguageId(i),tokenType:n,fontStyle:o,foreground:t[r],background:t[a]}}_tokenTypeToString(e){switch(e){case 0:return"Other";case 1:return"Comment";case 2:return"String";case 3:return"RegEx";default:return"??"}}_fontStyleToString(e){let t="";return e&1&&(t+="italic "),e&2&&(t+="bold "),e&4&&(t+="underline "),e&8&&(t+="strikethrough "),t.length===0&&(t="---"),t}_getTokensAtLine(e){const t=this._getStateBeforeLine(e),i=this._tokenizationSupport.tokenize(this._model.getLineContent(e),!0,t),n=this._tokenizationSupport.tokenizeEncoded(this._model.getLineContent(e),!0,t);return{startState:t,tokens1:i.tokens,tokens2:n.tokens,endState:i.endState}}_getStateBeforeLine(e){let t=this._tokenizationSupport.getInitialState();for(let i=1;i<e;i++)t=this._tokenizationSupport.tokenize(this._model.getLineContent(i),!0,t).endState;return t}getDomNode(){return this._domNode}getPosition(){return{position:this._editor.getPosition(),preference:[2,1]}}}gE._ID="editor.contrib.inspectTokensWidget";Ft(Dv.ID,Dv,4);ue(qMe);var YMe=function(s,e,t,i){var n=arguments.length,o=n<3?e:i===null?i=Object.getOwnPropertyDescriptor(e,t):i,r;if(typeof Reflect=="object"&&typeof Reflect.decorate=="function")o=Reflect.decorate(s,e,t,i);else for(var a=s.length-1;a>=0;a--)(r=s[a])&&(o=(n<3?r(o):n>3?r(e,t,o):r(e,t))||o);return n>3&&o&&Object.defineProperty(e,t,o),o},KH=function(s,e){return function(t,i){e(t,i,s)}},f0;let OD=f0=class{constructor(e,t){this.quickInputService=e,this.keybindingService=t,this.registry=qi.as(zp.Quickaccess)}provide(e){const t=new se;return t.add(e.onDidAccept(()=>{const[i]=e.selectedItems;i&&this.quickInputService.quickAccess.show(i.prefix,{preserveValue:!0})})),t.add(e.onDidChangeValue(i=>{const n=this.registry.getQuickAccessProvider(i.substr(f0.PREFIX.length));n&&n.prefix&&n.prefix!==f0.PREFIX&&this.quickInputService.quickAccess.show(n.prefix,{preserveValue:!0})})),e.items=this.getQuickAccessProviders().filter(i=>i.prefix!==f0.PREFIX),t}getQuickAccessProviders(){return this.registry.getQuickAccessProviders().sort((t,i)=>t.prefix.localeCompare(i.prefix)).flatMap(t=>this.createPicks(t))}createPicks(e){return e.helpEntries.map(t=>{const i=t.prefix||e.prefix,n=i||"…";return{prefix:i,label:n,keybinding:t.commandId?this.keybindingService.lookupKeybinding(t.commandId):void 0,ariaLabel:p("helpPickAriaLabel","{0}, {1}",n,t.description),description:t.description}})}};OD.PREFIX="?";OD=f0=YMe([KH(0,Yl),KH(1,$t)],OD);qi.as(zp.Quickaccess).registerQuickAccessProvider({ctor:OD,prefix:"",helpEntries:[{description:FA.helpQuickAccessActionLabel}]});class OY{constructor(e){this.options=e,this.rangeHighlightDecorationId=void 0}provide(e,t){var i;const n=new se;e.canAcceptInBackground=!!(!((i=this.options)===null||i===void 0)&&i.canAcceptInBackground),e.matchOnLabel=e.matchOnDescription=e.matchOnDetail=e.sortByLabel=!1;const o=n.add(new Zn);return o.value=this.doProvide(e,t),n.add(this.onDidActiveTextEditorControlChange(()=>{o.value=void 0,o.value=this.doProvide(e,t)})),n}doProvide(e,t){var i;const n=new se,o=this.activeTextEditorControl;if(o&&this.canProvideWithTextEditor(o)){const r={editor:o},a=Wq(o);if(a){let l=(i=o.saveViewState())!==null&&i!==void 0?i:void 0;n.add(a.onDidChangeCursorPosition(()=>{var d;l=(d=o.saveViewState())!==null&&d!==void 0?d:void 0})),r.restoreViewState=()=>{l&&o===this.activeTextEditorControl&&o.restoreViewState(l)},n.add(tf(t.onCancellationRequested)(()=>{var d;return(d=r.restoreViewState)===null||d===void 0?void 0:d.call(r)}))}n.add(ze(()=>this.clearDecorations(o))),n.add(this.provideWithTextEditor(r,e,t))}else n.add(this.provideWithoutTextEditor(e,t));return n}canProvideWithTextEditor(e){return!0}gotoLocation({editor:e},t){e.setSelection(t.range,"code.jump"),e.revealRangeInCenter(t.range,0),t.preserveFocus||e.focus();const i=e.getModel();i&&"getLineContent"in i&&Qd(`${i.getLineContent(t.range.startLineNumber)}`)}getModel(e){var t;return P5(e)?(t=e.getModel())===null||t===void 0?void 0:t.modified:e.getModel()}addDecorations(e,t){e.changeDecorations(i=>{const n=[];this.rangeHighlightDecorationId&&(n.push(this.rangeHighlightDecorationId.overviewRulerDecorationId),n.push(this.rangeHighlightDecorationId.rangeHighlightId),this.rangeHighlightDecorationId=void 0);const o=[{range:t,options:{description:"quick-access-range-highlight",className:"rangeHighlight",isWholeLine:!0}},{range:t,options:{description:"quick-access-range-highlight-overview",overviewRuler:{color:Xi(X$),position:Fr.Full}}}],[r,a]=i.deltaDecorations(n,o);this.rangeHighlightDecorationId={rangeHighlightId:r,overviewRulerDecorationId:a}})}clearDecorations(e){const t=this.rangeHighlightDecorationId;t&&(e.changeDecorations(i=>{i.deltaDecorations([t.overviewRulerDecorationId,t.rangeHighlightId],[])}),this.rangeHighlightDecorationId=void 0)}}class pE extends OY{constructor(){super({canAcceptInBackground:!0})}provideWithoutTextEditor(e){const t=p("cannotRunGotoLine","Open a text editor first to go to a line.");return e.items=[{label:t}],e.ariaLabel=t,j.None}provideWithTextEditor(e,t,i){const n=e.editor,o=new se;o.add(t.onDidAccept(l=>{const[d]=t.selectedItems;if(d){if(!this.isValidLineNumber(n,d.lineNumber))return;this.gotoLocation(e,{range:this.toRange(d.lineNumber,d.column),keyMods:t.keyMods,preserveFocus:l.inBackground}),l.inBackground||t.hide()}}));const r=()=>{const l=this.parsePosition(n,t.value.trim().substr(pE.PREFIX.length)),d=this.getPickLabel(n,l.lineNumber,l.column);if(t.items=[{lineNumber:l.lineNumber,column:l.column,label:d}],t.ariaLabel=d,!this.isValidLineNumber(n,l.lineNumber)){this.clearDecorations(n);return}const c=this.toRange(l.lineNumber,l.column);n.revealRangeInCenter(c,0),this.addDecorations(n,c)};r(),o.add(t.onDidChangeValue(()=>r()));const a=Wq(n);return a&&a.getOptions().get(68).renderType===2&&(a.updateOptions({lineNumbers:"on"}),o.add(ze(()=>a.updateOptions({lineNumbers:"relative"})))),o}toRange(e=1,t=1){return{startLineNumber:e,startColumn:t,endLineNumber:e,endColumn:t}}parsePosition(e,t){const i=t.split(/,|:|#/).map(o=>parseInt(o,10)).filter(o=>!isNaN(o)),n=this.lineCount(e)+1;return{lineNumber:i[0]>0?i[0]:n+i[0],column:i[1]}}getPickLabel(e,t,i){if(this.isValidLineNumber(e,t))return this.isValidColumn(e,t,i)?p("gotoLineColumnLabel","Go to line {0} and character {1}.",t,i):p("gotoLineLabel","Go to line {0}.",t);const n=e.getPosition()||{lineNumber:1,column:1},o=this.lineCount(e);return o>1?p("gotoLineLabelEmptyWithLimit","Current Line: {0}, Character: {1}. Type a line number between 1 and {2} to navigate to.",n.lineNumber,n.column,o):p("gotoLineLabelEmpty","Current Line: {0}, Character: {1}. Type a line number to navigate to.",n.lineNumber,n.column)}isValidLineNumber(e,t){return!t||typeof t!="number"?!1:t>0&&t<=this.lineCount(e)}isValidColumn(e,t,i){if(!i||typeof i!="number")return!1;const n=this.getModel(e);if(!n)return!1;const o={lineNumber:t,column:i};return n.validatePosition(o).equals(o)}lineCount(e){var t,i;return(i=(t=this.getModel(e))===null||t===void 0?void 0:t.getLineCount())!==null&&i!==void 0?i:0}}pE.PREFIX=":";var XMe=function(s,e,t,i){var n=arguments.length,o=n<3?e:i===null?i=Object.getOwnPropertyDescriptor(e,t):i,r;if(typeof Reflect=="object"&&typeof Reflect.decorate=="function")o=Reflect.decorate(s,e,t,i);else for(var a=s.length-1;a>=0;a--)(r=s[a])&&(o=(n<3?r(o):n>3?r(e,t,o):r(e,t))||o);return n>3&&o&&Object.defineProperty(e,t,o),o},QMe=function(s,e){return function(t,i){e(t,i,s)}};let K1=class extends pE{constructor(e){super(),this.editorService=e,this.onDidActiveTextEditorControlChange=ve.None}get activeTextEditorControl(){var e;return(e=this.editorService.getFocusedCodeEditor())!==null&&e!==void 0?e:void 0}};K1=XMe([QMe(0,Ot)],K1);let R3=class FY extends De{constructor(){super({id:FY.ID,label:Nx.gotoLineActionLabel,alias:"Go to Line/Column...",precondition:void 0,kbOpts:{kbExpr:N.focus,primary:2085,mac:{primary:293},weight:100}})}run(e){e.get(Yl).quickAccess.show(K1.PREFIX)}};R3.ID="editor.action.gotoLine";ue(R3);qi.as(zp.Quickaccess).registerQuickAccessProvider({ctor:K1,prefix:K1.PREFIX,helpEntries:[{description:Nx.gotoLineActionLabel,commandId:R3.ID}]});const BY=[void 0,[]];function BT(s,e,t=0,i=0){const n=e;return n.values&&n.values.length>1?JMe(s,n.values,t,i):WY(s,e,t,i)}function JMe(s,e,t,i){let n=0;const o=[];for(const r of e){const[a,l]=WY(s,r,t,i);if(typeof a!="number")return BY;n+=a,o.push(...l)}return[n,eRe(o)]}function WY(s,e,t,i){const n=dp(e.original,e.originalLowercase,t,s,s.toLowerCase(),i,{firstMatchCanBeWeak:!0,boostFullMatch:!0});return n?[n[0],Sw(n)]:BY}function eRe(s){const e=s.sort((n,o)=>n.start-o.start),t=[];let i;for(const n of e)!i||!tRe(i,n)?(i=n,t.push(n)):(i.start=Math.min(i.start,n.start),i.end=Math.max(i.end,n.end));return t}function tRe(s,e){return!(s.end<e.start||e.end<s.start)}function qH(s){return s.startsWith('"')&&s.endsWith('"')}const HY=" ";function MO(s){typeof s!="string"&&(s="");const e=s.toLowerCase(),{pathNormalized:t,normalized:i,normalizedLowercase:n}=GH(s),o=t.indexOf(xl)>=0,r=qH(s);let a;const l=s.split(HY);if(l.length>1)for(const d of l){const c=qH(d),{pathNormalized:u,normalized:h,normalizedLowercase:f}=GH(d);h&&(a||(a=[]),a.push({original:d,originalLowercase:d.toLowerCase(),pathNormalized:u,normalized:h,normalizedLowercase:f,expectContiguousMatch:c}))}return{original:s,originalLowercase:e,pathNormalized:t,normalized:i,normalizedLowercase:n,values:a,containsPathSeparator:o,expectContiguousMatch:r}}function GH(s){let e;Ls?e=s.replace(/\//g,xl):e=s.replace(/\\/g,xl);const t=ene(e).replace(/\s|"/g,"");return{pathNormalized:e,normalized:t,normalizedLowercase:t.toLowerCase()}}function ZH(s){return Array.isArray(s)?MO(s.map(e=>e.original).join(HY)):MO(s.original)}var iRe=function(s,e,t,i){var n=arguments.length,o=n<3?e:i===null?i=Object.getOwnPropertyDescriptor(e,t):i,r;if(typeof Reflect=="object"&&typeof Reflect.decorate=="function")o=Reflect.decorate(s,e,t,i);else for(var a=s.length-1;a>=0;a--)(r=s[a])&&(o=(n<3?r(o):n>3?r(e,t,o):r(e,t))||o);return n>3&&o&&Object.defineProperty(e,t,o),o},YH=function(s,e){return function(t,i){e(t,i,s)}},T_;let qd=T_=class extends OY{constructor(e,t,i=Object.create(null)){super(i),this._languageFeaturesService=e,this._outlineModelService=t,this.options=i,this.options.canAcceptInBackground=!0}provideWithoutTextEditor(e){return this.provideLabelPick(e,p("cannotRunGotoSymbolWithoutEditor","To go to a symbol, first open a text editor with symbol information.")),j.None}provideWithTextEditor(e,t,i){const n=e.editor,o=this.getModel(n);return o?this._languageFeaturesService.documentSymbolProvider.has(o)?this.doProvideWithEditorSymbols(e,o,t,i):this.doProvideWithoutEditorSymbols(e,o,t,i):j.None}doProvideWithoutEditorSymbols(e,t,i,n){const o=new se;return this.provideLabelPick(i,p("cannotRunGotoSymbolWithoutSymbolProvider","The active text editor does not provide symbol information.")),(async()=>!await this.waitForLanguageSymbolRegistry(t,o)||n.isCancellationRequested||o.add(this.doProvideWithEditorSymbols(e,t,i,n)))(),o}provideLabelPick(e,t){e.items=[{label:t,index:0,kind:14}],e.ariaLabel=t}async waitForLanguageSymbolRegistry(e,t){if(this._languageFeaturesService.documentSymbolProvider.has(e))return!0;const i=new aw,n=t.add(this._languageFeaturesService.documentSymbolProvider.onDidChange(()=>{this._languageFeaturesService.documentSymbolProvider.has(e)&&(n.dispose(),i.complete(!0))}));return t.add(ze(()=>i.complete(!1))),i.p}doProvideWithEditorSymbols(e,t,i,n){var o;const r=e.editor,a=new se;a.add(i.onDidAccept(u=>{const[h]=i.selectedItems;h&&h.range&&(this.gotoLocation(e,{range:h.range.selection,keyMods:i.keyMods,preserveFocus:u.inBackground}),u.inBackground||i.hide())})),a.add(i.onDidTriggerItemButton(({item:u})=>{u&&u.range&&(this.gotoLocation(e,{range:u.range.selection,keyMods:i.keyMods,forceSideBySide:!0}),i.hide())}));const l=this.getDocumentSymbols(t,n);let d;const c=async u=>{d==null||d.dispose(!0),i.busy=!1,d=new en(n),i.busy=!0;try{const h=MO(i.value.substr(T_.PREFIX.length).trim()),f=await this.doGetSymbolPicks(l,h,void 0,d.token);if(n.isCancellationRequested)return;if(f.length>0){if(i.items=f,u&&h.original.length===0){const g=DC(f,m=>!!(m.type!=="separator"&&m.range&&x.containsPosition(m.range.decoration,u)));g&&(i.activeItems=[g])}}else h.original.length>0?this.provideLabelPick(i,p("noMatchingSymbolResults","No matching editor symbols")):this.provideLabelPick(i,p("noSymbolResults","No editor symbols"))}finally{n.isCancellationRequested||(i.busy=!1)}};return a.add(i.onDidChangeValue(()=>c(void 0))),c((o=r.getSelection())===null||o===void 0?void 0:o.getPosition()),a.add(i.onDidChangeActive(()=>{const[u]=i.activeItems;u&&u.range&&(r.revealRangeInCenter(u.range.selection,0),this.addDecorations(r,u.range.decoration))})),a}async doGetSymbolPicks(e,t,i,n){var o,r;const a=await e;if(n.isCancellationRequested)return[];const l=t.original.indexOf(T_.SCOPE_PREFIX)===0,d=l?1:0;let c,u;t.values&&t.values.length>1?(c=ZH(t.values[0]),u=ZH(t.values.slice(1))):c=t;let h;const f=(r=(o=this.options)===null||o===void 0?void 0:o.openSideBySideDirection)===null||r===void 0?void 0:r.call(o);f&&(h=[{iconClass:f==="right"?Xe.asClassName(me.splitHorizontal):Xe.asClassName(me.splitVertical),tooltip:f==="right"?p("openToSide","Open to the Side"):p("openToBottom","Open to the Bottom")}]);const g=[];for(let v=0;v<a.length;v++){const b=a[v],C=Qie(b.name),w=`$(${HL.toIcon(b.kind).id}) ${C}`,S=w.length-C.length;let L=b.containerName;i!=null&&i.extraContainerLabel&&(L?L=`${i.extraContainerLabel} • ${L}`:L=i.extraContainerLabel);let D,I,T,V;if(t.original.length>d){let A=!1;if(c!==t&&([D,I]=BT(w,{...t,values:void 0},d,S),typeof D=="number"&&(A=!0)),typeof D!="number"&&([D,I]=BT(w,c,d,S),typeof D!="number"))continue;if(!A&&u){if(L&&u.original.length>0&&([T,V]=BT(L,u)),typeof T!="number")continue;typeof D=="number"&&(D+=T)}}const P=b.tags&&b.tags.indexOf(1)>=0;g.push({index:v,kind:b.kind,score:D,label:w,ariaLabel:Kie(b.name,b.kind),description:L,highlights:P?void 0:{label:I,description:V},range:{selection:x.collapseToStart(b.selectionRange),decoration:b.range},strikethrough:P,buttons:h})}const m=g.sort((v,b)=>l?this.compareByKindAndScore(v,b):this.compareByScore(v,b));let _=[];if(l){let w=function(){b&&typeof v=="number"&&C>0&&(b.label=Jg(HT[v]||WT,C))},v,b,C=0;for(const S of m)v!==S.kind?(w(),v=S.kind,C=1,b={type:"separator"},_.push(b)):C++,_.push(S);w()}else m.length>0&&(_=[{label:p("symbols","symbols ({0})",g.length),type:"separator"},...m]);return _}compareByScore(e,t){if(typeof e.score!="number"&&typeof t.score=="number")return 1;if(typeof e.score=="number"&&typeof t.score!="number")return-1;if(typeof e.score=="number"&&typeof t.score=="number"){if(e.score>t.score)return-1;if(e.score<t.score)return 1}return e.index<t.index?-1:e.index>t.index?1:0}compareByKindAndScore(e,t){const i=HT[e.kind]||WT,n=HT[t.kind]||WT,o=i.localeCompare(n);return o===0?this.compareByScore(e,t):o}async getDocumentSymbols(e,t){const i=await this._outlineModelService.getOrCreate(e,t);return t.isCancellationRequested?[]:i.asListOfDocumentSymbols()}};qd.PREFIX="@";qd.SCOPE_PREFIX=":";qd.PREFIX_BY_CATEGORY=`${T_.PREFIX}${T_.SCOPE_PREFIX}`;qd=T_=iRe([YH(0,Te),YH(1,Uw)],qd);const WT=p("property","properties ({0})"),HT={5:p("method","methods ({0})"),11:p("function","functions ({0})"),8:p("_constructor","constructors ({0})"),12:p("variable","variables ({0})"),4:p("class","classes ({0})"),22:p("struct","structs ({0})"),23:p("event","events ({0})"),24:p("operator","operators ({0})"),10:p("interface","interfaces ({0})"),2:p("namespace","namespaces ({0})"),3:p("package","packages ({0})"),25:p("typeParameter","type parameters ({0})"),1:p("modules","modules ({0})"),6:p("property","properties ({0})"),9:p("enum","enumerations ({0})"),21:p("enumMember","enumeration members ({0})"),14:p("string","strings ({0})"),0:p("file","files ({0})"),17:p("array","arrays ({0})"),15:p("number","numbers ({0})"),16:p("boolean","booleans ({0})"),18:p("object","objects ({0})"),19:p("key","keys ({0})"),7:p("field","fields ({0})"),13:p("constant","constants ({0})")};var nRe=function(s,e,t,i){var n=arguments.length,o=n<3?e:i===null?i=Object.getOwnPropertyDescriptor(e,t):i,r;if(typeof Reflect=="object"&&typeof Reflect.decorate=="function")o=Reflect.decorate(s,e,t,i);else for(var a=s.length-1;a>=0;a--)(r=s[a])&&(o=(n<3?r(o):n>3?r(e,t,o):r(e,t))||o);return n>3&&o&&Object.defineProperty(e,t,o),o},VT=function(s,e){return function(t,i){e(t,i,s)}};let RO=class extends qd{constructor(e,t,i){super(t,i),this.editorService=e,this.onDidActiveTextEditorControlChange=ve.None}get activeTextEditorControl(){var e;return(e=this.editorService.getFocusedCodeEditor())!==null&&e!==void 0?e:void 0}};RO=nRe([VT(0,Ot),VT(1,Te),VT(2,Uw)],RO);class Kw extends De{constructor(){super({id:Kw.ID,label:YC.quickOutlineActionLabel,alias:"Go to Symbol...",precondition:N.hasDocumentSymbolProvider,kbOpts:{kbExpr:N.focus,primary:3117,weight:100},contextMenuOpts:{group:"navigation",order:3}})}run(e){e.get(Yl).quickAccess.show(qd.PREFIX,{itemActivation:cl.NONE})}}Kw.ID="editor.action.quickOutline";ue(Kw);qi.as(zp.Quickaccess).registerQuickAccessProvider({ctor:RO,prefix:qd.PREFIX,helpEntries:[{description:YC.quickOutlineActionLabel,prefix:qd.PREFIX,commandId:Kw.ID},{description:YC.quickOutlineByCategoryActionLabel,prefix:qd.PREFIX_BY_CATEGORY}]});function zT(s,e){return e&&(s.stack||s.stacktrace)?p("stackTrace.format","{0}: {1}",QH(s),XH(s.stack)||XH(s.stacktrace)):QH(s)}function XH(s){return Array.isArray(s)?s.join(`
 `):s}function QH(s){return s.code==="ERR_UNC_HOST_NOT_ALLOWED"?`${s.message}. Please update the 'security.allowedUNCHosts' setting if you want to allow this host.`:typeof s.code=="string"&&typeof s.errno=="number"&&typeof s.syscall=="string"?p("nodeExceptionMessage","A system error occurred ({0})",s.message):s.message||p("error.defaultMessage","An unknown error occurred. Please consult the log for more details.")}function VY(s=null,e=!1){if(!s)return p("error.defaultMessage","An unknown error occurred. Please consult the log for more details.");if(Array.isArray(s)){const t=Al(s),i=VY(t[0],e);return t.length>1?p("error.moreErrors","{0} ({1} errors in total)",i,t.length):i}if(fo(s))return s;if(s.detail){const t=s.detail;if(t.error)return zT(t.error,e);if(t.exception)return zT(t.exception,e)}return s.stack?zT(s,e):s.message?s.message:p("error.defaultMessage","An unknown error occurred. Please consult the log for more details.")}function sRe(s){var e;const t=new Map;for(const i of s)t.set(i,((e=t.get(i))!==null&&e!==void 0?e:0)+1);return t}class nC{constructor(){this.chunkCount=0,this.chunkOccurrences=new Map,this.documents=new Map}calculateScores(e,t){const i=this.computeEmbedding(e),n=new Map,o=[];for(const[r,a]of this.documents){if(t.isCancellationRequested)return[];for(const l of a.chunks){const d=this.computeSimilarityScore(l,i,n);d>0&&o.push({key:r,score:d})}}return o}static termFrequencies(e){return sRe(nC.splitTerms(e))}static*splitTerms(e){const t=i=>i.toLowerCase();for(const[i]of e.matchAll(new RegExp("\\b\\p{Letter}[\\p{Letter}\\d]{2,}\\b","gu"))){yield t(i);const n=i.replace(/([a-z])([A-Z])/g,"$1 $2").split(/\s+/g);if(n.length>1)for(const o of n)o.length>2&&new RegExp("\\p{Letter}{3,}","gu").test(o)&&(yield t(o))}}updateDocuments(e){var t;for(const{key:i}of e)this.deleteDocument(i);for(const i of e){const n=[];for(const o of i.textChunks){const r=nC.termFrequencies(o);for(const a of r.keys())this.chunkOccurrences.set(a,((t=this.chunkOccurrences.get(a))!==null&&t!==void 0?t:0)+1);n.push({text:o,tf:r})}this.chunkCount+=n.length,this.documents.set(i.key,{chunks:n})}return this}deleteDocument(e){const t=this.documents.get(e);if(t){this.documents.delete(e),this.chunkCount-=t.chunks.length;for(const i of t.chunks)for(const n of i.tf.keys()){const o=this.chunkOccurrences.get(n);if(typeof o=="number"){const r=o-1;r<=0?this.chunkOccurrences.delete(n):this.chunkOccurrences.set(n,r)}}}}computeSimilarityScore(e,t,i){let n=0;for(const[o,r]of Object.entries(t)){const a=e.tf.get(o);if(!a)continue;let l=i.get(o);typeof l!="number"&&(l=this.computeIdf(o),i.set(o,l));const d=a*l;n+=d*r}return n}computeEmbedding(e){const t=nC.termFrequencies(e);return this.computeTfidf(t)}computeIdf(e){var t;const i=(t=this.chunkOccurrences.get(e))!==null&&t!==void 0?t:0;return i>0?Math.log((this.chunkCount+1)/i):0}computeTfidf(e){const t=Object.create(null);for(const[i,n]of e){const o=this.computeIdf(i);o>0&&(t[i]=n*o)}return t}}function oRe(s){var e,t;const i=s.slice(0);i.sort((o,r)=>r.score-o.score);const n=(t=(e=i[0])===null||e===void 0?void 0:e.score)!==null&&t!==void 0?t:0;if(n>0)for(const o of i)o.score/=n;return i}var e_;(function(s){s[s.NO_ACTION=0]="NO_ACTION",s[s.CLOSE_PICKER=1]="CLOSE_PICKER",s[s.REFRESH_PICKER=2]="REFRESH_PICKER",s[s.REMOVE_ITEM=3]="REMOVE_ITEM"})(e_||(e_={}));function UT(s){const e=s;return Array.isArray(e.items)}function JH(s){const e=s;return!!e.picks&&e.additionalPicks instanceof Promise}class rRe extends j{constructor(e,t){super(),this.prefix=e,this.options=t}provide(e,t,i){var n;const o=new se;e.canAcceptInBackground=!!(!((n=this.options)===null||n===void 0)&&n.canAcceptInBackground),e.matchOnLabel=e.matchOnDescription=e.matchOnDetail=e.sortByLabel=!1;let r;const a=o.add(new Zn),l=async()=>{var c;const u=a.value=new se;r==null||r.dispose(!0),e.busy=!1,r=new en(t);const h=r.token;let f=e.value.substring(this.prefix.length);!((c=this.options)===null||c===void 0)&&c.shouldSkipTrimPickFilter||(f=f.trim());const g=this._getPicks(f,u,h,i),m=(v,b)=>{var C;let w,S;if(UT(v)?(w=v.items,S=v.active):w=v,w.length===0){if(b)return!1;(f.length>0||e.hideInput)&&(!((C=this.options)===null||C===void 0)&&C.noResultsPick)&&(fC(this.options.noResultsPick)?w=[this.options.noResultsPick(f)]:w=[this.options.noResultsPick])}return e.items=w,S&&(e.activeItems=[S]),!0},_=async v=>{let b=!1,C=!1;await Promise.all([(async()=>{typeof v.mergeDelay=="number"&&(await ku(v.mergeDelay),h.isCancellationRequested)||C||(b=m(v.picks,!0))})(),(async()=>{e.busy=!0;try{const w=await v.additionalPicks;if(h.isCancellationRequested)return;let S,L;UT(v.picks)?(S=v.picks.items,L=v.picks.active):S=v.picks;let D,I;if(UT(w)?(D=w.items,I=w.active):D=w,D.length>0||!b){let T;if(!L&&!I){const V=e.activeItems[0];V&&S.indexOf(V)!==-1&&(T=V)}m({items:[...S,...D],active:L||I||T})}}finally{h.isCancellationRequested||(e.busy=!1),C=!0}})()])};if(g!==null)if(JH(g))await _(g);else if(!(g instanceof Promise))m(g);else{e.busy=!0;try{const v=await g;if(h.isCancellationRequested)return;JH(v)?await _(v):m(v)}finally{h.isCancellationRequested||(e.busy=!1)}}};o.add(e.onDidChangeValue(()=>l())),l(),o.add(e.onDidAccept(c=>{const[u]=e.selectedItems;typeof(u==null?void 0:u.accept)=="function"&&(c.inBackground||e.hide(),u.accept(e.keyMods,c))}));const d=async(c,u)=>{var h,f;if(typeof u.trigger!="function")return;const g=(f=(h=u.buttons)===null||h===void 0?void 0:h.indexOf(c))!==null&&f!==void 0?f:-1;if(g>=0){const m=u.trigger(g,e.keyMods),_=typeof m=="number"?m:await m;if(t.isCancellationRequested)return;switch(_){case e_.NO_ACTION:break;case e_.CLOSE_PICKER:e.hide();break;case e_.REFRESH_PICKER:l();break;case e_.REMOVE_ITEM:{const v=e.items.indexOf(u);if(v!==-1){const b=e.items.slice(),C=b.splice(v,1),w=e.activeItems.filter(L=>L!==C[0]),S=e.keepScrollPosition;e.keepScrollPosition=!0,e.items=b,w&&(e.activeItems=w),e.keepScrollPosition=S}break}}}};return o.add(e.onDidTriggerItemButton(({button:c,item:u})=>d(c,u))),o.add(e.onDidTriggerSeparatorButton(({button:c,separator:u})=>d(c,u))),o}}var zY=function(s,e,t,i){var n=arguments.length,o=n<3?e:i===null?i=Object.getOwnPropertyDescriptor(e,t):i,r;if(typeof Reflect=="object"&&typeof Reflect.decorate=="function")o=Reflect.decorate(s,e,t,i);else for(var a=s.length-1;a>=0;a--)(r=s[a])&&(o=(n<3?r(o):n>3?r(e,t,o):r(e,t))||o);return n>3&&o&&Object.defineProperty(e,t,o),o},bh=function(s,e){return function(t,i){e(t,i,s)}},hg,Nn;let Np=hg=class extends rRe{constructor(e,t,i,n,o,r){super(hg.PREFIX,e),this.instantiationService=t,this.keybindingService=i,this.commandService=n,this.telemetryService=o,this.dialogService=r,this.commandsHistory=this._register(this.instantiationService.createInstance(Ef)),this.options=e}async _getPicks(e,t,i,n){var o,r,a,l;const d=await this.getCommandPicks(i);if(i.isCancellationRequested)return[];const c=tf(()=>{const v=new nC;v.updateDocuments(d.map(C=>({key:C.commandId,textChunks:[this.getTfIdfChunk(C)]})));const b=v.calculateScores(e,i);return oRe(b).filter(C=>C.score>hg.TFIDF_THRESHOLD).slice(0,hg.TFIDF_MAX_RESULTS)}),u=[];for(const v of d){const b=(o=hg.WORD_FILTER(e,v.label))!==null&&o!==void 0?o:void 0,C=v.commandAlias&&(r=hg.WORD_FILTER(e,v.commandAlias))!==null&&r!==void 0?r:void 0;if(b||C)v.highlights={label:b,detail:this.options.showAlias?C:void 0},u.push(v);else if(e===v.commandId)u.push(v);else if(e.length>=3){const w=c();if(i.isCancellationRequested)return[];const S=w.find(L=>L.key===v.commandId);S&&(v.tfIdfScore=S.score,u.push(v))}}const h=new Map;for(const v of u){const b=h.get(v.label);b?(v.description=v.commandId,b.description=b.commandId):h.set(v.label,v)}u.sort((v,b)=>{if(v.tfIdfScore&&b.tfIdfScore)return v.tfIdfScore===b.tfIdfScore?v.label.localeCompare(b.label):b.tfIdfScore-v.tfIdfScore;if(v.tfIdfScore)return 1;if(b.tfIdfScore)return-1;const C=this.commandsHistory.peek(v.commandId),w=this.commandsHistory.peek(b.commandId);if(C&&w)return C>w?-1:1;if(C)return-1;if(w)return 1;if(this.options.suggestedCommandIds){const S=this.options.suggestedCommandIds.has(v.commandId),L=this.options.suggestedCommandIds.has(b.commandId);if(S&&L)return 0;if(S)return-1;if(L)return 1}return v.label.localeCompare(b.label)});const f=[];let g=!1,m=!0,_=!!this.options.suggestedCommandIds;for(let v=0;v<u.length;v++){const b=u[v];v===0&&this.commandsHistory.peek(b.commandId)&&(f.push({type:"separator",label:p("recentlyUsed","recently used")}),g=!0),m&&b.tfIdfScore!==void 0&&(f.push({type:"separator",label:p("suggested","similar commands")}),m=!1),_&&b.tfIdfScore===void 0&&!this.commandsHistory.peek(b.commandId)&&(!((a=this.options.suggestedCommandIds)===null||a===void 0)&&a.has(b.commandId))&&(f.push({type:"separator",label:p("commonlyUsed","commonly used")}),g=!0,_=!1),g&&b.tfIdfScore===void 0&&!this.commandsHistory.peek(b.commandId)&&!(!((l=this.options.suggestedCommandIds)===null||l===void 0)&&l.has(b.commandId))&&(f.push({type:"separator",label:p("morecCommands","other commands")}),g=!1),f.push(this.toCommandPick(b,n))}return this.hasAdditionalCommandPicks(e,i)?{picks:f,additionalPicks:(async()=>{var v;const b=await this.getAdditionalCommandPicks(d,u,e,i);if(i.isCancellationRequested)return[];const C=b.map(w=>this.toCommandPick(w,n));return m&&((v=C[0])===null||v===void 0?void 0:v.type)!=="separator"&&C.unshift({type:"separator",label:p("suggested","similar commands")}),C})()}:f}toCommandPick(e,t){if(e.type==="separator")return e;const i=this.keybindingService.lookupKeybinding(e.commandId),n=i?p("commandPickAriaLabelWithKeybinding","{0}, {1}",e.label,i.getAriaLabel()):e.label;return{...e,ariaLabel:n,detail:this.options.showAlias&&e.commandAlias!==e.label?e.commandAlias:void 0,keybinding:i,accept:async()=>{var o,r;this.commandsHistory.push(e.commandId),this.telemetryService.publicLog2("workbenchActionExecuted",{id:e.commandId,from:(o=t==null?void 0:t.from)!==null&&o!==void 0?o:"quick open"});try{!((r=e.args)===null||r===void 0)&&r.length?await this.commandService.executeCommand(e.commandId,...e.args):await this.commandService.executeCommand(e.commandId)}catch(a){$l(a)||this.dialogService.error(p("canNotRun","Command '{0}' resulted in an error",e.label),VY(a))}}}}getTfIdfChunk({label:e,commandAlias:t,commandDescription:i}){let n=e;return t&&t!==e&&(n+=` - ${t}`),i&&i.value!==e&&(n+=` - ${i.value===i.original?i.value:`${i.value} (${i.original})`}`),n}};Np.PREFIX=">";Np.TFIDF_THRESHOLD=.5;Np.TFIDF_MAX_RESULTS=5;Np.WORD_FILTER=z4(GC,Bpe,nK);Np=hg=zY([bh(1,$e),bh(2,$t),bh(3,Ii),bh(4,Os),bh(5,ww)],Np);let Ef=Nn=class extends j{constructor(e,t,i){super(),this.storageService=e,this.configurationService=t,this.logService=i,this.configuredCommandsHistoryLength=0,this.updateConfiguration(),this.load(),this.registerListeners()}registerListeners(){this._register(this.configurationService.onDidChangeConfiguration(e=>this.updateConfiguration(e))),this._register(this.storageService.onWillSaveState(e=>{e.reason===n1.SHUTDOWN&&this.saveState()}))}updateConfiguration(e){e&&!e.affectsConfiguration("workbench.commandPalette.history")||(this.configuredCommandsHistoryLength=Nn.getConfiguredCommandHistoryLength(this.configurationService),Nn.cache&&Nn.cache.limit!==this.configuredCommandsHistoryLength&&(Nn.cache.limit=this.configuredCommandsHistoryLength,Nn.hasChanges=!0))}load(){const e=this.storageService.get(Nn.PREF_KEY_CACHE,0);let t;if(e)try{t=JSON.parse(e)}catch(n){this.logService.error(`[CommandsHistory] invalid data: ${n}`)}const i=Nn.cache=new hc(this.configuredCommandsHistoryLength,1);if(t){let n;t.usesLRU?n=t.entries:n=t.entries.sort((o,r)=>o.value-r.value),n.forEach(o=>i.set(o.key,o.value))}Nn.counter=this.storageService.getNumber(Nn.PREF_KEY_COUNTER,0,Nn.counter)}push(e){Nn.cache&&(Nn.cache.set(e,Nn.counter++),Nn.hasChanges=!0)}peek(e){var t;return(t=Nn.cache)===null||t===void 0?void 0:t.peek(e)}saveState(){if(!Nn.cache||!Nn.hasChanges)return;const e={usesLRU:!0,entries:[]};Nn.cache.forEach((t,i)=>e.entries.push({key:i,value:t})),this.storageService.store(Nn.PREF_KEY_CACHE,JSON.stringify(e),0,0),this.storageService.store(Nn.PREF_KEY_COUNTER,Nn.counter,0,0),Nn.hasChanges=!1}static getConfiguredCommandHistoryLength(e){var t,i;const o=(i=(t=e.getValue().workbench)===null||t===void 0?void 0:t.commandPalette)===null||i===void 0?void 0:i.history;return typeof o=="number"?o:Nn.DEFAULT_COMMANDS_HISTORY_LENGTH}};Ef.DEFAULT_COMMANDS_HISTORY_LENGTH=50;Ef.PREF_KEY_CACHE="commandPalette.mru.cache";Ef.PREF_KEY_COUNTER="commandPalette.mru.counter";Ef.counter=1;Ef.hasChanges=!1;Ef=Nn=zY([bh(0,Xa),bh(1,vt),bh(2,Fs)],Ef);class aRe extends Np{constructor(e,t,i,n,o,r){super(e,t,i,n,o,r)}getCodeEditorCommandPicks(){var e;const t=this.activeTextEditorControl;if(!t)return[];const i=[];for(const n of t.getSupportedActions()){let o;!((e=n.metadata)===null||e===void 0)&&e.description&&(pve(n.metadata.description)?o=n.metadata.description:o={original:n.metadata.description,value:n.metadata.description}),i.push({commandId:n.id,commandAlias:n.alias,commandDescription:o,label:G4(n.label)||n.id})}return i}}var lRe=function(s,e,t,i){var n=arguments.length,o=n<3?e:i===null?i=Object.getOwnPropertyDescriptor(e,t):i,r;if(typeof Reflect=="object"&&typeof Reflect.decorate=="function")o=Reflect.decorate(s,e,t,i);else for(var a=s.length-1;a>=0;a--)(r=s[a])&&(o=(n<3?r(o):n>3?r(e,t,o):r(e,t))||o);return n>3&&o&&Object.defineProperty(e,t,o),o},vm=function(s,e){return function(t,i){e(t,i,s)}};let q1=class extends aRe{get activeTextEditorControl(){var e;return(e=this.codeEditorService.getFocusedCodeEditor())!==null&&e!==void 0?e:void 0}constructor(e,t,i,n,o,r){super({showAlias:!1},e,i,n,o,r),this.codeEditorService=t}async getCommandPicks(){return this.getCodeEditorCommandPicks()}hasAdditionalCommandPicks(){return!1}async getAdditionalCommandPicks(){return[]}};q1=lRe([vm(0,$e),vm(1,Ot),vm(2,$t),vm(3,Ii),vm(4,Os),vm(5,ww)],q1);class qw extends De{constructor(){super({id:qw.ID,label:Tx.quickCommandActionLabel,alias:"Command Palette",precondition:void 0,kbOpts:{kbExpr:N.focus,primary:59,weight:100},contextMenuOpts:{group:"z_commands",order:1}})}run(e){e.get(Yl).quickAccess.show(q1.PREFIX)}}qw.ID="editor.action.quickCommand";ue(qw);qi.as(zp.Quickaccess).registerQuickAccessProvider({ctor:q1,prefix:q1.PREFIX,helpEntries:[{description:Tx.quickCommandHelp,commandId:qw.ID}]});var dRe=function(s,e,t,i){var n=arguments.length,o=n<3?e:i===null?i=Object.getOwnPropertyDescriptor(e,t):i,r;if(typeof Reflect=="object"&&typeof Reflect.decorate=="function")o=Reflect.decorate(s,e,t,i);else for(var a=s.length-1;a>=0;a--)(r=s[a])&&(o=(n<3?r(o):n>3?r(e,t,o):r(e,t))||o);return n>3&&o&&Object.defineProperty(e,t,o),o},bm=function(s,e){return function(t,i){e(t,i,s)}};let AO=class extends yf{constructor(e,t,i,n,o,r,a){super(!0,e,t,i,n,o,r,a)}};AO=dRe([bm(1,Je),bm(2,Ot),bm(3,Cn),bm(4,$e),bm(5,Xa),bm(6,vt)],AO);Ft(yf.ID,AO,4);class cRe extends De{constructor(){super({id:"editor.action.toggleHighContrast",label:BA.toggleHighContrast,alias:"Toggle High Contrast Theme",precondition:void 0}),this._originalThemeName=null}run(e,t){const i=e.get(jr),n=i.getColorTheme();El(n.type)?(i.setTheme(this._originalThemeName||(G_(n.type)?I_:qc)),this._originalThemeName=null):(i.setTheme(G_(n.type)?Ug:$g),this._originalThemeName=n.themeName)}}ue(cRe);function uRe(s){return new Worker("/blog/admin/assets/editor.worker-iE_L6vXA.js",{name:s==null?void 0:s.name})}const hRe={class:"markdown-editor"},fRe={class:"markdown-tabs"},gRe={key:0,class:"markdown-toolbar"},pRe={class:"markdown-editor-body"},mRe=["innerHTML"],_Re={__name:"MarkdownEditor",props:{modelValue:{type:String,default:""},diffHighlight:{type:Object,default:null}},emits:["update:modelValue"],setup(s,{emit:e}){const t=s,i=e,n=ai(null),o=ai(null),r=ai("markdown"),a=Lg(()=>!!(t.diffHighlight&&typeof t.diffHighlight.previous=="string")),l=Lg(()=>kz.sanitize(Hi.parse(t.modelValue||"")));let d=null,c=null,u=null,h=!1,f=!1,g=null,m=!1;self.MonacoEnvironment||(self.MonacoEnvironment={getWorker:()=>new uRe});async function _(le){try{return(await Uee(le)).url}catch(oe){return alert("Failed to upload image: "+oe.message),null}}function v(le){if(!d)return;const oe=d.getModel();!oe||oe.getValue()===le||(h=!0,oe.setValue(le),h=!1)}function b(le){if(!d)return;const oe=d.getSelection();d.executeEdits("markdown-toolbar",[{range:oe,text:le,forceMoveMarkers:!0}]),d.focus()}function C(le,oe,Pe){if(!d)return;const de=d.getModel(),Ne=d.getSelection(),di=de.getValueInRange(Ne)||Pe,jt=`${le}${di}${oe}`;if(d.executeEdits("markdown-toolbar",[{range:Ne,text:jt,forceMoveMarkers:!0}]),Ne.startLineNumber===Ne.endLineNumber){const nt=Ne.startColumn+le.length,Ct=nt+di.length;d.setSelection(new Z2(Ne.startLineNumber,nt,Ne.endLineNumber,Ct))}d.focus()}function w(le){if(!d)return;d.getModel();const oe=d.getSelection(),Pe=oe.startLineNumber,de=oe.endLineNumber,Ne=[];for(let be=Pe;be<=de;be+=1)Ne.push({range:new Z2(be,1,be,1),text:le});d.executeEdits("markdown-toolbar",Ne),d.focus()}function S(){C("**","**","bold text")}function L(){C("*","*","italic text")}function D(){C("`","`","code")}function I(){C("```\n","\n```","code block")}function T(){w("# ")}function V(){w("> ")}function P(){w("- ")}function A(){w("1. ")}function B(){C("[","](https://)","link text")}async function Q(){if(!f){b("![](https://)");return}const le=document.createElement("input");le.type="file",le.accept="image/*",le.onchange=async()=>{const oe=le.files[0];if(!oe)return;const Pe=await _(oe);Pe&&b(`![${oe.name}](${Pe})`)},le.click()}async function ae(le){if(!f||!le.clipboardData)return;const oe=[];for(const Pe of le.clipboardData.items)if(Pe.type&&Pe.type.startsWith("image/")){const de=Pe.getAsFile();de&&oe.push(de)}if(oe.length!==0){le.preventDefault();for(const Pe of oe){const de=await _(Pe);de&&b(`![${Pe.name}](${de})
-`)}}}function we(le){le!==m&&(m=le,le?(g=oe=>ae(oe),window.addEventListener("paste",g,!0)):g&&(window.removeEventListener("paste",g,!0),g=null))}function Le(le){!c||!d||!le||typeof le.previous!="string"||(u?u.setValue(le.previous):u=o0.createModel(le.previous,"markdown"),c.setModel({original:u,modified:d.getModel()}))}return $D(async()=>{try{f=await zee()}catch{f=!1}const le=o0.createModel(t.modelValue||"","markdown");d=o0.create(n.value,{model:le,language:"markdown",wordWrap:"on",lineNumbers:"on",minimap:{enabled:!1},scrollBeyondLastLine:!1,fontSize:14,fontFamily:'Menlo, Monaco, "Courier New", monospace',padding:{top:12,bottom:12},automaticLayout:!0}),d.onDidChangeModelContent(()=>{h||i("update:modelValue",d.getValue())}),d.onDidFocusEditorText(()=>{r.value==="markdown"&&we(!0)}),d.onDidBlurEditorText(()=>{we(!1)}),c=o0.createDiffEditor(o.value,{readOnly:!0,renderSideBySide:!0,minimap:{enabled:!1},scrollBeyondLastLine:!1,wordWrap:"on",automaticLayout:!0}),Le(t.diffHighlight)}),Cl(()=>t.modelValue,le=>{v(le||"")}),Cl(()=>t.diffHighlight,le=>{if(!le||typeof le.previous!="string"){r.value==="diff"&&(r.value="markdown");return}Le(le)},{deep:!0}),Cl(a,le=>{!le&&r.value==="diff"&&(r.value="markdown")}),Cl(r,async()=>{await ZO(),r.value==="markdown"&&d&&(d.layout(),d.focus(),we(!0)),r.value==="diff"&&c&&c.layout(),r.value!=="markdown"&&we(!1)}),XO(()=>{d&&(we(!1),d.dispose(),d=null),c&&(c.dispose(),c=null),u&&(u.dispose(),u=null)}),(le,oe)=>(Kt(),Zt("div",hRe,[F("div",fRe,[F("button",{type:"button",class:ei(["markdown-tab",r.value==="markdown"?"is-active":""]),onClick:oe[0]||(oe[0]=Pe=>r.value="markdown")}," Markdown ",2),F("button",{type:"button",class:ei(["markdown-tab",r.value==="preview"?"is-active":""]),onClick:oe[1]||(oe[1]=Pe=>r.value="preview")}," Preview ",2),a.value?(Kt(),Zt("button",{key:0,type:"button",class:ei(["markdown-tab",r.value==="diff"?"is-active":""]),onClick:oe[2]||(oe[2]=Pe=>r.value="diff")}," Diff ",2)):ms("",!0)]),r.value==="markdown"?(Kt(),Zt("div",gRe,[F("button",{type:"button",class:"tool",title:"Bold",onClick:S},[...oe[3]||(oe[3]=[F("i",{class:"ph ph-text-b"},null,-1)])]),F("button",{type:"button",class:"tool",title:"Italic",onClick:L},[...oe[4]||(oe[4]=[F("i",{class:"ph ph-text-italic"},null,-1)])]),F("button",{type:"button",class:"tool",title:"Heading",onClick:T},[...oe[5]||(oe[5]=[F("i",{class:"ph ph-text-h"},null,-1)])]),oe[13]||(oe[13]=F("span",{class:"tool-divider"},null,-1)),F("button",{type:"button",class:"tool",title:"Quote",onClick:V},[...oe[6]||(oe[6]=[F("i",{class:"ph ph-quotes"},null,-1)])]),F("button",{type:"button",class:"tool",title:"Bulleted List",onClick:P},[...oe[7]||(oe[7]=[F("i",{class:"ph ph-list-bullets"},null,-1)])]),F("button",{type:"button",class:"tool",title:"Numbered List",onClick:A},[...oe[8]||(oe[8]=[F("i",{class:"ph ph-list-numbers"},null,-1)])]),oe[14]||(oe[14]=F("span",{class:"tool-divider"},null,-1)),F("button",{type:"button",class:"tool",title:"Link",onClick:B},[...oe[9]||(oe[9]=[F("i",{class:"ph ph-link"},null,-1)])]),F("button",{type:"button",class:"tool",title:"Inline Code",onClick:D},[...oe[10]||(oe[10]=[F("i",{class:"ph ph-code"},null,-1)])]),F("button",{type:"button",class:"tool",title:"Code Block",onClick:I},[...oe[11]||(oe[11]=[F("i",{class:"ph ph-code-block"},null,-1)])]),F("button",{type:"button",class:"tool",title:"Image",onClick:Q},[...oe[12]||(oe[12]=[F("i",{class:"ph ph-image"},null,-1)])])])):ms("",!0),F("div",pRe,[Ui(F("div",{ref_key:"editorContainer",ref:n,class:"monaco-editor-root"},null,512),[[xE,r.value==="markdown"]]),Ui(F("div",{class:"markdown-preview markdown-body",innerHTML:l.value},null,8,mRe),[[xE,r.value==="preview"]]),Ui(F("div",{ref_key:"diffContainer",ref:o,class:"monaco-diff-root"},null,512),[[xE,r.value==="diff"]])])]))}},vRe={class:"bg-surface text-slate-800 antialiased h-screen overflow-hidden selection:bg-brand-100 selection:text-brand-900"},bRe={class:"h-full flex flex-col md:flex-row relative"},CRe={class:"md:hidden flex items-center justify-between p-4 glass-panel sticky top-0 z-20"},wRe={class:"flex-1 px-4 space-y-1 overflow-y-auto"},SRe={class:"flex-1 overflow-hidden relative flex flex-col bg-slate-50/50"},yRe={key:0,class:"fixed top-4 right-4 z-50 toast"},LRe={class:"text-sm font-medium"},xRe={key:1,class:"h-full flex flex-col"},DRe={class:"px-4 md:px-8 pb-6"},kRe={class:"flex flex-col sm:flex-row gap-3"},IRe={class:"relative flex-1"},ERe={class:"flex gap-2 overflow-x-auto pb-1 sm:pb-0"},NRe={key:0,class:"flex-1 flex items-center justify-center"},TRe={key:1,class:"flex-1 overflow-y-auto px-4 md:px-8 pb-8"},MRe={key:0,class:"flex flex-col items-center justify-center h-64 text-slate-400"},RRe={class:"flex flex-col md:flex-row gap-4 items-start md:items-center"},ARe={class:"flex-1 min-w-0"},PRe={class:"flex items-center gap-2 mb-1"},ORe=["onClick"],FRe={class:"flex flex-wrap items-center gap-x-4 gap-y-2 text-xs text-slate-500 font-medium"},BRe={class:"flex items-center gap-1"},WRe={class:"flex items-center gap-1 font-mono text-slate-400 bg-slate-50 px-1.5 py-0.5 rounded"},HRe={class:"flex items-center gap-2 w-full md:w-auto mt-2 md:mt-0 pt-3 md:pt-0 border-t md:border-t-0 border-slate-100"},VRe=["href"],zRe=["onClick"],URe=["onClick"],$Re={key:2,class:"h-full flex flex-col bg-white"},jRe={class:"h-16 border-b border-slate-200 flex items-center justify-between px-4 md:px-6 bg-white z-10"},KRe={class:"flex items-center gap-3"},qRe={class:"text-sm font-semibold text-slate-500 hidden md:block"},GRe={class:"flex items-center gap-3"},ZRe={class:"flex items-center gap-2 mr-2"},YRe=["disabled"],XRe={key:0,class:"ph ph-warning-circle text-lg"},QRe={key:1,class:"ph ph-spinner animate-spin text-lg"},JRe={class:"flex-1 flex overflow-hidden"},eAe={class:"flex-1 flex flex-col h-full overflow-hidden relative"},tAe={class:"border-b border-slate-100 p-4 md:px-8 md:pt-8 md:pb-4 space-y-4 bg-white"},iAe={class:"grid grid-cols-1 md:grid-cols-2 gap-4"},nAe={class:"flex items-center gap-2 text-slate-500 border-b border-slate-100 pb-1 focus-within:border-brand-500 transition-colors"},sAe={class:"flex items-center gap-2 text-slate-500 border-b border-slate-100 pb-1 focus-within:border-brand-500 transition-colors"},oAe={class:"flex-1 flex overflow-hidden"},rAe={class:"flex-1 flex flex-col h-full relative"},aAe={class:"w-72 border-l border-slate-200 bg-white hidden lg:flex flex-col overflow-y-auto"},lAe={class:"p-4 space-y-6"},dAe={class:"space-y-2"},cAe={class:"text-xs font-semibold text-slate-500 uppercase"},uAe={key:0,class:"space-y-2"},hAe={class:"flex flex-wrap gap-2"},fAe={class:"space-y-3 border-t border-slate-100 pt-4"},gAe={class:"flex items-center justify-between"},pAe={key:0,class:"text-[11px] text-emerald-600 font-semibold"},mAe={key:1,class:"text-[11px] text-slate-400 font-semibold"},_Ae={key:0,class:"space-y-3"},vAe={class:"flex items-center gap-2"},bAe=["disabled"],CAe={class:"flex items-center gap-2 text-[11px] text-slate-500"},wAe={class:"flex items-center gap-2 text-[11px] text-slate-500"},SAe=["disabled"],yAe={key:0,class:"text-xs text-slate-600 bg-slate-50 border border-slate-100 rounded-lg p-2"},LAe={key:1,class:"text-xs text-slate-500"},xAe={key:3,class:"h-full flex flex-col overflow-y-auto"},DAe={class:"p-4 md:p-8 pb-4 flex flex-col sm:flex-row sm:items-center justify-between gap-4"},kAe=["disabled"],IAe={key:0,class:"ph ph-spinner animate-spin"},EAe={class:"px-4 md:px-8 pb-8 space-y-6"},NAe={key:0,class:"flex items-center gap-2 text-slate-500"},TAe={key:1,class:"grid grid-cols-1 lg:grid-cols-2 gap-6"},MAe={class:"bg-white border border-slate-200/60 rounded-2xl p-5 shadow-sm space-y-4"},RAe={class:"flex items-center justify-between"},AAe={class:"space-y-2"},PAe={class:"space-y-2"},OAe={class:"space-y-2"},FAe={class:"space-y-2"},BAe={class:"grid grid-cols-2 gap-3"},WAe={class:"space-y-2"},HAe={class:"space-y-2"},VAe={class:"bg-white border border-slate-200/60 rounded-2xl p-5 shadow-sm space-y-4"},zAe={class:"flex items-center justify-between"},UAe={class:"space-y-2"},$Ae={class:"space-y-2"},jAe={class:"space-y-2"},KAe={class:"space-y-2"},qAe={class:"grid grid-cols-2 gap-3"},GAe={class:"space-y-2"},ZAe={class:"space-y-2"},YAe={key:4,class:"h-full flex flex-col overflow-y-auto"},XAe={class:"p-4 md:p-8 pb-4 flex flex-col sm:flex-row sm:items-center justify-between gap-4"},QAe=["disabled"],JAe={key:0,class:"ph ph-spinner animate-spin"},e2e={class:"px-4 md:px-8 pb-8 space-y-6"},t2e={class:"bg-white border border-slate-200/60 rounded-2xl p-5 shadow-sm space-y-4"},i2e={class:"bg-white border border-slate-200/60 rounded-2xl p-5 shadow-sm"},n2e={class:"flex items-center justify-between gap-4"},s2e={class:"bg-white border border-slate-200/60 rounded-2xl p-5 shadow-sm space-y-4"},o2e={class:"flex items-center justify-between gap-4"},r2e={class:"flex flex-col md:flex-row md:items-center md:justify-between gap-3"},a2e={class:"text-xs text-slate-500"},l2e={class:"font-semibold text-slate-700"},d2e={class:"font-semibold text-slate-700"},c2e={class:"flex gap-2"},u2e=["disabled"],h2e=["disabled"],f2e={class:"grid grid-cols-1 md:grid-cols-2 gap-3"},g2e={class:"space-y-1"},p2e={class:"space-y-1"},m2e={class:"space-y-1"},_2e={class:"bg-white border border-slate-200/60 rounded-2xl p-5 shadow-sm"},v2e={class:"flex flex-col md:flex-row md:items-center md:justify-between gap-4"},b2e={class:"bg-white border border-slate-200/60 rounded-2xl p-5 shadow-sm space-y-4"},C2e={class:"flex flex-col md:flex-row md:items-center md:justify-between gap-3"},w2e={class:"flex flex-wrap gap-2"},S2e=["onClick"],y2e={key:0,class:"flex items-center gap-2 text-slate-500"},L2e={key:1,class:"text-sm text-slate-500"},x2e={key:2,class:"space-y-3"},D2e={class:"flex flex-col md:flex-row md:items-center md:justify-between gap-2"},k2e={class:"text-sm font-semibold text-slate-900"},I2e={class:"text-xs text-slate-500"},E2e={class:"text-sm text-slate-700 mt-3 whitespace-pre-wrap"},N2e={key:0,class:"text-xs text-rose-600 mt-2"},T2e={class:"flex flex-wrap gap-2 mt-4"},M2e=["onClick"],R2e=["onClick"],A2e=["onClick"],P2e=["onClick"],O2e={key:5,class:"h-full flex flex-col overflow-y-auto"},F2e={class:"p-4 md:p-8 pb-4 flex flex-col sm:flex-row sm:items-center justify-between gap-4"},B2e=["disabled"],W2e={key:0,class:"ph ph-spinner animate-spin"},H2e={class:"px-4 md:px-8 pb-8 space-y-6"},V2e={class:"bg-white border border-slate-200/60 rounded-2xl p-5 shadow-sm space-y-3"},z2e=["disabled"],U2e={class:"bg-white border border-slate-200/60 rounded-2xl p-5 shadow-sm space-y-4"},$2e={class:"flex flex-col md:flex-row md:items-center gap-3"},j2e=["disabled"],K2e={key:0,class:"ph ph-spinner animate-spin"},q2e={key:0,class:"text-sm text-slate-600 bg-slate-50 border border-slate-200/60 rounded-xl p-4"},G2e={__name:"App",setup(s){const e=ai(!1),t=ai("list"),i=ai(""),n=ai("all"),o=ai(window.innerWidth),r=ai(!1),a=ai(!1),l=ai([]),d=ai(""),c=ai(Yn()),u=ai({smart:!1,dumb:!1}),h=ai(!1),f=ai(!1),g=ai("smart"),m=ai(""),_=ai(!1),v=ai(""),b=ai(!1),C=ai(!0),w=ai(null),S=ai({comments_enabled:!0,notifications_enabled:!1,vapid_public_key:"",vapid_private_key:"",vapid_subscriber:"",date_display:"absolute",title:"",description:"",google_analytics_code:""}),L=ai(!1),D=ai(!1),I=ai({supported:!1,notifications_enabled:!1,public_key:"",private_key:"",subscriber:""}),T=ai(typeof Notification<"u"?Notification.permission:"default"),V=ai(!1),P=ai(!1),A=ai([]),B=ai(!1),Q=ai("pending"),ae=ai(null),we=ai(!1),Le=ai(!1),le=ai(null),oe=[{key:"pending",label:"Pending"},{key:"rejected",label:"Rejected"},{key:"approved",label:"Approved"},{key:"hidden",label:"Hidden"},{key:"all",label:"All"}],Pe=ai({show:!1,message:"",type:"success"}),de=ai({id:null,title:"",subtitle:"",slug:"",date:"",published:!1,publishedAt:null,description:"",content:"",tags:[]}),Ne=Lg(()=>!de.value.id),be=Lg(()=>JSON.stringify(de.value)!==d.value),di=Lg(()=>l.value.filter(J=>{const O=(J.title||"").toLowerCase().includes(i.value.toLowerCase())||(J.slug||"").toLowerCase().includes(i.value.toLowerCase());return n.value==="all"?O:n.value==="published"?O&&J.published_at:n.value==="draft"?O&&!J.published_at:O})),jt=Lg(()=>{const J=de.value.description.length;return J>160?"text-rose-500 font-bold":J>140?"text-amber-500":"text-slate-400"});function nt(J,O="success"){Pe.value={show:!0,message:J,type:O},setTimeout(()=>{Pe.value.show=!1},3e3)}const Ct=J=>J?new Date(J).toLocaleDateString("en-US",{month:"short",day:"numeric",year:"numeric"}):"Draft";function Qt(J=new Date){const O=J.getFullYear(),ce=String(J.getMonth()+1).padStart(2,"0"),ri=String(J.getDate()).padStart(2,"0");return`${O}-${ce}-${ri}`}function wn(J){if(!J)return Qt();const O=new Date(J);return Number.isNaN(O.getTime())?Qt():Qt(O)}function Ai(J,O){return!J||!O?!1:J===wn(O)}function rt(J){const O=Qt();if(!J||J===O)return new Date().toISOString();const[ce,ri,kn]=J.split("-").map(Number);return!ce||!ri||!kn?new Date().toISOString():new Date(ce,ri-1,kn,12,0,0,0).toISOString()}async function K(){r.value=!0;try{l.value=await Dee()}catch(J){nt("Failed to load posts: "+J.message,"error")}finally{r.value=!1}}async function ee(){h.value=!0;try{const J=await Fee();c.value=J!=null&&J.settings?Ht(J.settings):Yn(),u.value={smart:!!(J!=null&&J.smart_enabled),dumb:!!(J!=null&&J.dumb_enabled)},!u.value.dumb&&g.value==="dumb"&&(g.value="smart")}catch(J){nt("Failed to load AI settings: "+J.message,"error")}finally{h.value=!1}}async function _e(){f.value=!0;try{const J=Ht(c.value),O=await Bee(J);c.value=Ht((O==null?void 0:O.settings)||J),u.value={smart:!!(O!=null&&O.smart_enabled),dumb:!!(O!=null&&O.dumb_enabled)},!u.value.dumb&&g.value==="dumb"&&(g.value="smart"),nt("AI settings saved")}catch(J){nt("Failed to save AI settings: "+J.message,"error")}finally{f.value=!1}}async function Ee(){L.value=!0;try{const J=await Nee();S.value=J||{comments_enabled:!0,notifications_enabled:!1,vapid_public_key:"",vapid_private_key:"",vapid_subscriber:"",date_display:"absolute",title:"",description:"",google_analytics_code:""},await Ie()}catch(J){nt("Failed to load blog settings: "+J.message,"error")}finally{L.value=!1}}async function xe(){D.value=!0;try{const J=await Tee({comments_enabled:!!S.value.comments_enabled,notifications_enabled:!!S.value.notifications_enabled,vapid_public_key:S.value.vapid_public_key||"",vapid_private_key:S.value.vapid_private_key||"",vapid_subscriber:S.value.vapid_subscriber||"",date_display:S.value.date_display||"absolute",title:S.value.title||"",description:S.value.description||"",google_analytics_code:S.value.google_analytics_code||""});S.value=J||S.value,nt("Blog settings saved")}catch(J){nt("Failed to save blog settings: "+J.message,"error")}finally{D.value=!1}}async function Ie(){var J,O,ce;try{I.value=await OB(),(J=I.value)!=null&&J.public_key&&(S.value.vapid_public_key=I.value.public_key),(O=I.value)!=null&&O.private_key&&(S.value.vapid_private_key=I.value.private_key),(ce=I.value)!=null&&ce.subscriber&&(S.value.vapid_subscriber=I.value.subscriber)}catch{I.value={supported:!1,notifications_enabled:!1,public_key:"",private_key:"",subscriber:""}}await Ke()}async function Ke(){if(T.value=typeof Notification<"u"?Notification.permission:"default",V.value=!1,!(!("serviceWorker"in navigator)||!("PushManager"in window)))try{const J=await navigator.serviceWorker.getRegistration("/blog/admin/");if(!J)return;const O=await J.pushManager.getSubscription();V.value=!!O}catch{V.value=!1}}async function Ue(){if(!S.value.notifications_enabled){nt("Enable Notifications in settings first","error");return}P.value=!0;try{const J=await OB();if(I.value=J||{supported:!1,notifications_enabled:!1,public_key:"",private_key:"",subscriber:""},!(J!=null&&J.supported)||!(J!=null&&J.public_key))throw new Error("Push notifications are not configured on this server");if(!(J!=null&&J.notifications_enabled))throw new Error("Save settings after enabling notifications, then try again");if(!("serviceWorker"in navigator)||!("PushManager"in window)||typeof Notification>"u")throw new Error("This browser does not support push notifications");const O=await Notification.requestPermission();if(T.value=O,O!=="granted")throw new Error("Notification permission was not granted");const ce="/blog/admin/",ri=await navigator.serviceWorker.register(`${ce}sw.js`,{scope:ce});let kn=await ri.pushManager.getSubscription();kn||(kn=await ri.pushManager.subscribe({userVisibleOnly:!0,applicationServerKey:zi(J.public_key)})),await Pee(kn.toJSON()),V.value=!0,nt("Notifications enabled for this browser")}catch(J){nt("Failed to enable notifications: "+J.message,"error")}finally{P.value=!1}}async function G(){P.value=!0;try{if(!("serviceWorker"in navigator)||!("PushManager"in window)){V.value=!1;return}const J=await navigator.serviceWorker.getRegistration("/blog/admin/");if(!J){V.value=!1;return}const O=await J.pushManager.getSubscription();if(!O){V.value=!1;return}const ce=O.endpoint;await O.unsubscribe(),await Oee(ce),V.value=!1,nt("Notifications disabled for this browser")}catch(J){nt("Failed to disable notifications: "+J.message,"error")}finally{P.value=!1}}async function W(){B.value=!0;try{const J=Q.value==="all"?"":Q.value;A.value=await Mee(J?{status:J}:{})}catch(J){nt("Failed to load comments: "+J.message,"error")}finally{B.value=!1}}function q(J){switch(J){case"approved":return"bg-emerald-50 text-emerald-700";case"pending":return"bg-amber-50 text-amber-700";case"rejected":return"bg-rose-50 text-rose-700";case"hidden":return"bg-slate-100 text-slate-600";default:return"bg-slate-100 text-slate-600"}}async function E(J,O){try{await Ree(J.id,O),await W(),nt("Comment updated")}catch(ce){nt("Failed to update comment: "+ce.message,"error")}}async function R(J){if(confirm("Delete this comment?"))try{await Aee(J.id),await W(),nt("Comment deleted")}catch(O){nt("Failed to delete comment: "+O.message,"error")}}function $(){c.value.dumb={...c.value.smart}}function H(J){var ce,ri;const O=((ri=(ce=J.target)==null?void 0:ce.files)==null?void 0:ri[0])||null;ae.value=O,le.value=null}async function te(){we.value=!0;try{const J=await Hee(),O=URL.createObjectURL(J),ce=document.createElement("a");ce.href=O,ce.download=`blog-export-${new Date().toISOString().slice(0,10)}.xml`,document.body.appendChild(ce),ce.click(),ce.remove(),URL.revokeObjectURL(O),nt("Export downloaded")}catch(J){nt("Failed to export: "+J.message,"error")}finally{we.value=!1}}async function pe(){if(!ae.value){nt("Select a WXR file to import","error");return}Le.value=!0;try{const J=await Vee(ae.value);le.value=J,ae.value=null,await K(),nt("Import completed")}catch(J){nt("Failed to import: "+J.message,"error")}finally{Le.value=!1}}async function Me(){if(m.value.trim()){if(g.value==="dumb"&&!u.value.dumb){nt("Dumb AI is not configured","error");return}if(!u.value.smart&&g.value==="smart"){nt("Smart AI is not configured","error");return}_.value=!0,v.value="";try{const J=await Wee({mode:g.value,content_markdown:de.value.content,query:m.value,web_search:b.value}),O=(J==null?void 0:J.content_markdown)||"";if(!O){nt("AI response was empty","error");return}const ce=de.value.content;de.value.content=O,v.value=(J==null?void 0:J.notes)||"",C.value?w.value={previous:ce,current:O,nonce:Date.now()}:w.value=null,m.value="",nt("AI changes applied")}catch(J){nt("AI request failed: "+J.message,"error")}finally{_.value=!1}}}const Oe=()=>{de.value={id:null,title:"",subtitle:"",slug:"",date:Qt(),published:!1,publishedAt:null,description:"",content:"",tags:[]},v.value="",m.value="",w.value=null;const J=localStorage.getItem("autosave_new");if(J)try{const O=JSON.parse(J);confirm("Found an unsaved new post. Do you want to restore it?")?de.value=O:localStorage.removeItem("autosave_new")}catch(O){console.error(O)}d.value=JSON.stringify(de.value),t.value="editor"},tt=J=>{const O={id:J.id,title:J.title||"",subtitle:J.subtitle||"",slug:J.slug||"",date:J.published_at?wn(J.published_at):Qt(),published:!!J.published_at,publishedAt:J.published_at||null,description:J.meta_description||"",content:J.content_markdown||"",tags:J.tags||[]},ce=`autosave_${J.id}`,ri=localStorage.getItem(ce);if(ri)try{const kn=JSON.parse(ri);JSON.stringify(kn)!==JSON.stringify(O)&&confirm(`Found unsaved changes for this post from ${new Date().toLocaleDateString()}. Restore them?`)?de.value=kn:(de.value=O,localStorage.removeItem(ce))}catch{de.value=O}else de.value=O;d.value=JSON.stringify(de.value),t.value="editor",m.value="",v.value="",w.value=null};async function et(){if(!de.value.title){nt("Title is required","error");return}if(!de.value.slug){nt("Slug is required","error");return}a.value=!0;try{let J=null;de.value.published&&(de.value.publishedAt&&Ai(de.value.date,de.value.publishedAt)?J=de.value.publishedAt:J=rt(de.value.date));const O={title:de.value.title,subtitle:de.value.subtitle,slug:de.value.slug,content_markdown:de.value.content,content_html:kz.sanitize(Hi.parse(de.value.content||"")),meta_description:de.value.description,published_at:J,author_id:1};de.value.id?(await Iee(de.value.id,O),nt("Post updated successfully!"),localStorage.removeItem(`autosave_${de.value.id}`)):(await kee(O),nt("Post created successfully!"),localStorage.removeItem("autosave_new")),await K(),t.value="list"}catch(J){nt("Failed to save: "+J.message,"error")}finally{a.value=!1}}async function Bt(J){if(confirm(`Delete post "${J.title||J.slug}"?`))try{await Eee(J.id),await K(),nt("Post deleted successfully!")}catch(O){nt("Failed to delete: "+O.message,"error")}}const Vt=()=>{be.value&&!confirm("You have unsaved changes. Are you sure you want to leave?")||(t.value="list")},Be=J=>J.toString().toLowerCase().replace(/\s+/g,"-").replace(/[^\w\-]+/g,"").replace(/\-\-+/g,"-").replace(/^-+/,"").replace(/-+$/,""),Wt=()=>{(Ne.value||!de.value.slug)&&(de.value.slug=Be(de.value.title))},ci=()=>{o.value=window.innerWidth};$D(()=>{window.addEventListener("resize",ci),Hi.setOptions({breaks:!0,gfm:!0}),K(),ee();const J=new URLSearchParams(window.location.search).get("view");(J==="comments"||J==="list"||J==="ai-settings"||J==="wxr")&&(t.value=J)}),Cl(Q,()=>{t.value==="comments"&&W()}),Cl(t,J=>{J==="comments"&&(Ee(),W())}),Cl(de,J=>{if(t.value==="editor"){const O=J.id?`autosave_${J.id}`:"autosave_new";JSON.stringify(J)!==d.value&&localStorage.setItem(O,JSON.stringify(J))}},{deep:!0}),Cl(C,J=>{J||(w.value=null)}),QO(()=>{window.removeEventListener("resize",ci)});function Yn(){return{smart:{provider:"",model:"",api_key:"",base_url:"",temperature:null,max_tokens:null},dumb:{provider:"",model:"",api_key:"",base_url:"",temperature:null,max_tokens:null}}}function Ht(J){var vi,yo,ya,pc,Ys,Qa,Ja,mc,Uo,$o,jo,$u;const O=Number.isFinite((vi=J==null?void 0:J.smart)==null?void 0:vi.temperature)?J.smart.temperature:null,ce=Number.isFinite((yo=J==null?void 0:J.smart)==null?void 0:yo.max_tokens)?J.smart.max_tokens:null,ri=Number.isFinite((ya=J==null?void 0:J.dumb)==null?void 0:ya.temperature)?J.dumb.temperature:null,kn=Number.isFinite((pc=J==null?void 0:J.dumb)==null?void 0:pc.max_tokens)?J.dumb.max_tokens:null;return{smart:{provider:((Ys=J==null?void 0:J.smart)==null?void 0:Ys.provider)||"",model:((Qa=J==null?void 0:J.smart)==null?void 0:Qa.model)||"",api_key:((Ja=J==null?void 0:J.smart)==null?void 0:Ja.api_key)||"",base_url:((mc=J==null?void 0:J.smart)==null?void 0:mc.base_url)||"",temperature:O,max_tokens:ce},dumb:{provider:((Uo=J==null?void 0:J.dumb)==null?void 0:Uo.provider)||"",model:(($o=J==null?void 0:J.dumb)==null?void 0:$o.model)||"",api_key:((jo=J==null?void 0:J.dumb)==null?void 0:jo.api_key)||"",base_url:(($u=J==null?void 0:J.dumb)==null?void 0:$u.base_url)||"",temperature:ri,max_tokens:kn}}}function zi(J){const O="=".repeat((4-J.length%4)%4),ce=(J+O).replace(/-/g,"+").replace(/_/g,"/"),ri=window.atob(ce),kn=new Uint8Array(ri.length);for(let vi=0;vi<ri.length;vi+=1)kn[vi]=ri.charCodeAt(vi);return kn}return(J,O)=>(Kt(),Zt("div",vRe,[F("div",bRe,[F("header",CRe,[O[44]||(O[44]=F("div",{class:"flex items-center gap-2"},[F("div",{class:"w-8 h-8 rounded-lg bg-brand-600 flex items-center justify-center text-white"},[F("i",{class:"ph ph-hexagon text-xl"})]),F("span",{class:"font-bold text-lg tracking-tight"},"Blog Admin")],-1)),F("button",{onClick:O[0]||(O[0]=ce=>e.value=!e.value),class:"p-2 text-slate-600 hover:bg-slate-100 rounded-full transition-colors"},[...O[43]||(O[43]=[F("i",{class:"ph ph-list text-2xl"},null,-1)])])]),F("aside",{class:ei(["fixed md:static inset-y-0 left-0 z-30 w-64 bg-white/80 md:bg-white/50 backdrop-blur-xl border-r border-slate-200/60 transform transition-transform duration-300 ease-in-out flex flex-col",e.value?"translate-x-0":"-translate-x-full md:translate-x-0"])},[O[51]||(O[51]=tS('<div class="p-6 hidden md:flex items-center gap-3 mb-6"><div class="w-10 h-10 rounded-xl bg-gradient-to-br from-brand-500 to-indigo-600 flex items-center justify-center text-white shadow-lg shadow-brand-500/30"><i class="ph ph-hexagon-fill text-2xl"></i></div><div><h1 class="font-bold text-xl tracking-tight text-slate-900">Blog</h1><p class="text-xs text-slate-500 font-medium">Admin Panel</p></div></div>',1)),F("nav",wRe,[O[50]||(O[50]=F("p",{class:"px-2 text-xs font-semibold text-slate-400 uppercase tracking-wider mb-2 mt-4"},"Content",-1)),F("a",{href:"#",onClick:O[1]||(O[1]=nb(ce=>{t.value="list",e.value=!1},["prevent"])),class:ei(["flex items-center gap-3 px-3 py-2.5 rounded-xl text-sm font-medium transition-all duration-200 group",t.value==="list"?"bg-brand-50 text-brand-700 shadow-sm ring-1 ring-brand-100":"text-slate-600 hover:bg-white hover:shadow-sm"])},[O[45]||(O[45]=F("i",{class:"ph ph-article-ny-times text-lg"},null,-1)),O[46]||(O[46]=En(" All Posts ",-1)),F("span",{class:ei(["ml-auto text-xs font-bold px-2 py-0.5 rounded-full",t.value==="list"?"bg-brand-100 text-brand-700":"bg-slate-100 text-slate-500"])},Pi(l.value.length),3)],2),F("a",{href:"#",onClick:O[2]||(O[2]=nb(ce=>{t.value="ai-settings",e.value=!1},["prevent"])),class:ei(["flex items-center gap-3 px-3 py-2.5 rounded-xl text-sm font-medium transition-all duration-200 group",t.value==="ai-settings"?"bg-brand-50 text-brand-700 shadow-sm ring-1 ring-brand-100":"text-slate-600 hover:bg-white hover:shadow-sm"])},[...O[47]||(O[47]=[F("i",{class:"ph ph-brain text-lg"},null,-1),En(" AI Settings ",-1)])],2),F("a",{href:"#",onClick:O[3]||(O[3]=nb(ce=>{t.value="comments",e.value=!1},["prevent"])),class:ei(["flex items-center gap-3 px-3 py-2.5 rounded-xl text-sm font-medium transition-all duration-200 group",t.value==="comments"?"bg-brand-50 text-brand-700 shadow-sm ring-1 ring-brand-100":"text-slate-600 hover:bg-white hover:shadow-sm"])},[...O[48]||(O[48]=[F("i",{class:"ph ph-gear text-lg"},null,-1),En(" Settings ",-1)])],2),F("a",{href:"#",onClick:O[4]||(O[4]=nb(ce=>{t.value="wxr",e.value=!1},["prevent"])),class:ei(["flex items-center gap-3 px-3 py-2.5 rounded-xl text-sm font-medium transition-all duration-200 group",t.value==="wxr"?"bg-brand-50 text-brand-700 shadow-sm ring-1 ring-brand-100":"text-slate-600 hover:bg-white hover:shadow-sm"])},[...O[49]||(O[49]=[F("i",{class:"ph ph-arrows-clockwise text-lg"},null,-1),En(" Import / Export ",-1)])],2)]),O[52]||(O[52]=tS('<div class="p-4 border-t border-slate-200/60"><div class="flex items-center gap-3 w-full p-2 rounded-xl"><div class="w-8 h-8 rounded-full bg-brand-100 flex items-center justify-center text-brand-600"><i class="ph ph-user text-lg"></i></div><div class="text-left flex-1 min-w-0"><p class="text-sm font-semibold text-slate-900 truncate">Admin</p><p class="text-xs text-slate-500 truncate">Blog Administrator</p></div></div></div>',1))],2),e.value?(Kt(),Zt("div",{key:0,onClick:O[5]||(O[5]=ce=>e.value=!1),class:"fixed inset-0 bg-slate-900/20 backdrop-blur-sm z-20 md:hidden"})):ms("",!0),F("main",SRe,[Pe.value.show?(Kt(),Zt("div",yRe,[F("div",{class:ei(["px-4 py-3 rounded-xl shadow-lg flex items-center gap-3",Pe.value.type==="success"?"bg-emerald-500 text-white":"bg-rose-500 text-white"])},[F("i",{class:ei(["ph text-lg",Pe.value.type==="success"?"ph-check-circle":"ph-warning-circle"])},null,2),F("span",LRe,Pi(Pe.value.message),1)],2)])):ms("",!0),t.value==="list"?(Kt(),Zt("div",xRe,[F("div",{class:"p-4 md:p-8 pb-4 flex flex-col sm:flex-row sm:items-center justify-between gap-4"},[O[54]||(O[54]=F("div",null,[F("h2",{class:"text-2xl font-bold text-slate-900"},"Posts"),F("p",{class:"text-slate-500 text-sm mt-1"},"Manage and publish your blog content")],-1)),F("button",{onClick:Oe,class:"bg-slate-900 hover:bg-slate-800 text-white px-5 py-2.5 rounded-xl text-sm font-medium shadow-lg shadow-slate-900/20 flex items-center gap-2 transition-all active:scale-95 w-full sm:w-auto justify-center"},[...O[53]||(O[53]=[F("i",{class:"ph ph-plus-bold"},null,-1),En(" New Post ",-1)])])]),F("div",DRe,[F("div",kRe,[F("div",IRe,[O[55]||(O[55]=F("i",{class:"ph ph-magnifying-glass absolute left-3 top-1/2 -translate-y-1/2 text-slate-400"},null,-1)),Ui(F("input",{"onUpdate:modelValue":O[6]||(O[6]=ce=>i.value=ce),type:"text",placeholder:"Search posts...",class:"w-full pl-10 pr-4 py-2.5 bg-white border border-slate-200 rounded-xl text-sm focus:outline-none focus:ring-2 focus:ring-brand-500/20 focus:border-brand-500 transition-all shadow-sm"},null,512),[[zn,i.value]])]),F("div",ERe,[F("button",{onClick:O[7]||(O[7]=ce=>n.value="all"),class:ei(["px-4 py-2.5 rounded-xl text-sm font-medium whitespace-nowrap transition-all border",n.value==="all"?"bg-white border-slate-200 text-slate-900 shadow-sm":"border-transparent text-slate-500 hover:bg-white hover:text-slate-700"])}," All Posts ",2),F("button",{onClick:O[8]||(O[8]=ce=>n.value="published"),class:ei(["px-4 py-2.5 rounded-xl text-sm font-medium whitespace-nowrap transition-all border",n.value==="published"?"bg-emerald-50 border-emerald-100 text-emerald-700 shadow-sm":"border-transparent text-slate-500 hover:bg-white hover:text-slate-700"])}," Published ",2),F("button",{onClick:O[9]||(O[9]=ce=>n.value="draft"),class:ei(["px-4 py-2.5 rounded-xl text-sm font-medium whitespace-nowrap transition-all border",n.value==="draft"?"bg-amber-50 border-amber-100 text-amber-700 shadow-sm":"border-transparent text-slate-500 hover:bg-white hover:text-slate-700"])}," Drafts ",2)])])]),r.value?(Kt(),Zt("div",NRe,[...O[56]||(O[56]=[F("div",{class:"flex flex-col items-center gap-3 text-slate-400"},[F("i",{class:"ph ph-spinner text-4xl animate-spin"}),F("p",{class:"text-sm"},"Loading posts...")],-1)])])):(Kt(),Zt("div",TRe,[di.value.length===0?(Kt(),Zt("div",MRe,[...O[57]||(O[57]=[F("i",{class:"ph ph-files text-4xl mb-3 opacity-50"},null,-1),F("p",null,"No posts found matching your criteria.",-1)])])):ms("",!0),da(uJ,{name:"list",tag:"div",class:"space-y-3"},{default:EV(()=>[(Kt(!0),Zt(No,null,eS(di.value,ce=>(Kt(),Zt("div",{key:ce.id,class:"group bg-white rounded-2xl p-4 border border-slate-200/60 shadow-sm hover:shadow-md hover:border-brand-200 transition-all duration-300 relative overflow-hidden"},[F("div",RRe,[F("div",{class:ei(["w-12 h-12 rounded-xl flex items-center justify-center shrink-0",ce.published_at?"bg-emerald-50 text-emerald-600":"bg-amber-50 text-amber-600"])},[F("i",{class:ei(["text-xl ph",ce.published_at?"ph-check-circle-fill":"ph-pencil-simple-slash-fill"])},null,2)],2),F("div",ARe,[F("div",PRe,[F("a",{href:"#",onClick:nb(ri=>tt(ce),["prevent"]),class:"font-bold text-slate-900 truncate text-lg group-hover:text-brand-600 transition-colors"},Pi(ce.title||"(Untitled)"),9,ORe)]),F("div",FRe,[F("span",BRe,[O[58]||(O[58]=F("i",{class:"ph ph-calendar-blank"},null,-1)),En(" "+Pi(Ct(ce.published_at)),1)]),F("span",WRe," /"+Pi(ce.slug),1)])]),F("div",HRe,[ce.published_at?(Kt(),Zt("a",{key:0,href:`/blog/${ce.slug}`,target:"_blank",class:"p-2 rounded-lg text-slate-400 hover:bg-sky-50 hover:text-sky-600 transition-colors",title:"View Public Post"},[...O[59]||(O[59]=[F("i",{class:"ph ph-arrow-square-out text-lg"},null,-1)])],8,VRe)):ms("",!0),F("button",{onClick:ri=>tt(ce),class:"flex-1 md:flex-none py-2 md:py-1.5 px-4 rounded-lg bg-slate-50 text-slate-600 hover:bg-brand-50 hover:text-brand-600 text-sm font-medium transition-colors"}," Edit ",8,zRe),F("button",{onClick:ri=>Bt(ce),class:"p-2 rounded-lg text-slate-400 hover:bg-rose-50 hover:text-rose-600 transition-colors"},[...O[60]||(O[60]=[F("i",{class:"ph ph-trash text-lg"},null,-1)])],8,URe)])])]))),128))]),_:1})]))])):t.value==="editor"?(Kt(),Zt("div",$Re,[F("div",jRe,[F("div",KRe,[F("button",{onClick:Vt,class:"p-2 -ml-2 text-slate-400 hover:text-slate-800 hover:bg-slate-100 rounded-full transition-colors"},[...O[61]||(O[61]=[F("i",{class:"ph ph-arrow-left text-xl"},null,-1)])]),O[62]||(O[62]=F("div",{class:"h-6 w-px bg-slate-200 hidden md:block"},null,-1)),F("span",qRe,Pi(Ne.value?"New Post":"Editing Post"),1)]),F("div",GRe,[F("div",ZRe,[O[63]||(O[63]=F("span",{class:"text-xs font-semibold text-slate-500 uppercase hidden sm:block"},"Published",-1)),F("button",{onClick:O[10]||(O[10]=ce=>de.value.published=!de.value.published),class:ei(["w-11 h-6 rounded-full relative transition-colors duration-300 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-brand-500",de.value.published?"bg-emerald-500":"bg-slate-300"])},[F("span",{class:ei(["absolute top-1 left-1 w-4 h-4 bg-white rounded-full shadow-sm transition-transform duration-300",de.value.published?"translate-x-5":"translate-x-0"])},null,2)],2)]),F("button",{onClick:et,disabled:a.value,class:ei(["text-white px-5 py-2 rounded-xl text-sm font-bold shadow-lg transition-all active:scale-95 flex items-center gap-2",a.value?"bg-slate-800 opacity-50 cursor-not-allowed":be.value?"bg-amber-600 hover:bg-amber-700 shadow-amber-600/20":"bg-slate-900 hover:bg-slate-800 shadow-slate-900/20"])},[be.value&&!a.value?(Kt(),Zt("i",XRe)):ms("",!0),a.value?(Kt(),Zt("i",QRe)):ms("",!0),En(" "+Pi(a.value?"Saving...":be.value?"Unsaved Changes":"Saved"),1)],10,YRe)])]),F("div",JRe,[F("div",eAe,[F("div",tAe,[Ui(F("input",{"onUpdate:modelValue":O[11]||(O[11]=ce=>de.value.title=ce),onInput:Wt,type:"text",placeholder:"Post Title",class:"w-full text-2xl md:text-3xl font-bold placeholder-slate-300 border-none focus:ring-0 p-0 text-slate-900 bg-transparent outline-none"},null,544),[[zn,de.value.title]]),Ui(F("input",{"onUpdate:modelValue":O[12]||(O[12]=ce=>de.value.subtitle=ce),type:"text",placeholder:"Subtitle (optional)",class:"w-full text-lg md:text-xl font-medium placeholder-slate-300 border-none focus:ring-0 p-0 text-slate-700 bg-transparent outline-none"},null,512),[[zn,de.value.subtitle]]),F("div",iAe,[F("div",nAe,[O[64]||(O[64]=F("i",{class:"ph ph-link text-lg"},null,-1)),O[65]||(O[65]=F("span",{class:"text-xs font-mono text-slate-400"},"/",-1)),Ui(F("input",{"onUpdate:modelValue":O[13]||(O[13]=ce=>de.value.slug=ce),type:"text",placeholder:"url-slug",class:"w-full text-sm font-mono bg-transparent border-none focus:ring-0 p-0 text-slate-600 outline-none"},null,512),[[zn,de.value.slug]])]),F("div",sAe,[O[66]||(O[66]=F("i",{class:"ph ph-calendar-blank text-lg"},null,-1)),Ui(F("input",{"onUpdate:modelValue":O[14]||(O[14]=ce=>de.value.date=ce),type:"date",class:"w-full text-sm bg-transparent border-none focus:ring-0 p-0 text-slate-600 placeholder-slate-400 font-sans outline-none"},null,512),[[zn,de.value.date]])])])]),F("div",oAe,[F("div",rAe,[da(_Re,{modelValue:de.value.content,"onUpdate:modelValue":O[15]||(O[15]=ce=>de.value.content=ce),diffHighlight:w.value},null,8,["modelValue","diffHighlight"])])])]),F("div",aAe,[O[74]||(O[74]=F("div",{class:"p-4 border-b border-slate-100"},[F("h3",{class:"font-bold text-sm text-slate-900"},"SEO & Settings")],-1)),F("div",lAe,[F("div",dAe,[F("label",cAe,[O[67]||(O[67]=En(" Meta Description ",-1)),F("span",{class:ei(["ml-1",jt.value])},Pi(de.value.description.length)+"/160",3)]),Ui(F("textarea",{"onUpdate:modelValue":O[16]||(O[16]=ce=>de.value.description=ce),rows:"4",class:"w-full text-sm p-3 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none transition-all resize-none text-slate-600 leading-relaxed",placeholder:"Enter a meta description..."},null,512),[[zn,de.value.description]])]),de.value.tags&&de.value.tags.length>0?(Kt(),Zt("div",uAe,[O[68]||(O[68]=F("label",{class:"text-xs font-semibold text-slate-500 uppercase"},"Tags",-1)),F("div",hAe,[(Kt(!0),Zt(No,null,eS(de.value.tags,ce=>(Kt(),Zt("span",{key:ce.id,class:"px-2 py-1 bg-brand-50 text-brand-600 rounded-md text-xs font-bold"},Pi(ce.name),1))),128))])])):ms("",!0),F("div",fAe,[F("div",gAe,[O[69]||(O[69]=F("label",{class:"text-xs font-semibold text-slate-500 uppercase"},"AI Assistant",-1)),u.value.smart||u.value.dumb?(Kt(),Zt("span",pAe,"Ready")):(Kt(),Zt("span",mAe,"Not configured"))]),u.value.smart||u.value.dumb?(Kt(),Zt("div",_Ae,[F("div",vAe,[F("button",{onClick:O[17]||(O[17]=ce=>g.value="smart"),class:ei(["px-2 py-1 rounded-md text-xs font-semibold border",g.value==="smart"?"bg-brand-50 text-brand-700 border-brand-100":"border-slate-200 text-slate-500"])},"Smart",2),F("button",{onClick:O[18]||(O[18]=ce=>g.value="dumb"),disabled:!u.value.dumb,class:ei(["px-2 py-1 rounded-md text-xs font-semibold border",g.value==="dumb"?"bg-brand-50 text-brand-700 border-brand-100":"border-slate-200 text-slate-500",u.value.dumb?"":"opacity-50 cursor-not-allowed"])},"Dumb",10,bAe)]),F("label",CAe,[Ui(F("input",{"onUpdate:modelValue":O[19]||(O[19]=ce=>b.value=ce),type:"checkbox",class:"accent-brand-600"},null,512),[[bB,b.value]]),O[70]||(O[70]=En(" Use web search if supported ",-1))]),F("label",wAe,[Ui(F("input",{"onUpdate:modelValue":O[20]||(O[20]=ce=>C.value=ce),type:"checkbox",class:"accent-brand-600"},null,512),[[bB,C.value]]),O[71]||(O[71]=En(" Highlight applied changes in editor ",-1))]),Ui(F("textarea",{"onUpdate:modelValue":O[21]||(O[21]=ce=>m.value=ce),rows:"3",placeholder:"Ask for edits, rewrites, or tone changes...",class:"w-full text-xs p-2 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none resize-none"},null,512),[[zn,m.value]]),F("button",{onClick:Me,disabled:_.value||!m.value,class:ei(["w-full text-xs font-semibold px-3 py-2 rounded-lg text-white transition-all",_.value?"bg-slate-400 cursor-not-allowed":"bg-slate-900 hover:bg-slate-800"])},Pi(_.value?"Thinking...":"Send to AI"),11,SAe),v.value?(Kt(),Zt("div",yAe,[O[72]||(O[72]=F("span",{class:"font-semibold text-slate-700"},"Notes:",-1)),En(" "+Pi(v.value),1)])):ms("",!0),O[73]||(O[73]=F("p",{class:"text-[11px] text-slate-500"},"Changes apply instantly. Use the Diff tab to review AI edits, then return to Markdown to keep editing.",-1))])):(Kt(),Zt("div",LAe,"Configure AI providers in the settings page to enable the assistant."))])])])])])):t.value==="ai-settings"?(Kt(),Zt("div",xAe,[F("div",DAe,[O[75]||(O[75]=F("div",null,[F("h2",{class:"text-2xl font-bold text-slate-900"},"AI Settings"),F("p",{class:"text-slate-500 text-sm mt-1"},"Configure smart and dumb models for the editor assistant")],-1)),F("button",{onClick:_e,disabled:f.value,class:ei(["text-white px-5 py-2.5 rounded-xl text-sm font-medium shadow-lg transition-all active:scale-95 flex items-center gap-2",f.value?"bg-slate-500 cursor-not-allowed":"bg-slate-900 hover:bg-slate-800"])},[f.value?(Kt(),Zt("i",IAe)):ms("",!0),En(" "+Pi(f.value?"Saving...":"Save Settings"),1)],10,kAe)]),F("div",EAe,[h.value?(Kt(),Zt("div",NAe,[...O[76]||(O[76]=[F("i",{class:"ph ph-spinner animate-spin"},null,-1),En(" Loading settings... ",-1)])])):(Kt(),Zt("div",TAe,[F("div",MAe,[F("div",RAe,[O[77]||(O[77]=F("h3",{class:"text-lg font-bold text-slate-900"},"Smart AI",-1)),F("span",{class:ei(["text-xs font-semibold px-2 py-1 rounded-full",u.value.smart?"bg-emerald-50 text-emerald-700":"bg-slate-100 text-slate-500"])},Pi(u.value.smart?"Enabled":"Disabled"),3)]),O[85]||(O[85]=F("p",{class:"text-xs text-slate-500"},"Used to write or edit posts.",-1)),F("div",AAe,[O[79]||(O[79]=F("label",{class:"text-xs font-semibold text-slate-500 uppercase"},"Provider",-1)),Ui(F("select",{"onUpdate:modelValue":O[22]||(O[22]=ce=>c.value.smart.provider=ce),class:"w-full text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},[...O[78]||(O[78]=[tS('<option value="">Select provider</option><option value="openai">OpenAI</option><option value="anthropic">Anthropic</option><option value="gemini">Gemini</option><option value="ollama">Ollama</option>',5)])],512),[[IE,c.value.smart.provider]])]),F("div",PAe,[O[80]||(O[80]=F("label",{class:"text-xs font-semibold text-slate-500 uppercase"},"Model",-1)),Ui(F("input",{"onUpdate:modelValue":O[23]||(O[23]=ce=>c.value.smart.model=ce),type:"text",placeholder:"gpt-4o-mini",class:"w-full text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},null,512),[[zn,c.value.smart.model]])]),F("div",OAe,[O[81]||(O[81]=F("label",{class:"text-xs font-semibold text-slate-500 uppercase"},"API Key",-1)),Ui(F("input",{"onUpdate:modelValue":O[24]||(O[24]=ce=>c.value.smart.api_key=ce),type:"password",placeholder:"sk-...",class:"w-full text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},null,512),[[zn,c.value.smart.api_key]])]),F("div",FAe,[O[82]||(O[82]=F("label",{class:"text-xs font-semibold text-slate-500 uppercase"},"Base URL",-1)),Ui(F("input",{"onUpdate:modelValue":O[25]||(O[25]=ce=>c.value.smart.base_url=ce),type:"text",placeholder:"https://api.openai.com",class:"w-full text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},null,512),[[zn,c.value.smart.base_url]])]),F("div",BAe,[F("div",WAe,[O[83]||(O[83]=F("label",{class:"text-xs font-semibold text-slate-500 uppercase"},"Temperature",-1)),Ui(F("input",{"onUpdate:modelValue":O[26]||(O[26]=ce=>c.value.smart.temperature=ce),type:"number",step:"0.1",min:"0",max:"2",placeholder:"0.4",class:"w-full text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},null,512),[[zn,c.value.smart.temperature,void 0,{number:!0}]])]),F("div",HAe,[O[84]||(O[84]=F("label",{class:"text-xs font-semibold text-slate-500 uppercase"},"Max Tokens",-1)),Ui(F("input",{"onUpdate:modelValue":O[27]||(O[27]=ce=>c.value.smart.max_tokens=ce),type:"number",min:"1",placeholder:"800",class:"w-full text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},null,512),[[zn,c.value.smart.max_tokens,void 0,{number:!0}]])])])]),F("div",VAe,[F("div",zAe,[O[86]||(O[86]=F("h3",{class:"text-lg font-bold text-slate-900"},"Dumb AI",-1)),F("span",{class:ei(["text-xs font-semibold px-2 py-1 rounded-full",u.value.dumb?"bg-emerald-50 text-emerald-700":"bg-slate-100 text-slate-500"])},Pi(u.value.dumb?"Enabled":"Disabled"),3)]),F("div",{class:"flex items-center justify-between gap-3"},[O[87]||(O[87]=F("p",{class:"text-xs text-slate-500"},"Used for spam detection, quick rewrites, and simple edits.",-1)),F("button",{onClick:$,class:"text-xs font-semibold text-brand-600 hover:text-brand-700"},"Copy smart → dumb")]),F("div",UAe,[O[89]||(O[89]=F("label",{class:"text-xs font-semibold text-slate-500 uppercase"},"Provider",-1)),Ui(F("select",{"onUpdate:modelValue":O[28]||(O[28]=ce=>c.value.dumb.provider=ce),class:"w-full text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},[...O[88]||(O[88]=[tS('<option value="">Select provider</option><option value="openai">OpenAI</option><option value="anthropic">Anthropic</option><option value="gemini">Gemini</option><option value="ollama">Ollama</option>',5)])],512),[[IE,c.value.dumb.provider]])]),F("div",$Ae,[O[90]||(O[90]=F("label",{class:"text-xs font-semibold text-slate-500 uppercase"},"Model",-1)),Ui(F("input",{"onUpdate:modelValue":O[29]||(O[29]=ce=>c.value.dumb.model=ce),type:"text",placeholder:"gpt-4o-mini",class:"w-full text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},null,512),[[zn,c.value.dumb.model]])]),F("div",jAe,[O[91]||(O[91]=F("label",{class:"text-xs font-semibold text-slate-500 uppercase"},"API Key",-1)),Ui(F("input",{"onUpdate:modelValue":O[30]||(O[30]=ce=>c.value.dumb.api_key=ce),type:"password",placeholder:"sk-...",class:"w-full text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},null,512),[[zn,c.value.dumb.api_key]])]),F("div",KAe,[O[92]||(O[92]=F("label",{class:"text-xs font-semibold text-slate-500 uppercase"},"Base URL",-1)),Ui(F("input",{"onUpdate:modelValue":O[31]||(O[31]=ce=>c.value.dumb.base_url=ce),type:"text",placeholder:"https://api.openai.com",class:"w-full text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},null,512),[[zn,c.value.dumb.base_url]])]),F("div",qAe,[F("div",GAe,[O[93]||(O[93]=F("label",{class:"text-xs font-semibold text-slate-500 uppercase"},"Temperature",-1)),Ui(F("input",{"onUpdate:modelValue":O[32]||(O[32]=ce=>c.value.dumb.temperature=ce),type:"number",step:"0.1",min:"0",max:"2",placeholder:"0.2",class:"w-full text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},null,512),[[zn,c.value.dumb.temperature,void 0,{number:!0}]])]),F("div",ZAe,[O[94]||(O[94]=F("label",{class:"text-xs font-semibold text-slate-500 uppercase"},"Max Tokens",-1)),Ui(F("input",{"onUpdate:modelValue":O[33]||(O[33]=ce=>c.value.dumb.max_tokens=ce),type:"number",min:"1",placeholder:"400",class:"w-full text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},null,512),[[zn,c.value.dumb.max_tokens,void 0,{number:!0}]])])])])])),O[95]||(O[95]=F("div",{class:"bg-white border border-slate-200/60 rounded-2xl p-5 shadow-sm text-sm text-slate-600"},[F("p",null,"Tip: If provider, model, or required API key is missing, the assistant is disabled. Ollama typically runs without an API key.")],-1))])])):t.value==="comments"?(Kt(),Zt("div",YAe,[F("div",XAe,[O[96]||(O[96]=F("div",null,[F("h2",{class:"text-2xl font-bold text-slate-900"},"Settings"),F("p",{class:"text-slate-500 text-sm mt-1"},"Configure your blog identity, comments, and display options")],-1)),F("button",{onClick:xe,disabled:D.value,class:ei(["text-white px-5 py-2.5 rounded-xl text-sm font-medium shadow-lg transition-all active:scale-95 flex items-center gap-2",D.value?"bg-slate-500 cursor-not-allowed":"bg-slate-900 hover:bg-slate-800"])},[D.value?(Kt(),Zt("i",JAe)):ms("",!0),En(" "+Pi(D.value?"Saving...":"Save Settings"),1)],10,QAe)]),F("div",e2e,[F("div",t2e,[O[103]||(O[103]=F("div",null,[F("h3",{class:"text-lg font-bold text-slate-900"},"Site Identity"),F("p",{class:"text-sm text-slate-500 mt-1"},"Set the title and description used in page headers, SEO meta tags, and social sharing.")],-1)),F("div",null,[O[97]||(O[97]=F("label",{class:"block text-sm font-semibold text-slate-700 mb-1"},"Blog Title",-1)),Ui(F("input",{"onUpdate:modelValue":O[34]||(O[34]=ce=>S.value.title=ce),type:"text",placeholder:"My Blog",class:"w-full text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},null,512),[[zn,S.value.title]]),O[98]||(O[98]=F("p",{class:"text-xs text-slate-400 mt-1"},"Displayed in the header, browser tab, and Open Graph tags.",-1))]),F("div",null,[O[99]||(O[99]=F("label",{class:"block text-sm font-semibold text-slate-700 mb-1"},"Blog Description",-1)),Ui(F("textarea",{"onUpdate:modelValue":O[35]||(O[35]=ce=>S.value.description=ce),rows:"2",placeholder:"A short description of your blog",class:"w-full text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none resize-y"},null,512),[[zn,S.value.description]]),O[100]||(O[100]=F("p",{class:"text-xs text-slate-400 mt-1"},"Used as the meta description for listing pages and social previews.",-1))]),F("div",null,[O[101]||(O[101]=F("label",{class:"block text-sm font-semibold text-slate-700 mb-1"},"Google Analytics Measurement ID",-1)),Ui(F("input",{"onUpdate:modelValue":O[36]||(O[36]=ce=>S.value.google_analytics_code=ce),type:"text",placeholder:"G-XXXXXXXXXX",class:"w-full text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},null,512),[[zn,S.value.google_analytics_code]]),O[102]||(O[102]=F("p",{class:"text-xs text-slate-400 mt-1"},[En("If set, the default templates load the Google tag automatically and custom templates can use "),F("code",null,".GoogleAnalyticsCode"),En(".")],-1))])]),F("div",i2e,[F("div",n2e,[O[104]||(O[104]=F("div",null,[F("h3",{class:"text-lg font-bold text-slate-900"},"Allow comments"),F("p",{class:"text-sm text-slate-500 mt-1"},"Turn this off to hide comment forms and disable posting.")],-1)),F("button",{onClick:O[37]||(O[37]=ce=>S.value.comments_enabled=!S.value.comments_enabled),class:ei(["w-12 h-6 rounded-full relative transition-colors duration-300 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-brand-500",S.value.comments_enabled?"bg-emerald-500":"bg-slate-300"])},[F("span",{class:ei(["absolute top-1 left-1 w-4 h-4 bg-white rounded-full shadow-sm transition-transform duration-300",S.value.comments_enabled?"translate-x-6":"translate-x-0"])},null,2)],2)])]),F("div",s2e,[F("div",o2e,[O[105]||(O[105]=F("div",null,[F("h3",{class:"text-lg font-bold text-slate-900"},"Notifications"),F("p",{class:"text-sm text-slate-500 mt-1"},"Push admins when new comments are posted.")],-1)),F("button",{onClick:O[38]||(O[38]=ce=>S.value.notifications_enabled=!S.value.notifications_enabled),class:ei(["w-12 h-6 rounded-full relative transition-colors duration-300 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-brand-500",S.value.notifications_enabled?"bg-emerald-500":"bg-slate-300"])},[F("span",{class:ei(["absolute top-1 left-1 w-4 h-4 bg-white rounded-full shadow-sm transition-transform duration-300",S.value.notifications_enabled?"translate-x-6":"translate-x-0"])},null,2)],2)]),F("div",r2e,[F("p",a2e,[O[106]||(O[106]=En(" Browser permission: ",-1)),F("span",l2e,Pi(T.value),1),O[107]||(O[107]=En(" · Subscription: ",-1)),F("span",d2e,Pi(V.value?"active":"inactive"),1)]),F("div",c2e,[F("button",{onClick:Ue,disabled:P.value||!S.value.notifications_enabled,class:ei(["px-4 py-2 rounded-lg text-xs font-semibold transition-all",P.value||!S.value.notifications_enabled?"bg-slate-300 text-slate-500 cursor-not-allowed":"bg-slate-900 text-white hover:bg-slate-800"])},Pi(P.value?"Working...":"Enable In Browser"),11,u2e),F("button",{onClick:G,disabled:P.value||!V.value,class:ei(["px-4 py-2 rounded-lg text-xs font-semibold transition-all",P.value||!V.value?"bg-slate-200 text-slate-500 cursor-not-allowed":"bg-slate-100 text-slate-700 hover:bg-slate-200"])}," Disable ",10,h2e)])]),F("div",f2e,[F("div",g2e,[O[108]||(O[108]=F("label",{class:"block text-xs font-semibold text-slate-600 uppercase"},"VAPID Public Key",-1)),Ui(F("input",{"onUpdate:modelValue":O[39]||(O[39]=ce=>S.value.vapid_public_key=ce),type:"text",class:"w-full text-xs p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},null,512),[[zn,S.value.vapid_public_key]])]),F("div",p2e,[O[109]||(O[109]=F("label",{class:"block text-xs font-semibold text-slate-600 uppercase"},"VAPID Private Key",-1)),Ui(F("input",{"onUpdate:modelValue":O[40]||(O[40]=ce=>S.value.vapid_private_key=ce),type:"text",class:"w-full text-xs p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},null,512),[[zn,S.value.vapid_private_key]])])]),F("div",m2e,[O[110]||(O[110]=F("label",{class:"block text-xs font-semibold text-slate-600 uppercase"},"Subscriber",-1)),Ui(F("input",{"onUpdate:modelValue":O[41]||(O[41]=ce=>S.value.vapid_subscriber=ce),type:"text",placeholder:"mailto:admin@example.com",class:"w-full text-xs p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},null,512),[[zn,S.value.vapid_subscriber]])]),O[111]||(O[111]=F("p",{class:"text-xs text-slate-500"},"If keys are blank, Spore auto-generates and stores fresh VAPID keys in settings.",-1))]),F("div",_2e,[F("div",v2e,[O[113]||(O[113]=F("div",null,[F("h3",{class:"text-lg font-bold text-slate-900"},"Published date display"),F("p",{class:"text-sm text-slate-500 mt-1"},"Choose how dates appear on public posts.")],-1)),Ui(F("select",{"onUpdate:modelValue":O[42]||(O[42]=ce=>S.value.date_display=ce),class:"w-full md:w-64 text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},[...O[112]||(O[112]=[F("option",{value:"absolute"},"Exact date (Jan 2, 2006)",-1),F("option",{value:"approximate"},"Approximate (5 years ago)",-1)])],512),[[IE,S.value.date_display]])])]),F("div",b2e,[F("div",C2e,[O[114]||(O[114]=F("div",null,[F("h3",{class:"text-lg font-bold text-slate-900"},"Moderation Queue"),F("p",{class:"text-sm text-slate-500"},"Review recent comments and spam checks.")],-1)),F("div",w2e,[(Kt(),Zt(No,null,eS(oe,ce=>F("button",{key:ce.key,onClick:ri=>Q.value=ce.key,class:ei(["px-4 py-2 rounded-xl text-xs font-semibold border transition-all",Q.value===ce.key?"bg-slate-900 text-white border-slate-900":"border-slate-200 text-slate-500 hover:text-slate-700"])},Pi(ce.label),11,S2e)),64))])]),B.value?(Kt(),Zt("div",y2e,[...O[115]||(O[115]=[F("i",{class:"ph ph-spinner animate-spin"},null,-1),En(" Loading comments... ",-1)])])):A.value.length===0?(Kt(),Zt("div",L2e,"No comments to show.")):(Kt(),Zt("div",x2e,[(Kt(!0),Zt(No,null,eS(A.value,ce=>(Kt(),Zt("div",{key:ce.id,class:"border border-slate-200/60 rounded-2xl p-4 bg-slate-50/60"},[F("div",D2e,[F("div",null,[F("p",k2e,[En(Pi(ce.author_name)+" ",1),O[116]||(O[116]=F("span",{class:"text-xs text-slate-400"},"on",-1)),En(" "+Pi(ce.post_title),1)]),F("p",I2e,"/"+Pi(ce.post_slug)+" · "+Pi(Ct(ce.created_at)),1)]),F("span",{class:ei(["text-xs font-semibold px-2 py-1 rounded-full",q(ce.status)])},Pi(ce.status),3)]),F("p",E2e,Pi(ce.content),1),ce.spam_reason?(Kt(),Zt("p",N2e,"Spam note: "+Pi(ce.spam_reason),1)):ms("",!0),F("div",T2e,[ce.status!=="approved"?(Kt(),Zt("button",{key:0,onClick:ri=>E(ce,"approved"),class:"px-3 py-1.5 rounded-lg text-xs font-semibold bg-emerald-500 text-white"},"Approve",8,M2e)):ms("",!0),ce.status!=="hidden"?(Kt(),Zt("button",{key:1,onClick:ri=>E(ce,"hidden"),class:"px-3 py-1.5 rounded-lg text-xs font-semibold bg-slate-200 text-slate-700"},"Hide",8,R2e)):ms("",!0),ce.status!=="rejected"?(Kt(),Zt("button",{key:2,onClick:ri=>E(ce,"rejected"),class:"px-3 py-1.5 rounded-lg text-xs font-semibold bg-amber-500 text-white"},"Reject",8,A2e)):ms("",!0),F("button",{onClick:ri=>R(ce),class:"px-3 py-1.5 rounded-lg text-xs font-semibold bg-rose-500 text-white"},"Delete",8,P2e)])]))),128))]))])])])):t.value==="wxr"?(Kt(),Zt("div",O2e,[F("div",F2e,[O[117]||(O[117]=F("div",null,[F("h2",{class:"text-2xl font-bold text-slate-900"},"Import / Export"),F("p",{class:"text-slate-500 text-sm mt-1"},"Move your entire blog using WordPress WXR files")],-1)),F("button",{onClick:te,disabled:we.value,class:ei(["text-white px-5 py-2.5 rounded-xl text-sm font-medium shadow-lg transition-all active:scale-95 flex items-center gap-2",we.value?"bg-slate-500 cursor-not-allowed":"bg-slate-900 hover:bg-slate-800"])},[we.value?(Kt(),Zt("i",W2e)):ms("",!0),En(" "+Pi(we.value?"Exporting...":"Export WXR"),1)],10,B2e)]),F("div",H2e,[F("div",V2e,[O[119]||(O[119]=F("div",null,[F("h3",{class:"text-lg font-bold text-slate-900"},"Export Blog"),F("p",{class:"text-sm text-slate-500 mt-1"},"Download a WXR file containing all posts, tags, and comments.")],-1)),F("button",{onClick:te,disabled:we.value,class:ei(["text-white px-4 py-2 rounded-lg text-sm font-semibold transition-all",we.value?"bg-slate-400 cursor-not-allowed":"bg-slate-900 hover:bg-slate-800"])},[...O[118]||(O[118]=[F("i",{class:"ph ph-download-simple"},null,-1),En(" Download Export ",-1)])],10,z2e)]),F("div",U2e,[O[121]||(O[121]=F("div",null,[F("h3",{class:"text-lg font-bold text-slate-900"},"Import Blog"),F("p",{class:"text-sm text-slate-500 mt-1"},"Upload a WXR file. Existing posts and comments are skipped."),F("p",{class:"text-sm text-slate-500 mt-1"},"Ensure the old blog location is still accessible so that we can copy its images into our database.")],-1)),F("div",$2e,[F("input",{type:"file",accept:".xml,text/xml,application/xml",onChange:H,class:"block w-full text-sm text-slate-500 file:mr-4 file:py-2 file:px-4 file:rounded-lg file:border-0 file:text-sm file:font-semibold file:bg-slate-100 file:text-slate-700 hover:file:bg-slate-200"},null,32),F("button",{onClick:pe,disabled:Le.value||!ae.value,class:ei(["text-white px-4 py-2 rounded-lg text-sm font-semibold transition-all",Le.value||!ae.value?"bg-slate-400 cursor-not-allowed":"bg-emerald-600 hover:bg-emerald-700"])},[Le.value?(Kt(),Zt("i",K2e)):ms("",!0),En(" "+Pi(Le.value?"Importing...":"Import WXR"),1)],10,j2e)]),le.value?(Kt(),Zt("div",q2e,[O[120]||(O[120]=F("p",{class:"font-semibold text-slate-800"},"Import summary",-1)),F("p",null,"Posts added: "+Pi(le.value.posts_added),1),F("p",null,"Posts skipped: "+Pi(le.value.posts_skipped),1),F("p",null,"Comments added: "+Pi(le.value.comments_added),1),F("p",null,"Comments skipped: "+Pi(le.value.comments_skipped),1)])):ms("",!0)])])])):ms("",!0)])])]))}};Hi.setOptions({breaks:!0,gfm:!0});wJ(G2e).mount("#app");export{bI as m,aG as t};
+`)}}}function we(le){le!==m&&(m=le,le?(g=oe=>ae(oe),window.addEventListener("paste",g,!0)):g&&(window.removeEventListener("paste",g,!0),g=null))}function Le(le){!c||!d||!le||typeof le.previous!="string"||(u?u.setValue(le.previous):u=o0.createModel(le.previous,"markdown"),c.setModel({original:u,modified:d.getModel()}))}return $D(async()=>{try{f=await zee()}catch{f=!1}const le=o0.createModel(t.modelValue||"","markdown");d=o0.create(n.value,{model:le,language:"markdown",wordWrap:"on",lineNumbers:"on",minimap:{enabled:!1},scrollBeyondLastLine:!1,fontSize:14,fontFamily:'Menlo, Monaco, "Courier New", monospace',padding:{top:12,bottom:12},automaticLayout:!0}),d.onDidChangeModelContent(()=>{h||i("update:modelValue",d.getValue())}),d.onDidFocusEditorText(()=>{r.value==="markdown"&&we(!0)}),d.onDidBlurEditorText(()=>{we(!1)}),c=o0.createDiffEditor(o.value,{readOnly:!0,renderSideBySide:!0,minimap:{enabled:!1},scrollBeyondLastLine:!1,wordWrap:"on",automaticLayout:!0}),Le(t.diffHighlight)}),Cl(()=>t.modelValue,le=>{v(le||"")}),Cl(()=>t.diffHighlight,le=>{if(!le||typeof le.previous!="string"){r.value==="diff"&&(r.value="markdown");return}Le(le)},{deep:!0}),Cl(a,le=>{!le&&r.value==="diff"&&(r.value="markdown")}),Cl(r,async()=>{await ZO(),r.value==="markdown"&&d&&(d.layout(),d.focus(),we(!0)),r.value==="diff"&&c&&c.layout(),r.value!=="markdown"&&we(!1)}),XO(()=>{d&&(we(!1),d.dispose(),d=null),c&&(c.dispose(),c=null),u&&(u.dispose(),u=null)}),(le,oe)=>(Kt(),Zt("div",hRe,[F("div",fRe,[F("button",{type:"button",class:ei(["markdown-tab",r.value==="markdown"?"is-active":""]),onClick:oe[0]||(oe[0]=Pe=>r.value="markdown")}," Markdown ",2),F("button",{type:"button",class:ei(["markdown-tab",r.value==="preview"?"is-active":""]),onClick:oe[1]||(oe[1]=Pe=>r.value="preview")}," Preview ",2),a.value?(Kt(),Zt("button",{key:0,type:"button",class:ei(["markdown-tab",r.value==="diff"?"is-active":""]),onClick:oe[2]||(oe[2]=Pe=>r.value="diff")}," Diff ",2)):ms("",!0)]),r.value==="markdown"?(Kt(),Zt("div",gRe,[F("button",{type:"button",class:"tool",title:"Bold",onClick:S},[...oe[3]||(oe[3]=[F("i",{class:"ph ph-text-b"},null,-1)])]),F("button",{type:"button",class:"tool",title:"Italic",onClick:L},[...oe[4]||(oe[4]=[F("i",{class:"ph ph-text-italic"},null,-1)])]),F("button",{type:"button",class:"tool",title:"Heading",onClick:T},[...oe[5]||(oe[5]=[F("i",{class:"ph ph-text-h"},null,-1)])]),oe[13]||(oe[13]=F("span",{class:"tool-divider"},null,-1)),F("button",{type:"button",class:"tool",title:"Quote",onClick:V},[...oe[6]||(oe[6]=[F("i",{class:"ph ph-quotes"},null,-1)])]),F("button",{type:"button",class:"tool",title:"Bulleted List",onClick:P},[...oe[7]||(oe[7]=[F("i",{class:"ph ph-list-bullets"},null,-1)])]),F("button",{type:"button",class:"tool",title:"Numbered List",onClick:A},[...oe[8]||(oe[8]=[F("i",{class:"ph ph-list-numbers"},null,-1)])]),oe[14]||(oe[14]=F("span",{class:"tool-divider"},null,-1)),F("button",{type:"button",class:"tool",title:"Link",onClick:B},[...oe[9]||(oe[9]=[F("i",{class:"ph ph-link"},null,-1)])]),F("button",{type:"button",class:"tool",title:"Inline Code",onClick:D},[...oe[10]||(oe[10]=[F("i",{class:"ph ph-code"},null,-1)])]),F("button",{type:"button",class:"tool",title:"Code Block",onClick:I},[...oe[11]||(oe[11]=[F("i",{class:"ph ph-code-block"},null,-1)])]),F("button",{type:"button",class:"tool",title:"Image",onClick:Q},[...oe[12]||(oe[12]=[F("i",{class:"ph ph-image"},null,-1)])])])):ms("",!0),F("div",pRe,[Ui(F("div",{ref_key:"editorContainer",ref:n,class:"monaco-editor-root"},null,512),[[xE,r.value==="markdown"]]),Ui(F("div",{class:"markdown-preview markdown-body",innerHTML:l.value},null,8,mRe),[[xE,r.value==="preview"]]),Ui(F("div",{ref_key:"diffContainer",ref:o,class:"monaco-diff-root"},null,512),[[xE,r.value==="diff"]])])]))}},vRe={class:"bg-surface text-slate-800 antialiased h-screen overflow-hidden selection:bg-brand-100 selection:text-brand-900"},bRe={class:"h-full flex flex-col md:flex-row relative"},CRe={class:"md:hidden flex items-center justify-between p-4 glass-panel sticky top-0 z-20"},wRe={class:"flex-1 px-4 space-y-1 overflow-y-auto"},SRe={class:"flex-1 overflow-hidden relative flex flex-col bg-slate-50/50"},yRe={key:0,class:"fixed top-4 right-4 z-50 toast"},LRe={class:"text-sm font-medium"},xRe={key:1,class:"h-full flex flex-col"},DRe={class:"px-4 md:px-8 pb-6"},kRe={class:"flex flex-col sm:flex-row gap-3"},IRe={class:"relative flex-1"},ERe={class:"flex gap-2 overflow-x-auto pb-1 sm:pb-0"},NRe={key:0,class:"flex-1 flex items-center justify-center"},TRe={key:1,class:"flex-1 overflow-y-auto px-4 md:px-8 pb-8"},MRe={key:0,class:"flex flex-col items-center justify-center h-64 text-slate-400"},RRe={class:"flex flex-col md:flex-row gap-4 items-start md:items-center"},ARe={class:"flex-1 min-w-0"},PRe={class:"flex items-center gap-2 mb-1"},ORe=["onClick"],FRe={class:"flex flex-wrap items-center gap-x-4 gap-y-2 text-xs text-slate-500 font-medium"},BRe={class:"flex items-center gap-1"},WRe={class:"flex items-center gap-1 font-mono text-slate-400 bg-slate-50 px-1.5 py-0.5 rounded"},HRe={class:"flex items-center gap-2 w-full md:w-auto mt-2 md:mt-0 pt-3 md:pt-0 border-t md:border-t-0 border-slate-100"},VRe=["href"],zRe=["onClick"],URe=["onClick"],$Re={key:2,class:"h-full flex flex-col bg-white"},jRe={class:"h-16 border-b border-slate-200 flex items-center justify-between px-4 md:px-6 bg-white z-10"},KRe={class:"flex items-center gap-3"},qRe={class:"text-sm font-semibold text-slate-500 hidden md:block"},GRe={class:"flex items-center gap-3"},ZRe={class:"flex items-center gap-2 mr-2"},YRe=["disabled"],XRe={key:0,class:"ph ph-warning-circle text-lg"},QRe={key:1,class:"ph ph-spinner animate-spin text-lg"},JRe={class:"flex-1 flex overflow-hidden"},eAe={class:"flex-1 flex flex-col h-full overflow-hidden relative"},tAe={class:"border-b border-slate-100 p-4 md:px-8 md:pt-8 md:pb-4 space-y-4 bg-white"},iAe={class:"grid grid-cols-1 md:grid-cols-2 gap-4"},nAe={class:"flex items-center gap-2 text-slate-500 border-b border-slate-100 pb-1 focus-within:border-brand-500 transition-colors"},sAe={class:"flex items-center gap-2 text-slate-500 border-b border-slate-100 pb-1 focus-within:border-brand-500 transition-colors"},oAe={class:"flex-1 flex overflow-hidden"},rAe={class:"flex-1 flex flex-col h-full relative"},aAe={class:"w-72 border-l border-slate-200 bg-white hidden lg:flex flex-col overflow-y-auto"},lAe={class:"p-4 space-y-6"},dAe={class:"space-y-2"},cAe={class:"text-xs font-semibold text-slate-500 uppercase"},uAe={key:0,class:"space-y-2"},hAe={class:"flex flex-wrap gap-2"},fAe={class:"space-y-3 border-t border-slate-100 pt-4"},gAe={class:"flex items-center justify-between"},pAe={key:0,class:"text-[11px] text-emerald-600 font-semibold"},mAe={key:1,class:"text-[11px] text-slate-400 font-semibold"},_Ae={key:0,class:"space-y-3"},vAe={class:"flex items-center gap-2"},bAe=["disabled"],CAe={class:"flex items-center gap-2 text-[11px] text-slate-500"},wAe={class:"flex items-center gap-2 text-[11px] text-slate-500"},SAe=["disabled"],yAe={key:0,class:"text-xs text-slate-600 bg-slate-50 border border-slate-100 rounded-lg p-2"},LAe={key:1,class:"text-xs text-slate-500"},xAe={key:3,class:"h-full flex flex-col overflow-y-auto"},DAe={class:"p-4 md:p-8 pb-4 flex flex-col sm:flex-row sm:items-center justify-between gap-4"},kAe=["disabled"],IAe={key:0,class:"ph ph-spinner animate-spin"},EAe={class:"px-4 md:px-8 pb-8 space-y-6"},NAe={key:0,class:"flex items-center gap-2 text-slate-500"},TAe={key:1,class:"grid grid-cols-1 lg:grid-cols-2 gap-6"},MAe={class:"bg-white border border-slate-200/60 rounded-2xl p-5 shadow-sm space-y-4"},RAe={class:"flex items-center justify-between"},AAe={class:"space-y-2"},PAe={class:"space-y-2"},OAe={class:"space-y-2"},FAe={class:"space-y-2"},BAe={class:"grid grid-cols-2 gap-3"},WAe={class:"space-y-2"},HAe={class:"space-y-2"},VAe={class:"bg-white border border-slate-200/60 rounded-2xl p-5 shadow-sm space-y-4"},zAe={class:"flex items-center justify-between"},UAe={class:"space-y-2"},$Ae={class:"space-y-2"},jAe={class:"space-y-2"},KAe={class:"space-y-2"},qAe={class:"grid grid-cols-2 gap-3"},GAe={class:"space-y-2"},ZAe={class:"space-y-2"},YAe={key:4,class:"h-full flex flex-col overflow-y-auto"},XAe={class:"p-4 md:p-8 pb-4 flex flex-col sm:flex-row sm:items-center justify-between gap-4"},QAe=["disabled"],JAe={key:0,class:"ph ph-spinner animate-spin"},e2e={class:"px-4 md:px-8 pb-8 space-y-6"},t2e={class:"bg-white border border-slate-200/60 rounded-2xl p-5 shadow-sm space-y-4"},i2e={class:"bg-white border border-slate-200/60 rounded-2xl p-5 shadow-sm"},n2e={class:"flex items-center justify-between gap-4"},s2e={class:"bg-white border border-slate-200/60 rounded-2xl p-5 shadow-sm space-y-4"},o2e={class:"flex items-center justify-between gap-4"},r2e={class:"flex flex-col md:flex-row md:items-center md:justify-between gap-3"},a2e={class:"text-xs text-slate-500"},l2e={class:"font-semibold text-slate-700"},d2e={class:"font-semibold text-slate-700"},c2e={class:"flex gap-2"},u2e=["disabled"],h2e=["disabled"],f2e={class:"grid grid-cols-1 md:grid-cols-2 gap-3"},g2e={class:"space-y-1"},p2e={class:"space-y-1"},m2e={class:"space-y-1"},_2e={class:"bg-white border border-slate-200/60 rounded-2xl p-5 shadow-sm"},v2e={class:"flex flex-col md:flex-row md:items-center md:justify-between gap-4"},b2e={class:"bg-white border border-slate-200/60 rounded-2xl p-5 shadow-sm space-y-4"},C2e={class:"flex flex-col md:flex-row md:items-center md:justify-between gap-3"},w2e={class:"flex flex-wrap gap-2"},S2e=["onClick"],y2e={key:0,class:"flex items-center gap-2 text-slate-500"},L2e={key:1,class:"text-sm text-slate-500"},x2e={key:2,class:"space-y-3"},D2e={class:"flex flex-col md:flex-row md:items-center md:justify-between gap-2"},k2e={class:"text-sm font-semibold text-slate-900"},I2e={class:"text-xs text-slate-500"},E2e={class:"text-sm text-slate-700 mt-3 whitespace-pre-wrap"},N2e={key:0,class:"text-xs text-rose-600 mt-2"},T2e={class:"flex flex-wrap gap-2 mt-4"},M2e=["onClick"],R2e=["onClick"],A2e=["onClick"],P2e=["onClick"],O2e={key:5,class:"h-full flex flex-col overflow-y-auto"},F2e={class:"p-4 md:p-8 pb-4 flex flex-col sm:flex-row sm:items-center justify-between gap-4"},B2e=["disabled"],W2e={key:0,class:"ph ph-spinner animate-spin"},H2e={class:"px-4 md:px-8 pb-8 space-y-6"},V2e={class:"bg-white border border-slate-200/60 rounded-2xl p-5 shadow-sm space-y-3"},z2e=["disabled"],U2e={class:"bg-white border border-slate-200/60 rounded-2xl p-5 shadow-sm space-y-4"},$2e={class:"flex flex-col md:flex-row md:items-center gap-3"},j2e=["disabled"],K2e={key:0,class:"ph ph-spinner animate-spin"},q2e={key:0,class:"text-sm text-slate-600 bg-slate-50 border border-slate-200/60 rounded-xl p-4"},G2e={__name:"App",setup(s){const e=ai(!1),t=ai("list"),i=ai(""),n=ai("all"),o=ai(window.innerWidth),r=ai(!1),a=ai(!1),l=ai([]),d=ai(""),c=ai(Yn()),u=ai({smart:!1,dumb:!1}),h=ai(!1),f=ai(!1),g=ai("smart"),m=ai(""),_=ai(!1),v=ai(""),b=ai(!1),C=ai(!0),w=ai(null),S=ai({comments_enabled:!0,notifications_enabled:!1,vapid_public_key:"",vapid_private_key:"",vapid_subscriber:"",date_display:"absolute",title:"",description:"",google_analytics_code:""}),L=ai(!1),D=ai(!1),I=ai({supported:!1,notifications_enabled:!1,public_key:"",private_key:"",subscriber:""}),T=ai(typeof Notification<"u"?Notification.permission:"default"),V=ai(!1),P=ai(!1),A=ai([]),B=ai(!1),Q=ai("pending"),ae=ai(null),we=ai(!1),Le=ai(!1),le=ai(null),oe=[{key:"pending",label:"Pending"},{key:"rejected",label:"Rejected"},{key:"approved",label:"Approved"},{key:"hidden",label:"Hidden"},{key:"all",label:"All"}],Pe=ai({show:!1,message:"",type:"success"}),de=ai({id:null,title:"",subtitle:"",slug:"",date:"",published:!1,publishedAt:null,description:"",content:"",tags:[]}),Ne=Lg(()=>!de.value.id),be=Lg(()=>JSON.stringify(de.value)!==d.value),di=Lg(()=>l.value.filter(J=>{const O=(J.title||"").toLowerCase().includes(i.value.toLowerCase())||(J.slug||"").toLowerCase().includes(i.value.toLowerCase());return n.value==="all"?O:n.value==="published"?O&&J.published_at:n.value==="draft"?O&&!J.published_at:O})),jt=Lg(()=>{const J=de.value.description.length;return J>160?"text-rose-500 font-bold":J>140?"text-amber-500":"text-slate-400"});function nt(J,O="success"){Pe.value={show:!0,message:J,type:O},setTimeout(()=>{Pe.value.show=!1},3e3)}const Ct=J=>J?new Date(J).toLocaleDateString("en-US",{month:"short",day:"numeric",year:"numeric"}):"Draft";function Qt(J=new Date){const O=J.getFullYear(),ce=String(J.getMonth()+1).padStart(2,"0"),ri=String(J.getDate()).padStart(2,"0");return`${O}-${ce}-${ri}`}function wn(J){if(!J)return Qt();const O=new Date(J);return Number.isNaN(O.getTime())?Qt():Qt(O)}function Ai(J,O){return!J||!O?!1:J===wn(O)}function rt(J){const O=Qt();if(!J||J===O)return new Date().toISOString();const[ce,ri,kn]=J.split("-").map(Number);return!ce||!ri||!kn?new Date().toISOString():new Date(ce,ri-1,kn,12,0,0,0).toISOString()}async function K(){r.value=!0;try{l.value=await Dee()}catch(J){nt("Failed to load posts: "+J.message,"error")}finally{r.value=!1}}async function ee(){h.value=!0;try{const J=await Fee();c.value=J!=null&&J.settings?Ht(J.settings):Yn(),u.value={smart:!!(J!=null&&J.smart_enabled),dumb:!!(J!=null&&J.dumb_enabled)},!u.value.dumb&&g.value==="dumb"&&(g.value="smart")}catch(J){nt("Failed to load AI settings: "+J.message,"error")}finally{h.value=!1}}async function _e(){f.value=!0;try{const J=Ht(c.value),O=await Bee(J);c.value=Ht((O==null?void 0:O.settings)||J),u.value={smart:!!(O!=null&&O.smart_enabled),dumb:!!(O!=null&&O.dumb_enabled)},!u.value.dumb&&g.value==="dumb"&&(g.value="smart"),nt("AI settings saved")}catch(J){nt("Failed to save AI settings: "+J.message,"error")}finally{f.value=!1}}async function Ee(){L.value=!0;try{const J=await Nee();S.value=J||{comments_enabled:!0,notifications_enabled:!1,vapid_public_key:"",vapid_private_key:"",vapid_subscriber:"",date_display:"absolute",title:"",description:"",google_analytics_code:""},await Ie()}catch(J){nt("Failed to load blog settings: "+J.message,"error")}finally{L.value=!1}}async function xe(){D.value=!0;try{const J=await Tee({comments_enabled:!!S.value.comments_enabled,notifications_enabled:!!S.value.notifications_enabled,vapid_public_key:S.value.vapid_public_key||"",vapid_private_key:S.value.vapid_private_key||"",vapid_subscriber:S.value.vapid_subscriber||"",date_display:S.value.date_display||"absolute",title:S.value.title||"",description:S.value.description||"",google_analytics_code:S.value.google_analytics_code||""});S.value=J||S.value,nt("Blog settings saved")}catch(J){nt("Failed to save blog settings: "+J.message,"error")}finally{D.value=!1}}async function Ie(){var J,O,ce;try{I.value=await OB(),(J=I.value)!=null&&J.public_key&&(S.value.vapid_public_key=I.value.public_key),(O=I.value)!=null&&O.private_key&&(S.value.vapid_private_key=I.value.private_key),(ce=I.value)!=null&&ce.subscriber&&(S.value.vapid_subscriber=I.value.subscriber)}catch{I.value={supported:!1,notifications_enabled:!1,public_key:"",private_key:"",subscriber:""}}await Ke()}async function Ke(){if(T.value=typeof Notification<"u"?Notification.permission:"default",V.value=!1,!(!("serviceWorker"in navigator)||!("PushManager"in window)))try{const J=await navigator.serviceWorker.getRegistration("/blog/admin/");if(!J)return;const O=await J.pushManager.getSubscription();V.value=!!O}catch{V.value=!1}}async function Ue(){if(!S.value.notifications_enabled){nt("Enable Notifications in settings first","error");return}P.value=!0;try{const J=await OB();if(I.value=J||{supported:!1,notifications_enabled:!1,public_key:"",private_key:"",subscriber:""},!(J!=null&&J.supported)||!(J!=null&&J.public_key))throw new Error("Push notifications are not configured on this server");if(!(J!=null&&J.notifications_enabled))throw new Error("Save settings after enabling notifications, then try again");if(!("serviceWorker"in navigator)||!("PushManager"in window)||typeof Notification>"u")throw new Error("This browser does not support push notifications");const O=await Notification.requestPermission();if(T.value=O,O!=="granted")throw new Error("Notification permission was not granted");const ce="/blog/admin/",ri=await navigator.serviceWorker.register(`${ce}sw.js`,{scope:ce});let kn=await ri.pushManager.getSubscription();kn||(kn=await ri.pushManager.subscribe({userVisibleOnly:!0,applicationServerKey:zi(J.public_key)})),await Pee(kn.toJSON()),V.value=!0,nt("Notifications enabled for this browser")}catch(J){nt("Failed to enable notifications: "+J.message,"error")}finally{P.value=!1}}async function G(){P.value=!0;try{if(!("serviceWorker"in navigator)||!("PushManager"in window)){V.value=!1;return}const J=await navigator.serviceWorker.getRegistration("/blog/admin/");if(!J){V.value=!1;return}const O=await J.pushManager.getSubscription();if(!O){V.value=!1;return}const ce=O.endpoint;await O.unsubscribe(),await Oee(ce),V.value=!1,nt("Notifications disabled for this browser")}catch(J){nt("Failed to disable notifications: "+J.message,"error")}finally{P.value=!1}}async function W(){B.value=!0;try{const J=Q.value==="all"?"":Q.value;A.value=await Mee(J?{status:J}:{})}catch(J){nt("Failed to load comments: "+J.message,"error")}finally{B.value=!1}}function q(J){switch(J){case"approved":return"bg-emerald-50 text-emerald-700";case"pending":return"bg-amber-50 text-amber-700";case"rejected":return"bg-rose-50 text-rose-700";case"hidden":return"bg-slate-100 text-slate-600";default:return"bg-slate-100 text-slate-600"}}async function E(J,O){try{await Ree(J.id,O),await W(),nt("Comment updated")}catch(ce){nt("Failed to update comment: "+ce.message,"error")}}async function R(J){if(confirm("Delete this comment?"))try{await Aee(J.id),await W(),nt("Comment deleted")}catch(O){nt("Failed to delete comment: "+O.message,"error")}}function $(){c.value.dumb={...c.value.smart}}function H(J){var ce,ri;const O=((ri=(ce=J.target)==null?void 0:ce.files)==null?void 0:ri[0])||null;ae.value=O,le.value=null}async function te(){we.value=!0;try{const J=await Hee(),O=URL.createObjectURL(J),ce=document.createElement("a");ce.href=O,ce.download=`blog-export-${new Date().toISOString().slice(0,10)}.xml`,document.body.appendChild(ce),ce.click(),ce.remove(),URL.revokeObjectURL(O),nt("Export downloaded")}catch(J){nt("Failed to export: "+J.message,"error")}finally{we.value=!1}}async function pe(){if(!ae.value){nt("Select a WXR file to import","error");return}Le.value=!0;try{const J=await Vee(ae.value);le.value=J,ae.value=null,await K(),nt("Import completed")}catch(J){nt("Failed to import: "+J.message,"error")}finally{Le.value=!1}}async function Me(){if(m.value.trim()){if(g.value==="dumb"&&!u.value.dumb){nt("Dumb AI is not configured","error");return}if(!u.value.smart&&g.value==="smart"){nt("Smart AI is not configured","error");return}_.value=!0,v.value="";try{const J=await Wee({mode:g.value,content_markdown:de.value.content,query:m.value,web_search:b.value}),O=(J==null?void 0:J.content_markdown)||"";if(!O){nt("AI response was empty","error");return}const ce=de.value.content;de.value.content=O,v.value=(J==null?void 0:J.notes)||"",C.value?w.value={previous:ce,current:O,nonce:Date.now()}:w.value=null,m.value="",nt("AI changes applied")}catch(J){nt("AI request failed: "+J.message,"error")}finally{_.value=!1}}}const Oe=()=>{de.value={id:null,title:"",subtitle:"",slug:"",date:Qt(),published:!1,publishedAt:null,description:"",content:"",tags:[]},v.value="",m.value="",w.value=null;const J=localStorage.getItem("autosave_new");if(J)try{const O=JSON.parse(J);confirm("Found an unsaved new post. Do you want to restore it?")?de.value=O:localStorage.removeItem("autosave_new")}catch(O){console.error(O)}d.value=JSON.stringify(de.value),t.value="editor"},tt=J=>{const O={id:J.id,title:J.title||"",subtitle:J.subtitle||"",slug:J.slug||"",date:J.published_at?wn(J.published_at):Qt(),published:!!J.published_at,publishedAt:J.published_at||null,updatedAt:J.updated_at||null,description:J.meta_description||"",content:J.content_markdown||"",tags:J.tags||[]},ce=`autosave_${J.id}`,ri=localStorage.getItem(ce);if(ri)try{const kn=JSON.parse(ri);JSON.stringify(kn)!==JSON.stringify(O)&&confirm(`Found unsaved changes for this post from ${new Date().toLocaleDateString()}. Restore them?`)?de.value=kn:(de.value=O,localStorage.removeItem(ce))}catch{de.value=O}else de.value=O;d.value=JSON.stringify(de.value),t.value="editor",m.value="",v.value="",w.value=null};async function et(){if(!de.value.title){nt("Title is required","error");return}if(!de.value.slug){nt("Slug is required","error");return}a.value=!0;try{let J=null;de.value.published&&(de.value.publishedAt&&Ai(de.value.date,de.value.publishedAt)?J=de.value.publishedAt:J=rt(de.value.date));const O={title:de.value.title,subtitle:de.value.subtitle,slug:de.value.slug,content_markdown:de.value.content,content_html:kz.sanitize(Hi.parse(de.value.content||"")),meta_description:de.value.description,published_at:J,updated_at:de.value.updatedAt||void 0,author_id:1};de.value.id?(await Iee(de.value.id,O),nt("Post updated successfully!"),localStorage.removeItem(`autosave_${de.value.id}`)):(await kee(O),nt("Post created successfully!"),localStorage.removeItem("autosave_new")),await K(),t.value="list"}catch(J){nt(J.status===409?"This post was changed since you opened it. Reload it before saving.":"Failed to save: "+J.message,"error")}finally{a.value=!1}}async function Bt(J){if(confirm(`Delete post "${J.title||J.slug}"?`))try{await Eee(J.id),await K(),nt("Post deleted successfully!")}catch(O){nt("Failed to delete: "+O.message,"error")}}const Vt=()=>{be.value&&!confirm("You have unsaved changes. Are you sure you want to leave?")||(t.value="list")},Be=J=>J.toString().toLowerCase().replace(/\s+/g,"-").replace(/[^\w\-]+/g,"").replace(/\-\-+/g,"-").replace(/^-+/,"").replace(/-+$/,""),Wt=()=>{(Ne.value||!de.value.slug)&&(de.value.slug=Be(de.value.title))},ci=()=>{o.value=window.innerWidth};$D(()=>{window.addEventListener("resize",ci),Hi.setOptions({breaks:!0,gfm:!0}),K(),ee();const J=new URLSearchParams(window.location.search).get("view");(J==="comments"||J==="list"||J==="ai-settings"||J==="wxr")&&(t.value=J)}),Cl(Q,()=>{t.value==="comments"&&W()}),Cl(t,J=>{J==="comments"&&(Ee(),W())}),Cl(de,J=>{if(t.value==="editor"){const O=J.id?`autosave_${J.id}`:"autosave_new";JSON.stringify(J)!==d.value&&localStorage.setItem(O,JSON.stringify(J))}},{deep:!0}),Cl(C,J=>{J||(w.value=null)}),QO(()=>{window.removeEventListener("resize",ci)});function Yn(){return{smart:{provider:"",model:"",api_key:"",base_url:"",temperature:null,max_tokens:null},dumb:{provider:"",model:"",api_key:"",base_url:"",temperature:null,max_tokens:null}}}function Ht(J){var vi,yo,ya,pc,Ys,Qa,Ja,mc,Uo,$o,jo,$u;const O=Number.isFinite((vi=J==null?void 0:J.smart)==null?void 0:vi.temperature)?J.smart.temperature:null,ce=Number.isFinite((yo=J==null?void 0:J.smart)==null?void 0:yo.max_tokens)?J.smart.max_tokens:null,ri=Number.isFinite((ya=J==null?void 0:J.dumb)==null?void 0:ya.temperature)?J.dumb.temperature:null,kn=Number.isFinite((pc=J==null?void 0:J.dumb)==null?void 0:pc.max_tokens)?J.dumb.max_tokens:null;return{smart:{provider:((Ys=J==null?void 0:J.smart)==null?void 0:Ys.provider)||"",model:((Qa=J==null?void 0:J.smart)==null?void 0:Qa.model)||"",api_key:((Ja=J==null?void 0:J.smart)==null?void 0:Ja.api_key)||"",base_url:((mc=J==null?void 0:J.smart)==null?void 0:mc.base_url)||"",temperature:O,max_tokens:ce},dumb:{provider:((Uo=J==null?void 0:J.dumb)==null?void 0:Uo.provider)||"",model:(($o=J==null?void 0:J.dumb)==null?void 0:$o.model)||"",api_key:((jo=J==null?void 0:J.dumb)==null?void 0:jo.api_key)||"",base_url:(($u=J==null?void 0:J.dumb)==null?void 0:$u.base_url)||"",temperature:ri,max_tokens:kn}}}function zi(J){const O="=".repeat((4-J.length%4)%4),ce=(J+O).replace(/-/g,"+").replace(/_/g,"/"),ri=window.atob(ce),kn=new Uint8Array(ri.length);for(let vi=0;vi<ri.length;vi+=1)kn[vi]=ri.charCodeAt(vi);return kn}return(J,O)=>(Kt(),Zt("div",vRe,[F("div",bRe,[F("header",CRe,[O[44]||(O[44]=F("div",{class:"flex items-center gap-2"},[F("div",{class:"w-8 h-8 rounded-lg bg-brand-600 flex items-center justify-center text-white"},[F("i",{class:"ph ph-hexagon text-xl"})]),F("span",{class:"font-bold text-lg tracking-tight"},"Blog Admin")],-1)),F("button",{onClick:O[0]||(O[0]=ce=>e.value=!e.value),class:"p-2 text-slate-600 hover:bg-slate-100 rounded-full transition-colors"},[...O[43]||(O[43]=[F("i",{class:"ph ph-list text-2xl"},null,-1)])])]),F("aside",{class:ei(["fixed md:static inset-y-0 left-0 z-30 w-64 bg-white/80 md:bg-white/50 backdrop-blur-xl border-r border-slate-200/60 transform transition-transform duration-300 ease-in-out flex flex-col",e.value?"translate-x-0":"-translate-x-full md:translate-x-0"])},[O[51]||(O[51]=tS('<div class="p-6 hidden md:flex items-center gap-3 mb-6"><div class="w-10 h-10 rounded-xl bg-gradient-to-br from-brand-500 to-indigo-600 flex items-center justify-center text-white shadow-lg shadow-brand-500/30"><i class="ph ph-hexagon-fill text-2xl"></i></div><div><h1 class="font-bold text-xl tracking-tight text-slate-900">Blog</h1><p class="text-xs text-slate-500 font-medium">Admin Panel</p></div></div>',1)),F("nav",wRe,[O[50]||(O[50]=F("p",{class:"px-2 text-xs font-semibold text-slate-400 uppercase tracking-wider mb-2 mt-4"},"Content",-1)),F("a",{href:"#",onClick:O[1]||(O[1]=nb(ce=>{t.value="list",e.value=!1},["prevent"])),class:ei(["flex items-center gap-3 px-3 py-2.5 rounded-xl text-sm font-medium transition-all duration-200 group",t.value==="list"?"bg-brand-50 text-brand-700 shadow-sm ring-1 ring-brand-100":"text-slate-600 hover:bg-white hover:shadow-sm"])},[O[45]||(O[45]=F("i",{class:"ph ph-article-ny-times text-lg"},null,-1)),O[46]||(O[46]=En(" All Posts ",-1)),F("span",{class:ei(["ml-auto text-xs font-bold px-2 py-0.5 rounded-full",t.value==="list"?"bg-brand-100 text-brand-700":"bg-slate-100 text-slate-500"])},Pi(l.value.length),3)],2),F("a",{href:"#",onClick:O[2]||(O[2]=nb(ce=>{t.value="ai-settings",e.value=!1},["prevent"])),class:ei(["flex items-center gap-3 px-3 py-2.5 rounded-xl text-sm font-medium transition-all duration-200 group",t.value==="ai-settings"?"bg-brand-50 text-brand-700 shadow-sm ring-1 ring-brand-100":"text-slate-600 hover:bg-white hover:shadow-sm"])},[...O[47]||(O[47]=[F("i",{class:"ph ph-brain text-lg"},null,-1),En(" AI Settings ",-1)])],2),F("a",{href:"#",onClick:O[3]||(O[3]=nb(ce=>{t.value="comments",e.value=!1},["prevent"])),class:ei(["flex items-center gap-3 px-3 py-2.5 rounded-xl text-sm font-medium transition-all duration-200 group",t.value==="comments"?"bg-brand-50 text-brand-700 shadow-sm ring-1 ring-brand-100":"text-slate-600 hover:bg-white hover:shadow-sm"])},[...O[48]||(O[48]=[F("i",{class:"ph ph-gear text-lg"},null,-1),En(" Settings ",-1)])],2),F("a",{href:"#",onClick:O[4]||(O[4]=nb(ce=>{t.value="wxr",e.value=!1},["prevent"])),class:ei(["flex items-center gap-3 px-3 py-2.5 rounded-xl text-sm font-medium transition-all duration-200 group",t.value==="wxr"?"bg-brand-50 text-brand-700 shadow-sm ring-1 ring-brand-100":"text-slate-600 hover:bg-white hover:shadow-sm"])},[...O[49]||(O[49]=[F("i",{class:"ph ph-arrows-clockwise text-lg"},null,-1),En(" Import / Export ",-1)])],2)]),O[52]||(O[52]=tS('<div class="p-4 border-t border-slate-200/60"><div class="flex items-center gap-3 w-full p-2 rounded-xl"><div class="w-8 h-8 rounded-full bg-brand-100 flex items-center justify-center text-brand-600"><i class="ph ph-user text-lg"></i></div><div class="text-left flex-1 min-w-0"><p class="text-sm font-semibold text-slate-900 truncate">Admin</p><p class="text-xs text-slate-500 truncate">Blog Administrator</p></div></div></div>',1))],2),e.value?(Kt(),Zt("div",{key:0,onClick:O[5]||(O[5]=ce=>e.value=!1),class:"fixed inset-0 bg-slate-900/20 backdrop-blur-sm z-20 md:hidden"})):ms("",!0),F("main",SRe,[Pe.value.show?(Kt(),Zt("div",yRe,[F("div",{class:ei(["px-4 py-3 rounded-xl shadow-lg flex items-center gap-3",Pe.value.type==="success"?"bg-emerald-500 text-white":"bg-rose-500 text-white"])},[F("i",{class:ei(["ph text-lg",Pe.value.type==="success"?"ph-check-circle":"ph-warning-circle"])},null,2),F("span",LRe,Pi(Pe.value.message),1)],2)])):ms("",!0),t.value==="list"?(Kt(),Zt("div",xRe,[F("div",{class:"p-4 md:p-8 pb-4 flex flex-col sm:flex-row sm:items-center justify-between gap-4"},[O[54]||(O[54]=F("div",null,[F("h2",{class:"text-2xl font-bold text-slate-900"},"Posts"),F("p",{class:"text-slate-500 text-sm mt-1"},"Manage and publish your blog content")],-1)),F("button",{onClick:Oe,class:"bg-slate-900 hover:bg-slate-800 text-white px-5 py-2.5 rounded-xl text-sm font-medium shadow-lg shadow-slate-900/20 flex items-center gap-2 transition-all active:scale-95 w-full sm:w-auto justify-center"},[...O[53]||(O[53]=[F("i",{class:"ph ph-plus-bold"},null,-1),En(" New Post ",-1)])])]),F("div",DRe,[F("div",kRe,[F("div",IRe,[O[55]||(O[55]=F("i",{class:"ph ph-magnifying-glass absolute left-3 top-1/2 -translate-y-1/2 text-slate-400"},null,-1)),Ui(F("input",{"onUpdate:modelValue":O[6]||(O[6]=ce=>i.value=ce),type:"text",placeholder:"Search posts...",class:"w-full pl-10 pr-4 py-2.5 bg-white border border-slate-200 rounded-xl text-sm focus:outline-none focus:ring-2 focus:ring-brand-500/20 focus:border-brand-500 transition-all shadow-sm"},null,512),[[zn,i.value]])]),F("div",ERe,[F("button",{onClick:O[7]||(O[7]=ce=>n.value="all"),class:ei(["px-4 py-2.5 rounded-xl text-sm font-medium whitespace-nowrap transition-all border",n.value==="all"?"bg-white border-slate-200 text-slate-900 shadow-sm":"border-transparent text-slate-500 hover:bg-white hover:text-slate-700"])}," All Posts ",2),F("button",{onClick:O[8]||(O[8]=ce=>n.value="published"),class:ei(["px-4 py-2.5 rounded-xl text-sm font-medium whitespace-nowrap transition-all border",n.value==="published"?"bg-emerald-50 border-emerald-100 text-emerald-700 shadow-sm":"border-transparent text-slate-500 hover:bg-white hover:text-slate-700"])}," Published ",2),F("button",{onClick:O[9]||(O[9]=ce=>n.value="draft"),class:ei(["px-4 py-2.5 rounded-xl text-sm font-medium whitespace-nowrap transition-all border",n.value==="draft"?"bg-amber-50 border-amber-100 text-amber-700 shadow-sm":"border-transparent text-slate-500 hover:bg-white hover:text-slate-700"])}," Drafts ",2)])])]),r.value?(Kt(),Zt("div",NRe,[...O[56]||(O[56]=[F("div",{class:"flex flex-col items-center gap-3 text-slate-400"},[F("i",{class:"ph ph-spinner text-4xl animate-spin"}),F("p",{class:"text-sm"},"Loading posts...")],-1)])])):(Kt(),Zt("div",TRe,[di.value.length===0?(Kt(),Zt("div",MRe,[...O[57]||(O[57]=[F("i",{class:"ph ph-files text-4xl mb-3 opacity-50"},null,-1),F("p",null,"No posts found matching your criteria.",-1)])])):ms("",!0),da(uJ,{name:"list",tag:"div",class:"space-y-3"},{default:EV(()=>[(Kt(!0),Zt(No,null,eS(di.value,ce=>(Kt(),Zt("div",{key:ce.id,class:"group bg-white rounded-2xl p-4 border border-slate-200/60 shadow-sm hover:shadow-md hover:border-brand-200 transition-all duration-300 relative overflow-hidden"},[F("div",RRe,[F("div",{class:ei(["w-12 h-12 rounded-xl flex items-center justify-center shrink-0",ce.published_at?"bg-emerald-50 text-emerald-600":"bg-amber-50 text-amber-600"])},[F("i",{class:ei(["text-xl ph",ce.published_at?"ph-check-circle-fill":"ph-pencil-simple-slash-fill"])},null,2)],2),F("div",ARe,[F("div",PRe,[F("a",{href:"#",onClick:nb(ri=>tt(ce),["prevent"]),class:"font-bold text-slate-900 truncate text-lg group-hover:text-brand-600 transition-colors"},Pi(ce.title||"(Untitled)"),9,ORe)]),F("div",FRe,[F("span",BRe,[O[58]||(O[58]=F("i",{class:"ph ph-calendar-blank"},null,-1)),En(" "+Pi(Ct(ce.published_at)),1)]),F("span",WRe," /"+Pi(ce.slug),1)])]),F("div",HRe,[ce.published_at?(Kt(),Zt("a",{key:0,href:`/blog/${ce.slug}`,target:"_blank",class:"p-2 rounded-lg text-slate-400 hover:bg-sky-50 hover:text-sky-600 transition-colors",title:"View Public Post"},[...O[59]||(O[59]=[F("i",{class:"ph ph-arrow-square-out text-lg"},null,-1)])],8,VRe)):ms("",!0),F("button",{onClick:ri=>tt(ce),class:"flex-1 md:flex-none py-2 md:py-1.5 px-4 rounded-lg bg-slate-50 text-slate-600 hover:bg-brand-50 hover:text-brand-600 text-sm font-medium transition-colors"}," Edit ",8,zRe),F("button",{onClick:ri=>Bt(ce),class:"p-2 rounded-lg text-slate-400 hover:bg-rose-50 hover:text-rose-600 transition-colors"},[...O[60]||(O[60]=[F("i",{class:"ph ph-trash text-lg"},null,-1)])],8,URe)])])]))),128))]),_:1})]))])):t.value==="editor"?(Kt(),Zt("div",$Re,[F("div",jRe,[F("div",KRe,[F("button",{onClick:Vt,class:"p-2 -ml-2 text-slate-400 hover:text-slate-800 hover:bg-slate-100 rounded-full transition-colors"},[...O[61]||(O[61]=[F("i",{class:"ph ph-arrow-left text-xl"},null,-1)])]),O[62]||(O[62]=F("div",{class:"h-6 w-px bg-slate-200 hidden md:block"},null,-1)),F("span",qRe,Pi(Ne.value?"New Post":"Editing Post"),1)]),F("div",GRe,[F("div",ZRe,[O[63]||(O[63]=F("span",{class:"text-xs font-semibold text-slate-500 uppercase hidden sm:block"},"Published",-1)),F("button",{onClick:O[10]||(O[10]=ce=>de.value.published=!de.value.published),class:ei(["w-11 h-6 rounded-full relative transition-colors duration-300 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-brand-500",de.value.published?"bg-emerald-500":"bg-slate-300"])},[F("span",{class:ei(["absolute top-1 left-1 w-4 h-4 bg-white rounded-full shadow-sm transition-transform duration-300",de.value.published?"translate-x-5":"translate-x-0"])},null,2)],2)]),F("button",{onClick:et,disabled:a.value,class:ei(["text-white px-5 py-2 rounded-xl text-sm font-bold shadow-lg transition-all active:scale-95 flex items-center gap-2",a.value?"bg-slate-800 opacity-50 cursor-not-allowed":be.value?"bg-amber-600 hover:bg-amber-700 shadow-amber-600/20":"bg-slate-900 hover:bg-slate-800 shadow-slate-900/20"])},[be.value&&!a.value?(Kt(),Zt("i",XRe)):ms("",!0),a.value?(Kt(),Zt("i",QRe)):ms("",!0),En(" "+Pi(a.value?"Saving...":be.value?"Unsaved Changes":"Saved"),1)],10,YRe)])]),F("div",JRe,[F("div",eAe,[F("div",tAe,[Ui(F("input",{"onUpdate:modelValue":O[11]||(O[11]=ce=>de.value.title=ce),onInput:Wt,type:"text",placeholder:"Post Title",class:"w-full text-2xl md:text-3xl font-bold placeholder-slate-300 border-none focus:ring-0 p-0 text-slate-900 bg-transparent outline-none"},null,544),[[zn,de.value.title]]),Ui(F("input",{"onUpdate:modelValue":O[12]||(O[12]=ce=>de.value.subtitle=ce),type:"text",placeholder:"Subtitle (optional)",class:"w-full text-lg md:text-xl font-medium placeholder-slate-300 border-none focus:ring-0 p-0 text-slate-700 bg-transparent outline-none"},null,512),[[zn,de.value.subtitle]]),F("div",iAe,[F("div",nAe,[O[64]||(O[64]=F("i",{class:"ph ph-link text-lg"},null,-1)),O[65]||(O[65]=F("span",{class:"text-xs font-mono text-slate-400"},"/",-1)),Ui(F("input",{"onUpdate:modelValue":O[13]||(O[13]=ce=>de.value.slug=ce),type:"text",placeholder:"url-slug",class:"w-full text-sm font-mono bg-transparent border-none focus:ring-0 p-0 text-slate-600 outline-none"},null,512),[[zn,de.value.slug]])]),F("div",sAe,[O[66]||(O[66]=F("i",{class:"ph ph-calendar-blank text-lg"},null,-1)),Ui(F("input",{"onUpdate:modelValue":O[14]||(O[14]=ce=>de.value.date=ce),type:"date",class:"w-full text-sm bg-transparent border-none focus:ring-0 p-0 text-slate-600 placeholder-slate-400 font-sans outline-none"},null,512),[[zn,de.value.date]])])])]),F("div",oAe,[F("div",rAe,[da(_Re,{modelValue:de.value.content,"onUpdate:modelValue":O[15]||(O[15]=ce=>de.value.content=ce),diffHighlight:w.value},null,8,["modelValue","diffHighlight"])])])]),F("div",aAe,[O[74]||(O[74]=F("div",{class:"p-4 border-b border-slate-100"},[F("h3",{class:"font-bold text-sm text-slate-900"},"SEO & Settings")],-1)),F("div",lAe,[F("div",dAe,[F("label",cAe,[O[67]||(O[67]=En(" Meta Description ",-1)),F("span",{class:ei(["ml-1",jt.value])},Pi(de.value.description.length)+"/160",3)]),Ui(F("textarea",{"onUpdate:modelValue":O[16]||(O[16]=ce=>de.value.description=ce),rows:"4",class:"w-full text-sm p-3 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none transition-all resize-none text-slate-600 leading-relaxed",placeholder:"Enter a meta description..."},null,512),[[zn,de.value.description]])]),de.value.tags&&de.value.tags.length>0?(Kt(),Zt("div",uAe,[O[68]||(O[68]=F("label",{class:"text-xs font-semibold text-slate-500 uppercase"},"Tags",-1)),F("div",hAe,[(Kt(!0),Zt(No,null,eS(de.value.tags,ce=>(Kt(),Zt("span",{key:ce.id,class:"px-2 py-1 bg-brand-50 text-brand-600 rounded-md text-xs font-bold"},Pi(ce.name),1))),128))])])):ms("",!0),F("div",fAe,[F("div",gAe,[O[69]||(O[69]=F("label",{class:"text-xs font-semibold text-slate-500 uppercase"},"AI Assistant",-1)),u.value.smart||u.value.dumb?(Kt(),Zt("span",pAe,"Ready")):(Kt(),Zt("span",mAe,"Not configured"))]),u.value.smart||u.value.dumb?(Kt(),Zt("div",_Ae,[F("div",vAe,[F("button",{onClick:O[17]||(O[17]=ce=>g.value="smart"),class:ei(["px-2 py-1 rounded-md text-xs font-semibold border",g.value==="smart"?"bg-brand-50 text-brand-700 border-brand-100":"border-slate-200 text-slate-500"])},"Smart",2),F("button",{onClick:O[18]||(O[18]=ce=>g.value="dumb"),disabled:!u.value.dumb,class:ei(["px-2 py-1 rounded-md text-xs font-semibold border",g.value==="dumb"?"bg-brand-50 text-brand-700 border-brand-100":"border-slate-200 text-slate-500",u.value.dumb?"":"opacity-50 cursor-not-allowed"])},"Dumb",10,bAe)]),F("label",CAe,[Ui(F("input",{"onUpdate:modelValue":O[19]||(O[19]=ce=>b.value=ce),type:"checkbox",class:"accent-brand-600"},null,512),[[bB,b.value]]),O[70]||(O[70]=En(" Use web search if supported ",-1))]),F("label",wAe,[Ui(F("input",{"onUpdate:modelValue":O[20]||(O[20]=ce=>C.value=ce),type:"checkbox",class:"accent-brand-600"},null,512),[[bB,C.value]]),O[71]||(O[71]=En(" Highlight applied changes in editor ",-1))]),Ui(F("textarea",{"onUpdate:modelValue":O[21]||(O[21]=ce=>m.value=ce),rows:"3",placeholder:"Ask for edits, rewrites, or tone changes...",class:"w-full text-xs p-2 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none resize-none"},null,512),[[zn,m.value]]),F("button",{onClick:Me,disabled:_.value||!m.value,class:ei(["w-full text-xs font-semibold px-3 py-2 rounded-lg text-white transition-all",_.value?"bg-slate-400 cursor-not-allowed":"bg-slate-900 hover:bg-slate-800"])},Pi(_.value?"Thinking...":"Send to AI"),11,SAe),v.value?(Kt(),Zt("div",yAe,[O[72]||(O[72]=F("span",{class:"font-semibold text-slate-700"},"Notes:",-1)),En(" "+Pi(v.value),1)])):ms("",!0),O[73]||(O[73]=F("p",{class:"text-[11px] text-slate-500"},"Changes apply instantly. Use the Diff tab to review AI edits, then return to Markdown to keep editing.",-1))])):(Kt(),Zt("div",LAe,"Configure AI providers in the settings page to enable the assistant."))])])])])])):t.value==="ai-settings"?(Kt(),Zt("div",xAe,[F("div",DAe,[O[75]||(O[75]=F("div",null,[F("h2",{class:"text-2xl font-bold text-slate-900"},"AI Settings"),F("p",{class:"text-slate-500 text-sm mt-1"},"Configure smart and dumb models for the editor assistant")],-1)),F("button",{onClick:_e,disabled:f.value,class:ei(["text-white px-5 py-2.5 rounded-xl text-sm font-medium shadow-lg transition-all active:scale-95 flex items-center gap-2",f.value?"bg-slate-500 cursor-not-allowed":"bg-slate-900 hover:bg-slate-800"])},[f.value?(Kt(),Zt("i",IAe)):ms("",!0),En(" "+Pi(f.value?"Saving...":"Save Settings"),1)],10,kAe)]),F("div",EAe,[h.value?(Kt(),Zt("div",NAe,[...O[76]||(O[76]=[F("i",{class:"ph ph-spinner animate-spin"},null,-1),En(" Loading settings... ",-1)])])):(Kt(),Zt("div",TAe,[F("div",MAe,[F("div",RAe,[O[77]||(O[77]=F("h3",{class:"text-lg font-bold text-slate-900"},"Smart AI",-1)),F("span",{class:ei(["text-xs font-semibold px-2 py-1 rounded-full",u.value.smart?"bg-emerald-50 text-emerald-700":"bg-slate-100 text-slate-500"])},Pi(u.value.smart?"Enabled":"Disabled"),3)]),O[85]||(O[85]=F("p",{class:"text-xs text-slate-500"},"Used to write or edit posts.",-1)),F("div",AAe,[O[79]||(O[79]=F("label",{class:"text-xs font-semibold text-slate-500 uppercase"},"Provider",-1)),Ui(F("select",{"onUpdate:modelValue":O[22]||(O[22]=ce=>c.value.smart.provider=ce),class:"w-full text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},[...O[78]||(O[78]=[tS('<option value="">Select provider</option><option value="openai">OpenAI</option><option value="anthropic">Anthropic</option><option value="gemini">Gemini</option><option value="ollama">Ollama</option>',5)])],512),[[IE,c.value.smart.provider]])]),F("div",PAe,[O[80]||(O[80]=F("label",{class:"text-xs font-semibold text-slate-500 uppercase"},"Model",-1)),Ui(F("input",{"onUpdate:modelValue":O[23]||(O[23]=ce=>c.value.smart.model=ce),type:"text",placeholder:"gpt-4o-mini",class:"w-full text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},null,512),[[zn,c.value.smart.model]])]),F("div",OAe,[O[81]||(O[81]=F("label",{class:"text-xs font-semibold text-slate-500 uppercase"},"API Key",-1)),Ui(F("input",{"onUpdate:modelValue":O[24]||(O[24]=ce=>c.value.smart.api_key=ce),type:"password",placeholder:"sk-...",class:"w-full text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},null,512),[[zn,c.value.smart.api_key]])]),F("div",FAe,[O[82]||(O[82]=F("label",{class:"text-xs font-semibold text-slate-500 uppercase"},"Base URL",-1)),Ui(F("input",{"onUpdate:modelValue":O[25]||(O[25]=ce=>c.value.smart.base_url=ce),type:"text",placeholder:"https://api.openai.com",class:"w-full text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},null,512),[[zn,c.value.smart.base_url]])]),F("div",BAe,[F("div",WAe,[O[83]||(O[83]=F("label",{class:"text-xs font-semibold text-slate-500 uppercase"},"Temperature",-1)),Ui(F("input",{"onUpdate:modelValue":O[26]||(O[26]=ce=>c.value.smart.temperature=ce),type:"number",step:"0.1",min:"0",max:"2",placeholder:"0.4",class:"w-full text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},null,512),[[zn,c.value.smart.temperature,void 0,{number:!0}]])]),F("div",HAe,[O[84]||(O[84]=F("label",{class:"text-xs font-semibold text-slate-500 uppercase"},"Max Tokens",-1)),Ui(F("input",{"onUpdate:modelValue":O[27]||(O[27]=ce=>c.value.smart.max_tokens=ce),type:"number",min:"1",placeholder:"800",class:"w-full text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},null,512),[[zn,c.value.smart.max_tokens,void 0,{number:!0}]])])])]),F("div",VAe,[F("div",zAe,[O[86]||(O[86]=F("h3",{class:"text-lg font-bold text-slate-900"},"Dumb AI",-1)),F("span",{class:ei(["text-xs font-semibold px-2 py-1 rounded-full",u.value.dumb?"bg-emerald-50 text-emerald-700":"bg-slate-100 text-slate-500"])},Pi(u.value.dumb?"Enabled":"Disabled"),3)]),F("div",{class:"flex items-center justify-between gap-3"},[O[87]||(O[87]=F("p",{class:"text-xs text-slate-500"},"Used for spam detection, quick rewrites, and simple edits.",-1)),F("button",{onClick:$,class:"text-xs font-semibold text-brand-600 hover:text-brand-700"},"Copy smart → dumb")]),F("div",UAe,[O[89]||(O[89]=F("label",{class:"text-xs font-semibold text-slate-500 uppercase"},"Provider",-1)),Ui(F("select",{"onUpdate:modelValue":O[28]||(O[28]=ce=>c.value.dumb.provider=ce),class:"w-full text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},[...O[88]||(O[88]=[tS('<option value="">Select provider</option><option value="openai">OpenAI</option><option value="anthropic">Anthropic</option><option value="gemini">Gemini</option><option value="ollama">Ollama</option>',5)])],512),[[IE,c.value.dumb.provider]])]),F("div",$Ae,[O[90]||(O[90]=F("label",{class:"text-xs font-semibold text-slate-500 uppercase"},"Model",-1)),Ui(F("input",{"onUpdate:modelValue":O[29]||(O[29]=ce=>c.value.dumb.model=ce),type:"text",placeholder:"gpt-4o-mini",class:"w-full text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},null,512),[[zn,c.value.dumb.model]])]),F("div",jAe,[O[91]||(O[91]=F("label",{class:"text-xs font-semibold text-slate-500 uppercase"},"API Key",-1)),Ui(F("input",{"onUpdate:modelValue":O[30]||(O[30]=ce=>c.value.dumb.api_key=ce),type:"password",placeholder:"sk-...",class:"w-full text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},null,512),[[zn,c.value.dumb.api_key]])]),F("div",KAe,[O[92]||(O[92]=F("label",{class:"text-xs font-semibold text-slate-500 uppercase"},"Base URL",-1)),Ui(F("input",{"onUpdate:modelValue":O[31]||(O[31]=ce=>c.value.dumb.base_url=ce),type:"text",placeholder:"https://api.openai.com",class:"w-full text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},null,512),[[zn,c.value.dumb.base_url]])]),F("div",qAe,[F("div",GAe,[O[93]||(O[93]=F("label",{class:"text-xs font-semibold text-slate-500 uppercase"},"Temperature",-1)),Ui(F("input",{"onUpdate:modelValue":O[32]||(O[32]=ce=>c.value.dumb.temperature=ce),type:"number",step:"0.1",min:"0",max:"2",placeholder:"0.2",class:"w-full text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},null,512),[[zn,c.value.dumb.temperature,void 0,{number:!0}]])]),F("div",ZAe,[O[94]||(O[94]=F("label",{class:"text-xs font-semibold text-slate-500 uppercase"},"Max Tokens",-1)),Ui(F("input",{"onUpdate:modelValue":O[33]||(O[33]=ce=>c.value.dumb.max_tokens=ce),type:"number",min:"1",placeholder:"400",class:"w-full text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},null,512),[[zn,c.value.dumb.max_tokens,void 0,{number:!0}]])])])])])),O[95]||(O[95]=F("div",{class:"bg-white border border-slate-200/60 rounded-2xl p-5 shadow-sm text-sm text-slate-600"},[F("p",null,"Tip: If provider, model, or required API key is missing, the assistant is disabled. Ollama typically runs without an API key.")],-1))])])):t.value==="comments"?(Kt(),Zt("div",YAe,[F("div",XAe,[O[96]||(O[96]=F("div",null,[F("h2",{class:"text-2xl font-bold text-slate-900"},"Settings"),F("p",{class:"text-slate-500 text-sm mt-1"},"Configure your blog identity, comments, and display options")],-1)),F("button",{onClick:xe,disabled:D.value,class:ei(["text-white px-5 py-2.5 rounded-xl text-sm font-medium shadow-lg transition-all active:scale-95 flex items-center gap-2",D.value?"bg-slate-500 cursor-not-allowed":"bg-slate-900 hover:bg-slate-800"])},[D.value?(Kt(),Zt("i",JAe)):ms("",!0),En(" "+Pi(D.value?"Saving...":"Save Settings"),1)],10,QAe)]),F("div",e2e,[F("div",t2e,[O[103]||(O[103]=F("div",null,[F("h3",{class:"text-lg font-bold text-slate-900"},"Site Identity"),F("p",{class:"text-sm text-slate-500 mt-1"},"Set the title and description used in page headers, SEO meta tags, and social sharing.")],-1)),F("div",null,[O[97]||(O[97]=F("label",{class:"block text-sm font-semibold text-slate-700 mb-1"},"Blog Title",-1)),Ui(F("input",{"onUpdate:modelValue":O[34]||(O[34]=ce=>S.value.title=ce),type:"text",placeholder:"My Blog",class:"w-full text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},null,512),[[zn,S.value.title]]),O[98]||(O[98]=F("p",{class:"text-xs text-slate-400 mt-1"},"Displayed in the header, browser tab, and Open Graph tags.",-1))]),F("div",null,[O[99]||(O[99]=F("label",{class:"block text-sm font-semibold text-slate-700 mb-1"},"Blog Description",-1)),Ui(F("textarea",{"onUpdate:modelValue":O[35]||(O[35]=ce=>S.value.description=ce),rows:"2",placeholder:"A short description of your blog",class:"w-full text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none resize-y"},null,512),[[zn,S.value.description]]),O[100]||(O[100]=F("p",{class:"text-xs text-slate-400 mt-1"},"Used as the meta description for listing pages and social previews.",-1))]),F("div",null,[O[101]||(O[101]=F("label",{class:"block text-sm font-semibold text-slate-700 mb-1"},"Google Analytics Measurement ID",-1)),Ui(F("input",{"onUpdate:modelValue":O[36]||(O[36]=ce=>S.value.google_analytics_code=ce),type:"text",placeholder:"G-XXXXXXXXXX",class:"w-full text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},null,512),[[zn,S.value.google_analytics_code]]),O[102]||(O[102]=F("p",{class:"text-xs text-slate-400 mt-1"},[En("If set, the default templates load the Google tag automatically and custom templates can use "),F("code",null,".GoogleAnalyticsCode"),En(".")],-1))])]),F("div",i2e,[F("div",n2e,[O[104]||(O[104]=F("div",null,[F("h3",{class:"text-lg font-bold text-slate-900"},"Allow comments"),F("p",{class:"text-sm text-slate-500 mt-1"},"Turn this off to hide comment forms and disable posting.")],-1)),F("button",{onClick:O[37]||(O[37]=ce=>S.value.comments_enabled=!S.value.comments_enabled),class:ei(["w-12 h-6 rounded-full relative transition-colors duration-300 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-brand-500",S.value.comments_enabled?"bg-emerald-500":"bg-slate-300"])},[F("span",{class:ei(["absolute top-1 left-1 w-4 h-4 bg-white rounded-full shadow-sm transition-transform duration-300",S.value.comments_enabled?"translate-x-6":"translate-x-0"])},null,2)],2)])]),F("div",s2e,[F("div",o2e,[O[105]||(O[105]=F("div",null,[F("h3",{class:"text-lg font-bold text-slate-900"},"Notifications"),F("p",{class:"text-sm text-slate-500 mt-1"},"Push admins when new comments are posted.")],-1)),F("button",{onClick:O[38]||(O[38]=ce=>S.value.notifications_enabled=!S.value.notifications_enabled),class:ei(["w-12 h-6 rounded-full relative transition-colors duration-300 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-brand-500",S.value.notifications_enabled?"bg-emerald-500":"bg-slate-300"])},[F("span",{class:ei(["absolute top-1 left-1 w-4 h-4 bg-white rounded-full shadow-sm transition-transform duration-300",S.value.notifications_enabled?"translate-x-6":"translate-x-0"])},null,2)],2)]),F("div",r2e,[F("p",a2e,[O[106]||(O[106]=En(" Browser permission: ",-1)),F("span",l2e,Pi(T.value),1),O[107]||(O[107]=En(" · Subscription: ",-1)),F("span",d2e,Pi(V.value?"active":"inactive"),1)]),F("div",c2e,[F("button",{onClick:Ue,disabled:P.value||!S.value.notifications_enabled,class:ei(["px-4 py-2 rounded-lg text-xs font-semibold transition-all",P.value||!S.value.notifications_enabled?"bg-slate-300 text-slate-500 cursor-not-allowed":"bg-slate-900 text-white hover:bg-slate-800"])},Pi(P.value?"Working...":"Enable In Browser"),11,u2e),F("button",{onClick:G,disabled:P.value||!V.value,class:ei(["px-4 py-2 rounded-lg text-xs font-semibold transition-all",P.value||!V.value?"bg-slate-200 text-slate-500 cursor-not-allowed":"bg-slate-100 text-slate-700 hover:bg-slate-200"])}," Disable ",10,h2e)])]),F("div",f2e,[F("div",g2e,[O[108]||(O[108]=F("label",{class:"block text-xs font-semibold text-slate-600 uppercase"},"VAPID Public Key",-1)),Ui(F("input",{"onUpdate:modelValue":O[39]||(O[39]=ce=>S.value.vapid_public_key=ce),type:"text",class:"w-full text-xs p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},null,512),[[zn,S.value.vapid_public_key]])]),F("div",p2e,[O[109]||(O[109]=F("label",{class:"block text-xs font-semibold text-slate-600 uppercase"},"VAPID Private Key",-1)),Ui(F("input",{"onUpdate:modelValue":O[40]||(O[40]=ce=>S.value.vapid_private_key=ce),type:"text",class:"w-full text-xs p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},null,512),[[zn,S.value.vapid_private_key]])])]),F("div",m2e,[O[110]||(O[110]=F("label",{class:"block text-xs font-semibold text-slate-600 uppercase"},"Subscriber",-1)),Ui(F("input",{"onUpdate:modelValue":O[41]||(O[41]=ce=>S.value.vapid_subscriber=ce),type:"text",placeholder:"mailto:admin@example.com",class:"w-full text-xs p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},null,512),[[zn,S.value.vapid_subscriber]])]),O[111]||(O[111]=F("p",{class:"text-xs text-slate-500"},"If keys are blank, Spore auto-generates and stores fresh VAPID keys in settings.",-1))]),F("div",_2e,[F("div",v2e,[O[113]||(O[113]=F("div",null,[F("h3",{class:"text-lg font-bold text-slate-900"},"Published date display"),F("p",{class:"text-sm text-slate-500 mt-1"},"Choose how dates appear on public posts.")],-1)),Ui(F("select",{"onUpdate:modelValue":O[42]||(O[42]=ce=>S.value.date_display=ce),class:"w-full md:w-64 text-sm p-2.5 border border-slate-200 rounded-lg focus:border-brand-500 focus:ring-1 focus:ring-brand-500 outline-none"},[...O[112]||(O[112]=[F("option",{value:"absolute"},"Exact date (Jan 2, 2006)",-1),F("option",{value:"approximate"},"Approximate (5 years ago)",-1)])],512),[[IE,S.value.date_display]])])]),F("div",b2e,[F("div",C2e,[O[114]||(O[114]=F("div",null,[F("h3",{class:"text-lg font-bold text-slate-900"},"Moderation Queue"),F("p",{class:"text-sm text-slate-500"},"Review recent comments and spam checks.")],-1)),F("div",w2e,[(Kt(),Zt(No,null,eS(oe,ce=>F("button",{key:ce.key,onClick:ri=>Q.value=ce.key,class:ei(["px-4 py-2 rounded-xl text-xs font-semibold border transition-all",Q.value===ce.key?"bg-slate-900 text-white border-slate-900":"border-slate-200 text-slate-500 hover:text-slate-700"])},Pi(ce.label),11,S2e)),64))])]),B.value?(Kt(),Zt("div",y2e,[...O[115]||(O[115]=[F("i",{class:"ph ph-spinner animate-spin"},null,-1),En(" Loading comments... ",-1)])])):A.value.length===0?(Kt(),Zt("div",L2e,"No comments to show.")):(Kt(),Zt("div",x2e,[(Kt(!0),Zt(No,null,eS(A.value,ce=>(Kt(),Zt("div",{key:ce.id,class:"border border-slate-200/60 rounded-2xl p-4 bg-slate-50/60"},[F("div",D2e,[F("div",null,[F("p",k2e,[En(Pi(ce.author_name)+" ",1),O[116]||(O[116]=F("span",{class:"text-xs text-slate-400"},"on",-1)),En(" "+Pi(ce.post_title),1)]),F("p",I2e,"/"+Pi(ce.post_slug)+" · "+Pi(Ct(ce.created_at)),1)]),F("span",{class:ei(["text-xs font-semibold px-2 py-1 rounded-full",q(ce.status)])},Pi(ce.status),3)]),F("p",E2e,Pi(ce.content),1),ce.spam_reason?(Kt(),Zt("p",N2e,"Spam note: "+Pi(ce.spam_reason),1)):ms("",!0),F("div",T2e,[ce.status!=="approved"?(Kt(),Zt("button",{key:0,onClick:ri=>E(ce,"approved"),class:"px-3 py-1.5 rounded-lg text-xs font-semibold bg-emerald-500 text-white"},"Approve",8,M2e)):ms("",!0),ce.status!=="hidden"?(Kt(),Zt("button",{key:1,onClick:ri=>E(ce,"hidden"),class:"px-3 py-1.5 rounded-lg text-xs font-semibold bg-slate-200 text-slate-700"},"Hide",8,R2e)):ms("",!0),ce.status!=="rejected"?(Kt(),Zt("button",{key:2,onClick:ri=>E(ce,"rejected"),class:"px-3 py-1.5 rounded-lg text-xs font-semibold bg-amber-500 text-white"},"Reject",8,A2e)):ms("",!0),F("button",{onClick:ri=>R(ce),class:"px-3 py-1.5 rounded-lg text-xs font-semibold bg-rose-500 text-white"},"Delete",8,P2e)])]))),128))]))])])])):t.value==="wxr"?(Kt(),Zt("div",O2e,[F("div",F2e,[O[117]||(O[117]=F("div",null,[F("h2",{class:"text-2xl font-bold text-slate-900"},"Import / Export"),F("p",{class:"text-slate-500 text-sm mt-1"},"Move your entire blog using WordPress WXR files")],-1)),F("button",{onClick:te,disabled:we.value,class:ei(["text-white px-5 py-2.5 rounded-xl text-sm font-medium shadow-lg transition-all active:scale-95 flex items-center gap-2",we.value?"bg-slate-500 cursor-not-allowed":"bg-slate-900 hover:bg-slate-800"])},[we.value?(Kt(),Zt("i",W2e)):ms("",!0),En(" "+Pi(we.value?"Exporting...":"Export WXR"),1)],10,B2e)]),F("div",H2e,[F("div",V2e,[O[119]||(O[119]=F("div",null,[F("h3",{class:"text-lg font-bold text-slate-900"},"Export Blog"),F("p",{class:"text-sm text-slate-500 mt-1"},"Download a WXR file containing all posts, tags, and comments.")],-1)),F("button",{onClick:te,disabled:we.value,class:ei(["text-white px-4 py-2 rounded-lg text-sm font-semibold transition-all",we.value?"bg-slate-400 cursor-not-allowed":"bg-slate-900 hover:bg-slate-800"])},[...O[118]||(O[118]=[F("i",{class:"ph ph-download-simple"},null,-1),En(" Download Export ",-1)])],10,z2e)]),F("div",U2e,[O[121]||(O[121]=F("div",null,[F("h3",{class:"text-lg font-bold text-slate-900"},"Import Blog"),F("p",{class:"text-sm text-slate-500 mt-1"},"Upload a WXR file. Existing posts and comments are skipped."),F("p",{class:"text-sm text-slate-500 mt-1"},"Ensure the old blog location is still accessible so that we can copy its images into our database.")],-1)),F("div",$2e,[F("input",{type:"file",accept:".xml,text/xml,application/xml",onChange:H,class:"block w-full text-sm text-slate-500 file:mr-4 file:py-2 file:px-4 file:rounded-lg file:border-0 file:text-sm file:font-semibold file:bg-slate-100 file:text-slate-700 hover:file:bg-slate-200"},null,32),F("button",{onClick:pe,disabled:Le.value||!ae.value,class:ei(["text-white px-4 py-2 rounded-lg text-sm font-semibold transition-all",Le.value||!ae.value?"bg-slate-400 cursor-not-allowed":"bg-emerald-600 hover:bg-emerald-700"])},[Le.value?(Kt(),Zt("i",K2e)):ms("",!0),En(" "+Pi(Le.value?"Importing...":"Import WXR"),1)],10,j2e)]),le.value?(Kt(),Zt("div",q2e,[O[120]||(O[120]=F("p",{class:"font-semibold text-slate-800"},"Import summary",-1)),F("p",null,"Posts added: "+Pi(le.value.posts_added),1),F("p",null,"Posts skipped: "+Pi(le.value.posts_skipped),1),F("p",null,"Comments added: "+Pi(le.value.comments_added),1),F("p",null,"Comments skipped: "+Pi(le.value.comments_skipped),1)])):ms("",!0)])])])):ms("",!0)])])]))}};Hi.setOptions({breaks:!0,gfm:!0});wJ(G2e).mount("#app");export{bI as m,aG as t};
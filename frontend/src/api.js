@@ -8,7 +8,9 @@ async function jsonRequest(url, options = {}) {
   })
   if (!res.ok) {
     const body = await res.text()
-    throw new Error(`Request failed ${res.status}: ${body}`)
+    const err = new Error(`Request failed ${res.status}: ${body}`)
+    err.status = res.status
+    throw err
   }
   const text = await res.text()
   return text ? JSON.parse(text) : null
@@ -1,13 +1,16 @@
 package blog
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"io"
 	"io/fs"
 	"net/http"
 	"path"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 )
@@ -15,10 +18,35 @@ import (
 func (s *service) mountAdminRoutes(r chi.Router) {
 	r.Route("/api", func(r chi.Router) {
 		r.Get("/posts", s.handleAdminListPosts)
+		r.Get("/posts/export", s.handleAdminExportPosts)
+		r.Post("/posts/import", s.handleAdminImportPosts)
 		r.Get("/posts/{id}", s.handleAdminGetPost)
 		r.Post("/posts", s.handleAdminCreatePost)
 		r.Put("/posts/{id}", s.handleAdminUpdatePost)
-		r.Delete("/posts/{id}", s.handleAdminDeletePost)
+		r.Delete("/posts/{id}", s.handleAdminTrashPost)
+		r.Post("/posts/{id}/restore", s.handleAdminRestorePost)
+		r.Delete("/posts/{id}/purge", s.handleAdminPurgePost)
+		r.Get("/posts/{id}/revisions", s.handleAdminListPostRevisions)
+		r.Get("/posts/{id}/revisions/diff", s.handleAdminDiffPostRevisions)
+		r.Post("/posts/{id}/revisions/{revID}/restore", s.handleAdminRestorePostRevision)
+		r.Post("/posts/{id}/translate", s.handleAdminTranslatePost)
+		r.Post("/posts/{id}/generate-description", s.handleAdminGenerateDescription)
+		r.Post("/posts/{id}/generate-tags", s.handleAdminGenerateTags)
+		r.Get("/posts/{id}/ai/history", s.handleAdminGetAIChatHistory)
+		r.Delete("/posts/{id}/ai/history", s.handleAdminDeleteAIChatHistory)
+		r.Get("/posts/{id}/preview-link", s.handleAdminPreviewLink)
+		r.Get("/search", s.handleAdminSearch)
+
+		r.Get("/tags", s.handleAdminListTags)
+		r.Put("/tags/{slug}", s.handleAdminUpdateTag)
+		r.Post("/tags/{slug}/rename", s.handleAdminRenameTag)
+		r.Post("/tags/merge", s.handleAdminMergeTags)
+
+		r.Get("/authors", s.handleAdminListAuthors)
+		r.Post("/authors", s.handleAdminCreateAuthor)
+		r.Get("/authors/{id}", s.handleAdminGetAuthor)
+		r.Put("/authors/{id}", s.handleAdminUpdateAuthor)
+		r.Delete("/authors/{id}", s.handleAdminDeleteAuthor)
 
 		r.Get("/settings", s.handleAdminGetBlogSettings)
 		r.Put("/settings", s.handleAdminUpdateBlogSettings)
@@ -27,21 +55,39 @@ func (s *service) mountAdminRoutes(r chi.Router) {
 		r.Put("/comments/{id}/status", s.handleAdminUpdateCommentStatus)
 		r.Delete("/comments/{id}", s.handleAdminDeleteComment)
 
+		if s.cfg.Webmentions {
+			r.Get("/webmentions", s.handleAdminListWebmentions)
+			r.Delete("/webmentions/{id}", s.handleAdminDeleteWebmention)
+		}
+
 		r.Get("/notifications/vapid-key", s.handleAdminGetNotificationPublicKey)
 		r.Post("/notifications/subscribe", s.handleAdminSubscribeNotifications)
 		r.Delete("/notifications/subscribe", s.handleAdminUnsubscribeNotifications)
+		r.Get("/notifications/subscriptions", s.handleAdminListPushSubscriptions)
+		r.Delete("/notifications/subscriptions", s.handleAdminClearPushSubscriptions)
 
 		r.Get("/ai/settings", s.handleAdminGetAISettings)
 		r.Put("/ai/settings", s.handleAdminUpdateAISettings)
 		r.Post("/ai/chat", s.handleAdminAIChat)
+		r.Post("/ai/chat/stream", s.handleAdminAIChatStream)
+		r.Post("/ai/alt-text", s.handleAdminGenerateAltText)
+		r.Get("/ai/usage", s.handleAdminAIUsage)
 
 		r.Get("/wxr/export", s.handleAdminExportWXR)
 		r.Post("/wxr/import", s.handleAdminImportWXR)
+		r.Post("/wxr/import-url", s.handleAdminImportWXRURL)
 
 		r.Get("/tasks", s.handleAdminListTasks)
+		r.Get("/tasks/{id}", s.handleAdminGetTask)
+		r.Post("/tasks/{id}/cancel", s.handleAdminCancelTask)
+
+		if s.cfg.ServeMetrics {
+			r.Get("/metrics", s.handleAdminMetrics)
+		}
 
 		// Image endpoints (only available if ImageStore is configured)
 		r.Get("/images/enabled", s.handleImagesEnabled)
+		r.Get("/images", s.handleListImages)
 		r.Post("/images", s.handleUploadImage)
 		r.Delete("/images/{id}", s.handleDeleteImage)
 	})
@@ -69,11 +115,31 @@ func (s *service) handleAdminListPosts(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	posts, err := s.store.ListAllPosts(r.Context(), limit, offset)
+	var posts []Post
+	var err error
+	switch status := r.URL.Query().Get("status"); status {
+	case "":
+		posts, err = s.store.ListAllPosts(r.Context(), limit, offset)
+	case "trashed":
+		posts, err = s.store.ListTrashedPosts(r.Context(), limit, offset)
+	default:
+		http.Error(w, "invalid status", http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		http.Error(w, "failed to list posts", http.StatusInternalServerError)
 		return
 	}
+	if s.views != nil {
+		counts, err := s.store.PostViewCounts(r.Context())
+		if err != nil {
+			http.Error(w, "failed to load view counts", http.StatusInternalServerError)
+			return
+		}
+		for i := range posts {
+			posts[i].ViewCount = counts[posts[i].ID]
+		}
+	}
 	writeJSON(w, posts)
 }
 
@@ -100,23 +166,38 @@ func (s *service) handleAdminCreatePost(w http.ResponseWriter, r *http.Request)
 	if p.ID == "" {
 		p.ID = generateID()
 	}
+	if err := s.assignPostSlug(r.Context(), &p); err != nil {
+		writeSlugError(w, err)
+		return
+	}
 	// Convert markdown to HTML
 	if p.ContentMarkdown != "" {
-		html, err := markdownToHTMLUnsafe(p.ContentMarkdown)
+		html, err := s.renderPostHTML(p.ContentMarkdown)
 		if err != nil {
 			http.Error(w, "failed to convert markdown", http.StatusInternalServerError)
 			return
 		}
 		p.ContentHTML = html
+	} else {
+		p.ContentHTML = s.sanitizeStoredHTML(p.ContentHTML)
 	}
 	if err := s.store.CreatePost(r.Context(), &p); err != nil {
 		http.Error(w, "failed to create post", http.StatusInternalServerError)
 		return
 	}
 	s.queuePostProcessing("post saved")
+	s.maybeQueueSitemapPing(r, nil, &p)
+	s.maybeQueuePublish(r, &p)
+	s.maybeQueueUnpublish(r, &p)
+	if postIsLive(&p, time.Now()) {
+		s.queueWebhook(webhookEvent{Event: WebhookEventPostPublished, Post: s.webhookPost(r, &p)})
+	}
 	writeJSON(w, p)
 }
 
+// handleAdminUpdatePost replaces a post. When the payload carries the
+// updated_at it was loaded with, the update is rejected with 409 and the
+// stored post if someone else saved it in the meantime.
 func (s *service) handleAdminUpdatePost(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	var p Post
@@ -131,32 +212,105 @@ func (s *service) handleAdminUpdatePost(w http.ResponseWriter, r *http.Request)
 		http.Error(w, "id mismatch", http.StatusBadRequest)
 		return
 	}
+	p.Slug = strings.TrimSpace(p.Slug)
+	if err := s.checkPostSlug(r.Context(), &p); err != nil {
+		writeSlugError(w, err)
+		return
+	}
+	expected := p.UpdatedAt
+	if expected == nil && s.cfg.RequirePostVersion {
+		http.Error(w, "updated_at required", http.StatusPreconditionRequired)
+		return
+	}
+	if expected != nil {
+		current, err := s.store.GetPostByID(r.Context(), id)
+		if err != nil {
+			http.Error(w, "failed to load post", http.StatusInternalServerError)
+			return
+		}
+		if current == nil || current.UpdatedAt == nil || !current.UpdatedAt.Equal(*expected) {
+			s.writePostConflict(w, r, current)
+			return
+		}
+	}
 
 	// Convert markdown to HTML
 	if p.ContentMarkdown != "" {
-		html, err := markdownToHTMLUnsafe(p.ContentMarkdown)
+		html, err := s.renderPostHTML(p.ContentMarkdown)
 		if err != nil {
 			http.Error(w, "failed to convert markdown", http.StatusInternalServerError)
 			return
 		}
 		p.ContentHTML = html
+	} else {
+		p.ContentHTML = s.sanitizeStoredHTML(p.ContentHTML)
 	}
-	if err := s.store.UpdatePost(r.Context(), &p); err != nil {
+	// The revision is saved once the update is, so a rejected update
+	// leaves no snapshot behind.
+	previous, err := s.store.GetPostByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "failed to load post", http.StatusInternalServerError)
+		return
+	}
+	// Only the trash and restore routes move a post in or out of the trash.
+	p.DeletedAt = nil
+	if previous != nil {
+		p.DeletedAt = previous.DeletedAt
+	}
+	if expected == nil {
+		err = s.store.UpdatePost(r.Context(), &p)
+	} else {
+		var saved bool
+		saved, err = s.store.UpdatePostIfUnchanged(r.Context(), &p, *expected)
+		if err == nil && !saved {
+			// Someone saved the post between the check above and now.
+			current, err := s.store.GetPostByID(r.Context(), id)
+			if err != nil {
+				http.Error(w, "failed to load post", http.StatusInternalServerError)
+				return
+			}
+			s.writePostConflict(w, r, current)
+			return
+		}
+	}
+	if err != nil {
 		http.Error(w, "failed to update post", http.StatusInternalServerError)
 		return
 	}
+	if err := s.savePostRevision(r.Context(), previous, &p); err != nil {
+		s.logf("revisions: save post_id=%s: %v", p.ID, err)
+	}
 	s.queuePostProcessing("post saved")
+	s.maybeQueueRetag(r.Context(), previous, &p)
+	s.maybeQueueSitemapPing(r, previous, &p)
+	s.maybeQueuePublish(r, &p)
+	s.maybeQueueUnpublish(r, &p)
+	now := time.Now()
+	if postIsLive(&p, now) && !postIsLive(previous, now) {
+		s.queueWebhook(webhookEvent{Event: WebhookEventPostPublished, Post: s.webhookPost(r, &p)})
+	}
 
 	writeJSON(w, p)
 }
 
-func (s *service) handleAdminDeletePost(w http.ResponseWriter, r *http.Request) {
-	id := chi.URLParam(r, "id")
-	if err := s.store.DeletePost(r.Context(), id); err != nil {
-		http.Error(w, "failed to delete post", http.StatusInternalServerError)
+// writePostConflict answers a stale update with 409 Conflict and the post
+// as it is stored now, so the editor can merge the changes.
+func (s *service) writePostConflict(w http.ResponseWriter, r *http.Request, current *Post) {
+	if current == nil {
+		http.NotFound(w, r)
 		return
 	}
-	w.WriteHeader(http.StatusNoContent)
+	writeJSONStatus(w, http.StatusConflict, current)
+}
+
+// writeSlugError reports a failed slug check, with 409 when another post
+// has the slug.
+func writeSlugError(w http.ResponseWriter, err error) {
+	if errors.Is(err, errSlugTaken) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	http.Error(w, "failed to check slug", http.StatusInternalServerError)
 }
 
 func (s *service) handleImagesEnabled(w http.ResponseWriter, r *http.Request) {
@@ -164,14 +318,32 @@ func (s *service) handleImagesEnabled(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, map[string]bool{"enabled": enabled})
 }
 
+// defaultMaxImageUploadBytes is the upload cap when
+// Config.MaxImageUploadBytes is zero.
+const defaultMaxImageUploadBytes = 20 << 20
+
 func (s *service) handleUploadImage(w http.ResponseWriter, r *http.Request) {
 	if s.cfg.ImageStore == nil {
 		http.Error(w, "image storage not configured", http.StatusNotImplemented)
 		return
 	}
 
+	maxBytes := s.cfg.MaxImageUploadBytes
+	if maxBytes == 0 {
+		maxBytes = defaultMaxImageUploadBytes
+	}
+	if maxBytes > 0 {
+		// Leave room for the multipart headers around the file.
+		r.Body = http.MaxBytesReader(w, r.Body, maxBytes+1<<20)
+	}
+
 	// Parse multipart form with 32MB max memory
 	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "image too large", http.StatusRequestEntityTooLarge)
+			return
+		}
 		http.Error(w, "failed to parse form", http.StatusBadRequest)
 		return
 	}
@@ -182,18 +354,43 @@ func (s *service) handleUploadImage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	defer file.Close()
+	if maxBytes > 0 && header.Size > maxBytes {
+		http.Error(w, "image too large", http.StatusRequestEntityTooLarge)
+		return
+	}
 
-	contentType := header.Header.Get("Content-Type")
-	if contentType == "" {
-		contentType = "application/octet-stream"
+	data, err := io.ReadAll(file)
+	if err != nil {
+		http.Error(w, "failed to read image", http.StatusBadRequest)
+		return
 	}
 
+	// The declared Content-Type and file name are the client's word; the
+	// stored type comes from the bytes themselves.
+	contentType, ok := sniffImageType(data)
+	if !ok {
+		http.Error(w, "unsupported image type", http.StatusUnsupportedMediaType)
+		return
+	}
+	filename := imageFilename(header.Filename, contentType)
+
 	id := generateID()
-	storeURL, err := s.cfg.ImageStore.SaveImage(r.Context(), id, header.Filename, contentType, file)
+	variants, err := s.saveImageVariants(r.Context(), id, filename, contentType, data)
+	if errors.Is(err, errImageTooLarge) {
+		http.Error(w, "image too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err != nil {
+		http.Error(w, "failed to save image variants", http.StatusInternalServerError)
+		return
+	}
+
+	storeURL, err := s.cfg.ImageStore.SaveImage(r.Context(), id, filename, contentType, bytes.NewReader(data))
 	if err != nil {
 		http.Error(w, "failed to save image", http.StatusInternalServerError)
 		return
 	}
+	s.metrics.inc(metricImageUploads)
 	// Extract the filename from the store URL to build the public-facing URL.
 	savedFilename := path.Base(storeURL)
 	savedID := savedFilename
@@ -202,10 +399,14 @@ func (s *service) handleUploadImage(w http.ResponseWriter, r *http.Request) {
 	}
 	publicURL := s.routePrefix + "/images/" + savedFilename
 
-	writeJSON(w, map[string]string{
+	resp := map[string]any{
 		"id":  savedID,
 		"url": publicURL,
-	})
+	}
+	if len(variants) > 0 {
+		resp["variants"] = variants
+	}
+	writeJSON(w, resp)
 }
 
 func (s *service) handleGetImage(w http.ResponseWriter, r *http.Request) {
@@ -224,7 +425,67 @@ func (s *service) handleGetImage(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", contentType)
 	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
-	io.Copy(w, reader)
+	content, ok := reader.(io.ReadSeeker)
+	if !ok {
+		// Stores that can't seek are streamed as before unless the client
+		// wants a range, which needs the whole image in memory.
+		if r.Header.Get("Range") == "" {
+			w.Header().Set("Accept-Ranges", "bytes")
+			io.Copy(w, reader)
+			return
+		}
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			http.Error(w, "failed to read image", http.StatusInternalServerError)
+			return
+		}
+		content = bytes.NewReader(data)
+	}
+	var modTime time.Time
+	if f, ok := reader.(interface{ Stat() (fs.FileInfo, error) }); ok {
+		if info, err := f.Stat(); err == nil {
+			modTime = info.ModTime()
+		}
+	}
+	http.ServeContent(w, r, "", modTime, content)
+}
+
+// handleListImages lists uploaded images for the editor's media picker,
+// with URLs served by the blog's /images route.
+func (s *service) handleListImages(w http.ResponseWriter, r *http.Request) {
+	if s.cfg.ImageStore == nil {
+		http.Error(w, "image storage not configured", http.StatusNotImplemented)
+		return
+	}
+	lister, ok := s.cfg.ImageStore.(ImageLister)
+	if !ok {
+		http.Error(w, "image store cannot list images", http.StatusNotImplemented)
+		return
+	}
+	limit := 0
+	offset := 0
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			limit = n
+		}
+	}
+	if v := r.URL.Query().Get("offset"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			offset = n
+		}
+	}
+	images, err := lister.ListImages(r.Context(), limit, offset)
+	if err != nil {
+		http.Error(w, "failed to list images", http.StatusInternalServerError)
+		return
+	}
+	for i := range images {
+		images[i].URL = s.routePrefix + "/images/" + path.Base(images[i].URL)
+	}
+	if images == nil {
+		images = []ImageInfo{}
+	}
+	writeJSON(w, images)
 }
 
 func (s *service) handleDeleteImage(w http.ResponseWriter, r *http.Request) {
@@ -277,9 +538,106 @@ func (s *service) handleAdminListTasks(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, tasks)
 }
 
+// taskDetail is a task with its result decoded, so clients don't have to
+// parse the JSON string stored in Task.Result.
+type taskDetail struct {
+	*Task
+	Result json.RawMessage `json:"result"`
+}
+
+func newTaskDetail(task *Task) taskDetail {
+	result := json.RawMessage(task.Result)
+	if !json.Valid(result) {
+		result, _ = json.Marshal(task.Result)
+	}
+	return taskDetail{Task: task, Result: result}
+}
+
+func (s *service) handleAdminGetTask(w http.ResponseWriter, r *http.Request) {
+	task, err := s.store.GetTask(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "failed to load task", http.StatusInternalServerError)
+		return
+	}
+	if task == nil {
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, newTaskDetail(task))
+}
+
+// handleAdminCancelTask cancels a pending or running task. A task running
+// in this process has its context cancelled so in-flight AI and image work
+// stops, and the runner records it as cancelled; the response waits for
+// that. Other tasks are marked cancelled in the store. A task running on
+// another instance is not stopped, but the cancel sticks: runners only
+// record an outcome while the stored task is still running. Tasks that
+// have already finished are left alone with 409.
+func (s *service) handleAdminCancelTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+	for {
+		task, err := s.store.GetTask(ctx, id)
+		if err != nil {
+			http.Error(w, "failed to load task", http.StatusInternalServerError)
+			return
+		}
+		if task == nil {
+			http.NotFound(w, r)
+			return
+		}
+		if task.Status != TaskStatusPending && task.Status != TaskStatusRunning {
+			http.Error(w, "task already "+task.Status, http.StatusConflict)
+			return
+		}
+		if finished, ok := s.tasks.cancel(id); ok {
+			select {
+			case <-finished:
+			case <-ctx.Done():
+				return
+			}
+			task, err = s.store.GetTask(ctx, id)
+			if err != nil || task == nil {
+				http.Error(w, "failed to load task", http.StatusInternalServerError)
+				return
+			}
+			if task.Status != TaskStatusCancelled {
+				// It finished before it noticed the cancel.
+				http.Error(w, "task already "+task.Status, http.StatusConflict)
+				return
+			}
+			writeJSON(w, newTaskDetail(task))
+			return
+		}
+		// The status may change under us as the runner claims or finishes
+		// the task; only a successful transition counts. A task claimed
+		// here but not yet started sees the cancel before it runs.
+		cancelled, err := s.store.TransitionTask(ctx, id, task.Status, TaskStatusCancelled)
+		if err != nil {
+			http.Error(w, "failed to cancel task", http.StatusInternalServerError)
+			return
+		}
+		if cancelled == nil {
+			continue
+		}
+		// It may have started here since the cancel above missed; stop it.
+		s.tasks.cancel(id)
+		writeJSON(w, newTaskDetail(cancelled))
+		return
+	}
+}
+
 func writeJSON(w http.ResponseWriter, v any) {
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(v); err != nil {
 		http.Error(w, "json encode error", http.StatusInternalServerError)
 	}
 }
+
+// writeJSONStatus is writeJSON with a status other than 200. The
+// Content-Type is set before WriteHeader, which fixes the headers.
+func writeJSONStatus(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
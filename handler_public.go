@@ -1,9 +1,13 @@
 package blog
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"hash/fnv"
+	"html/template"
+	"math"
 	"math/rand"
 	"net/http"
 	"os"
@@ -11,6 +15,8 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/go-chi/chi/v5"
 )
@@ -21,43 +27,54 @@ var firstImageRe = regexp.MustCompile(`<img[^>]+src="([^"]+)"`)
 func (s *service) mountPublicRoutes(r chi.Router) {
 	r.Get("/", s.handleListPosts)
 	r.Get("/feed", s.handleRSSFeed)
+	r.Get("/feed.atom", s.handleAtomFeed)
 	r.Get("/tag/{tagSlug}", s.handleListPostsByTag)
+	r.Get("/tag/{tagSlug}/feed", s.handleTagRSSFeed)
+	r.Get("/search", s.handlePublicSearch)
+	if s.cfg.ServeSitemap {
+		r.Get("/sitemap.xml", s.handleSitemap)
+		r.Get("/sitemap-{n}.xml", s.handleSitemapPage)
+	}
 	r.Get("/images/{id}", s.handleGetImage)
-	s.mountCommentRoutes(r)
+	if s.highlightCSS != nil {
+		r.Get("/highlight.css", s.handleHighlightCSS)
+	}
+	r.Get("/preview/{id}", s.handlePreviewPost)
+	if s.cfg.Webmentions {
+		r.Post("/webmention", s.handleReceiveWebmention)
+	}
+	// Only the JSON API answers cross-origin requests.
+	r.Group(func(r chi.Router) {
+		r.Use(s.cors)
+		if s.cfg.ServeTagsAPI {
+			r.Get("/api/tags", s.handlePublicListTags)
+		}
+		r.Get("/api/posts", s.handlePublicListPostsAPI)
+		r.Get("/api/posts/*", s.handlePublicGetPostAPI)
+		s.mountCommentRoutes(r)
+	})
 	r.Get("/*", s.handleViewPost)
 }
 
 func (s *service) handleListPosts(w http.ResponseWriter, r *http.Request) {
-	limit := 10
-	offset := 0
-	page := 1
+	limit, offset, page := s.listParams(r)
+	cursor, err := s.listCursor(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
-	if s.cfg.ListAll {
-		limit = 100000
+	var posts []Post
+	if cursor != nil {
+		posts, err = s.listPublishedPostsAfter(r.Context(), *cursor, limit)
 	} else {
-		if v := r.URL.Query().Get("limit"); v != "" {
-			if n, err := strconv.Atoi(v); err == nil && n > 0 && n <= 100 {
-				limit = n
-			}
-		}
-		if v := r.URL.Query().Get("offset"); v != "" {
-			if n, err := strconv.Atoi(v); err == nil && n >= 0 {
-				offset = n
-			}
-		}
-		if v := r.URL.Query().Get("page"); v != "" {
-			if n, err := strconv.Atoi(v); err == nil && n > 0 {
-				page = n
-				offset = (page - 1) * limit
-			}
-		}
+		posts, err = s.listPublishedPosts(r.Context(), limit, offset)
 	}
-
-	posts, err := s.store.ListPublishedPosts(r.Context(), limit, offset)
 	if err != nil {
 		http.Error(w, "failed to list posts", http.StatusInternalServerError)
 		return
 	}
+	next := nextCursor(posts, limit)
 
 	settings := resolveBlogSettings(nil)
 	if rawSettings, err := s.store.GetBlogSettings(r.Context()); err == nil {
@@ -67,10 +84,17 @@ func (s *service) handleListPosts(w http.ResponseWriter, r *http.Request) {
 	// Build PostSummary slice
 	summaries := postsToSummaries(posts)
 
-	// Build pagination (omitted when ListAll is enabled)
+	// Build pagination (omitted when ListAll is enabled). Pages past the
+	// end render empty rather than failing.
 	var pagination *Pagination
-	if !s.cfg.ListAll {
-		totalCount := s.countPublishedPosts(r.Context())
+	if cursor != nil {
+		pagination = cursorPagination(next, limit, s.routePrefix+"/")
+	} else if !s.cfg.ListAll {
+		totalCount, err := s.countPublishedPosts(r.Context())
+		if err != nil {
+			http.Error(w, "failed to list posts", http.StatusInternalServerError)
+			return
+		}
 		p := buildPagination(page, limit, totalCount, s.routePrefix+"/")
 		pagination = &p
 	}
@@ -80,53 +104,70 @@ func (s *service) handleListPosts(w http.ResponseWriter, r *http.Request) {
 		"AllPosts":            posts,
 		"Pagination":          pagination,
 		"RoutePrefix":         s.routePrefix,
-		"CustomCSS":           s.cfg.CustomCSSURLs,
+		"CustomCSS":           s.stylesheets(),
 		"DateDisplay":         settings.DateDisplay,
 		"GoogleAnalyticsCode": settings.GoogleAnalyticsCode,
 		"Limit":               limit,
 		"NextOffset":          offset + len(posts),
+		"NextCursor":          next,
 		"SiteTitle":           s.effectiveTitle(settings),
 		"SiteURL":             s.cfg.SiteURL,
 		"SiteDescription":     s.effectiveDescription(settings),
-		"CanonicalURL":        s.canonicalURL("/"),
-		"FeedURL":             s.canonicalURL("/feed"),
+		"CanonicalURL":        s.canonicalURL(r, "/"),
+		"FeedURL":             s.canonicalURL(r, "/feed"),
+		"WebSiteJSONLD":       s.webSiteJSONLD(r, settings),
+		"BlogJSONLD":          s.blogJSONLD(r, s.effectiveTitle(settings), s.effectiveDescription(settings), s.canonicalURL(r, "/"), posts, offset+1),
 	}
 
-	s.executeTemplate(w, "list.html", data)
+	s.executeCachedTemplate(w, r, "list.html", data, newestModified(posts))
 }
 
 func (s *service) handleListPostsByTag(w http.ResponseWriter, r *http.Request) {
 	tagSlug := chi.URLParam(r, "tagSlug")
-	limit := 10
-	offset := 0
-	page := 1
+	limit, offset, page := s.listParams(r)
+	cursor, err := s.listCursor(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
-	if s.cfg.ListAll {
-		limit = 100000
-	} else {
-		if v := r.URL.Query().Get("limit"); v != "" {
-			if n, err := strconv.Atoi(v); err == nil && n > 0 && n <= 100 {
-				limit = n
-			}
-		}
-		if v := r.URL.Query().Get("offset"); v != "" {
-			if n, err := strconv.Atoi(v); err == nil && n >= 0 {
-				offset = n
-			}
-		}
-		if v := r.URL.Query().Get("page"); v != "" {
-			if n, err := strconv.Atoi(v); err == nil && n > 0 {
-				page = n
-				offset = (page - 1) * limit
-			}
-		}
+	tag, err := s.store.GetTag(r.Context(), tagSlug)
+	if err != nil {
+		http.Error(w, "failed to list posts", http.StatusInternalServerError)
+		return
+	}
+	if tag != nil && tag.Hidden {
+		s.renderNotFound(w, r)
+		return
+	}
+	var tagDescription string
+	if tag != nil {
+		tagDescription = tag.Description
 	}
 
-	posts, err := s.store.ListPostsByTag(r.Context(), tagSlug, limit, offset)
+	totalCount, err := s.store.CountPostsByTag(r.Context(), tagSlug)
 	if err != nil {
 		http.Error(w, "failed to list posts", http.StatusInternalServerError)
 		return
 	}
+	// Tags nobody has used or described don't exist, and out-of-range
+	// pages 404 so crawlers don't index empty archives.
+	if (tag == nil && totalCount == 0) || (!s.cfg.ListAll && offset > 0 && offset >= totalCount) {
+		s.renderNotFound(w, r)
+		return
+	}
+
+	var posts []Post
+	if cursor != nil {
+		posts, err = s.store.ListPostsByTagAfter(r.Context(), tagSlug, *cursor, limit)
+	} else {
+		posts, err = s.store.ListPostsByTag(r.Context(), tagSlug, limit, offset)
+	}
+	if err != nil {
+		http.Error(w, "failed to list posts", http.StatusInternalServerError)
+		return
+	}
+	next := nextCursor(posts, limit)
 
 	settings := resolveBlogSettings(nil)
 	if rawSettings, err := s.store.GetBlogSettings(r.Context()); err == nil {
@@ -138,8 +179,9 @@ func (s *service) handleListPostsByTag(w http.ResponseWriter, r *http.Request) {
 
 	// Build pagination (omitted when ListAll is enabled)
 	var pagination *Pagination
-	if !s.cfg.ListAll {
-		totalCount := s.countPostsByTag(r.Context(), tagSlug)
+	if cursor != nil {
+		pagination = cursorPagination(next, limit, s.routePrefix+"/tag/"+tagSlug)
+	} else if !s.cfg.ListAll {
 		p := buildPagination(page, limit, totalCount, s.routePrefix+"/tag/"+tagSlug)
 		pagination = &p
 	}
@@ -149,20 +191,60 @@ func (s *service) handleListPostsByTag(w http.ResponseWriter, r *http.Request) {
 		"AllPosts":            posts,
 		"Pagination":          pagination,
 		"RoutePrefix":         s.routePrefix,
-		"CustomCSS":           s.cfg.CustomCSSURLs,
+		"CustomCSS":           s.stylesheets(),
 		"TagSlug":             tagSlug,
+		"TagDescription":      tagDescription,
+		"TagPostCount":        totalCount,
 		"DateDisplay":         settings.DateDisplay,
 		"GoogleAnalyticsCode": settings.GoogleAnalyticsCode,
 		"Limit":               limit,
 		"NextOffset":          offset + len(posts),
+		"NextCursor":          next,
 		"SiteTitle":           s.effectiveTitle(settings),
 		"SiteURL":             s.cfg.SiteURL,
 		"SiteDescription":     s.effectiveDescription(settings),
-		"CanonicalURL":        s.canonicalURL("/tag/" + tagSlug),
-		"FeedURL":             s.canonicalURL("/feed"),
+		"CanonicalURL":        s.canonicalURL(r, "/tag/"+tagSlug),
+		"BlogJSONLD":          s.blogJSONLD(r, s.effectiveTitle(settings), tagDescription, s.canonicalURL(r, "/tag/"+tagSlug), posts, offset+1),
+		"FeedURL":             s.canonicalURL(r, "/feed"),
+		"TagFeedURL":          s.canonicalURL(r, "/tag/"+tagSlug+"/feed"),
 	}
+	if s.isThinArchive(totalCount) {
+		data["Robots"] = "noindex,follow"
+	}
+
+	s.executeCachedTemplate(w, r, "list.html", data, newestModified(posts))
+}
 
-	s.executeTemplate(w, "list.html", data)
+// listParams parses limit, offset and page from the query string.
+// page takes precedence over offset when both are given.
+func (s *service) listParams(r *http.Request) (limit, offset, page int) {
+	limit = 10
+	page = 1
+	if s.cfg.ListAll {
+		return 100000, 0, 1
+	}
+	maxLimit := s.cfg.MaxPageSize
+	if maxLimit <= 0 {
+		maxLimit = 100
+	}
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 && n <= maxLimit {
+			limit = n
+		}
+	}
+	if v := r.URL.Query().Get("offset"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			offset = n
+			page = offset/limit + 1
+		}
+	}
+	if v := r.URL.Query().Get("page"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			page = n
+			offset = (page - 1) * limit
+		}
+	}
+	return limit, offset, page
 }
 
 // RelatedPost holds a post with its first image and excerpt for the related posts section.
@@ -195,9 +277,32 @@ func (s *service) handleViewPost(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 
-		http.NotFound(w, r)
+		// Static files win over near matches, so a trailing slash or a
+		// different case only finds a post when no file has that name.
+		post, err = s.findCanonicalPost(r.Context(), slug)
+		if err != nil {
+			http.Error(w, "failed to load post", http.StatusInternalServerError)
+			return
+		}
+		if post == nil {
+			s.renderNotFound(w, r)
+			return
+		}
+	}
+	if s.redirectToCanonical(w, r, slug, post) {
 		return
 	}
+	s.recordView(r, post)
+	s.metrics.inc(metricPostsServed)
+	s.servePost(w, r, post, false)
+}
+
+// servePost renders a post page. A preview leaves out comments and marks
+// the page as a preview.
+func (s *service) servePost(w http.ResponseWriter, r *http.Request, post *Post, preview bool) {
+	if hidden, err := s.store.HiddenTagSlugs(r.Context()); err == nil {
+		post.Tags = visibleTags(post.Tags, hidden)
+	}
 
 	settings := resolveBlogSettings(nil)
 	if rawSettings, err := s.store.GetBlogSettings(r.Context()); err == nil {
@@ -206,18 +311,21 @@ func (s *service) handleViewPost(w http.ResponseWriter, r *http.Request) {
 
 	// Load related posts
 	var finalPosts []Post
-	targetCount := 5
+	targetCount := s.relatedPostsCount()
 
 	// 1. Try to get distinct related posts
-	rawRelated, err := s.store.GetRelatedPosts(r.Context(), post.ID, targetCount)
-	if err == nil {
-		finalPosts = append(finalPosts, rawRelated...)
+	showRelated := targetCount > 0 && FeatureEnabled(r.Context(), FeatureRelatedPosts)
+	if showRelated {
+		rawRelated, err := s.store.GetRelatedPosts(r.Context(), post.ID, targetCount)
+		if err == nil {
+			finalPosts = append(finalPosts, rawRelated...)
+		}
 	}
 
 	// 2. If we need more, fill with random recent posts
-	if len(finalPosts) < targetCount {
+	if showRelated && !s.cfg.RelatedPostsNoRandomFill && len(finalPosts) < targetCount {
 		needed := targetCount - len(finalPosts)
-		fallback, err := s.store.ListPublishedPosts(r.Context(), 50, 0)
+		fallback, err := s.listPublishedPosts(r.Context(), 50, 0)
 		if err == nil && len(fallback) > 0 {
 			// Build set of exclusion IDs (current post + already picked related)
 			exclude := make(map[string]bool)
@@ -246,34 +354,128 @@ func (s *service) handleViewPost(w http.ResponseWriter, r *http.Request) {
 	if len(finalPosts) > 0 {
 		if err := s.store.LoadPostsTags(r.Context(), finalPosts); err == nil {
 			for _, rp := range finalPosts {
+				rp.ReadingTimeMinutes = readingTimeMinutes(rp.ContentMarkdown)
 				relatedPosts = append(relatedPosts, RelatedPost{
 					Post:       rp,
 					FirstImage: extractFirstImage(rp.ContentHTML),
-					Excerpt:    trimToLength(markdownToPlainText(rp.ContentMarkdown), 150),
+					Excerpt:    postExcerpt(rp, 150),
 				})
 			}
 		}
 	}
 
-	firstImage := extractFirstImage(post.ContentHTML)
+	var moreByAuthor []Post
+	if FeatureEnabled(r.Context(), FeatureMoreByAuthor) {
+		moreByAuthor = s.moreByAuthor(r.Context(), post, finalPosts)
+	}
+
+	var author *AuthorProfile
+	if a, err := s.store.GetAuthor(r.Context(), post.AuthorID); err == nil {
+		author = s.authorProfile(a)
+	}
+
+	post.ContentHTML = s.sanitizeContent(post.ContentHTML)
+	var toc []TOCEntry
+	if FeatureEnabled(r.Context(), FeatureTableOfContents) {
+		post.ContentHTML, toc = tableOfContents(post.ContentHTML)
+	}
+
+	firstImage := s.resolveImageURL(extractFirstImage(post.ContentHTML))
+	post.ReadingTimeMinutes = readingTimeMinutes(post.ContentMarkdown)
 
 	data := map[string]any{
 		"Post":                post,
 		"RoutePrefix":         s.routePrefix,
-		"CustomCSS":           s.cfg.CustomCSSURLs,
-		"CommentsEnabled":     settings.CommentsEnabled,
+		"CustomCSS":           s.stylesheets(),
+		"CommentsEnabled":     settings.CommentsEnabled && !preview,
+		"CommentsClosed":      commentsClosed(settings, post, time.Now()),
+		"Preview":             preview,
+		"MaxCommentDepth":     s.maxCommentDepth(),
+		"CommentClaimLinks":   s.cfg.CommentClaimLinks,
 		"RelatedPosts":        relatedPosts,
+		"MoreByAuthor":        moreByAuthor,
+		"ReadingTime":         post.ReadingTimeMinutes,
 		"DateDisplay":         settings.DateDisplay,
 		"GoogleAnalyticsCode": settings.GoogleAnalyticsCode,
 		"SiteTitle":           s.effectiveTitle(settings),
 		"SiteURL":             s.cfg.SiteURL,
 		"SiteDescription":     s.effectiveDescription(settings),
-		"CanonicalURL":        s.canonicalURL("/" + post.Slug),
-		"FirstImage":          s.resolveImageURL(firstImage),
-		"FeedURL":             s.canonicalURL("/feed"),
+		"CanonicalURL":        s.canonicalURL(r, s.postPath(post.Slug)),
+		"FirstImage":          firstImage,
+		"ArticleJSONLD":       s.articleJSONLD(r, post, author, firstImage),
+		"FeedURL":             s.canonicalURL(r, "/feed"),
+		"Language":            s.postLanguage(post),
+		"Translations":        s.postTranslations(r, post),
+		"Author":              author,
+		"TOC":                 toc,
+	}
+	if s.views != nil {
+		if count, err := s.store.GetPostViewCount(r.Context(), post.ID); err == nil {
+			data["ViewCount"] = count
+		}
+	}
+	if s.cfg.Webmentions && !preview {
+		data["WebmentionURL"] = s.canonicalURL(r, "/webmention")
+		if mentions, err := s.store.ListWebmentions(r.Context(), post.ID, webmentionVerified); err == nil {
+			data["Webmentions"] = mentions
+		}
+	}
+
+	if preview {
+		s.executeTemplate(w, "post.html", data)
+		return
 	}
+	s.executeCachedTemplate(w, r, "post.html", data, postModified(*post))
+}
+
+// defaultRelatedPostsCount is the number of posts under Read Next when
+// Config.RelatedPostsCount is not set.
+const defaultRelatedPostsCount = 5
+
+// relatedPostsCount returns how many posts Read Next shows, or 0 when
+// Config.RelatedPostsCount hides it.
+func (s *service) relatedPostsCount() int {
+	switch n := s.cfg.RelatedPostsCount; {
+	case n < 0:
+		return 0
+	case n == 0:
+		return defaultRelatedPostsCount
+	default:
+		return n
+	}
+}
+
+// defaultMoreByAuthorCount is the number of posts in the "More by" section
+// when Config.MoreByAuthorCount is not set.
+const defaultMoreByAuthorCount = 3
 
-	s.executeTemplate(w, "post.html", data)
+// moreByAuthor returns the author's latest published posts other than post
+// and the posts already shown under Read Next.
+func (s *service) moreByAuthor(ctx context.Context, post *Post, shown []Post) []Post {
+	count := s.cfg.MoreByAuthorCount
+	if count <= 0 {
+		count = defaultMoreByAuthorCount
+	}
+	exclude := map[string]bool{post.ID: true}
+	for _, p := range shown {
+		exclude[p.ID] = true
+	}
+	posts, err := s.store.ListPostsByAuthor(ctx, post.AuthorID, count+len(exclude), 0)
+	if err != nil {
+		return nil
+	}
+	var out []Post
+	for _, p := range posts {
+		if exclude[p.ID] || p.PostType == PostTypePage {
+			continue
+		}
+		p.ReadingTimeMinutes = readingTimeMinutes(p.ContentMarkdown)
+		out = append(out, p)
+		if len(out) == count {
+			break
+		}
+	}
+	return out
 }
 
 // extractFirstImage pulls the first image URL from HTML content.
@@ -345,13 +547,43 @@ func (s *service) executeTemplate(w http.ResponseWriter, name string, data any)
 	}
 }
 
-// canonicalURL builds a full canonical URL by joining SiteURL + routePrefix + path.
-func (s *service) canonicalURL(path string) string {
-	if s.cfg.SiteURL == "" {
-		return ""
+// renderNotFound answers 404 with notfound.html in the site layout, so a
+// mistyped link still shows the blog with a search box.
+func (s *service) renderNotFound(w http.ResponseWriter, r *http.Request) {
+	settings := resolveBlogSettings(nil)
+	if rawSettings, err := s.store.GetBlogSettings(r.Context()); err == nil {
+		settings = resolveBlogSettings(rawSettings)
 	}
-	base := strings.TrimSuffix(s.cfg.SiteURL, "/")
-	return base + s.routePrefix + path
+	data := map[string]any{
+		"NotFound":            true,
+		"RoutePrefix":         s.routePrefix,
+		"CustomCSS":           s.stylesheets(),
+		"GoogleAnalyticsCode": settings.GoogleAnalyticsCode,
+		"SiteTitle":           s.effectiveTitle(settings),
+		"SiteURL":             s.cfg.SiteURL,
+		"SiteDescription":     s.effectiveDescription(settings),
+		"FeedURL":             s.canonicalURL(r, "/feed"),
+		"NoIndex":             true,
+	}
+	tpl, ok := s.templates["notfound.html"]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	var buf bytes.Buffer
+	if err := tpl.ExecuteTemplate(&buf, "base.html", data); err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusNotFound)
+	_, _ = w.Write(buf.Bytes())
+}
+
+// canonicalURL builds a full canonical URL by joining the base URL,
+// routePrefix and path.
+func (s *service) canonicalURL(r *http.Request, path string) string {
+	return s.baseURL(r) + s.routePrefix + path
 }
 
 // resolveImageURL converts a relative image URL to an absolute URL using SiteURL.
@@ -376,15 +608,52 @@ func (s *service) resolveImageURL(img string) string {
 func postsToSummaries(posts []Post) []PostSummary {
 	summaries := make([]PostSummary, len(posts))
 	for i, p := range posts {
+		p.ReadingTimeMinutes = readingTimeMinutes(p.ContentMarkdown)
 		summaries[i] = PostSummary{
 			Post:       p,
 			FirstImage: extractFirstImage(p.ContentHTML),
-			Excerpt:    trimToLength(markdownToPlainText(p.ContentMarkdown), 300),
+			Excerpt:    postExcerpt(p, 300),
 		}
 	}
 	return summaries
 }
 
+// postExcerpt returns the post's Excerpt, or the first maxLen characters
+// of its text when the author didn't write one.
+func postExcerpt(p Post, maxLen int) string {
+	if excerpt := strings.TrimSpace(p.Excerpt); excerpt != "" {
+		return excerpt
+	}
+	return trimToLength(markdownToPlainText(p.ContentMarkdown), maxLen)
+}
+
+// Reading speeds used by readingTimeMinutes.
+const (
+	readingWordsPerMinute = 200
+	readingCJKCharsPerMin = 500
+)
+
+// readingTimeMinutes estimates how long the post takes to read, never less
+// than one minute. CJK text has no word spacing, so those characters are
+// counted individually at a separate rate.
+func readingTimeMinutes(markdown string) int {
+	text := markdownToPlainText(markdown)
+	cjk := 0
+	text = strings.Map(func(r rune) rune {
+		if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+			cjk++
+			return ' '
+		}
+		return r
+	}, text)
+	words := len(strings.Fields(text))
+	minutes := float64(words)/readingWordsPerMinute + float64(cjk)/readingCJKCharsPerMin
+	if n := int(math.Ceil(minutes)); n > 1 {
+		return n
+	}
+	return 1
+}
+
 // buildPagination creates a Pagination struct for template use.
 func buildPagination(currentPage, perPage, totalCount int, basePath string) Pagination {
 	if perPage <= 0 {
@@ -401,36 +670,149 @@ func buildPagination(currentPage, perPage, totalCount int, basePath string) Pagi
 	p := Pagination{
 		CurrentPage: currentPage,
 		TotalPages:  totalPages,
+		TotalPosts:  totalCount,
 	}
 
 	if currentPage < totalPages {
 		p.NextPageURL = fmt.Sprintf("%s?page=%d&limit=%d", basePath, currentPage+1, perPage)
 	}
 	if currentPage > 1 {
-		p.PrevPageURL = fmt.Sprintf("%s?page=%d&limit=%d", basePath, currentPage-1, perPage)
+		// From past the end, "previous" leads back to the last page.
+		prevPage := min(currentPage-1, totalPages)
+		p.PrevPageURL = fmt.Sprintf("%s?page=%d&limit=%d", basePath, prevPage, perPage)
+		p.PrevOffset = (prevPage - 1) * perPage
 	}
 
 	return p
 }
 
-// countPublishedPosts returns the total number of published posts.
-func (s *service) countPublishedPosts(ctx context.Context) int {
-	// Use a large limit to fetch all published post IDs for counting.
-	posts, err := s.store.ListPublishedPosts(ctx, 100000, 0)
-	if err != nil {
-		return 0
+// webSiteJSONLD builds schema.org WebSite structured data with a SearchAction
+// pointing at the public search page.
+func (s *service) webSiteJSONLD(r *http.Request, settings BlogSettings) template.JS {
+	home := s.canonicalURL(r, "/")
+	name := s.effectiveTitle(settings)
+	if name == "" {
+		name = "Blog"
+	}
+	doc := map[string]any{
+		"@context": "https://schema.org",
+		"@type":    "WebSite",
+		"name":     name,
+		"url":      home,
+		"potentialAction": map[string]any{
+			"@type":       "SearchAction",
+			"target":      map[string]string{"@type": "EntryPoint", "urlTemplate": s.canonicalURL(r, "/search") + "?q={search_term_string}"},
+			"query-input": "required name=search_term_string",
+		},
+	}
+	if description := s.effectiveDescription(settings); description != "" {
+		doc["description"] = description
+	}
+	return marshalJSONLD(doc)
+}
+
+// articleJSONLD builds schema.org Article structured data for a post page.
+// image must already be absolute.
+func (s *service) articleJSONLD(r *http.Request, post *Post, author *AuthorProfile, image string) template.JS {
+	canonical := s.canonicalURL(r, s.postPath(post.Slug))
+	doc := map[string]any{
+		"@context":         "https://schema.org",
+		"@type":            "Article",
+		"headline":         post.Title,
+		"url":              canonical,
+		"mainEntityOfPage": map[string]string{"@type": "WebPage", "@id": canonical},
+	}
+	if description := firstNonEmpty(post.MetaDescription, post.Excerpt); description != "" {
+		doc["description"] = description
+	}
+	if post.PublishedAt != nil {
+		doc["datePublished"] = post.PublishedAt.Format(time.RFC3339)
+	}
+	if modified := postModified(*post); !modified.IsZero() {
+		doc["dateModified"] = modified.Format(time.RFC3339)
+	}
+	if author != nil && author.Name != "" {
+		person := map[string]string{"@type": "Person", "name": author.Name}
+		if link := author.homeLink(); link != "" {
+			person["url"] = link
+		}
+		if author.AvatarURL != "" {
+			person["image"] = author.AvatarURL
+		}
+		doc["author"] = person
+	}
+	if image != "" {
+		doc["image"] = image
 	}
-	return len(posts)
+	return marshalJSONLD(doc)
 }
 
-// countPostsByTag returns the total number of published posts with a given tag.
-func (s *service) countPostsByTag(ctx context.Context, tagSlug string) int {
-	// Use a large limit to fetch all matching posts for counting.
-	posts, err := s.store.ListPostsByTag(ctx, tagSlug, 100000, 0)
+// blogJSONLD builds schema.org Blog structured data for a list page, with
+// the listed posts as an ItemList. first is the position of posts[0].
+func (s *service) blogJSONLD(r *http.Request, name, description, canonical string, posts []Post, first int) template.JS {
+	if name == "" {
+		name = "Blog"
+	}
+	items := make([]map[string]any, 0, len(posts))
+	for i, p := range posts {
+		items = append(items, map[string]any{
+			"@type":    "ListItem",
+			"position": first + i,
+			"url":      s.canonicalURL(r, s.postPath(p.Slug)),
+			"name":     p.Title,
+		})
+	}
+	doc := map[string]any{
+		"@context": "https://schema.org",
+		"@type":    "Blog",
+		"name":     name,
+		"url":      canonical,
+		"mainEntity": map[string]any{
+			"@type":           "ItemList",
+			"itemListElement": items,
+		},
+	}
+	if description != "" {
+		doc["description"] = description
+	}
+	return marshalJSONLD(doc)
+}
+
+// marshalJSONLD encodes doc for a <script type="application/ld+json">
+// block. json.Marshal escapes <, > and &, so text such as "</script>" in a
+// title can't end the block early.
+func marshalJSONLD(doc map[string]any) template.JS {
+	raw, err := json.Marshal(doc)
 	if err != nil {
-		return 0
+		return ""
+	}
+	return template.JS(raw)
+}
+
+// countPublishedPosts returns the number of posts listPublishedPosts pages
+// through.
+func (s *service) countPublishedPosts(ctx context.Context) (int, error) {
+	if s.cfg.ListPages {
+		return s.store.CountPublishedPosts(ctx)
+	}
+	return s.store.CountPublishedArticles(ctx)
+}
+
+// listPublishedPosts lists published posts for the post list and feeds.
+// Pages are left out unless Config.ListPages is set.
+func (s *service) listPublishedPosts(ctx context.Context, limit, offset int) ([]Post, error) {
+	if s.cfg.ListPages {
+		return s.store.ListPublishedPosts(ctx, limit, offset)
+	}
+	return s.store.ListPublishedArticles(ctx, limit, offset)
+}
+
+// listPublishedPostsAfter is listPublishedPosts resuming after a cursor.
+func (s *service) listPublishedPostsAfter(ctx context.Context, after postCursor, limit int) ([]Post, error) {
+	if s.cfg.ListPages {
+		return s.store.ListPublishedPostsAfter(ctx, after, limit)
 	}
-	return len(posts)
+	return s.store.ListPublishedArticlesAfter(ctx, after, limit)
 }
 
 // tplTruncate is a template function that truncates a string to the given length.
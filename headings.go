@@ -0,0 +1,72 @@
+package blog
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+// headingSlug turns heading text into a URL fragment: lowercase letters and
+// digits, with runs of spaces and punctuation collapsed to a single dash.
+func headingSlug(text string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			dash = false
+			b.WriteRune(r)
+		case unicode.IsSpace(r) || r == '-' || r == '_':
+			dash = true
+		}
+	}
+	if b.Len() == 0 {
+		return "section"
+	}
+	return b.String()
+}
+
+// headingIDs implements parser.IDs with headingSlug, suffixing repeats with
+// -1, -2, ... so every id in a post is unique.
+type headingIDs struct {
+	used map[string]bool
+}
+
+func newHeadingIDs() *headingIDs {
+	return &headingIDs{used: map[string]bool{}}
+}
+
+func (ids *headingIDs) Generate(value []byte, kind ast.NodeKind) []byte {
+	base := headingSlug(string(value))
+	id := base
+	for i := 1; ids.used[id]; i++ {
+		id = base + "-" + strconv.Itoa(i)
+	}
+	ids.used[id] = true
+	return []byte(id)
+}
+
+func (ids *headingIDs) Put(value []byte) {
+	ids.used[string(value)] = true
+}
+
+var headingWithIDRe = regexp.MustCompile(`(?s)<h([1-6])([^>]*\sid="([^"]+)"[^>]*)>(.*?)</h([1-6])>`)
+
+// addHeadingAnchors appends a permalink (<a class="heading-anchor">) to every
+// heading that has an id.
+func addHeadingAnchors(html string) string {
+	return headingWithIDRe.ReplaceAllStringFunc(html, func(match string) string {
+		m := headingWithIDRe.FindStringSubmatch(match)
+		if m[1] != m[5] || strings.Contains(m[4], `class="heading-anchor"`) {
+			return match
+		}
+		return "<h" + m[1] + m[2] + ">" + m[4] +
+			` <a class="heading-anchor" href="#` + m[3] + `" aria-label="Link to this section">¶</a></h` + m[1] + ">"
+	})
+}
@@ -0,0 +1,49 @@
+package blog
+
+import (
+	"regexp"
+	"strings"
+)
+
+// captionedImageRe matches a paragraph holding nothing but an image with a
+// title, which is how goldmark renders ![alt](url "caption") on its own
+// line.
+var captionedImageRe = regexp.MustCompile(`<p>\s*(<img\s[^>]*\btitle="([^"]*)"[^>]*>)\s*</p>`)
+
+// addImageCaptions turns each image that stands alone in its paragraph and
+// has a title into a <figure> with the title as its <figcaption>. The img
+// keeps all its attributes, so srcset, sizes and loading carry over.
+// Images already inside a <figure>, inline images and images without a
+// title are left alone.
+func addImageCaptions(html string) string {
+	matches := captionedImageRe.FindAllStringSubmatchIndex(html, -1)
+	if len(matches) == 0 {
+		return html
+	}
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		caption := strings.TrimSpace(html[m[4]:m[5]])
+		if caption == "" || insideFigure(html[:m[0]]) {
+			continue
+		}
+		b.WriteString(html[last:m[0]])
+		b.WriteString("<figure>")
+		b.WriteString(html[m[2]:m[3]])
+		b.WriteString("<figcaption>")
+		// The title is already escaped for the attribute, which is also
+		// valid as text.
+		b.WriteString(caption)
+		b.WriteString("</figcaption></figure>")
+		last = m[1]
+	}
+	b.WriteString(html[last:])
+	return b.String()
+}
+
+// insideFigure reports whether html, the text before an image, leaves a
+// <figure> element open.
+func insideFigure(html string) bool {
+	lower := strings.ToLower(html)
+	return strings.Count(lower, "<figure") > strings.Count(lower, "</figure>")
+}
@@ -6,8 +6,10 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
@@ -64,9 +66,10 @@ func (s *FileImageStore) SaveImage(ctx context.Context, id, filename, contentTyp
 		return "", fmt.Errorf("failed to store file: %w", err)
 	}
 
-	// Store metadata in a sidecar file
+	// Store metadata in a sidecar file. The ID lets ListImages tell the
+	// variants of an upload from the upload itself.
 	metaPath := filepath.Join(s.Directory, hashID+".meta")
-	metaContent := fmt.Sprintf("%s\n%s", filename, contentType)
+	metaContent := fmt.Sprintf("%s\n%s\n%s", filename, contentType, id)
 	if err := os.WriteFile(metaPath, []byte(metaContent), 0644); err != nil {
 		// Non-fatal: we can still serve the file
 	}
@@ -89,7 +92,7 @@ func (s *FileImageStore) GetImage(ctx context.Context, id string) (string, io.Re
 	metaPath := filepath.Join(s.Directory, baseID+".meta")
 
 	if metaBytes, err := os.ReadFile(metaPath); err == nil {
-		lines := strings.SplitN(string(metaBytes), "\n", 2)
+		lines := strings.SplitN(string(metaBytes), "\n", 3)
 		if len(lines) >= 2 {
 			contentType = lines[1]
 		}
@@ -116,6 +119,74 @@ func (s *FileImageStore) GetImage(ctx context.Context, id string) (string, io.Re
 	return "", nil, fmt.Errorf("image not found: %s", id)
 }
 
+// ListImages lists the images in the directory, newest first, with the
+// original file names from their .meta sidecars. The resized variants
+// saved for an upload are left out while the upload itself is listed.
+func (s *FileImageStore) ListImages(ctx context.Context, limit, offset int) ([]ImageInfo, error) {
+	entries, err := os.ReadDir(s.Directory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image directory: %w", err)
+	}
+	var images []ImageInfo
+	var saveIDs []string
+	uploads := map[string]bool{}
+	for _, entry := range entries {
+		name := entry.Name()
+		ext := filepath.Ext(name)
+		// Skip sidecars and uploads still being written.
+		if entry.IsDir() || ext == ".meta" || strings.HasPrefix(name, "img-") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		id := strings.TrimSuffix(name, ext)
+		image := ImageInfo{
+			ID:         id,
+			URL:        s.URLPrefix + "/" + name,
+			Filename:   name,
+			Size:       info.Size(),
+			UploadedAt: info.ModTime().UTC(),
+		}
+		saveID := ""
+		if meta, err := os.ReadFile(filepath.Join(s.Directory, id+".meta")); err == nil {
+			lines := strings.SplitN(string(meta), "\n", 3)
+			if lines[0] != "" {
+				image.Filename = lines[0]
+			}
+			if len(lines) == 3 {
+				saveID = lines[2]
+				uploads[saveID] = true
+			}
+		}
+		images = append(images, image)
+		saveIDs = append(saveIDs, saveID)
+	}
+	kept := images[:0]
+	for i, image := range images {
+		if original, ok := variantOf(saveIDs[i]); ok && uploads[original] {
+			continue
+		}
+		kept = append(kept, image)
+	}
+	images = kept
+	sort.Slice(images, func(i, j int) bool {
+		if !images[i].UploadedAt.Equal(images[j].UploadedAt) {
+			return images[i].UploadedAt.After(images[j].UploadedAt)
+		}
+		return images[i].ID < images[j].ID
+	})
+	if offset >= len(images) {
+		return []ImageInfo{}, nil
+	}
+	images = images[offset:]
+	if limit > 0 && limit < len(images) {
+		images = images[:limit]
+	}
+	return images, nil
+}
+
 // DeleteImage removes an image by ID.
 func (s *FileImageStore) DeleteImage(ctx context.Context, id string) error {
 	// Try to delete with various extensions
@@ -133,6 +204,30 @@ func (s *FileImageStore) DeleteImage(ctx context.Context, id string) error {
 	return nil
 }
 
+// sniffImageType returns the type of an image from its first bytes, which
+// must be JPEG, PNG, GIF or WebP. It reports false for anything else,
+// whatever the file's name or declared type.
+func sniffImageType(data []byte) (string, bool) {
+	contentType := http.DetectContentType(data)
+	switch contentType {
+	case "image/jpeg", "image/png", "image/gif", "image/webp":
+		return contentType, true
+	default:
+		return contentType, false
+	}
+}
+
+// imageFilename gives filename the extension of contentType unless it
+// already has a matching one, so "photo.exe" holding a PNG is stored as
+// "photo.png".
+func imageFilename(filename, contentType string) string {
+	ext := filepath.Ext(filename)
+	if contentTypeFromExtension(ext) == contentType {
+		return filename
+	}
+	return strings.TrimSuffix(filename, ext) + extensionFromContentType(contentType)
+}
+
 func extensionFromContentType(contentType string) string {
 	switch contentType {
 	case "image/jpeg":
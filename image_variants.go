@@ -0,0 +1,170 @@
+package blog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// ImageVariantConfig enables downscaled copies of uploaded images so
+// templates can emit srcset attributes.
+type ImageVariantConfig struct {
+	// Widths lists the variant widths in pixels (default 320, 640, 1280).
+	// Variants at or above the original width are skipped.
+	Widths []int
+	// MaxPixels rejects uploads whose width*height exceeds it, bounding the
+	// memory needed to decode them (default 40 megapixels).
+	MaxPixels int
+}
+
+var defaultVariantWidths = []int{320, 640, 1280}
+
+const defaultVariantMaxPixels = 40_000_000
+
+// errImageTooLarge is returned when an upload exceeds MaxPixels.
+var errImageTooLarge = fmt.Errorf("image exceeds the maximum pixel count")
+
+// saveImageVariants stores resized copies of data and returns their public
+// URLs keyed by width. SVGs and animated GIFs are left alone.
+func (s *service) saveImageVariants(ctx context.Context, id, filename, contentType string, data []byte) (map[int]string, error) {
+	cfg := s.cfg.ImageVariants
+	if cfg == nil || strings.Contains(contentType, "svg") || strings.EqualFold(path.Ext(filename), ".svg") {
+		return nil, nil
+	}
+	widths := cfg.Widths
+	if len(widths) == 0 {
+		widths = defaultVariantWidths
+	}
+	maxPixels := cfg.MaxPixels
+	if maxPixels <= 0 {
+		maxPixels = defaultVariantMaxPixels
+	}
+
+	imgCfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		// Not a format we can resize (e.g. webp); store the original only.
+		return nil, nil
+	}
+	if imgCfg.Width*imgCfg.Height > maxPixels {
+		return nil, errImageTooLarge
+	}
+	if format == "gif" {
+		anim, err := gif.DecodeAll(bytes.NewReader(data))
+		if err != nil || len(anim.Image) > 1 {
+			return nil, nil
+		}
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil
+	}
+
+	base := strings.TrimSuffix(filename, path.Ext(filename))
+	variants := map[int]string{}
+	for _, width := range widths {
+		if width <= 0 || width >= imgCfg.Width {
+			continue
+		}
+		resized := resizeImage(src, width)
+
+		var buf bytes.Buffer
+		variantType, ext := "image/png", ".png"
+		if format == "jpeg" {
+			variantType, ext = "image/jpeg", ".jpg"
+			err = jpeg.Encode(&buf, resized, &jpeg.Options{Quality: 85})
+		} else {
+			err = png.Encode(&buf, resized)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("encode %dw variant: %w", width, err)
+		}
+
+		suffix := variantSuffix(width)
+		storeURL, err := s.cfg.ImageStore.SaveImage(ctx, id+suffix, base+suffix+ext, variantType, &buf)
+		if err != nil {
+			return nil, fmt.Errorf("save %dw variant: %w", width, err)
+		}
+		variants[width] = s.routePrefix + "/images/" + path.Base(storeURL)
+	}
+	return variants, nil
+}
+
+// variantSuffix is appended to an upload's ID and file name to name its
+// variant of the given width.
+func variantSuffix(width int) string {
+	return "-" + strconv.Itoa(width) + "w"
+}
+
+// variantOf returns the upload ID that a variant's ID was made from, and
+// whether id has a variant suffix at all.
+func variantOf(id string) (string, bool) {
+	i := strings.LastIndex(id, "-")
+	if i < 0 {
+		return "", false
+	}
+	digits, ok := strings.CutSuffix(id[i+1:], "w")
+	if !ok || digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return "", false
+	}
+	return id[:i], true
+}
+
+// resizeImage downscales src to the given width, preserving aspect ratio,
+// by averaging the source pixels covered by each destination pixel.
+func resizeImage(src image.Image, width int) *image.RGBA {
+	bounds := src.Bounds()
+	sw, sh := bounds.Dx(), bounds.Dy()
+	height := sh * width / sw
+	if height < 1 {
+		height = 1
+	}
+
+	rgba, ok := src.(*image.RGBA)
+	if !ok {
+		rgba = image.NewRGBA(image.Rect(0, 0, sw, sh))
+		draw.Draw(rgba, rgba.Bounds(), src, bounds.Min, draw.Src)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := y * sh / height
+		y1 := (y + 1) * sh / height
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < width; x++ {
+			x0 := x * sw / width
+			x1 := (x + 1) * sw / width
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+			var r, g, b, a, n int
+			for sy := y0; sy < y1; sy++ {
+				row := rgba.Pix[sy*rgba.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					i := sx * 4
+					r += int(row[i])
+					g += int(row[i+1])
+					b += int(row[i+2])
+					a += int(row[i+3])
+					n++
+				}
+			}
+			o := dst.PixOffset(x, y)
+			dst.Pix[o] = uint8(r / n)
+			dst.Pix[o+1] = uint8(g / n)
+			dst.Pix[o+2] = uint8(b / n)
+			dst.Pix[o+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,74 @@
+package blog
+
+import (
+	"log"
+	"net/http"
+	"time"
+)
+
+// Logger receives the blog's log output: background task progress, AI
+// calls, failed notifications and, with Config.LogRequests, one line per
+// request. *log.Logger satisfies it; to send the lines to a log/slog
+// handler, pass slog.NewLogLogger(handler, level).
+type Logger interface {
+	Printf(format string, v ...any)
+}
+
+// logf writes to Config.Logger, or the standard logger when it is nil.
+func (s *service) logf(format string, v ...any) {
+	if s.cfg.Logger != nil {
+		s.cfg.Logger.Printf(format, v...)
+		return
+	}
+	log.Printf(format, v...)
+}
+
+// logRequests logs each request's method, path, status, size and duration
+// once it has been served. The query string is left out, since it can
+// carry secrets such as preview tokens.
+func (s *service) logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		lw := &loggingWriter{ResponseWriter: w}
+		next.ServeHTTP(lw, r)
+		if lw.status == 0 {
+			lw.status = http.StatusOK
+		}
+		s.logf("http: %s %s status=%d bytes=%d dt=%s", r.Method, r.URL.EscapedPath(), lw.status, lw.bytes, time.Since(start))
+	})
+}
+
+// loggingWriter records the status and body size of a response.
+type loggingWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (lw *loggingWriter) WriteHeader(code int) {
+	if lw.status == 0 {
+		lw.status = code
+	}
+	lw.ResponseWriter.WriteHeader(code)
+}
+
+func (lw *loggingWriter) Write(b []byte) (int, error) {
+	if lw.status == 0 {
+		lw.status = http.StatusOK
+	}
+	n, err := lw.ResponseWriter.Write(b)
+	lw.bytes += n
+	return n, err
+}
+
+// Flush keeps streamed responses, such as the AI chat stream, working.
+func (lw *loggingWriter) Flush() {
+	if f, ok := lw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (lw *loggingWriter) Unwrap() http.ResponseWriter {
+	return lw.ResponseWriter
+}
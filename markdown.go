@@ -0,0 +1,146 @@
+package blog
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	gmhtml "github.com/yuin/goldmark/renderer/html"
+)
+
+// defaultHighlightStyle is the chroma style used when
+// MarkdownExtensions.HighlightStyle is empty.
+const defaultHighlightStyle = "github"
+
+// MarkdownExtensions turns on Markdown syntax beyond CommonMark when posts
+// are saved. Tables are always enabled.
+type MarkdownExtensions struct {
+	// GFM adds the rest of GitHub Flavored Markdown: ~~strikethrough~~,
+	// task lists and bare URLs turned into links.
+	GFM bool
+	// Footnotes adds [^1] references, with the notes listed at the end of
+	// the post.
+	Footnotes bool
+	// DefinitionLists adds "Term" lines followed by ": definition" lines.
+	DefinitionLists bool
+	// SyntaxHighlighting colours fenced code blocks that name their
+	// language. Tokens get chroma's CSS classes, and the stylesheet for
+	// HighlightStyle is served at <prefix>/highlight.css and linked from
+	// the page templates.
+	SyntaxHighlighting bool
+	// HighlightStyle names the chroma style for SyntaxHighlighting
+	// (default "github").
+	HighlightStyle string
+}
+
+// highlightStyleName returns the chroma style for HighlightStyle.
+func (e MarkdownExtensions) highlightStyleName() string {
+	if style := strings.ToLower(strings.TrimSpace(e.HighlightStyle)); style != "" {
+		return style
+	}
+	return defaultHighlightStyle
+}
+
+// validate rejects a highlight style chroma doesn't know, which it would
+// otherwise silently replace with its fallback.
+func (e MarkdownExtensions) validate() error {
+	name := strings.ToLower(strings.TrimSpace(e.HighlightStyle))
+	if name == "" {
+		return nil
+	}
+	if _, ok := styles.Registry[name]; !ok {
+		return fmt.Errorf("unknown highlight style %q", e.HighlightStyle)
+	}
+	return nil
+}
+
+// newMarkdown builds a goldmark converter with the given extensions.
+// allowUnsafe passes raw HTML through, and headingIDs gives every heading
+// an id; the caller supplies the ids through the parser context.
+func newMarkdown(ext MarkdownExtensions, allowUnsafe, headingIDs bool) goldmark.Markdown {
+	extensions := []goldmark.Extender{extension.Table}
+	if ext.GFM {
+		extensions = append(extensions, extension.Strikethrough, extension.TaskList, extension.Linkify)
+	}
+	if ext.Footnotes {
+		extensions = append(extensions, extension.Footnote)
+	}
+	if ext.DefinitionLists {
+		extensions = append(extensions, extension.DefinitionList)
+	}
+	if ext.SyntaxHighlighting {
+		// Classes rather than inline styles, which the sanitizer presets
+		// strip.
+		extensions = append(extensions, highlighting.NewHighlighting(
+			highlighting.WithStyle(ext.highlightStyleName()),
+			highlighting.WithFormatOptions(chromahtml.WithClasses(true)),
+		))
+	}
+	opts := []goldmark.Option{goldmark.WithExtensions(extensions...)}
+	if headingIDs {
+		opts = append(opts, goldmark.WithParserOptions(parser.WithAutoHeadingID()))
+	}
+	if allowUnsafe {
+		opts = append(opts, goldmark.WithRendererOptions(gmhtml.WithUnsafe()))
+	}
+	return goldmark.New(opts...)
+}
+
+// renderPostHTML converts post markdown into the HTML stored on the post,
+// using Config.MarkdownExtensions and applying the configured save-time
+// transforms. Everything that stores or exports post HTML goes through it,
+// so they all agree.
+func (s *service) renderPostHTML(markdown string) (string, error) {
+	md := newMarkdown(s.cfg.MarkdownExtensions, true, s.cfg.HeadingAnchors)
+	var opts []parser.ParseOption
+	if s.cfg.HeadingAnchors {
+		opts = append(opts, parser.WithContext(parser.NewContext(parser.WithIDs(newHeadingIDs()))))
+	}
+	var buf bytes.Buffer
+	if err := md.Convert([]byte(markdown), &buf, opts...); err != nil {
+		return "", err
+	}
+	html := buf.String()
+	if s.cfg.HeadingAnchors {
+		html = addHeadingAnchors(html)
+	}
+	if s.cfg.ImageCaptions {
+		html = addImageCaptions(html)
+	}
+	return s.sanitizeStoredHTML(html), nil
+}
+
+// highlightCSS returns the stylesheet for the classes SyntaxHighlighting
+// puts on code blocks, in the configured style.
+func highlightCSS(ext MarkdownExtensions) ([]byte, error) {
+	var buf bytes.Buffer
+	formatter := chromahtml.New(chromahtml.WithClasses(true))
+	if err := formatter.WriteCSS(&buf, styles.Get(ext.highlightStyleName())); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// handleHighlightCSS serves the syntax highlighting stylesheet.
+func (s *service) handleHighlightCSS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	writeCached(w, r, "text/css; charset=utf-8", time.Time{}, s.highlightCSS)
+}
+
+// stylesheets returns the stylesheet URLs page templates link as
+// .CustomCSS: the highlighting stylesheet, when enabled, then
+// Config.CustomCSSURLs so they can override it.
+func (s *service) stylesheets() []string {
+	if s.highlightCSS == nil {
+		return s.cfg.CustomCSSURLs
+	}
+	return append([]string{s.routePrefix + "/highlight.css"}, s.cfg.CustomCSSURLs...)
+}
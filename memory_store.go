@@ -0,0 +1,375 @@
+package blog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// memoryStoreDefaultLimit is how many entities Find returns when the query
+// has no limit, as for SQLXStore.
+const memoryStoreDefaultLimit = 200
+
+// MemoryStore is a BlogStore that keeps entities in memory. It is safe for
+// concurrent use and is meant for tests, demos and quick starts: nothing
+// survives a restart. Find filters, orders and pages like SQLXStore, and
+// MemoryStore implements the optional BatchSaver, StatusClaimer,
+// CursorFinder, ConditionalSaver, ChangeApplier and Counter interfaces.
+type MemoryStore struct {
+	mu       sync.RWMutex
+	entities map[string]*Entity
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entities: map[string]*Entity{}}
+}
+
+// Migrate implements BlogStore. There is nothing to migrate.
+func (m *MemoryStore) Migrate(ctx context.Context) error { return nil }
+
+// Save creates or updates an entity by ID. An entity without an ID gets a
+// generated one. Updates keep the stored created_at.
+func (m *MemoryStore) Save(ctx context.Context, e *Entity) error {
+	stored, err := memoryEntity(e)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.put(stored)
+	return nil
+}
+
+// SaveBatch implements BatchSaver. Every entity is checked before any is
+// saved, so either all are saved or none is.
+func (m *MemoryStore) SaveBatch(ctx context.Context, entities []*Entity) error {
+	stored := make([]*Entity, 0, len(entities))
+	for _, e := range entities {
+		s, err := memoryEntity(e)
+		if err != nil {
+			return err
+		}
+		stored = append(stored, s)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, s := range stored {
+		m.put(s)
+	}
+	return nil
+}
+
+// SaveIfUnchanged implements ConditionalSaver.
+func (m *MemoryStore) SaveIfUnchanged(ctx context.Context, e *Entity, expected time.Time) (bool, error) {
+	stored, err := memoryEntity(e)
+	if err != nil {
+		return false, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	existing, ok := m.entities[stored.ID]
+	if !ok || existing.UpdatedAt == nil || !existing.UpdatedAt.Equal(expected) {
+		return false, nil
+	}
+	m.put(stored)
+	return true, nil
+}
+
+// ApplyChanges implements ChangeApplier. Every entity is checked, and
+// every condition compared with the stored entities, before any change is
+// applied.
+func (m *MemoryStore) ApplyChanges(ctx context.Context, changes []EntityChange) (bool, error) {
+	stored := make([]*Entity, len(changes))
+	for i, change := range changes {
+		if change.Save == nil {
+			continue
+		}
+		s, err := memoryEntity(change.Save)
+		if err != nil {
+			return false, err
+		}
+		stored[i] = s
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, change := range changes {
+		if change.Save == nil || change.Expected == nil {
+			continue
+		}
+		existing, ok := m.entities[change.Save.ID]
+		if !ok || existing.UpdatedAt == nil || !existing.UpdatedAt.Equal(*change.Expected) {
+			return false, nil
+		}
+	}
+	for i, change := range changes {
+		if stored[i] != nil {
+			m.put(stored[i])
+		} else {
+			delete(m.entities, change.Delete)
+		}
+	}
+	return true, nil
+}
+
+// ClaimStatus implements StatusClaimer.
+func (m *MemoryStore) ClaimStatus(ctx context.Context, id, from, to string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entities[id]
+	if !ok || e.Status != from {
+		return false, nil
+	}
+	now := time.Now().UTC()
+	e.Status = to
+	e.UpdatedAt = &now
+	return true, nil
+}
+
+// Get retrieves a single entity by ID, or nil if there is none.
+func (m *MemoryStore) Get(ctx context.Context, id string) (*Entity, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	e, ok := m.entities[id]
+	if !ok {
+		return nil, nil
+	}
+	return cloneEntity(e), nil
+}
+
+// Find retrieves entities matching a query.
+func (m *MemoryStore) Find(ctx context.Context, q Query) ([]*Entity, error) {
+	m.mu.RLock()
+	var out []*Entity
+	for _, e := range m.entities {
+		if q.Kind != "" && e.Kind != q.Kind {
+			continue
+		}
+		if !matchesFilters(e, q.Filter) || !afterCursor(e, q) {
+			continue
+		}
+		out = append(out, cloneEntity(e))
+	}
+	m.mu.RUnlock()
+
+	applyEntityOrder(out, q.OrderBy)
+	limit := q.Limit
+	if limit <= 0 {
+		limit = memoryStoreDefaultLimit
+	}
+	return sliceEntities(out, limit, q.Offset), nil
+}
+
+// Count implements Counter.
+func (m *MemoryStore) Count(ctx context.Context, q Query) (int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	n := 0
+	for _, e := range m.entities {
+		if (q.Kind == "" || e.Kind == q.Kind) && matchesFilters(e, q.Filter) && afterCursor(e, q) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// FindsByCursor implements CursorFinder.
+func (m *MemoryStore) FindsByCursor() bool { return true }
+
+// Delete removes an entity by ID.
+func (m *MemoryStore) Delete(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entities, id)
+	return nil
+}
+
+// put stores e, keeping the created_at of the entity it replaces. The
+// caller holds m.mu.
+func (m *MemoryStore) put(e *Entity) {
+	if existing, ok := m.entities[e.ID]; ok {
+		e.CreatedAt = existing.CreatedAt
+	}
+	m.entities[e.ID] = e
+}
+
+// memoryEntity validates e, fills in its ID, and returns the copy to
+// store, with timestamps defaulted and in UTC like SQLXStore's.
+func memoryEntity(e *Entity) (*Entity, error) {
+	if e == nil {
+		return nil, fmt.Errorf("entity required")
+	}
+	if strings.TrimSpace(e.Kind) == "" {
+		return nil, fmt.Errorf("entity kind required")
+	}
+	if e.ID == "" {
+		e.ID = generateID()
+	}
+	stored := cloneEntity(e)
+	now := time.Now().UTC()
+	if stored.CreatedAt.IsZero() {
+		stored.CreatedAt = now
+	}
+	stored.CreatedAt = stored.CreatedAt.UTC()
+	if stored.UpdatedAt == nil {
+		stored.UpdatedAt = &now
+	}
+	updated := stored.UpdatedAt.UTC()
+	stored.UpdatedAt = &updated
+	if stored.PublishedAt != nil {
+		published := stored.PublishedAt.UTC()
+		stored.PublishedAt = &published
+	}
+	return stored, nil
+}
+
+// cloneEntity copies e. Attrs go through JSON, as they would through a
+// database, so callers can't change stored values and get numbers back as
+// float64.
+func cloneEntity(e *Entity) *Entity {
+	copy := *e
+	if e.UpdatedAt != nil {
+		t := *e.UpdatedAt
+		copy.UpdatedAt = &t
+	}
+	if e.PublishedAt != nil {
+		t := *e.PublishedAt
+		copy.PublishedAt = &t
+	}
+	copy.Attrs = Attributes{}
+	if raw, err := json.Marshal(e.Attrs); err == nil {
+		_ = json.Unmarshal(raw, &copy.Attrs)
+	}
+	if copy.Attrs == nil {
+		copy.Attrs = Attributes{}
+	}
+	return &copy
+}
+
+// matchesFilters reports whether e has every value in filter. Keys are
+// promoted columns or attrs; a nil value matches a missing or null one.
+func matchesFilters(e *Entity, filter map[string]interface{}) bool {
+	for key, want := range filter {
+		got, ok := entityField(e, key)
+		if want == nil {
+			if ok {
+				return false
+			}
+			continue
+		}
+		if !ok || fmt.Sprint(got) != fmt.Sprint(want) {
+			return false
+		}
+	}
+	return true
+}
+
+// entityField returns the value of a promoted column or attr of e, and
+// whether it is set. Empty strings and nil times count as unset, as they
+// are stored as NULL by SQLXStore.
+func entityField(e *Entity, key string) (interface{}, bool) {
+	var s string
+	switch key {
+	case "id":
+		s = e.ID
+	case "kind":
+		s = e.Kind
+	case "slug":
+		s = e.Slug
+	case "status":
+		s = e.Status
+	case "owner_id":
+		s = e.OwnerID
+	case "parent_id":
+		s = e.ParentID
+	case "created_at":
+		return e.CreatedAt, true
+	case "updated_at":
+		if e.UpdatedAt == nil {
+			return nil, false
+		}
+		return *e.UpdatedAt, true
+	case "published_at":
+		if e.PublishedAt == nil {
+			return nil, false
+		}
+		return *e.PublishedAt, true
+	default:
+		v, ok := e.Attrs[key]
+		return v, ok && v != nil
+	}
+	return s, s != ""
+}
+
+// afterCursor reports whether e comes after q's PublishedBefore and
+// AfterID cursor, if it has one.
+func afterCursor(e *Entity, q Query) bool {
+	if q.PublishedBefore == nil {
+		return true
+	}
+	if e.PublishedAt == nil {
+		return false
+	}
+	if e.PublishedAt.Equal(*q.PublishedBefore) {
+		return q.AfterID != "" && e.ID < q.AfterID
+	}
+	return e.PublishedAt.Before(*q.PublishedBefore)
+}
+
+// applyEntityOrder sorts entities by an OrderBy such as "created_at DESC",
+// breaking ties by ID in the same direction. A field without a direction
+// sorts ascending; an empty or unknown OrderBy sorts by created_at, newest
+// first.
+func applyEntityOrder(entities []*Entity, orderBy string) {
+	field, desc := "created_at", true
+	if order := sanitizeOrderBy(orderBy); order != "" {
+		field, _, _ = strings.Cut(order, " ")
+		desc = strings.HasSuffix(order, " DESC")
+	}
+	sort.SliceStable(entities, func(i, j int) bool {
+		c := compareEntityField(entities[i], entities[j], field)
+		if c == 0 {
+			c = strings.Compare(entities[i].ID, entities[j].ID)
+		}
+		if desc {
+			return c > 0
+		}
+		return c < 0
+	})
+}
+
+// compareEntityField compares a promoted column of a and b. Unset times
+// sort first, as NULLs do in SQLite.
+func compareEntityField(a, b *Entity, field string) int {
+	switch field {
+	case "created_at", "updated_at", "published_at":
+		left, _ := entityField(a, field)
+		right, _ := entityField(b, field)
+		lt, _ := left.(time.Time)
+		rt, _ := right.(time.Time)
+		return lt.Compare(rt)
+	default:
+		left, _ := entityField(a, field)
+		right, _ := entityField(b, field)
+		return strings.Compare(fmt.Sprint(left), fmt.Sprint(right))
+	}
+}
+
+// sliceEntities returns the page of entities at offset, at most limit long.
+func sliceEntities(entities []*Entity, limit, offset int) []*Entity {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= len(entities) {
+		return []*Entity{}
+	}
+	end := len(entities)
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+	return entities[offset:end]
+}
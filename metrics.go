@@ -0,0 +1,113 @@
+package blog
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/smhanov/llmhub"
+)
+
+// Counters kept by metrics, indexing metricCounters.
+const (
+	metricPostsServed = iota
+	metricCommentsCreated
+	metricCommentsSpam
+	metricAIRequests
+	metricAIFailures
+	metricImageUploads
+	numMetricCounters
+)
+
+var metricCounters = [numMetricCounters]struct{ name, help string }{
+	metricPostsServed:     {"spore_posts_served_total", "Published post pages served."},
+	metricCommentsCreated: {"spore_comments_created_total", "Comments created by readers."},
+	metricCommentsSpam:    {"spore_comments_spam_total", "Comments rejected as spam by the spam check."},
+	metricAIRequests:      {"spore_ai_requests_total", "Requests sent to AI providers."},
+	metricAIFailures:      {"spore_ai_request_failures_total", "Requests to AI providers that returned an error."},
+	metricImageUploads:    {"spore_image_uploads_total", "Images uploaded through the admin API."},
+}
+
+// metrics holds the counters served by GET /admin/api/metrics. Each
+// handler has its own, so building several handlers in one process is
+// fine. A nil *metrics counts nothing.
+type metrics struct {
+	counters [numMetricCounters]atomic.Int64
+
+	mu    sync.Mutex
+	tasks map[[2]string]int64 // task type and outcome -> count
+}
+
+func newMetrics() *metrics {
+	return &metrics{tasks: map[[2]string]int64{}}
+}
+
+// inc adds one to the counter c.
+func (m *metrics) inc(c int) {
+	if m != nil {
+		m.counters[c].Add(1)
+	}
+}
+
+// taskFinished counts a task run by its type and outcome: completed,
+// failed, retried, cancelled or interrupted.
+func (m *metrics) taskFinished(taskType, outcome string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tasks[[2]string{taskType, outcome}]++
+}
+
+// aiRequest counts one request to an AI provider and whether it failed.
+func (m *metrics) aiRequest(err error) {
+	m.inc(metricAIRequests)
+	if err != nil {
+		m.inc(metricAIFailures)
+	}
+}
+
+// aiGenerate sends prompt to client, counting the request in the metrics.
+func (s *service) aiGenerate(ctx context.Context, client *llmhub.Client, prompt []*llmhub.Message) (*llmhub.Response, error) {
+	resp, err := client.Generate(ctx, prompt)
+	s.metrics.aiRequest(err)
+	return resp, err
+}
+
+// writeTo writes the counters in the Prometheus text format.
+func (m *metrics) writeTo(b *strings.Builder) {
+	for c, counter := range metricCounters {
+		fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s counter\n%s %d\n", counter.name, counter.help, counter.name, counter.name, m.counters[c].Load())
+	}
+
+	m.mu.Lock()
+	keys := make([][2]string, 0, len(m.tasks))
+	for key := range m.tasks {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	b.WriteString("# HELP spore_tasks_total Background task runs by type and outcome.\n# TYPE spore_tasks_total counter\n")
+	for _, key := range keys {
+		fmt.Fprintf(b, "spore_tasks_total{type=%q,status=%q} %d\n", key[0], key[1], m.tasks[key])
+	}
+	m.mu.Unlock()
+}
+
+// handleAdminMetrics serves the counters for a Prometheus scraper. It sits
+// behind Config.AdminAuthMiddleware like the rest of the admin API.
+func (s *service) handleAdminMetrics(w http.ResponseWriter, r *http.Request) {
+	var b strings.Builder
+	s.metrics.writeTo(&b)
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = w.Write([]byte(b.String()))
+}
@@ -1,10 +1,19 @@
 package blog
 
 // migration defines a single schema change for SQL-backed stores.
+// PostgresStatements, when set, replace Statements on PostgreSQL.
 type migration struct {
-	Version    int
-	Name       string
-	Statements []string
+	Version            int
+	Name               string
+	Statements         []string
+	PostgresStatements []string
+}
+
+func (m migration) statementsFor(dialect string) []string {
+	if normalizeDialect(dialect) == DialectPostgres && len(m.PostgresStatements) > 0 {
+		return m.PostgresStatements
+	}
+	return m.Statements
 }
 
 var migrations = []migration{
@@ -14,5 +23,27 @@ var migrations = []migration{
 		Statements: []string{
 			SchemaBlogEntities,
 		},
+		PostgresStatements: []string{
+			SchemaBlogEntitiesPostgres,
+		},
+	},
+	{
+		// SQLite compares published_at as text, so rows saved with a local
+		// offset before it was stored in UTC sort out of order. Rewrite
+		// them in the UTC form Save now writes, keeping any fraction of a
+		// second. TIMESTAMPTZ columns compare instants, so Postgres has
+		// nothing to do.
+		Version: 7,
+		Name:    "store published_at in utc",
+		Statements: []string{
+			`UPDATE blog_entities
+SET published_at = strftime('%Y-%m-%d %H:%M:%S', published_at) || substr(published_at, 20, length(published_at) - 25) || '+00:00'
+WHERE typeof(published_at) = 'text'
+AND (published_at LIKE '%-__:__' OR published_at LIKE '%+__:__')
+AND published_at NOT LIKE '%+00:00'`,
+		},
+		PostgresStatements: []string{
+			`SELECT 1`,
+		},
 	},
 }
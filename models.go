@@ -12,16 +12,68 @@ type Post struct {
 	ContentHTML     string     `json:"content_html" db:"content_html"`
 	PublishedAt     *time.Time `json:"published_at" db:"published_at"`
 	UpdatedAt       *time.Time `json:"updated_at,omitempty" db:"updated_at"`
+	// UnpublishAt hides a published post from public pages, feeds and the
+	// sitemap once it passes. Admin views still show the post.
+	UnpublishAt     *time.Time `json:"unpublish_at,omitempty" db:"unpublish_at"`
 	MetaDescription string     `json:"meta_description" db:"meta_description"`
 	AuthorID        int        `json:"author_id" db:"author_id"`
 	Tags            []Tag      `json:"tags"`
+	// Excerpt is an author-written summary shown on list cards and in
+	// feeds. When empty, the start of the post is used on cards and the
+	// meta description in feeds.
+	Excerpt string `json:"excerpt,omitempty" db:"excerpt"`
+	// Language is the post's language code, such as "fr". Empty means the
+	// site language.
+	Language string `json:"language,omitempty" db:"language"`
+	// TranslationOf is the ID of the original post this one translates.
+	TranslationOf string `json:"translation_of,omitempty" db:"translation_of"`
+	// PostType is PostTypePage for standalone pages, such as an About page
+	// imported from WordPress. Empty means a regular post.
+	PostType string `json:"post_type,omitempty" db:"post_type"`
+	// ReadingTimeMinutes is computed for public views and never persisted.
+	ReadingTimeMinutes int `json:"reading_time_minutes,omitempty" db:"-"`
+	// DeletedAt is set while the post is in the trash. Trashed posts are
+	// left out of public pages and the default admin list until restored.
+	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	// ViewCount is filled in for admin views when Config.TrackViews is set.
+	// It is stored apart from the post.
+	ViewCount int `json:"view_count,omitempty" db:"-"`
 }
 
+// PostTypePage marks a post as a standalone page. Pages are served at their
+// slug like posts but are left out of the post list and feeds unless
+// Config.ListPages is set.
+const PostTypePage = "page"
+
 // Tag represents a simple keyword.
 type Tag struct {
 	ID   string `json:"id" db:"id"`
 	Name string `json:"name" db:"name"`
 	Slug string `json:"slug" db:"slug"`
+	// Hidden tags have no public archive page and are left out of tag
+	// listings. Posts carrying them are otherwise unaffected.
+	Hidden bool `json:"hidden,omitempty" db:"hidden"`
+	// Description is shown at the top of the tag's archive page.
+	Description string `json:"description,omitempty" db:"description"`
+}
+
+// TagWithCount is a tag with the number of published posts carrying it.
+type TagWithCount struct {
+	Tag
+	Count int `json:"count"`
+}
+
+// Author describes a post author. Posts refer to authors by AuthorID.
+type Author struct {
+	ID   int    `json:"id" db:"id"`
+	Name string `json:"name" db:"name"`
+	// Email is only used to look up a Gravatar when AvatarURL is empty. It
+	// is never shown on public pages.
+	Email     string `json:"email,omitempty" db:"email"`
+	AvatarURL string `json:"avatar_url,omitempty" db:"avatar_url"`
+	Bio       string `json:"bio,omitempty" db:"bio"`
+	// Links maps a label, such as "website" or "mastodon", to a URL.
+	Links map[string]string `json:"links,omitempty" db:"-"`
 }
 
 // AIProviderSettings holds configuration for a single LLM provider.
@@ -47,6 +99,18 @@ type BlogSettings struct {
 	Title               string `json:"title" db:"title"`
 	Description         string `json:"description" db:"description"`
 	GoogleAnalyticsCode string `json:"google_analytics_code" db:"google_analytics_code"`
+	// ModerationMode is "auto" to publish comments straight away, "manual"
+	// to hold them for an admin, or "ai" to hold them for a spam check.
+	// Empty means "ai" when a dumb provider is configured, else "auto".
+	ModerationMode string `json:"moderation_mode" db:"moderation_mode"`
+	// SyncSpamCheck runs the AI spam check before a new comment is saved,
+	// so the commenter gets its final status. By default the check runs in
+	// the background after the response.
+	SyncSpamCheck bool `json:"sync_spam_check" db:"sync_spam_check"`
+	// CloseCommentsAfterDays stops new comments on posts published more
+	// than this many days ago. Existing comments stay visible. Zero means
+	// comments never close.
+	CloseCommentsAfterDays int `json:"close_comments_after_days" db:"close_comments_after_days"`
 }
 
 // Comment represents a public comment on a blog post.
@@ -64,6 +128,38 @@ type Comment struct {
 	SpamReason     *string    `json:"spam_reason,omitempty" db:"spam_reason"`
 }
 
+// Webmention is a link to a post from another site, received at
+// POST <prefix>/webmention. It is shown under the post once fetching Source
+// confirms that it links to Target.
+type Webmention struct {
+	ID         string     `json:"id"`
+	PostID     string     `json:"post_id"`
+	Source     string     `json:"source"`
+	Target     string     `json:"target"`
+	Status     string     `json:"status"` // pending, verified or rejected
+	Title      string     `json:"title,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+}
+
+// PostRevision is a snapshot of a post's editable text taken before an update.
+type PostRevision struct {
+	ID              string    `json:"id" db:"id"`
+	PostID          string    `json:"post_id" db:"post_id"`
+	Title           string    `json:"title" db:"title"`
+	ContentMarkdown string    `json:"content_markdown" db:"content_markdown"`
+	MetaDescription string    `json:"meta_description" db:"meta_description"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+}
+
+// AIChatTurn is one exchange of a post's AI chat history: the editor's
+// request and the notes the model replied with.
+type AIChatTurn struct {
+	Query     string    `json:"query"`
+	Notes     string    `json:"notes,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 // AdminComment adds post metadata for moderation views.
 type AdminComment struct {
 	Comment
@@ -78,10 +174,20 @@ type PostSummary struct {
 	Excerpt    string `json:"excerpt"`
 }
 
+// SearchResult is a post matched by a search query. Snippet is HTML with
+// the matching text wrapped in <mark>.
+type SearchResult struct {
+	Post
+	Status  string `json:"status"`
+	Snippet string `json:"snippet"`
+}
+
 // Pagination holds page navigation state for list templates.
 type Pagination struct {
 	CurrentPage int    `json:"current_page"`
 	TotalPages  int    `json:"total_pages"`
+	TotalPosts  int    `json:"total_posts"`
+	PrevOffset  int    `json:"prev_offset"` // offset of the previous page; 0 on page 1
 	NextPageURL string `json:"next_page_url,omitempty"`
 	PrevPageURL string `json:"prev_page_url,omitempty"`
 }
@@ -96,4 +202,8 @@ type Task struct {
 	ErrorMessage *string   `json:"error_message,omitempty" db:"error_message"`
 	CreatedAt    time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	// Attempts counts how many times the task has run.
+	Attempts int `json:"attempts,omitempty" db:"attempts"`
+	// RunAfter delays a pending task that is waiting to be retried.
+	RunAfter *time.Time `json:"run_after,omitempty" db:"run_after"`
 }
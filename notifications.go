@@ -4,10 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	webpush "github.com/SherClockHolmes/webpush-go"
 )
@@ -17,8 +17,16 @@ const (
 	envVAPIDPrivateKey     = "SPORE_VAPID_PRIVATE_KEY"
 	envVAPIDSubscriber     = "SPORE_VAPID_SUBSCRIBER"
 	defaultVAPIDSubscriber = "mailto:admin@example.com"
+
+	defaultPushRetryAttempts = 5
+	defaultPushRetryBackoff  = 30 * time.Second
 )
 
+type sendPushPayload struct {
+	Endpoint string `json:"endpoint"`
+	Payload  string `json:"payload"`
+}
+
 func (s *service) configurePushFromEnv() {
 	s.pushPublicKey = strings.TrimSpace(os.Getenv(envVAPIDPublicKey))
 	s.pushPrivateKey = strings.TrimSpace(os.Getenv(envVAPIDPrivateKey))
@@ -47,7 +55,25 @@ func parsePushSubscription(raw []byte) (endpoint string, normalizedJSON string,
 }
 
 func (s *service) notifyAdminsOfNewComment(comment Comment, post Post) {
-	ctx := context.Background()
+	// In digest mode the periodic digest task reports new comments instead.
+	if s.cfg.NotificationDigestInterval > 0 {
+		return
+	}
+
+	title := "New comment posted"
+	switch comment.Status {
+	case "pending":
+		title = "New comment awaiting moderation"
+	case "rejected":
+		title = "New comment rejected as spam"
+	}
+	body := fmt.Sprintf("%s commented on \"%s\"", comment.AuthorName, post.Title)
+	s.pushToAdmins(context.Background(), title, body, s.routePrefix+"/admin?view=comments")
+}
+
+// pushToAdmins sends a notification to every admin push subscription when
+// notifications are enabled.
+func (s *service) pushToAdmins(ctx context.Context, title, body, url string) {
 	publicKey, privateKey, subscriber, err := s.ensurePushSettings(ctx)
 	if err != nil || publicKey == "" || privateKey == "" {
 		return
@@ -61,12 +87,6 @@ func (s *service) notifyAdminsOfNewComment(comment Comment, post Post) {
 		return
 	}
 
-	title := "New comment posted"
-	if comment.Status == "pending" {
-		title = "New comment awaiting moderation"
-	}
-	body := fmt.Sprintf("%s commented on \"%s\"", comment.AuthorName, post.Title)
-	url := s.routePrefix + "/admin?view=comments"
 	payload, _ := json.Marshal(map[string]string{
 		"title": title,
 		"body":  body,
@@ -75,9 +95,96 @@ func (s *service) notifyAdminsOfNewComment(comment Comment, post Post) {
 
 	for _, sub := range subscriptions {
 		if err := s.sendPushToSubscription(payload, sub.SubscriptionJSON, publicKey, privateKey, subscriber); err != nil {
-			log.Printf("spore push failed for endpoint %s: %v", sub.Endpoint, err)
+			s.logf("spore push failed for endpoint %s: %v", sub.Endpoint, err)
+			if retryableDelivery(err) {
+				s.queuePushRetry(sub.Endpoint, payload)
+			}
+		}
+	}
+}
+
+func (s *service) pushRetryAttempts() int {
+	if s.cfg.PushRetryAttempts > 0 {
+		return s.cfg.PushRetryAttempts
+	}
+	return defaultPushRetryAttempts
+}
+
+// pushRetryDelay returns the backoff after the given number of failed
+// attempts: PushRetryBackoff, doubling each time.
+func (s *service) pushRetryDelay(attempts int) time.Duration {
+	delay := s.cfg.PushRetryBackoff
+	if delay <= 0 {
+		delay = defaultPushRetryBackoff
+	}
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+	}
+	return delay
+}
+
+// queuePushRetry queues a durable send_push task after a failed immediate
+// send, counting that send as the first attempt.
+func (s *service) queuePushRetry(endpoint string, payload []byte) {
+	if s.pushRetryAttempts() <= 1 {
+		return
+	}
+	raw, _ := json.Marshal(sendPushPayload{Endpoint: endpoint, Payload: string(payload)})
+	runAfter := time.Now().Add(s.pushRetryDelay(1)).UTC()
+	task := Task{
+		ID:       generateID(),
+		TaskType: TaskTypeSendPush,
+		Status:   TaskStatusPending,
+		Payload:  string(raw),
+		Result:   "{}",
+		Attempts: 1,
+		RunAfter: &runAfter,
+	}
+	if err := s.store.CreateTask(context.Background(), &task); err != nil {
+		s.logf("tasks: queue push retry endpoint=%s: %v", endpoint, err)
+		return
+	}
+	s.tasks.nudge()
+}
+
+// processSendPush retries delivery to one subscription. It gives up quietly
+// if the subscription was removed or notifications were turned off.
+func (s *service) processSendPush(ctx context.Context, task *Task) error {
+	var payload sendPushPayload
+	if err := json.Unmarshal([]byte(task.Payload), &payload); err != nil {
+		return failTask(fmt.Errorf("invalid payload: %w", err))
+	}
+	enabled, err := s.store.GetNotificationsEnabled(ctx)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+	subscriptions, err := s.store.ListAdminPushSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+	var sub *AdminPushSubscription
+	for i := range subscriptions {
+		if subscriptions[i].Endpoint == payload.Endpoint {
+			sub = &subscriptions[i]
+			break
 		}
 	}
+	if sub == nil {
+		return nil
+	}
+	publicKey, privateKey, subscriber, err := s.ensurePushSettings(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = s.sendPushToSubscription([]byte(payload.Payload), sub.SubscriptionJSON, publicKey, privateKey, subscriber)
+	if err == nil || !retryableDelivery(err) || task.Attempts >= s.pushRetryAttempts() {
+		return failTask(err)
+	}
+	return retryTaskAfter(err, s.pushRetryDelay(task.Attempts))
 }
 
 func (s *service) sendPushToSubscription(payload []byte, subscriptionJSON, publicKey, privateKey, subscriber string) error {
@@ -101,7 +208,7 @@ func (s *service) sendPushToSubscription(payload []byte, subscriptionJSON, publi
 		return nil
 	}
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted {
-		return fmt.Errorf("unexpected push response: %d", resp.StatusCode)
+		return &httpStatusError{msg: "unexpected push response", code: resp.StatusCode}
 	}
 	return nil
 }
@@ -148,3 +255,71 @@ func normalizedSubscriber(value string) string {
 	}
 	return value
 }
+
+// runNotificationDigests queues a digest task once per interval.
+func (s *service) runNotificationDigests(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.queueNotificationDigest()
+		case <-s.closing:
+			return
+		}
+	}
+}
+
+// processNotificationDigest sends one push summarizing the comments created
+// since the previous digest, then advances the persisted marker.
+func (s *service) processNotificationDigest(ctx context.Context, task *Task) error {
+	since, err := s.store.GetNotificationDigestMarker(ctx)
+	if err != nil {
+		return fmt.Errorf("load digest marker: %w", err)
+	}
+	if since.IsZero() {
+		since = time.Now().UTC().Add(-s.cfg.NotificationDigestInterval)
+	}
+
+	var fresh []AdminComment
+	const pageSize = 100
+	for offset := 0; ; offset += pageSize {
+		page, err := s.store.ListCommentsForModeration(ctx, "", pageSize, offset)
+		if err != nil {
+			return fmt.Errorf("load comments: %w", err)
+		}
+		done := len(page) < pageSize
+		for _, c := range page {
+			if !c.CreatedAt.After(since) {
+				done = true
+				break
+			}
+			fresh = append(fresh, c)
+		}
+		if done {
+			break
+		}
+	}
+
+	s.saveTaskResult(ctx, task, map[string]int{"comments": len(fresh)})
+	if len(fresh) == 0 {
+		return nil
+	}
+
+	title := "1 new comment"
+	if len(fresh) > 1 {
+		title = fmt.Sprintf("%d new comments", len(fresh))
+	}
+	var lines []string
+	for i, c := range fresh {
+		if i == 3 {
+			lines = append(lines, fmt.Sprintf("and %d more", len(fresh)-i))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("%s on \"%s\"", c.AuthorName, c.PostTitle))
+	}
+	s.pushToAdmins(ctx, title, strings.Join(lines, "\n"), s.routePrefix+"/admin?view=comments")
+
+	// fresh is newest first, so the first entry becomes the new marker.
+	return s.store.UpdateNotificationDigestMarker(ctx, fresh[0].CreatedAt)
+}
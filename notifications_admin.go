@@ -61,7 +61,7 @@ func (s *service) handleAdminSubscribeNotifications(w http.ResponseWriter, r *ht
 		http.Error(w, "invalid subscription", http.StatusBadRequest)
 		return
 	}
-	if err := s.store.UpsertAdminPushSubscription(r.Context(), endpoint, normalized); err != nil {
+	if err := s.store.UpsertAdminPushSubscription(r.Context(), endpoint, normalized, r.UserAgent()); err != nil {
 		http.Error(w, "failed to save subscription", http.StatusInternalServerError)
 		return
 	}
@@ -85,3 +85,21 @@ func (s *service) handleAdminUnsubscribeNotifications(w http.ResponseWriter, r *
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
+
+func (s *service) handleAdminListPushSubscriptions(w http.ResponseWriter, r *http.Request) {
+	subscriptions, err := s.store.ListAdminPushSubscriptions(r.Context())
+	if err != nil {
+		http.Error(w, "failed to list subscriptions", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, subscriptions)
+}
+
+func (s *service) handleAdminClearPushSubscriptions(w http.ResponseWriter, r *http.Request) {
+	deleted, err := s.store.DeleteAllAdminPushSubscriptions(r.Context())
+	if err != nil {
+		http.Error(w, "failed to remove subscriptions", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, map[string]int{"deleted": deleted})
+}
@@ -0,0 +1,97 @@
+package blog
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// postCursor marks a place in the newest-first list of published posts:
+// the last post already shown. Posts are ordered by published_at, then ID,
+// both descending.
+type postCursor struct {
+	PublishedAt time.Time
+	ID          string
+}
+
+// cursorAfter returns the cursor that resumes a listing after p.
+func cursorAfter(p Post) postCursor {
+	var published time.Time
+	if p.PublishedAt != nil {
+		published = *p.PublishedAt
+	}
+	return postCursor{PublishedAt: published, ID: p.ID}
+}
+
+// precedes reports whether p comes after the cursor in the listing.
+func (c postCursor) precedes(p Post) bool {
+	if p.PublishedAt == nil {
+		return false
+	}
+	if p.PublishedAt.Equal(c.PublishedAt) {
+		return p.ID < c.ID
+	}
+	return p.PublishedAt.Before(c.PublishedAt)
+}
+
+// String encodes the cursor for the ?cursor= query parameter.
+func (c postCursor) String() string {
+	raw := strconv.FormatInt(c.PublishedAt.UnixNano(), 10) + ":" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// parsePostCursor decodes a cursor made by postCursor.String.
+func parsePostCursor(v string) (postCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(v)
+	if err != nil {
+		return postCursor{}, errInvalidCursor
+	}
+	ts, id, ok := strings.Cut(string(raw), ":")
+	if !ok || id == "" {
+		return postCursor{}, errInvalidCursor
+	}
+	nanos, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return postCursor{}, errInvalidCursor
+	}
+	return postCursor{PublishedAt: time.Unix(0, nanos).UTC(), ID: id}, nil
+}
+
+// listCursor returns the ?cursor= parameter of a list page, or nil when
+// there is none or ListAll is set.
+func (s *service) listCursor(r *http.Request) (*postCursor, error) {
+	v := r.URL.Query().Get("cursor")
+	if v == "" || s.cfg.ListAll {
+		return nil, nil
+	}
+	c, err := parsePostCursor(v)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// nextCursor returns the cursor for the page after posts, or "" when posts
+// came up short of limit and so reached the end.
+func nextCursor(posts []Post, limit int) string {
+	if len(posts) == 0 || len(posts) < limit {
+		return ""
+	}
+	return cursorAfter(posts[len(posts)-1]).String()
+}
+
+// cursorPagination links to the page after one reached by cursor. Page
+// numbers aren't known there, so CurrentPage and TotalPages are left 0.
+func cursorPagination(next string, perPage int, basePath string) *Pagination {
+	p := &Pagination{}
+	if next != "" {
+		p.NextPageURL = fmt.Sprintf("%s?cursor=%s&limit=%d", basePath, next, perPage)
+	}
+	return p
+}
@@ -0,0 +1,66 @@
+package blog
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// errSlugTaken is returned when a post's slug already belongs to another
+// post.
+var errSlugTaken = errors.New("slug already in use")
+
+// assignPostSlug gives a new post its slug. A post without one gets a slug
+// made from its title, with -2, -3 and so on appended until it is free. A
+// slug the client chose is kept as is, and errSlugTaken is returned if
+// another post has it.
+func (s *service) assignPostSlug(ctx context.Context, p *Post) error {
+	p.Slug = strings.TrimSpace(p.Slug)
+	if p.Slug != "" {
+		return s.checkPostSlug(ctx, p)
+	}
+	base := slugify(p.Title)
+	if base == "" {
+		base = "post"
+	}
+	slug, err := s.uniquePostSlug(ctx, base, p.ID)
+	if err != nil {
+		return err
+	}
+	p.Slug = slug
+	return nil
+}
+
+// uniquePostSlug returns base, or base with -2, -3 and so on appended, the
+// first that no post other than the one with id has.
+func (s *service) uniquePostSlug(ctx context.Context, base, id string) (string, error) {
+	for n := 1; ; n++ {
+		slug := base
+		if n > 1 {
+			slug = base + "-" + strconv.Itoa(n)
+		}
+		existing, err := s.store.GetPostBySlug(ctx, slug)
+		if err != nil {
+			return "", err
+		}
+		if existing == nil || existing.ID == id {
+			return slug, nil
+		}
+	}
+}
+
+// checkPostSlug returns errSlugTaken if a post other than p has p's slug.
+func (s *service) checkPostSlug(ctx context.Context, p *Post) error {
+	if p.Slug == "" {
+		return nil
+	}
+	existing, err := s.store.GetPostBySlug(ctx, p.Slug)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != p.ID {
+		return errSlugTaken
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+package blog
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// postRecord is one line of a JSON posts export: a post with its tags and
+// comments.
+type postRecord struct {
+	Post
+	Comments []Comment `json:"comments,omitempty"`
+}
+
+type postImportResult struct {
+	PostsAdded      int `json:"posts_added"`
+	PostsUpdated    int `json:"posts_updated"`
+	CommentsAdded   int `json:"comments_added"`
+	CommentsSkipped int `json:"comments_skipped"`
+	// Errors lists the lines that couldn't be imported, such as
+	// "line 3: invalid json". Other lines are still imported.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// handleAdminExportPosts streams every post, drafts included, as
+// newline-delimited JSON with its tags and comments.
+func (s *service) handleAdminExportPosts(w http.ResponseWriter, r *http.Request) {
+	posts, err := s.listAllPosts(r.Context())
+	if err != nil {
+		http.Error(w, "failed to list posts", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Content-Disposition", "attachment; filename=blog-posts.ndjson")
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	for _, post := range posts {
+		comments, err := s.store.ListCommentsByPost(r.Context(), post.ID)
+		if err != nil {
+			// Part of the export may already be sent, so the response can
+			// only be cut short.
+			s.logf("posts export failed post_id=%s err=%v", post.ID, err)
+			return
+		}
+		if err := enc.Encode(postRecord{Post: post, Comments: comments}); err != nil {
+			return
+		}
+	}
+	bw.Flush()
+}
+
+// handleAdminImportPosts reads the format written by handleAdminExportPosts
+// (multipart form field "file" or raw body) and upserts each post by slug.
+func (s *service) handleAdminImportPosts(w http.ResponseWriter, r *http.Request) {
+	reader, err := readWXRPayload(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if closer, ok := reader.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	result, err := s.importPosts(r.Context(), reader)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if result.PostsAdded+result.PostsUpdated > 0 {
+		s.queuePostProcessing("posts import")
+	}
+	writeJSON(w, result)
+}
+
+// importPosts upserts the posts in an NDJSON export. A post whose slug
+// matches an existing post updates it, keeping the existing ID; other posts
+// are created, keeping their exported ID when it is free. Comments are
+// added unless an identical one exists, with replies linked to their
+// imported parents. Lines that don't parse are reported in the result;
+// store failures stop the import.
+func (s *service) importPosts(ctx context.Context, r io.Reader) (postImportResult, error) {
+	var result postImportResult
+	existingPosts, err := s.listAllPosts(ctx)
+	if err != nil {
+		return result, fmt.Errorf("load posts: %w", err)
+	}
+	postBySlug := map[string]Post{}
+	for _, post := range existingPosts {
+		if key := normalizeSlugKey(post.Slug); key != "" {
+			postBySlug[key] = post
+		}
+	}
+	// idMap maps exported post IDs to local ones, so translations keep
+	// pointing at their originals.
+	idMap := map[string]string{}
+
+	br := bufio.NewReader(r)
+	for line := 1; ; line++ {
+		data, readErr := br.ReadBytes('\n')
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return result, fmt.Errorf("read: %w", readErr)
+		}
+		if data = bytes.TrimSpace(data); len(data) > 0 {
+			var record postRecord
+			if err := json.Unmarshal(data, &record); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("line %d: invalid json", line))
+			} else if err := s.importPostRecord(ctx, &record, postBySlug, idMap, &result); err != nil {
+				var lineErr postRecordError
+				if !errors.As(err, &lineErr) {
+					return result, err
+				}
+				result.Errors = append(result.Errors, fmt.Sprintf("line %d: %s", line, lineErr))
+			}
+		}
+		if readErr != nil {
+			return result, nil
+		}
+	}
+}
+
+// postRecordError is a problem with one record that doesn't stop the
+// import.
+type postRecordError string
+
+func (e postRecordError) Error() string { return string(e) }
+
+func (s *service) importPostRecord(ctx context.Context, record *postRecord, postBySlug map[string]Post, idMap map[string]string, result *postImportResult) error {
+	post := record.Post
+	post.Slug = strings.TrimSpace(post.Slug)
+	if post.Slug == "" {
+		post.Slug = tagSlug(post.Title)
+	}
+	slugKey := normalizeSlugKey(post.Slug)
+	if slugKey == "" {
+		return postRecordError("missing slug")
+	}
+
+	exportedID := post.ID
+	existing, exists := postBySlug[slugKey]
+	switch {
+	case exists:
+		post.ID = existing.ID
+	case post.ID == "":
+		post.ID = generateID()
+	default:
+		taken, err := s.store.GetPostByID(ctx, post.ID)
+		if err != nil {
+			return fmt.Errorf("load post: %w", err)
+		}
+		if taken != nil {
+			post.ID = generateID()
+		}
+	}
+	if exportedID != "" {
+		idMap[exportedID] = post.ID
+	}
+	if mapped, ok := idMap[post.TranslationOf]; ok {
+		post.TranslationOf = mapped
+	}
+	if strings.TrimSpace(post.ContentMarkdown) != "" {
+		html, err := s.renderPostHTML(post.ContentMarkdown)
+		if err != nil {
+			return fmt.Errorf("render markdown: %w", err)
+		}
+		post.ContentHTML = html
+	} else {
+		post.ContentHTML = s.sanitizeStoredHTML(post.ContentHTML)
+	}
+	tagNames := make([]string, 0, len(post.Tags))
+	for _, tag := range post.Tags {
+		tagNames = append(tagNames, tag.Name)
+	}
+	post.Tags = nil
+
+	if exists {
+		if _, err := s.snapshotBeforeUpdate(ctx, &post); err != nil {
+			return fmt.Errorf("save revision: %w", err)
+		}
+		if err := s.store.UpdatePost(ctx, &post); err != nil {
+			return fmt.Errorf("update post: %w", err)
+		}
+		result.PostsUpdated++
+	} else {
+		if err := s.store.CreatePost(ctx, &post); err != nil {
+			return fmt.Errorf("create post: %w", err)
+		}
+		result.PostsAdded++
+	}
+	postBySlug[slugKey] = post
+	if err := s.store.SetPostTags(ctx, post.ID, tagNames); err != nil {
+		return fmt.Errorf("set tags: %w", err)
+	}
+	return s.importPostComments(ctx, post.ID, record.Comments, result)
+}
+
+// importPostComments adds the exported comments to a post, skipping those
+// it already has. Replies whose parent can't be found are skipped.
+func (s *service) importPostComments(ctx context.Context, postID string, comments []Comment, result *postImportResult) error {
+	if len(comments) == 0 {
+		return nil
+	}
+	existing, err := s.store.ListCommentsByPost(ctx, postID)
+	if err != nil {
+		return fmt.Errorf("load comments: %w", err)
+	}
+	existingIDs := map[string]string{}
+	for _, c := range existing {
+		existingIDs[commentKey(c.AuthorName, c.Content, c.CreatedAt)] = c.ID
+	}
+
+	// Parents are queued before their replies, in rounds, however deeply
+	// the replies nest.
+	idMap := map[string]string{}
+	var newComments []Comment
+	pending := comments
+	for len(pending) > 0 {
+		var waiting []Comment
+		for _, c := range pending {
+			var parentID *string
+			if c.ParentID != nil && *c.ParentID != "" {
+				mapped, ok := idMap[*c.ParentID]
+				if !ok {
+					waiting = append(waiting, c)
+					continue
+				}
+				parentID = &mapped
+			}
+			key := commentKey(c.AuthorName, c.Content, c.CreatedAt)
+			if id, ok := existingIDs[key]; ok {
+				idMap[c.ID] = id
+				result.CommentsSkipped++
+				continue
+			}
+			comment := Comment{
+				ID:             generateID(),
+				PostID:         postID,
+				ParentID:       parentID,
+				AuthorName:     strings.TrimSpace(c.AuthorName),
+				Content:        c.Content,
+				Status:         c.Status,
+				OwnerTokenHash: hashToken(generateToken()),
+				CreatedAt:      ensureCommentTime(c.CreatedAt),
+				SpamCheckedAt:  c.SpamCheckedAt,
+				SpamReason:     c.SpamReason,
+			}
+			existingIDs[key] = comment.ID
+			idMap[c.ID] = comment.ID
+			newComments = append(newComments, comment)
+		}
+		if len(waiting) == len(pending) {
+			result.CommentsSkipped += len(waiting)
+			break
+		}
+		pending = waiting
+	}
+
+	added, err := s.createCommentsInBatches(ctx, newComments)
+	result.CommentsAdded += added
+	return err
+}
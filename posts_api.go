@@ -0,0 +1,144 @@
+package blog
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// postsAPICacheControl lets clients and CDNs reuse a posts API response
+// for a minute before checking it again with its ETag.
+const postsAPICacheControl = "public, max-age=60"
+
+// apiTag is a tag as the public posts API returns it.
+type apiTag struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// apiPostSummary is a post in the GET /api/posts listing.
+type apiPostSummary struct {
+	ID          string     `json:"id"`
+	Slug        string     `json:"slug"`
+	Title       string     `json:"title"`
+	Excerpt     string     `json:"excerpt"`
+	Tags        []apiTag   `json:"tags"`
+	PublishedAt *time.Time `json:"published_at"`
+}
+
+// apiPost is a post as GET /api/posts/{slug} returns it.
+type apiPost struct {
+	apiPostSummary
+	Subtitle           string     `json:"subtitle"`
+	MetaDescription    string     `json:"meta_description"`
+	Language           string     `json:"language"`
+	UpdatedAt          *time.Time `json:"updated_at"`
+	ReadingTimeMinutes int        `json:"reading_time_minutes"`
+	URL                string     `json:"url"`
+	ContentHTML        string     `json:"content_html"`
+}
+
+// apiPostsPage is the GET /api/posts response. NextCursor is empty on the
+// last page; pass it back as ?cursor= to fetch the next one.
+type apiPostsPage struct {
+	Posts      []apiPostSummary `json:"posts"`
+	Total      int              `json:"total"`
+	Limit      int              `json:"limit"`
+	Offset     int              `json:"offset"`
+	NextCursor string           `json:"next_cursor"`
+}
+
+// toAPIPostSummary converts p for the posts API, leaving out hidden tags.
+func toAPIPostSummary(p Post, hidden map[string]bool) apiPostSummary {
+	tags := make([]apiTag, 0, len(p.Tags))
+	for _, t := range visibleTags(p.Tags, hidden) {
+		tags = append(tags, apiTag{Name: t.Name, Slug: t.Slug})
+	}
+	return apiPostSummary{
+		ID:          p.ID,
+		Slug:        p.Slug,
+		Title:       p.Title,
+		Excerpt:     postExcerpt(p, 300),
+		Tags:        tags,
+		PublishedAt: p.PublishedAt,
+	}
+}
+
+// handlePublicListPostsAPI lists published posts as JSON, paged like the
+// post list with ?limit, ?offset, ?page or ?cursor.
+func (s *service) handlePublicListPostsAPI(w http.ResponseWriter, r *http.Request) {
+	limit, offset, _ := s.listParams(r)
+	cursor, err := s.listCursor(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var posts []Post
+	if cursor != nil {
+		posts, err = s.listPublishedPostsAfter(r.Context(), *cursor, limit)
+		offset = 0
+	} else {
+		posts, err = s.listPublishedPosts(r.Context(), limit, offset)
+	}
+	if err != nil {
+		http.Error(w, "failed to list posts", http.StatusInternalServerError)
+		return
+	}
+	total, err := s.countPublishedPosts(r.Context())
+	if err != nil {
+		http.Error(w, "failed to list posts", http.StatusInternalServerError)
+		return
+	}
+	hidden, _ := s.store.HiddenTagSlugs(r.Context())
+
+	page := apiPostsPage{
+		Posts:      make([]apiPostSummary, len(posts)),
+		Total:      total,
+		Limit:      limit,
+		Offset:     offset,
+		NextCursor: nextCursor(posts, limit),
+	}
+	for i, p := range posts {
+		page.Posts[i] = toAPIPostSummary(p, hidden)
+	}
+	s.writeCachedJSON(w, r, page, newestModified(posts))
+}
+
+// handlePublicGetPostAPI returns one published post as JSON, including its
+// rendered HTML filtered by Config.SanitizePolicy, as on the post page.
+func (s *service) handlePublicGetPostAPI(w http.ResponseWriter, r *http.Request) {
+	post, err := s.store.GetPublishedPostBySlug(r.Context(), chi.URLParam(r, "*"))
+	if err != nil {
+		http.Error(w, "failed to load post", http.StatusInternalServerError)
+		return
+	}
+	if post == nil {
+		http.NotFound(w, r)
+		return
+	}
+	hidden, _ := s.store.HiddenTagSlugs(r.Context())
+	s.writeCachedJSON(w, r, apiPost{
+		apiPostSummary:     toAPIPostSummary(*post, hidden),
+		Subtitle:           post.Subtitle,
+		MetaDescription:    post.MetaDescription,
+		Language:           post.Language,
+		UpdatedAt:          post.UpdatedAt,
+		ReadingTimeMinutes: readingTimeMinutes(post.ContentMarkdown),
+		URL:                s.canonicalURL(r, s.postPath(post.Slug)),
+		ContentHTML:        s.sanitizeContent(post.ContentHTML),
+	}, postModified(*post))
+}
+
+// writeCachedJSON sends v through writeCached with postsAPICacheControl.
+func (s *service) writeCachedJSON(w http.ResponseWriter, r *http.Request, v any, modified time.Time) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, "json encode error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Cache-Control", postsAPICacheControl)
+	writeCached(w, r, "application/json", modified, body)
+}
@@ -0,0 +1,95 @@
+package blog
+
+import (
+	"crypto/hmac"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// defaultPreviewLinkMaxAge is how long a preview link works when
+// Config.PreviewLinkMaxAge is unset.
+const defaultPreviewLinkMaxAge = 7 * 24 * time.Hour
+
+var (
+	errPreviewTokenInvalid = errors.New("invalid preview token")
+	errPreviewTokenExpired = errors.New("preview link expired")
+)
+
+// previewToken returns a token that lets anyone holding it view postID
+// until expires: "<unix seconds>.<signature>".
+func (s *service) previewToken(postID string, expires time.Time) string {
+	ts := strconv.FormatInt(expires.Unix(), 10)
+	return ts + "." + s.signValue("post-preview", postID, ts)
+}
+
+// checkPreviewToken verifies a token issued by previewToken for postID.
+func (s *service) checkPreviewToken(token, postID string, now time.Time) error {
+	ts, sig, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(sig), []byte(s.signValue("post-preview", postID, ts))) {
+		return errPreviewTokenInvalid
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return errPreviewTokenInvalid
+	}
+	if now.After(time.Unix(unix, 0)) {
+		return errPreviewTokenExpired
+	}
+	return nil
+}
+
+type previewLinkResponse struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// handleAdminPreviewLink issues a signed link to a post's page that works
+// whether or not the post is published, for sharing drafts with reviewers.
+func (s *service) handleAdminPreviewLink(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	post, err := s.store.GetPostByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "failed to load post", http.StatusInternalServerError)
+		return
+	}
+	if post == nil {
+		http.NotFound(w, r)
+		return
+	}
+	maxAge := s.cfg.PreviewLinkMaxAge
+	if maxAge <= 0 {
+		maxAge = defaultPreviewLinkMaxAge
+	}
+	expires := time.Now().Add(maxAge).UTC().Truncate(time.Second)
+	link := s.canonicalURL(r, "/preview/"+url.PathEscape(post.ID)) + "?token=" + url.QueryEscape(s.previewToken(post.ID, expires))
+	writeJSON(w, previewLinkResponse{URL: link, ExpiresAt: expires})
+}
+
+// handlePreviewPost serves a post, published or not, to holders of a valid
+// preview token. Comments are left out and search engines are asked not to
+// index the page.
+func (s *service) handlePreviewPost(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if err := s.checkPreviewToken(r.URL.Query().Get("token"), id, time.Now()); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	post, err := s.store.GetPostByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "failed to load post", http.StatusInternalServerError)
+		return
+	}
+	if post == nil || post.DeletedAt != nil {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Robots-Tag", "noindex")
+	s.servePost(w, r, post, true)
+}
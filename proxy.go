@@ -0,0 +1,134 @@
+package blog
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// parseTrustedProxies parses Config.TrustedProxies, which may hold single
+// addresses ("10.0.0.1") or CIDR ranges ("10.0.0.0/8").
+func parseTrustedProxies(values []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if strings.Contains(v, "/") {
+			prefix, err := netip.ParsePrefix(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %w", v, err)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", v, err)
+		}
+		prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+	}
+	return prefixes, nil
+}
+
+// fromTrustedProxy reports whether the request's immediate peer is listed in
+// Config.TrustedProxies.
+func (s *service) fromTrustedProxy(r *http.Request) bool {
+	if len(s.trustedProxies) == 0 {
+		return false
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return s.trustedProxyAddr(host)
+}
+
+// trustedProxyAddr reports whether host, an IP address, is listed in
+// Config.TrustedProxies.
+func (s *service) trustedProxyAddr(host string) bool {
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range s.trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// baseURL returns the public origin of the site, without a trailing slash:
+// Config.SiteURL when set, otherwise one derived from the request. The
+// X-Forwarded-Proto, X-Forwarded-Host and X-Forwarded-Port headers are only
+// honored when the request comes from a trusted proxy.
+func (s *service) baseURL(r *http.Request) string {
+	if site := strings.TrimSpace(s.cfg.SiteURL); site != "" {
+		return strings.TrimSuffix(site, "/")
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	host := r.Host
+	if s.fromTrustedProxy(r) {
+		if xf := strings.ToLower(firstHeaderValue(r, "X-Forwarded-Proto")); xf == "http" || xf == "https" {
+			scheme = xf
+		}
+		if xf := firstHeaderValue(r, "X-Forwarded-Host"); xf != "" {
+			host = xf
+		}
+		if port := firstHeaderValue(r, "X-Forwarded-Port"); port != "" {
+			host = hostWithPort(host, port, scheme)
+		}
+	}
+	if host == "" {
+		host = "localhost"
+	}
+	return scheme + "://" + host
+}
+
+// firstHeaderValue returns the first comma-separated value of a header,
+// which is the one set by the proxy closest to the client.
+func firstHeaderValue(r *http.Request, name string) string {
+	v, _, _ := strings.Cut(r.Header.Get(name), ",")
+	return strings.TrimSpace(v)
+}
+
+// hostWithPort replaces the port of host, omitting it when it is the
+// scheme's default.
+func hostWithPort(host, port, scheme string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	} else {
+		host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	}
+	if (scheme == "http" && port == "80") || (scheme == "https" && port == "443") {
+		if strings.Contains(host, ":") {
+			return "[" + host + "]"
+		}
+		return host
+	}
+	return net.JoinHostPort(host, port)
+}
+
+type baseURLContextKey struct{}
+
+// withBaseURL records the request's base URL so code that only receives a
+// context, such as the sitemap builders, can produce absolute URLs.
+func withBaseURL(ctx context.Context, base string) context.Context {
+	return context.WithValue(ctx, baseURLContextKey{}, base)
+}
+
+func baseURLFromContext(ctx context.Context) string {
+	base, _ := ctx.Value(baseURLContextKey{}).(string)
+	return base
+}
@@ -0,0 +1,79 @@
+package blog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type publishPostPayload struct {
+	PostID      string    `json:"post_id"`
+	PublishedAt time.Time `json:"published_at"`
+	BaseURL     string    `json:"base_url"`
+	SitemapURL  string    `json:"sitemap_url"`
+}
+
+// maybeQueuePublish schedules a publish task for when p's future
+// PublishedAt arrives, so the post.published webhook and the sitemap ping
+// go out when the post actually goes live. The task ID is derived from the
+// post and its publication time, so saving a scheduled post again doesn't
+// queue a second one.
+func (s *service) maybeQueuePublish(r *http.Request, p *Post) {
+	if p.PublishedAt == nil || !p.PublishedAt.After(time.Now()) || p.DeletedAt != nil {
+		return
+	}
+	sitemapURL := ""
+	if len(s.cfg.PingSearchEngines) > 0 {
+		sitemapURL = s.pingSitemapURL(r)
+	}
+	if s.cfg.WebhookURL == "" && sitemapURL == "" {
+		return
+	}
+	publishedAt := p.PublishedAt.UTC()
+	payload, _ := json.Marshal(publishPostPayload{
+		PostID:      p.ID,
+		PublishedAt: publishedAt,
+		BaseURL:     s.baseURL(r),
+		SitemapURL:  sitemapURL,
+	})
+	task := Task{
+		ID:       fmt.Sprintf("publish-%s-%d", p.ID, publishedAt.UnixNano()),
+		TaskType: TaskTypePublishPost,
+		Status:   TaskStatusPending,
+		Payload:  string(payload),
+		Result:   "{}",
+		RunAfter: &publishedAt,
+	}
+	if err := s.store.CreateTask(context.Background(), &task); err != nil {
+		s.logf("tasks: queue publish post=%s: %v", p.ID, err)
+		return
+	}
+	s.tasks.nudge()
+}
+
+// processPublishPost fires the post.published webhook and queues a sitemap
+// ping for a scheduled post that has gone live. Nothing happens if the post
+// was deleted, trashed or unpublished, or its PublishedAt moved since the
+// task was queued: a move queues a task of its own, and a move into the
+// past publishes the post when it is saved.
+func (s *service) processPublishPost(ctx context.Context, task *Task) error {
+	var payload publishPostPayload
+	if err := json.Unmarshal([]byte(task.Payload), &payload); err != nil {
+		return failTask(fmt.Errorf("invalid payload: %w", err))
+	}
+	post, err := s.store.GetPostByID(ctx, payload.PostID)
+	if err != nil {
+		return err
+	}
+	if post == nil || post.PublishedAt == nil || !post.PublishedAt.Equal(payload.PublishedAt) || !postIsLive(post, time.Now()) {
+		return nil
+	}
+	s.logf("tasks: published post=%s", post.ID)
+	s.queueWebhook(webhookEvent{Event: WebhookEventPostPublished, Post: s.webhookPostAt(payload.BaseURL, post)})
+	if len(s.cfg.PingSearchEngines) > 0 {
+		s.queueSitemapPing(payload.SitemapURL)
+	}
+	return nil
+}
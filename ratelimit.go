@@ -0,0 +1,125 @@
+package blog
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults for Config.CommentRateLimit and Config.CommentRateWindow.
+const (
+	defaultCommentRateLimit  = 5
+	defaultCommentRateWindow = 10 * time.Minute
+)
+
+// rateLimiter is a sliding-window limiter allowing at most max events per
+// window for each key. Buckets whose events have all aged out are evicted
+// periodically so idle clients don't accumulate.
+type rateLimiter struct {
+	max    int
+	window time.Duration
+
+	mu        sync.Mutex
+	buckets   map[string][]time.Time
+	lastSweep time.Time
+}
+
+func newRateLimiter(max int, window time.Duration) *rateLimiter {
+	return &rateLimiter{max: max, window: window, buckets: map[string][]time.Time{}}
+}
+
+// newCommentRateLimiter builds the comment limiter from cfg, returning nil
+// when CommentRateLimit is negative.
+func newCommentRateLimiter(cfg Config) *rateLimiter {
+	max := cfg.CommentRateLimit
+	if max < 0 {
+		return nil
+	}
+	if max == 0 {
+		max = defaultCommentRateLimit
+	}
+	window := cfg.CommentRateWindow
+	if window <= 0 {
+		window = defaultCommentRateWindow
+	}
+	return newRateLimiter(max, window)
+}
+
+// allow records an event for every key and reports whether all of them
+// were under the limit. When any key is over, nothing is recorded and
+// retryAfter says when the oldest event in the fullest bucket expires.
+func (l *rateLimiter) allow(now time.Time, keys ...string) (ok bool, retryAfter time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) >= l.window {
+		l.sweep(now)
+	}
+
+	cutoff := now.Add(-l.window)
+	for _, key := range keys {
+		events := pruneEvents(l.buckets[key], cutoff)
+		l.buckets[key] = events
+		if len(events) >= l.max {
+			if wait := events[0].Sub(cutoff); wait > retryAfter {
+				retryAfter = wait
+			}
+		}
+	}
+	if retryAfter > 0 {
+		return false, retryAfter
+	}
+	for _, key := range keys {
+		l.buckets[key] = append(l.buckets[key], now)
+	}
+	return true, 0
+}
+
+// sweep drops buckets with no events inside the window.
+func (l *rateLimiter) sweep(now time.Time) {
+	cutoff := now.Add(-l.window)
+	for key, events := range l.buckets {
+		if len(events) == 0 || !events[len(events)-1].After(cutoff) {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+// pruneEvents drops events at or before cutoff; events are in time order.
+func pruneEvents(events []time.Time, cutoff time.Time) []time.Time {
+	i := 0
+	for i < len(events) && !events[i].After(cutoff) {
+		i++
+	}
+	return events[i:]
+}
+
+// clientIP returns the address of the client that sent r. When the
+// immediate peer is a trusted proxy, X-Forwarded-For is read from the
+// right, skipping trusted proxies, so a client can't pick its own address
+// by sending the header; otherwise the header is ignored and the host part
+// of RemoteAddr is used.
+func (s *service) clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if !s.fromTrustedProxy(r) {
+		return host
+	}
+	hops := strings.Split(r.Header.Get("X-Forwarded-For"), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if hop == "" {
+			continue
+		}
+		host = hop
+		if !s.trustedProxyAddr(hop) {
+			break
+		}
+	}
+	return host
+}
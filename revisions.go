@@ -0,0 +1,225 @@
+package blog
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// errRevisionNotFound is returned by DiffRevisions when a revision is missing
+// or belongs to another post.
+var errRevisionNotFound = errors.New("revision not found")
+
+// snapshotBeforeUpdate stores a revision of the saved post when next changes
+// its title, markdown or meta description.
+func (s *service) snapshotBeforeUpdate(ctx context.Context, next *Post) (*Post, error) {
+	current, err := s.store.GetPostByID(ctx, next.ID)
+	if err != nil || current == nil {
+		return nil, err
+	}
+	if err := s.savePostRevision(ctx, current, next); err != nil {
+		return nil, err
+	}
+	return current, nil
+}
+
+// savePostRevision stores previous as a revision if next changes its
+// title, markdown or meta description.
+func (s *service) savePostRevision(ctx context.Context, previous, next *Post) error {
+	if previous == nil ||
+		(previous.Title == next.Title &&
+			previous.ContentMarkdown == next.ContentMarkdown &&
+			previous.MetaDescription == next.MetaDescription) {
+		return nil
+	}
+	_, err := s.store.CreatePostRevision(ctx, previous)
+	return err
+}
+
+func (s *service) handleAdminListPostRevisions(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	post, err := s.store.GetPostByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "failed to load post", http.StatusInternalServerError)
+		return
+	}
+	if post == nil {
+		http.NotFound(w, r)
+		return
+	}
+	revisions, err := s.store.ListPostRevisions(r.Context(), id)
+	if err != nil {
+		http.Error(w, "failed to list revisions", http.StatusInternalServerError)
+		return
+	}
+	if revisions == nil {
+		revisions = []PostRevision{}
+	}
+	writeJSON(w, revisions)
+}
+
+// handleAdminRestorePostRevision copies a revision back onto its post. The
+// current text is snapshotted first, so a restore can itself be undone.
+func (s *service) handleAdminRestorePostRevision(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	post, err := s.store.GetPostByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "failed to load post", http.StatusInternalServerError)
+		return
+	}
+	rev, err := s.store.GetPostRevision(r.Context(), chi.URLParam(r, "revID"))
+	if err != nil {
+		http.Error(w, "failed to load revision", http.StatusInternalServerError)
+		return
+	}
+	if post == nil || rev == nil || rev.PostID != post.ID {
+		http.NotFound(w, r)
+		return
+	}
+
+	restored := *post
+	restored.Title = rev.Title
+	restored.ContentMarkdown = rev.ContentMarkdown
+	restored.MetaDescription = rev.MetaDescription
+	html, err := s.renderPostHTML(restored.ContentMarkdown)
+	if err != nil {
+		http.Error(w, "failed to convert markdown", http.StatusInternalServerError)
+		return
+	}
+	restored.ContentHTML = html
+
+	if _, err := s.snapshotBeforeUpdate(r.Context(), &restored); err != nil {
+		http.Error(w, "failed to save revision", http.StatusInternalServerError)
+		return
+	}
+	if err := s.store.UpdatePost(r.Context(), &restored); err != nil {
+		http.Error(w, "failed to update post", http.StatusInternalServerError)
+		return
+	}
+	s.queuePostProcessing("post restored")
+	writeJSON(w, restored)
+}
+
+// DiffRevisions returns a unified diff from revision fromRev to revision toRev
+// of a post, covering the title, meta description and markdown. An empty
+// toRev compares against the post as it is now. Fields that didn't change are
+// left out, so identical revisions produce an empty diff.
+func (a *storeAdapter) DiffRevisions(ctx context.Context, postID, fromRev, toRev string) (string, error) {
+	from, err := a.GetPostRevision(ctx, fromRev)
+	if err != nil {
+		return "", err
+	}
+	if from == nil || from.PostID != postID {
+		return "", errRevisionNotFound
+	}
+
+	var to *PostRevision
+	if toRev == "" {
+		post, err := a.GetPostByID(ctx, postID)
+		if err != nil {
+			return "", err
+		}
+		if post == nil {
+			return "", errRevisionNotFound
+		}
+		to = &PostRevision{
+			ID:              "current",
+			PostID:          post.ID,
+			Title:           post.Title,
+			ContentMarkdown: post.ContentMarkdown,
+			MetaDescription: post.MetaDescription,
+		}
+		if post.UpdatedAt != nil {
+			to.CreatedAt = *post.UpdatedAt
+		}
+	} else {
+		to, err = a.GetPostRevision(ctx, toRev)
+		if err != nil {
+			return "", err
+		}
+		if to == nil || to.PostID != postID {
+			return "", errRevisionNotFound
+		}
+	}
+
+	fields := []struct {
+		name     string
+		from, to string
+	}{
+		{"title", from.Title, to.Title},
+		{"meta_description", from.MetaDescription, to.MetaDescription},
+		{"content_markdown", from.ContentMarkdown, to.ContentMarkdown},
+	}
+	var out strings.Builder
+	for _, f := range fields {
+		if f.from == f.to {
+			continue
+		}
+		text, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(withTrailingNewline(f.from)),
+			B:        difflib.SplitLines(withTrailingNewline(f.to)),
+			FromFile: from.ID + "/" + f.name,
+			ToFile:   to.ID + "/" + f.name,
+			FromDate: revisionDate(from),
+			ToDate:   revisionDate(to),
+			Context:  3,
+		})
+		if err != nil {
+			return "", err
+		}
+		out.WriteString(text)
+	}
+	return out.String(), nil
+}
+
+// withTrailingNewline keeps the last line of a field from being reported as
+// changed just because a line was appended after it.
+func withTrailingNewline(s string) string {
+	if s == "" || strings.HasSuffix(s, "\n") {
+		return s
+	}
+	return s + "\n"
+}
+
+func revisionDate(rev *PostRevision) string {
+	if rev.CreatedAt.IsZero() {
+		return ""
+	}
+	return rev.CreatedAt.UTC().Format(time.RFC3339)
+}
+
+type revisionDiffResponse struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Diff string `json:"diff"`
+}
+
+// handleAdminDiffPostRevisions compares two revisions of a post, or one
+// revision with the current post when "to" is omitted.
+func (s *service) handleAdminDiffPostRevisions(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	from := r.URL.Query().Get("from")
+	to := r.URL.Query().Get("to")
+	if from == "" {
+		http.Error(w, "from is required", http.StatusBadRequest)
+		return
+	}
+	diff, err := s.store.DiffRevisions(r.Context(), id, from, to)
+	if errors.Is(err, errRevisionNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		http.Error(w, "failed to diff revisions", http.StatusInternalServerError)
+		return
+	}
+	if to == "" {
+		to = "current"
+	}
+	writeJSON(w, revisionDiffResponse{From: from, To: to, Diff: diff})
+}
@@ -3,7 +3,11 @@ package blog
 import (
 	"encoding/xml"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/go-chi/chi/v5"
 )
 
 // rssXML is the top-level RSS 2.0 document.
@@ -12,21 +16,23 @@ type rssXML struct {
 	Version   string     `xml:"version,attr"`
 	AtomNS    string     `xml:"xmlns:atom,attr"`
 	ContentNS string     `xml:"xmlns:content,attr"`
+	DCNS      string     `xml:"xmlns:dc,attr"`
 	Channel   rssChannel `xml:"channel"`
 }
 
 // rssChannel holds the feed metadata and items.
 type rssChannel struct {
-	Title         string    `xml:"title"`
-	Link          string    `xml:"link"`
-	Description   string    `xml:"description"`
-	Language      string    `xml:"language,omitempty"`
-	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
-	AtomLink      atomLink  `xml:"atom:link"`
-	Items         []rssItem `xml:"item"`
+	Title         string     `xml:"title"`
+	Link          string     `xml:"link"`
+	Description   string     `xml:"description"`
+	Language      string     `xml:"language,omitempty"`
+	LastBuildDate string     `xml:"lastBuildDate,omitempty"`
+	AtomLinks     []atomLink `xml:"atom:link"`
+	Items         []rssItem  `xml:"item"`
 }
 
-// atomLink provides the self-referencing link required by best practices.
+// atomLink provides the self-referencing link required by best practices,
+// and the first/last/prev/next links of paged feeds.
 type atomLink struct {
 	Href string `xml:"href,attr"`
 	Rel  string `xml:"rel,attr"`
@@ -40,6 +46,8 @@ type rssItem struct {
 	Description    string   `xml:"description"`
 	ContentEncoded string   `xml:"content:encoded"`
 	PubDate        string   `xml:"pubDate,omitempty"`
+	Creator        string   `xml:"dc:creator,omitempty"`
+	Language       string   `xml:"dc:language,omitempty"`
 	GUID           rssGUID  `xml:"guid"`
 	Categories     []string `xml:"category,omitempty"`
 }
@@ -50,13 +58,147 @@ type rssGUID struct {
 	Value       string `xml:",chardata"`
 }
 
+// feedPageSize is the number of posts in a feed, or in each page of a
+// paged feed.
+const feedPageSize = 20
+
+// feedPage places a feed document within its feed. page is 0 when paging
+// is off.
+type feedPage struct {
+	path     string // feed path relative to the route prefix
+	page     int
+	lastPage int
+}
+
+// rssFeedPage reads ?page for a feed with total posts. It reports false
+// after writing a 404 when the page is out of range. With Config.PagedFeeds
+// off, ?page is ignored and the feed has a single page.
+func (s *service) rssFeedPage(w http.ResponseWriter, r *http.Request, feedPath string, total int) (feedPage, bool) {
+	fp := feedPage{path: feedPath}
+	if !s.cfg.PagedFeeds {
+		return fp, true
+	}
+	fp.page, fp.lastPage = 1, (total+feedPageSize-1)/feedPageSize
+	if fp.lastPage < 1 {
+		fp.lastPage = 1
+	}
+	if v := r.URL.Query().Get("page"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > fp.lastPage {
+			http.NotFound(w, r)
+			return fp, false
+		}
+		fp.page = n
+	}
+	return fp, true
+}
+
+// offset returns the number of posts before this page.
+func (fp feedPage) offset() int {
+	if fp.page == 0 {
+		return 0
+	}
+	return (fp.page - 1) * feedPageSize
+}
+
+// atomLinks returns the channel's atom:link elements: the self link and,
+// for paged feeds, the RFC 5005 first/last/prev/next links. Page 1 is the
+// feed URL without ?page.
+func (s *service) atomLinks(r *http.Request, fp feedPage) []atomLink {
+	pageURL := func(page int) string {
+		u := s.canonicalURL(r, fp.path)
+		if page > 1 {
+			u += "?page=" + strconv.Itoa(page)
+		}
+		return u
+	}
+	link := func(rel string, page int) atomLink {
+		return atomLink{Href: pageURL(page), Rel: rel, Type: "application/rss+xml"}
+	}
+	if fp.page == 0 {
+		return []atomLink{link("self", 1)}
+	}
+	links := []atomLink{link("self", fp.page), link("first", 1), link("last", fp.lastPage)}
+	if fp.page > 1 {
+		links = append(links, link("prev", fp.page-1))
+	}
+	if fp.page < fp.lastPage {
+		links = append(links, link("next", fp.page+1))
+	}
+	return links
+}
+
 func (s *service) handleRSSFeed(w http.ResponseWriter, r *http.Request) {
-	posts, err := s.store.ListPublishedPosts(r.Context(), 20, 0)
+	var total int
+	if s.cfg.PagedFeeds {
+		n, err := s.countPublishedPosts(r.Context())
+		if err != nil {
+			http.Error(w, "failed to list posts", http.StatusInternalServerError)
+			return
+		}
+		total = n
+	}
+	fp, ok := s.rssFeedPage(w, r, "/feed", total)
+	if !ok {
+		return
+	}
+	posts, err := s.listPublishedPosts(r.Context(), feedPageSize, fp.offset())
+	if err != nil {
+		http.Error(w, "failed to list posts", http.StatusInternalServerError)
+		return
+	}
+	s.writeRSSFeed(w, r, posts, "", fp)
+}
+
+// handleTagRSSFeed serves the 20 most recent posts carrying a tag. Unknown,
+// empty and hidden tags 404.
+func (s *service) handleTagRSSFeed(w http.ResponseWriter, r *http.Request) {
+	tagSlug := chi.URLParam(r, "tagSlug")
+	if hidden, err := s.store.IsTagHidden(r.Context(), tagSlug); err != nil {
+		http.Error(w, "failed to list posts", http.StatusInternalServerError)
+		return
+	} else if hidden {
+		http.NotFound(w, r)
+		return
+	}
+
+	var total int
+	if s.cfg.PagedFeeds {
+		n, err := s.store.CountPostsByTag(r.Context(), tagSlug)
+		if err != nil {
+			http.Error(w, "failed to list posts", http.StatusInternalServerError)
+			return
+		}
+		total = n
+	}
+	fp, ok := s.rssFeedPage(w, r, "/tag/"+tagSlug+"/feed", total)
+	if !ok {
+		return
+	}
+	posts, err := s.store.ListPostsByTag(r.Context(), tagSlug, feedPageSize, fp.offset())
 	if err != nil {
 		http.Error(w, "failed to list posts", http.StatusInternalServerError)
 		return
 	}
+	if len(posts) == 0 {
+		http.NotFound(w, r)
+		return
+	}
 
+	tagName := tagSlug
+	for _, tag := range posts[0].Tags {
+		if strings.EqualFold(tag.Slug, tagSlug) {
+			tagName = tag.Name
+			break
+		}
+	}
+	s.writeRSSFeed(w, r, posts, tagName, fp)
+}
+
+// writeRSSFeed renders posts as an RSS 2.0 feed. A non-empty tagName is
+// appended to the channel title; fp gives the feed's path and, for paged
+// feeds, the current page.
+func (s *service) writeRSSFeed(w http.ResponseWriter, r *http.Request, posts []Post, tagName string, fp feedPage) {
 	// Load tags for all posts
 	if len(posts) > 0 {
 		_ = s.store.LoadPostsTags(r.Context(), posts)
@@ -71,43 +213,45 @@ func (s *service) handleRSSFeed(w http.ResponseWriter, r *http.Request) {
 	if title == "" {
 		title = "Blog"
 	}
+	if tagName != "" {
+		title += " - " + tagName
+	}
 	description := s.effectiveDescription(settings)
 
-	siteURL := s.cfg.SiteURL
-	if siteURL == "" {
-		// Derive from request if not configured
-		scheme := "https"
-		if r.TLS == nil {
-			scheme = "http"
-		}
-		siteURL = scheme + "://" + r.Host
-	}
+	siteURL := s.baseURL(r)
 
-	feedURL := s.canonicalURL("/feed")
-	if feedURL == "" {
-		feedURL = siteURL + s.routePrefix + "/feed"
-	}
+	authors := s.authorProfiles(r.Context())
 
+	// In a feed mixing languages, items not in the channel's language
+	// carry their own.
+	lang := s.feedLanguage(posts)
 	var items []rssItem
 	var lastBuild time.Time
 
 	for _, p := range posts {
-		link := s.canonicalURL("/" + p.Slug)
-		if link == "" {
-			link = siteURL + s.routePrefix + "/" + p.Slug
-		}
+		link := s.canonicalURL(r, s.postPath(p.Slug))
 
 		item := rssItem{
 			Title:          p.Title,
 			Link:           link,
-			Description:    p.MetaDescription,
-			ContentEncoded: p.ContentHTML,
+			Description:    firstNonEmpty(p.Excerpt, p.MetaDescription),
+			ContentEncoded: s.sanitizeContent(p.ContentHTML),
 			GUID: rssGUID{
 				IsPermaLink: "true",
 				Value:       link,
 			},
 		}
 
+		if postLang := s.postLanguage(&p); !strings.EqualFold(postLang, lang) {
+			item.Language = postLang
+		}
+
+		if profile := authors[p.AuthorID]; profile != nil && profile.Name != "" {
+			item.Creator = profile.Name
+		} else {
+			item.Creator = s.cfg.DefaultAuthorDisplayName
+		}
+
 		if p.PublishedAt != nil {
 			item.PubDate = p.PublishedAt.UTC().Format(time.RFC1123Z)
 			if p.PublishedAt.After(lastBuild) {
@@ -122,26 +266,18 @@ func (s *service) handleRSSFeed(w http.ResponseWriter, r *http.Request) {
 		items = append(items, item)
 	}
 
-	lang := s.cfg.SiteLanguage
-	if lang == "" {
-		lang = "en"
-	}
-
 	feed := rssXML{
 		Version:   "2.0",
 		AtomNS:    "http://www.w3.org/2005/Atom",
 		ContentNS: "http://purl.org/rss/1.0/modules/content/",
+		DCNS:      "http://purl.org/dc/elements/1.1/",
 		Channel: rssChannel{
 			Title:       title,
 			Link:        siteURL + s.routePrefix + "/",
 			Description: description,
 			Language:    lang,
-			AtomLink: atomLink{
-				Href: feedURL,
-				Rel:  "self",
-				Type: "application/rss+xml",
-			},
-			Items: items,
+			AtomLinks:   s.atomLinks(r, fp),
+			Items:       items,
 		},
 	}
 
@@ -149,11 +285,7 @@ func (s *service) handleRSSFeed(w http.ResponseWriter, r *http.Request) {
 		feed.Channel.LastBuildDate = lastBuild.UTC().Format(time.RFC1123Z)
 	}
 
-	w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
-	w.Write([]byte(xml.Header))
-	enc := xml.NewEncoder(w)
-	enc.Indent("", "  ")
-	if err := enc.Encode(feed); err != nil {
+	if err := writeCachedXML(w, r, "application/rss+xml", feed, newestModified(posts)); err != nil {
 		http.Error(w, "failed to encode RSS", http.StatusInternalServerError)
 	}
 }
@@ -0,0 +1,325 @@
+package blog
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// S3Options configures how S3ImageStore reaches the bucket. Empty credentials
+// and region are read from the standard AWS_* environment variables.
+type S3Options struct {
+	// Region is the bucket region (default: AWS_REGION, then "us-east-1").
+	Region string
+	// Endpoint overrides the AWS endpoint for S3-compatible services such as
+	// MinIO or Cloudflare R2 (e.g. "https://minio.internal:9000"). When set,
+	// path-style addressing is used.
+	Endpoint        string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	// HTTPClient is used for all requests (default: a client with a 60s timeout).
+	HTTPClient *http.Client
+	// URLPrefix is the blog's image route, such as "/blog/images", for
+	// serving images through the blog instead of from a public URL.
+	// SaveImage returns URLs under it when the public URL is empty.
+	URLPrefix string
+}
+
+// S3ImageStore is an ImageStore backed by an S3 bucket. Like FileImageStore,
+// objects are keyed by the SHA-256 of their content so identical uploads
+// share a single object. It uses a minimal Signature V4 client rather than
+// the AWS SDK.
+type S3ImageStore struct {
+	// Bucket is the S3 bucket name.
+	Bucket string
+	// Prefix is prepended to every object key (e.g. "blog/images/").
+	Prefix string
+	// PublicURL is the base URL (e.g. a CDN) the bucket's objects are
+	// served from, by key. When it is empty, SaveImage returns URLs under
+	// URLPrefix instead.
+	PublicURL string
+	// URLPrefix is S3Options.URLPrefix.
+	URLPrefix string
+
+	opts S3Options
+	now  func() time.Time
+}
+
+// NewS3ImageStore creates an S3ImageStore for the given bucket and key prefix.
+// publicURL may be empty, in which case images are served through the blog's
+// <prefix>/images/{id} route and opts.URLPrefix must be set to that route.
+func NewS3ImageStore(bucket, prefix, publicURL string, opts S3Options) (*S3ImageStore, error) {
+	if strings.TrimSpace(bucket) == "" {
+		return nil, fmt.Errorf("s3 bucket required")
+	}
+	if strings.TrimSpace(publicURL) == "" && strings.TrimSpace(opts.URLPrefix) == "" {
+		return nil, fmt.Errorf("s3 public url or url prefix required")
+	}
+	if opts.Region == "" {
+		opts.Region = os.Getenv("AWS_REGION")
+	}
+	if opts.Region == "" {
+		opts.Region = "us-east-1"
+	}
+	if opts.AccessKeyID == "" {
+		opts.AccessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+		opts.SecretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+		opts.SessionToken = os.Getenv("AWS_SESSION_TOKEN")
+	}
+	if opts.AccessKeyID == "" || opts.SecretAccessKey == "" {
+		return nil, fmt.Errorf("s3 credentials required")
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = &http.Client{Timeout: 60 * time.Second}
+	}
+	prefix = strings.TrimPrefix(prefix, "/")
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return &S3ImageStore{
+		Bucket:    bucket,
+		Prefix:    prefix,
+		PublicURL: strings.TrimSuffix(publicURL, "/"),
+		URLPrefix: strings.TrimSuffix(opts.URLPrefix, "/"),
+		opts:      opts,
+		now:       time.Now,
+	}, nil
+}
+
+// SaveImage uploads an image unless an object with the same content hash
+// already exists, and returns its URL.
+func (s *S3ImageStore) SaveImage(ctx context.Context, id, filename, contentType string, reader io.Reader) (string, error) {
+	ext := path.Ext(filename)
+	if ext == "" {
+		ext = extensionFromContentType(contentType)
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to read image: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	objectName := hex.EncodeToString(sum[:]) + ext
+	key := s.Prefix + objectName
+
+	exists, err := s.objectExists(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		headers := http.Header{}
+		headers.Set("Content-Type", contentType)
+		headers.Set("X-Amz-Meta-Filename", url.QueryEscape(filename))
+		resp, err := s.do(ctx, http.MethodPut, key, headers, data)
+		if err != nil {
+			return "", err
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("s3 put %s: unexpected status %d", key, resp.StatusCode)
+		}
+	}
+
+	if s.PublicURL != "" {
+		return s.PublicURL + "/" + key, nil
+	}
+	return s.URLPrefix + "/" + objectName, nil
+}
+
+// GetImage retrieves an image by ID. IDs without an extension are resolved
+// against the common image extensions, matching FileImageStore.
+func (s *S3ImageStore) GetImage(ctx context.Context, id string) (string, io.ReadCloser, error) {
+	candidates := []string{id}
+	if path.Ext(id) == "" {
+		candidates = candidates[:0]
+		for _, ext := range imageExtensions {
+			candidates = append(candidates, id+ext)
+		}
+	}
+	for _, name := range candidates {
+		resp, err := s.do(ctx, http.MethodGet, s.Prefix+name, nil, nil)
+		if err != nil {
+			return "", nil, err
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			resp.Body.Close()
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return "", nil, fmt.Errorf("s3 get %s: unexpected status %d", name, resp.StatusCode)
+		}
+		contentType := resp.Header.Get("Content-Type")
+		if contentType == "" || contentType == "binary/octet-stream" {
+			contentType = contentTypeFromExtension(path.Ext(name))
+		}
+		return contentType, resp.Body, nil
+	}
+	return "", nil, fmt.Errorf("image not found: %s", id)
+}
+
+// DeleteImage removes an image by ID (with or without extension).
+func (s *S3ImageStore) DeleteImage(ctx context.Context, id string) error {
+	candidates := []string{id}
+	if path.Ext(id) == "" {
+		candidates = candidates[:0]
+		for _, ext := range imageExtensions {
+			candidates = append(candidates, id+ext)
+		}
+	}
+	deleted := false
+	for _, name := range candidates {
+		exists, err := s.objectExists(ctx, s.Prefix+name)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+		resp, err := s.do(ctx, http.MethodDelete, s.Prefix+name, nil, nil)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("s3 delete %s: unexpected status %d", name, resp.StatusCode)
+		}
+		deleted = true
+	}
+	if !deleted {
+		return fmt.Errorf("no objects found for image: %s", id)
+	}
+	return nil
+}
+
+// imageExtensions are tried in order when an image ID has no extension.
+var imageExtensions = []string{".jpg", ".jpeg", ".png", ".gif", ".webp"}
+
+func (s *S3ImageStore) objectExists(ctx context.Context, key string) (bool, error) {
+	resp, err := s.do(ctx, http.MethodHead, key, nil, nil)
+	if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound, http.StatusForbidden:
+		// S3 answers 403 for missing keys when the caller lacks ListBucket.
+		return false, nil
+	default:
+		return false, fmt.Errorf("s3 head %s: unexpected status %d", key, resp.StatusCode)
+	}
+}
+
+func (s *S3ImageStore) objectURL(key string) string {
+	escaped := s3EscapePath(key)
+	if s.opts.Endpoint != "" {
+		return strings.TrimSuffix(s.opts.Endpoint, "/") + "/" + s.Bucket + "/" + escaped
+	}
+	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.Bucket, s.opts.Region, escaped)
+}
+
+func (s *S3ImageStore) do(ctx context.Context, method, key string, headers http.Header, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, s.objectURL(key), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range headers {
+		req.Header[name] = values
+	}
+	if s.opts.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.opts.SessionToken)
+	}
+	sum := sha256.Sum256(body)
+	signS3Request(req, hex.EncodeToString(sum[:]), s.opts.AccessKeyID, s.opts.SecretAccessKey, s.opts.Region, s.now().UTC())
+	return s.opts.HTTPClient.Do(req)
+}
+
+// signS3Request adds AWS Signature Version 4 headers to req, signing the
+// host and every header already set on the request.
+func signS3Request(req *http.Request, payloadHash, accessKeyID, secretKey, region string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	canonical := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		canonical[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(canonical))
+	for name := range canonical {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var headerLines strings.Builder
+	for _, name := range names {
+		headerLines.WriteString(name + ":" + canonical[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		s3CanonicalQuery(req.URL.Query()),
+		headerLines.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := day + "/" + region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), day)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3EscapePath URI-encodes each segment of an object key as SigV4 requires.
+func s3EscapePath(key string) string {
+	segments := strings.Split(key, "/")
+	for i, seg := range segments {
+		segments[i] = strings.ReplaceAll(url.PathEscape(seg), "+", "%2B")
+	}
+	return strings.Join(segments, "/")
+}
+
+func s3CanonicalQuery(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		for _, v := range values[k] {
+			parts = append(parts, url.QueryEscape(k)+"="+strings.ReplaceAll(url.QueryEscape(v), "+", "%20"))
+		}
+	}
+	return strings.Join(parts, "&")
+}
@@ -0,0 +1,288 @@
+package blog
+
+import (
+	"io"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Sanitization presets for Config.SanitizePolicy.
+const (
+	// SanitizeStrict keeps text and basic formatting: paragraphs, headings,
+	// lists, quotes and emphasis. Links are kept only within the page.
+	SanitizeStrict = "strict"
+	// SanitizeStandard adds links, images, code blocks and tables.
+	SanitizeStandard = "standard"
+	// SanitizeRelaxed adds iframes from Config.SanitizeAllowedIframeHosts.
+	SanitizeRelaxed = "relaxed"
+)
+
+// sanitizePolicy lists the tags a preset keeps and the attributes each may
+// carry. Tags not listed are removed but their text is kept, except for
+// the tags in sanitizeDropContent.
+type sanitizePolicy struct {
+	tags        map[string][]string
+	iframeHosts map[string]bool
+	// fragmentLinksOnly limits href to "#..." links within the page.
+	fragmentLinksOnly bool
+	// linkRel, when set, is the rel given to every link, replacing any
+	// rel in the input.
+	linkRel string
+}
+
+// sanitizeDropContent are removed along with everything inside them.
+var sanitizeDropContent = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true, "embed": true,
+	"noscript": true, "template": true, "textarea": true, "select": true,
+	"svg": true, "math": true, "title": true, "head": true,
+}
+
+var sanitizeVoidTags = map[string]bool{
+	"br": true, "hr": true, "img": true, "source": true, "wbr": true, "input": true,
+}
+
+// Footnotes and task lists from the markdown extensions carry these ids,
+// classes and roles, which every preset keeps so their links still work.
+var (
+	footnoteIDPattern    = regexp.MustCompile(`^fn(ref)?:`)
+	footnoteClassPattern = regexp.MustCompile(`^footnote(s|-[a-z]+)$`)
+	footnoteRoles        = []string{"doc-noteref", "doc-endnotes", "doc-backlink"}
+)
+
+var strictTags = map[string][]string{
+	"p": nil, "br": nil, "hr": nil, "div": {"class", "role"}, "span": nil,
+	"strong": nil, "b": nil, "em": nil, "i": nil, "u": nil, "s": nil, "del": nil, "ins": nil,
+	"mark": nil, "small": nil, "sub": nil, "sup": {"id"}, "abbr": {"title"},
+	"blockquote": nil, "ul": nil, "ol": {"start"}, "li": {"id"}, "dl": nil, "dt": nil, "dd": nil,
+	"h1": {"id"}, "h2": {"id"}, "h3": {"id"}, "h4": {"id"}, "h5": {"id"}, "h6": {"id"},
+	"a": {"href", "class", "aria-label", "role"},
+	// Task list checkboxes; allowTag drops any other input.
+	"input": {"type", "checked", "disabled"},
+}
+
+var standardTags = map[string][]string{
+	"a":          {"href", "title", "rel", "class", "aria-label", "role"},
+	"img":        {"src", "srcset", "sizes", "alt", "title", "width", "height", "loading"},
+	"picture":    nil,
+	"source":     {"srcset", "sizes", "type", "media"},
+	"figure":     nil,
+	"figcaption": nil,
+	"pre":        {"class"},
+	"code":       {"class"},
+	"kbd":        nil,
+	"table":      nil,
+	"thead":      nil,
+	"tbody":      nil,
+	"tfoot":      nil,
+	"tr":         nil,
+	"th":         {"colspan", "rowspan", "scope", "align"},
+	"td":         {"colspan", "rowspan", "align"},
+	"caption":    nil,
+	// Syntax highlighting marks code tokens with classes.
+	"span": {"class"},
+}
+
+var relaxedTags = map[string][]string{
+	"iframe": {"src", "width", "height", "title", "allow", "allowfullscreen", "frameborder", "loading"},
+}
+
+// newSanitizePolicy returns the policy for a preset name, or nil when
+// content should be served as stored.
+func newSanitizePolicy(preset string, iframeHosts []string) *sanitizePolicy {
+	layers := map[string][]map[string][]string{
+		SanitizeStrict:   {strictTags},
+		SanitizeStandard: {strictTags, standardTags},
+		SanitizeRelaxed:  {strictTags, standardTags, relaxedTags},
+	}[strings.ToLower(strings.TrimSpace(preset))]
+	if layers == nil {
+		return nil
+	}
+	p := &sanitizePolicy{tags: map[string][]string{}, iframeHosts: map[string]bool{}}
+	for _, layer := range layers {
+		for tag, attrs := range layer {
+			p.tags[tag] = attrs
+		}
+	}
+	p.fragmentLinksOnly = len(layers) == 1
+	for _, host := range iframeHosts {
+		p.iframeHosts[strings.ToLower(strings.TrimSpace(host))] = true
+	}
+	return p
+}
+
+// sanitizeContent applies Config.SanitizePolicy to post HTML before it is
+// served. With no policy the HTML is returned unchanged.
+func (s *service) sanitizeContent(content string) string {
+	if s.sanitizer == nil {
+		return content
+	}
+	return s.sanitizer.sanitize(content)
+}
+
+// sanitizeStoredHTML filters post HTML before it is stored when
+// Config.SanitizeHTML is set, using Config.SanitizePolicy or, without one,
+// the standard preset. Otherwise raw HTML in posts is stored as written.
+func (s *service) sanitizeStoredHTML(content string) string {
+	if !s.cfg.SanitizeHTML {
+		return content
+	}
+	policy := s.sanitizer
+	if policy == nil {
+		policy = newSanitizePolicy(SanitizeStandard, s.cfg.SanitizeAllowedIframeHosts)
+	}
+	return policy.sanitize(content)
+}
+
+func (p *sanitizePolicy) sanitize(content string) string {
+	var b strings.Builder
+	z := html.NewTokenizer(strings.NewReader(content))
+	skipping := "" // tag whose content is being dropped
+	depth := 0
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			if z.Err() != io.EOF {
+				return ""
+			}
+			return b.String()
+		}
+		tok := z.Token()
+		if skipping != "" {
+			switch {
+			case tt == html.StartTagToken && tok.Data == skipping:
+				depth++
+			case tt == html.EndTagToken && tok.Data == skipping:
+				depth--
+				if depth == 0 {
+					skipping = ""
+				}
+			}
+			continue
+		}
+		switch tt {
+		case html.TextToken:
+			b.WriteString(html.EscapeString(tok.Data))
+		case html.StartTagToken, html.SelfClosingTagToken:
+			allowed, ok := p.allowTag(&tok)
+			if !ok {
+				if sanitizeDropContent[tok.Data] && tt == html.StartTagToken {
+					skipping, depth = tok.Data, 1
+				}
+				continue
+			}
+			tok.Attr = allowed
+			if tok.Data == "a" && p.linkRel != "" {
+				tok.Attr = append(tok.Attr, html.Attribute{Key: "rel", Val: p.linkRel})
+			}
+			if sanitizeVoidTags[tok.Data] {
+				tok.Type = html.SelfClosingTagToken
+			} else {
+				tok.Type = html.StartTagToken
+			}
+			b.WriteString(tok.String())
+		case html.EndTagToken:
+			if _, ok := p.tags[tok.Data]; ok && !sanitizeVoidTags[tok.Data] {
+				b.WriteString(tok.String())
+			}
+		}
+	}
+}
+
+// allowTag reports whether the policy keeps tok, and returns the
+// attributes it may keep.
+func (p *sanitizePolicy) allowTag(tok *html.Token) ([]html.Attribute, bool) {
+	names, ok := p.tags[tok.Data]
+	if !ok {
+		return nil, false
+	}
+	var attrs []html.Attribute
+	for _, attr := range tok.Attr {
+		if attr.Namespace != "" || !slices.Contains(names, attr.Key) {
+			continue
+		}
+		switch attr.Key {
+		case "href":
+			if !p.allowHref(attr.Val) {
+				continue
+			}
+		case "src":
+			if !safeURL(attr.Val, false) {
+				continue
+			}
+		case "srcset":
+			if !safeSrcset(attr.Val) {
+				continue
+			}
+		case "id":
+			if (tok.Data == "sup" || tok.Data == "li") && !footnoteIDPattern.MatchString(attr.Val) {
+				continue
+			}
+		case "class":
+			if tok.Data == "div" && !footnoteClassPattern.MatchString(attr.Val) {
+				continue
+			}
+		case "role":
+			if !slices.Contains(footnoteRoles, attr.Val) {
+				continue
+			}
+		}
+		attrs = append(attrs, attr)
+	}
+	if tok.Data == "iframe" && !p.allowIframe(attrs) {
+		return nil, false
+	}
+	if tok.Data == "input" && !slices.Contains(attrs, html.Attribute{Key: "type", Val: "checkbox"}) {
+		return nil, false
+	}
+	return attrs, true
+}
+
+func (p *sanitizePolicy) allowHref(raw string) bool {
+	if p.fragmentLinksOnly {
+		return strings.HasPrefix(strings.TrimSpace(raw), "#")
+	}
+	return safeURL(raw, true)
+}
+
+// allowIframe keeps iframes whose src is an https URL on an allowlisted
+// host.
+func (p *sanitizePolicy) allowIframe(attrs []html.Attribute) bool {
+	for _, attr := range attrs {
+		if attr.Key != "src" {
+			continue
+		}
+		u, err := url.Parse(strings.TrimSpace(attr.Val))
+		return err == nil && u.Scheme == "https" && p.iframeHosts[strings.ToLower(u.Hostname())]
+	}
+	return false
+}
+
+// safeURL accepts relative URLs and http(s) URLs, plus mailto: when
+// mailto is set.
+func safeURL(raw string, mailto bool) bool {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "", "http", "https":
+		return true
+	case "mailto":
+		return mailto
+	}
+	return false
+}
+
+func safeSrcset(raw string) bool {
+	for _, candidate := range strings.Split(raw, ",") {
+		fields := strings.Fields(candidate)
+		if len(fields) > 0 && !safeURL(fields[0], false) {
+			return false
+		}
+	}
+	return true
+}
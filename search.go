@@ -0,0 +1,179 @@
+package blog
+
+import (
+	"html"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// searchSnippetRadius is the number of characters of context shown on each
+// side of the first match in a search snippet.
+const searchSnippetRadius = 80
+
+// handlePublicSearch renders published posts matching ?q= using list.html.
+func (s *service) handlePublicSearch(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	limit, offset, _ := s.listParams(r)
+
+	var posts []Post
+	if query != "" {
+		matches, err := s.store.SearchPublishedPosts(r.Context(), query, limit, offset)
+		if err != nil {
+			http.Error(w, "failed to search posts", http.StatusInternalServerError)
+			return
+		}
+		posts = matches
+	}
+
+	settings := resolveBlogSettings(nil)
+	if rawSettings, err := s.store.GetBlogSettings(r.Context()); err == nil {
+		settings = resolveBlogSettings(rawSettings)
+	}
+
+	summaries := postsToSummaries(posts)
+	for i := range summaries {
+		summaries[i].Excerpt = searchSnippetText(summaries[i].Post, query)
+	}
+
+	data := map[string]any{
+		"Posts":               summaries,
+		"AllPosts":            posts,
+		"RoutePrefix":         s.routePrefix,
+		"CustomCSS":           s.stylesheets(),
+		"SearchQuery":         query,
+		"DateDisplay":         settings.DateDisplay,
+		"GoogleAnalyticsCode": settings.GoogleAnalyticsCode,
+		"Limit":               limit,
+		"NextOffset":          offset + len(posts),
+		"SiteTitle":           s.effectiveTitle(settings),
+		"SiteURL":             s.cfg.SiteURL,
+		"SiteDescription":     s.effectiveDescription(settings),
+		"FeedURL":             s.canonicalURL(r, "/feed"),
+		"NoIndex":             true,
+	}
+
+	s.executeTemplate(w, "list.html", data)
+}
+
+// handleAdminSearch searches every post, drafts included unless ?drafts=false.
+func (s *service) handleAdminSearch(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		writeJSON(w, []SearchResult{})
+		return
+	}
+	limit := 50
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 && n <= 200 {
+			limit = n
+		}
+	}
+
+	var posts []Post
+	var err error
+	if v := r.URL.Query().Get("drafts"); v == "false" || v == "0" {
+		posts, err = s.store.SearchPublishedPosts(r.Context(), query, limit, 0)
+	} else {
+		posts, err = s.store.SearchAllPosts(r.Context(), query, limit)
+	}
+	if err != nil {
+		http.Error(w, "failed to search posts", http.StatusInternalServerError)
+		return
+	}
+
+	results := make([]SearchResult, 0, len(posts))
+	for _, p := range posts {
+		results = append(results, SearchResult{
+			Post:    p,
+			Status:  postStatus(&p),
+			Snippet: searchSnippetHTML(p, query),
+		})
+	}
+	writeJSON(w, results)
+}
+
+// postMatchesQuery reports whether every whitespace-separated term of query
+// appears in the post's title, subtitle or content, ignoring case.
+func postMatchesQuery(p Post, query string) bool {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return false
+	}
+	haystack := strings.ToLower(p.Title + "\n" + p.Subtitle + "\n" + p.ContentMarkdown)
+	for _, term := range terms {
+		if !strings.Contains(haystack, term) {
+			return false
+		}
+	}
+	return true
+}
+
+// searchSnippetHTML returns an HTML-escaped window of plain text around the
+// first matching term, with the match wrapped in <mark>.
+func searchSnippetHTML(p Post, query string) string {
+	before, match, after := searchSnippetParts(p, query)
+	if match == "" {
+		return html.EscapeString(before)
+	}
+	return html.EscapeString(before) + "<mark>" + html.EscapeString(match) + "</mark>" + html.EscapeString(after)
+}
+
+// searchSnippetText is searchSnippetHTML without markup, for templates.
+func searchSnippetText(p Post, query string) string {
+	before, match, after := searchSnippetParts(p, query)
+	return before + match + after
+}
+
+func searchSnippetParts(p Post, query string) (before, match, after string) {
+	text := []rune(markdownToPlainText(p.ContentMarkdown))
+	lower := make([]rune, len(text))
+	for i, r := range text {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	start, end := -1, -1
+	for _, term := range strings.Fields(strings.ToLower(query)) {
+		if idx := runeIndex(lower, []rune(term)); idx >= 0 && (start < 0 || idx < start) {
+			start, end = idx, idx+len([]rune(term))
+		}
+	}
+	if start < 0 {
+		return trimToLength(string(text), searchSnippetRadius*2), "", ""
+	}
+
+	from := start - searchSnippetRadius
+	if from < 0 {
+		from = 0
+	}
+	to := end + searchSnippetRadius
+	if to > len(text) {
+		to = len(text)
+	}
+	before = string(text[from:start])
+	if from > 0 {
+		before = "..." + before
+	}
+	after = string(text[end:to])
+	if to < len(text) {
+		after += "..."
+	}
+	return before, string(text[start:end]), after
+}
+
+func runeIndex(haystack, needle []rune) int {
+	if len(needle) == 0 {
+		return -1
+	}
+outer:
+	for i := 0; i+len(needle) <= len(haystack); i++ {
+		for j, r := range needle {
+			if haystack[i+j] != r {
+				continue outer
+			}
+		}
+		return i
+	}
+	return -1
+}
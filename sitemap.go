@@ -2,7 +2,13 @@ package blog
 
 import (
 	"context"
+	"encoding/xml"
+	"net/http"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/go-chi/chi/v5"
 )
 
 // SitemapEntry represents a single URL entry for use in an XML sitemap.
@@ -15,46 +21,237 @@ type SitemapEntry struct {
 	LastMod *time.Time
 }
 
-// SitemapEntries returns sitemap entries for all published blog posts plus
-// the blog index page. The host application can merge these into its own
-// sitemap.xml. SiteURL must be set in Config for absolute URLs to be generated;
-// if it is empty the entries will use relative paths.
+// sitemapMaxURLs is the sitemap protocol's per-file URL limit.
+const sitemapMaxURLs = 50000
+
+// SitemapEntries returns sitemap entries for the blog index page, the RSS
+// feed, the archive page of each visible tag, and all published blog posts.
+// The host application can merge these into its own sitemap.xml. SiteURL must
+// be set in Config for absolute URLs to be generated; if it is empty the
+// entries will use relative paths.
+//
+// Large blogs should use SitemapIndex and SitemapPage instead, since a single
+// sitemap file may hold at most 50,000 URLs.
 func (h *Handler) SitemapEntries(ctx context.Context) ([]SitemapEntry, error) {
-	svc := h.svc
+	return h.svc.sitemapEntries(ctx)
+}
+
+// SitemapIndex returns one entry per sitemap chunk, suitable for a
+// <sitemapindex>. Chunk n is served by SitemapPage(ctx, n) and, when
+// Config.ServeSitemap is set, at <prefix>/sitemap-{n}.xml.
+func (h *Handler) SitemapIndex(ctx context.Context) ([]SitemapEntry, error) {
+	return h.svc.sitemapIndex(ctx)
+}
+
+// SitemapPage returns the entries of sitemap chunk n, counting from 1. Each
+// chunk holds at most 50,000 entries. It returns nil for chunks past the end.
+func (h *Handler) SitemapPage(ctx context.Context, n int) ([]SitemapEntry, error) {
+	return h.svc.sitemapPage(ctx, n)
+}
 
-	// Collect all published posts (page through in batches of 100).
-	var allPosts []Post
-	offset := 0
-	for {
-		batch, err := svc.store.ListPublishedPosts(ctx, 100, offset)
+func (s *service) sitemapEntries(ctx context.Context) ([]SitemapEntry, error) {
+	pages, err := s.sitemapPageCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var entries []SitemapEntry
+	for n := 1; n <= pages; n++ {
+		page, err := s.sitemapPage(ctx, n)
 		if err != nil {
 			return nil, err
 		}
-		allPosts = append(allPosts, batch...)
-		if len(batch) < 100 {
+		entries = append(entries, page...)
+	}
+	return entries, nil
+}
+
+func (s *service) sitemapIndex(ctx context.Context) ([]SitemapEntry, error) {
+	pages, err := s.sitemapPageCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]SitemapEntry, 0, pages)
+	for n := 1; n <= pages; n++ {
+		entries = append(entries, SitemapEntry{Loc: s.sitemapLoc(ctx, "/sitemap-"+strconv.Itoa(n)+".xml")})
+	}
+	return entries, nil
+}
+
+// sitemapChunk returns the number of entries per sitemap file.
+func (s *service) sitemapChunk() int {
+	if s.sitemapChunkSize > 0 {
+		return s.sitemapChunkSize
+	}
+	return sitemapMaxURLs
+}
+
+// sitemapHead returns the entries that precede the posts: the index page,
+// the feed, and one entry per visible tag archive that isn't thin. Tag post
+// counts are computed together in one pass over the posts.
+func (s *service) sitemapHead(ctx context.Context) ([]SitemapEntry, error) {
+	tags, err := s.store.ListVisibleTagCounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	head := make([]SitemapEntry, 0, len(tags)+2)
+	head = append(head,
+		SitemapEntry{Loc: s.sitemapLoc(ctx, "/")},
+		SitemapEntry{Loc: s.sitemapLoc(ctx, "/feed")},
+	)
+	for _, t := range tags {
+		if s.isThinArchive(t.Count) {
+			continue
+		}
+		head = append(head, SitemapEntry{Loc: s.sitemapLoc(ctx, "/tag/"+t.Slug)})
+	}
+	return head, nil
+}
+
+func (s *service) sitemapPageCount(ctx context.Context) (int, error) {
+	head, err := s.sitemapHead(ctx)
+	if err != nil {
+		return 0, err
+	}
+	posts, err := s.store.CountPublishedPosts(ctx)
+	if err != nil {
+		return 0, err
+	}
+	chunk := s.sitemapChunk()
+	return (len(head) + posts + chunk - 1) / chunk, nil
+}
+
+func (s *service) sitemapPage(ctx context.Context, n int) ([]SitemapEntry, error) {
+	if n < 1 {
+		return nil, nil
+	}
+	chunk := s.sitemapChunk()
+	start, end := (n-1)*chunk, n*chunk
+
+	head, err := s.sitemapHead(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var entries []SitemapEntry
+	if start < len(head) {
+		entries = append(entries, head[start:min(end, len(head))]...)
+	}
+
+	// Posts follow the head entries; page through them in batches of 1000.
+	offset := max(start-len(head), 0)
+	remaining := end - max(start, len(head))
+	for remaining > 0 {
+		batch, err := s.store.ListPublishedPosts(ctx, min(remaining, 1000), offset)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range batch {
+			lastMod := p.UpdatedAt
+			if lastMod == nil {
+				lastMod = p.PublishedAt
+			}
+			entries = append(entries, SitemapEntry{
+				Loc:     s.sitemapLoc(ctx, s.postPath(p.Slug)),
+				LastMod: lastMod,
+			})
+		}
+		if len(batch) < min(remaining, 1000) {
 			break
 		}
 		offset += len(batch)
+		remaining -= len(batch)
+	}
+	return entries, nil
+}
+
+// sitemapLoc returns the absolute URL for path. Without SiteURL it uses the
+// base URL recorded in ctx by the sitemap handlers, or falls back to the
+// prefixed relative path.
+func (s *service) sitemapLoc(ctx context.Context, path string) string {
+	base := strings.TrimSuffix(strings.TrimSpace(s.cfg.SiteURL), "/")
+	if base == "" {
+		base = baseURLFromContext(ctx)
 	}
+	return base + s.routePrefix + path
+}
+
+// sitemapURLSet is the top-level <urlset> element.
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
 
-	entries := make([]SitemapEntry, 0, len(allPosts)+1)
+// sitemapURL is a single <url> entry.
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
 
-	// Blog index page.
-	entries = append(entries, SitemapEntry{
-		Loc: svc.canonicalURL("/"),
-	})
+// sitemapIndexXML is the top-level <sitemapindex> element.
+type sitemapIndexXML struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	XMLNS    string       `xml:"xmlns,attr"`
+	Sitemaps []sitemapURL `xml:"sitemap"`
+}
 
-	// One entry per published post.
-	for _, p := range allPosts {
-		lastMod := p.UpdatedAt
-		if lastMod == nil {
-			lastMod = p.PublishedAt
+// handleSitemap serves the sitemap index, which points at each
+// sitemap-{n}.xml chunk. It is only mounted when Config.ServeSitemap is set.
+func (s *service) handleSitemap(w http.ResponseWriter, r *http.Request) {
+	refs, err := s.sitemapIndex(withBaseURL(r.Context(), s.baseURL(r)))
+	if err != nil {
+		http.Error(w, "failed to build sitemap", http.StatusInternalServerError)
+		return
+	}
+
+	doc := sitemapIndexXML{XMLNS: "http://www.sitemaps.org/schemas/sitemap/0.9"}
+	for _, ref := range refs {
+		doc.Sitemaps = append(doc.Sitemaps, sitemapURL{Loc: ref.Loc})
+	}
+
+	if err := writeXML(w, "application/xml", doc); err != nil {
+		http.Error(w, "failed to encode sitemap", http.StatusInternalServerError)
+	}
+}
+
+// handleSitemapPage serves one sitemap chunk as a urlset.
+func (s *service) handleSitemapPage(w http.ResponseWriter, r *http.Request) {
+	n, err := strconv.Atoi(chi.URLParam(r, "n"))
+	if err != nil || n < 1 {
+		http.NotFound(w, r)
+		return
+	}
+	entries, err := s.sitemapPage(withBaseURL(r.Context(), s.baseURL(r)), n)
+	if err != nil {
+		http.Error(w, "failed to build sitemap", http.StatusInternalServerError)
+		return
+	}
+	if len(entries) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+	if err := WriteSitemap(w, entries); err != nil {
+		http.Error(w, "failed to encode sitemap", http.StatusInternalServerError)
+	}
+}
+
+// WriteSitemap writes entries as a sitemap <urlset> document with an
+// application/xml Content-Type. Host applications serving their own
+// sitemap.xml can pass it the result of Handler.SitemapEntries or
+// Handler.SitemapPage.
+func WriteSitemap(w http.ResponseWriter, entries []SitemapEntry) error {
+	urls := make([]sitemapURL, 0, len(entries))
+	for _, e := range entries {
+		u := sitemapURL{Loc: e.Loc}
+		if e.LastMod != nil {
+			u.LastMod = e.LastMod.UTC().Format(time.RFC3339)
 		}
-		entries = append(entries, SitemapEntry{
-			Loc:     svc.canonicalURL("/" + p.Slug),
-			LastMod: lastMod,
-		})
+		urls = append(urls, u)
 	}
 
-	return entries, nil
+	doc := sitemapURLSet{
+		XMLNS: "http://www.sitemaps.org/schemas/sitemap/0.9",
+		URLs:  urls,
+	}
+
+	return writeXML(w, "application/xml", doc)
 }
@@ -0,0 +1,156 @@
+package blog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// sitemapPingAttempts is how many times each ping endpoint is tried before
+// the task gives up on it.
+const sitemapPingAttempts = 3
+
+// sitemapPingBackoff is the delay before the second attempt; it doubles for
+// each attempt after that.
+var sitemapPingBackoff = 2 * time.Second
+
+type sitemapPingPayload struct {
+	SitemapURL string   `json:"sitemap_url"`
+	Endpoints  []string `json:"endpoints"`
+}
+
+// maybeQueueSitemapPing queues a ping when Config.PingSearchEngines lists
+// endpoints and the save changed what the sitemap lists: p went live, or
+// its slug changed while live. previous is the post before the save, or
+// nil for a new post.
+func (s *service) maybeQueueSitemapPing(r *http.Request, previous, p *Post) {
+	now := time.Now()
+	if len(s.cfg.PingSearchEngines) == 0 || !postIsLive(p, now) {
+		return
+	}
+	if postIsLive(previous, now) && previous.Slug == p.Slug {
+		return
+	}
+	s.queueSitemapPing(s.pingSitemapURL(r))
+}
+
+// pingSitemapURL returns Config.PingSitemapURL, or the built-in sitemap's
+// absolute URL when Config.ServeSitemap mounts it. With neither there is
+// no sitemap to ping about, and it returns "".
+func (s *service) pingSitemapURL(r *http.Request) string {
+	if s.cfg.PingSitemapURL != "" {
+		return s.cfg.PingSitemapURL
+	}
+	if !s.cfg.ServeSitemap {
+		return ""
+	}
+	return s.canonicalURL(r, "/sitemap.xml")
+}
+
+// queueSitemapPing queues a ping_sitemap task for sitemapURL. It does
+// nothing for an empty URL.
+func (s *service) queueSitemapPing(sitemapURL string) {
+	if sitemapURL == "" {
+		return
+	}
+	payload, _ := json.Marshal(sitemapPingPayload{
+		SitemapURL: sitemapURL,
+		Endpoints:  s.cfg.PingSearchEngines,
+	})
+	task := Task{
+		ID:       generateID(),
+		TaskType: TaskTypePingSitemap,
+		Status:   TaskStatusPending,
+		Payload:  string(payload),
+		Result:   "{}",
+	}
+	if err := s.store.CreateTask(context.Background(), &task); err != nil {
+		s.logf("tasks: queue sitemap ping: %v", err)
+		return
+	}
+	s.tasks.nudge()
+}
+
+// processPingSitemap sends GET <endpoint>?sitemap=<url> to every endpoint,
+// retrying failures with backoff. The result maps each endpoint to "ok" or
+// its last error; the task fails only if every endpoint failed.
+func (s *service) processPingSitemap(ctx context.Context, task *Task) error {
+	var payload sitemapPingPayload
+	if err := json.Unmarshal([]byte(task.Payload), &payload); err != nil {
+		return failTask(fmt.Errorf("invalid payload: %w", err))
+	}
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	results := map[string]string{}
+	failed := 0
+	for _, endpoint := range payload.Endpoints {
+		err := pingSitemapEndpoint(ctx, client, endpoint, payload.SitemapURL)
+		if err != nil {
+			s.logf("tasks: sitemap ping %s: %v", endpoint, err)
+			results[endpoint] = err.Error()
+			failed++
+			continue
+		}
+		results[endpoint] = "ok"
+	}
+
+	raw, _ := json.Marshal(results)
+	task.Result = string(raw)
+	if failed > 0 && failed == len(payload.Endpoints) {
+		return fmt.Errorf("all %d sitemap pings failed", failed)
+	}
+	return nil
+}
+
+func pingSitemapEndpoint(ctx context.Context, client *http.Client, endpoint, sitemapURL string) error {
+	pingURL, err := sitemapPingURL(endpoint, sitemapURL)
+	if err != nil {
+		return err
+	}
+	delay := sitemapPingBackoff
+	for attempt := 1; ; attempt++ {
+		err = sendSitemapPing(ctx, client, pingURL)
+		if err == nil || attempt == sitemapPingAttempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+func sendSitemapPing(ctx context.Context, client *http.Client, pingURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pingURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// sitemapPingURL sets the sitemap query parameter on endpoint, replacing any
+// empty "sitemap=" placeholder it already has.
+func sitemapPingURL(endpoint, sitemapURL string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(endpoint))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", fmt.Errorf("invalid ping endpoint %q", endpoint)
+	}
+	q := u.Query()
+	q.Set("sitemap", sitemapURL)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
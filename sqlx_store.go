@@ -35,10 +35,42 @@ CREATE INDEX IF NOT EXISTS idx_blog_entities_kind_created ON blog_entities(kind,
 CREATE INDEX IF NOT EXISTS idx_blog_entities_kind_published ON blog_entities(kind, published_at);
 `
 
+// SchemaBlogEntitiesPostgres is the PostgreSQL flavor of SchemaBlogEntities.
+// It stores attributes as JSONB and timestamps with time zones.
+const SchemaBlogEntitiesPostgres = `
+CREATE TABLE IF NOT EXISTS blog_entities (
+    id TEXT PRIMARY KEY,
+    kind TEXT NOT NULL,
+    slug TEXT NULL,
+    status TEXT NULL,
+    owner_id TEXT NULL,
+    parent_id TEXT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NULL,
+    published_at TIMESTAMPTZ NULL,
+    attributes JSONB NOT NULL DEFAULT '{}'::jsonb
+);
+CREATE INDEX IF NOT EXISTS idx_blog_entities_kind ON blog_entities(kind);
+CREATE INDEX IF NOT EXISTS idx_blog_entities_kind_slug ON blog_entities(kind, slug);
+CREATE INDEX IF NOT EXISTS idx_blog_entities_kind_status ON blog_entities(kind, status);
+CREATE INDEX IF NOT EXISTS idx_blog_entities_kind_owner ON blog_entities(kind, owner_id);
+CREATE INDEX IF NOT EXISTS idx_blog_entities_kind_parent ON blog_entities(kind, parent_id);
+CREATE INDEX IF NOT EXISTS idx_blog_entities_kind_created ON blog_entities(kind, created_at);
+CREATE INDEX IF NOT EXISTS idx_blog_entities_kind_published ON blog_entities(kind, published_at);
+`
+
+// Dialects understood by SQLXStore.
+const (
+	DialectSQLite   = "sqlite"
+	DialectPostgres = "postgres"
+)
+
 // SQLXStore is a reference implementation of BlogStore using sqlx.
+// It supports SQLite and PostgreSQL; the dialect is derived from the
+// sqlx driver name.
 type SQLXStore struct {
 	DB       *sqlx.DB
-	Dialect  string
+	Dialect  string // DialectSQLite or DialectPostgres
 	keyGuard *regexp.Regexp
 }
 
@@ -102,15 +134,15 @@ CREATE TABLE IF NOT EXISTS blog_migrations (
 		if applied[m.Version] {
 			continue
 		}
-		for _, stmt := range m.Statements {
-			if strings.TrimSpace(stmt) == "" {
-				continue
-			}
+		// Statements run one at a time: some postgres drivers reject
+		// multi-statement strings in the extended query protocol.
+		for _, stmt := range splitSQLStatements(m.statementsFor(s.Dialect)) {
 			if _, err = tx.ExecContext(ctx, stmt); err != nil {
 				return fmt.Errorf("migration %d (%s) failed: %w", m.Version, m.Name, err)
 			}
 		}
-		if _, err = tx.ExecContext(ctx, `INSERT INTO blog_migrations (version, name) VALUES ($1, $2) ON CONFLICT DO NOTHING`, m.Version, m.Name); err != nil {
+		record := tx.Rebind(`INSERT INTO blog_migrations (version, name) VALUES (?, ?) ON CONFLICT (version) DO NOTHING`)
+		if _, err = tx.ExecContext(ctx, record, m.Version, m.Name); err != nil {
 			return fmt.Errorf("record migration %d: %w", m.Version, err)
 		}
 	}
@@ -121,13 +153,92 @@ CREATE TABLE IF NOT EXISTS blog_migrations (
 	return nil
 }
 
+// entityColumns lists the blog_entities columns written by Save and SaveBatch.
+const entityColumns = `id, kind, slug, status, owner_id, parent_id, created_at, updated_at, published_at, attributes`
+
+// entityUpsert is appended to the INSERT statements so saves replace
+// existing rows with the same ID.
+const entityUpsert = `
+ON CONFLICT(id) DO UPDATE SET
+	kind = excluded.kind,
+	slug = excluded.slug,
+	status = excluded.status,
+	owner_id = excluded.owner_id,
+	parent_id = excluded.parent_id,
+	updated_at = excluded.updated_at,
+	published_at = excluded.published_at,
+	attributes = excluded.attributes
+`
+
+// entityInsert upserts one entity given its columns in entityColumns order.
+const entityInsert = `INSERT INTO blog_entities (` + entityColumns + `)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)` + entityUpsert
+
 // Save creates or updates an entity by ID.
 func (s *SQLXStore) Save(ctx context.Context, e *Entity) error {
+	args, err := s.entityArgs(e)
+	if err != nil {
+		return err
+	}
+	_, err = s.DB.ExecContext(ctx, s.DB.Rebind(entityInsert), args...)
+	return err
+}
+
+// saveBatchRows is the number of entities written per INSERT statement.
+// Ten columns per row keeps each statement under SQLite's default limit of
+// 999 bound parameters.
+const saveBatchRows = 90
+
+// SaveBatch upserts entities with multi-row INSERT statements inside a
+// single transaction, so either every entity is saved or none is.
+func (s *SQLXStore) SaveBatch(ctx context.Context, entities []*Entity) (err error) {
+	if len(entities) == 0 {
+		return nil
+	}
+	rows := make([][]interface{}, 0, len(entities))
+	for _, e := range entities {
+		args, err := s.entityArgs(e)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, args)
+	}
+
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	for start := 0; start < len(rows); start += saveBatchRows {
+		chunk := rows[start:min(start+saveBatchRows, len(rows))]
+		placeholders := make([]string, len(chunk))
+		var args []interface{}
+		for i, row := range chunk {
+			placeholders[i] = "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+			args = append(args, row...)
+		}
+		query := tx.Rebind(`INSERT INTO blog_entities (` + entityColumns + `)
+VALUES ` + strings.Join(placeholders, ", ") + entityUpsert)
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// entityArgs validates e, fills in its ID and timestamps, and returns its
+// column values in entityColumns order.
+func (s *SQLXStore) entityArgs(e *Entity) ([]interface{}, error) {
 	if e == nil {
-		return fmt.Errorf("entity required")
+		return nil, fmt.Errorf("entity required")
 	}
 	if strings.TrimSpace(e.Kind) == "" {
-		return fmt.Errorf("entity kind required")
+		return nil, fmt.Errorf("entity kind required")
 	}
 	if e.ID == "" {
 		e.ID = generateID()
@@ -144,23 +255,24 @@ func (s *SQLXStore) Save(ctx context.Context, e *Entity) error {
 	if e.UpdatedAt != nil {
 		updatedAt = e.UpdatedAt.UTC()
 	}
+	// SQLite compares timestamps as text, so they are all stored in UTC.
+	var publishedAt *time.Time
+	if e.PublishedAt != nil {
+		t := e.PublishedAt.UTC()
+		publishedAt = &t
+	}
 
-	query := `
-INSERT INTO blog_entities (id, kind, slug, status, owner_id, parent_id, created_at, updated_at, published_at, attributes)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-ON CONFLICT(id) DO UPDATE SET
-	kind = excluded.kind,
-	slug = excluded.slug,
-	status = excluded.status,
-	owner_id = excluded.owner_id,
-	parent_id = excluded.parent_id,
-	updated_at = excluded.updated_at,
-	published_at = excluded.published_at,
-	attributes = excluded.attributes
-`
-	query = s.DB.Rebind(query)
+	var attrs interface{} = e.Attrs
+	if s.isPostgres() {
+		// Drivers such as lib/pq send []byte as bytea, which JSONB rejects.
+		raw, err := e.Attrs.Value()
+		if err != nil {
+			return nil, err
+		}
+		attrs = string(raw.([]byte))
+	}
 
-	_, err := s.DB.ExecContext(ctx, query,
+	return []interface{}{
 		e.ID,
 		e.Kind,
 		nullIfEmpty(e.Slug),
@@ -169,10 +281,9 @@ ON CONFLICT(id) DO UPDATE SET
 		nullIfEmpty(e.ParentID),
 		createdAt,
 		updatedAt,
-		e.PublishedAt,
-		e.Attrs,
-	)
-	return err
+		publishedAt,
+		attrs,
+	}, nil
 }
 
 // Get retrieves a single entity by ID.
@@ -195,6 +306,59 @@ func (s *SQLXStore) Get(ctx context.Context, id string) (*Entity, error) {
 // Find retrieves entities matching a query.
 func (s *SQLXStore) Find(ctx context.Context, q Query) ([]*Entity, error) {
 	baseQuery := `SELECT id, kind, COALESCE(slug,'') AS slug, COALESCE(status,'') AS status, COALESCE(owner_id,'') AS owner_id, COALESCE(parent_id,'') AS parent_id, created_at, updated_at, published_at, attributes FROM blog_entities`
+	where, args, err := s.whereClause(q)
+	if err != nil {
+		return nil, err
+	}
+	fullQuery := baseQuery + where
+
+	// Ties are broken by ID so that pages resumed from a cursor line up.
+	if orderBy := sanitizeOrderBy(q.OrderBy); orderBy != "" {
+		fullQuery += " ORDER BY " + orderBy
+		if strings.HasSuffix(orderBy, " DESC") {
+			fullQuery += ", id DESC"
+		} else {
+			fullQuery += ", id ASC"
+		}
+	} else {
+		fullQuery += " ORDER BY created_at DESC, id DESC"
+	}
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = 200
+	}
+	offset := q.Offset
+	if offset < 0 {
+		offset = 0
+	}
+	fullQuery += " LIMIT ? OFFSET ?"
+	args = append(args, limit, offset)
+	fullQuery = s.DB.Rebind(fullQuery)
+
+	var entities []*Entity
+	if err := s.DB.SelectContext(ctx, &entities, fullQuery, args...); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+// Count implements Counter.
+func (s *SQLXStore) Count(ctx context.Context, q Query) (int, error) {
+	where, args, err := s.whereClause(q)
+	if err != nil {
+		return 0, err
+	}
+	var n int
+	if err := s.DB.GetContext(ctx, &n, s.DB.Rebind(`SELECT COUNT(*) FROM blog_entities`+where), args...); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// whereClause returns the WHERE clause, if any, selecting q's Kind, Filter
+// and cursor, with its arguments.
+func (s *SQLXStore) whereClause(q Query) (string, []interface{}, error) {
 	var conditions []string
 	var args []interface{}
 
@@ -226,7 +390,7 @@ func (s *SQLXStore) Find(ctx context.Context, q Query) ([]*Entity, error) {
 			continue
 		}
 		if !s.validKey(key) {
-			return nil, fmt.Errorf("invalid filter key: %s", key)
+			return "", nil, fmt.Errorf("invalid filter key: %s", key)
 		}
 		expr := s.jsonExtractExpr(key)
 		if val == nil {
@@ -234,37 +398,134 @@ func (s *SQLXStore) Find(ctx context.Context, q Query) ([]*Entity, error) {
 			continue
 		}
 		conditions = append(conditions, fmt.Sprintf("%s = ?", expr))
+		if s.isPostgres() {
+			// ->> yields text, so compare against the textual form.
+			val = fmt.Sprint(val)
+		}
 		args = append(args, val)
 	}
 
-	fullQuery := baseQuery
-	if len(conditions) > 0 {
-		fullQuery += " WHERE " + strings.Join(conditions, " AND ")
+	if q.PublishedBefore != nil {
+		if q.AfterID != "" {
+			conditions = append(conditions, "(published_at < ? OR (published_at = ? AND id < ?))")
+			args = append(args, q.PublishedBefore.UTC(), q.PublishedBefore.UTC(), q.AfterID)
+		} else {
+			conditions = append(conditions, "published_at < ?")
+			args = append(args, q.PublishedBefore.UTC())
+		}
 	}
 
-	if orderBy := sanitizeOrderBy(q.OrderBy); orderBy != "" {
-		fullQuery += " ORDER BY " + orderBy
-	} else {
-		fullQuery += " ORDER BY created_at DESC"
+	if len(conditions) == 0 {
+		return "", args, nil
 	}
+	return " WHERE " + strings.Join(conditions, " AND "), args, nil
+}
 
-	limit := q.Limit
-	if limit <= 0 {
-		limit = 200
+// FindsByCursor implements CursorFinder.
+func (s *SQLXStore) FindsByCursor() bool { return true }
+
+// CountPublishedPosts implements PostCounter.
+func (s *SQLXStore) CountPublishedPosts(ctx context.Context, includePages bool) (int, error) {
+	query := `SELECT COUNT(*) FROM blog_entities WHERE kind = 'post' AND status = 'published'`
+	var now interface{}
+	if s.isPostgres() {
+		query += ` AND (attributes ->> 'unpublish_at' IS NULL OR (attributes ->> 'unpublish_at')::timestamptz > ?)`
+		if !includePages {
+			query += ` AND COALESCE(attributes ->> 'post_type', '') <> 'page'`
+		}
+		now = time.Now().UTC()
+	} else {
+		query += ` AND (json_extract(attributes, '$.unpublish_at') IS NULL OR julianday(json_extract(attributes, '$.unpublish_at')) > julianday(?))`
+		if !includePages {
+			query += ` AND COALESCE(json_extract(attributes, '$.post_type'), '') <> 'page'`
+		}
+		now = time.Now().UTC().Format(time.RFC3339Nano)
 	}
-	offset := q.Offset
-	if offset < 0 {
-		offset = 0
+	var n int
+	if err := s.DB.GetContext(ctx, &n, s.DB.Rebind(query), now); err != nil {
+		return 0, err
 	}
-	fullQuery += " LIMIT ? OFFSET ?"
-	args = append(args, limit, offset)
-	fullQuery = s.DB.Rebind(fullQuery)
+	return n, nil
+}
 
-	var entities []*Entity
-	if err := s.DB.SelectContext(ctx, &entities, fullQuery, args...); err != nil {
-		return nil, err
+// FindTagCounts implements TagPager. The tags are read from the JSON tags
+// of published posts and counted with GROUP BY, so only the page leaves
+// the database.
+func (s *SQLXStore) FindTagCounts(ctx context.Context, q TagQuery) ([]TagWithCount, int, error) {
+	// tagRows has one row per tag on each published post that hasn't passed
+	// its unpublish_at.
+	var tagRows string
+	var now interface{}
+	if s.isPostgres() {
+		tagRows = `SELECT p.id AS post_id, lower(t.value ->> 'slug') AS slug, t.value ->> 'name' AS name
+		FROM blog_entities p CROSS JOIN LATERAL jsonb_array_elements(
+			CASE WHEN jsonb_typeof(p.attributes -> 'tags') = 'array' THEN p.attributes -> 'tags' ELSE '[]'::jsonb END) AS t(value)
+		WHERE p.kind = 'post' AND p.status = 'published'
+		AND (p.attributes ->> 'unpublish_at' IS NULL OR (p.attributes ->> 'unpublish_at')::timestamptz > ?)`
+		now = time.Now().UTC()
+	} else {
+		tagRows = `SELECT p.id AS post_id, lower(json_extract(t.value, '$.slug')) AS slug, json_extract(t.value, '$.name') AS name
+		FROM blog_entities p, json_each(p.attributes, '$.tags') t
+		WHERE p.kind = 'post' AND p.status = 'published' AND t.type = 'object'
+		AND (json_extract(p.attributes, '$.unpublish_at') IS NULL OR julianday(json_extract(p.attributes, '$.unpublish_at')) > julianday(?))`
+		now = time.Now().UTC().Format(time.RFC3339Nano)
+	}
+	hidden := `COALESCE(json_extract(h.attributes, '$.hidden'), 0) = 0`
+	description := `COALESCE(json_extract(h.attributes, '$.description'), '')`
+	if s.isPostgres() {
+		hidden = `NOT COALESCE((h.attributes ->> 'hidden')::boolean, false)`
+		description = `COALESCE(h.attributes ->> 'description', '')`
+	}
+
+	matching := `WITH tag_rows AS (` + tagRows + `),
+	counted AS (
+		SELECT slug, MIN(name) AS name, COUNT(DISTINCT post_id) AS count
+		FROM tag_rows WHERE slug IS NOT NULL AND slug <> '' GROUP BY slug
+	)
+	SELECT c.slug AS slug, c.name AS name, c.count AS count, ` + description + ` AS description
+	FROM counted c LEFT JOIN blog_entities h ON h.id = 'tag-' || c.slug AND h.kind = 'tag'
+	WHERE ` + hidden
+	args := []interface{}{now}
+	if prefix := strings.ToLower(strings.TrimSpace(q.Prefix)); prefix != "" {
+		pattern := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix) + "%"
+		matching += ` AND (lower(c.name) LIKE ? ESCAPE '\' OR c.slug LIKE ? ESCAPE '\')`
+		args = append(args, pattern, pattern)
+	}
+
+	var total int
+	if err := s.DB.GetContext(ctx, &total, s.DB.Rebind(`SELECT COUNT(*) FROM (`+matching+`) matching`), args...); err != nil {
+		return nil, 0, err
+	}
+
+	page := matching + ` ORDER BY lower(c.name), c.slug`
+	if q.Sort == tagSortCount {
+		page = matching + ` ORDER BY c.count DESC, lower(c.name), c.slug`
+	}
+	// A NULL limit means none in Postgres; SQLite wants -1.
+	var limit interface{} = q.Limit
+	if q.Limit <= 0 {
+		limit = -1
+		if s.isPostgres() {
+			limit = nil
+		}
 	}
-	return entities, nil
+	page += ` LIMIT ? OFFSET ?`
+	args = append(args, limit, max(q.Offset, 0))
+
+	var rows []struct {
+		Slug        string `db:"slug"`
+		Name        string `db:"name"`
+		Count       int    `db:"count"`
+		Description string `db:"description"`
+	}
+	if err := s.DB.SelectContext(ctx, &rows, s.DB.Rebind(page), args...); err != nil {
+		return nil, 0, err
+	}
+	tags := make([]TagWithCount, len(rows))
+	for i, row := range rows {
+		tags[i] = TagWithCount{Tag: Tag{ID: row.Slug, Name: row.Name, Slug: row.Slug, Description: row.Description}, Count: row.Count}
+	}
+	return tags, total, nil
 }
 
 // Delete removes an entity by ID.
@@ -278,6 +539,88 @@ func (s *SQLXStore) Delete(ctx context.Context, id string) error {
 	return err
 }
 
+// SaveIfUnchanged implements ConditionalSaver with an UPDATE that only
+// matches while updated_at is unchanged.
+func (s *SQLXStore) SaveIfUnchanged(ctx context.Context, e *Entity, expected time.Time) (bool, error) {
+	return s.saveIfUnchanged(ctx, s.DB, e, expected)
+}
+
+// saveIfUnchanged runs SaveIfUnchanged's UPDATE on db, which is either the
+// store's DB or a transaction.
+func (s *SQLXStore) saveIfUnchanged(ctx context.Context, db sqlx.ExecerContext, e *Entity, expected time.Time) (bool, error) {
+	args, err := s.entityArgs(e)
+	if err != nil {
+		return false, err
+	}
+	query := s.DB.Rebind(`UPDATE blog_entities SET kind = ?, slug = ?, status = ?, owner_id = ?, parent_id = ?,
+	updated_at = ?, published_at = ?, attributes = ? WHERE id = ? AND updated_at = ?`)
+	// args is in entityColumns order: id, kind, ..., created_at, updated_at, ...
+	res, err := db.ExecContext(ctx, query, args[1], args[2], args[3], args[4], args[5],
+		args[7], args[8], args[9], args[0], expected.UTC())
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+// ApplyChanges implements ChangeApplier. The changes run in one
+// transaction that is rolled back on the first error or conflict.
+func (s *SQLXStore) ApplyChanges(ctx context.Context, changes []EntityChange) (applied bool, err error) {
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if err != nil || !applied {
+			_ = tx.Rollback()
+		}
+	}()
+
+	for _, change := range changes {
+		switch {
+		case change.Save != nil && change.Expected != nil:
+			ok, err := s.saveIfUnchanged(ctx, tx, change.Save, *change.Expected)
+			if err != nil || !ok {
+				return false, err
+			}
+		case change.Save != nil:
+			args, err := s.entityArgs(change.Save)
+			if err != nil {
+				return false, err
+			}
+			if _, err := tx.ExecContext(ctx, tx.Rebind(entityInsert), args...); err != nil {
+				return false, err
+			}
+		case strings.TrimSpace(change.Delete) != "":
+			if _, err := tx.ExecContext(ctx, tx.Rebind(`DELETE FROM blog_entities WHERE id = ?`), change.Delete); err != nil {
+				return false, err
+			}
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ClaimStatus implements StatusClaimer with a conditional UPDATE.
+func (s *SQLXStore) ClaimStatus(ctx context.Context, id, from, to string) (bool, error) {
+	query := s.DB.Rebind(`UPDATE blog_entities SET status = ?, updated_at = ? WHERE id = ? AND status = ?`)
+	res, err := s.DB.ExecContext(ctx, query, to, time.Now().UTC(), id, from)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
 func (s *SQLXStore) validKey(key string) bool {
 	if s == nil || s.keyGuard == nil {
 		return false
@@ -285,8 +628,12 @@ func (s *SQLXStore) validKey(key string) bool {
 	return s.keyGuard.MatchString(key)
 }
 
+func (s *SQLXStore) isPostgres() bool {
+	return s != nil && normalizeDialect(s.Dialect) == DialectPostgres
+}
+
 func (s *SQLXStore) jsonExtractExpr(key string) string {
-	if strings.HasPrefix(strings.ToLower(s.Dialect), "postgres") || strings.HasPrefix(strings.ToLower(s.Dialect), "pgx") {
+	if s.isPostgres() {
 		return fmt.Sprintf("attributes ->> '%s'", key)
 	}
 	return fmt.Sprintf("json_extract(attributes, '$.%s')", key)
@@ -294,15 +641,34 @@ func (s *SQLXStore) jsonExtractExpr(key string) string {
 
 func detectDialect(db *sqlx.DB) string {
 	if db == nil {
-		return "sqlite"
+		return DialectSQLite
 	}
-	type driverNamer interface {
-		DriverName() string
+	return normalizeDialect(db.DriverName())
+}
+
+// normalizeDialect maps a database/sql driver name to a supported dialect.
+func normalizeDialect(driverName string) string {
+	switch strings.ToLower(strings.TrimSpace(driverName)) {
+	case "postgres", "postgresql", "pgx", "pgx/v5", "pq", "cloudsqlpostgres", "nrpostgres":
+		return DialectPostgres
 	}
-	if namer, ok := interface{}(db).(driverNamer); ok {
-		return namer.DriverName()
+	return DialectSQLite
+}
+
+// splitSQLStatements breaks a semicolon-separated script into individual
+// statements, dropping blanks. The built-in schemas contain no string
+// literals with semicolons, so a plain split is sufficient.
+func splitSQLStatements(scripts []string) []string {
+	var out []string
+	for _, script := range scripts {
+		for _, stmt := range strings.Split(script, ";") {
+			if strings.TrimSpace(stmt) == "" {
+				continue
+			}
+			out = append(out, strings.TrimSpace(stmt))
+		}
 	}
-	return "sqlite"
+	return out
 }
 
 func sanitizeOrderBy(order string) string {
@@ -349,7 +715,14 @@ func nullIfEmpty(value string) interface{} {
 
 // tagSlug converts a tag name to a URL-friendly slug.
 func tagSlug(name string) string {
-	s := strings.ToLower(strings.TrimSpace(name))
+	return slugify(name)
+}
+
+// slugify converts text such as a tag name or post title to a URL-friendly
+// slug: lowercase ASCII letters and digits separated by single dashes.
+// Other characters are dropped.
+func slugify(text string) string {
+	s := strings.ToLower(strings.TrimSpace(text))
 	s = strings.Map(func(r rune) rune {
 		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
 			return r
@@ -21,12 +21,31 @@ type ImageStore interface {
 
 	// GetImage retrieves an image by its ID.
 	// Returns the content type, reader, and any error.
+	// Readers that also implement io.Seeker, such as an *os.File, serve
+	// Range requests directly; others are read into memory for them.
 	GetImage(ctx context.Context, id string) (contentType string, reader io.ReadCloser, err error)
 
 	// DeleteImage removes an image by its ID.
 	DeleteImage(ctx context.Context, id string) error
 }
 
+// ImageInfo describes a stored image for the admin media library.
+type ImageInfo struct {
+	ID         string    `json:"id"`
+	URL        string    `json:"url"`
+	Filename   string    `json:"filename"`
+	Size       int64     `json:"size"`
+	UploadedAt time.Time `json:"uploaded_at"`
+}
+
+// ImageLister is an optional interface an ImageStore can implement so the
+// admin can browse uploaded images. ListImages returns them newest first;
+// a limit of 0 means no limit. Without it, GET /admin/api/images returns
+// 501.
+type ImageLister interface {
+	ListImages(ctx context.Context, limit, offset int) ([]ImageInfo, error)
+}
+
 // Attributes stores flexible per-entity data as JSON.
 type Attributes map[string]interface{}
 
@@ -83,6 +102,13 @@ type Query struct {
 	Limit   int
 	Offset  int
 	OrderBy string // e.g., "created_at DESC"
+
+	// PublishedBefore and AfterID resume a "published_at DESC" listing
+	// after the entity with that published_at and ID: only entities
+	// published earlier, or at the same instant with a smaller ID, match.
+	// They are only set for stores implementing CursorFinder.
+	PublishedBefore *time.Time
+	AfterID         string
 }
 
 // BlogStore defines the minimal persistence contract the host application must satisfy.
@@ -103,3 +129,95 @@ type BlogStore interface {
 	// Delete removes an entity by ID.
 	Delete(ctx context.Context, id string) error
 }
+
+// BatchSaver is an optional interface a BlogStore can implement to save many
+// entities at once, such as the comments of a large import. SaveBatch should
+// upsert by ID like Save and be atomic: either every entity is saved or none
+// is. Stores without it are written one entity at a time.
+type BatchSaver interface {
+	SaveBatch(ctx context.Context, entities []*Entity) error
+}
+
+// StatusClaimer is an optional interface a BlogStore can implement so that
+// several app instances can share one task queue. ClaimStatus changes the
+// status of entity id from `from` to `to` in one atomic step and reports
+// whether it did; it returns false when the status was no longer `from`.
+// Without it, tasks are claimed under a lock that only covers one process.
+type StatusClaimer interface {
+	ClaimStatus(ctx context.Context, id, from, to string) (bool, error)
+}
+
+// CursorFinder is an optional interface a BlogStore can implement so that
+// deep archive pages are listed without rescanning from the newest post.
+// FindsByCursor reports whether Find honors Query.PublishedBefore and
+// Query.AfterID; such stores must break published_at ties by ID in the
+// same direction. Without it, the adapter scans from the newest post.
+type CursorFinder interface {
+	FindsByCursor() bool
+}
+
+// ConditionalSaver is an optional interface a BlogStore can implement so
+// that editors on several app instances can't overwrite each other's
+// changes. SaveIfUnchanged saves e only if the stored entity's updated_at
+// still equals expected, and reports whether it did. Without it, the check
+// runs under a lock that only covers one process.
+type ConditionalSaver interface {
+	SaveIfUnchanged(ctx context.Context, e *Entity, expected time.Time) (bool, error)
+}
+
+// EntityChange is one step of a ChangeApplier batch. Save is saved, and if
+// Expected is set only while the stored entity's updated_at still equals
+// it. When Save is nil, the entity with ID Delete is deleted.
+type EntityChange struct {
+	Save     *Entity
+	Expected *time.Time
+	Delete   string
+}
+
+// ChangeApplier is an optional interface a BlogStore can implement so that
+// edits spanning several entities, such as merging tags, are all or
+// nothing. ApplyChanges applies changes in order in one transaction. If a
+// conditional save finds its entity changed, or any step fails, none of
+// them is applied; a conflict reports false with a nil error. Without it,
+// the entities are saved and deleted one at a time.
+type ChangeApplier interface {
+	ApplyChanges(ctx context.Context, changes []EntityChange) (bool, error)
+}
+
+// Counter is an optional interface a BlogStore can implement so that
+// totals are counted in the database. Count returns the number of entities
+// matching q's Kind, Filter and cursor, ignoring its Limit, Offset and
+// OrderBy. Without it, the adapter pages through the matches with Find.
+type Counter interface {
+	Count(ctx context.Context, q Query) (int, error)
+}
+
+// PostCounter is an optional interface a BlogStore can implement so that
+// the post list's total is counted in the database. CountPublishedPosts
+// returns the number of published posts that haven't reached their
+// unpublish_at, leaving out pages unless includePages is set. Without it,
+// the adapter pages through the published posts with Find.
+type PostCounter interface {
+	CountPublishedPosts(ctx context.Context, includePages bool) (int, error)
+}
+
+// TagPager is an optional interface a BlogStore can implement so that the
+// public tags API counts and pages tags in the database. FindTagCounts
+// returns the page of q among the visible tags of published posts, with
+// the number of published posts carrying each, and the number of tags
+// matching q before paging. Without it, the adapter counts every tag in
+// memory and cuts the page there.
+type TagPager interface {
+	FindTagCounts(ctx context.Context, q TagQuery) ([]TagWithCount, int, error)
+}
+
+// TagQuery selects a page of tags for TagPager.
+type TagQuery struct {
+	// Prefix keeps tags whose name or slug starts with it, ignoring case.
+	Prefix string
+	// Sort is "name" (the default), or "count" for the most used first
+	// with ties by name.
+	Sort   string
+	Limit  int // 0 means no limit
+	Offset int
+}
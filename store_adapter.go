@@ -3,22 +3,35 @@ package blog
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 const (
-	entityKindPost    = "post"
-	entityKindComment = "comment"
-	entityKindTask    = "task"
-	entityKindSetting = "setting"
-	entityKindPushSub = "admin_push_subscription"
-
-	entityIDAISettings   = "settings-ai"
-	entityIDBlogSettings = "settings-blog"
+	entityKindPost     = "post"
+	entityKindComment  = "comment"
+	entityKindTask     = "task"
+	entityKindSetting  = "setting"
+	entityKindPushSub  = "admin_push_subscription"
+	entityKindTag      = "tag"
+	entityKindRevision = "revision"
+	entityKindAuthor   = "author"
+	entityKindAIChat   = "ai_chat"
+	entityKindReaction = "comment_reaction"
+	entityKindViews    = "post_views"
+	entityKindMention  = "webmention"
+
+	entityIDAISettings     = "settings-ai"
+	entityIDBlogSettings   = "settings-blog"
+	entityIDAuthorSequence = "settings-author-sequence"
 )
 
 const (
@@ -26,10 +39,19 @@ const (
 	attrVAPIDPublicKey       = "vapid_public_key"
 	attrVAPIDPrivateKey      = "vapid_private_key"
 	attrVAPIDSubscriber      = "vapid_subscriber"
+	attrNotificationDigestAt = "notification_digest_at"
 )
 
 type storeAdapter struct {
 	store BlogStore
+
+	// claimMu serializes TransitionTask for stores without StatusClaimer,
+	// and UpdatePostIfUnchanged, NextAuthorID and AddPostViews for stores
+	// without ConditionalSaver.
+	claimMu sync.Mutex
+
+	// relatedHalfLife is Config.RelatedPostsHalfLife.
+	relatedHalfLife time.Duration
 }
 
 func newStoreAdapter(store BlogStore) *storeAdapter {
@@ -37,13 +59,31 @@ func newStoreAdapter(store BlogStore) *storeAdapter {
 }
 
 type postAttrs struct {
+	Title           string     `json:"title"`
+	Subtitle        string     `json:"subtitle"`
+	ContentMarkdown string     `json:"content_markdown"`
+	ContentHTML     string     `json:"content_html"`
+	MetaDescription string     `json:"meta_description"`
+	Excerpt         string     `json:"excerpt,omitempty"`
+	AuthorID        int        `json:"author_id"`
+	Tags            []Tag      `json:"tags"`
+	UnpublishAt     *time.Time `json:"unpublish_at,omitempty"`
+	Language        string     `json:"language,omitempty"`
+	TranslationOf   string     `json:"translation_of,omitempty"`
+	PostType        string     `json:"post_type,omitempty"`
+	DeletedAt       *time.Time `json:"deleted_at,omitempty"`
+}
+
+type tagAttrs struct {
+	Name        string `json:"name"`
+	Hidden      bool   `json:"hidden"`
+	Description string `json:"description,omitempty"`
+}
+
+type revisionAttrs struct {
 	Title           string `json:"title"`
-	Subtitle        string `json:"subtitle"`
 	ContentMarkdown string `json:"content_markdown"`
-	ContentHTML     string `json:"content_html"`
 	MetaDescription string `json:"meta_description"`
-	AuthorID        int    `json:"author_id"`
-	Tags            []Tag  `json:"tags"`
 }
 
 type commentAttrs struct {
@@ -55,10 +95,20 @@ type commentAttrs struct {
 }
 
 type taskAttrs struct {
-	TaskType     string  `json:"task_type"`
-	Payload      string  `json:"payload"`
-	Result       string  `json:"result"`
-	ErrorMessage *string `json:"error_message,omitempty"`
+	TaskType     string     `json:"task_type"`
+	Payload      string     `json:"payload"`
+	Result       string     `json:"result"`
+	ErrorMessage *string    `json:"error_message,omitempty"`
+	Attempts     int        `json:"attempts,omitempty"`
+	RunAfter     *time.Time `json:"run_after,omitempty"`
+}
+
+type authorAttrs struct {
+	Name      string            `json:"name"`
+	Email     string            `json:"email,omitempty"`
+	AvatarURL string            `json:"avatar_url,omitempty"`
+	Bio       string            `json:"bio,omitempty"`
+	Links     map[string]string `json:"links,omitempty"`
 }
 
 type aiSettingsAttrs struct {
@@ -67,11 +117,14 @@ type aiSettingsAttrs struct {
 }
 
 type blogSettingsAttrs struct {
-	CommentsEnabled     bool   `json:"comments_enabled"`
-	DateDisplay         string `json:"date_display"`
-	Title               string `json:"title"`
-	Description         string `json:"description"`
-	GoogleAnalyticsCode string `json:"google_analytics_code"`
+	CommentsEnabled        bool   `json:"comments_enabled"`
+	DateDisplay            string `json:"date_display"`
+	Title                  string `json:"title"`
+	Description            string `json:"description"`
+	GoogleAnalyticsCode    string `json:"google_analytics_code"`
+	ModerationMode         string `json:"moderation_mode,omitempty"`
+	SyncSpamCheck          bool   `json:"sync_spam_check,omitempty"`
+	CloseCommentsAfterDays int    `json:"close_comments_after_days,omitempty"`
 }
 
 func decodeAttrs(attrs Attributes, target interface{}) error {
@@ -85,18 +138,41 @@ func decodeAttrs(attrs Attributes, target interface{}) error {
 	return json.Unmarshal(payload, target)
 }
 
+// postStatus is stored in the entity's status column. Trashed and expired
+// posts are marked "trashed" and "unpublished" so the store's published
+// queries skip them.
 func postStatus(p *Post) string {
+	if p != nil && p.DeletedAt != nil {
+		return "trashed"
+	}
+	if postExpired(p, time.Now()) {
+		return "unpublished"
+	}
 	if p != nil && p.PublishedAt != nil {
 		return "published"
 	}
 	return "draft"
 }
 
+// postIsLive reports whether p is published with a publication time that
+// has arrived and an unpublish time, if any, that hasn't, and is not in the
+// trash.
+func postIsLive(p *Post, now time.Time) bool {
+	return p != nil && p.PublishedAt != nil && !p.PublishedAt.After(now) && !postExpired(p, now) && p.DeletedAt == nil
+}
+
+// postExpired reports whether p's UnpublishAt has passed.
+func postExpired(p *Post, now time.Time) bool {
+	return p != nil && p.UnpublishAt != nil && !p.UnpublishAt.After(now)
+}
+
 func entityFromPost(p *Post) *Entity {
 	if p == nil {
 		return nil
 	}
-	now := time.Now().UTC()
+	// Postgres keeps microseconds; a finer updated_at would never match
+	// the stored one when a client sends it back as the post's version.
+	now := time.Now().UTC().Truncate(time.Microsecond)
 	p.UpdatedAt = &now
 	attrs := postAttrs{
 		Title:           p.Title,
@@ -104,8 +180,14 @@ func entityFromPost(p *Post) *Entity {
 		ContentMarkdown: p.ContentMarkdown,
 		ContentHTML:     p.ContentHTML,
 		MetaDescription: p.MetaDescription,
+		Excerpt:         p.Excerpt,
 		AuthorID:        p.AuthorID,
 		Tags:            p.Tags,
+		UnpublishAt:     p.UnpublishAt,
+		Language:        p.Language,
+		TranslationOf:   p.TranslationOf,
+		PostType:        p.PostType,
+		DeletedAt:       p.DeletedAt,
 	}
 	return &Entity{
 		ID:          p.ID,
@@ -120,8 +202,14 @@ func entityFromPost(p *Post) *Entity {
 			"content_markdown": attrs.ContentMarkdown,
 			"content_html":     attrs.ContentHTML,
 			"meta_description": attrs.MetaDescription,
+			"excerpt":          attrs.Excerpt,
 			"author_id":        attrs.AuthorID,
 			"tags":             attrs.Tags,
+			"unpublish_at":     attrs.UnpublishAt,
+			"language":         attrs.Language,
+			"translation_of":   attrs.TranslationOf,
+			"post_type":        attrs.PostType,
+			"deleted_at":       attrs.DeletedAt,
 		},
 	}
 }
@@ -147,8 +235,14 @@ func entityToPost(e *Entity) (*Post, error) {
 		PublishedAt:     e.PublishedAt,
 		UpdatedAt:       e.UpdatedAt,
 		MetaDescription: attrs.MetaDescription,
+		Excerpt:         attrs.Excerpt,
 		AuthorID:        attrs.AuthorID,
 		Tags:            attrs.Tags,
+		UnpublishAt:     attrs.UnpublishAt,
+		Language:        attrs.Language,
+		TranslationOf:   attrs.TranslationOf,
+		PostType:        attrs.PostType,
+		DeletedAt:       attrs.DeletedAt,
 	}, nil
 }
 
@@ -217,18 +311,23 @@ func entityFromTask(t *Task) *Entity {
 		Payload:      t.Payload,
 		Result:       t.Result,
 		ErrorMessage: t.ErrorMessage,
+		Attempts:     t.Attempts,
+		RunAfter:     t.RunAfter,
 	}
+	updatedAt := t.UpdatedAt
 	return &Entity{
 		ID:        t.ID,
 		Kind:      entityKindTask,
 		Status:    t.Status,
 		CreatedAt: t.CreatedAt,
-		UpdatedAt: &t.UpdatedAt,
+		UpdatedAt: &updatedAt,
 		Attrs: Attributes{
 			"task_type":     attrs.TaskType,
 			"payload":       attrs.Payload,
 			"result":        attrs.Result,
 			"error_message": attrs.ErrorMessage,
+			"attempts":      attrs.Attempts,
+			"run_after":     attrs.RunAfter,
 		},
 	}
 }
@@ -248,6 +347,8 @@ func entityToTask(e *Entity) (*Task, error) {
 		Payload:      attrs.Payload,
 		Result:       attrs.Result,
 		ErrorMessage: attrs.ErrorMessage,
+		Attempts:     attrs.Attempts,
+		RunAfter:     attrs.RunAfter,
 		CreatedAt:    e.CreatedAt,
 		UpdatedAt:    resolvedTime(e.UpdatedAt, e.CreatedAt),
 	}
@@ -289,16 +390,22 @@ func entityFromBlogSettings(settings *BlogSettings) *Entity {
 		attrs.Title = settings.Title
 		attrs.Description = settings.Description
 		attrs.GoogleAnalyticsCode = settings.GoogleAnalyticsCode
+		attrs.ModerationMode = settings.ModerationMode
+		attrs.SyncSpamCheck = settings.SyncSpamCheck
+		attrs.CloseCommentsAfterDays = settings.CloseCommentsAfterDays
 	}
 	return &Entity{
 		ID:   entityIDBlogSettings,
 		Kind: entityKindSetting,
 		Attrs: Attributes{
-			"comments_enabled":      attrs.CommentsEnabled,
-			"date_display":          attrs.DateDisplay,
-			"title":                 attrs.Title,
-			"description":           attrs.Description,
-			"google_analytics_code": attrs.GoogleAnalyticsCode,
+			"comments_enabled":          attrs.CommentsEnabled,
+			"date_display":              attrs.DateDisplay,
+			"title":                     attrs.Title,
+			"description":               attrs.Description,
+			"google_analytics_code":     attrs.GoogleAnalyticsCode,
+			"moderation_mode":           attrs.ModerationMode,
+			"sync_spam_check":           attrs.SyncSpamCheck,
+			"close_comments_after_days": attrs.CloseCommentsAfterDays,
 		},
 	}
 }
@@ -312,11 +419,14 @@ func entityToBlogSettings(e *Entity) (*BlogSettings, error) {
 		return nil, err
 	}
 	return &BlogSettings{
-		CommentsEnabled:     attrs.CommentsEnabled,
-		DateDisplay:         attrs.DateDisplay,
-		Title:               attrs.Title,
-		Description:         attrs.Description,
-		GoogleAnalyticsCode: attrs.GoogleAnalyticsCode,
+		CommentsEnabled:        attrs.CommentsEnabled,
+		DateDisplay:            attrs.DateDisplay,
+		Title:                  attrs.Title,
+		Description:            attrs.Description,
+		GoogleAnalyticsCode:    attrs.GoogleAnalyticsCode,
+		ModerationMode:         attrs.ModerationMode,
+		SyncSpamCheck:          attrs.SyncSpamCheck,
+		CloseCommentsAfterDays: attrs.CloseCommentsAfterDays,
 	}, nil
 }
 
@@ -347,9 +457,30 @@ func (a *storeAdapter) GetPublishedPostBySlug(ctx context.Context, slug string)
 	if err != nil || len(entities) == 0 {
 		return nil, err
 	}
+	post, err := entityToPost(entities[0])
+	if err != nil || postExpired(post, time.Now()) || post.DeletedAt != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// GetPostBySlug returns the post with the given slug whatever its status,
+// or nil if there is none.
+func (a *storeAdapter) GetPostBySlug(ctx context.Context, slug string) (*Post, error) {
+	entities, err := a.store.Find(ctx, Query{
+		Kind:   entityKindPost,
+		Filter: map[string]interface{}{"slug": slug},
+		Limit:  1,
+	})
+	if err != nil || len(entities) == 0 {
+		return nil, err
+	}
 	return entityToPost(entities[0])
 }
 
+// ListPublishedPosts returns published posts and pages, newest first. Posts past their
+// UnpublishAt are left out; the unpublish task restamps their status soon
+// after they expire, so until then a page may come up short.
 func (a *storeAdapter) ListPublishedPosts(ctx context.Context, limit, offset int) ([]Post, error) {
 	q := Query{
 		Kind: entityKindPost,
@@ -364,21 +495,133 @@ func (a *storeAdapter) ListPublishedPosts(ctx context.Context, limit, offset int
 	if err != nil {
 		return nil, err
 	}
-	return entitiesToPosts(entities)
+	posts, err := entitiesToPosts(entities)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	live := posts[:0]
+	for _, post := range posts {
+		if !postExpired(&post, now) {
+			live = append(live, post)
+		}
+	}
+	return live, nil
+}
+
+// ListPublishedArticles is ListPublishedPosts without pages. Most blogs
+// have no pages, so it checks for one first and otherwise lets the store
+// apply limit and offset.
+func (a *storeAdapter) ListPublishedArticles(ctx context.Context, limit, offset int) ([]Post, error) {
+	hasPages, err := a.hasPublishedPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !hasPages {
+		return a.ListPublishedPosts(ctx, limit, offset)
+	}
+	return a.collectPublishedPosts(ctx, limit, offset, func(post Post) bool {
+		return post.PostType != PostTypePage
+	})
+}
+
+func (a *storeAdapter) hasPublishedPages(ctx context.Context) (bool, error) {
+	entities, err := a.store.Find(ctx, Query{
+		Kind:   entityKindPost,
+		Filter: map[string]interface{}{"status": "published", "post_type": PostTypePage},
+		Limit:  1,
+	})
+	if err != nil {
+		return false, err
+	}
+	posts, err := entitiesToPosts(entities)
+	if err != nil {
+		return false, err
+	}
+	return len(posts) > 0 && posts[0].PostType == PostTypePage, nil
 }
 
 func (a *storeAdapter) ListPostsByTag(ctx context.Context, tagSlug string, limit, offset int) ([]Post, error) {
 	filterFn := func(post Post) bool {
-		for _, tag := range post.Tags {
-			if strings.EqualFold(tag.Slug, tagSlug) {
-				return true
-			}
-		}
-		return false
+		return postHasTag(post, tagSlug)
 	}
 	return a.collectPublishedPosts(ctx, limit, offset, filterFn)
 }
 
+// postHasTag reports whether post carries the tag.
+func postHasTag(post Post, tagSlug string) bool {
+	for _, tag := range post.Tags {
+		if strings.EqualFold(tag.Slug, tagSlug) {
+			return true
+		}
+	}
+	return false
+}
+
+// ListPostsByAuthor returns the author's published posts, newest first.
+func (a *storeAdapter) ListPostsByAuthor(ctx context.Context, authorID int, limit, offset int) ([]Post, error) {
+	return a.collectPublishedPosts(ctx, limit, offset, func(post Post) bool {
+		return post.AuthorID == authorID
+	})
+}
+
+// ListPublishedPostsAfter is ListPublishedPosts resuming after a cursor
+// rather than an offset, so deep pages cost no more than the first.
+func (a *storeAdapter) ListPublishedPostsAfter(ctx context.Context, after postCursor, limit int) ([]Post, error) {
+	return a.collectPublishedPostsAfter(ctx, &after, limit, 0, func(Post) bool { return true })
+}
+
+// ListPublishedArticlesAfter is ListPublishedPostsAfter without pages.
+func (a *storeAdapter) ListPublishedArticlesAfter(ctx context.Context, after postCursor, limit int) ([]Post, error) {
+	return a.collectPublishedPostsAfter(ctx, &after, limit, 0, func(post Post) bool {
+		return post.PostType != PostTypePage
+	})
+}
+
+// ListPostsByTagAfter is ListPostsByTag resuming after a cursor.
+func (a *storeAdapter) ListPostsByTagAfter(ctx context.Context, tagSlug string, after postCursor, limit int) ([]Post, error) {
+	return a.collectPublishedPostsAfter(ctx, &after, limit, 0, func(post Post) bool {
+		return postHasTag(post, tagSlug)
+	})
+}
+
+// CountPublishedPosts returns the number of published posts and pages,
+// matching what ListPublishedPosts pages through. Stores implementing
+// PostCounter count them in the database.
+func (a *storeAdapter) CountPublishedPosts(ctx context.Context) (int, error) {
+	if counter, ok := a.store.(PostCounter); ok {
+		return counter.CountPublishedPosts(ctx, true)
+	}
+	posts, err := a.collectPublishedPosts(ctx, 0, 0, func(Post) bool { return true })
+	if err != nil {
+		return 0, err
+	}
+	return len(posts), nil
+}
+
+// CountPublishedArticles is CountPublishedPosts without pages.
+func (a *storeAdapter) CountPublishedArticles(ctx context.Context) (int, error) {
+	if counter, ok := a.store.(PostCounter); ok {
+		return counter.CountPublishedPosts(ctx, false)
+	}
+	posts, err := a.collectPublishedPosts(ctx, 0, 0, func(post Post) bool {
+		return post.PostType != PostTypePage
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(posts), nil
+}
+
+// CountPostsByTag returns the number of published posts carrying the tag.
+func (a *storeAdapter) CountPostsByTag(ctx context.Context, tagSlug string) (int, error) {
+	posts, err := a.ListPostsByTag(ctx, tagSlug, 0, 0)
+	if err != nil {
+		return 0, err
+	}
+	return len(posts), nil
+}
+
 func (a *storeAdapter) CreatePost(ctx context.Context, p *Post) error {
 	if p == nil {
 		return fmt.Errorf("post required")
@@ -404,6 +647,25 @@ func (a *storeAdapter) UpdatePost(ctx context.Context, p *Post) error {
 	return a.store.Save(ctx, entity)
 }
 
+// UpdatePostIfUnchanged saves p only if the stored post's UpdatedAt still
+// equals expected, and reports whether it did.
+func (a *storeAdapter) UpdatePostIfUnchanged(ctx context.Context, p *Post, expected time.Time) (bool, error) {
+	if p == nil {
+		return false, fmt.Errorf("post required")
+	}
+	if saver, ok := a.store.(ConditionalSaver); ok {
+		return saver.SaveIfUnchanged(ctx, entityFromPost(p), expected)
+	}
+
+	a.claimMu.Lock()
+	defer a.claimMu.Unlock()
+	current, err := a.GetPostByID(ctx, p.ID)
+	if err != nil || current == nil || current.UpdatedAt == nil || !current.UpdatedAt.Equal(expected) {
+		return false, err
+	}
+	return true, a.UpdatePost(ctx, p)
+}
+
 func (a *storeAdapter) GetPostByID(ctx context.Context, id string) (*Post, error) {
 	entity, err := a.store.Get(ctx, id)
 	if err != nil || entity == nil {
@@ -415,11 +677,311 @@ func (a *storeAdapter) GetPostByID(ctx context.Context, id string) (*Post, error
 	return entityToPost(entity)
 }
 
+// ListPostTranslations returns the post rootID and every post that
+// translates it, drafts included.
+func (a *storeAdapter) ListPostTranslations(ctx context.Context, rootID string) ([]Post, error) {
+	root, err := a.GetPostByID(ctx, rootID)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := a.findAll(ctx, Query{
+		Kind:    entityKindPost,
+		Filter:  map[string]interface{}{"translation_of": rootID},
+		OrderBy: "created_at ASC",
+	})
+	if err != nil {
+		return nil, err
+	}
+	translations, err := entitiesToPosts(entities)
+	if err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return translations, nil
+	}
+	return append([]Post{*root}, translations...), nil
+}
+
 func (a *storeAdapter) DeletePost(ctx context.Context, id string) error {
-	return a.store.Delete(ctx, id)
+	if err := a.store.Delete(ctx, id); err != nil {
+		return err
+	}
+	if err := a.DeleteAIChatHistory(ctx, id); err != nil {
+		return err
+	}
+	if err := a.store.Delete(ctx, postViewsEntityID(id)); err != nil {
+		return err
+	}
+	if err := a.deletePostWebmentions(ctx, id); err != nil {
+		return err
+	}
+	if err := a.deletePostComments(ctx, id); err != nil {
+		return err
+	}
+	return a.prunePostRevisions(ctx, id, 0)
+}
+
+// deletePostComments deletes every comment on a post, whatever its status,
+// and the reactions on them.
+func (a *storeAdapter) deletePostComments(ctx context.Context, postID string) error {
+	for _, q := range []Query{
+		{Kind: entityKindReaction, Filter: map[string]interface{}{"parent_id": postID}},
+		{Kind: entityKindComment, Filter: map[string]interface{}{"owner_id": postID}},
+	} {
+		entities, err := a.findAll(ctx, q)
+		if err != nil {
+			return err
+		}
+		for _, entity := range entities {
+			if err := a.store.Delete(ctx, entity.ID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func postViewsEntityID(postID string) string {
+	return "views-" + postID
+}
+
+type postViewsAttrs struct {
+	Count int `json:"count"`
+}
+
+// AddPostViews adds counts, by post ID, to the stored view counts. Counts
+// are kept in their own entities so they don't touch the post's UpdatedAt.
+// Stores with ConditionalSaver update each count only if no one else has
+// since, retrying otherwise, so flushes running at once on several workers
+// or app instances don't lose views; other stores serialize the updates
+// within the process. Posts are stored in ID order, and each is removed
+// from counts once its views are stored, so on error counts holds only the
+// views that weren't.
+func (a *storeAdapter) AddPostViews(ctx context.Context, counts map[string]int) error {
+	saver, conditional := a.store.(ConditionalSaver)
+	if !conditional {
+		a.claimMu.Lock()
+		defer a.claimMu.Unlock()
+	}
+	postIDs := make([]string, 0, len(counts))
+	for postID := range counts {
+		postIDs = append(postIDs, postID)
+	}
+	sort.Strings(postIDs)
+	for _, postID := range postIDs {
+		if err := a.addPostViews(ctx, saver, postID, counts[postID]); err != nil {
+			return err
+		}
+		delete(counts, postID)
+	}
+	return nil
+}
+
+// addPostViews adds n to one post's view count, conditionally when saver
+// is not nil.
+func (a *storeAdapter) addPostViews(ctx context.Context, saver ConditionalSaver, postID string, n int) error {
+	for attempt := 0; attempt < 10; attempt++ {
+		entity, err := a.store.Get(ctx, postViewsEntityID(postID))
+		if err != nil {
+			return err
+		}
+		var attrs postViewsAttrs
+		if entity != nil {
+			if err := decodeAttrs(entity.Attrs, &attrs); err != nil {
+				return err
+			}
+		}
+		next := &Entity{
+			ID:      postViewsEntityID(postID),
+			Kind:    entityKindViews,
+			OwnerID: postID,
+			Attrs:   Attributes{"count": attrs.Count + n},
+		}
+		if saver == nil {
+			return a.store.Save(ctx, next)
+		}
+		if entity == nil || entity.UpdatedAt == nil {
+			// As in NextAuthorID, every instance creates the count with the
+			// same updated_at, so only one of their conditional saves below
+			// succeeds.
+			created := time.Unix(0, 0).UTC()
+			entity = &Entity{ID: next.ID, Kind: entityKindViews, OwnerID: postID, Attrs: Attributes{"count": attrs.Count}, UpdatedAt: &created}
+			if err := a.store.Save(ctx, entity); err != nil {
+				return err
+			}
+		}
+		expected := *entity.UpdatedAt
+		updated := conditionalUpdatedAt(expected)
+		next.UpdatedAt = &updated
+		ok, err := saver.SaveIfUnchanged(ctx, next, expected)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+	}
+	return fmt.Errorf("store views of post %s: too much contention", postID)
+}
+
+// PostViewCounts returns the stored view counts by post ID.
+func (a *storeAdapter) PostViewCounts(ctx context.Context) (map[string]int, error) {
+	entities, err := a.fetchAllEntities(ctx, entityKindViews)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int, len(entities))
+	for _, entity := range entities {
+		var attrs postViewsAttrs
+		if err := decodeAttrs(entity.Attrs, &attrs); err != nil {
+			return nil, err
+		}
+		counts[entity.OwnerID] = attrs.Count
+	}
+	return counts, nil
+}
+
+// GetPostViewCount returns the stored view count of one post.
+func (a *storeAdapter) GetPostViewCount(ctx context.Context, postID string) (int, error) {
+	entity, err := a.store.Get(ctx, postViewsEntityID(postID))
+	if err != nil || entity == nil {
+		return 0, err
+	}
+	var attrs postViewsAttrs
+	err = decodeAttrs(entity.Attrs, &attrs)
+	return attrs.Count, err
+}
+
+// maxPostRevisions is the number of revisions kept per post.
+const maxPostRevisions = 50
+
+// CreatePostRevision snapshots the post's title, markdown and description,
+// then prunes revisions beyond maxPostRevisions.
+func (a *storeAdapter) CreatePostRevision(ctx context.Context, p *Post) (*PostRevision, error) {
+	if p == nil || p.ID == "" {
+		return nil, fmt.Errorf("post required")
+	}
+	rev := &PostRevision{
+		ID:              generateID(),
+		PostID:          p.ID,
+		Title:           p.Title,
+		ContentMarkdown: p.ContentMarkdown,
+		MetaDescription: p.MetaDescription,
+		CreatedAt:       time.Now().UTC(),
+	}
+	entity := &Entity{
+		ID:        rev.ID,
+		Kind:      entityKindRevision,
+		OwnerID:   rev.PostID,
+		CreatedAt: rev.CreatedAt,
+		Attrs: Attributes{
+			"title":            rev.Title,
+			"content_markdown": rev.ContentMarkdown,
+			"meta_description": rev.MetaDescription,
+		},
+	}
+	if err := a.store.Save(ctx, entity); err != nil {
+		return nil, err
+	}
+	return rev, a.prunePostRevisions(ctx, p.ID, maxPostRevisions)
+}
+
+// ListPostRevisions returns a post's revisions, newest first.
+func (a *storeAdapter) ListPostRevisions(ctx context.Context, postID string) ([]PostRevision, error) {
+	var out []PostRevision
+	offset := 0
+	for {
+		q := Query{
+			Kind: entityKindRevision,
+			Filter: map[string]interface{}{
+				"owner_id": postID,
+			},
+			Limit:   200,
+			Offset:  offset,
+			OrderBy: "created_at DESC",
+		}
+		entities, err := a.store.Find(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		if len(entities) == 0 {
+			break
+		}
+		for _, entity := range entities {
+			rev, err := entityToRevision(entity)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, *rev)
+		}
+		offset += len(entities)
+	}
+	return out, nil
+}
+
+// GetPostRevision returns a revision by id, or nil if it doesn't exist.
+func (a *storeAdapter) GetPostRevision(ctx context.Context, id string) (*PostRevision, error) {
+	entity, err := a.store.Get(ctx, id)
+	if err != nil || entity == nil || entity.Kind != entityKindRevision {
+		return nil, err
+	}
+	return entityToRevision(entity)
+}
+
+// prunePostRevisions deletes all but the newest keep revisions of a post.
+func (a *storeAdapter) prunePostRevisions(ctx context.Context, postID string, keep int) error {
+	revisions, err := a.ListPostRevisions(ctx, postID)
+	if err != nil {
+		return err
+	}
+	for i := keep; i < len(revisions); i++ {
+		if err := a.store.Delete(ctx, revisions[i].ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func entityToRevision(e *Entity) (*PostRevision, error) {
+	var attrs revisionAttrs
+	if err := decodeAttrs(e.Attrs, &attrs); err != nil {
+		return nil, err
+	}
+	return &PostRevision{
+		ID:              e.ID,
+		PostID:          e.OwnerID,
+		Title:           attrs.Title,
+		ContentMarkdown: attrs.ContentMarkdown,
+		MetaDescription: attrs.MetaDescription,
+		CreatedAt:       e.CreatedAt,
+	}, nil
 }
 
 func (a *storeAdapter) ListAllPosts(ctx context.Context, limit, offset int) ([]Post, error) {
+	posts, err := a.listPosts(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	posts = sortPostsForAdmin(posts)
+	return slicePosts(posts, limit, offset), nil
+}
+
+// ListTrashedPosts returns the posts in the trash, most recently trashed
+// first.
+func (a *storeAdapter) ListTrashedPosts(ctx context.Context, limit, offset int) ([]Post, error) {
+	posts, err := a.listPosts(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(posts, func(i, j int) bool {
+		return posts[i].DeletedAt.After(*posts[j].DeletedAt)
+	})
+	return slicePosts(posts, limit, offset), nil
+}
+
+// listPosts returns every post in the trash when trashed is set, or every
+// post outside it otherwise, in no particular order.
+func (a *storeAdapter) listPosts(ctx context.Context, trashed bool) ([]Post, error) {
 	entities, err := a.fetchAllEntities(ctx, entityKindPost)
 	if err != nil {
 		return nil, err
@@ -428,8 +990,40 @@ func (a *storeAdapter) ListAllPosts(ctx context.Context, limit, offset int) ([]P
 	if err != nil {
 		return nil, err
 	}
-	posts = sortPostsForAdmin(posts)
-	return slicePosts(posts, limit, offset), nil
+	out := posts[:0]
+	for _, post := range posts {
+		if (post.DeletedAt != nil) == trashed {
+			out = append(out, post)
+		}
+	}
+	return out, nil
+}
+
+// SearchPublishedPosts returns published posts matching query, newest first.
+func (a *storeAdapter) SearchPublishedPosts(ctx context.Context, query string, limit, offset int) ([]Post, error) {
+	return a.collectPublishedPosts(ctx, limit, offset, func(post Post) bool {
+		return postMatchesQuery(post, query)
+	})
+}
+
+// SearchAllPosts is SearchPublishedPosts without the published constraint,
+// so drafts are included. Results use the admin ordering.
+func (a *storeAdapter) SearchAllPosts(ctx context.Context, query string, limit int) ([]Post, error) {
+	posts, err := a.ListAllPosts(ctx, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	var out []Post
+	for _, post := range posts {
+		if !postMatchesQuery(post, query) {
+			continue
+		}
+		out = append(out, post)
+		if limit > 0 && len(out) >= limit {
+			break
+		}
+	}
+	return out, nil
 }
 
 func (a *storeAdapter) SetPostTags(ctx context.Context, postID string, tagNames []string) error {
@@ -464,6 +1058,453 @@ func (a *storeAdapter) GetPostTags(ctx context.Context, postID string) ([]Tag, e
 	return post.Tags, nil
 }
 
+// ListAllTags returns the visible tags used by published posts, sorted by
+// name. Hidden tags are omitted.
+func (a *storeAdapter) ListAllTags(ctx context.Context) ([]Tag, error) {
+	tags, err := a.listTags(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	visible := make([]Tag, 0, len(tags))
+	for _, tag := range tags {
+		if !tag.Hidden {
+			visible = append(visible, tag)
+		}
+	}
+	return visible, nil
+}
+
+// ListVisibleTagCounts returns the visible tags used by published posts,
+// with the number of published posts carrying each, sorted by name.
+func (a *storeAdapter) ListVisibleTagCounts(ctx context.Context) ([]TagWithCount, error) {
+	counted, err := a.listTagCounts(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	visible := make([]TagWithCount, 0, len(counted))
+	for _, tag := range counted {
+		if !tag.Hidden {
+			visible = append(visible, tag)
+		}
+	}
+	return visible, nil
+}
+
+// ListTagsForAdmin returns every tag used by any post, drafts included,
+// with its settings and the number of published posts carrying it.
+func (a *storeAdapter) ListTagsForAdmin(ctx context.Context) ([]TagWithCount, error) {
+	return a.listTagCounts(ctx, false)
+}
+
+func (a *storeAdapter) listTags(ctx context.Context, publishedOnly bool) ([]Tag, error) {
+	counted, err := a.listTagCounts(ctx, publishedOnly)
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]Tag, len(counted))
+	for i, tag := range counted {
+		tags[i] = tag.Tag
+	}
+	return tags, nil
+}
+
+// Sort orders for TagQuery.
+const (
+	tagSortName  = "name"
+	tagSortCount = "count"
+)
+
+// ListTagsPage returns a page of the visible tags used by published posts,
+// with their post counts, and the number of tags matching before paging.
+// Stores implementing TagPager page in the database; for others, tags live
+// on their posts, so the page is cut after counting every tag.
+func (a *storeAdapter) ListTagsPage(ctx context.Context, q TagQuery) ([]TagWithCount, int, error) {
+	if pager, ok := a.store.(TagPager); ok {
+		return pager.FindTagCounts(ctx, q)
+	}
+	counted, err := a.listTagCounts(ctx, true)
+	if err != nil {
+		return nil, 0, err
+	}
+	prefix := strings.ToLower(strings.TrimSpace(q.Prefix))
+	matched := make([]TagWithCount, 0, len(counted))
+	for _, tag := range counted {
+		if tag.Hidden {
+			continue
+		}
+		if prefix != "" && !strings.HasPrefix(strings.ToLower(tag.Name), prefix) && !strings.HasPrefix(tag.Slug, prefix) {
+			continue
+		}
+		matched = append(matched, tag)
+	}
+	if q.Sort == tagSortCount {
+		sort.SliceStable(matched, func(i, j int) bool { return matched[i].Count > matched[j].Count })
+	}
+	total := len(matched)
+	start := min(max(q.Offset, 0), total)
+	end := total
+	if q.Limit > 0 {
+		end = min(start+q.Limit, total)
+	}
+	return matched[start:end], total, nil
+}
+
+// listTagCounts returns the tags of published posts, or of all posts when
+// publishedOnly is false, with their stored settings and the number of
+// published posts carrying them, sorted by name.
+func (a *storeAdapter) listTagCounts(ctx context.Context, publishedOnly bool) ([]TagWithCount, error) {
+	posts, err := a.listPosts(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := a.tagSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	bySlug := map[string]*TagWithCount{}
+	for _, post := range posts {
+		published := post.PublishedAt != nil && !postExpired(&post, now)
+		if publishedOnly && !published {
+			continue
+		}
+		for _, tag := range post.Tags {
+			slug := strings.ToLower(strings.TrimSpace(tag.Slug))
+			if slug == "" {
+				slug = tagSlug(tag.Name)
+			}
+			if slug == "" {
+				continue
+			}
+			counted, ok := bySlug[slug]
+			if !ok {
+				stored := settings[slug]
+				counted = &TagWithCount{Tag: Tag{ID: slug, Name: tag.Name, Slug: slug, Hidden: stored.Hidden, Description: stored.Description}}
+				bySlug[slug] = counted
+			}
+			if published {
+				counted.Count++
+			}
+		}
+	}
+
+	tags := make([]TagWithCount, 0, len(bySlug))
+	for _, tag := range bySlug {
+		tags = append(tags, *tag)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return strings.ToLower(tags[i].Name) < strings.ToLower(tags[j].Name)
+	})
+	return tags, nil
+}
+
+// tagSettings returns the stored per-tag settings by slug.
+func (a *storeAdapter) tagSettings(ctx context.Context) (map[string]tagAttrs, error) {
+	entities, err := a.fetchAllEntities(ctx, entityKindTag)
+	if err != nil {
+		return nil, err
+	}
+	settings := map[string]tagAttrs{}
+	for _, entity := range entities {
+		var attrs tagAttrs
+		if err := decodeAttrs(entity.Attrs, &attrs); err != nil {
+			return nil, err
+		}
+		settings[entity.Slug] = attrs
+	}
+	return settings, nil
+}
+
+// HiddenTagSlugs returns the set of tag slugs marked hidden.
+func (a *storeAdapter) HiddenTagSlugs(ctx context.Context) (map[string]bool, error) {
+	settings, err := a.tagSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hidden := map[string]bool{}
+	for slug, attrs := range settings {
+		if attrs.Hidden {
+			hidden[slug] = true
+		}
+	}
+	return hidden, nil
+}
+
+// GetTag returns the stored settings of the tag with the given slug, or nil
+// if it has none.
+func (a *storeAdapter) GetTag(ctx context.Context, slug string) (*Tag, error) {
+	entity, err := a.store.Get(ctx, tagEntityID(slug))
+	if err != nil || entity == nil || entity.Kind != entityKindTag {
+		return nil, err
+	}
+	var attrs tagAttrs
+	if err := decodeAttrs(entity.Attrs, &attrs); err != nil {
+		return nil, err
+	}
+	return &Tag{ID: entity.Slug, Name: attrs.Name, Slug: entity.Slug, Hidden: attrs.Hidden, Description: attrs.Description}, nil
+}
+
+// IsTagHidden reports whether the tag with the given slug is hidden.
+func (a *storeAdapter) IsTagHidden(ctx context.Context, slug string) (bool, error) {
+	tag, err := a.GetTag(ctx, slug)
+	if err != nil || tag == nil {
+		return false, err
+	}
+	return tag.Hidden, nil
+}
+
+// UpdateTag stores the per-tag settings for tag.Slug.
+func (a *storeAdapter) UpdateTag(ctx context.Context, tag Tag) error {
+	slug := strings.ToLower(strings.TrimSpace(tag.Slug))
+	if slug == "" {
+		return fmt.Errorf("tag slug required")
+	}
+	return a.store.Save(ctx, &Entity{
+		ID:   tagEntityID(slug),
+		Kind: entityKindTag,
+		Slug: slug,
+		Attrs: Attributes{
+			"name":        tag.Name,
+			"hidden":      tag.Hidden,
+			"description": tag.Description,
+		},
+	})
+}
+
+// MergeTags moves every post tagged with one of the source slugs onto
+// target and returns how many posts changed. Posts end up with target.Name,
+// and a post carrying several of the tags keeps one. The target's stored
+// settings are kept; if it has none it takes those of the first source that
+// does. When the store implements ChangeApplier, the posts, the target's
+// settings and the deletion of the sources' settings are applied in one
+// transaction, each post only if it hasn't changed since it was read; if
+// one has, the whole merge is read and applied again, so edits made
+// meanwhile are kept. Otherwise, when the store implements
+// ConditionalSaver, each post is saved that way on its own, and read again
+// and merged anew if it changed; other stores that implement BatchSaver
+// have the posts and the target's settings written in one batch. Without
+// ChangeApplier, the sources' settings are deleted afterwards.
+func (a *storeAdapter) MergeTags(ctx context.Context, sources []string, target Tag) (int, error) {
+	target.Slug = strings.ToLower(strings.TrimSpace(target.Slug))
+	if target.Slug == "" {
+		return 0, fmt.Errorf("tag slug required")
+	}
+	merged := map[string]bool{target.Slug: true}
+	for _, slug := range sources {
+		merged[strings.ToLower(strings.TrimSpace(slug))] = true
+	}
+
+	if applier, ok := a.store.(ChangeApplier); ok {
+		for attempt := 0; attempt < 10; attempt++ {
+			posts, err := a.mergeTagPosts(ctx, merged, target)
+			if err != nil {
+				return 0, err
+			}
+			settings, deleted, err := a.mergedTagSettings(ctx, sources, merged, target)
+			if err != nil {
+				return 0, err
+			}
+			changes := make([]EntityChange, 0, len(posts)+1+len(deleted))
+			for i := range posts {
+				read := posts[i].UpdatedAt
+				change := EntityChange{Save: entityFromPost(&posts[i])}
+				if read != nil {
+					expected := *read
+					updated := conditionalUpdatedAt(expected)
+					change.Save.UpdatedAt = &updated
+					change.Expected = &expected
+				}
+				changes = append(changes, change)
+			}
+			if settings != nil {
+				changes = append(changes, EntityChange{Save: settings})
+			}
+			for _, id := range deleted {
+				changes = append(changes, EntityChange{Delete: id})
+			}
+			applied, err := applier.ApplyChanges(ctx, changes)
+			if err != nil {
+				return 0, err
+			}
+			if applied {
+				return len(posts), nil
+			}
+		}
+		return 0, fmt.Errorf("merge tags into %s: too much contention", target.Slug)
+	}
+
+	posts, err := a.mergeTagPosts(ctx, merged, target)
+	if err != nil {
+		return 0, err
+	}
+	saver, conditional := a.store.(ConditionalSaver)
+	var changed []*Entity
+	postsChanged := 0
+	for i := range posts {
+		post := &posts[i]
+		if !conditional {
+			changed = append(changed, entityFromPost(post))
+			postsChanged++
+			continue
+		}
+		saved, err := a.saveMergedPostTags(ctx, saver, post, merged, target)
+		if err != nil {
+			return 0, err
+		}
+		if saved {
+			postsChanged++
+		}
+	}
+
+	settings, deleted, err := a.mergedTagSettings(ctx, sources, merged, target)
+	if err != nil {
+		return 0, err
+	}
+	if settings != nil {
+		changed = append(changed, settings)
+	}
+	if batch, ok := a.store.(BatchSaver); ok {
+		if err := batch.SaveBatch(ctx, changed); err != nil {
+			return 0, err
+		}
+	} else {
+		for _, entity := range changed {
+			if err := a.store.Save(ctx, entity); err != nil {
+				return 0, err
+			}
+		}
+	}
+	for _, id := range deleted {
+		if err := a.store.Delete(ctx, id); err != nil {
+			return 0, err
+		}
+	}
+	return postsChanged, nil
+}
+
+// mergeTagPosts returns the posts whose tags mergePostTags changed, with
+// the merged tags.
+func (a *storeAdapter) mergeTagPosts(ctx context.Context, merged map[string]bool, target Tag) ([]Post, error) {
+	entities, err := a.fetchAllEntities(ctx, entityKindPost)
+	if err != nil {
+		return nil, err
+	}
+	posts, err := entitiesToPosts(entities)
+	if err != nil {
+		return nil, err
+	}
+	var changed []Post
+	for i := range posts {
+		if mergePostTags(&posts[i], merged, target) {
+			changed = append(changed, posts[i])
+		}
+	}
+	return changed, nil
+}
+
+// mergedTagSettings returns the settings entity to save for the target of
+// a merge, or nil if neither it nor any source has settings, and the IDs of
+// the sources' settings entities to delete.
+func (a *storeAdapter) mergedTagSettings(ctx context.Context, sources []string, merged map[string]bool, target Tag) (*Entity, []string, error) {
+	settings, err := a.tagSettings(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	var deleted []string
+	for slug := range merged {
+		if _, has := settings[slug]; has && slug != target.Slug {
+			deleted = append(deleted, tagEntityID(slug))
+		}
+	}
+	sort.Strings(deleted)
+	stored, ok := settings[target.Slug]
+	for _, slug := range sources {
+		if ok {
+			break
+		}
+		stored, ok = settings[strings.ToLower(strings.TrimSpace(slug))]
+	}
+	if !ok {
+		return nil, deleted, nil
+	}
+	return &Entity{
+		ID:   tagEntityID(target.Slug),
+		Kind: entityKindTag,
+		Slug: target.Slug,
+		Attrs: Attributes{
+			"name":        target.Name,
+			"hidden":      stored.Hidden,
+			"description": stored.Description,
+		},
+	}, deleted, nil
+}
+
+// mergePostTags replaces the post's tags in merged with one target tag and
+// reports whether that changed them.
+func mergePostTags(post *Post, merged map[string]bool, target Tag) bool {
+	tags := make([]Tag, 0, len(post.Tags))
+	touched, added := false, false
+	for _, tag := range post.Tags {
+		slug := strings.ToLower(strings.TrimSpace(tag.Slug))
+		if slug == "" {
+			slug = tagSlug(tag.Name)
+		}
+		if !merged[slug] {
+			tags = append(tags, tag)
+			continue
+		}
+		if slug != target.Slug || tag.Name != target.Name {
+			touched = true
+		}
+		if !added {
+			tags = append(tags, Tag{ID: target.Slug, Name: target.Name, Slug: target.Slug})
+			added = true
+		}
+	}
+	if touched {
+		post.Tags = tags
+	}
+	return touched
+}
+
+// saveMergedPostTags saves a post whose tags mergePostTags changed, unless
+// it was saved since it was read. Then it reads the post again and merges
+// its tags anew, and reports false if they no longer need merging.
+func (a *storeAdapter) saveMergedPostTags(ctx context.Context, saver ConditionalSaver, post *Post, merged map[string]bool, target Tag) (bool, error) {
+	for attempt := 0; attempt < 10; attempt++ {
+		if attempt > 0 {
+			latest, err := a.GetPostByID(ctx, post.ID)
+			if err != nil {
+				return false, err
+			}
+			if latest == nil || !mergePostTags(latest, merged, target) {
+				return false, nil
+			}
+			post = latest
+		}
+		if post.UpdatedAt == nil {
+			return true, a.store.Save(ctx, entityFromPost(post))
+		}
+		expected := *post.UpdatedAt
+		entity := entityFromPost(post)
+		updated := conditionalUpdatedAt(expected)
+		entity.UpdatedAt = &updated
+		ok, err := saver.SaveIfUnchanged(ctx, entity, expected)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, fmt.Errorf("merge tags of post %s: too much contention", post.ID)
+}
+
+func tagEntityID(slug string) string {
+	return "tag-" + strings.ToLower(strings.TrimSpace(slug))
+}
+
 func (a *storeAdapter) LoadPostsTags(ctx context.Context, posts []Post) error {
 	for i := range posts {
 		if posts[i].Tags == nil {
@@ -473,6 +1514,13 @@ func (a *storeAdapter) LoadPostsTags(ctx context.Context, posts []Post) error {
 	return nil
 }
 
+// defaultRelatedPostsHalfLife is used when Config.RelatedPostsHalfLife is
+// unset.
+const defaultRelatedPostsHalfLife = 2 * 365 * 24 * time.Hour
+
+// GetRelatedPosts returns up to limit published posts sharing tags with
+// postID, best match first. Rare tags weigh more than common ones, and a
+// post's score halves every relatedHalfLife of age.
 func (a *storeAdapter) GetRelatedPosts(ctx context.Context, postID string, limit int) ([]Post, error) {
 	post, err := a.GetPostByID(ctx, postID)
 	if err != nil || post == nil {
@@ -482,29 +1530,56 @@ func (a *storeAdapter) GetRelatedPosts(ctx context.Context, postID string, limit
 		return []Post{}, nil
 	}
 
-	entities, err := a.fetchAllEntities(ctx, entityKindPost)
-	if err != nil {
-		return nil, err
-	}
-	posts, err := entitiesToPosts(entities)
+	posts, err := a.listPosts(ctx, false)
 	if err != nil {
 		return nil, err
 	}
 
+	// Each shared tag counts 1 + ln(N/df), where N is the number of
+	// published posts and df the number carrying the tag, so a rare tag in
+	// common says more than one on every post.
+	now := time.Now()
+	published := make([]Post, 0, len(posts))
+	tagFreq := map[string]int{}
+	for _, candidate := range posts {
+		if candidate.PublishedAt == nil || postExpired(&candidate, now) {
+			continue
+		}
+		published = append(published, candidate)
+		for slug := range tagSlugSet(candidate.Tags) {
+			tagFreq[slug]++
+		}
+	}
 	targetTags := tagSlugSet(post.Tags)
+	weights := make(map[string]float64, len(targetTags))
+	for slug := range targetTags {
+		df := max(tagFreq[slug], 1)
+		weights[slug] = 1 + math.Log(float64(max(len(published), df))/float64(df))
+	}
+
+	halfLife := a.relatedHalfLife
+	if halfLife == 0 {
+		halfLife = defaultRelatedPostsHalfLife
+	}
 	type scored struct {
 		post  Post
-		score int
+		score float64
 	}
 	var scoredPosts []scored
-	for _, candidate := range posts {
-		if candidate.ID == postID || candidate.PublishedAt == nil {
+	for _, candidate := range published {
+		if candidate.ID == postID {
 			continue
 		}
-		score := countSharedTags(targetTags, candidate.Tags)
+		score := 0.0
+		for slug := range tagSlugSet(candidate.Tags) {
+			score += weights[slug]
+		}
 		if score == 0 {
 			continue
 		}
+		if age := now.Sub(*candidate.PublishedAt); halfLife > 0 && age > 0 {
+			score *= math.Exp2(-float64(age) / float64(halfLife))
+		}
 		scoredPosts = append(scoredPosts, scored{post: candidate, score: score})
 	}
 
@@ -561,6 +1636,9 @@ func (a *storeAdapter) UpdateBlogSettings(ctx context.Context, settings *BlogSet
 	attrs["title"] = resolved.Title
 	attrs["description"] = resolved.Description
 	attrs["google_analytics_code"] = resolved.GoogleAnalyticsCode
+	attrs["moderation_mode"] = resolved.ModerationMode
+	attrs["sync_spam_check"] = resolved.SyncSpamCheck
+	attrs["close_comments_after_days"] = resolved.CloseCommentsAfterDays
 	entity.Attrs = attrs
 	return a.store.Save(ctx, entity)
 }
@@ -636,13 +1714,47 @@ func (a *storeAdapter) UpdateVAPIDSettings(ctx context.Context, publicKey, priva
 	return a.store.Save(ctx, entity)
 }
 
+// GetNotificationDigestMarker returns when the last comment digest was sent,
+// or the zero time if none has been.
+func (a *storeAdapter) GetNotificationDigestMarker(ctx context.Context) (time.Time, error) {
+	entity, err := a.store.Get(ctx, entityIDBlogSettings)
+	if err != nil || entity == nil || entity.Attrs == nil {
+		return time.Time{}, err
+	}
+	raw, _ := entity.Attrs[attrNotificationDigestAt].(string)
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	marker, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		return time.Time{}, nil
+	}
+	return marker, nil
+}
+
+func (a *storeAdapter) UpdateNotificationDigestMarker(ctx context.Context, marker time.Time) error {
+	entity, err := a.getOrCreateBlogSettingsEntity(ctx)
+	if err != nil {
+		return err
+	}
+	attrs := cloneAttributes(entity.Attrs)
+	if attrs == nil {
+		attrs = Attributes{}
+	}
+	attrs[attrNotificationDigestAt] = marker.UTC().Format(time.RFC3339Nano)
+	entity.Attrs = attrs
+	return a.store.Save(ctx, entity)
+}
+
 type AdminPushSubscription struct {
-	ID               string
-	Endpoint         string
-	SubscriptionJSON string
+	ID               string    `json:"id"`
+	Endpoint         string    `json:"endpoint"`
+	SubscriptionJSON string    `json:"-"`
+	UserAgent        string    `json:"user_agent"`
+	CreatedAt        time.Time `json:"created_at"`
 }
 
-func (a *storeAdapter) UpsertAdminPushSubscription(ctx context.Context, endpoint, subscriptionJSON string) error {
+func (a *storeAdapter) UpsertAdminPushSubscription(ctx context.Context, endpoint, subscriptionJSON, userAgent string) error {
 	endpoint = strings.TrimSpace(endpoint)
 	if endpoint == "" {
 		return fmt.Errorf("subscription endpoint required")
@@ -660,6 +1772,7 @@ func (a *storeAdapter) UpsertAdminPushSubscription(ctx context.Context, endpoint
 		Attrs: Attributes{
 			"endpoint":          endpoint,
 			"subscription_json": subscriptionJSON,
+			"user_agent":        strings.TrimSpace(userAgent),
 		},
 	}
 	return a.store.Save(ctx, entity)
@@ -688,10 +1801,13 @@ func (a *storeAdapter) ListAdminPushSubscriptions(ctx context.Context) ([]AdminP
 		if endpoint == "" || subscription == "" {
 			continue
 		}
+		userAgent, _ := entity.Attrs["user_agent"].(string)
 		out = append(out, AdminPushSubscription{
 			ID:               entity.ID,
 			Endpoint:         endpoint,
 			SubscriptionJSON: subscription,
+			UserAgent:        userAgent,
+			CreatedAt:        entity.CreatedAt,
 		})
 	}
 	return out, nil
@@ -706,6 +1822,21 @@ func (a *storeAdapter) DeleteAdminPushSubscriptionByEndpoint(ctx context.Context
 	return a.store.Delete(ctx, entityID)
 }
 
+// DeleteAllAdminPushSubscriptions removes every admin push subscription and
+// returns how many were deleted.
+func (a *storeAdapter) DeleteAllAdminPushSubscriptions(ctx context.Context) (int, error) {
+	entities, err := a.fetchAllEntities(ctx, entityKindPushSub)
+	if err != nil {
+		return 0, err
+	}
+	for _, entity := range entities {
+		if err := a.store.Delete(ctx, entity.ID); err != nil {
+			return 0, err
+		}
+	}
+	return len(entities), nil
+}
+
 func (a *storeAdapter) CreateComment(ctx context.Context, c *Comment) error {
 	if c == nil {
 		return fmt.Errorf("comment required")
@@ -723,6 +1854,35 @@ func (a *storeAdapter) CreateComment(ctx context.Context, c *Comment) error {
 	return a.store.Save(ctx, entity)
 }
 
+// CreateComments saves comments in order, filling in IDs, timestamps and
+// statuses like CreateComment. Parents must come before their replies. When
+// the store implements BatchSaver, all comments are written in one batch.
+func (a *storeAdapter) CreateComments(ctx context.Context, comments []Comment) error {
+	entities := make([]*Entity, 0, len(comments))
+	for i := range comments {
+		c := &comments[i]
+		if c.ID == "" {
+			c.ID = generateID()
+		}
+		if c.CreatedAt.IsZero() {
+			c.CreatedAt = time.Now().UTC()
+		}
+		if strings.TrimSpace(c.Status) == "" {
+			c.Status = "approved"
+		}
+		entities = append(entities, entityFromComment(c))
+	}
+	if batch, ok := a.store.(BatchSaver); ok {
+		return batch.SaveBatch(ctx, entities)
+	}
+	for _, entity := range entities {
+		if err := a.store.Save(ctx, entity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (a *storeAdapter) GetCommentByID(ctx context.Context, id string) (*Comment, error) {
 	entity, err := a.store.Get(ctx, id)
 	if err != nil || entity == nil {
@@ -760,7 +1920,108 @@ func (a *storeAdapter) ListCommentsByPost(ctx context.Context, postID string) ([
 	return entitiesToComments(all)
 }
 
-func (a *storeAdapter) UpdateCommentContentByOwner(ctx context.Context, id, ownerTokenHash, content string) (bool, error) {
+// ListCommentsByPostPaged returns one page of the post's approved top-level
+// comments, newest first, followed by every reply beneath them. The total is
+// the number of approved top-level comments. When ownerTokenHash is set and
+// offset is 0, that commenter's pending top-level comments are included too.
+func (a *storeAdapter) ListCommentsByPostPaged(ctx context.Context, postID, ownerTokenHash string, limit, offset int) ([]Comment, int, error) {
+	rootFilter := map[string]interface{}{
+		"owner_id":  postID,
+		"parent_id": nil,
+		"status":    "approved",
+	}
+	roots, err := a.store.Find(ctx, Query{
+		Kind:    entityKindComment,
+		Filter:  rootFilter,
+		Limit:   limit,
+		Offset:  offset,
+		OrderBy: "created_at DESC",
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := a.count(ctx, Query{Kind: entityKindComment, Filter: rootFilter})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if ownerTokenHash != "" && offset == 0 {
+		pending, err := a.findAll(ctx, Query{
+			Kind: entityKindComment,
+			Filter: map[string]interface{}{
+				"owner_id":         postID,
+				"parent_id":        nil,
+				"status":           "pending",
+				"owner_token_hash": ownerTokenHash,
+			},
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		roots = append(pending, roots...)
+	}
+
+	// Replies are read for the whole post at once, grouped by parent, and
+	// the threads of the page's roots walked down one level at a time.
+	postComments, err := a.findAll(ctx, Query{
+		Kind:    entityKindComment,
+		Filter:  map[string]interface{}{"owner_id": postID},
+		OrderBy: "created_at ASC",
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	children := map[string][]*Entity{}
+	for _, c := range postComments {
+		if c.ParentID != "" {
+			children[c.ParentID] = append(children[c.ParentID], c)
+		}
+	}
+	entities := roots
+	frontier := roots
+	seen := map[string]bool{}
+	for len(frontier) > 0 {
+		var next []*Entity
+		for _, parent := range frontier {
+			for _, child := range children[parent.ID] {
+				if !seen[child.ID] {
+					seen[child.ID] = true
+					next = append(next, child)
+				}
+			}
+		}
+		entities = append(entities, next...)
+		frontier = next
+	}
+
+	comments, err := entitiesToComments(entities)
+	if err != nil {
+		return nil, 0, err
+	}
+	return comments, total, nil
+}
+
+// HasCommentsByOwner reports whether any comment carries the owner token hash.
+func (a *storeAdapter) HasCommentsByOwner(ctx context.Context, ownerTokenHash string) (bool, error) {
+	entities, err := a.store.Find(ctx, Query{
+		Kind:   entityKindComment,
+		Filter: map[string]interface{}{"owner_token_hash": ownerTokenHash},
+		Limit:  1,
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(entities) > 0, nil
+}
+
+// errCommentEditWindowClosed is returned when an owner tries to edit a
+// comment after the edit window has passed.
+var errCommentEditWindowClosed = errors.New("comments can only be edited shortly after they are posted")
+
+// UpdateCommentContentByOwner replaces the content of a comment if
+// ownerTokenHash owns it. With a positive window, comments older than
+// window are refused with errCommentEditWindowClosed.
+func (a *storeAdapter) UpdateCommentContentByOwner(ctx context.Context, id, ownerTokenHash, content string, window time.Duration) (bool, error) {
 	comment, err := a.GetCommentByID(ctx, id)
 	if err != nil || comment == nil {
 		return false, err
@@ -769,6 +2030,9 @@ func (a *storeAdapter) UpdateCommentContentByOwner(ctx context.Context, id, owne
 		return false, nil
 	}
 	now := time.Now().UTC()
+	if window > 0 && now.Sub(comment.CreatedAt) > window {
+		return false, errCommentEditWindowClosed
+	}
 	comment.Content = content
 	comment.UpdatedAt = &now
 	entity := entityFromComment(comment)
@@ -783,7 +2047,7 @@ func (a *storeAdapter) DeleteCommentByOwner(ctx context.Context, id, ownerTokenH
 	if comment.OwnerTokenHash != ownerTokenHash {
 		return false, nil
 	}
-	return true, a.store.Delete(ctx, id)
+	return true, a.DeleteCommentByID(ctx, id)
 }
 
 func (a *storeAdapter) UpdateCommentStatus(ctx context.Context, id, status string, spamReason *string) error {
@@ -844,10 +2108,193 @@ func (a *storeAdapter) ListCommentsForModeration(ctx context.Context, status str
 	return out, nil
 }
 
+// DeleteCommentByID deletes a comment and its reactions.
 func (a *storeAdapter) DeleteCommentByID(ctx context.Context, id string) error {
+	if err := a.store.Delete(ctx, id); err != nil {
+		return err
+	}
+	reactions, err := a.store.Find(ctx, Query{
+		Kind:   entityKindReaction,
+		Filter: map[string]interface{}{"owner_id": id},
+	})
+	if err != nil {
+		return err
+	}
+	for _, reaction := range reactions {
+		if err := a.store.Delete(ctx, reaction.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// commentReactionEntityID keys a reaction by comment and reader, so each
+// reader has at most one reaction per comment.
+func commentReactionEntityID(commentID, ownerTokenHash string) string {
+	return "reaction-" + commentID + "-" + ownerTokenHash
+}
+
+// ToggleCommentReaction adds the reader's reaction to a comment, or removes
+// it if they had already reacted. It reports whether the reader now reacts
+// and the comment's new reaction count.
+func (a *storeAdapter) ToggleCommentReaction(ctx context.Context, c *Comment, ownerTokenHash string) (bool, int, error) {
+	id := commentReactionEntityID(c.ID, ownerTokenHash)
+	existing, err := a.store.Get(ctx, id)
+	if err != nil {
+		return false, 0, err
+	}
+	reacted := existing == nil
+	if reacted {
+		err = a.store.Save(ctx, &Entity{
+			ID:        id,
+			Kind:      entityKindReaction,
+			OwnerID:   c.ID,
+			ParentID:  c.PostID,
+			CreatedAt: time.Now().UTC(),
+			Attrs:     Attributes{"owner_token_hash": ownerTokenHash},
+		})
+	} else {
+		err = a.store.Delete(ctx, id)
+	}
+	if err != nil {
+		return false, 0, err
+	}
+	reactions, err := a.store.Find(ctx, Query{
+		Kind:   entityKindReaction,
+		Filter: map[string]interface{}{"owner_id": c.ID},
+	})
+	if err != nil {
+		return false, 0, err
+	}
+	return reacted, len(reactions), nil
+}
+
+// ListCommentReactions returns the reactions on a post's comments by
+// comment ID, flagging the ones ownerTokenHash made.
+func (a *storeAdapter) ListCommentReactions(ctx context.Context, postID, ownerTokenHash string) (map[string]commentReactions, error) {
+	entities, err := a.store.Find(ctx, Query{
+		Kind:   entityKindReaction,
+		Filter: map[string]interface{}{"parent_id": postID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]commentReactions{}
+	for _, entity := range entities {
+		r := out[entity.OwnerID]
+		r.Count++
+		if ownerTokenHash != "" && entity.ID == commentReactionEntityID(entity.OwnerID, ownerTokenHash) {
+			r.Reacted = true
+		}
+		out[entity.OwnerID] = r
+	}
+	return out, nil
+}
+
+// webmentionEntityID keys a webmention by source and target, so a sender
+// repeating one updates it rather than adding another.
+func webmentionEntityID(source, target string) string {
+	sum := sha256.Sum256([]byte(source + "\n" + target))
+	return "webmention-" + hex.EncodeToString(sum[:16])
+}
+
+type webmentionAttrs struct {
+	Source     string     `json:"source"`
+	Target     string     `json:"target"`
+	Title      string     `json:"title,omitempty"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+}
+
+func entityToWebmention(e *Entity) (*Webmention, error) {
+	var attrs webmentionAttrs
+	if err := decodeAttrs(e.Attrs, &attrs); err != nil {
+		return nil, err
+	}
+	return &Webmention{
+		ID:         e.ID,
+		PostID:     e.OwnerID,
+		Source:     attrs.Source,
+		Target:     attrs.Target,
+		Status:     e.Status,
+		Title:      attrs.Title,
+		CreatedAt:  e.CreatedAt,
+		VerifiedAt: attrs.VerifiedAt,
+	}, nil
+}
+
+// SaveWebmention creates or replaces a webmention.
+func (a *storeAdapter) SaveWebmention(ctx context.Context, m *Webmention) error {
+	return a.store.Save(ctx, &Entity{
+		ID:        m.ID,
+		Kind:      entityKindMention,
+		Status:    m.Status,
+		OwnerID:   m.PostID,
+		CreatedAt: m.CreatedAt,
+		Attrs: Attributes{
+			"source":      m.Source,
+			"target":      m.Target,
+			"title":       m.Title,
+			"verified_at": m.VerifiedAt,
+		},
+	})
+}
+
+// GetWebmention returns the webmention with the given ID, or nil.
+func (a *storeAdapter) GetWebmention(ctx context.Context, id string) (*Webmention, error) {
+	entity, err := a.store.Get(ctx, id)
+	if err != nil || entity == nil || entity.Kind != entityKindMention {
+		return nil, err
+	}
+	return entityToWebmention(entity)
+}
+
+// ListWebmentions returns webmentions, newest first. An empty postID or
+// status matches any.
+func (a *storeAdapter) ListWebmentions(ctx context.Context, postID, status string) ([]Webmention, error) {
+	filter := map[string]interface{}{}
+	if postID != "" {
+		filter["owner_id"] = postID
+	}
+	if status != "" {
+		filter["status"] = status
+	}
+	entities, err := a.store.Find(ctx, Query{
+		Kind:    entityKindMention,
+		Filter:  filter,
+		OrderBy: "created_at DESC",
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Webmention, 0, len(entities))
+	for _, entity := range entities {
+		m, err := entityToWebmention(entity)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *m)
+	}
+	return out, nil
+}
+
+// DeleteWebmention removes a webmention.
+func (a *storeAdapter) DeleteWebmention(ctx context.Context, id string) error {
 	return a.store.Delete(ctx, id)
 }
 
+func (a *storeAdapter) deletePostWebmentions(ctx context.Context, postID string) error {
+	mentions, err := a.ListWebmentions(ctx, postID, "")
+	if err != nil {
+		return err
+	}
+	for _, m := range mentions {
+		if err := a.store.Delete(ctx, m.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (a *storeAdapter) CreateTask(ctx context.Context, task *Task) error {
 	if task == nil {
 		return fmt.Errorf("task required")
@@ -891,16 +2338,31 @@ func (a *storeAdapter) ListPendingTasks(ctx context.Context) ([]Task, error) {
 		Filter: map[string]interface{}{
 			"status": TaskStatusPending,
 		},
-		Limit:   50,
 		OrderBy: "created_at ASC",
 	}
-	entities, err := a.store.Find(ctx, q)
+	entities, err := a.findAll(ctx, q)
 	if err != nil {
 		return nil, err
 	}
 	return entitiesToTasks(entities)
 }
 
+// HasDueTaskOfType reports whether a pending task of the given type is due
+// to run now. Tasks waiting for a retry later don't count.
+func (a *storeAdapter) HasDueTaskOfType(ctx context.Context, taskType string) (bool, error) {
+	tasks, err := a.ListPendingTasks(ctx)
+	if err != nil {
+		return false, err
+	}
+	now := time.Now()
+	for _, t := range tasks {
+		if t.TaskType == taskType && (t.RunAfter == nil || !t.RunAfter.After(now)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (a *storeAdapter) ListRecentTasks(ctx context.Context, limit int) ([]Task, error) {
 	q := Query{
 		Kind:    entityKindTask,
@@ -931,6 +2393,74 @@ func (a *storeAdapter) UpdateTask(ctx context.Context, task *Task) error {
 	return a.store.Save(ctx, entity)
 }
 
+// UpdateTaskIfStatus saves task only if the stored task's status is still
+// status, and reports whether it did, so a runner never overwrites a cancel
+// recorded meanwhile by this or another instance. Stores that are not a
+// ConditionalSaver are checked under claimMu, as in TransitionTask.
+func (a *storeAdapter) UpdateTaskIfStatus(ctx context.Context, task *Task, status string) (bool, error) {
+	if task == nil || task.ID == "" {
+		return false, fmt.Errorf("task id required")
+	}
+	saver, ok := a.store.(ConditionalSaver)
+	if !ok {
+		a.claimMu.Lock()
+		defer a.claimMu.Unlock()
+		stored, err := a.GetTask(ctx, task.ID)
+		if err != nil || stored == nil || stored.Status != status {
+			return false, err
+		}
+		task.UpdatedAt = time.Now().UTC()
+		return true, a.UpdateTask(ctx, task)
+	}
+	for attempt := 0; attempt < 10; attempt++ {
+		stored, err := a.GetTask(ctx, task.ID)
+		if err != nil || stored == nil || stored.Status != status {
+			return false, err
+		}
+		expected := stored.UpdatedAt
+		task.UpdatedAt = conditionalUpdatedAt(expected)
+		if task.CreatedAt.IsZero() {
+			task.CreatedAt = stored.CreatedAt
+		}
+		ok, err := saver.SaveIfUnchanged(ctx, entityFromTask(task), expected)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, fmt.Errorf("update task %s: too much contention", task.ID)
+}
+
+// ClaimTask moves a pending task to running and returns it, or returns nil
+// if the task is no longer pending because another worker claimed it.
+func (a *storeAdapter) ClaimTask(ctx context.Context, id string) (*Task, error) {
+	return a.TransitionTask(ctx, id, TaskStatusPending, TaskStatusRunning)
+}
+
+// TransitionTask changes a task's status from `from` to `to` and returns
+// the updated task, or returns nil if its status was no longer `from`.
+func (a *storeAdapter) TransitionTask(ctx context.Context, id, from, to string) (*Task, error) {
+	if claimer, ok := a.store.(StatusClaimer); ok {
+		claimed, err := claimer.ClaimStatus(ctx, id, from, to)
+		if err != nil || !claimed {
+			return nil, err
+		}
+		return a.GetTask(ctx, id)
+	}
+
+	a.claimMu.Lock()
+	defer a.claimMu.Unlock()
+	task, err := a.GetTask(ctx, id)
+	if err != nil || task == nil || task.Status != from {
+		return nil, err
+	}
+	task.Status = to
+	task.UpdatedAt = time.Now().UTC()
+	if err := a.UpdateTask(ctx, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
 func (a *storeAdapter) ResetRunningTasks(ctx context.Context) error {
 	q := Query{
 		Kind: entityKindTask,
@@ -957,6 +2487,46 @@ func (a *storeAdapter) ResetRunningTasks(ctx context.Context) error {
 	return nil
 }
 
+// findAll pages through every entity matching q, ignoring q.Limit and
+// q.Offset.
+func (a *storeAdapter) findAll(ctx context.Context, q Query) ([]*Entity, error) {
+	var out []*Entity
+	q.Limit, q.Offset = 200, 0
+	for {
+		entities, err := a.store.Find(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, entities...)
+		if len(entities) < q.Limit {
+			break
+		}
+		q.Offset += len(entities)
+	}
+	return out, nil
+}
+
+// count returns the number of entities matching q, in the store when it
+// implements Counter and otherwise by paging through them.
+func (a *storeAdapter) count(ctx context.Context, q Query) (int, error) {
+	if counter, ok := a.store.(Counter); ok {
+		return counter.Count(ctx, q)
+	}
+	n := 0
+	q.Limit, q.Offset = 200, 0
+	for {
+		entities, err := a.store.Find(ctx, q)
+		if err != nil {
+			return 0, err
+		}
+		n += len(entities)
+		if len(entities) < q.Limit {
+			return n, nil
+		}
+		q.Offset += len(entities)
+	}
+}
+
 func (a *storeAdapter) fetchAllEntities(ctx context.Context, kind string) ([]*Entity, error) {
 	var out []*Entity
 	offset := 0
@@ -1017,9 +2587,22 @@ func entitiesToTasks(entities []*Entity) ([]Task, error) {
 	return tasks, nil
 }
 
+// collectPublishedPosts pages through published, unexpired posts, newest
+// first, keeping those that filterFn accepts.
 func (a *storeAdapter) collectPublishedPosts(ctx context.Context, limit, offset int, filterFn func(Post) bool) ([]Post, error) {
+	return a.collectPublishedPostsAfter(ctx, nil, limit, offset, filterFn)
+}
+
+// collectPublishedPostsAfter is collectPublishedPosts starting after the
+// cursor, when there is one. Stores implementing CursorFinder resume each
+// batch where the last one ended; others are read from the newest post.
+func (a *storeAdapter) collectPublishedPostsAfter(ctx context.Context, after *postCursor, limit, offset int, filterFn func(Post) bool) ([]Post, error) {
+	finder, ok := a.store.(CursorFinder)
+	seek := ok && finder.FindsByCursor()
+	now := time.Now()
 	var out []Post
 	totalOffset := offset
+	resume := after
 	page := 0
 	for {
 		q := Query{
@@ -1028,9 +2611,14 @@ func (a *storeAdapter) collectPublishedPosts(ctx context.Context, limit, offset
 				"status": "published",
 			},
 			Limit:   100,
-			Offset:  page * 100,
 			OrderBy: "published_at DESC",
 		}
+		if seek && resume != nil {
+			q.PublishedBefore = &resume.PublishedAt
+			q.AfterID = resume.ID
+		} else if !seek {
+			q.Offset = page * 100
+		}
 		entities, err := a.store.Find(ctx, q)
 		if err != nil {
 			return nil, err
@@ -1043,7 +2631,10 @@ func (a *storeAdapter) collectPublishedPosts(ctx context.Context, limit, offset
 			return nil, err
 		}
 		for _, post := range posts {
-			if !filterFn(post) {
+			if !seek && after != nil && !after.precedes(post) {
+				continue
+			}
+			if postExpired(&post, now) || !filterFn(post) {
 				continue
 			}
 			if totalOffset > 0 {
@@ -1055,6 +2646,11 @@ func (a *storeAdapter) collectPublishedPosts(ctx context.Context, limit, offset
 				return out, nil
 			}
 		}
+		if len(entities) < 100 {
+			break
+		}
+		last := cursorAfter(posts[len(posts)-1])
+		resume = &last
 		page++
 	}
 	return out, nil
@@ -1146,26 +2742,181 @@ func tagSlugSet(tags []Tag) map[string]bool {
 	return set
 }
 
-func countSharedTags(target map[string]bool, tags []Tag) int {
-	count := 0
-	for _, tag := range tags {
-		slug := strings.TrimSpace(tag.Slug)
-		if slug == "" {
-			slug = tagSlug(tag.Name)
+func publishedAtOrZero(post Post) time.Time {
+	if post.PublishedAt != nil {
+		return post.PublishedAt.UTC()
+	}
+	return time.Time{}
+}
+
+func authorEntityID(id int) string {
+	return "author-" + strconv.Itoa(id)
+}
+
+func entityToAuthor(e *Entity) (*Author, error) {
+	var attrs authorAttrs
+	if err := decodeAttrs(e.Attrs, &attrs); err != nil {
+		return nil, err
+	}
+	id, err := strconv.Atoi(strings.TrimPrefix(e.ID, "author-"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid author id %q", e.ID)
+	}
+	return &Author{
+		ID:        id,
+		Name:      attrs.Name,
+		Email:     attrs.Email,
+		AvatarURL: attrs.AvatarURL,
+		Bio:       attrs.Bio,
+		Links:     attrs.Links,
+	}, nil
+}
+
+// GetAuthor returns the author with the given ID, or nil if there is none.
+func (a *storeAdapter) GetAuthor(ctx context.Context, id int) (*Author, error) {
+	entity, err := a.store.Get(ctx, authorEntityID(id))
+	if err != nil || entity == nil || entity.Kind != entityKindAuthor {
+		return nil, err
+	}
+	return entityToAuthor(entity)
+}
+
+// ListAuthors returns every author, ordered by ID.
+func (a *storeAdapter) ListAuthors(ctx context.Context) ([]Author, error) {
+	entities, err := a.fetchAllEntities(ctx, entityKindAuthor)
+	if err != nil {
+		return nil, err
+	}
+	authors := make([]Author, 0, len(entities))
+	for _, entity := range entities {
+		author, err := entityToAuthor(entity)
+		if err != nil {
+			return nil, err
 		}
-		if slug == "" {
-			continue
+		authors = append(authors, *author)
+	}
+	sort.Slice(authors, func(i, j int) bool { return authors[i].ID < authors[j].ID })
+	return authors, nil
+}
+
+// UpsertAuthor creates or replaces the author with author.ID.
+func (a *storeAdapter) UpsertAuthor(ctx context.Context, author *Author) error {
+	if author == nil {
+		return fmt.Errorf("author required")
+	}
+	return a.store.Save(ctx, &Entity{
+		ID:   authorEntityID(author.ID),
+		Kind: entityKindAuthor,
+		Attrs: Attributes{
+			"name":       author.Name,
+			"email":      author.Email,
+			"avatar_url": author.AvatarURL,
+			"bio":        author.Bio,
+			"links":      author.Links,
+		},
+	})
+}
+
+func (a *storeAdapter) DeleteAuthor(ctx context.Context, id int) error {
+	return a.store.Delete(ctx, authorEntityID(id))
+}
+
+// NextAuthorID allocates an ID above every existing author and every ID
+// allocated before, so a deleted author's ID is never handed out again.
+// The last allocated ID is kept in a sequence entity. Stores with
+// ConditionalSaver update it only if no one else has since, so app
+// instances sharing the store never allocate the same ID; other stores
+// serialize allocation within the process.
+func (a *storeAdapter) NextAuthorID(ctx context.Context) (int, error) {
+	saver, conditional := a.store.(ConditionalSaver)
+	if !conditional {
+		a.claimMu.Lock()
+		defer a.claimMu.Unlock()
+	}
+	for attempt := 0; attempt < 10; attempt++ {
+		entity, err := a.store.Get(ctx, entityIDAuthorSequence)
+		if err != nil {
+			return 0, err
+		}
+		last := 0
+		if entity != nil {
+			n, _ := entity.Attrs["last"].(float64)
+			last = int(n)
+		}
+		authors, err := a.ListAuthors(ctx)
+		if err != nil {
+			return 0, err
 		}
-		if target[strings.ToLower(slug)] {
-			count++
+		if len(authors) > 0 && authors[len(authors)-1].ID > last {
+			last = authors[len(authors)-1].ID
+		}
+		id := last + 1
+		if !conditional {
+			return id, a.store.Save(ctx, &Entity{ID: entityIDAuthorSequence, Kind: entityKindSetting, Attrs: Attributes{"last": id}})
+		}
+		if entity == nil || entity.UpdatedAt == nil {
+			// Every instance creates the sequence with the same updated_at,
+			// so only one of their conditional saves below succeeds.
+			created := time.Unix(0, 0).UTC()
+			entity = &Entity{ID: entityIDAuthorSequence, Kind: entityKindSetting, Attrs: Attributes{"last": last}, UpdatedAt: &created}
+			if err := a.store.Save(ctx, entity); err != nil {
+				return 0, err
+			}
+		}
+		expected := *entity.UpdatedAt
+		updated := conditionalUpdatedAt(expected)
+		ok, err := saver.SaveIfUnchanged(ctx, &Entity{ID: entityIDAuthorSequence, Kind: entityKindSetting, Attrs: Attributes{"last": id}, UpdatedAt: &updated}, expected)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			return id, nil
 		}
 	}
-	return count
+	return 0, fmt.Errorf("allocate author id: too much contention")
 }
 
-func publishedAtOrZero(post Post) time.Time {
-	if post.PublishedAt != nil {
-		return post.PublishedAt.UTC()
+// conditionalUpdatedAt returns the updated_at for a conditional save over
+// an entity last updated at expected: now, at the microsecond precision
+// stores keep, but always after expected so the next save sees a change.
+func conditionalUpdatedAt(expected time.Time) time.Time {
+	updated := time.Now().UTC().Truncate(time.Microsecond)
+	if !updated.After(expected) {
+		updated = expected.Add(time.Microsecond)
 	}
-	return time.Time{}
+	return updated
+}
+
+func aiChatEntityID(postID string) string {
+	return "ai-chat-" + postID
+}
+
+// GetAIChatHistory returns the stored AI chat turns for a post, oldest
+// first.
+func (a *storeAdapter) GetAIChatHistory(ctx context.Context, postID string) ([]AIChatTurn, error) {
+	entity, err := a.store.Get(ctx, aiChatEntityID(postID))
+	if err != nil || entity == nil || entity.Kind != entityKindAIChat {
+		return nil, err
+	}
+	var attrs struct {
+		Turns []AIChatTurn `json:"turns"`
+	}
+	if err := decodeAttrs(entity.Attrs, &attrs); err != nil {
+		return nil, err
+	}
+	return attrs.Turns, nil
+}
+
+// SaveAIChatHistory replaces the AI chat turns stored for a post.
+func (a *storeAdapter) SaveAIChatHistory(ctx context.Context, postID string, turns []AIChatTurn) error {
+	return a.store.Save(ctx, &Entity{
+		ID:      aiChatEntityID(postID),
+		Kind:    entityKindAIChat,
+		OwnerID: postID,
+		Attrs:   Attributes{"turns": turns},
+	})
+}
+
+func (a *storeAdapter) DeleteAIChatHistory(ctx context.Context, postID string) error {
+	return a.store.Delete(ctx, aiChatEntityID(postID))
 }
@@ -0,0 +1,253 @@
+package blog
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// defaultThinArchiveThreshold is the post count below which a tag page is
+// kept out of search indexes.
+const defaultThinArchiveThreshold = 2
+
+// isThinArchive reports whether a tag page with count published posts is
+// too thin to index.
+func (s *service) isThinArchive(count int) bool {
+	threshold := s.cfg.ThinArchiveThreshold
+	if threshold == 0 {
+		threshold = defaultThinArchiveThreshold
+	}
+	return count < threshold
+}
+
+// handleAdminListTags returns every tag in use, including hidden ones, with
+// its published post count.
+func (s *service) handleAdminListTags(w http.ResponseWriter, r *http.Request) {
+	tags, err := s.store.ListTagsForAdmin(r.Context())
+	if err != nil {
+		http.Error(w, "failed to list tags", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, tags)
+}
+
+// handleAdminUpdateTag changes the per-tag settings: Hidden and
+// Description. Fields left out of the payload keep their values; tag names
+// come from the posts that carry them.
+func (s *service) handleAdminUpdateTag(w http.ResponseWriter, r *http.Request) {
+	slug := strings.ToLower(strings.TrimSpace(chi.URLParam(r, "slug")))
+	var payload struct {
+		Hidden      *bool   `json:"hidden"`
+		Description *string `json:"description"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "invalid json", http.StatusBadRequest)
+		return
+	}
+
+	tags, err := s.store.ListTagsForAdmin(r.Context())
+	if err != nil {
+		http.Error(w, "failed to load tags", http.StatusInternalServerError)
+		return
+	}
+	tag := findAdminTag(tags, slug)
+	if tag == nil {
+		http.Error(w, "tag not found", http.StatusNotFound)
+		return
+	}
+
+	if payload.Hidden != nil {
+		tag.Hidden = *payload.Hidden
+	}
+	if payload.Description != nil {
+		tag.Description = strings.TrimSpace(*payload.Description)
+	}
+	if err := s.store.UpdateTag(r.Context(), tag.Tag); err != nil {
+		http.Error(w, "failed to update tag", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, tag)
+}
+
+type tagMergeResponse struct {
+	Tag           Tag  `json:"tag"`
+	PostsAffected int  `json:"posts_affected"`
+	Merged        bool `json:"merged"`
+}
+
+// findAdminTag returns the tag with the given slug from tags, or nil.
+func findAdminTag(tags []TagWithCount, slug string) *TagWithCount {
+	for i := range tags {
+		if tags[i].Slug == slug {
+			return &tags[i]
+		}
+	}
+	return nil
+}
+
+// handleAdminRenameTag gives a tag a new name, and with it the slug derived
+// from the name, on every post that carries it. Renaming onto a tag that
+// already exists merges the two.
+func (s *service) handleAdminRenameTag(w http.ResponseWriter, r *http.Request) {
+	slug := strings.ToLower(strings.TrimSpace(chi.URLParam(r, "slug")))
+	var payload struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "invalid json", http.StatusBadRequest)
+		return
+	}
+	name := strings.TrimSpace(payload.Name)
+	newSlug := tagSlug(name)
+	if newSlug == "" {
+		http.Error(w, "name required", http.StatusBadRequest)
+		return
+	}
+
+	tags, err := s.store.ListTagsForAdmin(r.Context())
+	if err != nil {
+		http.Error(w, "failed to load tags", http.StatusInternalServerError)
+		return
+	}
+	if findAdminTag(tags, slug) == nil {
+		http.Error(w, "tag not found", http.StatusNotFound)
+		return
+	}
+	merged := newSlug != slug && findAdminTag(tags, newSlug) != nil
+
+	target := Tag{ID: newSlug, Name: name, Slug: newSlug}
+	affected, err := s.store.MergeTags(r.Context(), []string{slug}, target)
+	if err != nil {
+		http.Error(w, "failed to rename tag", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, tagMergeResponse{Tag: target, PostsAffected: affected, Merged: merged})
+}
+
+// handleAdminMergeTags moves the posts of the source tags onto the target
+// tag, which keeps its name and settings, and removes the sources.
+func (s *service) handleAdminMergeTags(w http.ResponseWriter, r *http.Request) {
+	var payload struct {
+		Sources []string `json:"sources"`
+		Target  string   `json:"target"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "invalid json", http.StatusBadRequest)
+		return
+	}
+	targetSlug := strings.ToLower(strings.TrimSpace(payload.Target))
+	var sources []string
+	for _, source := range payload.Sources {
+		if slug := strings.ToLower(strings.TrimSpace(source)); slug != "" && slug != targetSlug {
+			sources = append(sources, slug)
+		}
+	}
+	if targetSlug == "" || len(sources) == 0 {
+		http.Error(w, "sources and target required", http.StatusBadRequest)
+		return
+	}
+
+	tags, err := s.store.ListTagsForAdmin(r.Context())
+	if err != nil {
+		http.Error(w, "failed to load tags", http.StatusInternalServerError)
+		return
+	}
+	target := findAdminTag(tags, targetSlug)
+	if target == nil {
+		http.Error(w, "tag not found: "+targetSlug, http.StatusNotFound)
+		return
+	}
+	for _, slug := range sources {
+		if findAdminTag(tags, slug) == nil {
+			http.Error(w, "tag not found: "+slug, http.StatusNotFound)
+			return
+		}
+	}
+
+	affected, err := s.store.MergeTags(r.Context(), sources, target.Tag)
+	if err != nil {
+		http.Error(w, "failed to merge tags", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, tagMergeResponse{Tag: Tag{ID: target.Slug, Name: target.Name, Slug: target.Slug}, PostsAffected: affected, Merged: true})
+}
+
+// visibleTags drops hidden tags so public pages don't link to archives that
+// 404.
+func visibleTags(tags []Tag, hidden map[string]bool) []Tag {
+	if len(hidden) == 0 {
+		return tags
+	}
+	out := make([]Tag, 0, len(tags))
+	for _, tag := range tags {
+		slug := strings.ToLower(strings.TrimSpace(tag.Slug))
+		if slug == "" {
+			slug = tagSlug(tag.Name)
+		}
+		if !hidden[slug] {
+			out = append(out, tag)
+		}
+	}
+	return out
+}
+
+// defaultTagsPageSize is the page size of GET <prefix>/api/tags without
+// ?limit.
+const defaultTagsPageSize = 50
+
+type tagsPageResponse struct {
+	Tags   []TagWithCount `json:"tags"`
+	Total  int            `json:"total"`
+	Limit  int            `json:"limit"`
+	Offset int            `json:"offset"`
+}
+
+// handlePublicListTags lists the visible tags with their post counts.
+// ?q filters by name or slug prefix, ?sort is "name" (default) or "count",
+// and ?limit (capped by Config.MaxPageSize) and ?offset page the result.
+func (s *service) handlePublicListTags(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	q := TagQuery{
+		Prefix: query.Get("q"),
+		Sort:   strings.ToLower(strings.TrimSpace(query.Get("sort"))),
+		Limit:  defaultTagsPageSize,
+	}
+	switch q.Sort {
+	case "":
+		q.Sort = tagSortName
+	case tagSortName, tagSortCount:
+	default:
+		http.Error(w, "invalid sort", http.StatusBadRequest)
+		return
+	}
+	maxLimit := s.cfg.MaxPageSize
+	if maxLimit <= 0 {
+		maxLimit = 100
+	}
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		q.Limit = min(n, maxLimit)
+	}
+	if v := query.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			http.Error(w, "invalid offset", http.StatusBadRequest)
+			return
+		}
+		q.Offset = n
+	}
+
+	tags, total, err := s.store.ListTagsPage(r.Context(), q)
+	if err != nil {
+		http.Error(w, "failed to list tags", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, tagsPageResponse{Tags: tags, Total: total, Limit: q.Limit, Offset: q.Offset})
+}
@@ -5,14 +5,15 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"net/url"
 	"path"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/smhanov/llmhub"
@@ -23,11 +24,23 @@ const (
 	TaskStatusRunning   = "running"
 	TaskStatusCompleted = "completed"
 	TaskStatusFailed    = "failed"
+	TaskStatusCancelled = "cancelled"
 
 	TaskTypeGenerateDescription = "generate_description"
 	TaskTypeGenerateTags        = "generate_tags"
 	TaskTypePostProcessing      = "post_processing"
 	TaskTypeImportImages        = "import_images"
+	TaskTypeNotificationDigest  = "notification_digest"
+	TaskTypeImportURL           = "import_url"
+	TaskTypePingSitemap         = "ping_sitemap"
+	TaskTypeSendPush            = "send_push"
+	TaskTypeWebhook             = "webhook"
+	TaskTypePublishPost         = "publish_post"
+	TaskTypeUnpublishPost       = "unpublish_post"
+	TaskTypeGenerateAltText     = "generate_alt_text"
+	TaskTypeTranslatePost       = "translate_post"
+	TaskTypeRecordViews         = "record_views"
+	TaskTypeVerifyWebmention    = "verify_webmention"
 )
 
 // ---------------------------------------------------------------------------
@@ -38,12 +51,117 @@ const (
 type taskRunner struct {
 	svc    *service
 	notify chan struct{}
+	// slots holds one token per busy worker, bounding concurrency to
+	// Config.TaskWorkers.
+	slots chan struct{}
+
+	mu    sync.Mutex
+	timer *time.Timer // wakes the runner for the next delayed retry
+	// cancels stops the tasks running in this process, by task ID.
+	cancels map[string]context.CancelFunc
+	// finished is closed once a running task's outcome is saved.
+	finished map[string]chan struct{}
+	// stopped is set once stop has closed notify; no new work starts.
+	stopped bool
+	// interrupted is set when stop gives up waiting and cancels the running
+	// tasks; they are then put back in the queue rather than cancelled.
+	interrupted bool
+
+	workers sync.WaitGroup
+	runDone chan struct{} // closed when run returns
+}
+
+// retryTaskError asks the runner to put a task back in the queue and run it
+// again after a delay, instead of marking it failed.
+type retryTaskError struct {
+	err   error
+	after time.Duration
+}
+
+func (e *retryTaskError) Error() string { return e.err.Error() }
+func (e *retryTaskError) Unwrap() error { return e.err }
+
+// retryTaskAfter wraps err so the task is retried after the given delay.
+func retryTaskAfter(err error, after time.Duration) error {
+	return &retryTaskError{err: err, after: after}
+}
+
+// permanentTaskError marks a task failed straight away, skipping the
+// runner's retries. Tasks with their own retry policy return it once that
+// policy gives up.
+type permanentTaskError struct {
+	err error
+}
+
+func (e *permanentTaskError) Error() string { return e.err.Error() }
+func (e *permanentTaskError) Unwrap() error { return e.err }
+
+// failTask wraps err so the task is marked failed without further retries.
+func failTask(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentTaskError{err: err}
+}
+
+// httpStatusError is an unsuccessful response from a remote endpoint, such
+// as a webhook receiver or a push service.
+type httpStatusError struct {
+	msg  string
+	code int
+}
+
+func (e *httpStatusError) Error() string { return fmt.Sprintf("%s: %d", e.msg, e.code) }
+
+// retryableDelivery reports whether a failed delivery is worth trying
+// again: network errors, 5xx responses and 429 are; other 4xx responses
+// would only be refused again.
+func retryableDelivery(err error) bool {
+	var status *httpStatusError
+	if errors.As(err, &status) {
+		return status.code >= 500 || status.code == http.StatusTooManyRequests
+	}
+	return true
+}
+
+const (
+	defaultTaskMaxAttempts  = 3
+	defaultTaskRetryBackoff = 30 * time.Second
+	maxTaskRetryBackoff     = time.Hour
+)
+
+func (s *service) taskMaxAttempts() int {
+	if s.cfg.TaskMaxAttempts > 0 {
+		return s.cfg.TaskMaxAttempts
+	}
+	return defaultTaskMaxAttempts
+}
+
+// taskRetryDelay returns the backoff after the given number of failed
+// attempts: TaskRetryBackoff, doubling each time, capped at an hour.
+func (s *service) taskRetryDelay(attempts int) time.Duration {
+	delay := s.cfg.TaskRetryBackoff
+	if delay <= 0 {
+		delay = defaultTaskRetryBackoff
+	}
+	for i := 1; i < attempts && delay < maxTaskRetryBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxTaskRetryBackoff)
 }
 
 func newTaskRunner(svc *service) *taskRunner {
+	workers := svc.cfg.TaskWorkers
+	if workers < 1 {
+		workers = 1
+	}
 	return &taskRunner{
-		svc:    svc,
-		notify: make(chan struct{}, 1),
+		svc:      svc,
+		notify:   make(chan struct{}, 1),
+		slots:    make(chan struct{}, workers),
+		cancels:  map[string]context.CancelFunc{},
+		finished: map[string]chan struct{}{},
+		runDone:  make(chan struct{}),
 	}
 }
 
@@ -51,21 +169,87 @@ func newTaskRunner(svc *service) *taskRunner {
 func (tr *taskRunner) start() {
 	ctx := context.Background()
 	if err := tr.svc.store.ResetRunningTasks(ctx); err != nil {
-		log.Printf("tasks: failed to reset running tasks: %v", err)
+		tr.svc.logf("tasks: failed to reset running tasks: %v", err)
 	}
 
 	go tr.run()
 }
 
-// nudge signals the runner that new work is available.
+// nudge signals the runner that new work is available. It does nothing
+// once the runner is stopped.
 func (tr *taskRunner) nudge() {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	if tr.stopped {
+		return
+	}
 	select {
 	case tr.notify <- struct{}{}:
 	default:
 	}
 }
 
+// taskInterruptGrace is how long stop waits, after cancelling the tasks
+// still running when its ctx is done, for them to record their progress.
+const taskInterruptGrace = 5 * time.Second
+
+// stop stops the runner from starting tasks and waits until the running
+// ones finish or ctx is done. Tasks still running then have their contexts
+// cancelled and are put back in the queue as pending, with the progress
+// they saved, to resume in the next process. Calling stop again waits
+// again.
+func (tr *taskRunner) stop(ctx context.Context) error {
+	tr.mu.Lock()
+	if !tr.stopped {
+		tr.stopped = true
+		close(tr.notify)
+		if tr.timer != nil {
+			tr.timer.Stop()
+		}
+	}
+	tr.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		// Workers are only added by run, so they are all counted once it
+		// returns.
+		<-tr.runDone
+		tr.workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+	}
+
+	tr.mu.Lock()
+	tr.interrupted = true
+	for _, cancel := range tr.cancels {
+		cancel()
+	}
+	tr.mu.Unlock()
+	select {
+	case <-done:
+	case <-time.After(taskInterruptGrace):
+	}
+	return ctx.Err()
+}
+
+func (tr *taskRunner) isInterrupted() bool {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	return tr.interrupted
+}
+
+func (tr *taskRunner) isStopped() bool {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	return tr.stopped
+}
+
 func (tr *taskRunner) run() {
+	defer close(tr.runDone)
 	// Process anything already queued from a previous run.
 	tr.processPending()
 
@@ -74,61 +258,200 @@ func (tr *taskRunner) run() {
 	}
 }
 
+// processPending hands every due pending task to a worker, waiting for a
+// free one when all are busy. Workers nudge the runner when they finish, so
+// tasks queued in the meantime are picked up. Tasks waiting for a retry are
+// skipped; the runner wakes itself when the earliest of them becomes due.
 func (tr *taskRunner) processPending() {
 	ctx := context.Background()
-	for {
-		tasks, err := tr.svc.store.ListPendingTasks(ctx)
-		if err != nil {
-			log.Printf("tasks: list pending: %v", err)
-			return
-		}
-		if len(tasks) == 0 {
+	tasks, err := tr.svc.store.ListPendingTasks(ctx)
+	if err != nil {
+		tr.svc.logf("tasks: list pending: %v", err)
+		return
+	}
+	now := time.Now()
+	var next time.Time
+	for _, task := range tasks {
+		if tr.isStopped() {
 			return
 		}
-		for _, task := range tasks {
-			tr.processTask(ctx, task)
+		if task.RunAfter != nil && task.RunAfter.After(now) {
+			if next.IsZero() || task.RunAfter.Before(next) {
+				next = *task.RunAfter
+			}
+			continue
 		}
+		tr.slots <- struct{}{}
+		tr.workers.Add(1)
+		go tr.work(ctx, task.ID)
+	}
+	if !next.IsZero() {
+		tr.wakeAt(next)
+	}
+}
+
+// work claims a task and runs it. A task claimed by another worker or app
+// instance since it was listed is skipped, and so is every task once the
+// runner has stopped, including one stop just put back in the queue.
+func (tr *taskRunner) work(ctx context.Context, id string) {
+	defer tr.workers.Done()
+	defer func() { <-tr.slots }()
+	if tr.isStopped() {
+		return
+	}
+	task, err := tr.svc.store.ClaimTask(ctx, id)
+	if err != nil {
+		tr.svc.logf("tasks: claim id=%s: %v", id, err)
+		return
 	}
+	if task == nil {
+		return
+	}
+	tr.processTask(ctx, *task)
+	tr.nudge()
 }
 
+// wakeAt nudges the runner at t, replacing any earlier wake-up.
+func (tr *taskRunner) wakeAt(t time.Time) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	if tr.stopped {
+		return
+	}
+	if tr.timer != nil {
+		tr.timer.Stop()
+	}
+	tr.timer = time.AfterFunc(time.Until(t), tr.nudge)
+}
+
+// cancel stops the task if it is running in this process. It returns a
+// channel that is closed once the runner has saved the task's outcome, and
+// whether the task was found.
+func (tr *taskRunner) cancel(id string) (<-chan struct{}, bool) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	cancel, ok := tr.cancels[id]
+	if !ok {
+		return nil, false
+	}
+	cancel()
+	return tr.finished[id], true
+}
+
+// processTask runs a claimed task and records the outcome. The task's
+// context is cancelled when the task is cancelled through the admin API;
+// the task is then recorded as cancelled whatever it returns. When stop
+// cancels it instead, it goes back to pending. A task cancelled in the
+// store before it starts does not run, and its outcome is only recorded
+// while the stored task is still running.
 func (tr *taskRunner) processTask(ctx context.Context, task Task) {
+	taskCtx, cancel := context.WithCancel(ctx)
+	finished := make(chan struct{})
+	tr.mu.Lock()
+	tr.cancels[task.ID] = cancel
+	tr.finished[task.ID] = finished
+	if tr.interrupted {
+		cancel() // claimed just as stop gave up waiting
+	}
+	tr.mu.Unlock()
+	defer func() {
+		tr.mu.Lock()
+		delete(tr.cancels, task.ID)
+		delete(tr.finished, task.ID)
+		tr.mu.Unlock()
+		cancel()
+		close(finished)
+	}()
+
 	task.Status = TaskStatusRunning
-	task.UpdatedAt = time.Now().UTC()
-	if err := tr.svc.store.UpdateTask(ctx, &task); err != nil {
-		log.Printf("tasks: mark running id=%s: %v", task.ID, err)
+	task.Attempts++
+	if started, err := tr.svc.store.UpdateTaskIfStatus(ctx, &task, TaskStatusRunning); err != nil {
+		tr.svc.logf("tasks: mark running id=%s: %v", task.ID, err)
 		return
+	} else if !started {
+		return // cancelled between the claim and now
 	}
 
-	log.Printf("tasks: start id=%s type=%s", task.ID, task.TaskType)
+	tr.svc.logf("tasks: start id=%s type=%s", task.ID, task.TaskType)
 	start := time.Now()
 
 	var err error
 	switch task.TaskType {
 	case TaskTypeGenerateDescription:
-		err = tr.svc.processGenerateDescription(ctx, &task)
+		err = tr.svc.processGenerateDescription(taskCtx, &task)
 	case TaskTypeGenerateTags:
-		err = tr.svc.processGenerateTags(ctx, &task)
+		err = tr.svc.processGenerateTags(taskCtx, &task)
 	case TaskTypePostProcessing:
-		err = tr.svc.processPostProcessing(ctx, &task)
+		err = tr.svc.processPostProcessing(taskCtx, &task)
 	case TaskTypeImportImages:
-		err = tr.svc.processImportImages(ctx, &task)
+		err = tr.svc.processImportImages(taskCtx, &task)
+	case TaskTypeNotificationDigest:
+		err = tr.svc.processNotificationDigest(taskCtx, &task)
+	case TaskTypeImportURL:
+		err = tr.svc.processImportURL(taskCtx, &task)
+	case TaskTypePingSitemap:
+		err = tr.svc.processPingSitemap(taskCtx, &task)
+	case TaskTypeSendPush:
+		err = tr.svc.processSendPush(taskCtx, &task)
+	case TaskTypeWebhook:
+		err = tr.svc.processWebhook(taskCtx, &task)
+	case TaskTypePublishPost:
+		err = tr.svc.processPublishPost(taskCtx, &task)
+	case TaskTypeUnpublishPost:
+		err = tr.svc.processUnpublishPost(taskCtx, &task)
+	case TaskTypeGenerateAltText:
+		err = tr.svc.processGenerateAltText(taskCtx, &task)
+	case TaskTypeTranslatePost:
+		err = tr.svc.processTranslatePost(taskCtx, &task)
+	case TaskTypeRecordViews:
+		err = tr.svc.processRecordViews(taskCtx, &task)
+	case TaskTypeVerifyWebmention:
+		err = tr.svc.processVerifyWebmention(taskCtx, &task)
 	default:
-		err = fmt.Errorf("unknown task type: %s", task.TaskType)
-	}
-
-	if err != nil {
-		log.Printf("tasks: failed id=%s type=%s dt=%s err=%v", task.ID, task.TaskType, time.Since(start), err)
+		err = failTask(fmt.Errorf("unknown task type: %s", task.TaskType))
+	}
+
+	var retry *retryTaskError
+	var permanent *permanentTaskError
+	if err != nil && !errors.As(err, &retry) && !errors.As(err, &permanent) && task.Attempts < tr.svc.taskMaxAttempts() {
+		retry = &retryTaskError{err: err, after: tr.svc.taskRetryDelay(task.Attempts)}
+	}
+	if taskCtx.Err() != nil && tr.isInterrupted() {
+		tr.svc.logf("tasks: interrupted id=%s type=%s dt=%s", task.ID, task.TaskType, time.Since(start))
+		task.Status = TaskStatusPending
+		task.RunAfter = nil
+		tr.svc.metrics.taskFinished(task.TaskType, "interrupted")
+	} else if taskCtx.Err() != nil {
+		tr.svc.logf("tasks: cancelled id=%s type=%s dt=%s", task.ID, task.TaskType, time.Since(start))
+		task.Status = TaskStatusCancelled
+		task.RunAfter = nil
+		tr.svc.metrics.taskFinished(task.TaskType, TaskStatusCancelled)
+	} else if retry != nil {
+		runAfter := time.Now().Add(retry.after).UTC()
+		tr.svc.logf("tasks: retry id=%s type=%s attempt=%d at=%s err=%v", task.ID, task.TaskType, task.Attempts, runAfter.Format(time.RFC3339), retry.err)
+		task.Status = TaskStatusPending
+		task.RunAfter = &runAfter
+		errMsg := retry.err.Error()
+		task.ErrorMessage = &errMsg
+		tr.svc.metrics.taskFinished(task.TaskType, "retried")
+	} else if err != nil {
+		tr.svc.logf("tasks: failed id=%s type=%s dt=%s err=%v", task.ID, task.TaskType, time.Since(start), err)
 		task.Status = TaskStatusFailed
 		errMsg := err.Error()
 		task.ErrorMessage = &errMsg
+		tr.svc.metrics.taskFinished(task.TaskType, TaskStatusFailed)
 	} else {
-		log.Printf("tasks: done id=%s type=%s dt=%s", task.ID, task.TaskType, time.Since(start))
+		tr.svc.logf("tasks: done id=%s type=%s dt=%s", task.ID, task.TaskType, time.Since(start))
 		task.Status = TaskStatusCompleted
+		tr.svc.metrics.taskFinished(task.TaskType, TaskStatusCompleted)
 	}
 
-	task.UpdatedAt = time.Now().UTC()
-	if updateErr := tr.svc.store.UpdateTask(ctx, &task); updateErr != nil {
-		log.Printf("tasks: update id=%s: %v", task.ID, updateErr)
+	// The outcome is only written while the task is still running, so a
+	// cancel recorded by another instance meanwhile sticks.
+	if saved, updateErr := tr.svc.store.UpdateTaskIfStatus(ctx, &task, TaskStatusRunning); updateErr != nil {
+		tr.svc.logf("tasks: update id=%s: %v", task.ID, updateErr)
+	} else if !saved {
+		tr.svc.logf("tasks: discarded outcome id=%s status=%s: no longer running", task.ID, task.Status)
 	}
 }
 
@@ -136,8 +459,16 @@ func (tr *taskRunner) processTask(ctx context.Context, task Task) {
 // Task queueing helpers
 // ---------------------------------------------------------------------------
 
+// generatePostPayload is the payload of generate_description and
+// generate_tags tasks. Force regenerates a description or tags the post
+// already has; otherwise the task leaves them alone.
+type generatePostPayload struct {
+	PostID string `json:"post_id"`
+	Force  bool   `json:"force,omitempty"`
+}
+
 func (s *service) queueDescriptionGeneration(postID string) {
-	payload, _ := json.Marshal(map[string]string{"post_id": postID})
+	payload, _ := json.Marshal(generatePostPayload{PostID: postID})
 	task := Task{
 		ID:       generateID(),
 		TaskType: TaskTypeGenerateDescription,
@@ -146,14 +477,16 @@ func (s *service) queueDescriptionGeneration(postID string) {
 		Result:   "{}",
 	}
 	if err := s.store.CreateTask(context.Background(), &task); err != nil {
-		log.Printf("tasks: queue description post=%s: %v", postID, err)
+		s.logf("tasks: queue description post=%s: %v", postID, err)
 		return
 	}
 	s.tasks.nudge()
 }
 
-func (s *service) queueTagGeneration(postID string) {
-	payload, _ := json.Marshal(map[string]string{"post_id": postID})
+// queueTagGeneration queues tags for a post, replacing any it has when
+// force is set.
+func (s *service) queueTagGeneration(postID string, force bool) {
+	payload, _ := json.Marshal(generatePostPayload{PostID: postID, Force: force})
 	task := Task{
 		ID:       generateID(),
 		TaskType: TaskTypeGenerateTags,
@@ -162,13 +495,28 @@ func (s *service) queueTagGeneration(postID string) {
 		Result:   "{}",
 	}
 	if err := s.store.CreateTask(context.Background(), &task); err != nil {
-		log.Printf("tasks: queue tags post=%s: %v", postID, err)
+		s.logf("tasks: queue tags post=%s: %v", postID, err)
 		return
 	}
 	s.tasks.nudge()
 }
 
+// queuePostProcessing queues a post_processing task unless one is already
+// pending and due: that task scans every post when it runs, so it covers
+// this change too. A running task doesn't count, as it may have passed the
+// post that changed, and neither does one waiting up to an hour for a
+// retry, which would leave new posts without descriptions until then.
 func (s *service) queuePostProcessing(reason string) {
+	s.postProcessingMu.Lock()
+	defer s.postProcessingMu.Unlock()
+	pending, err := s.store.HasDueTaskOfType(context.Background(), TaskTypePostProcessing)
+	if err != nil {
+		s.logf("tasks: queue post processing reason=%s: %v", reason, err)
+		return
+	}
+	if pending {
+		return
+	}
 	payload, _ := json.Marshal(map[string]string{"reason": reason})
 	task := Task{
 		ID:       generateID(),
@@ -178,16 +526,17 @@ func (s *service) queuePostProcessing(reason string) {
 		Result:   "{}",
 	}
 	if err := s.store.CreateTask(context.Background(), &task); err != nil {
-		log.Printf("tasks: queue post processing reason=%s: %v", reason, err)
+		s.logf("tasks: queue post processing reason=%s: %v", reason, err)
 		return
 	}
 	s.tasks.nudge()
 }
 
-func (s *service) queueImageImport(baseSiteURL string, postIDs []string) {
+func (s *service) queueImageImport(baseSiteURL string, postIDs, attachmentURLs []string) {
 	payload, _ := json.Marshal(importImagesPayload{
-		BaseSiteURL: baseSiteURL,
-		PostIDs:     postIDs,
+		BaseSiteURL:    baseSiteURL,
+		PostIDs:        postIDs,
+		AttachmentURLs: attachmentURLs,
 	})
 	task := Task{
 		ID:       generateID(),
@@ -197,16 +546,55 @@ func (s *service) queueImageImport(baseSiteURL string, postIDs []string) {
 		Result:   "{}",
 	}
 	if err := s.store.CreateTask(context.Background(), &task); err != nil {
-		log.Printf("tasks: queue image import: %v", err)
+		s.logf("tasks: queue image import: %v", err)
 		return
 	}
 	s.tasks.nudge()
 }
 
+func (s *service) queueNotificationDigest() {
+	task := Task{
+		ID:       generateID(),
+		TaskType: TaskTypeNotificationDigest,
+		Status:   TaskStatusPending,
+		Payload:  "{}",
+		Result:   "{}",
+	}
+	if err := s.store.CreateTask(context.Background(), &task); err != nil {
+		s.logf("tasks: queue notification digest: %v", err)
+		return
+	}
+	s.tasks.nudge()
+}
+
+func (s *service) queueURLImport(rawURL string) (*Task, error) {
+	payload, _ := json.Marshal(importURLPayload{URL: rawURL})
+	task := Task{
+		ID:       generateID(),
+		TaskType: TaskTypeImportURL,
+		Status:   TaskStatusPending,
+		Payload:  string(payload),
+		Result:   "{}",
+	}
+	if err := s.store.CreateTask(context.Background(), &task); err != nil {
+		s.logf("tasks: queue url import: %v", err)
+		return nil, err
+	}
+	s.tasks.nudge()
+	return &task, nil
+}
+
 // ---------------------------------------------------------------------------
 // Post processing (async task)
 // ---------------------------------------------------------------------------
 
+type postProcessingResult struct {
+	Processed    int      `json:"processed"`
+	Descriptions int      `json:"descriptions"`
+	Tags         int      `json:"tags"`
+	Usage        *aiUsage `json:"usage,omitempty"`
+}
+
 func (s *service) processPostProcessing(ctx context.Context, task *Task) error {
 	var payload struct {
 		Reason string `json:"reason"`
@@ -217,18 +605,18 @@ func (s *service) processPostProcessing(ctx context.Context, task *Task) error {
 	if err != nil {
 		return fmt.Errorf("load posts: %w", err)
 	}
-	log.Printf("tasks: post-processing start reason=%s posts=%d", strings.TrimSpace(payload.Reason), len(posts))
+	s.logf("tasks: post-processing start reason=%s posts=%d", strings.TrimSpace(payload.Reason), len(posts))
 	if len(posts) == 0 {
 		return nil
 	}
 
-	settings, err := s.store.GetAISettings(ctx)
+	settings, err := s.aiSettings(ctx)
 	if err != nil {
 		return fmt.Errorf("load ai settings: %w", err)
 	}
 	provider := dumbAISettings(settings)
 	if provider == nil {
-		log.Printf("tasks: post-processing skipped (ai not configured)")
+		s.logf("tasks: post-processing skipped (ai not configured)")
 		return nil
 	}
 
@@ -237,64 +625,74 @@ func (s *service) processPostProcessing(ctx context.Context, task *Task) error {
 		return fmt.Errorf("create ai client: %w", err)
 	}
 
-	processed := 0
-	filledDescriptions := 0
-	filledTags := 0
+	var result postProcessingResult
 	for _, post := range posts {
+		// Stop between posts once the task is cancelled, keeping the
+		// counts so far. Posts already done are skipped when it runs again.
+		if err := ctx.Err(); err != nil {
+			s.saveTaskResult(ctx, task, result)
+			s.logf("tasks: post-processing stopped processed=%d descriptions=%d tags=%d", result.Processed, result.Descriptions, result.Tags)
+			return err
+		}
 		content := strings.TrimSpace(post.ContentMarkdown)
 		if content == "" {
 			continue
 		}
 
 		missingDesc := strings.TrimSpace(post.MetaDescription) == ""
-		missingTags := len(post.Tags) == 0
+		// Pages aren't tagged: tags drive archives and related posts,
+		// which only list regular posts.
+		missingTags := len(post.Tags) == 0 && post.PostType != PostTypePage
 		if !missingDesc && !missingTags {
 			continue
 		}
 
-		processed++
-		log.Printf("tasks: post-processing post_id=%s missing_desc=%t missing_tags=%t", post.ID, missingDesc, missingTags)
+		result.Processed++
+		s.logf("tasks: post-processing post_id=%s missing_desc=%t missing_tags=%t", post.ID, missingDesc, missingTags)
 
 		if missingDesc {
-			prompt := buildDescriptionPrompt(post.Title, post.ContentMarkdown)
+			prompt := s.prompts.buildDescriptionPrompt(post.Title, post.ContentMarkdown)
 			aiCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
-			resp, err := client.Generate(aiCtx, prompt)
+			resp, err := s.aiGenerate(aiCtx, client, prompt)
 			cancel()
 			if err != nil {
-				log.Printf("tasks: post-processing description failed post_id=%s err=%v", post.ID, err)
+				s.logf("tasks: post-processing description failed post_id=%s err=%v", post.ID, err)
 			} else {
-				description := parseDescriptionResponse(resp.Text())
+				result.Usage = s.recordAIUsage(result.Usage, *provider, resp)
+				description := parseDescriptionResponse(resp.Text(), s.prompts.DescriptionMaxLength)
 				if description != "" {
-					if err := s.updatePostDescription(ctx, post.ID, description); err != nil {
-						log.Printf("tasks: post-processing update description failed post_id=%s err=%v", post.ID, err)
+					if err := s.updatePostDescription(ctx, post.ID, description, ""); err != nil {
+						s.logf("tasks: post-processing update description failed post_id=%s err=%v", post.ID, err)
 					} else {
-						filledDescriptions++
+						result.Descriptions++
 					}
 				}
 			}
 		}
 
 		if missingTags {
-			prompt := buildTaggingPrompt(post.Title, post.ContentMarkdown)
+			prompt := s.prompts.buildTaggingPrompt(post.Title, post.ContentMarkdown)
 			aiCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
-			resp, err := client.Generate(aiCtx, prompt)
+			resp, err := s.aiGenerate(aiCtx, client, prompt)
 			cancel()
 			if err != nil {
-				log.Printf("tasks: post-processing tags failed post_id=%s err=%v", post.ID, err)
+				s.logf("tasks: post-processing tags failed post_id=%s err=%v", post.ID, err)
 			} else {
-				resultTags := parseTaggingResponse(resp.Text())
+				result.Usage = s.recordAIUsage(result.Usage, *provider, resp)
+				resultTags := parseTaggingResponse(resp.Text(), s.prompts.MaxTags)
 				if len(resultTags) > 0 {
 					if err := s.store.SetPostTags(ctx, post.ID, resultTags); err != nil {
-						log.Printf("tasks: post-processing set tags failed post_id=%s err=%v", post.ID, err)
+						s.logf("tasks: post-processing set tags failed post_id=%s err=%v", post.ID, err)
 					} else {
-						filledTags++
+						result.Tags++
 					}
 				}
 			}
 		}
 	}
 
-	log.Printf("tasks: post-processing done processed=%d descriptions=%d tags=%d", processed, filledDescriptions, filledTags)
+	s.saveTaskResult(ctx, task, result)
+	s.logf("tasks: post-processing done processed=%d descriptions=%d tags=%d", result.Processed, result.Descriptions, result.Tags)
 	return nil
 }
 
@@ -303,11 +701,9 @@ func (s *service) processPostProcessing(ctx context.Context, task *Task) error {
 // ---------------------------------------------------------------------------
 
 func (s *service) processGenerateDescription(ctx context.Context, task *Task) error {
-	var payload struct {
-		PostID string `json:"post_id"`
-	}
+	var payload generatePostPayload
 	if err := json.Unmarshal([]byte(task.Payload), &payload); err != nil {
-		return fmt.Errorf("invalid payload: %w", err)
+		return failTask(fmt.Errorf("invalid payload: %w", err))
 	}
 
 	post, err := s.store.GetPostByID(ctx, payload.PostID)
@@ -319,11 +715,11 @@ func (s *service) processGenerateDescription(ctx context.Context, task *Task) er
 	}
 
 	// Skip if description was set between queueing and processing.
-	if strings.TrimSpace(post.MetaDescription) != "" {
+	if strings.TrimSpace(post.MetaDescription) != "" && !payload.Force {
 		return nil
 	}
 
-	settings, err := s.store.GetAISettings(ctx)
+	settings, err := s.aiSettings(ctx)
 	if err != nil {
 		return fmt.Errorf("load ai settings: %w", err)
 	}
@@ -337,35 +733,39 @@ func (s *service) processGenerateDescription(ctx context.Context, task *Task) er
 		return fmt.Errorf("create ai client: %w", err)
 	}
 
-	prompt := buildDescriptionPrompt(post.Title, post.ContentMarkdown)
+	prompt := s.prompts.buildDescriptionPrompt(post.Title, post.ContentMarkdown)
 	aiCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel()
 
-	log.Printf("ai description start post_id=%s provider=%s model=%s",
+	s.logf("ai description start post_id=%s provider=%s model=%s",
 		post.ID,
 		strings.ToLower(strings.TrimSpace(provider.Provider)),
 		strings.TrimSpace(provider.Model),
 	)
 	start := time.Now()
-	resp, err := client.Generate(aiCtx, prompt)
+	resp, err := s.aiGenerate(aiCtx, client, prompt)
 	if err != nil {
-		log.Printf("ai description failed post_id=%s dt=%s err=%v", post.ID, time.Since(start), err)
+		s.logf("ai description failed post_id=%s dt=%s err=%v", post.ID, time.Since(start), err)
 		return fmt.Errorf("ai generation: %w", err)
 	}
-	log.Printf("ai description done post_id=%s dt=%s", post.ID, time.Since(start))
+	s.logf("ai description done post_id=%s dt=%s", post.ID, time.Since(start))
+	s.saveTaskResult(ctx, task, aiTaskResult{Usage: s.recordAIUsage(nil, *provider, resp)})
 
-	description := parseDescriptionResponse(resp.Text())
+	description := parseDescriptionResponse(resp.Text(), s.prompts.DescriptionMaxLength)
 	if description == "" {
 		return fmt.Errorf("ai returned empty description")
 	}
 
-	if err := s.updatePostDescription(ctx, post.ID, description); err != nil {
+	if err := s.updatePostDescription(ctx, post.ID, description, post.MetaDescription); err != nil {
 		return fmt.Errorf("update post: %w", err)
 	}
 	return nil
 }
 
-func (s *service) updatePostDescription(ctx context.Context, postID, description string) error {
+// updatePostDescription saves a generated description unless the post's
+// description is no longer previous, the one it had when generation
+// started.
+func (s *service) updatePostDescription(ctx context.Context, postID, description, previous string) error {
 	description = strings.TrimSpace(description)
 	if postID == "" || description == "" {
 		return nil
@@ -380,7 +780,7 @@ func (s *service) updatePostDescription(ctx context.Context, postID, description
 	}
 
 	// Respect edits made while the AI request was running.
-	if strings.TrimSpace(latest.MetaDescription) != "" {
+	if strings.TrimSpace(latest.MetaDescription) != strings.TrimSpace(previous) {
 		return nil
 	}
 
@@ -388,15 +788,10 @@ func (s *service) updatePostDescription(ctx context.Context, postID, description
 	return s.store.UpdatePost(ctx, latest)
 }
 
-func buildDescriptionPrompt(title, content string) []*llmhub.Message {
-	excerpt := markdownToPlainText(content)
-	excerpt = trimToLength(excerpt, 3000)
-
-	system := llmhub.NewSystemMessage(llmhub.Text(
-		`You are an expert SEO copywriter who creates irresistible meta descriptions that maximize click-through rates from search results.
+const defaultDescriptionPrompt = `You are an expert SEO copywriter who creates irresistible meta descriptions that maximize click-through rates from search results.
 
 Create a meta description for this blog post following these rules:
-- 140-160 characters maximum
+- {{.DescriptionMinLength}}-{{.DescriptionMaxLength}} characters maximum
 - Open with a bold claim, surprising fact, provocative question, or counterintuitive insight
 - Make the reader feel they'll miss out if they don't click
 - Include a clear benefit or takeaway
@@ -404,15 +799,20 @@ Create a meta description for this blog post following these rules:
 - Write in second person ("you") when appropriate
 - Avoid weak openings like "This post discusses...", "In this article...", "Learn about..."
 - Do NOT repeat the title verbatim
-- Return ONLY the description text, nothing else — no quotes, no JSON, no labels`,
-	))
+- Return ONLY the description text, nothing else — no quotes, no JSON, no labels`
+
+func (p *aiPrompts) buildDescriptionPrompt(title, content string) []*llmhub.Message {
+	excerpt := markdownToPlainText(content)
+	excerpt = trimToLength(excerpt, 3000)
+
+	system := llmhub.NewSystemMessage(llmhub.Text(p.system(p.description)))
 	user := llmhub.NewUserMessage(llmhub.Text(
 		"Title: " + title + "\n\nContent:\n" + excerpt,
 	))
 	return []*llmhub.Message{system, user}
 }
 
-func parseDescriptionResponse(text string) string {
+func parseDescriptionResponse(text string, maxLength int) string {
 	trimmed := stripThinkTags(text)
 	if trimmed == "" {
 		return ""
@@ -437,10 +837,10 @@ func parseDescriptionResponse(text string) string {
 		}
 	}
 
-	// Truncate to 160 chars if needed.
+	// Truncate to maxLength chars if needed.
 	runes := []rune(trimmed)
-	if len(runes) > 160 {
-		trimmed = string(runes[:157]) + "..."
+	if len(runes) > maxLength && maxLength > 3 {
+		trimmed = string(runes[:maxLength-3]) + "..."
 	}
 
 	return trimmed
@@ -451,11 +851,9 @@ func parseDescriptionResponse(text string) string {
 // ---------------------------------------------------------------------------
 
 func (s *service) processGenerateTags(ctx context.Context, task *Task) error {
-	var payload struct {
-		PostID string `json:"post_id"`
-	}
+	var payload generatePostPayload
 	if err := json.Unmarshal([]byte(task.Payload), &payload); err != nil {
-		return fmt.Errorf("invalid payload: %w", err)
+		return failTask(fmt.Errorf("invalid payload: %w", err))
 	}
 
 	post, err := s.store.GetPostByID(ctx, payload.PostID)
@@ -466,16 +864,16 @@ func (s *service) processGenerateTags(ctx context.Context, task *Task) error {
 		return nil
 	}
 
-	// Skip if tags were already set.
-	tags, err := s.store.GetPostTags(ctx, post.ID)
+	previous, err := s.store.GetPostTags(ctx, post.ID)
 	if err != nil {
 		return fmt.Errorf("load tags: %w", err)
 	}
-	if len(tags) > 0 {
+	// Skip if tags were already set.
+	if len(previous) > 0 && !payload.Force {
 		return nil
 	}
 
-	settings, err := s.store.GetAISettings(ctx)
+	settings, err := s.aiSettings(ctx)
 	if err != nil {
 		return fmt.Errorf("load ai settings: %w", err)
 	}
@@ -489,29 +887,49 @@ func (s *service) processGenerateTags(ctx context.Context, task *Task) error {
 		return fmt.Errorf("create ai client: %w", err)
 	}
 
-	prompt := buildTaggingPrompt(post.Title, post.ContentMarkdown)
+	prompt := s.prompts.buildTaggingPrompt(post.Title, post.ContentMarkdown)
 	aiCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel()
 
-	log.Printf("ai tagger-task start post_id=%s provider=%s model=%s",
+	s.logf("ai tagger-task start post_id=%s provider=%s model=%s",
 		post.ID,
 		strings.ToLower(strings.TrimSpace(provider.Provider)),
 		strings.TrimSpace(provider.Model),
 	)
 	start := time.Now()
-	resp, err := client.Generate(aiCtx, prompt)
+	resp, err := s.aiGenerate(aiCtx, client, prompt)
 	if err != nil {
-		log.Printf("ai tagger-task failed post_id=%s dt=%s err=%v", post.ID, time.Since(start), err)
+		s.logf("ai tagger-task failed post_id=%s dt=%s err=%v", post.ID, time.Since(start), err)
 		return fmt.Errorf("ai generation: %w", err)
 	}
-	log.Printf("ai tagger-task done post_id=%s dt=%s", post.ID, time.Since(start))
+	s.logf("ai tagger-task done post_id=%s dt=%s", post.ID, time.Since(start))
+	s.saveTaskResult(ctx, task, aiTaskResult{Usage: s.recordAIUsage(nil, *provider, resp)})
 
-	resultTags := parseTaggingResponse(resp.Text())
+	resultTags := parseTaggingResponse(resp.Text(), s.prompts.MaxTags)
 	if len(resultTags) == 0 {
 		return fmt.Errorf("ai returned no tags")
 	}
 
-	return s.store.SetPostTags(ctx, post.ID, resultTags)
+	if err := s.updatePostTags(ctx, post.ID, resultTags, previous); err != nil {
+		return fmt.Errorf("update tags: %w", err)
+	}
+	return nil
+}
+
+// updatePostTags saves generated tags unless the post's tags are no longer
+// previous, the ones it had when generation started.
+func (s *service) updatePostTags(ctx context.Context, postID string, tags []string, previous []Tag) error {
+	latest, err := s.store.GetPostTags(ctx, postID)
+	if err != nil {
+		return err
+	}
+
+	// Respect edits made while the AI request was running.
+	if !sameTagSlugs(latest, previous) {
+		return nil
+	}
+
+	return s.store.SetPostTags(ctx, postID, tags)
 }
 
 // ---------------------------------------------------------------------------
@@ -521,6 +939,9 @@ func (s *service) processGenerateTags(ctx context.Context, task *Task) error {
 type importImagesPayload struct {
 	BaseSiteURL string   `json:"base_site_url"`
 	PostIDs     []string `json:"post_ids"`
+	// AttachmentURLs are media library images from the WXR file. They
+	// are re-hosted even when no imported post shows them.
+	AttachmentURLs []string `json:"attachment_urls,omitempty"`
 }
 
 type importImagesResult struct {
@@ -533,12 +954,12 @@ type importImagesResult struct {
 
 func (s *service) processImportImages(ctx context.Context, task *Task) error {
 	if s.cfg.ImageStore == nil {
-		return fmt.Errorf("image store not configured")
+		return failTask(fmt.Errorf("image store not configured"))
 	}
 
 	var payload importImagesPayload
 	if err := json.Unmarshal([]byte(task.Payload), &payload); err != nil {
-		return fmt.Errorf("invalid payload: %w", err)
+		return failTask(fmt.Errorf("invalid payload: %w", err))
 	}
 	if payload.BaseSiteURL == "" {
 		return fmt.Errorf("base_site_url is required")
@@ -568,25 +989,40 @@ func (s *service) processImportImages(ctx context.Context, task *Task) error {
 		}
 	}
 
+	for _, candidate := range attachmentImageCandidates(payload.AttachmentURLs, payload.BaseSiteURL) {
+		aliases := resolvedImages[candidate.Resolved]
+		aliases = appendImageAlias(aliases, candidate.Raw)
+		aliases = appendImageAlias(aliases, candidate.Resolved)
+		resolvedImages[candidate.Resolved] = aliases
+	}
+
 	result.TotalCount = len(resolvedImages)
-	log.Printf("tasks: image import found %d unique images from %d posts", result.TotalCount, len(payload.PostIDs))
+	s.logf("tasks: image import found %d unique images from %d posts and %d attachments",
+		result.TotalCount, len(payload.PostIDs), len(payload.AttachmentURLs))
 
 	// Download each image, skipping already-processed ones.
 	for resolvedURL, aliases := range resolvedImages {
 		if _, ok := result.URLMap[resolvedURL]; ok {
 			continue // already downloaded in a previous run
 		}
+		// Stop between images once the task is cancelled. The URL map
+		// saved so far lets a rerun skip the downloaded ones.
+		if err := ctx.Err(); err != nil {
+			s.saveTaskResult(ctx, task, result)
+			s.logf("tasks: image import stopped processed=%d total=%d", result.ProcessedCount, result.TotalCount)
+			return err
+		}
 
 		newURL, err := s.downloadAndStoreImage(ctx, resolvedURL)
 		if err != nil {
-			log.Printf("tasks: image download failed url=%s err=%v", resolvedURL, err)
+			s.logf("tasks: image download failed url=%s err=%v", resolvedURL, err)
 			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", resolvedURL, err))
 			result.ProcessedCount++
 			s.saveTaskResult(ctx, task, result)
 			continue
 		}
 
-		log.Printf("tasks: image downloaded url=%s -> %s", resolvedURL, newURL)
+		s.logf("tasks: image downloaded url=%s -> %s", resolvedURL, newURL)
 		result.URLMap[resolvedURL] = newURL
 		for _, alias := range aliases {
 			result.URLMap[alias] = newURL
@@ -624,8 +1060,12 @@ func (s *service) processImportImages(ctx context.Context, task *Task) error {
 	}
 
 	s.saveTaskResult(ctx, task, result)
-	log.Printf("tasks: image import complete downloaded=%d replaced=%d errors=%d",
+	s.logf("tasks: image import complete downloaded=%d replaced=%d errors=%d",
 		len(result.URLMap), result.ReplacedCount, len(result.Errors))
+	// Describe the re-hosted images now that they are served locally.
+	if len(payload.PostIDs) > 0 {
+		s.queueAltTextGeneration(payload.PostIDs)
+	}
 	return nil
 }
 
@@ -691,12 +1131,8 @@ type imageCandidate struct {
 
 // extractImageCandidates finds image URLs in HTML/Markdown content from the given base site.
 func extractImageCandidates(html, markdown, baseSiteURL string) []imageCandidate {
-	baseSiteURL = strings.TrimSpace(baseSiteURL)
-	if baseSiteURL != "" && !strings.HasSuffix(baseSiteURL, "/") {
-		baseSiteURL += "/"
-	}
-	parsedBase, err := url.Parse(baseSiteURL)
-	if err != nil || parsedBase.Host == "" {
+	parsedBase, ok := parseImageBaseURL(baseSiteURL)
+	if !ok {
 		return nil
 	}
 	baseHost := parsedBase.Host
@@ -737,6 +1173,34 @@ func extractImageCandidates(html, markdown, baseSiteURL string) []imageCandidate
 	return result
 }
 
+// attachmentImageCandidates resolves WXR attachment URLs the same way
+// extractImageCandidates resolves images found in posts.
+func attachmentImageCandidates(urls []string, baseSiteURL string) []imageCandidate {
+	parsedBase, ok := parseImageBaseURL(baseSiteURL)
+	if !ok {
+		return nil
+	}
+	var result []imageCandidate
+	for _, raw := range urls {
+		if cleaned, resolved, ok := resolveImageURL(raw, parsedBase, parsedBase.Host); ok {
+			result = append(result, imageCandidate{Raw: cleaned, Resolved: resolved})
+		}
+	}
+	return result
+}
+
+func parseImageBaseURL(baseSiteURL string) (*url.URL, bool) {
+	baseSiteURL = strings.TrimSpace(baseSiteURL)
+	if baseSiteURL != "" && !strings.HasSuffix(baseSiteURL, "/") {
+		baseSiteURL += "/"
+	}
+	parsedBase, err := url.Parse(baseSiteURL)
+	if err != nil || parsedBase.Host == "" {
+		return nil, false
+	}
+	return parsedBase, true
+}
+
 func resolveImageURL(raw string, base *url.URL, baseHost string) (string, string, bool) {
 	if base == nil {
 		return "", "", false
@@ -790,7 +1254,8 @@ var imageURLRe = regexp.MustCompile(`https?://[^\s"'<>\)]+\.(?:jpg|jpeg|png|gif|
 var htmlImageSrcRe = regexp.MustCompile(`(?i)src=["']([^"']+)["']`)
 var markdownImageURLRe = regexp.MustCompile(`!\[[^\]]*\]\(([^\)]+)\)`)
 
-// saveTaskResult persists intermediate progress for resumability.
+// saveTaskResult persists intermediate progress for resumability, unless
+// the stored task's status has changed since, as when it was cancelled.
 func (s *service) saveTaskResult(ctx context.Context, task *Task, result any) {
 	data, err := json.Marshal(result)
 	if err != nil {
@@ -798,5 +1263,8 @@ func (s *service) saveTaskResult(ctx context.Context, task *Task, result any) {
 	}
 	task.Result = string(data)
 	task.UpdatedAt = time.Now().UTC()
-	_ = s.store.UpdateTask(ctx, task)
+	if ctx.Err() != nil {
+		return // cancelled; the runner saves the task once it stops
+	}
+	_, _ = s.store.UpdateTaskIfStatus(ctx, task, task.Status)
 }
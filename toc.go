@@ -0,0 +1,65 @@
+package blog
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// TOCEntry is one heading in a post's table of contents.
+type TOCEntry struct {
+	ID    string
+	Text  string
+	Level int
+}
+
+// tocMinEntries is the fewest headings worth a table of contents.
+const tocMinEntries = 2
+
+var (
+	tocHeadingRe = regexp.MustCompile(`(?s)<h([23])([^>]*)>(.*?)</h([23])>`)
+	tocIDAttrRe  = regexp.MustCompile(`\sid="([^"]*)"`)
+	tocAnchorRe  = regexp.MustCompile(`(?s)<a class="heading-anchor".*?</a>`)
+	tocTagRe     = regexp.MustCompile(`<[^>]*>`)
+)
+
+// tableOfContents lists the h2 and h3 headings of a post's HTML. Headings
+// without an id get one from headingSlug, so the returned HTML may differ
+// from the input. It returns no entries for posts with fewer than
+// tocMinEntries headings.
+func tableOfContents(content string) (string, []TOCEntry) {
+	used := map[string]bool{}
+	for _, m := range tocIDAttrRe.FindAllStringSubmatch(content, -1) {
+		used[m[1]] = true
+	}
+	var entries []TOCEntry
+	out := tocHeadingRe.ReplaceAllStringFunc(content, func(match string) string {
+		m := tocHeadingRe.FindStringSubmatch(match)
+		if m[1] != m[4] {
+			return match
+		}
+		text := tocAnchorRe.ReplaceAllString(m[3], "")
+		text = strings.TrimSpace(html.UnescapeString(tocTagRe.ReplaceAllString(text, "")))
+		if text == "" {
+			return match
+		}
+		level, _ := strconv.Atoi(m[1])
+		if id := tocIDAttrRe.FindStringSubmatch(m[2]); id != nil {
+			entries = append(entries, TOCEntry{ID: html.UnescapeString(id[1]), Text: text, Level: level})
+			return match
+		}
+		base := headingSlug(text)
+		id := base
+		for i := 1; used[id]; i++ {
+			id = base + "-" + strconv.Itoa(i)
+		}
+		used[id] = true
+		entries = append(entries, TOCEntry{ID: id, Text: text, Level: level})
+		return "<h" + m[1] + ` id="` + id + `"` + m[2] + ">" + m[3] + "</h" + m[1] + ">"
+	})
+	if len(entries) < tocMinEntries {
+		return content, nil
+	}
+	return out, entries
+}
@@ -0,0 +1,79 @@
+package blog
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Values of Config.TrailingSlash.
+const (
+	trailingSlashStrip  = "strip"
+	trailingSlashAppend = "append"
+	trailingSlashOff    = "off"
+)
+
+// normalizeTrailingSlash checks Config.TrailingSlash, returning the policy
+// to use. Empty means strip.
+func normalizeTrailingSlash(v string) (string, error) {
+	switch policy := strings.ToLower(strings.TrimSpace(v)); policy {
+	case "":
+		return trailingSlashStrip, nil
+	case trailingSlashStrip, trailingSlashAppend, trailingSlashOff:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown trailing slash policy %q", v)
+	}
+}
+
+// postPath returns the path of the post with the given slug below the
+// route prefix, ending in a slash under the append policy.
+func postPath(policy, slug string) string {
+	if policy == trailingSlashAppend {
+		return "/" + strings.TrimSuffix(slug, "/") + "/"
+	}
+	return "/" + slug
+}
+
+// postPath is postPath with Config.TrailingSlash.
+func (s *service) postPath(slug string) string {
+	return postPath(s.cfg.TrailingSlash, slug)
+}
+
+// findCanonicalPost looks up the published post a non-canonical path
+// refers to: the path without trailing slashes, then lowercased. It
+// returns nil under the off policy or when neither matches.
+func (s *service) findCanonicalPost(ctx context.Context, path string) (*Post, error) {
+	if s.cfg.TrailingSlash == trailingSlashOff {
+		return nil, nil
+	}
+	tried := map[string]bool{path: true}
+	trimmed := strings.TrimRight(path, "/")
+	for _, slug := range []string{trimmed, strings.ToLower(trimmed)} {
+		if slug == "" || tried[slug] {
+			continue
+		}
+		tried[slug] = true
+		post, err := s.store.GetPublishedPostBySlug(ctx, slug)
+		if post != nil || err != nil {
+			return post, err
+		}
+	}
+	return nil, nil
+}
+
+// redirectToCanonical sends a 301 to post's canonical path, keeping the
+// query string, when path is not already it. It reports whether it did.
+func (s *service) redirectToCanonical(w http.ResponseWriter, r *http.Request, path string, post *Post) bool {
+	canonical := s.postPath(post.Slug)
+	if s.cfg.TrailingSlash == trailingSlashOff || "/"+path == canonical {
+		return false
+	}
+	target := s.routePrefix + canonical
+	if r.URL.RawQuery != "" {
+		target += "?" + r.URL.RawQuery
+	}
+	http.Redirect(w, r, target, http.StatusMovedPermanently)
+	return true
+}
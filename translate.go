@@ -0,0 +1,316 @@
+package blog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/smhanov/llmhub"
+)
+
+// languageCodeRe matches BCP 47 style codes such as "fr", "pt-BR" or
+// "zh-Hant".
+var languageCodeRe = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{2,8})?$`)
+
+type translatePostPayload struct {
+	PostID   string `json:"post_id"`
+	Language string `json:"language"`
+}
+
+type translatePostResult struct {
+	PostID string `json:"post_id"`
+	Slug   string `json:"slug"`
+}
+
+// PostTranslation is one entry of a post page's language switcher.
+// Original marks the post the others translate, which is also the
+// hreflang="x-default" page.
+type PostTranslation struct {
+	Language string
+	Title    string
+	URL      string
+	Current  bool
+	Original bool
+}
+
+// translationGroup returns the ID shared by a post and its translations:
+// the ID of the original post.
+func translationGroup(p *Post) string {
+	if p.TranslationOf != "" {
+		return p.TranslationOf
+	}
+	return p.ID
+}
+
+// handleAdminTranslatePost queues a translation of a post into the language
+// in the request body. The translated post is created as a draft by the
+// task runner.
+func (s *service) handleAdminTranslatePost(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	var req struct {
+		Language string `json:"language"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid json", http.StatusBadRequest)
+		return
+	}
+	lang := strings.TrimSpace(req.Language)
+	if !languageCodeRe.MatchString(lang) {
+		http.Error(w, "invalid language code", http.StatusBadRequest)
+		return
+	}
+
+	post, err := s.store.GetPostByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "failed to load post", http.StatusInternalServerError)
+		return
+	}
+	if post == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	settings, err := s.aiSettings(r.Context())
+	if err != nil {
+		http.Error(w, "failed to load ai settings", http.StatusInternalServerError)
+		return
+	}
+	if settings == nil || !aiProviderConfigured(settings.Smart) {
+		http.Error(w, "ai not configured", http.StatusConflict)
+		return
+	}
+
+	payload, _ := json.Marshal(translatePostPayload{PostID: post.ID, Language: lang})
+	task := Task{
+		ID:       generateID(),
+		TaskType: TaskTypeTranslatePost,
+		Status:   TaskStatusPending,
+		Payload:  string(payload),
+		Result:   "{}",
+	}
+	if err := s.store.CreateTask(r.Context(), &task); err != nil {
+		http.Error(w, "failed to queue translation", http.StatusInternalServerError)
+		return
+	}
+	s.tasks.nudge()
+	writeJSONStatus(w, http.StatusAccepted, task)
+}
+
+// processTranslatePost asks the smart model to translate a post and saves
+// the result as a draft linked to the original. Translating into a
+// language that already has a translation updates that post instead.
+func (s *service) processTranslatePost(ctx context.Context, task *Task) error {
+	var payload translatePostPayload
+	if err := json.Unmarshal([]byte(task.Payload), &payload); err != nil {
+		return failTask(fmt.Errorf("invalid payload: %w", err))
+	}
+
+	post, err := s.store.GetPostByID(ctx, payload.PostID)
+	if err != nil {
+		return fmt.Errorf("load post: %w", err)
+	}
+	if post == nil {
+		return nil // post deleted, nothing to do
+	}
+
+	settings, err := s.aiSettings(ctx)
+	if err != nil {
+		return fmt.Errorf("load ai settings: %w", err)
+	}
+	if settings == nil || !aiProviderConfigured(settings.Smart) {
+		return failTask(fmt.Errorf("ai not configured"))
+	}
+	client, err := newLLMClient(settings.Smart, false)
+	if err != nil {
+		return fmt.Errorf("create ai client: %w", err)
+	}
+
+	aiCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+	s.logf("ai translate start post_id=%s language=%s", post.ID, payload.Language)
+	start := time.Now()
+	resp, err := s.aiGenerate(aiCtx, client, buildTranslatePrompt(post, payload.Language))
+	if err != nil {
+		s.logf("ai translate failed post_id=%s dt=%s err=%v", post.ID, time.Since(start), err)
+		return fmt.Errorf("ai generation: %w", err)
+	}
+	s.logf("ai translate done post_id=%s dt=%s", post.ID, time.Since(start))
+
+	translated, ok := parseTranslateResponse(resp.Text())
+	if !ok {
+		return fmt.Errorf("ai returned no translation")
+	}
+
+	rootID := translationGroup(post)
+	target, err := s.findTranslation(ctx, rootID, payload.Language)
+	if err != nil {
+		return fmt.Errorf("load translations: %w", err)
+	}
+	isNew := target == nil
+	if isNew {
+		target = &Post{
+			ID:            generateID(),
+			Subtitle:      post.Subtitle,
+			AuthorID:      post.AuthorID,
+			Tags:          post.Tags,
+			Language:      payload.Language,
+			TranslationOf: rootID,
+		}
+		// Another post may already have the derived slug.
+		target.Slug, err = s.uniquePostSlug(ctx, translationSlug(post, payload.Language), target.ID)
+		if err != nil {
+			return fmt.Errorf("assign slug: %w", err)
+		}
+	}
+	target.Title = translated.Title
+	target.MetaDescription = translated.MetaDescription
+	target.ContentMarkdown = translated.ContentMarkdown
+	html, err := s.renderPostHTML(target.ContentMarkdown)
+	if err != nil {
+		return fmt.Errorf("render markdown: %w", err)
+	}
+	target.ContentHTML = html
+
+	if isNew {
+		err = s.store.CreatePost(ctx, target)
+	} else {
+		if _, err = s.snapshotBeforeUpdate(ctx, target); err != nil {
+			return fmt.Errorf("save revision: %w", err)
+		}
+		err = s.store.UpdatePost(ctx, target)
+	}
+	if err != nil {
+		return fmt.Errorf("save translation: %w", err)
+	}
+	s.saveTaskResult(ctx, task, translatePostResult{PostID: target.ID, Slug: target.Slug})
+	return nil
+}
+
+// findTranslation returns the post translating rootID into lang, or nil.
+func (s *service) findTranslation(ctx context.Context, rootID, lang string) (*Post, error) {
+	posts, err := s.store.ListPostTranslations(ctx, rootID)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range posts {
+		if p.TranslationOf == rootID && strings.EqualFold(p.Language, lang) {
+			return &p, nil
+		}
+	}
+	return nil, nil
+}
+
+// translationSlug derives a translation's slug from the original's, such
+// as "original-slug-fr".
+func translationSlug(original *Post, lang string) string {
+	slug := original.Slug
+	if original.Language != "" {
+		slug = strings.TrimSuffix(slug, "-"+strings.ToLower(original.Language))
+	}
+	return slug + "-" + strings.ToLower(lang)
+}
+
+func buildTranslatePrompt(post *Post, lang string) []*llmhub.Message {
+	system := llmhub.NewSystemMessage(llmhub.Text(
+		`You are a professional translator of blog posts. Translate the post into the language with the code the user gives.
+- Keep the markdown structure exactly: headings, lists, tables, emphasis, links, images and HTML tags stay where they are
+- Do not translate code blocks, inline code, URLs, image paths or HTML attribute names
+- Translate link text and image alt text
+- Return ONLY JSON with keys title, meta_description and content_markdown. Do not wrap in code fences.`,
+	))
+	source, _ := json.Marshal(map[string]string{
+		"title":            post.Title,
+		"meta_description": post.MetaDescription,
+		"content_markdown": post.ContentMarkdown,
+	})
+	user := llmhub.NewUserMessage(llmhub.Text("Target language: " + lang + "\n\nPost:\n" + string(source)))
+	return []*llmhub.Message{system, user}
+}
+
+type translatedPost struct {
+	Title           string `json:"title"`
+	MetaDescription string `json:"meta_description"`
+	ContentMarkdown string `json:"content_markdown"`
+}
+
+func parseTranslateResponse(text string) (translatedPost, bool) {
+	var out translatedPost
+	trimmed := stripThinkTags(text)
+	if json.Unmarshal([]byte(trimmed), &out) != nil {
+		obj, ok := extractJSONObject(trimmed)
+		if !ok || json.Unmarshal([]byte(obj), &out) != nil {
+			return translatedPost{}, false
+		}
+	}
+	out.Title = strings.TrimSpace(out.Title)
+	out.MetaDescription = strings.TrimSpace(out.MetaDescription)
+	return out, out.Title != "" && strings.TrimSpace(out.ContentMarkdown) != ""
+}
+
+// postTranslations returns the language switcher entries for post: every
+// live post in its translation group. It returns nil when the post has no
+// live translations.
+func (s *service) postTranslations(r *http.Request, post *Post) []PostTranslation {
+	rootID := translationGroup(post)
+	posts, err := s.store.ListPostTranslations(r.Context(), rootID)
+	if err != nil {
+		return nil
+	}
+	now := time.Now()
+	var out []PostTranslation
+	for _, p := range posts {
+		if !postIsLive(&p, now) {
+			continue
+		}
+		out = append(out, PostTranslation{
+			Language: s.postLanguage(&p),
+			Title:    p.Title,
+			URL:      s.canonicalURL(r, s.postPath(p.Slug)),
+			Current:  p.ID == post.ID,
+			Original: p.ID == rootID,
+		})
+	}
+	if len(out) < 2 {
+		return nil
+	}
+	return out
+}
+
+// postLanguage returns the post's language, falling back to the site
+// language.
+func (s *service) postLanguage(p *Post) string {
+	if p.Language != "" {
+		return p.Language
+	}
+	return s.siteLanguage()
+}
+
+// siteLanguage returns Config.SiteLanguage, or "en".
+func (s *service) siteLanguage() string {
+	if s.cfg.SiteLanguage != "" {
+		return s.cfg.SiteLanguage
+	}
+	return "en"
+}
+
+// feedLanguage returns the language of a feed of posts: the posts' own
+// language when they all share one, otherwise the site language.
+func (s *service) feedLanguage(posts []Post) string {
+	lang := ""
+	for i := range posts {
+		postLang := s.postLanguage(&posts[i])
+		if lang != "" && !strings.EqualFold(lang, postLang) {
+			return s.siteLanguage()
+		}
+		lang = postLang
+	}
+	if lang == "" {
+		return s.siteLanguage()
+	}
+	return lang
+}
@@ -0,0 +1,81 @@
+package blog
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// handleAdminTrashPost moves a post to the trash. It keeps its comments,
+// revisions and slug, and drops out of public pages, feeds and the default
+// admin list until it is restored or purged.
+func (s *service) handleAdminTrashPost(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	post, err := s.store.GetPostByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "failed to load post", http.StatusInternalServerError)
+		return
+	}
+	if post == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if post.DeletedAt == nil {
+		now := time.Now().UTC()
+		post.DeletedAt = &now
+		if err := s.store.UpdatePost(r.Context(), post); err != nil {
+			http.Error(w, "failed to delete post", http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleAdminRestorePost takes a post out of the trash, returning it to the
+// status it had before.
+func (s *service) handleAdminRestorePost(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	post, err := s.store.GetPostByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "failed to load post", http.StatusInternalServerError)
+		return
+	}
+	if post == nil || post.DeletedAt == nil {
+		http.NotFound(w, r)
+		return
+	}
+	post.DeletedAt = nil
+	if err := s.store.UpdatePost(r.Context(), post); err != nil {
+		http.Error(w, "failed to restore post", http.StatusInternalServerError)
+		return
+	}
+	s.queuePostProcessing("post restored")
+	s.maybeQueueUnpublish(r, post)
+	writeJSON(w, post)
+}
+
+// handleAdminPurgePost deletes a trashed post for good, with its revisions,
+// view count, AI chat history, webmentions, comments and reactions. Posts
+// must be trashed first.
+func (s *service) handleAdminPurgePost(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	post, err := s.store.GetPostByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "failed to load post", http.StatusInternalServerError)
+		return
+	}
+	if post == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if post.DeletedAt == nil {
+		http.Error(w, "post is not in the trash", http.StatusConflict)
+		return
+	}
+	if err := s.store.DeletePost(r.Context(), id); err != nil {
+		http.Error(w, "failed to delete post", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
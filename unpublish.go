@@ -0,0 +1,67 @@
+package blog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type unpublishPostPayload struct {
+	PostID     string `json:"post_id"`
+	SitemapURL string `json:"sitemap_url"`
+}
+
+// maybeQueueUnpublish schedules an unpublish task for when p's UnpublishAt
+// arrives. Past unpublish times need no task: the post is saved as
+// unpublished straight away.
+func (s *service) maybeQueueUnpublish(r *http.Request, p *Post) {
+	if p.PublishedAt == nil || p.UnpublishAt == nil || !p.UnpublishAt.After(time.Now()) {
+		return
+	}
+	payload, _ := json.Marshal(unpublishPostPayload{
+		PostID:     p.ID,
+		SitemapURL: s.pingSitemapURL(r),
+	})
+	runAfter := p.UnpublishAt.UTC()
+	task := Task{
+		ID:       generateID(),
+		TaskType: TaskTypeUnpublishPost,
+		Status:   TaskStatusPending,
+		Payload:  string(payload),
+		Result:   "{}",
+		RunAfter: &runAfter,
+	}
+	if err := s.store.CreateTask(context.Background(), &task); err != nil {
+		s.logf("tasks: queue unpublish post=%s: %v", p.ID, err)
+		return
+	}
+	s.tasks.nudge()
+}
+
+// processUnpublishPost re-saves a post whose UnpublishAt has passed, so its
+// stored status drops out of the published queries, then pings search
+// engines so the sitemap is fetched again. Nothing happens if the post was
+// deleted or its UnpublishAt moved since the task was queued.
+func (s *service) processUnpublishPost(ctx context.Context, task *Task) error {
+	var payload unpublishPostPayload
+	if err := json.Unmarshal([]byte(task.Payload), &payload); err != nil {
+		return failTask(fmt.Errorf("invalid payload: %w", err))
+	}
+	post, err := s.store.GetPostByID(ctx, payload.PostID)
+	if err != nil {
+		return err
+	}
+	if post == nil || post.PublishedAt == nil || !postExpired(post, time.Now()) {
+		return nil
+	}
+	if err := s.store.UpdatePost(ctx, post); err != nil {
+		return err
+	}
+	s.logf("tasks: unpublished post=%s", post.ID)
+	if len(s.cfg.PingSearchEngines) > 0 {
+		s.queueSitemapPing(payload.SitemapURL)
+	}
+	return nil
+}
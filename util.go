@@ -7,9 +7,6 @@ import (
 
 	htmd "github.com/JohannesKaufmann/html-to-markdown/v2"
 	"github.com/google/uuid"
-	"github.com/yuin/goldmark"
-	"github.com/yuin/goldmark/extension"
-	gmhtml "github.com/yuin/goldmark/renderer/html"
 )
 
 func generateID() string {
@@ -25,34 +22,20 @@ func hashToken(token string) string {
 	return hex.EncodeToString(sum[:])
 }
 
-// markdownToHTML converts markdown content to HTML using goldmark.
-func markdownToHTML(markdown string) (string, error) {
-	return markdownToHTMLWithOptions(markdown, false)
+// markdownToHTML converts markdown content to HTML using goldmark, with
+// the given extensions. Raw HTML is left out.
+func markdownToHTML(markdown string, ext MarkdownExtensions) (string, error) {
+	return markdownToHTMLWithOptions(markdown, ext, false)
 }
 
 // markdownToHTMLUnsafe converts markdown content to HTML and allows raw HTML passthrough.
-func markdownToHTMLUnsafe(markdown string) (string, error) {
-	return markdownToHTMLWithOptions(markdown, true)
+func markdownToHTMLUnsafe(markdown string, ext MarkdownExtensions) (string, error) {
+	return markdownToHTMLWithOptions(markdown, ext, true)
 }
 
-func markdownToHTMLWithOptions(markdown string, allowUnsafe bool) (string, error) {
+func markdownToHTMLWithOptions(markdown string, ext MarkdownExtensions, allowUnsafe bool) (string, error) {
 	var buf bytes.Buffer
-	md := goldmark.New(
-		goldmark.WithExtensions(
-			extension.Table,
-		),
-	)
-	if allowUnsafe {
-		md = goldmark.New(
-			goldmark.WithExtensions(
-				extension.Table,
-			),
-			goldmark.WithRendererOptions(
-				gmhtml.WithUnsafe(),
-			),
-		)
-	}
-	if err := md.Convert([]byte(markdown), &buf); err != nil {
+	if err := newMarkdown(ext, allowUnsafe, false).Convert([]byte(markdown), &buf); err != nil {
 		return "", err
 	}
 	return buf.String(), nil
@@ -0,0 +1,157 @@
+package blog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// viewDedupWindow is how long repeat views of a post by the same
+	// visitor count once, so refreshing doesn't inflate the count.
+	viewDedupWindow = 30 * time.Minute
+	// viewFlushDelay is how long views are gathered in memory before they
+	// are handed to a record_views task that adds them to the store.
+	viewFlushDelay = 5 * time.Minute
+)
+
+// viewCounter gathers post views in memory between flushes.
+type viewCounter struct {
+	mu      sync.Mutex
+	pending map[string]int       // post ID -> views not yet stored
+	seen    map[string]time.Time // visitor and post -> when last counted
+	timer   *time.Timer          // the scheduled flush, if any
+}
+
+func newViewCounter() *viewCounter {
+	return &viewCounter{pending: map[string]int{}, seen: map[string]time.Time{}}
+}
+
+// record counts a view of postID by visitor unless the visitor was counted
+// for that post within viewDedupWindow. It reports whether a flush needs to
+// be scheduled.
+func (c *viewCounter) record(postID, visitor string, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := visitor + "\x00" + postID
+	if last, ok := c.seen[key]; ok && now.Sub(last) < viewDedupWindow {
+		return false
+	}
+	c.seen[key] = now
+	c.pending[postID]++
+	return c.timer == nil
+}
+
+// schedule runs flush after viewFlushDelay unless a flush is already
+// scheduled.
+func (c *viewCounter) schedule(flush func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.timer == nil {
+		c.timer = time.AfterFunc(viewFlushDelay, flush)
+	}
+}
+
+// take returns the views gathered since the last flush, forgets visitors
+// seen longer ago than viewDedupWindow, and cancels the scheduled flush.
+func (c *viewCounter) take(now time.Time) map[string]int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	counts := c.pending
+	c.pending = map[string]int{}
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+	for key, last := range c.seen {
+		if now.Sub(last) >= viewDedupWindow {
+			delete(c.seen, key)
+		}
+	}
+	return counts
+}
+
+// restore puts back counts that couldn't be stored.
+func (c *viewCounter) restore(counts map[string]int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for postID, n := range counts {
+		c.pending[postID] += n
+	}
+}
+
+// recordViewsPayload is the payload of record_views tasks: the views to
+// add, by post ID. Posts are removed as their views are stored, so a retry
+// doesn't count them twice.
+type recordViewsPayload struct {
+	Counts map[string]int `json:"counts"`
+}
+
+// recordView counts a view of a published post when Config.TrackViews is
+// set. Visitors are told apart by the commenter cookie when they have one,
+// otherwise by IP address; no cookie is issued for this.
+func (s *service) recordView(r *http.Request, post *Post) {
+	if s.views == nil {
+		return
+	}
+	visitor := s.ownerTokenHash(r)
+	if visitor == "" {
+		visitor = hashToken("ip:" + s.clientIP(r))
+	}
+	if s.views.record(post.ID, visitor, time.Now()) {
+		s.views.schedule(s.flushViews)
+	}
+}
+
+// flushViews hands the views gathered in this process to a record_views
+// task. The counts travel in the task's payload, so whichever instance
+// claims the task stores them. Handler.Close saves the views itself, so
+// nothing is queued once it has been called.
+func (s *service) flushViews() {
+	select {
+	case <-s.closing:
+		return
+	default:
+	}
+	counts := s.views.take(time.Now())
+	if len(counts) == 0 {
+		return
+	}
+	payload, _ := json.Marshal(recordViewsPayload{Counts: counts})
+	task := Task{
+		ID:       generateID(),
+		TaskType: TaskTypeRecordViews,
+		Status:   TaskStatusPending,
+		Payload:  string(payload),
+		Result:   "{}",
+	}
+	if err := s.store.CreateTask(context.Background(), &task); err != nil {
+		s.logf("tasks: queue record views: %v", err)
+		s.views.restore(counts)
+		s.views.schedule(s.flushViews)
+		return
+	}
+	s.tasks.nudge()
+}
+
+// processRecordViews adds the views in the task's payload to the stored
+// counts. When only some are stored, the payload keeps the rest for the
+// retry. Views not yet handed to a task when the process stops without
+// Handler.Close are lost.
+func (s *service) processRecordViews(ctx context.Context, task *Task) error {
+	var payload recordViewsPayload
+	if err := json.Unmarshal([]byte(task.Payload), &payload); err != nil {
+		return failTask(fmt.Errorf("invalid payload: %w", err))
+	}
+	posts := len(payload.Counts)
+	if err := s.store.AddPostViews(ctx, payload.Counts); err != nil {
+		remaining, _ := json.Marshal(payload)
+		task.Payload = string(remaining)
+		return err
+	}
+	s.saveTaskResult(ctx, task, map[string]int{"posts": posts})
+	return nil
+}
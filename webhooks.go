@@ -0,0 +1,159 @@
+package blog
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Webhook event types.
+const (
+	WebhookEventCommentCreated       = "comment.created"
+	WebhookEventCommentStatusChanged = "comment.status_changed"
+	WebhookEventPostPublished        = "post.published"
+)
+
+// webhookMaxAttempts bounds deliveries of a single event.
+const webhookMaxAttempts = 5
+
+// webhookRetryBackoff is the delay before the first retry; it doubles with
+// each further attempt.
+var webhookRetryBackoff = 10 * time.Second
+
+// webhookEvent is the JSON body POSTed to Config.WebhookURL.
+type webhookEvent struct {
+	Event          string       `json:"event"`
+	Timestamp      time.Time    `json:"timestamp"`
+	Post           *webhookPost `json:"post,omitempty"`
+	Comment        *Comment     `json:"comment,omitempty"`
+	PreviousStatus string       `json:"previous_status,omitempty"`
+}
+
+type webhookPost struct {
+	ID          string     `json:"id"`
+	Slug        string     `json:"slug"`
+	Title       string     `json:"title"`
+	URL         string     `json:"url"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+}
+
+type webhookPayload struct {
+	Event string `json:"event"`
+	Body  string `json:"body"`
+}
+
+func (s *service) webhookPost(r *http.Request, p *Post) *webhookPost {
+	return s.webhookPostAt(s.baseURL(r), p)
+}
+
+// webhookPostAt is webhookPost for code without a request, such as
+// background tasks, given the site's base URL.
+func (s *service) webhookPostAt(base string, p *Post) *webhookPost {
+	return &webhookPost{
+		ID:          p.ID,
+		Slug:        p.Slug,
+		Title:       p.Title,
+		URL:         base + s.routePrefix + s.postPath(p.Slug),
+		PublishedAt: p.PublishedAt,
+	}
+}
+
+// queueCommentStatusWebhook reports a moderation change to comment.
+func (s *service) queueCommentStatusWebhook(r *http.Request, comment Comment, status string) {
+	if s.cfg.WebhookURL == "" {
+		return
+	}
+	event := webhookEvent{Event: WebhookEventCommentStatusChanged, PreviousStatus: comment.Status}
+	comment.Status = status
+	event.Comment = &comment
+	if post, err := s.store.GetPostByID(r.Context(), comment.PostID); err == nil && post != nil {
+		event.Post = s.webhookPost(r, post)
+	}
+	s.queueWebhook(event)
+}
+
+// queueWebhook records an event for asynchronous delivery to
+// Config.WebhookURL. It does nothing when no webhook is configured.
+func (s *service) queueWebhook(event webhookEvent) {
+	if s.cfg.WebhookURL == "" {
+		return
+	}
+	event.Timestamp = time.Now().UTC()
+	body, err := json.Marshal(event)
+	if err != nil {
+		s.logf("webhook: encode %s: %v", event.Event, err)
+		return
+	}
+	payload, _ := json.Marshal(webhookPayload{Event: event.Event, Body: string(body)})
+	task := Task{
+		ID:       generateID(),
+		TaskType: TaskTypeWebhook,
+		Status:   TaskStatusPending,
+		Payload:  string(payload),
+		Result:   "{}",
+	}
+	if err := s.store.CreateTask(context.Background(), &task); err != nil {
+		s.logf("tasks: queue webhook event=%s: %v", event.Event, err)
+		return
+	}
+	s.tasks.nudge()
+}
+
+// processWebhook POSTs one event, retrying network errors, 5xx and 429
+// responses with backoff up to webhookMaxAttempts times.
+func (s *service) processWebhook(ctx context.Context, task *Task) error {
+	var payload webhookPayload
+	if err := json.Unmarshal([]byte(task.Payload), &payload); err != nil {
+		return failTask(fmt.Errorf("invalid payload: %w", err))
+	}
+	if s.cfg.WebhookURL == "" {
+		return nil
+	}
+
+	err := s.deliverWebhook(ctx, payload.Event, []byte(payload.Body))
+	if err == nil || !retryableDelivery(err) || task.Attempts >= webhookMaxAttempts {
+		return failTask(err)
+	}
+	delay := webhookRetryBackoff
+	for i := 1; i < task.Attempts; i++ {
+		delay *= 2
+	}
+	return retryTaskAfter(err, delay)
+}
+
+func (s *service) deliverWebhook(ctx context.Context, event string, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Spore-Webhook")
+	req.Header.Set("X-Spore-Event", event)
+	if s.cfg.WebhookSecret != "" {
+		req.Header.Set("X-Spore-Signature", webhookSignature(s.cfg.WebhookSecret, body))
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &httpStatusError{msg: "webhook responded", code: resp.StatusCode}
+	}
+	return nil
+}
+
+// webhookSignature returns "sha256=" followed by the hex HMAC-SHA256 of body.
+func webhookSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,264 @@
+package blog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Webmention statuses. Only verified mentions are shown on posts.
+const (
+	webmentionPending  = "pending"
+	webmentionVerified = "verified"
+	webmentionRejected = "rejected"
+)
+
+const (
+	// webmentionFetchTimeout bounds the fetch of a mention's source.
+	webmentionFetchTimeout = 30 * time.Second
+	// webmentionMaxSourceBytes is how much of the source page is read
+	// when looking for the link back.
+	webmentionMaxSourceBytes = 1 << 20
+)
+
+var (
+	htmlHrefRe  = regexp.MustCompile(`(?i)\bhref\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+	htmlTitleRe = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+)
+
+type verifyWebmentionPayload struct {
+	ID string `json:"id"`
+}
+
+// handleReceiveWebmention accepts a webmention when target is one of the
+// blog's published posts. The source is fetched later by a
+// verify_webmention task, so senders get 202 Accepted straight away.
+func (s *service) handleReceiveWebmention(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, 64<<10)
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+	source := strings.TrimSpace(r.PostForm.Get("source"))
+	target := strings.TrimSpace(r.PostForm.Get("target"))
+	sourceURL, err := url.Parse(source)
+	if err != nil || (sourceURL.Scheme != "http" && sourceURL.Scheme != "https") || sourceURL.Host == "" {
+		http.Error(w, "source must be an absolute http or https url", http.StatusBadRequest)
+		return
+	}
+	targetURL, err := url.Parse(target)
+	if err != nil || (targetURL.Scheme != "http" && targetURL.Scheme != "https") || targetURL.Host == "" {
+		http.Error(w, "target must be an absolute http or https url", http.StatusBadRequest)
+		return
+	}
+	if source == target {
+		http.Error(w, "source and target must differ", http.StatusBadRequest)
+		return
+	}
+
+	post, err := s.webmentionTarget(r, targetURL)
+	if err != nil {
+		http.Error(w, "failed to load post", http.StatusInternalServerError)
+		return
+	}
+	if post == nil {
+		http.Error(w, "target is not a post on this blog", http.StatusBadRequest)
+		return
+	}
+
+	if s.commentLimiter != nil {
+		ok, retryAfter := s.commentLimiter.allow(time.Now(), "webmention-ip:"+s.clientIP(r))
+		if !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			http.Error(w, "too many webmentions, please try again later", http.StatusTooManyRequests)
+			return
+		}
+	}
+
+	// A repeated mention keeps its status until verification runs again,
+	// so an update doesn't hide a mention that is already shown.
+	id := webmentionEntityID(source, target)
+	mention, err := s.store.GetWebmention(r.Context(), id)
+	if err != nil {
+		http.Error(w, "failed to save webmention", http.StatusInternalServerError)
+		return
+	}
+	if mention == nil {
+		mention = &Webmention{
+			ID:        id,
+			PostID:    post.ID,
+			Source:    source,
+			Target:    target,
+			Status:    webmentionPending,
+			CreatedAt: time.Now().UTC(),
+		}
+		if err := s.store.SaveWebmention(r.Context(), mention); err != nil {
+			http.Error(w, "failed to save webmention", http.StatusInternalServerError)
+			return
+		}
+	}
+	if err := s.queueWebmentionVerification(mention.ID); err != nil {
+		http.Error(w, "failed to queue verification", http.StatusInternalServerError)
+		return
+	}
+	writeJSONStatus(w, http.StatusAccepted, mention)
+}
+
+// webmentionTarget returns the published post target points to, or nil
+// when it is not a post URL on this blog.
+func (s *service) webmentionTarget(r *http.Request, target *url.URL) (*Post, error) {
+	base, err := url.Parse(s.baseURL(r))
+	if err != nil || !strings.EqualFold(target.Host, base.Host) {
+		return nil, nil
+	}
+	slug, ok := strings.CutPrefix(target.Path, s.routePrefix+"/")
+	if !ok || slug == "" {
+		return nil, nil
+	}
+	post, err := s.store.GetPublishedPostBySlug(r.Context(), slug)
+	if post != nil || err != nil {
+		return post, err
+	}
+	return s.findCanonicalPost(r.Context(), slug)
+}
+
+func (s *service) queueWebmentionVerification(id string) error {
+	payload, _ := json.Marshal(verifyWebmentionPayload{ID: id})
+	task := Task{
+		ID:       generateID(),
+		TaskType: TaskTypeVerifyWebmention,
+		Status:   TaskStatusPending,
+		Payload:  string(payload),
+		Result:   "{}",
+	}
+	if err := s.store.CreateTask(context.Background(), &task); err != nil {
+		s.logf("tasks: queue webmention verification: %v", err)
+		return err
+	}
+	s.tasks.nudge()
+	return nil
+}
+
+// processVerifyWebmention fetches a mention's source and marks the mention
+// verified if the page links to its target, or rejected if it doesn't or
+// is gone. Network errors and server errors fail the task so it is
+// retried.
+func (s *service) processVerifyWebmention(ctx context.Context, task *Task) error {
+	var payload verifyWebmentionPayload
+	if err := json.Unmarshal([]byte(task.Payload), &payload); err != nil {
+		return failTask(fmt.Errorf("invalid payload: %w", err))
+	}
+	mention, err := s.store.GetWebmention(ctx, payload.ID)
+	if err != nil {
+		return err
+	}
+	if mention == nil {
+		return nil
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, webmentionFetchTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(fetchCtx, http.MethodGet, mention.Source, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/html, */*;q=0.5")
+	resp, err := s.remoteImportClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("fetch source: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("fetch source: http status %d", resp.StatusCode)
+	}
+
+	mention.Status = webmentionRejected
+	mention.VerifiedAt = nil
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		body, err := io.ReadAll(io.LimitReader(resp.Body, webmentionMaxSourceBytes))
+		if err != nil {
+			return fmt.Errorf("read source: %w", err)
+		}
+		isHTML := strings.Contains(strings.ToLower(resp.Header.Get("Content-Type")), "html")
+		if linksTo(string(body), mention.Target, isHTML) {
+			now := time.Now().UTC()
+			mention.Status = webmentionVerified
+			mention.VerifiedAt = &now
+			if isHTML {
+				mention.Title = htmlTitle(string(body))
+			}
+		}
+	}
+	if err := s.store.SaveWebmention(ctx, mention); err != nil {
+		return err
+	}
+	s.saveTaskResult(ctx, task, map[string]string{"status": mention.Status})
+	return nil
+}
+
+// linksTo reports whether body links to target. HTML pages need an href
+// to it; other documents need only mention the URL.
+func linksTo(body, target string, isHTML bool) bool {
+	if !isHTML {
+		return strings.Contains(body, target)
+	}
+	want := strings.TrimSuffix(target, "/")
+	for _, m := range htmlHrefRe.FindAllStringSubmatch(body, -1) {
+		href := html.UnescapeString(m[1] + m[2] + m[3])
+		if strings.TrimSuffix(strings.TrimSpace(href), "/") == want {
+			return true
+		}
+	}
+	return false
+}
+
+// htmlTitle returns the text of the page's <title>, shortened for display.
+func htmlTitle(body string) string {
+	m := htmlTitleRe.FindStringSubmatch(body)
+	if m == nil {
+		return ""
+	}
+	return trimToLength(strings.Join(strings.Fields(html.UnescapeString(m[1])), " "), 200)
+}
+
+// handleAdminListWebmentions lists received webmentions, newest first,
+// optionally for one post (?post_id=) or with one status (?status=).
+func (s *service) handleAdminListWebmentions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	mentions, err := s.store.ListWebmentions(r.Context(), query.Get("post_id"), query.Get("status"))
+	if err != nil {
+		http.Error(w, "failed to list webmentions", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, mentions)
+}
+
+// handleAdminDeleteWebmention removes a webmention.
+func (s *service) handleAdminDeleteWebmention(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	mention, err := s.store.GetWebmention(r.Context(), id)
+	if err != nil {
+		http.Error(w, "failed to load webmention", http.StatusInternalServerError)
+		return
+	}
+	if mention == nil {
+		http.Error(w, "webmention not found", http.StatusNotFound)
+		return
+	}
+	if err := s.store.DeleteWebmention(r.Context(), id); err != nil {
+		http.Error(w, "failed to delete webmention", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -1,6 +1,7 @@
 package blog
 
 import (
+	"bytes"
 	"context"
 	"encoding/xml"
 	"fmt"
@@ -39,17 +40,17 @@ type wxrRSS struct {
 }
 
 type wxrChannel struct {
-	Title       string      `xml:"title"`
-	Link        string      `xml:"link"`
-	Description string      `xml:"description"`
-	PubDate     string      `xml:"pubDate"`
-	Language    string      `xml:"language,omitempty"`
-	WXRVersion  string      `xml:"wp:wxr_version"`
-	BaseSiteURL string      `xml:"wp:base_site_url"`
-	BaseBlogURL string      `xml:"wp:base_blog_url"`
-	Authors     []wxrAuthor `xml:"wp:author,omitempty"`
-	Tags        []wxrTag    `xml:"wp:tag,omitempty"`
-	Items       []wxrItem   `xml:"item"`
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	Description string        `xml:"description"`
+	PubDate     string        `xml:"pubDate"`
+	Language    string        `xml:"language,omitempty"`
+	WXRVersion  string        `xml:"wp:wxr_version"`
+	BaseSiteURL string        `xml:"wp:base_site_url"`
+	BaseBlogURL string        `xml:"wp:base_blog_url"`
+	Authors     []wxrAuthor   `xml:"wp:author,omitempty"`
+	Tags        []wxrTag      `xml:"wp:tag,omitempty"`
+	Items       wxrItemStream `xml:"item"`
 }
 
 type wxrAuthor struct {
@@ -81,6 +82,7 @@ type wxrItem struct {
 	Description    string        `xml:"description"`
 	ContentEncoded cdataString   `xml:"content:encoded"`
 	ExcerptEncoded cdataString   `xml:"excerpt:encoded,omitempty"`
+	SporeMarkdown  cdataString   `xml:"wp:spore_markdown,omitempty"`
 	PostID         int           `xml:"wp:post_id"`
 	PostDate       string        `xml:"wp:post_date"`
 	PostDateGMT    string        `xml:"wp:post_date_gmt"`
@@ -121,9 +123,15 @@ type wxrImport struct {
 }
 
 type wxrImportChannel struct {
-	BaseSiteURL string          `xml:"http://wordpress.org/export/1.2/ base_site_url"`
-	BaseBlogURL string          `xml:"http://wordpress.org/export/1.2/ base_blog_url"`
-	Items       []wxrImportItem `xml:"item"`
+	BaseSiteURL string            `xml:"http://wordpress.org/export/1.2/ base_site_url"`
+	BaseBlogURL string            `xml:"http://wordpress.org/export/1.2/ base_blog_url"`
+	Authors     []wxrImportAuthor `xml:"http://wordpress.org/export/1.2/ author"`
+	Items       []wxrImportItem   `xml:"item"`
+}
+
+type wxrImportAuthor struct {
+	Login       string `xml:"http://wordpress.org/export/1.2/ author_login"`
+	DisplayName string `xml:"http://wordpress.org/export/1.2/ author_display_name"`
 }
 
 type wxrImportItem struct {
@@ -135,12 +143,14 @@ type wxrImportItem struct {
 	Description    string              `xml:"description"`
 	ContentEncoded string              `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
 	ExcerptEncoded string              `xml:"http://wordpress.org/export/1.2/excerpt/ encoded"`
+	SporeMarkdown  string              `xml:"http://wordpress.org/export/1.2/ spore_markdown"`
 	PostID         string              `xml:"http://wordpress.org/export/1.2/ post_id"`
 	PostDate       string              `xml:"http://wordpress.org/export/1.2/ post_date"`
 	PostDateGMT    string              `xml:"http://wordpress.org/export/1.2/ post_date_gmt"`
 	PostName       string              `xml:"http://wordpress.org/export/1.2/ post_name"`
 	Status         string              `xml:"http://wordpress.org/export/1.2/ status"`
 	PostType       string              `xml:"http://wordpress.org/export/1.2/ post_type"`
+	AttachmentURL  string              `xml:"http://wordpress.org/export/1.2/ attachment_url"`
 	Categories     []wxrImportCategory `xml:"category"`
 	Comments       []wxrImportComment  `xml:"http://wordpress.org/export/1.2/ comment"`
 }
@@ -170,15 +180,91 @@ type wxrImportResult struct {
 	PostsSkipped    int `json:"posts_skipped"`
 	CommentsAdded   int `json:"comments_added"`
 	CommentsSkipped int `json:"comments_skipped"`
+	PagesAdded      int `json:"pages_added"`
+	// AttachmentsFound counts media library items. Their images are
+	// re-hosted by the image import task along with the posts' images.
+	AttachmentsFound int `json:"attachments_found"`
+	// UnmappedAuthors lists the export's authors that have no entry in
+	// Config.AuthorLoginToID. Their posts get the default author.
+	UnmappedAuthors []wxrUnmappedAuthor `json:"unmapped_authors,omitempty"`
 	// Internal tracking (not serialised to JSON).
 	importedPostIDs          []string
+	attachmentURLs           []string
 	postsNeedingDescriptions []string
 	postsNeedingTags         []string
 	baseSiteURL              string
 }
 
+// wxrExportFilter narrows an export to recently changed posts and can leave
+// out comments, for incremental backups and syncs.
+type wxrExportFilter struct {
+	since           time.Time
+	includeComments bool
+}
+
+// parseWXRExportFilter reads ?since (a date or RFC 3339 time) and
+// ?include_comments (default true).
+func parseWXRExportFilter(r *http.Request) (wxrExportFilter, error) {
+	filter := wxrExportFilter{includeComments: true}
+	q := r.URL.Query()
+	if v := strings.TrimSpace(q.Get("since")); v != "" {
+		since, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			since, err = time.Parse(time.DateOnly, v)
+		}
+		if err != nil {
+			return filter, fmt.Errorf("invalid since")
+		}
+		filter.since = since
+	}
+	if v := strings.TrimSpace(q.Get("include_comments")); v != "" {
+		include, err := strconv.ParseBool(v)
+		if err != nil {
+			return filter, fmt.Errorf("invalid include_comments")
+		}
+		filter.includeComments = include
+	}
+	return filter, nil
+}
+
+// includes reports whether post was published or updated at or after the
+// filter's since time.
+func (f wxrExportFilter) includes(post Post) bool {
+	if f.since.IsZero() {
+		return true
+	}
+	return (post.PublishedAt != nil && !post.PublishedAt.Before(f.since)) ||
+		(post.UpdatedAt != nil && !post.UpdatedAt.Before(f.since))
+}
+
+// wxrItemStream produces the items of an export one at a time. It encodes
+// as a run of <item> elements, so the export is written as it is built.
+type wxrItemStream func(yield func(wxrItem) error) error
+
+func (st wxrItemStream) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return st(func(item wxrItem) error {
+		return e.EncodeElement(item, start)
+	})
+}
+
+// handleAdminExportWXR streams every post as a WXR document. ?since limits
+// it to posts published or updated since a date, and
+// ?include_comments=false leaves comments out.
 func (s *service) handleAdminExportWXR(w http.ResponseWriter, r *http.Request) {
-	posts, err := s.listAllPosts(r.Context())
+	filter, err := parseWXRExportFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// The channel lists the tags before any item, so collect them in a
+	// first pass; the items are read again as they are written.
+	tags := wxrTagSet{}
+	err = s.eachPost(r.Context(), func(post Post) error {
+		if filter.includes(post) {
+			tags.add(post)
+		}
+		return nil
+	})
 	if err != nil {
 		http.Error(w, "failed to list posts", http.StatusInternalServerError)
 		return
@@ -207,103 +293,20 @@ func (s *service) handleAdminExportWXR(w http.ResponseWriter, r *http.Request) {
 		language = "en-US"
 	}
 
-	tags := collectTags(posts)
-
-	items := make([]wxrItem, 0, len(posts))
-	postID := 1
-	commentID := 1
-	for _, post := range posts {
-		postDate := time.Now().UTC()
-		status := "draft"
-		if post.PublishedAt != nil {
-			postDate = post.PublishedAt.UTC()
-			status = "publish"
-		}
-
-		contentHTML := strings.TrimSpace(post.ContentHTML)
-		if contentHTML == "" && strings.TrimSpace(post.ContentMarkdown) != "" {
-			if html, err := markdownToHTMLUnsafe(post.ContentMarkdown); err == nil {
-				contentHTML = html
-			} else {
-				contentHTML = post.ContentMarkdown
+	items := func(yield func(wxrItem) error) error {
+		postID := 1
+		commentID := 1
+		return s.eachPost(r.Context(), func(post Post) error {
+			if !filter.includes(post) {
+				return nil
 			}
-		}
-
-		link := strings.TrimSuffix(baseBlogURL, "/") + "/" + strings.TrimPrefix(post.Slug, "/")
-		guid := strings.TrimSuffix(baseBlogURL, "/") + "/?p=" + strconv.Itoa(postID)
-
-		categoryNodes := make([]wxrCategory, 0, len(post.Tags))
-		for _, tag := range post.Tags {
-			slug := strings.TrimSpace(tag.Slug)
-			if slug == "" {
-				slug = tagSlug(tag.Name)
+			item, err := s.wxrExportItem(r.Context(), post, postID, &commentID, commentStatus, baseBlogURL, filter.includeComments)
+			if err != nil {
+				return err
 			}
-			categoryNodes = append(categoryNodes, wxrCategory{
-				Domain:   "post_tag",
-				Nicename: slug,
-				Name:     cdataString(tag.Name),
-			})
-		}
-
-		comments, err := s.store.ListCommentsByPost(r.Context(), post.ID)
-		if err != nil {
-			http.Error(w, "failed to load comments", http.StatusInternalServerError)
-			return
-		}
-
-		commentIDMap := map[string]int{}
-		for _, c := range comments {
-			commentIDMap[c.ID] = commentID
-			commentID++
-		}
-
-		commentNodes := make([]wxrComment, 0, len(comments))
-		for _, c := range comments {
-			parentID := 0
-			if c.ParentID != nil {
-				if mapped, ok := commentIDMap[*c.ParentID]; ok {
-					parentID = mapped
-				}
-			}
-			commentNodes = append(commentNodes, wxrComment{
-				CommentID:          commentIDMap[c.ID],
-				CommentAuthor:      cdataString(c.AuthorName),
-				CommentAuthorEmail: "",
-				CommentAuthorURL:   "",
-				CommentAuthorIP:    "",
-				CommentDate:        formatWXRDateTime(c.CreatedAt),
-				CommentDateGMT:     formatWXRDateTime(c.CreatedAt.UTC()),
-				CommentContent:     cdataString(c.Content),
-				CommentApproved:    exportCommentStatus(c.Status),
-				CommentType:        "comment",
-				CommentParent:      parentID,
-			})
-		}
-
-		items = append(items, wxrItem{
-			Title:          post.Title,
-			Link:           link,
-			PubDate:        postDate.Format(time.RFC1123Z),
-			Creator:        cdataString(defaultExportAuthorLogin(s.cfg.DefaultAuthorLogin)),
-			GUID:           wxrGUID{IsPermaLink: "false", Value: guid},
-			Description:    "",
-			ContentEncoded: cdataString(contentHTML),
-			ExcerptEncoded: cdataString(strings.TrimSpace(post.MetaDescription)),
-			PostID:         postID,
-			PostDate:       formatWXRDateTime(postDate),
-			PostDateGMT:    formatWXRDateTime(postDate.UTC()),
-			CommentStatus:  commentStatus,
-			PingStatus:     "open",
-			PostName:       post.Slug,
-			Status:         status,
-			PostParent:     0,
-			MenuOrder:      0,
-			PostType:       "post",
-			IsSticky:       0,
-			Categories:     categoryNodes,
-			Comments:       commentNodes,
+			postID++
+			return yield(item)
 		})
-		postID++
 	}
 
 	rss := wxrRSS{
@@ -330,21 +333,118 @@ func (s *service) handleAdminExportWXR(w http.ResponseWriter, r *http.Request) {
 					AuthorDisplayName: cdataString(defaultExportAuthorDisplay(s.cfg.DefaultAuthorDisplayName)),
 				},
 			},
-			Tags:  tags,
+			Tags:  tags.list(),
 			Items: items,
 		},
 	}
 
-	w.Header().Set("Content-Type", "text/xml; charset=utf-8")
 	w.Header().Set("Content-Disposition", "attachment; filename=blog-export.xml")
-	_, _ = io.WriteString(w, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	if started, err := streamXML(w, "text/xml", rss); err != nil {
+		if !started {
+			http.Error(w, "failed to build export", http.StatusInternalServerError)
+			return
+		}
+		s.logf("wxr export failed part way: %v", err)
+	}
+}
 
-	enc := xml.NewEncoder(w)
-	enc.Indent("", "  ")
-	if err := enc.Encode(rss); err != nil {
-		http.Error(w, "failed to build export", http.StatusInternalServerError)
-		return
+// wxrExportItem converts a post to a WXR item. Its comments are numbered
+// from *commentID, which is advanced past them.
+func (s *service) wxrExportItem(ctx context.Context, post Post, postID int, commentID *int, commentStatus, baseBlogURL string, includeComments bool) (wxrItem, error) {
+	postDate := time.Now().UTC()
+	status := "draft"
+	if post.PublishedAt != nil {
+		postDate = post.PublishedAt.UTC()
+		status = "publish"
+	}
+
+	contentHTML := strings.TrimSpace(post.ContentHTML)
+	if contentHTML == "" && strings.TrimSpace(post.ContentMarkdown) != "" {
+		if html, err := s.renderPostHTML(post.ContentMarkdown); err == nil {
+			contentHTML = html
+		} else {
+			contentHTML = post.ContentMarkdown
+		}
+	}
+
+	link := strings.TrimSuffix(baseBlogURL, "/") + "/" + strings.TrimPrefix(post.Slug, "/")
+	guid := strings.TrimSuffix(baseBlogURL, "/") + "/?p=" + strconv.Itoa(postID)
+
+	categoryNodes := make([]wxrCategory, 0, len(post.Tags))
+	for _, tag := range post.Tags {
+		slug := strings.TrimSpace(tag.Slug)
+		if slug == "" {
+			slug = tagSlug(tag.Name)
+		}
+		categoryNodes = append(categoryNodes, wxrCategory{
+			Domain:   "post_tag",
+			Nicename: slug,
+			Name:     cdataString(tag.Name),
+		})
+	}
+
+	var comments []Comment
+	if includeComments {
+		var err error
+		comments, err = s.store.ListCommentsByPost(ctx, post.ID)
+		if err != nil {
+			return wxrItem{}, fmt.Errorf("load comments: %w", err)
+		}
+	}
+
+	commentIDMap := map[string]int{}
+	for _, c := range comments {
+		commentIDMap[c.ID] = *commentID
+		*commentID++
 	}
+
+	commentNodes := make([]wxrComment, 0, len(comments))
+	for _, c := range comments {
+		parentID := 0
+		if c.ParentID != nil {
+			if mapped, ok := commentIDMap[*c.ParentID]; ok {
+				parentID = mapped
+			}
+		}
+		commentNodes = append(commentNodes, wxrComment{
+			CommentID:          commentIDMap[c.ID],
+			CommentAuthor:      cdataString(c.AuthorName),
+			CommentAuthorEmail: "",
+			CommentAuthorURL:   "",
+			CommentAuthorIP:    "",
+			CommentDate:        formatWXRDateTime(c.CreatedAt),
+			CommentDateGMT:     formatWXRDateTime(c.CreatedAt.UTC()),
+			CommentContent:     cdataString(c.Content),
+			CommentApproved:    exportCommentStatus(c.Status),
+			CommentType:        "comment",
+			CommentParent:      parentID,
+		})
+	}
+
+	return wxrItem{
+		Title:          post.Title,
+		Link:           link,
+		PubDate:        postDate.Format(time.RFC1123Z),
+		Creator:        cdataString(defaultExportAuthorLogin(s.cfg.DefaultAuthorLogin)),
+		GUID:           wxrGUID{IsPermaLink: "false", Value: guid},
+		Description:    "",
+		ContentEncoded: cdataString(contentHTML),
+		ExcerptEncoded: cdataString(strings.TrimSpace(post.MetaDescription)),
+		SporeMarkdown:  cdataString(post.ContentMarkdown),
+		PostID:         postID,
+		PostDate:       formatWXRDateTime(postDate),
+		PostDateGMT:    formatWXRDateTime(postDate.UTC()),
+		CommentStatus:  commentStatus,
+		PingStatus:     "open",
+		PostName:       post.Slug,
+		Status:         status,
+		PostParent:     0,
+		MenuOrder:      0,
+		PostType:       firstNonEmpty(post.PostType, "post"),
+		IsSticky:       0,
+		Categories:     categoryNodes,
+		Comments:       commentNodes,
+	}, nil
 }
 
 func (s *service) handleAdminImportWXR(w http.ResponseWriter, r *http.Request) {
@@ -356,32 +456,40 @@ func (s *service) handleAdminImportWXR(w http.ResponseWriter, r *http.Request) {
 	if closer, ok := reader.(io.Closer); ok {
 		defer closer.Close()
 	}
-	payload, err := io.ReadAll(reader)
-	if err != nil {
-		http.Error(w, "failed to read import", http.StatusBadRequest)
-		return
-	}
 
-	result, err := s.importWXR(r.Context(), payload)
+	result, err := s.importWXR(r.Context(), reader)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	s.queueWXRFollowUps(result)
 
-	// Queue background task to enrich imported posts.
+	writeJSON(w, result)
+}
+
+// queueWXRFollowUps queues background tasks to enrich imported posts.
+func (s *service) queueWXRFollowUps(result wxrImportResult) {
 	if len(result.importedPostIDs) > 0 {
 		s.queuePostProcessing("wxr import")
 	}
-	if result.baseSiteURL != "" && s.cfg.ImageStore != nil && len(result.importedPostIDs) > 0 {
-		s.queueImageImport(result.baseSiteURL, result.importedPostIDs)
+	if result.baseSiteURL != "" && s.cfg.ImageStore != nil && (len(result.importedPostIDs) > 0 || len(result.attachmentURLs) > 0) {
+		s.queueImageImport(result.baseSiteURL, result.importedPostIDs, result.attachmentURLs)
 	}
+}
 
-	writeJSON(w, result)
+// wxrUnmappedAuthor is an author of an imported WXR file with no local
+// author ID. Posts counts the posts imported with the default author
+// instead.
+type wxrUnmappedAuthor struct {
+	Login       string `json:"login"`
+	DisplayName string `json:"display_name,omitempty"`
+	Posts       int    `json:"posts"`
 }
 
-func (s *service) importWXR(ctx context.Context, payload []byte) (wxrImportResult, error) {
+// importWXR decodes a WXR document from r and imports its posts and comments.
+func (s *service) importWXR(ctx context.Context, r io.Reader) (wxrImportResult, error) {
 	var doc wxrImport
-	if err := xml.Unmarshal(payload, &doc); err != nil {
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
 		return wxrImportResult{}, fmt.Errorf("invalid xml: %w", err)
 	}
 
@@ -405,13 +513,18 @@ func (s *service) importWXR(ctx context.Context, payload []byte) (wxrImportResul
 	result := wxrImportResult{
 		baseSiteURL: baseSiteURL,
 	}
+	authors := s.newWXRAuthorMapper(doc.Channel.Authors)
 	for _, item := range doc.Channel.Items {
 		postType := strings.ToLower(strings.TrimSpace(item.PostType))
 		if postType == "attachment" {
+			if u := strings.TrimSpace(item.AttachmentURL); u != "" {
+				result.AttachmentsFound++
+				result.attachmentURLs = append(result.attachmentURLs, u)
+			}
 			continue
 		}
-		if postType == "" {
-			postType = "post"
+		if postType != PostTypePage {
+			postType = ""
 		}
 
 		slug := importItemSlug(item)
@@ -434,6 +547,7 @@ func (s *service) importWXR(ctx context.Context, payload []byte) (wxrImportResul
 			if contentHTML == "" {
 				contentHTML = strings.TrimSpace(item.ExcerptEncoded)
 			}
+			contentHTML = s.sanitizeStoredHTML(contentHTML)
 
 			postDate := parseWXRDate(item.PostDateGMT)
 			if postDate.IsZero() {
@@ -449,9 +563,14 @@ func (s *service) importWXR(ctx context.Context, payload []byte) (wxrImportResul
 				publishedAt = &postDate
 			}
 
-			contentMarkdown := contentHTML
-			if md, err := htmlToMarkdown(contentHTML); err == nil && strings.TrimSpace(md) != "" {
-				contentMarkdown = md
+			// Spore exports carry the source Markdown, which converting
+			// the HTML back would only approximate.
+			contentMarkdown := item.SporeMarkdown
+			if strings.TrimSpace(contentMarkdown) == "" {
+				contentMarkdown = contentHTML
+				if md, err := htmlToMarkdown(contentHTML); err == nil && strings.TrimSpace(md) != "" {
+					contentMarkdown = md
+				}
 			}
 
 			post := Post{
@@ -462,13 +581,18 @@ func (s *service) importWXR(ctx context.Context, payload []byte) (wxrImportResul
 				ContentHTML:     contentHTML,
 				PublishedAt:     publishedAt,
 				MetaDescription: strings.TrimSpace(firstNonEmpty(item.ExcerptEncoded, item.Description)),
-				AuthorID:        defaultImportAuthorID(s.cfg.ImportAuthorID),
+				AuthorID:        authors.resolve(item.Creator),
+				PostType:        postType,
 			}
 
 			if err := s.store.CreatePost(ctx, &post); err != nil {
 				return result, fmt.Errorf("create post: %w", err)
 			}
-			result.PostsAdded++
+			if postType == PostTypePage {
+				result.PagesAdded++
+			} else {
+				result.PostsAdded++
+			}
 			result.importedPostIDs = append(result.importedPostIDs, post.ID)
 			if strings.TrimSpace(post.MetaDescription) == "" {
 				result.postsNeedingDescriptions = append(result.postsNeedingDescriptions, post.ID)
@@ -481,7 +605,7 @@ func (s *service) importWXR(ctx context.Context, payload []byte) (wxrImportResul
 				if err := s.store.SetPostTags(ctx, post.ID, tagNames); err != nil {
 					return result, fmt.Errorf("set tags: %w", err)
 				}
-			} else if strings.TrimSpace(post.ContentMarkdown) != "" {
+			} else if strings.TrimSpace(post.ContentMarkdown) != "" && postType != PostTypePage {
 				result.postsNeedingTags = append(result.postsNeedingTags, post.ID)
 			}
 		}
@@ -502,7 +626,8 @@ func (s *service) importWXR(ctx context.Context, payload []byte) (wxrImportResul
 
 		sortedComments := splitImportComments(item.Comments)
 		importedMap := map[string]string{}
-		for _, comment := range sortedComments.topLevel {
+		var newComments []Comment
+		addComment := func(comment wxrImportComment, parentID *string) {
 			createdAt := parseWXRDate(comment.CommentDateGMT)
 			if createdAt.IsZero() {
 				createdAt = parseWXRDate(comment.CommentDate)
@@ -514,73 +639,57 @@ func (s *service) importWXR(ctx context.Context, payload []byte) (wxrImportResul
 			key := commentKey(comment.CommentAuthor, commentContent, createdAt)
 			if commentKeys[key] {
 				result.CommentsSkipped++
-				continue
+				return
 			}
 
 			newComment := Comment{
 				ID:             generateID(),
 				PostID:         targetPost.ID,
-				ParentID:       nil,
+				ParentID:       parentID,
 				AuthorName:     strings.TrimSpace(comment.CommentAuthor),
 				Content:        commentContent,
 				Status:         importCommentStatus(comment.CommentApproved),
 				OwnerTokenHash: hashToken(generateToken()),
 				CreatedAt:      ensureCommentTime(createdAt),
 			}
-
-			if err := s.store.CreateComment(ctx, &newComment); err != nil {
-				return result, fmt.Errorf("create comment: %w", err)
-			}
-			result.CommentsAdded++
+			newComments = append(newComments, newComment)
 			commentKeys[key] = true
 			if comment.CommentID != "" {
 				importedMap[comment.CommentID] = newComment.ID
 			}
 		}
 
-		for _, comment := range sortedComments.replies {
-			parentID := strings.TrimSpace(comment.CommentParent)
-			if parentID == "" || parentID == "0" {
-				continue
-			}
-			mappedParent, ok := importedMap[parentID]
-			if !ok {
-				continue
-			}
-
-			createdAt := parseWXRDate(comment.CommentDateGMT)
-			if createdAt.IsZero() {
-				createdAt = parseWXRDate(comment.CommentDate)
-			}
-			commentContent := strings.TrimSpace(comment.CommentContent)
-			if md, err := htmlToMarkdown(commentContent); err == nil && strings.TrimSpace(md) != "" {
-				commentContent = md
-			}
-			key := commentKey(comment.CommentAuthor, commentContent, createdAt)
-			if commentKeys[key] {
-				result.CommentsSkipped++
-				continue
+		// Top-level comments go first, then replies in rounds so that every
+		// parent is queued before its replies, however deeply they nest.
+		for _, comment := range sortedComments.topLevel {
+			addComment(comment, nil)
+		}
+		replies := sortedComments.replies
+		for len(replies) > 0 {
+			var waiting []wxrImportComment
+			for _, comment := range replies {
+				mappedParent, ok := importedMap[strings.TrimSpace(comment.CommentParent)]
+				if !ok {
+					waiting = append(waiting, comment)
+					continue
+				}
+				addComment(comment, &mappedParent)
 			}
-
-			newComment := Comment{
-				ID:             generateID(),
-				PostID:         targetPost.ID,
-				ParentID:       &mappedParent,
-				AuthorName:     strings.TrimSpace(comment.CommentAuthor),
-				Content:        commentContent,
-				Status:         importCommentStatus(comment.CommentApproved),
-				OwnerTokenHash: hashToken(generateToken()),
-				CreatedAt:      ensureCommentTime(createdAt),
+			if len(waiting) == len(replies) {
+				// The rest reply to comments that were skipped or missing.
+				break
 			}
+			replies = waiting
+		}
 
-			if err := s.store.CreateComment(ctx, &newComment); err != nil {
-				return result, fmt.Errorf("create comment: %w", err)
-			}
-			result.CommentsAdded++
-			commentKeys[key] = true
+		added, err := s.createCommentsInBatches(ctx, newComments)
+		result.CommentsAdded += added
+		if err != nil {
+			return result, err
 		}
 	}
 
+	result.UnmappedAuthors = authors.unmapped()
 	return result, nil
 }
 
@@ -670,21 +779,23 @@ func normalizeWXRPostStatus(value string) string {
 	}
 }
 
-func collectTags(posts []Post) []wxrTag {
-	tags := map[string]Tag{}
-	for _, post := range posts {
-		for _, tag := range post.Tags {
-			slug := strings.TrimSpace(tag.Slug)
-			if slug == "" {
-				slug = tagSlug(tag.Name)
-			}
-			if slug == "" {
-				continue
-			}
-			tags[slug] = tag
+// wxrTagSet collects the tags of exported posts by slug.
+type wxrTagSet map[string]Tag
+
+func (tags wxrTagSet) add(post Post) {
+	for _, tag := range post.Tags {
+		slug := strings.TrimSpace(tag.Slug)
+		if slug == "" {
+			slug = tagSlug(tag.Name)
+		}
+		if slug == "" {
+			continue
 		}
+		tags[slug] = tag
 	}
+}
 
+func (tags wxrTagSet) list() []wxrTag {
 	out := make([]wxrTag, 0, len(tags))
 	idx := 1
 	for slug, tag := range tags {
@@ -773,6 +884,100 @@ func defaultImportAuthorID(value int) int {
 	return value
 }
 
+// wxrAuthorMapper resolves the dc:creator login of imported items to local
+// author IDs through Config.AuthorLoginToID, and tracks the logins it
+// couldn't map. Logins are matched case-insensitively.
+type wxrAuthorMapper struct {
+	ids       map[string]int
+	defaultID int
+	unknown   map[string]*wxrUnmappedAuthor
+	order     []string
+}
+
+func (s *service) newWXRAuthorMapper(authors []wxrImportAuthor) *wxrAuthorMapper {
+	m := &wxrAuthorMapper{
+		ids:       map[string]int{},
+		defaultID: defaultImportAuthorID(s.cfg.ImportAuthorID),
+		unknown:   map[string]*wxrUnmappedAuthor{},
+	}
+	for login, id := range s.cfg.AuthorLoginToID {
+		m.ids[strings.ToLower(strings.TrimSpace(login))] = id
+	}
+	for _, author := range authors {
+		m.track(author.Login, strings.TrimSpace(author.DisplayName))
+	}
+	return m
+}
+
+// track records login as unmapped unless it has an ID, and returns its
+// entry.
+func (m *wxrAuthorMapper) track(login, displayName string) *wxrUnmappedAuthor {
+	login = strings.TrimSpace(login)
+	key := strings.ToLower(login)
+	if key == "" {
+		return nil
+	}
+	if _, ok := m.ids[key]; ok {
+		return nil
+	}
+	entry := m.unknown[key]
+	if entry == nil {
+		entry = &wxrUnmappedAuthor{Login: login, DisplayName: displayName}
+		m.unknown[key] = entry
+		m.order = append(m.order, key)
+	}
+	return entry
+}
+
+// resolve returns the author ID for a post created by login, falling back
+// to the default import author.
+func (m *wxrAuthorMapper) resolve(login string) int {
+	if id, ok := m.ids[strings.ToLower(strings.TrimSpace(login))]; ok {
+		return id
+	}
+	if entry := m.track(login, ""); entry != nil {
+		entry.Posts++
+	}
+	return m.defaultID
+}
+
+// unmapped returns the unmapped authors in the order they were first
+// seen.
+func (m *wxrAuthorMapper) unmapped() []wxrUnmappedAuthor {
+	var out []wxrUnmappedAuthor
+	for _, key := range m.order {
+		out = append(out, *m.unknown[key])
+	}
+	return out
+}
+
+// defaultCommentImportBatchSize is used when Config.CommentImportBatchSize
+// is not set.
+const defaultCommentImportBatchSize = 500
+
+// createCommentsInBatches saves imported comments commentImportBatchSize at
+// a time and returns how many were saved. Parents must come before their
+// replies.
+func (s *service) createCommentsInBatches(ctx context.Context, comments []Comment) (int, error) {
+	added := 0
+	batchSize := s.commentImportBatchSize()
+	for start := 0; start < len(comments); start += batchSize {
+		batch := comments[start:min(start+batchSize, len(comments))]
+		if err := s.store.CreateComments(ctx, batch); err != nil {
+			return added, fmt.Errorf("create comments: %w", err)
+		}
+		added += len(batch)
+	}
+	return added, nil
+}
+
+func (s *service) commentImportBatchSize() int {
+	if s.cfg.CommentImportBatchSize > 0 {
+		return s.cfg.CommentImportBatchSize
+	}
+	return defaultCommentImportBatchSize
+}
+
 func defaultExportAuthorLogin(value string) string {
 	value = strings.TrimSpace(value)
 	if value == "" {
@@ -790,30 +995,8 @@ func defaultExportAuthorDisplay(value string) string {
 }
 
 func (s *service) resolveBaseURLs(r *http.Request) (string, string) {
-	baseSiteURL := strings.TrimSpace(s.cfg.SiteURL)
-	if baseSiteURL == "" {
-		baseSiteURL = siteURLFromRequest(r)
-	}
-	baseSiteURL = strings.TrimSuffix(baseSiteURL, "/")
-
-	baseBlogURL := strings.TrimSuffix(baseSiteURL, "/") + s.routePrefix
-	return baseSiteURL, baseBlogURL
-}
-
-func siteURLFromRequest(r *http.Request) string {
-	scheme := "http"
-	if r.TLS != nil {
-		scheme = "https"
-	}
-	if xf := r.Header.Get("X-Forwarded-Proto"); xf != "" {
-		parts := strings.Split(xf, ",")
-		scheme = strings.TrimSpace(parts[0])
-	}
-	host := r.Host
-	if host == "" {
-		host = "localhost"
-	}
-	return fmt.Sprintf("%s://%s", scheme, host)
+	baseSiteURL := s.baseURL(r)
+	return baseSiteURL, baseSiteURL + s.routePrefix
 }
 
 func readWXRPayload(r *http.Request) (io.Reader, error) {
@@ -839,24 +1022,41 @@ func ImportWXRData(ctx context.Context, store BlogStore, payload []byte) error {
 		cfg:   Config{Store: store},
 		store: newStoreAdapter(store),
 	}
-	_, err := s.importWXR(ctx, payload)
+	_, err := s.importWXR(ctx, bytes.NewReader(payload))
 	return err
 }
 
 func (s *service) listAllPosts(ctx context.Context) ([]Post, error) {
+	var out []Post
+	err := s.eachPost(ctx, func(post Post) error {
+		out = append(out, post)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// eachPost calls fn with every post, reading them from the store a page at
+// a time so they are never all in memory. It stops at the first error fn
+// returns.
+func (s *service) eachPost(ctx context.Context, fn func(Post) error) error {
 	limit := 200
 	offset := 0
-	var out []Post
 	for {
 		posts, err := s.store.ListAllPosts(ctx, limit, offset)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		if len(posts) == 0 {
-			break
+			return nil
+		}
+		for _, post := range posts {
+			if err := fn(post); err != nil {
+				return err
+			}
 		}
-		out = append(out, posts...)
 		offset += len(posts)
 	}
-	return out, nil
 }
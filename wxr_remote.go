@@ -0,0 +1,184 @@
+package blog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// defaultRemoteImportMaxBytes caps remote WXR downloads when
+// Config.RemoteImportMaxBytes is unset.
+const defaultRemoteImportMaxBytes = 512 << 20
+
+type importURLPayload struct {
+	URL string `json:"url"`
+}
+
+// handleAdminImportWXRURL queues a background import of a WXR file hosted at
+// a URL, such as a WordPress export or another Spore instance's export.
+func (s *service) handleAdminImportWXRURL(w http.ResponseWriter, r *http.Request) {
+	var payload importURLPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+	payload.URL = strings.TrimSpace(payload.URL)
+	parsed, err := url.Parse(payload.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		http.Error(w, "url must be an absolute http or https url", http.StatusBadRequest)
+		return
+	}
+
+	task, err := s.queueURLImport(payload.URL)
+	if err != nil {
+		http.Error(w, "failed to queue import", http.StatusInternalServerError)
+		return
+	}
+	writeJSONStatus(w, http.StatusAccepted, task)
+}
+
+// processImportURL downloads a WXR document and imports it as it streams in.
+func (s *service) processImportURL(ctx context.Context, task *Task) error {
+	var payload importURLPayload
+	if err := json.Unmarshal([]byte(task.Payload), &payload); err != nil {
+		return failTask(fmt.Errorf("invalid payload: %w", err))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, payload.URL, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/xml, text/xml")
+
+	resp, err := s.remoteImportClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("download: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("download: http status %d", resp.StatusCode)
+	}
+	if contentType := strings.ToLower(resp.Header.Get("Content-Type")); !strings.Contains(contentType, "xml") {
+		return fmt.Errorf("download: unexpected content type %q", contentType)
+	}
+
+	maxBytes := s.cfg.RemoteImportMaxBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultRemoteImportMaxBytes
+	}
+	if resp.ContentLength > maxBytes {
+		return fmt.Errorf("download: %d bytes exceeds the %d byte limit", resp.ContentLength, maxBytes)
+	}
+
+	body := &progressReader{
+		r:     resp.Body,
+		limit: maxBytes,
+		report: func(n int64) {
+			s.saveTaskResult(ctx, task, map[string]any{"bytes_downloaded": n})
+		},
+	}
+	result, err := s.importWXR(ctx, body)
+	if errors.Is(body.err, errImportTooLarge) {
+		return fmt.Errorf("download: exceeds the %d byte limit", maxBytes)
+	}
+	if err != nil {
+		return err
+	}
+
+	s.saveTaskResult(ctx, task, result)
+	s.queueWXRFollowUps(result)
+	return nil
+}
+
+var errImportTooLarge = errors.New("remote import too large")
+
+// progressReader enforces a size cap and reports progress every few MB.
+type progressReader struct {
+	r        io.Reader
+	limit    int64
+	read     int64
+	reported int64
+	report   func(n int64)
+	err      error
+}
+
+const progressReportEvery = 4 << 20
+
+func (p *progressReader) Read(buf []byte) (int, error) {
+	n, err := p.r.Read(buf)
+	p.read += int64(n)
+	if p.read > p.limit {
+		p.err = errImportTooLarge
+		return n, p.err
+	}
+	if p.report != nil && p.read-p.reported >= progressReportEvery {
+		p.reported = p.read
+		p.report(p.read)
+	}
+	return n, err
+}
+
+// remoteImportClient returns an HTTP client that refuses to connect to
+// loopback, private and link-local addresses, so an admin-supplied URL, a
+// webmention source or an image in a post can't be used to probe internal
+// services. Hosts listed in Config.TrustedImportHosts bypass the check.
+func (s *service) remoteImportClient() *http.Client {
+	trusted := map[string]bool{}
+	for _, host := range s.cfg.TrustedImportHosts {
+		trusted[strings.ToLower(strings.TrimSpace(host))] = true
+	}
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+	transport := &http.Transport{
+		Proxy: nil,
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			host, port, err := net.SplitHostPort(addr)
+			if err != nil {
+				return nil, err
+			}
+			if trusted[strings.ToLower(host)] {
+				return dialer.DialContext(ctx, network, addr)
+			}
+			ips, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+			if err != nil {
+				return nil, err
+			}
+			for _, ip := range ips {
+				if !isPublicIP(ip.IP) {
+					return nil, fmt.Errorf("refusing to connect to non-public address %s", ip.IP)
+				}
+			}
+			if len(ips) == 0 {
+				return nil, fmt.Errorf("no addresses for %s", host)
+			}
+			// Dial the address we validated rather than resolving again.
+			return dialer.DialContext(ctx, network, net.JoinHostPort(ips[0].IP.String(), port))
+		},
+		TLSHandshakeTimeout:   30 * time.Second,
+		ResponseHeaderTimeout: 60 * time.Second,
+	}
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 5 {
+				return errors.New("too many redirects")
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return fmt.Errorf("unsupported redirect scheme %q", req.URL.Scheme)
+			}
+			return nil
+		},
+	}
+}
+
+func isPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsUnspecified() || ip.IsMulticast())
+}
@@ -0,0 +1,75 @@
+package blog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"time"
+)
+
+// writeXML renders v as an indented XML document and writes it with the
+// given media type. The output starts with exactly one
+// `<?xml version="1.0" encoding="UTF-8"?>` declaration, never a byte order
+// mark, and the Content-Type always declares charset=utf-8 to match. The
+// document is encoded before anything is written, so an encoding error can
+// still be reported as a 500.
+func writeXML(w http.ResponseWriter, mediaType string, v any) error {
+	body, err := encodeXML(v)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", mediaType+"; charset=utf-8")
+	_, _ = w.Write(body)
+	return nil
+}
+
+// writeCachedXML is writeXML for feeds, sent through writeCached.
+func writeCachedXML(w http.ResponseWriter, r *http.Request, mediaType string, v any, modified time.Time) error {
+	body, err := encodeXML(v)
+	if err != nil {
+		return err
+	}
+	writeCached(w, r, mediaType+"; charset=utf-8", modified, body)
+	return nil
+}
+
+// encodeXML renders v as an indented XML document with its declaration.
+func encodeXML(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// streamXML is writeXML for large documents: it writes v as it is encoded
+// instead of buffering the whole document. It reports whether any of the
+// response was sent: an error before that can still be reported as a 500,
+// while a later one can only cut the response short.
+func streamXML(w http.ResponseWriter, mediaType string, v any) (started bool, err error) {
+	w.Header().Set("Content-Type", mediaType+"; charset=utf-8")
+	sw := &startedWriter{w: w}
+	bw := bufio.NewWriter(sw)
+	bw.WriteString(xml.Header)
+	enc := xml.NewEncoder(bw)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return sw.started, err
+	}
+	return true, bw.Flush()
+}
+
+// startedWriter records whether anything has been written to w.
+type startedWriter struct {
+	w       http.ResponseWriter
+	started bool
+}
+
+func (sw *startedWriter) Write(p []byte) (int, error) {
+	sw.started = true
+	return sw.w.Write(p)
+}